@@ -0,0 +1,65 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rh-mobb/ocm-operator/pkg/alerts"
+)
+
+const (
+	alertsCommand = "alerts"
+)
+
+// runAlerts runs the alerts subcommand, which writes a PrometheusRule object containing the alerts
+// of the operator to standard output.  It returns the exit code of the subcommand.
+func runAlerts(args []string) int {
+	flags := flag.NewFlagSet(alertsCommand, flag.ExitOnError)
+
+	name := flags.String("name", "controller-manager-alerts", "The name of the PrometheusRule object.")
+	namespace := flags.String("namespace", "system", "The namespace of the PrometheusRule object.")
+
+	//nolint:errcheck
+	flags.Parse(args)
+
+	out, err := alerts.NewPrometheusRule(*name, *namespace, map[string]string{
+		"app.kubernetes.io/name":       "prometheusrule",
+		"app.kubernetes.io/instance":   *name,
+		"app.kubernetes.io/component":  "metrics",
+		"app.kubernetes.io/created-by": "ocm-machine-pool-operator",
+		"app.kubernetes.io/part-of":    "ocm-machine-pool-operator",
+		"app.kubernetes.io/managed-by": "kustomize",
+	}, alerts.Alerts()...).YAML()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	fmt.Fprint(os.Stdout, "# Prometheus Alerting Rules (generated by 'manager alerts', do not edit)\n---\n")
+
+	if _, err := os.Stdout.Write(out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	return 0
+}
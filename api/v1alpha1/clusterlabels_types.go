@@ -0,0 +1,218 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:message="exactly one of clusterName or clusterReference must be set",rule=(has(self.clusterName) != has(self.clusterReference))
+// ClusterLabelsSpec defines the desired state of ClusterLabels
+type ClusterLabelsSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:message="clusterName is immutable",rule=(self == oldSelf)
+	// Cluster name in OpenShift Cluster Manager by which this should be managed for.  The cluster name
+	// can be obtained on the Clusters page for the individual cluster.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	LocalClusterReference `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	// Labels which should exist on the subscription of the cluster in OpenShift Cluster Manager.
+	// Only the labels which are managed by this resource are reconciled; labels which were added
+	// to the subscription by other means are left untouched.  Labels which are removed from this
+	// list are removed from the subscription.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ClusterLabelsStatus defines the observed state of ClusterLabels
+type ClusterLabelsStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Represents a bounded history of the conditions which have been replaced
+	// on this resource, ordered from oldest to newest.
+	ConditionHistory []metav1.Condition `json:"conditionHistory,omitempty"`
+
+	// Represents a bounded history of the operations which have been sent to OpenShift
+	// Cluster Manager for this resource, ordered from oldest to newest.
+	OperationHistory []OCMOperation `json:"operationHistory,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// Represents the number of reconciliations of this resource which failed within each of the most
+	// recent hours, ordered from oldest to newest.  Failures are counted independently of the condition
+	// history, which only keeps a limited number of conditions.
+	ReconcileFailures []ReconcileFailureCount `json:"reconcileFailures,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic cluster ID of the cluster, as
+	// determined during reconciliation.  This is used to reduce
+	// the number of API calls to look up a cluster ID based on
+	// the cluster name.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.subscriptionID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic subscription ID of the cluster, as
+	// determined during reconciliation.  Labels are attached to the
+	// subscription of the cluster.
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// Represents the keys of the labels which have been created, or
+	// taken over by updating their value, on the subscription of the
+	// cluster by this resource.  Only these labels are removed from the
+	// subscription when they are no longer desired, or when this
+	// resource is deleted.
+	ManagedLabels []string `json:"managedLabels,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+//+kubebuilder:printcolumn:name="Cluster ID",type=string,JSONPath=`.status.clusterID`
+
+// ClusterLabels is the Schema for the clusterlabels API.  It manages the labels of the
+// subscription of a cluster in OpenShift Cluster Manager.
+type ClusterLabels struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterLabelsSpec   `json:"spec,omitempty"`
+	Status ClusterLabelsStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterLabelsList contains a list of ClusterLabels
+type ClusterLabelsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterLabels `json:"items"`
+}
+
+// GetConditions returns the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (labels *ClusterLabels) GetConditions() []metav1.Condition {
+	return labels.Status.Conditions
+}
+
+// SetConditions sets the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (labels *ClusterLabels) SetConditions(conditions []metav1.Condition) {
+	labels.Status.Conditions = conditions
+}
+
+// GetClusterName returns the spec.clusterName field from the object.  It is used to satisfy the
+// ClusterWorkload interface.
+func (labels *ClusterLabels) GetClusterName() string {
+	return labels.Spec.ClusterName
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (labels *ClusterLabels) GetOCMEnvironment() string {
+	return labels.Spec.OCMEnvironment
+}
+
+// GetClusterReferenceBinding returns the reference of the object to a cluster reference, bound to the
+// fields of the object which are resolved from the cluster reference.  It is used to satisfy the
+// ClusterReferencer interface.
+func (labels *ClusterLabels) GetClusterReferenceBinding() ClusterReferenceBinding {
+	return ClusterReferenceBinding{
+		LocalClusterReference: &labels.Spec.LocalClusterReference,
+		ClusterName:           &labels.Spec.ClusterName,
+		OCMEnvironment:        &labels.Spec.OCMEnvironment,
+		ClusterID:             &labels.Status.ClusterID,
+	}
+}
+
+// GetConditionHistory returns the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (labels *ClusterLabels) GetConditionHistory() []metav1.Condition {
+	return labels.Status.ConditionHistory
+}
+
+// SetConditionHistory sets the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (labels *ClusterLabels) SetConditionHistory(history []metav1.Condition) {
+	labels.Status.ConditionHistory = history
+}
+
+// GetOperationHistory returns the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (labels *ClusterLabels) GetOperationHistory() []OCMOperation {
+	return labels.Status.OperationHistory
+}
+
+// SetOperationHistory sets the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (labels *ClusterLabels) SetOperationHistory(history []OCMOperation) {
+	labels.Status.OperationHistory = history
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (labels *ClusterLabels) GetLastReconcile() *ReconcileTiming {
+	return labels.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (labels *ClusterLabels) SetLastReconcile(timing *ReconcileTiming) {
+	labels.Status.LastReconcile = timing
+}
+
+// GetReconcileFailures returns the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (labels *ClusterLabels) GetReconcileFailures() []ReconcileFailureCount {
+	return labels.Status.ReconcileFailures
+}
+
+// SetReconcileFailures sets the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (labels *ClusterLabels) SetReconcileFailures(failures []ReconcileFailureCount) {
+	labels.Status.ReconcileFailures = failures
+}
+
+// DesiredKeys returns the keys of the desired labels, sorted so that labels are applied in a
+// consistent order.
+func (labels *ClusterLabels) DesiredKeys() []string {
+	keys := make([]string, 0, len(labels.Spec.Labels))
+	for key := range labels.Spec.Labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterLabels{}, &ClusterLabelsList{})
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager sets up the validating webhook for the ClusterLabels with the Manager.
+func (labels *ClusterLabels) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(labels).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-ocm-mobb-redhat-com-v1alpha1-clusterlabels,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=clusterlabels;clusterlabels/status,verbs=update,versions=v1alpha1,name=vclusterlabels.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ClusterLabels{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.  Creation
+// is not validated.
+func (labels *ClusterLabels) ValidateCreate() error {
+	return nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (labels *ClusterLabels) ValidateUpdate(old runtime.Object) error {
+	previous, err := convertOld[*ClusterLabels](old)
+	if err != nil {
+		return err
+	}
+
+	return invalid("ClusterLabels", labels.Name, labels.validateImmutable(previous))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.  Deletion
+// is not validated.
+func (labels *ClusterLabels) ValidateDelete() error {
+	return nil
+}
+
+// validateImmutable returns the field errors of the fields of the ClusterLabels which may not
+// be changed once they have been set.
+func (labels *ClusterLabels) validateImmutable(old *ClusterLabels) field.ErrorList {
+	spec, status := field.NewPath("spec"), field.NewPath("status")
+
+	return validateImmutable(
+		immutableField{path: spec.Child("clusterName"), oldValue: old.Spec.ClusterName, newValue: labels.Spec.ClusterName},
+		immutableField{path: spec.Child("clusterReference"), oldValue: old.Spec.ClusterReference, newValue: labels.Spec.ClusterReference},
+		immutableField{path: spec.Child("ocmEnvironment"), oldValue: old.Spec.OCMEnvironment, newValue: labels.Spec.OCMEnvironment},
+		immutableField{path: status.Child("clusterID"), oldValue: old.Status.ClusterID, newValue: labels.Status.ClusterID, onceSet: true},
+		immutableField{
+			path:     status.Child("subscriptionID"),
+			oldValue: old.Status.SubscriptionID,
+			newValue: labels.Status.SubscriptionID,
+			onceSet:  true,
+		},
+	)
+}
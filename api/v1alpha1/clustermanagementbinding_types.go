@@ -0,0 +1,92 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/pkg/utils"
+)
+
+// ClusterManagementBindingSpec defines the desired state of ClusterManagementBinding
+type ClusterManagementBindingSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// Namespaces which are granted management of the clusters.
+	Namespaces []string `json:"namespaces"`
+
+	// +kubebuilder:validation:Optional
+	// Names of the clusters in OpenShift Cluster Manager which the namespaces may manage.
+	ClusterNames []string `json:"clusterNames,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IDs of the clusters in OpenShift Cluster Manager which the namespaces may manage.
+	ClusterIDs []string `json:"clusterIDs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// ClusterManagementBinding is the Schema for the clustermanagementbindings API.  It grants the
+// custom resources within a set of namespaces the ability to manage a set of clusters in
+// OpenShift Cluster Manager.  Once any ClusterManagementBinding exists, the controllers refuse
+// to manage a cluster which has not been granted to the namespace of a custom resource.
+type ClusterManagementBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterManagementBindingSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterManagementBindingList contains a list of ClusterManagementBinding
+type ClusterManagementBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterManagementBinding `json:"items"`
+}
+
+// Allows determines if the binding grants a namespace the ability to manage a cluster, either by
+// its name or by its id.  An empty cluster id is never matched.
+func (binding *ClusterManagementBinding) Allows(namespace, clusterName, clusterID string) bool {
+	if !utils.ContainsString(binding.Spec.Namespaces, namespace) {
+		return false
+	}
+
+	if utils.ContainsString(binding.Spec.ClusterNames, clusterName) {
+		return true
+	}
+
+	return clusterID != "" && utils.ContainsString(binding.Spec.ClusterIDs, clusterID)
+}
+
+// Allows determines if any of the bindings in the list grants a namespace the ability to manage
+// a cluster.
+func (bindings *ClusterManagementBindingList) Allows(namespace, clusterName, clusterID string) bool {
+	for i := range bindings.Items {
+		if bindings.Items[i].Allows(namespace, clusterName, clusterID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterManagementBinding{}, &ClusterManagementBindingList{})
+}
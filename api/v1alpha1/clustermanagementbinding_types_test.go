@@ -0,0 +1,59 @@
+package v1alpha1
+
+import "testing"
+
+func TestClusterManagementBindingList_Allows(t *testing.T) {
+	t.Parallel()
+
+	bindings := &ClusterManagementBindingList{
+		Items: []ClusterManagementBinding{
+			{Spec: ClusterManagementBindingSpec{Namespaces: []string{"team-a"}, ClusterNames: []string{"dev"}}},
+			{Spec: ClusterManagementBindingSpec{Namespaces: []string{"team-b"}, ClusterIDs: []string{"abc123"}}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		namespace   string
+		clusterName string
+		clusterID   string
+		want        bool
+	}{
+		{
+			name:        "ensure a cluster granted by name is allowed",
+			namespace:   "team-a",
+			clusterName: "dev",
+			want:        true,
+		},
+		{
+			name:        "ensure a cluster granted by id is allowed",
+			namespace:   "team-b",
+			clusterName: "prod",
+			clusterID:   "abc123",
+			want:        true,
+		},
+		{
+			name:        "ensure a cluster granted to another namespace is forbidden",
+			namespace:   "team-b",
+			clusterName: "dev",
+			want:        false,
+		},
+		{
+			name:        "ensure a cluster without a known id is forbidden when only granted by id",
+			namespace:   "team-b",
+			clusterName: "prod",
+			clusterID:   "",
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := bindings.Allows(tt.namespace, tt.clusterName, tt.clusterID); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultSupportCaseSeverity = "Normal"
+)
+
+// +kubebuilder:validation:XValidation:message="exactly one of clusterName or clusterReference must be set",rule=(has(self.clusterName) != has(self.clusterReference))
+// ClusterNotificationSpec defines the desired state of ClusterNotification
+type ClusterNotificationSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:message="clusterName is immutable",rule=(self == oldSelf)
+	// Cluster name in OpenShift Cluster Manager by which this should be managed for.  The cluster name
+	// can be obtained on the Clusters page for the individual cluster.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	LocalClusterReference `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// Red Hat account usernames or email addresses which should receive notifications
+	// for the cluster.  The accounts must belong to the same organization as the cluster.
+	// This list is authoritative; notification contacts which exist in OpenShift Cluster
+	// Manager but are not listed here are removed.
+	Contacts []string `json:"contacts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Support case configuration.  When set, a support case is opened in OpenShift Cluster
+	// Manager when the cluster enters an error state.  Only one support case is opened per
+	// failure; a new support case may only be opened once the cluster has left the error state.
+	SupportCase *ClusterNotificationSupportCase `json:"supportCase,omitempty"`
+}
+
+// ClusterNotificationSupportCase defines the support case which is opened when a cluster
+// enters a terminal failure state.
+type ClusterNotificationSupportCase struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Normal
+	// +kubebuilder:validation:Enum=Urgent;High;Normal;Low
+	// Severity of the support case which is opened.  Must be one of Urgent, High, Normal (default)
+	// or Low.
+	Severity string `json:"severity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	// Summary of the support case which is opened.  If this is empty, a summary is generated
+	// from the cluster name.
+	Summary string `json:"summary,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Description of the support case which is opened.  If this is empty, a description is
+	// generated from the cluster name and state.
+	Description string `json:"description,omitempty"`
+}
+
+// ClusterNotificationStatus defines the observed state of ClusterNotification
+type ClusterNotificationStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Represents a bounded history of the conditions which have been replaced
+	// on this resource, ordered from oldest to newest.
+	ConditionHistory []metav1.Condition `json:"conditionHistory,omitempty"`
+
+	// Represents a bounded history of the operations which have been sent to OpenShift
+	// Cluster Manager for this resource, ordered from oldest to newest.
+	OperationHistory []OCMOperation `json:"operationHistory,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// Represents the number of reconciliations of this resource which failed within each of the most
+	// recent hours, ordered from oldest to newest.  Failures are counted independently of the condition
+	// history, which only keeps a limited number of conditions.
+	ReconcileFailures []ReconcileFailureCount `json:"reconcileFailures,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic cluster ID of the cluster, as
+	// determined during reconciliation.  This is used to reduce
+	// the number of API calls to look up a cluster ID based on
+	// the cluster name.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.subscriptionID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic subscription ID of the cluster, as
+	// determined during reconciliation.  Notification contacts are
+	// attached to the subscription of the cluster.
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// Represents the case number of the support case which was opened for the
+	// current cluster failure.  It is cleared once the cluster leaves the error state.
+	SupportCaseNumber string `json:"supportCaseNumber,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ClusterNotification is the Schema for the clusternotifications API
+type ClusterNotification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterNotificationSpec   `json:"spec,omitempty"`
+	Status ClusterNotificationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterNotificationList contains a list of ClusterNotification
+type ClusterNotificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterNotification `json:"items"`
+}
+
+// GetConditions returns the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (notification *ClusterNotification) GetConditions() []metav1.Condition {
+	return notification.Status.Conditions
+}
+
+// SetConditions sets the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (notification *ClusterNotification) SetConditions(conditions []metav1.Condition) {
+	notification.Status.Conditions = conditions
+}
+
+// GetClusterName returns the spec.clusterName field from the object.  It is used to satisfy the
+// ClusterWorkload interface.
+func (notification *ClusterNotification) GetClusterName() string {
+	return notification.Spec.ClusterName
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (notification *ClusterNotification) GetOCMEnvironment() string {
+	return notification.Spec.OCMEnvironment
+}
+
+// GetClusterReferenceBinding returns the reference of the object to a cluster reference, bound to the
+// fields of the object which are resolved from the cluster reference.  It is used to satisfy the
+// ClusterReferencer interface.
+func (notification *ClusterNotification) GetClusterReferenceBinding() ClusterReferenceBinding {
+	return ClusterReferenceBinding{
+		LocalClusterReference: &notification.Spec.LocalClusterReference,
+		ClusterName:           &notification.Spec.ClusterName,
+		OCMEnvironment:        &notification.Spec.OCMEnvironment,
+		ClusterID:             &notification.Status.ClusterID,
+	}
+}
+
+// GetConditionHistory returns the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (notification *ClusterNotification) GetConditionHistory() []metav1.Condition {
+	return notification.Status.ConditionHistory
+}
+
+// SetConditionHistory sets the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (notification *ClusterNotification) SetConditionHistory(history []metav1.Condition) {
+	notification.Status.ConditionHistory = history
+}
+
+// GetOperationHistory returns the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (notification *ClusterNotification) GetOperationHistory() []OCMOperation {
+	return notification.Status.OperationHistory
+}
+
+// SetOperationHistory sets the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (notification *ClusterNotification) SetOperationHistory(history []OCMOperation) {
+	notification.Status.OperationHistory = history
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (notification *ClusterNotification) GetLastReconcile() *ReconcileTiming {
+	return notification.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (notification *ClusterNotification) SetLastReconcile(timing *ReconcileTiming) {
+	notification.Status.LastReconcile = timing
+}
+
+// GetReconcileFailures returns the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (notification *ClusterNotification) GetReconcileFailures() []ReconcileFailureCount {
+	return notification.Status.ReconcileFailures
+}
+
+// SetReconcileFailures sets the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (notification *ClusterNotification) SetReconcileFailures(failures []ReconcileFailureCount) {
+	notification.Status.ReconcileFailures = failures
+}
+
+// SupportCaseBuilder returns the builder object used to open a support case in OCM
+// for a failed cluster.
+func (notification *ClusterNotification) SupportCaseBuilder(clusterUUID string) *accountsmgmtv1.SupportCaseRequestBuilder {
+	severity, summary, description := defaultSupportCaseSeverity, notification.Spec.SupportCase.Summary, notification.Spec.SupportCase.Description
+
+	if notification.Spec.SupportCase.Severity != "" {
+		severity = notification.Spec.SupportCase.Severity
+	}
+
+	if summary == "" {
+		summary = fmt.Sprintf("cluster [%s] has entered an error state", notification.Spec.ClusterName)
+	}
+
+	if description == "" {
+		description = fmt.Sprintf(
+			"cluster [%s] has entered an error state in openshift cluster manager.  this case was opened "+
+				"automatically from [%s/%s].", notification.Spec.ClusterName, notification.Namespace, notification.Name,
+		)
+	}
+
+	return accountsmgmtv1.NewSupportCaseRequest().
+		ClusterId(notification.Status.ClusterID).
+		ClusterUuid(clusterUUID).
+		SubscriptionId(notification.Status.SubscriptionID).
+		Severity(severity).
+		Summary(summary).
+		Description(description)
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterNotification{}, &ClusterNotificationList{})
+}
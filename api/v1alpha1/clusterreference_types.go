@@ -0,0 +1,265 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// keys of the config map in which the details of the cluster of a ClusterReference are published.
+const (
+	ClusterInfoKeyClusterName = "clusterName"
+	ClusterInfoKeyClusterID   = "clusterID"
+	ClusterInfoKeyExternalID  = "externalID"
+	ClusterInfoKeyRegion      = "region"
+	ClusterInfoKeyVersion     = "version"
+	ClusterInfoKeyHosted      = "hosted"
+	ClusterInfoKeyConsoleURL  = "consoleURL"
+	ClusterInfoKeyAPIURL      = "apiURL"
+	ClusterInfoKeyOIDCIssuer  = "oidcIssuer"
+)
+
+// ClusterReferenceSpec defines the desired state of ClusterReference
+type ClusterReferenceSpec struct {
+	// +kubebuilder:validation:Required
+	// Cluster name in OpenShift Cluster Manager which this reference resolves.  Unlike the
+	// clusterName of the resources which reference it, the cluster name may be changed, for
+	// example after the cluster has been renamed in OpenShift Cluster Manager, so that each
+	// referencing resource follows the cluster without being recreated.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.  Resources which reference this cluster reference, and which do not set
+	// their own ocmEnvironment, are managed in this environment.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Name of a config map, in the same namespace, in which the resolved details of the cluster are
+	// published, so that pipelines may consume them without credentials for OpenShift Cluster Manager.
+	// The config map is owned by this cluster reference.  If this is empty, the details of the cluster
+	// are not published.
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// ClusterReferenceStatus defines the observed state of ClusterReference
+type ClusterReferenceStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic cluster ID of the cluster, as determined when the
+	// cluster was first resolved.  A cluster reference always resolves the same cluster
+	// once this has been set.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// Represents the external ID of the cluster, which is the ID of the cluster within
+	// the cluster itself.
+	ExternalID string `json:"externalID,omitempty"`
+
+	// Represents the subscription ID of the cluster in OpenShift Cluster Manager.
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// Represents the region in which the cluster is provisioned.
+	Region string `json:"region,omitempty"`
+
+	// Represents the version of the cluster as last reported by OpenShift Cluster Manager.
+	Version string `json:"version,omitempty"`
+
+	// Represents the state of the cluster as last reported by OpenShift Cluster Manager.
+	State string `json:"state,omitempty"`
+
+	// Represents whether or not the cluster is using hosted control plane.
+	Hosted bool `json:"hosted,omitempty"`
+
+	// Represents the url of the web console of the cluster.
+	ConsoleURL string `json:"consoleURL,omitempty"`
+
+	// Represents the url of the api server of the cluster.
+	APIURL string `json:"apiURL,omitempty"`
+
+	// Represents the issuer url of the oidc provider of the cluster, if the cluster uses AWS STS.
+	OIDCIssuer string `json:"oidcIssuer,omitempty"`
+
+	// Represents the name of the config map in which the details of the cluster were last published.
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Time at which the cluster was last resolved from OpenShift Cluster Manager.
+	LastResolvedTime *metav1.Time `json:"lastResolvedTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.clusterID`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Resolved",type=date,JSONPath=`.status.lastResolvedTime`
+
+// ClusterReference is the Schema for the clusterreferences API.  It resolves a cluster in
+// OpenShift Cluster Manager once and caches its ID and metadata in its status, so that other
+// resources in the same namespace may reference the cluster reference rather than repeating
+// the name of the cluster.
+type ClusterReference struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterReferenceSpec   `json:"spec,omitempty"`
+	Status ClusterReferenceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterReferenceList contains a list of ClusterReference
+type ClusterReferenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterReference `json:"items"`
+}
+
+// GetConditions returns the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (reference *ClusterReference) GetConditions() []metav1.Condition {
+	return reference.Status.Conditions
+}
+
+// SetConditions sets the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (reference *ClusterReference) SetConditions(conditions []metav1.Condition) {
+	reference.Status.Conditions = conditions
+}
+
+// GetClusterName returns the spec.clusterName field from the object.  It is used to satisfy the
+// ClusterWorkload interface.
+func (reference *ClusterReference) GetClusterName() string {
+	return reference.Spec.ClusterName
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (reference *ClusterReference) GetOCMEnvironment() string {
+	return reference.Spec.OCMEnvironment
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (reference *ClusterReference) GetLastReconcile() *ReconcileTiming {
+	return reference.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (reference *ClusterReference) SetLastReconcile(timing *ReconcileTiming) {
+	reference.Status.LastReconcile = timing
+}
+
+// Resolved determines if the cluster of the cluster reference has been resolved from OpenShift
+// Cluster Manager.
+func (reference *ClusterReference) Resolved() bool {
+	return reference.Status.ClusterID != ""
+}
+
+// ClusterInfo returns the details of the resolved cluster which are published in the config map of the
+// cluster reference.  Every key is returned, with an empty value for a detail which is not known, so
+// that consumers of the config map may rely upon the presence of each key.
+func (reference *ClusterReference) ClusterInfo() map[string]string {
+	return map[string]string{
+		ClusterInfoKeyClusterName: reference.Spec.ClusterName,
+		ClusterInfoKeyClusterID:   reference.Status.ClusterID,
+		ClusterInfoKeyExternalID:  reference.Status.ExternalID,
+		ClusterInfoKeyRegion:      reference.Status.Region,
+		ClusterInfoKeyVersion:     reference.Status.Version,
+		ClusterInfoKeyHosted:      strconv.FormatBool(reference.Status.Hosted),
+		ClusterInfoKeyConsoleURL:  reference.Status.ConsoleURL,
+		ClusterInfoKeyAPIURL:      reference.Status.APIURL,
+		ClusterInfoKeyOIDCIssuer:  reference.Status.OIDCIssuer,
+	}
+}
+
+// LocalClusterReference references a ClusterReference, in the same namespace, which resolves the cluster
+// of a resource rather than the resource naming its cluster.  It is embedded in the spec of each resource
+// which may reference a ClusterReference.
+type LocalClusterReference struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:message="clusterReference is immutable",rule=(self == oldSelf)
+	// Name of a ClusterReference, in the same namespace, which resolves the cluster in OpenShift Cluster
+	// Manager by which this should be managed for.  It may be set instead of clusterName so that the
+	// cluster is resolved once for every resource which references it, and so that a renamed cluster
+	// is only updated on the ClusterReference.  Exactly one of clusterName or clusterReference must
+	// be set.
+	ClusterReference string `json:"clusterReference,omitempty"`
+}
+
+// GetClusterReference returns the spec.clusterReference field from the resource.
+func (local *LocalClusterReference) GetClusterReference() string {
+	return local.ClusterReference
+}
+
+// ClusterReferenceBinding binds the reference of a resource to a ClusterReference to the fields of the
+// resource which are resolved from the ClusterReference.  Hosted is nil for a resource which does not
+// record whether its cluster is hosted.
+//
+// +kubebuilder:object:generate=false
+type ClusterReferenceBinding struct {
+	*LocalClusterReference
+
+	ClusterName    *string
+	OCMEnvironment *string
+	ClusterID      *string
+	Hosted         *bool
+}
+
+// UseClusterReference sets the cluster name, and the environment when it is not set, of the resource
+// from the cluster reference which it references.  The environment of the resource takes precedence
+// over the environment of the cluster reference when it is set.
+func (binding ClusterReferenceBinding) UseClusterReference(reference *ClusterReference) {
+	*binding.ClusterName = reference.Spec.ClusterName
+
+	if *binding.OCMEnvironment == "" {
+		*binding.OCMEnvironment = reference.Spec.OCMEnvironment
+	}
+}
+
+// SeedClusterStatus seeds the cluster id, and whether the cluster is hosted, of the resource from the
+// status of the cluster reference, so that the resource targets the cluster which the reference resolves
+// without looking the cluster up again.  Whether the cluster is hosted is only seeded for a resource
+// which has not yet recorded its cluster, as it may not change once recorded.  It returns whether the
+// status of the resource was changed.
+func (binding ClusterReferenceBinding) SeedClusterStatus(reference *ClusterReference) bool {
+	if reference.Status.ClusterID == "" || *binding.ClusterID == reference.Status.ClusterID {
+		return false
+	}
+
+	if binding.Hosted != nil && *binding.ClusterID == "" {
+		*binding.Hosted = reference.Status.Hosted
+	}
+
+	*binding.ClusterID = reference.Status.ClusterID
+
+	return true
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterReference{}, &ClusterReferenceList{})
+}
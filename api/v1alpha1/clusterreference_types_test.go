@@ -0,0 +1,180 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClusterReferenceBinding_UseClusterReference(t *testing.T) {
+	t.Parallel()
+
+	reference := &ClusterReference{
+		Spec: ClusterReferenceSpec{ClusterName: "dev", OCMEnvironment: "stage"},
+	}
+
+	tests := []struct {
+		name            string
+		environment     string
+		wantClusterName string
+		wantEnvironment string
+	}{
+		{
+			name:            "ensure the environment of the reference is used when the environment is not set",
+			environment:     "",
+			wantClusterName: "dev",
+			wantEnvironment: "stage",
+		},
+		{
+			name:            "ensure the environment of the machine pool takes precedence over the reference",
+			environment:     "production",
+			wantClusterName: "dev",
+			wantEnvironment: "production",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pool := &MachinePool{Spec: MachinePoolSpec{
+				LocalClusterReference: LocalClusterReference{ClusterReference: "cluster"},
+				OCMEnvironment:        tt.environment,
+			}}
+			pool.GetClusterReferenceBinding().UseClusterReference(reference)
+
+			if pool.Spec.ClusterName != tt.wantClusterName {
+				t.Errorf("UseClusterReference() clusterName = %v, want %v", pool.Spec.ClusterName, tt.wantClusterName)
+			}
+
+			if pool.Spec.OCMEnvironment != tt.wantEnvironment {
+				t.Errorf("UseClusterReference() ocmEnvironment = %v, want %v", pool.Spec.OCMEnvironment, tt.wantEnvironment)
+			}
+		})
+	}
+}
+
+func TestClusterReferenceBinding_SeedClusterStatus(t *testing.T) {
+	t.Parallel()
+
+	reference := &ClusterReference{
+		Spec:   ClusterReferenceSpec{ClusterName: "dev"},
+		Status: ClusterReferenceStatus{ClusterID: "abc123", Hosted: true},
+	}
+
+	tests := []struct {
+		name       string
+		reference  *ClusterReference
+		status     MachinePoolStatus
+		want       bool
+		wantStatus MachinePoolStatus
+	}{
+		{
+			name:       "ensure the cluster id and hosted state are seeded for a new machine pool",
+			reference:  reference,
+			want:       true,
+			wantStatus: MachinePoolStatus{ClusterID: "abc123", Hosted: true},
+		},
+		{
+			name:       "ensure the cluster id is seeded without changing the hosted state of a recorded cluster",
+			reference:  reference,
+			status:     MachinePoolStatus{ClusterID: "def456"},
+			want:       true,
+			wantStatus: MachinePoolStatus{ClusterID: "abc123"},
+		},
+		{
+			name:       "ensure an unchanged cluster id is not seeded",
+			reference:  reference,
+			status:     MachinePoolStatus{ClusterID: "abc123", Hosted: true},
+			want:       false,
+			wantStatus: MachinePoolStatus{ClusterID: "abc123", Hosted: true},
+		},
+		{
+			name:       "ensure nothing is seeded from an unresolved reference",
+			reference:  &ClusterReference{Spec: ClusterReferenceSpec{ClusterName: "dev"}},
+			want:       false,
+			wantStatus: MachinePoolStatus{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pool := &MachinePool{Status: tt.status}
+
+			if got := pool.GetClusterReferenceBinding().SeedClusterStatus(tt.reference); got != tt.want {
+				t.Errorf("SeedClusterStatus() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(pool.Status, tt.wantStatus) {
+				t.Errorf("SeedClusterStatus() status = %+v, want %+v", pool.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClusterReference_ClusterInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reference *ClusterReference
+		want      map[string]string
+	}{
+		{
+			name: "ensure the resolved details of the cluster are published",
+			reference: &ClusterReference{
+				Spec: ClusterReferenceSpec{ClusterName: "dev"},
+				Status: ClusterReferenceStatus{
+					ClusterID:  "abc123",
+					ExternalID: "def456",
+					Region:     "us-east-1",
+					Version:    "4.12.1",
+					Hosted:     true,
+					ConsoleURL: "https://console.dev.example.com",
+					APIURL:     "https://api.dev.example.com:6443",
+					OIDCIssuer: "https://oidc.example.com/abc123",
+				},
+			},
+			want: map[string]string{
+				ClusterInfoKeyClusterName: "dev",
+				ClusterInfoKeyClusterID:   "abc123",
+				ClusterInfoKeyExternalID:  "def456",
+				ClusterInfoKeyRegion:      "us-east-1",
+				ClusterInfoKeyVersion:     "4.12.1",
+				ClusterInfoKeyHosted:      "true",
+				ClusterInfoKeyConsoleURL:  "https://console.dev.example.com",
+				ClusterInfoKeyAPIURL:      "https://api.dev.example.com:6443",
+				ClusterInfoKeyOIDCIssuer:  "https://oidc.example.com/abc123",
+			},
+		},
+		{
+			name: "ensure unknown details of the cluster are published as empty values",
+			reference: &ClusterReference{
+				Spec:   ClusterReferenceSpec{ClusterName: "dev"},
+				Status: ClusterReferenceStatus{ClusterID: "abc123"},
+			},
+			want: map[string]string{
+				ClusterInfoKeyClusterName: "dev",
+				ClusterInfoKeyClusterID:   "abc123",
+				ClusterInfoKeyExternalID:  "",
+				ClusterInfoKeyRegion:      "",
+				ClusterInfoKeyVersion:     "",
+				ClusterInfoKeyHosted:      "false",
+				ClusterInfoKeyConsoleURL:  "",
+				ClusterInfoKeyAPIURL:      "",
+				ClusterInfoKeyOIDCIssuer:  "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.reference.ClusterInfo(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
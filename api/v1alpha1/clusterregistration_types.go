@@ -0,0 +1,282 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/pkg/diff"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	clusterRegistrationPullSecretSuffix = "pull-secret"
+
+	defaultPullSecretRotationHours = 24
+)
+
+// ClusterRegistrationSpec defines the desired state of ClusterRegistration
+type ClusterRegistrationSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
+	// +kubebuilder:validation:XValidation:message="clusterUUID is immutable",rule=(self == oldSelf)
+	// Unique identifier of the existing cluster which is registered with OpenShift Cluster Manager.
+	// This is the spec.clusterID field of the ClusterVersion object named 'version' in the cluster,
+	// which may be retrieved with 'oc get clusterversion version -o jsonpath={.spec.clusterID}'.
+	ClusterUUID string `json:"clusterUUID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Friendly display name of the cluster as displayed in the OpenShift Cluster Manager
+	// console.  If this is empty, the metadata.name field of the parent resource is used
+	// as the display name.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// URL of the web console of the cluster, which is linked to from the OpenShift Cluster
+	// Manager console.
+	ConsoleURL string `json:"consoleURL,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Name of the secret, in the namespace of this resource, in which the pull secret of the
+	// account which registered the cluster is stored.  If this is empty, the secret is named
+	// after the metadata.name field of the parent resource with a '-pull-secret' suffix.  The
+	// secret is owned by, and deleted along with, this resource.
+	PullSecretName string `json:"pullSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=24
+	// Interval, in hours, at which the pull secret is retrieved from OpenShift Cluster Manager
+	// and rotated in the secret.  The secret is also restored immediately if it is deleted.
+	PullSecretRotationHours int `json:"pullSecretRotationHours,omitempty"`
+}
+
+// ClusterRegistrationStatus defines the observed state of ClusterRegistration
+type ClusterRegistrationStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Represents a bounded history of the conditions which have been replaced
+	// on this resource, ordered from oldest to newest.
+	ConditionHistory []metav1.Condition `json:"conditionHistory,omitempty"`
+
+	// Represents a bounded history of the operations which have been sent to OpenShift
+	// Cluster Manager for this resource, ordered from oldest to newest.
+	OperationHistory []OCMOperation `json:"operationHistory,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// Represents the number of reconciliations of this resource which failed within each of the most
+	// recent hours, ordered from oldest to newest.  Failures are counted independently of the condition
+	// history, which only keeps a limited number of conditions.
+	ReconcileFailures []ReconcileFailureCount `json:"reconcileFailures,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic cluster ID which was assigned to
+	// the cluster by OpenShift Cluster Manager upon registration.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.subscriptionID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic subscription ID which was created
+	// for the cluster by OpenShift Cluster Manager upon registration.
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// Represents the status of the subscription of the cluster, as
+	// last reported by OpenShift Cluster Manager.
+	SubscriptionStatus string `json:"subscriptionStatus,omitempty"`
+
+	// Represents the name of the secret in which the pull secret of
+	// the registered cluster is stored.
+	PullSecretName string `json:"pullSecretName,omitempty"`
+
+	// Time at which the pull secret was last retrieved from OpenShift
+	// Cluster Manager and stored in the secret.
+	PullSecretRotatedTime *metav1.Time `json:"pullSecretRotatedTime,omitempty"`
+
+	// Represents whether the cluster was registered with OpenShift Cluster
+	// Manager by this resource, rather than an existing subscription being
+	// adopted.  Only a subscription which was registered by this resource
+	// is archived when it is deleted.
+	Registered bool `json:"registered,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="UUID",type=string,JSONPath=`.spec.clusterUUID`
+//+kubebuilder:printcolumn:name="Cluster ID",type=string,JSONPath=`.status.clusterID`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.subscriptionStatus`
+
+// ClusterRegistration is the Schema for the clusterregistrations API.  It registers an
+// existing cluster, which was not provisioned by OpenShift Cluster Manager, with
+// OpenShift Cluster Manager so that it may be managed.
+type ClusterRegistration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterRegistrationSpec   `json:"spec,omitempty"`
+	Status ClusterRegistrationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterRegistrationList contains a list of ClusterRegistration
+type ClusterRegistrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRegistration `json:"items"`
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (registration *ClusterRegistration) GetOCMEnvironment() string {
+	return registration.Spec.OCMEnvironment
+}
+
+// GetConditions returns the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (registration *ClusterRegistration) GetConditions() []metav1.Condition {
+	return registration.Status.Conditions
+}
+
+// SetConditions sets the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (registration *ClusterRegistration) SetConditions(conditions []metav1.Condition) {
+	registration.Status.Conditions = conditions
+}
+
+// GetConditionHistory returns the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (registration *ClusterRegistration) GetConditionHistory() []metav1.Condition {
+	return registration.Status.ConditionHistory
+}
+
+// SetConditionHistory sets the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (registration *ClusterRegistration) SetConditionHistory(history []metav1.Condition) {
+	registration.Status.ConditionHistory = history
+}
+
+// GetOperationHistory returns the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (registration *ClusterRegistration) GetOperationHistory() []OCMOperation {
+	return registration.Status.OperationHistory
+}
+
+// SetOperationHistory sets the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (registration *ClusterRegistration) SetOperationHistory(history []OCMOperation) {
+	registration.Status.OperationHistory = history
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (registration *ClusterRegistration) GetLastReconcile() *ReconcileTiming {
+	return registration.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (registration *ClusterRegistration) SetLastReconcile(timing *ReconcileTiming) {
+	registration.Status.LastReconcile = timing
+}
+
+// GetReconcileFailures returns the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (registration *ClusterRegistration) GetReconcileFailures() []ReconcileFailureCount {
+	return registration.Status.ReconcileFailures
+}
+
+// SetReconcileFailures sets the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (registration *ClusterRegistration) SetReconcileFailures(failures []ReconcileFailureCount) {
+	registration.Status.ReconcileFailures = failures
+}
+
+// GetDisplayName returns the display name of the registered cluster.  It defaults to wanting to
+// use the spec.displayName field but returns the metadata.name field if unset.
+func (registration *ClusterRegistration) GetDisplayName() string {
+	return defaultDisplayName(registration.Spec.DisplayName, registration.GetName())
+}
+
+// GetPullSecretName returns the name of the secret in which the pull secret is stored.  It defaults
+// to wanting to use the spec.pullSecretName field but derives the name from the metadata.name field
+// if unset.
+func (registration *ClusterRegistration) GetPullSecretName() string {
+	if registration.Spec.PullSecretName == "" {
+		return fmt.Sprintf("%s-%s", registration.GetName(), clusterRegistrationPullSecretSuffix)
+	}
+
+	return registration.Spec.PullSecretName
+}
+
+// PullSecretRotationInterval returns the interval at which the pull secret is rotated.
+func (registration *ClusterRegistration) PullSecretRotationInterval() time.Duration {
+	if registration.Spec.PullSecretRotationHours < 1 {
+		return defaultPullSecretRotationHours * time.Hour
+	}
+
+	return time.Duration(registration.Spec.PullSecretRotationHours) * time.Hour
+}
+
+// RegistrationBuilder returns the builder object used to register the cluster in OCM.
+func (registration *ClusterRegistration) RegistrationBuilder() *accountsmgmtv1.SubscriptionRegistrationBuilder {
+	builder := accountsmgmtv1.NewSubscriptionRegistration().
+		ClusterUUID(registration.Spec.ClusterUUID).
+		DisplayName(registration.GetDisplayName()).
+		PlanID(accountsmgmtv1.PlanIDOCP).
+		Status(ocm.SubscriptionStatusDisconnected)
+
+	if registration.Spec.ConsoleURL != "" {
+		builder = builder.ConsoleURL(registration.Spec.ConsoleURL)
+	}
+
+	return builder
+}
+
+// SubscriptionBuilder returns the builder object used to update the subscription of the registered
+// cluster in OCM.
+func (registration *ClusterRegistration) SubscriptionBuilder() *accountsmgmtv1.SubscriptionBuilder {
+	return accountsmgmtv1.NewSubscription().
+		DisplayName(registration.GetDisplayName()).
+		ConsoleURL(registration.Spec.ConsoleURL)
+}
+
+// SubscriptionDesired determines if the subscription of the registered cluster in OCM matches the
+// desired state of the registration.
+func (registration *ClusterRegistration) SubscriptionDesired(subscription *accountsmgmtv1.Subscription) bool {
+	return diff.New().
+		Field("displayName", diff.Values(registration.GetDisplayName(), subscription.DisplayName())).
+		Field("consoleURL", diff.URLs(registration.Spec.ConsoleURL, subscription.ConsoleURL())).
+		Equal()
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterRegistration{}, &ClusterRegistrationList{})
+}
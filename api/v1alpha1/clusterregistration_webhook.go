@@ -0,0 +1,76 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager sets up the validating webhook for the ClusterRegistration with the Manager.
+func (registration *ClusterRegistration) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(registration).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-ocm-mobb-redhat-com-v1alpha1-clusterregistration,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=clusterregistrations;clusterregistrations/status,verbs=update,versions=v1alpha1,name=vclusterregistration.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ClusterRegistration{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.  Creation
+// is not validated.
+func (registration *ClusterRegistration) ValidateCreate() error {
+	return nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (registration *ClusterRegistration) ValidateUpdate(old runtime.Object) error {
+	previous, err := convertOld[*ClusterRegistration](old)
+	if err != nil {
+		return err
+	}
+
+	return invalid("ClusterRegistration", registration.Name, registration.validateImmutable(previous))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.  Deletion
+// is not validated.
+func (registration *ClusterRegistration) ValidateDelete() error {
+	return nil
+}
+
+// validateImmutable returns the field errors of the fields of the ClusterRegistration which may not
+// be changed once they have been set.
+func (registration *ClusterRegistration) validateImmutable(old *ClusterRegistration) field.ErrorList {
+	spec, status := field.NewPath("spec"), field.NewPath("status")
+
+	return validateImmutable(
+		immutableField{path: spec.Child("clusterUUID"), oldValue: old.Spec.ClusterUUID, newValue: registration.Spec.ClusterUUID},
+		immutableField{path: spec.Child("ocmEnvironment"), oldValue: old.Spec.OCMEnvironment, newValue: registration.Spec.OCMEnvironment},
+		immutableField{path: status.Child("clusterID"), oldValue: old.Status.ClusterID, newValue: registration.Status.ClusterID, onceSet: true},
+		immutableField{
+			path:     status.Child("subscriptionID"),
+			oldValue: old.Status.SubscriptionID,
+			newValue: registration.Status.SubscriptionID,
+			onceSet:  true,
+		},
+	)
+}
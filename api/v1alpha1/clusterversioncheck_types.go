@@ -0,0 +1,158 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:message="exactly one of clusterName or clusterReference must be set",rule=(has(self.clusterName) != has(self.clusterReference))
+// ClusterVersionCheckSpec defines the desired state of ClusterVersionCheck
+type ClusterVersionCheckSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:message="clusterName is immutable",rule=(self == oldSelf)
+	// Cluster name in OpenShift Cluster Manager for which available upgrades are checked.  The cluster
+	// name can be obtained on the Clusters page for the individual cluster.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	LocalClusterReference `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+}
+
+// ClusterVersionCheckStatus defines the observed state of ClusterVersionCheck
+type ClusterVersionCheckStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
+	// Represents the programmatic cluster ID of the cluster, as
+	// determined during reconciliation.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// Represents the version of the cluster which was last reported by
+	// OpenShift Cluster Manager.
+	Version string `json:"version,omitempty"`
+
+	// Represents the channel group of the version of the cluster, which
+	// determines the upgrades which are available.
+	ChannelGroup string `json:"channelGroup,omitempty"`
+
+	// Represents the versions which the cluster may be upgraded to, as
+	// last reported by OpenShift Cluster Manager.
+	AvailableUpgrades []string `json:"availableUpgrades,omitempty"`
+
+	// Represents the latest available upgrade within the current minor
+	// version of the cluster (e.g. 4.12.z).
+	LatestZStreamUpgrade string `json:"latestZStreamUpgrade,omitempty"`
+
+	// Represents the latest available upgrade to a newer minor version
+	// of the cluster.
+	LatestMinorUpgrade string `json:"latestMinorUpgrade,omitempty"`
+
+	// Time at which the available upgrades were last checked.
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+//+kubebuilder:printcolumn:name="Z-Stream",type=string,JSONPath=`.status.latestZStreamUpgrade`
+//+kubebuilder:printcolumn:name="Minor",type=string,JSONPath=`.status.latestMinorUpgrade`
+//+kubebuilder:printcolumn:name="Checked",type=date,JSONPath=`.status.lastCheckedTime`
+
+// ClusterVersionCheck is the Schema for the clusterversionchecks API.  It periodically checks
+// OpenShift Cluster Manager for the upgrades which are available to a cluster and publishes
+// them in its status.  It does not upgrade the cluster.
+type ClusterVersionCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterVersionCheckSpec   `json:"spec,omitempty"`
+	Status ClusterVersionCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterVersionCheckList contains a list of ClusterVersionCheck
+type ClusterVersionCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterVersionCheck `json:"items"`
+}
+
+// GetConditions returns the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (check *ClusterVersionCheck) GetConditions() []metav1.Condition {
+	return check.Status.Conditions
+}
+
+// SetConditions sets the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (check *ClusterVersionCheck) SetConditions(conditions []metav1.Condition) {
+	check.Status.Conditions = conditions
+}
+
+// GetClusterName returns the spec.clusterName field from the object.  It is used to satisfy the
+// ClusterWorkload interface.
+func (check *ClusterVersionCheck) GetClusterName() string {
+	return check.Spec.ClusterName
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (check *ClusterVersionCheck) GetOCMEnvironment() string {
+	return check.Spec.OCMEnvironment
+}
+
+// GetClusterReferenceBinding returns the reference of the object to a cluster reference, bound to the
+// fields of the object which are resolved from the cluster reference.  It is used to satisfy the
+// ClusterReferencer interface.
+func (check *ClusterVersionCheck) GetClusterReferenceBinding() ClusterReferenceBinding {
+	return ClusterReferenceBinding{
+		LocalClusterReference: &check.Spec.LocalClusterReference,
+		ClusterName:           &check.Spec.ClusterName,
+		OCMEnvironment:        &check.Spec.OCMEnvironment,
+		ClusterID:             &check.Status.ClusterID,
+	}
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (check *ClusterVersionCheck) GetLastReconcile() *ReconcileTiming {
+	return check.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (check *ClusterVersionCheck) SetLastReconcile(timing *ReconcileTiming) {
+	check.Status.LastReconcile = timing
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterVersionCheck{}, &ClusterVersionCheckList{})
+}
@@ -0,0 +1,382 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// field indexes which are registered by the controllers, and which back the lookup of colliding
+// objects.  They must match the field indexes of the controllers package, which may not be imported
+// here as it imports this package.
+const (
+	indexClusterName      = "spec.clusterName"
+	indexClusterReference = "spec.clusterReference"
+	indexClusterID        = "status.clusterID"
+)
+
+// Collider represents an object which manages an object in OpenShift Cluster Manager that is identified
+// by the cluster which it belongs to and its display name, so that only a single object, in any
+// namespace, may manage it.
+type Collider interface {
+	client.Object
+
+	GetClusterName() string
+	GetOCMEnvironment() string
+	GetDisplayName() string
+	GetClusterReferenceBinding() ClusterReferenceBinding
+}
+
+// collisionWebhook is an admission webhook which rejects the creation of an object which would manage
+// the same object in OpenShift Cluster Manager as an existing object, for example when two objects in
+// different namespaces target the same cluster with the same display name.  It is registered separately
+// from the validating webhook of the object, as the validators of this version of controller-runtime
+// have no access to a client.
+type collisionWebhook struct {
+	collider Collider
+	client   client.Reader
+	scheme   *runtime.Scheme
+	decoder  *admission.Decoder
+
+	// lists are the list types of the objects which share the names of the objects in OpenShift Cluster
+	// Manager with the collider, including the list type of the collider itself.
+	lists []client.ObjectList
+
+	// defaultEnvironment is the environment of OpenShift Cluster Manager which is targeted by an object
+	// that does not set its own ocmEnvironment.
+	defaultEnvironment string
+}
+
+// setupCollisionWebhookWithManager registers the collision webhook of an object with the Manager.  It
+// requires that the cluster name and cluster reference fields of each of the list types, and the cluster
+// name field of cluster references, are indexed by the controllers.  Objects which do not set their
+// environment are compared as if they target the default environment, which falls back to production
+// when it is empty.
+func setupCollisionWebhookWithManager(
+	mgr ctrl.Manager,
+	collider Collider,
+	defaultEnvironment string,
+	lists ...client.ObjectList,
+) error {
+	if defaultEnvironment == "" {
+		defaultEnvironment = ocm.EnvironmentProduction
+	}
+
+	gvk, err := apiutil.GVKForObject(collider, mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("unable to determine group version kind of collision webhook - %w", err)
+	}
+
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("unable to create decoder for collision webhook - %w", err)
+	}
+
+	path := "/collide-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+
+	mgr.GetWebhookServer().Register(path, &webhook.Admission{
+		Handler: &collisionWebhook{
+			collider: collider,
+			client:   mgr.GetClient(),
+			scheme:   mgr.GetScheme(),
+			decoder:  decoder,
+			lists:    lists,
+
+			defaultEnvironment: defaultEnvironment,
+		},
+	})
+
+	return nil
+}
+
+// Handle implements admission.Handler.  Only the creation of an object is checked, as the cluster and
+// display name of an object may not be changed once it has been created.  An object which references a
+// cluster reference that does not yet exist is admitted, as its cluster is not yet known.
+func (hook *collisionWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	//nolint:forcetypeassert
+	object := hook.collider.DeepCopyObject().(Collider)
+	if err := hook.decoder.Decode(req, object); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// the namespace of the request is used, as it is not always set on the object itself
+	if object.GetNamespace() == "" {
+		object.SetNamespace(req.Namespace)
+	}
+
+	// the display name defaults to the name of the object, which may be generated by the api server
+	if object.GetName() == "" {
+		object.SetName(req.Name)
+	}
+
+	resolved, err := hook.resolve(ctx, object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if !resolved {
+		return admission.Allowed("")
+	}
+
+	candidates, err := hook.candidates(ctx, object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if existing := hook.collision(object, candidates); existing != nil {
+		return admission.Errored(http.StatusConflict, fmt.Errorf(
+			"%s [%s] of cluster [%s] is already managed by %s [%s/%s]",
+			hook.kind(object),
+			object.GetDisplayName(),
+			object.GetClusterName(),
+			hook.kind(existing),
+			existing.GetNamespace(),
+			existing.GetName(),
+		))
+	}
+
+	return admission.Allowed("")
+}
+
+// resolve resolves the cluster of an object which references a cluster reference.  It returns false if
+// the cluster reference does not exist.
+func (hook *collisionWebhook) resolve(ctx context.Context, object Collider) (bool, error) {
+	binding := object.GetClusterReferenceBinding()
+	if binding.GetClusterReference() == "" {
+		return true, nil
+	}
+
+	reference := &ClusterReference{}
+
+	if err := hook.client.Get(ctx, types.NamespacedName{
+		Namespace: object.GetNamespace(),
+		Name:      binding.GetClusterReference(),
+	}, reference); err != nil {
+		if apierrs.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf(
+			"unable to fetch cluster reference [%s/%s] - %w",
+			object.GetNamespace(),
+			binding.GetClusterReference(),
+			err,
+		)
+	}
+
+	binding.UseClusterReference(reference)
+	binding.SeedClusterStatus(reference)
+
+	return true, nil
+}
+
+// candidates returns the objects, in every namespace, which target the cluster of an object either by its
+// name or by a cluster reference which resolves the cluster.  When the id of the cluster is known, either
+// from the object or from another object which targets the cluster by the same name, the objects which
+// have recorded the id are also returned, as they may target the cluster by a name which it had before
+// it was renamed.  The id is set on the object in memory.  The cluster of an object which references a
+// cluster reference is resolved in memory.
+func (hook *collisionWebhook) candidates(ctx context.Context, object Collider) ([]Collider, error) {
+	candidates, err := hook.candidatesBy(ctx, indexClusterName, object.GetClusterName())
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID := clusterIDOf(object)
+	if clusterID == "" {
+		clusterID = hook.knownClusterID(object, candidates)
+	}
+
+	if clusterID == "" {
+		return candidates, nil
+	}
+
+	if binding := object.GetClusterReferenceBinding(); binding.ClusterID != nil {
+		*binding.ClusterID = clusterID
+	}
+
+	identified, err := hook.candidatesBy(ctx, indexClusterID, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(candidates, identified...), nil
+}
+
+// candidatesBy returns the objects, in every namespace, whose indexed field matches the value, along
+// with the objects which reference a cluster reference whose indexed field matches the value.
+func (hook *collisionWebhook) candidatesBy(ctx context.Context, field, value string) ([]Collider, error) {
+	references := &ClusterReferenceList{}
+	if err := hook.client.List(ctx, references, client.MatchingFields{field: value}); err != nil {
+		return nil, fmt.Errorf("unable to list cluster references by field [%s] - %w", field, err)
+	}
+
+	candidates := []Collider{}
+
+	for _, list := range hook.lists {
+		matching, err := hook.list(ctx, list, client.MatchingFields{field: value})
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, matching...)
+
+		for i := range references.Items {
+			reference := &references.Items[i]
+
+			referencing, err := hook.list(
+				ctx,
+				list,
+				client.InNamespace(reference.Namespace),
+				client.MatchingFields{indexClusterReference: reference.Name},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, candidate := range referencing {
+				candidate.GetClusterReferenceBinding().UseClusterReference(reference)
+				candidate.GetClusterReferenceBinding().SeedClusterStatus(reference)
+			}
+
+			candidates = append(candidates, referencing...)
+		}
+	}
+
+	return candidates, nil
+}
+
+// list returns the objects of a list type which match the list options.
+func (hook *collisionWebhook) list(ctx context.Context, list client.ObjectList, opts ...client.ListOption) ([]Collider, error) {
+	//nolint:forcetypeassert
+	objects := list.DeepCopyObject().(client.ObjectList)
+	if err := hook.client.List(ctx, objects, opts...); err != nil {
+		return nil, fmt.Errorf("unable to list objects - %w", err)
+	}
+
+	items, err := meta.ExtractList(objects)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract objects - %w", err)
+	}
+
+	colliders := make([]Collider, 0, len(items))
+
+	for _, item := range items {
+		if collider, ok := item.(Collider); ok {
+			colliders = append(colliders, collider)
+		}
+	}
+
+	return colliders, nil
+}
+
+// kind returns the kind of an object, for use in the message of a collision.
+func (hook *collisionWebhook) kind(object Collider) string {
+	gvk, err := apiutil.GVKForObject(object, hook.scheme)
+	if err != nil {
+		return "object"
+	}
+
+	return gvk.Kind
+}
+
+// collision returns the first candidate which manages the same object in OpenShift Cluster Manager as an
+// object, or nil if there is none.  The object itself is never a collision.  Objects which target
+// different environments of OpenShift Cluster Manager do not collide, as they target different
+// clusters, where an object which does not set its environment targets the default environment.
+func (hook *collisionWebhook) collision(object Collider, candidates []Collider) Collider {
+	for _, candidate := range candidates {
+		if candidate.GetNamespace() == object.GetNamespace() &&
+			candidate.GetName() == object.GetName() &&
+			reflect.TypeOf(candidate) == reflect.TypeOf(object) {
+			continue
+		}
+
+		if sameCluster(candidate, object) &&
+			hook.environmentOf(candidate) == hook.environmentOf(object) &&
+			candidate.GetDisplayName() == object.GetDisplayName() {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+// sameCluster determines if two objects target the same cluster.  The ids of the clusters are compared
+// when both are known, as the name of a cluster may have changed since it was recorded, and the names
+// are compared otherwise.
+func sameCluster(object, other Collider) bool {
+	if clusterIDOf(object) != "" && clusterIDOf(other) != "" {
+		return clusterIDOf(object) == clusterIDOf(other)
+	}
+
+	return object.GetClusterName() == other.GetClusterName()
+}
+
+// knownClusterID returns the id of the cluster which an object targets, as recorded by another object
+// which targets the cluster by the same name in the same environment, or an empty string if none has
+// recorded it.
+func (hook *collisionWebhook) knownClusterID(object Collider, candidates []Collider) string {
+	for _, candidate := range candidates {
+		if candidate.GetClusterName() == object.GetClusterName() &&
+			hook.environmentOf(candidate) == hook.environmentOf(object) &&
+			clusterIDOf(candidate) != "" {
+			return clusterIDOf(candidate)
+		}
+	}
+
+	return ""
+}
+
+// clusterIDOf returns the id of the cluster which has been recorded in the status of an object.
+func clusterIDOf(object Collider) string {
+	if clusterID := object.GetClusterReferenceBinding().ClusterID; clusterID != nil {
+		return *clusterID
+	}
+
+	return ""
+}
+
+// environmentOf returns the environment of OpenShift Cluster Manager which an object targets.
+func (hook *collisionWebhook) environmentOf(object Collider) string {
+	if object.GetOCMEnvironment() == "" {
+		return hook.defaultEnvironment
+	}
+
+	return object.GetOCMEnvironment()
+}
@@ -0,0 +1,205 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+func TestCollisionWebhook_Handle(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	pool := func(namespace, name, clusterName, reference, displayName string) *MachinePool {
+		return &MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: MachinePoolSpec{
+				ClusterName:           clusterName,
+				LocalClusterReference: LocalClusterReference{ClusterReference: reference},
+				DisplayName:           displayName,
+			},
+		}
+	}
+
+	ldap := &LDAPIdentityProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ldap"},
+		Spec:       LDAPIdentityProviderSpec{ClusterName: "dev", DisplayName: "idp"},
+	}
+
+	reference := &ClusterReference{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "cluster"},
+		Spec:       ClusterReferenceSpec{ClusterName: "dev"},
+	}
+
+	identified := func(machinePool *MachinePool, clusterID string) *MachinePool {
+		machinePool.Status.ClusterID = clusterID
+
+		return machinePool
+	}
+
+	inEnvironment := func(machinePool *MachinePool, environment string) *MachinePool {
+		machinePool.Spec.OCMEnvironment = environment
+
+		return machinePool
+	}
+
+	existing := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			identified(pool("team-a", "infra", "dev", "", "infra"), "dev-id"),
+			pool("team-b", "referenced", "", "cluster", "gpu"),
+			identified(pool("team-d", "renamed", "old-dev", "", "autoscale"), "dev-id"),
+			ldap,
+			reference,
+		).
+		WithIndex(&MachinePool{}, indexClusterName, func(object client.Object) []string {
+			return []string{object.(*MachinePool).Spec.ClusterName}
+		}).
+		WithIndex(&MachinePool{}, indexClusterReference, func(object client.Object) []string {
+			return []string{object.(*MachinePool).Spec.ClusterReference}
+		}).
+		WithIndex(&MachinePool{}, indexClusterID, func(object client.Object) []string {
+			return []string{object.(*MachinePool).Status.ClusterID}
+		}).
+		WithIndex(&ClusterReference{}, indexClusterName, func(object client.Object) []string {
+			return []string{object.(*ClusterReference).Spec.ClusterName}
+		}).
+		WithIndex(&ClusterReference{}, indexClusterID, func(object client.Object) []string {
+			return []string{object.(*ClusterReference).Status.ClusterID}
+		}).
+		Build()
+
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		object    *MachinePool
+		wantCode  int32
+	}{
+		{
+			name:      "ensure a machine pool with the same name on the same cluster in another namespace is rejected",
+			operation: admissionv1.Create,
+			object:    pool("team-b", "infra", "dev", "", "infra"),
+			wantCode:  http.StatusConflict,
+		},
+		{
+			name:      "ensure a machine pool which collides with a machine pool which references the cluster is rejected",
+			operation: admissionv1.Create,
+			object:    pool("team-c", "gpu", "dev", "", "gpu"),
+			wantCode:  http.StatusConflict,
+		},
+		{
+			name:      "ensure a machine pool which references the cluster and collides with a machine pool is rejected",
+			operation: admissionv1.Create,
+			object:    pool("team-b", "pool", "", "cluster", "infra"),
+			wantCode:  http.StatusConflict,
+		},
+		{
+			name:      "ensure a machine pool which sets the default environment collides with one which does not set it",
+			operation: admissionv1.Create,
+			object:    inEnvironment(pool("team-b", "infra", "dev", "", "infra"), ocm.EnvironmentProduction),
+			wantCode:  http.StatusConflict,
+		},
+		{
+			name:      "ensure a machine pool with the same name on the same cluster in another environment is allowed",
+			operation: admissionv1.Create,
+			object:    inEnvironment(pool("team-b", "infra", "dev", "", "infra"), "stage"),
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "ensure a machine pool which collides with a machine pool of the cluster before it was renamed is rejected",
+			operation: admissionv1.Create,
+			object:    pool("team-c", "autoscale", "dev", "", "autoscale"),
+			wantCode:  http.StatusConflict,
+		},
+		{
+			name:      "ensure a machine pool with the same name on a different cluster with the same name is allowed",
+			operation: admissionv1.Create,
+			object:    identified(pool("team-b", "infra", "dev", "", "infra"), "other-id"),
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "ensure a machine pool with a different name on the same cluster is allowed",
+			operation: admissionv1.Create,
+			object:    pool("team-b", "workers", "dev", "", "workers"),
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "ensure a machine pool with the same name on a different cluster is allowed",
+			operation: admissionv1.Create,
+			object:    pool("team-b", "infra", "prod", "", "infra"),
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "ensure a machine pool which references a missing cluster reference is allowed",
+			operation: admissionv1.Create,
+			object:    pool("team-c", "infra", "", "missing", "infra"),
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "ensure an update is allowed",
+			operation: admissionv1.Update,
+			object:    pool("team-b", "infra", "dev", "", "infra"),
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "ensure an object of a type which is not checked does not collide",
+			operation: admissionv1.Create,
+			object:    pool("team-c", "pool", "dev", "", ldap.Spec.DisplayName),
+			wantCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hook := &collisionWebhook{
+				collider: &MachinePool{},
+				client:   existing,
+				scheme:   scheme,
+				decoder:  decoder,
+				lists:    []client.ObjectList{&MachinePoolList{}},
+
+				defaultEnvironment: ocm.EnvironmentProduction,
+			}
+
+			raw, err := json.Marshal(tt.object)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			response := hook.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tt.operation,
+					Namespace: tt.object.Namespace,
+					Name:      tt.object.Name,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+
+			if code := response.Result.Code; code != tt.wantCode {
+				t.Errorf("Handle() code = %v, want %v (%s)", code, tt.wantCode, response.Result.Message)
+			}
+		})
+	}
+}
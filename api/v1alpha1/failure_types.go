@@ -0,0 +1,38 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReconcileFailureHours is the number of hours for which the failed reconciliations of a resource are
+// counted, which bounds the window over which a reconcile report summarizes reconciliation errors.
+const ReconcileFailureHours = 168
+
+// ReconcileFailureCount represents the number of reconciliations of a resource which failed within
+// an individual hour.
+type ReconcileFailureCount struct {
+	// +kubebuilder:validation:Required
+	// Represents the start of the hour within which the reconciliations failed.
+	Hour metav1.Time `json:"hour"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// Represents the number of reconciliations which failed within the hour.
+	Count int `json:"count"`
+}
@@ -29,6 +29,7 @@ const (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// +kubebuilder:validation:XValidation:message="exactly one of clusterName or clusterReference must be set",rule=(has(self.clusterName) != has(self.clusterReference))
 // GitLabIdentityProviderSpec defines the desired state of GitLabIdentityProvider
 type GitLabIdentityProviderSpec struct {
 	// +kubebuilder:validation:Required
@@ -47,6 +48,17 @@ type GitLabIdentityProviderSpec struct {
 	// for a detailed description of what these mean.  Must be one of claim (default), lookup, generate, or add.
 	MappingMethod string `json:"mappingMethod,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// Whether the identity provider may be used by clients, such as the oc command line, which
+	// authenticate by responding to a challenge for credentials rather than through a web login.
+	// If unset, the default of OpenShift Cluster Manager is used.
+	Challenge *bool `json:"challenge,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the identity provider is offered as a web login option, for example on the login
+	// page of the web console.  If unset, the default of OpenShift Cluster Manager is used.
+	Login *bool `json:"login,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// ca is an optional reference containing the PEM-encoded CA bundle data, as a string value.
 	// It is used as a trust anchor to validate the TLS certificate presented by the remote server.
@@ -55,7 +67,7 @@ type GitLabIdentityProviderSpec struct {
 	// +optional
 	CA string `json:"ca,omitempty"`
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:message="clusterName is immutable",rule=(self == oldSelf)
 	// Cluster ID in OpenShift Cluster Manager by which this should be managed for.  The cluster ID
 	// can be obtained on the Clusters page for the individual cluster.  It may also be known as the
@@ -63,6 +75,16 @@ type GitLabIdentityProviderSpec struct {
 	// where the 'x' represents any alphanumeric character.
 	ClusterName string `json:"clusterName,omitempty"`
 
+	LocalClusterReference `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=4
 	// +kubebuilder:validation:MaxLength=15
@@ -82,12 +104,62 @@ type GitLabIdentityProviderSpec struct {
 	// expected key is not found, the identity provider is not honored. The namespace
 	// for this secret must exist in the same namespace as the resource.
 	AccessTokenSecret string `json:"accessTokenSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// callbackURLConfigMap is an optional name of a config map, in the same namespace as the
+	// resource, to which the OAuth callback URL of the identity provider is written at the key
+	// 'callbackURL'.  This allows the callback URL to be consumed programmatically.  The
+	// config map is owned by, and deleted with, the resource.
+	CallbackURLConfigMap string `json:"callbackURLConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// migrateFrom is an optional reference to an existing LDAPIdentityProvider which is replaced
+	// by this identity provider.  Once this identity provider has been created in OpenShift
+	// Cluster Manager, the referenced LDAPIdentityProvider is deleted.
+	MigrateFrom *IdentityProviderMigration `json:"migrateFrom,omitempty"`
 }
 
+const (
+	// GitLabCallbackURLKey is the key of the callback url in the callback url config map.
+	GitLabCallbackURLKey = "callbackURL"
+)
+
 // GitLabIdentityProviderStatus defines the observed state of GitLabIdentityProvider
 type GitLabIdentityProviderStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Represents a bounded history of the conditions which have been replaced
+	// on this resource, ordered from oldest to newest.
+	ConditionHistory []metav1.Condition `json:"conditionHistory,omitempty"`
+
+	// Represents a bounded history of the operations which have been sent to OpenShift
+	// Cluster Manager for this resource, ordered from oldest to newest.
+	OperationHistory []OCMOperation `json:"operationHistory,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// Represents the number of reconciliations of this resource which failed within each of the most
+	// recent hours, ordered from oldest to newest.  Failures are counted independently of the condition
+	// history, which only keeps a limited number of conditions.
+	ReconcileFailures []ReconcileFailureCount `json:"reconcileFailures,omitempty"`
+
+	// Represents the generation of the resource which was last applied to OpenShift
+	// Cluster Manager.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents a hash of the inputs, such as the desired spec and the versions of
+	// referenced secrets, which were last applied to OpenShift Cluster Manager.
+	// Reading OpenShift Cluster Manager is skipped while neither the generation nor
+	// the inputs have changed, until the drift interval has elapsed since the
+	// inputs were last applied.
+	InputHash string `json:"inputHash,omitempty"`
+
+	// Represents the time at which the inputs were last applied, after the state in
+	// OpenShift Cluster Manager was read and brought in line with them.
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
 	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
 	// Represents the programmatic cluster ID of the cluster, as
 	// determined during reconciliation.  This is used to reduce
@@ -136,12 +208,164 @@ func (gitlab *GitLabIdentityProvider) SetConditions(conditions []metav1.Conditio
 	gitlab.Status.Conditions = conditions
 }
 
+// GetClusterName returns the spec.clusterName field from the object.  It is used to satisfy the
+// ClusterWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetClusterName() string {
+	return gitlab.Spec.ClusterName
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetOCMEnvironment() string {
+	return gitlab.Spec.OCMEnvironment
+}
+
+// GetClusterReferenceBinding returns the reference of the object to a cluster reference, bound to the
+// fields of the object which are resolved from the cluster reference.  It is used to satisfy the
+// ClusterReferencer interface.
+func (gitlab *GitLabIdentityProvider) GetClusterReferenceBinding() ClusterReferenceBinding {
+	return ClusterReferenceBinding{
+		LocalClusterReference: &gitlab.Spec.LocalClusterReference,
+		ClusterName:           &gitlab.Spec.ClusterName,
+		OCMEnvironment:        &gitlab.Spec.OCMEnvironment,
+		ClusterID:             &gitlab.Status.ClusterID,
+	}
+}
+
+// GetConditionHistory returns the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetConditionHistory() []metav1.Condition {
+	return gitlab.Status.ConditionHistory
+}
+
+// SetConditionHistory sets the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (gitlab *GitLabIdentityProvider) SetConditionHistory(history []metav1.Condition) {
+	gitlab.Status.ConditionHistory = history
+}
+
+// GetOperationHistory returns the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetOperationHistory() []OCMOperation {
+	return gitlab.Status.OperationHistory
+}
+
+// SetOperationHistory sets the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (gitlab *GitLabIdentityProvider) SetOperationHistory(history []OCMOperation) {
+	gitlab.Status.OperationHistory = history
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetLastReconcile() *ReconcileTiming {
+	return gitlab.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (gitlab *GitLabIdentityProvider) SetLastReconcile(timing *ReconcileTiming) {
+	gitlab.Status.LastReconcile = timing
+}
+
+// GetReconcileFailures returns the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetReconcileFailures() []ReconcileFailureCount {
+	return gitlab.Status.ReconcileFailures
+}
+
+// SetReconcileFailures sets the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (gitlab *GitLabIdentityProvider) SetReconcileFailures(failures []ReconcileFailureCount) {
+	gitlab.Status.ReconcileFailures = failures
+}
+
+// GetObservedGeneration returns the generation which was last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetObservedGeneration() int64 {
+	return gitlab.Status.ObservedGeneration
+}
+
+// SetObservedGeneration sets the generation which was last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (gitlab *GitLabIdentityProvider) SetObservedGeneration(generation int64) {
+	gitlab.Status.ObservedGeneration = generation
+}
+
+// GetInputHash returns the hash of the inputs which were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetInputHash() string {
+	return gitlab.Status.InputHash
+}
+
+// SetInputHash sets the hash of the inputs which were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (gitlab *GitLabIdentityProvider) SetInputHash(hash string) {
+	gitlab.Status.InputHash = hash
+}
+
+// GetLastAppliedTime returns the time at which the inputs were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (gitlab *GitLabIdentityProvider) GetLastAppliedTime() *metav1.Time {
+	return gitlab.Status.LastAppliedTime
+}
+
+// SetLastAppliedTime sets the time at which the inputs were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (gitlab *GitLabIdentityProvider) SetLastAppliedTime(at *metav1.Time) {
+	gitlab.Status.LastAppliedTime = at
+}
+
+// GetSecretKeys returns the keys which are required from each secret referenced by the
+// GitLabIdentityProvider, indexed by the name of the secret.
+func (gitlab *GitLabIdentityProvider) GetSecretKeys() map[string][]string {
+	return map[string][]string{
+		gitlab.Spec.AccessTokenSecret: {GitLabAccessTokenKey},
+	}
+}
+
+// GetDisplayName returns the name of the identity provider in OpenShift Cluster Manager.  It defaults
+// to the spec.displayName field but returns the metadata.name field if unset.
+func (gitlab *GitLabIdentityProvider) GetDisplayName() string {
+	return defaultDisplayName(gitlab.Spec.DisplayName, gitlab.GetName())
+}
+
+// GetMappingMethod returns the mapping method of the identity provider.  The lookup mapping method
+// is used while migrating from another identity provider, if requested, so that the identities of
+// the replaced identity provider may be mapped to existing users.
+func (gitlab *GitLabIdentityProvider) GetMappingMethod() string {
+	if gitlab.Spec.MigrateFrom != nil && gitlab.Spec.MigrateFrom.LookupMapping {
+		return string(clustersmgmtv1.IdentityProviderMappingMethodLookup)
+	}
+
+	return gitlab.Spec.MappingMethod
+}
+
 // CopyFrom copies a GitLab Identity provider into an object that is able to be reconciled.
 func (gitlab *GitLabIdentityProvider) CopyFrom(source *clustersmgmtv1.GitlabIdentityProvider) {
 	gitlab.Spec.CA = source.CA()
 	gitlab.Spec.URL = source.URL()
 }
 
+// ImportFrom imports the fields which are stored in OpenShift Cluster Manager from the current state
+// of a GitLab identity provider into the spec.  The url is immutable, so it is not imported and is
+// reported by ImportMismatches instead.
+func (gitlab *GitLabIdentityProvider) ImportFrom(current *GitLabIdentityProvider) {
+	gitlab.Spec.CA = current.Spec.CA
+	gitlab.Spec.Challenge = current.Spec.Challenge
+	gitlab.Spec.Login = current.Spec.Login
+}
+
+// ImportMismatches returns the paths of the immutable fields of the spec which differ from the current
+// state of a GitLab identity provider, and so may not be imported.
+func (gitlab *GitLabIdentityProvider) ImportMismatches(current *GitLabIdentityProvider) (fields []string) {
+	if gitlab.Spec.URL != current.Spec.URL {
+		fields = append(fields, "url")
+	}
+
+	return fields
+}
+
 // Builder returns the builder object from a reconciler object.  This object is used to
 // pass into the OCM API for creating the object.
 func (gitlab *GitLabIdentityProvider) Builder(ca, clientSecret string) *clustersmgmtv1.GitlabIdentityProviderBuilder {
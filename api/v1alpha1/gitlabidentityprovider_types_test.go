@@ -0,0 +1,60 @@
+package v1alpha1
+
+import "testing"
+
+func TestGitLabIdentityProvider_GetMappingMethod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		migrateFrom *IdentityProviderMigration
+		want        string
+	}{
+		{
+			name: "ensure the mapping method is used without a migration",
+			want: "claim",
+		},
+		{
+			name:        "ensure the mapping method is used for a migration without lookup mapping",
+			migrateFrom: &IdentityProviderMigration{Name: "ldap"},
+			want:        "claim",
+		},
+		{
+			name:        "ensure the lookup mapping method is used for a migration with lookup mapping",
+			migrateFrom: &IdentityProviderMigration{Name: "ldap", LookupMapping: true},
+			want:        "lookup",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gitlab := &GitLabIdentityProvider{
+				Spec: GitLabIdentityProviderSpec{MappingMethod: "claim", MigrateFrom: tt.migrateFrom},
+			}
+
+			if got := gitlab.GetMappingMethod(); got != tt.want {
+				t.Errorf("GetMappingMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitLabIdentityProvider_ImportFrom(t *testing.T) {
+	t.Parallel()
+
+	gitlab := &GitLabIdentityProvider{Spec: GitLabIdentityProviderSpec{URL: "https://gitlab.example.com"}}
+	current := &GitLabIdentityProvider{Spec: GitLabIdentityProviderSpec{URL: "https://gitlab.other.com", CA: "ca"}}
+
+	gitlab.ImportFrom(current)
+
+	if gitlab.Spec.URL != "https://gitlab.example.com" || gitlab.Spec.CA != "ca" {
+		t.Errorf("ImportFrom() spec = %+v, want the url preserved and the ca imported", gitlab.Spec)
+	}
+
+	if got := gitlab.ImportMismatches(current); len(got) != 1 || got[0] != "url" {
+		t.Errorf("ImportMismatches() = %v, want %v", got, []string{"url"})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager sets up the defaulting, validating and collision webhooks for the GitLabIdentityProvider with the Manager.
+// The identity providers of a cluster share their names regardless of their type, so a GitLabIdentityProvider
+// also collides with an LDAPIdentityProvider.  A GitLabIdentityProvider which does not set its ocmEnvironment
+// is checked for collisions in the default environment.
+func (gitlab *GitLabIdentityProvider) SetupWebhookWithManager(mgr ctrl.Manager, defaultEnvironment string) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(gitlab).Complete(); err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+
+	return setupCollisionWebhookWithManager(
+		mgr,
+		gitlab,
+		defaultEnvironment,
+		&GitLabIdentityProviderList{},
+		&LDAPIdentityProviderList{},
+	)
+}
+
+//+kubebuilder:webhook:path=/collide-ocm-mobb-redhat-com-v1alpha1-gitlabidentityprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=gitlabidentityproviders,verbs=create,versions=v1alpha1,name=cgitlabidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ Collider = &GitLabIdentityProvider{}
+
+//+kubebuilder:webhook:path=/mutate-ocm-mobb-redhat-com-v1alpha1-gitlabidentityprovider,mutating=true,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=gitlabidentityproviders,verbs=create,versions=v1alpha1,name=mgitlabidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &GitLabIdentityProvider{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.  The spec.displayName
+// field defaults to the metadata.name field on creation, so that the name of the object in OpenShift
+// Cluster Manager is visible in the spec.  Existing objects are not defaulted, as the spec.displayName
+// field may not be changed once it has been set.
+func (gitlab *GitLabIdentityProvider) Default() {
+	if gitlab.Spec.DisplayName == "" {
+		gitlab.Spec.DisplayName = gitlab.GetName()
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-ocm-mobb-redhat-com-v1alpha1-gitlabidentityprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=gitlabidentityproviders;gitlabidentityproviders/status,verbs=create;update,versions=v1alpha1,name=vgitlabidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &GitLabIdentityProvider{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.  Only the
+// name of the GitLabIdentityProvider in OpenShift Cluster Manager is validated on creation.
+func (gitlab *GitLabIdentityProvider) ValidateCreate() error {
+	return invalid("GitLabIdentityProvider", gitlab.Name, validateDisplayName(gitlab.Spec.DisplayName, gitlab.Name, identityProviderNamePattern, reservedIdentityProviderName))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (gitlab *GitLabIdentityProvider) ValidateUpdate(old runtime.Object) error {
+	previous, err := convertOld[*GitLabIdentityProvider](old)
+	if err != nil {
+		return err
+	}
+
+	return invalid("GitLabIdentityProvider", gitlab.Name, gitlab.validateImmutable(previous))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.  Deletion
+// is not validated.
+func (gitlab *GitLabIdentityProvider) ValidateDelete() error {
+	return nil
+}
+
+// validateImmutable returns the field errors of the fields of the GitLabIdentityProvider which may not
+// be changed once they have been set.
+func (gitlab *GitLabIdentityProvider) validateImmutable(old *GitLabIdentityProvider) field.ErrorList {
+	spec, status := field.NewPath("spec"), field.NewPath("status")
+
+	return validateImmutable(
+		immutableField{path: spec.Child("clusterName"), oldValue: old.Spec.ClusterName, newValue: gitlab.Spec.ClusterName},
+		immutableField{path: spec.Child("clusterReference"), oldValue: old.Spec.ClusterReference, newValue: gitlab.Spec.ClusterReference},
+		immutableField{path: spec.Child("ocmEnvironment"), oldValue: old.Spec.OCMEnvironment, newValue: gitlab.Spec.OCMEnvironment},
+		immutableField{path: spec.Child("displayName"), oldValue: old.Spec.DisplayName, newValue: gitlab.Spec.DisplayName},
+		immutableField{path: spec.Child("url"), oldValue: old.Spec.URL, newValue: gitlab.Spec.URL},
+		immutableField{path: spec.Child("accessTokenSecret"), oldValue: old.Spec.AccessTokenSecret, newValue: gitlab.Spec.AccessTokenSecret},
+		immutableField{path: status.Child("clusterID"), oldValue: old.Status.ClusterID, newValue: gitlab.Status.ClusterID, onceSet: true},
+		immutableField{path: status.Child("callbackURL"), oldValue: old.Status.CallbackURL, newValue: gitlab.Status.CallbackURL, onceSet: true},
+	)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// immutableField represents a field which may not be changed once the object has been created.  Fields
+// which are populated during reconciliation, such as those in the status, set onceSet so that they may
+// be populated once but not changed afterwards.
+type immutableField struct {
+	path     *field.Path
+	oldValue interface{}
+	newValue interface{}
+	onceSet  bool
+}
+
+// validateImmutable returns an error for each of the immutable fields whose value has changed.  Changing
+// these fields would either create a duplicate object in OpenShift Cluster Manager or orphan the object
+// which was previously managed.
+func validateImmutable(fields ...immutableField) field.ErrorList {
+	var errs field.ErrorList
+
+	for i := range fields {
+		if fields[i].onceSet && reflect.ValueOf(fields[i].oldValue).IsZero() {
+			continue
+		}
+
+		errs = append(errs, apivalidation.ValidateImmutableField(fields[i].newValue, fields[i].oldValue, fields[i].path)...)
+	}
+
+	return errs
+}
+
+// invalid returns the error for a set of field errors of an object of a particular kind, or nil if there
+// are no field errors.
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
+
+// convertOld converts the old object of an update request to the type of the new object.
+func convertOld[T runtime.Object](old runtime.Object) (T, error) {
+	converted, ok := old.(T)
+	if !ok {
+		return converted, apierrors.NewBadRequest(fmt.Sprintf("expected an object of type %T but got %T", converted, old))
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func TestValidateUpdate_immutable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		updated   webhook.Validator
+		old       runtime.Object
+		wantField string
+	}{
+		{
+			name:    "ensure an unchanged machine pool passes",
+			updated: &MachinePool{Spec: MachinePoolSpec{ClusterName: "dscott", MinimumNodesPerZone: 3}},
+			old:     &MachinePool{Spec: MachinePoolSpec{ClusterName: "dscott", MinimumNodesPerZone: 2}},
+		},
+		{
+			name:      "ensure a changed machine pool cluster name fails",
+			updated:   &MachinePool{Spec: MachinePoolSpec{ClusterName: "other"}},
+			old:       &MachinePool{Spec: MachinePoolSpec{ClusterName: "dscott"}},
+			wantField: "spec.clusterName",
+		},
+		{
+			name:      "ensure a changed machine pool display name fails",
+			updated:   &MachinePool{Spec: MachinePoolSpec{DisplayName: "other"}},
+			old:       &MachinePool{},
+			wantField: "spec.displayName",
+		},
+		{
+			name:    "ensure machine pool availability zones may be initially set",
+			updated: &MachinePool{Status: MachinePoolStatus{AvailabilityZones: []string{"us-east-1a"}}},
+			old:     &MachinePool{},
+		},
+		{
+			name:      "ensure changed machine pool availability zones fail",
+			updated:   &MachinePool{Status: MachinePoolStatus{AvailabilityZones: []string{"us-east-1b"}}},
+			old:       &MachinePool{Status: MachinePoolStatus{AvailabilityZones: []string{"us-east-1a"}}},
+			wantField: "status.availabilityZones",
+		},
+		{
+			name:      "ensure a changed gitlab identity provider url fails",
+			updated:   &GitLabIdentityProvider{Spec: GitLabIdentityProviderSpec{URL: "https://gitlab.example.com"}},
+			old:       &GitLabIdentityProvider{Spec: GitLabIdentityProviderSpec{URL: "https://gitlab.com"}},
+			wantField: "spec.url",
+		},
+		{
+			name:      "ensure a changed ldap identity provider display name fails",
+			updated:   &LDAPIdentityProvider{Spec: LDAPIdentityProviderSpec{DisplayName: "other"}},
+			old:       &LDAPIdentityProvider{Spec: LDAPIdentityProviderSpec{DisplayName: "ldap"}},
+			wantField: "spec.displayName",
+		},
+		{
+			name:    "ensure an ldap identity provider with an unchanged invalid spec passes",
+			updated: &LDAPIdentityProvider{Status: LDAPIdentityProviderStatus{ProviderID: "abc"}},
+			old:     &LDAPIdentityProvider{},
+		},
+		{
+			name:    "ensure a changed ldap identity provider id passes as it is assigned by ocm",
+			updated: &LDAPIdentityProvider{Status: LDAPIdentityProviderStatus{ProviderID: "def"}},
+			old:     &LDAPIdentityProvider{Status: LDAPIdentityProviderStatus{ProviderID: "abc"}},
+		},
+		{
+			name:      "ensure a changed cluster notification subscription id fails",
+			updated:   &ClusterNotification{Status: ClusterNotificationStatus{SubscriptionID: "def"}},
+			old:       &ClusterNotification{Status: ClusterNotificationStatus{SubscriptionID: "abc"}},
+			wantField: "status.subscriptionID",
+		},
+		{
+			name:      "ensure a changed cluster version check cluster name fails",
+			updated:   &ClusterVersionCheck{Spec: ClusterVersionCheckSpec{ClusterName: "other"}},
+			old:       &ClusterVersionCheck{Spec: ClusterVersionCheckSpec{ClusterName: "dscott"}},
+			wantField: "spec.clusterName",
+		},
+		{
+			name:      "ensure an old object of a different type fails",
+			updated:   &MachinePool{},
+			old:       &ClusterNotification{},
+			wantField: "expected an object of type",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.updated.ValidateUpdate(tt.old)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateUpdate() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantField) {
+				t.Errorf("ValidateUpdate() error = %v, want error containing %v", err, tt.wantField)
+			}
+		})
+	}
+}
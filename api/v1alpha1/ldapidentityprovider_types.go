@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
@@ -26,16 +28,20 @@ import (
 const (
 	LDAPBindPasswordKey = "bindPassword"
 	LDAPCAKey           = "ca.crt"
+
+	LDAPCAKindConfigMap = "ConfigMap"
+	LDAPCAKindSecret    = "Secret"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// +kubebuilder:validation:XValidation:message="exactly one of clusterName or clusterReference must be set",rule=(has(self.clusterName) != has(self.clusterReference))
 // LDAPIdentityProviderSpec defines the desired state of LDAPIdentityProvider
 type LDAPIdentityProviderSpec struct {
 	configv1.LDAPIdentityProvider `json:",inline"`
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:message="clusterName is immutable",rule=(self == oldSelf)
 	// Cluster ID in OpenShift Cluster Manager by which this should be managed for.  The cluster ID
 	// can be obtained on the Clusters page for the individual cluster.  It may also be known as the
@@ -43,6 +49,16 @@ type LDAPIdentityProviderSpec struct {
 	// where the 'x' represents any alphanumeric character.
 	ClusterName string `json:"clusterName,omitempty"`
 
+	LocalClusterReference `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=4
 	// +kubebuilder:validation:MaxLength=15
@@ -60,12 +76,86 @@ type LDAPIdentityProviderSpec struct {
 	// See https://docs.openshift.com/container-platform/latest/authentication/understanding-identity-provider.html#identity-provider-parameters_understanding-identity-provider
 	// for a detailed description of what these mean.  Must be one of claim (default), lookup, generate, or add.
 	MappingMethod string `json:"mappingMethod,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the identity provider may be used by clients, such as the oc command line, which
+	// authenticate by responding to a challenge for credentials rather than through a web login.
+	// If unset, the default of OpenShift Cluster Manager is used.
+	Challenge *bool `json:"challenge,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the identity provider is offered as a web login option, for example on the login
+	// page of the web console.  If unset, the default of OpenShift Cluster Manager is used.
+	Login *bool `json:"login,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Attempt a connection, and a bind when spec.bindDN is set, to the LDAP server from the operator
+	// before the identity provider is applied to OpenShift Cluster Manager.  This surfaces DNS, TLS
+	// and bind failures in the LDAPConnectionValidated condition rather than in a failed login.  The
+	// LDAP server must be reachable from the operator for this validation to pass.
+	ValidateConnection bool `json:"validateConnection,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=bindPassword
+	// Key within the secret referenced by spec.bindPassword.name which contains the bind password.
+	// Defaults to 'bindPassword'.  Trailing newlines are removed from the bind password.
+	BindPasswordKey string `json:"bindPasswordKey,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ConfigMap
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// Kind of the object referenced by spec.ca.name which contains the PEM-encoded CA bundle.  Must
+	// be one of ConfigMap (default) or Secret.
+	CAKind string `json:"caKind,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ca.crt
+	// Key within the object referenced by spec.ca.name which contains the PEM-encoded CA bundle.
+	// Defaults to 'ca.crt'.
+	CAKey string `json:"caKey,omitempty"`
 }
 
 // LDAPIdentityProviderStatus defines the observed state of LDAPIdentityProvider
 type LDAPIdentityProviderStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Represents a bounded history of the conditions which have been replaced
+	// on this resource, ordered from oldest to newest.
+	ConditionHistory []metav1.Condition `json:"conditionHistory,omitempty"`
+
+	// Represents a bounded history of the operations which have been sent to OpenShift
+	// Cluster Manager for this resource, ordered from oldest to newest.
+	OperationHistory []OCMOperation `json:"operationHistory,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// Represents the number of reconciliations of this resource which failed within each of the most
+	// recent hours, ordered from oldest to newest.  Failures are counted independently of the condition
+	// history, which only keeps a limited number of conditions.
+	ReconcileFailures []ReconcileFailureCount `json:"reconcileFailures,omitempty"`
+
+	// Represents the generation of the resource which was last applied to OpenShift
+	// Cluster Manager.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents a hash of the inputs, such as the desired spec and the versions of
+	// referenced secrets, which were last applied to OpenShift Cluster Manager.
+	// Reading OpenShift Cluster Manager is skipped while neither the generation nor
+	// the inputs have changed, until the drift interval has elapsed since the
+	// inputs were last applied.
+	InputHash string `json:"inputHash,omitempty"`
+
+	// Represents the time at which the inputs were last applied, after the state in
+	// OpenShift Cluster Manager was read and brought in line with them.
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// Represents the effective attributes of the identity provider which were last
+	// applied to OpenShift Cluster Manager, after defaulting the attributes which
+	// were not requested.
+	Attributes *configv1.LDAPAttributeMapping `json:"attributes,omitempty"`
+
 	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
 	// Represents the programmatic cluster ID of the cluster, as
 	// determined during reconciliation.  This is used to reduce
@@ -73,11 +163,11 @@ type LDAPIdentityProviderStatus struct {
 	// the cluster name.
 	ClusterID string `json:"clusterID,omitempty"`
 
-	// +kubebuilder:validation:XValidation:message="status.providerID is immutable",rule=(self == oldSelf)
 	// Represents the programmatic identity provider ID of the IDP, as
 	// determined during reconciliation.  This is used to reduce
 	// the number of API calls to look up a cluster ID based on
-	// the identity provider name.
+	// the identity provider name.  It is assigned by OpenShift Cluster
+	// Manager and changes if the identity provider is recreated.
 	ProviderID string `json:"providerID,omitempty"`
 }
 
@@ -115,6 +205,182 @@ func (ldap *LDAPIdentityProvider) SetConditions(conditions []metav1.Condition) {
 	ldap.Status.Conditions = conditions
 }
 
+// GetClusterName returns the spec.clusterName field from the object.  It is used to satisfy the
+// ClusterWorkload interface.
+func (ldap *LDAPIdentityProvider) GetClusterName() string {
+	return ldap.Spec.ClusterName
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (ldap *LDAPIdentityProvider) GetOCMEnvironment() string {
+	return ldap.Spec.OCMEnvironment
+}
+
+// GetClusterReferenceBinding returns the reference of the object to a cluster reference, bound to the
+// fields of the object which are resolved from the cluster reference.  It is used to satisfy the
+// ClusterReferencer interface.
+func (ldap *LDAPIdentityProvider) GetClusterReferenceBinding() ClusterReferenceBinding {
+	return ClusterReferenceBinding{
+		LocalClusterReference: &ldap.Spec.LocalClusterReference,
+		ClusterName:           &ldap.Spec.ClusterName,
+		OCMEnvironment:        &ldap.Spec.OCMEnvironment,
+		ClusterID:             &ldap.Status.ClusterID,
+	}
+}
+
+// GetConditionHistory returns the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (ldap *LDAPIdentityProvider) GetConditionHistory() []metav1.Condition {
+	return ldap.Status.ConditionHistory
+}
+
+// SetConditionHistory sets the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (ldap *LDAPIdentityProvider) SetConditionHistory(history []metav1.Condition) {
+	ldap.Status.ConditionHistory = history
+}
+
+// GetOperationHistory returns the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (ldap *LDAPIdentityProvider) GetOperationHistory() []OCMOperation {
+	return ldap.Status.OperationHistory
+}
+
+// SetOperationHistory sets the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (ldap *LDAPIdentityProvider) SetOperationHistory(history []OCMOperation) {
+	ldap.Status.OperationHistory = history
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (ldap *LDAPIdentityProvider) GetLastReconcile() *ReconcileTiming {
+	return ldap.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (ldap *LDAPIdentityProvider) SetLastReconcile(timing *ReconcileTiming) {
+	ldap.Status.LastReconcile = timing
+}
+
+// GetReconcileFailures returns the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (ldap *LDAPIdentityProvider) GetReconcileFailures() []ReconcileFailureCount {
+	return ldap.Status.ReconcileFailures
+}
+
+// SetReconcileFailures sets the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (ldap *LDAPIdentityProvider) SetReconcileFailures(failures []ReconcileFailureCount) {
+	ldap.Status.ReconcileFailures = failures
+}
+
+// GetObservedGeneration returns the generation which was last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (ldap *LDAPIdentityProvider) GetObservedGeneration() int64 {
+	return ldap.Status.ObservedGeneration
+}
+
+// SetObservedGeneration sets the generation which was last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (ldap *LDAPIdentityProvider) SetObservedGeneration(generation int64) {
+	ldap.Status.ObservedGeneration = generation
+}
+
+// GetInputHash returns the hash of the inputs which were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (ldap *LDAPIdentityProvider) GetInputHash() string {
+	return ldap.Status.InputHash
+}
+
+// SetInputHash sets the hash of the inputs which were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (ldap *LDAPIdentityProvider) SetInputHash(hash string) {
+	ldap.Status.InputHash = hash
+}
+
+// GetLastAppliedTime returns the time at which the inputs were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (ldap *LDAPIdentityProvider) GetLastAppliedTime() *metav1.Time {
+	return ldap.Status.LastAppliedTime
+}
+
+// SetLastAppliedTime sets the time at which the inputs were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (ldap *LDAPIdentityProvider) SetLastAppliedTime(at *metav1.Time) {
+	ldap.Status.LastAppliedTime = at
+}
+
+// GetDisplayName returns the name of the identity provider in OpenShift Cluster Manager.  It defaults
+// to the spec.displayName field but returns the metadata.name field if unset.
+func (ldap *LDAPIdentityProvider) GetDisplayName() string {
+	return defaultDisplayName(ldap.Spec.DisplayName, ldap.GetName())
+}
+
+// GetBindPasswordKey returns the key within the bind password secret which contains the bind password.
+func (ldap *LDAPIdentityProvider) GetBindPasswordKey() string {
+	if ldap.Spec.BindPasswordKey == "" {
+		return LDAPBindPasswordKey
+	}
+
+	return ldap.Spec.BindPasswordKey
+}
+
+// GetCAKind returns the kind of object which contains the ca data.
+func (ldap *LDAPIdentityProvider) GetCAKind() string {
+	if ldap.Spec.CAKind == "" {
+		return LDAPCAKindConfigMap
+	}
+
+	return ldap.Spec.CAKind
+}
+
+// GetCAKey returns the key within the ca object which contains the ca data.
+func (ldap *LDAPIdentityProvider) GetCAKey() string {
+	if ldap.Spec.CAKey == "" {
+		return LDAPCAKey
+	}
+
+	return ldap.Spec.CAKey
+}
+
+// GetCAConfigMap returns the name of the config map which contains the ca data, or an empty string if
+// the ca data is not contained in a config map.
+func (ldap *LDAPIdentityProvider) GetCAConfigMap() string {
+	if ldap.GetCAKind() != LDAPCAKindConfigMap {
+		return ""
+	}
+
+	return ldap.Spec.CA.Name
+}
+
+// GetConfigMapNames returns the names of the config maps referenced by the LDAPIdentityProvider.
+func (ldap *LDAPIdentityProvider) GetConfigMapNames() []string {
+	if ldap.GetCAConfigMap() == "" {
+		return nil
+	}
+
+	return []string{ldap.GetCAConfigMap()}
+}
+
+// GetSecretKeys returns the keys which are required from each secret referenced by the
+// LDAPIdentityProvider, indexed by the name of the secret.
+func (ldap *LDAPIdentityProvider) GetSecretKeys() map[string][]string {
+	keys := map[string][]string{}
+
+	if ldap.Spec.BindPassword.Name != "" {
+		keys[ldap.Spec.BindPassword.Name] = append(keys[ldap.Spec.BindPassword.Name], ldap.GetBindPasswordKey())
+	}
+
+	if ldap.Spec.CA.Name != "" && ldap.GetCAKind() == LDAPCAKindSecret {
+		keys[ldap.Spec.CA.Name] = append(keys[ldap.Spec.CA.Name], ldap.GetCAKey())
+	}
+
+	return keys
+}
+
 // CopyFrom copies relevant fields from an LDAP Identity provider into an object that is able to be reconciled.
 func (ldap *LDAPIdentityProvider) CopyFrom(source *clustersmgmtv1.LDAPIdentityProvider) {
 	ldap.Spec.URL = source.URL()
@@ -128,6 +394,19 @@ func (ldap *LDAPIdentityProvider) CopyFrom(source *clustersmgmtv1.LDAPIdentityPr
 	)
 }
 
+// ImportFrom imports the fields which are stored in OpenShift Cluster Manager from the current state
+// of an LDAP identity provider into the spec.  The bind password and CA are not returned from
+// OpenShift Cluster Manager, so the references to them are preserved.
+func (ldap *LDAPIdentityProvider) ImportFrom(current *LDAPIdentityProvider) {
+	ldap.Spec.URL = current.Spec.URL
+	ldap.Spec.BindDN = current.Spec.BindDN
+	ldap.Spec.Insecure = current.Spec.Insecure
+	ldap.Spec.MappingMethod = current.Spec.MappingMethod
+	ldap.Spec.Challenge = current.Spec.Challenge
+	ldap.Spec.Login = current.Spec.Login
+	ldap.Spec.Attributes = current.Spec.Attributes
+}
+
 // Builder returns the builder object from a reconciler object.  This object is used to
 // pass into the OCM API for creating the object.
 func (ldap *LDAPIdentityProvider) Builder(ca, bindPassword string) *clustersmgmtv1.IdentityProviderBuilder {
@@ -136,6 +415,14 @@ func (ldap *LDAPIdentityProvider) Builder(ca, bindPassword string) *clustersmgmt
 		Name(ldap.Spec.DisplayName).
 		Type(clustersmgmtv1.IdentityProviderTypeLDAP)
 
+	if ldap.Spec.Challenge != nil {
+		builder.Challenge(*ldap.Spec.Challenge)
+	}
+
+	if ldap.Spec.Login != nil {
+		builder.Login(*ldap.Spec.Login)
+	}
+
 	return builder.LDAP(
 		clustersmgmtv1.NewLDAPIdentityProvider().
 			URL(ldap.Spec.URL).
@@ -152,6 +439,18 @@ func (ldap *LDAPIdentityProvider) Builder(ca, bindPassword string) *clustersmgmt
 	)
 }
 
+// EffectiveAttributes returns the attributes of the identity provider, with each of the attributes
+// which were not requested defaulted in the same way as OpenShift, so that they may be compared
+// against the normalized attributes which are returned from OpenShift Cluster Manager.
+func (ldap *LDAPIdentityProvider) EffectiveAttributes() configv1.LDAPAttributeMapping {
+	return LDAPAttributesToOpenShift(
+		ldap.Spec.Attributes.ID,
+		ldap.Spec.Attributes.Name,
+		ldap.Spec.Attributes.Email,
+		ldap.Spec.Attributes.PreferredUsername,
+	)
+}
+
 // LDAPAttributesToOpenShift copies fields from an OCM LDAP object into an OpenShift object.
 func LDAPAttributesToOpenShift(id, name, email, username []string) configv1.LDAPAttributeMapping {
 	return configv1.LDAPAttributeMapping{
@@ -171,13 +470,29 @@ func LDAPAttributesToOCM(id, name, email, username []string) *clustersmgmtv1.LDA
 		PreferredUsername(getLDAPAttributes(username, ocm.DefaultAttributeUsername)...)
 }
 
-// getLDAPAttributes will return the attributes with a default if attributes are not provided.
+// getLDAPAttributes will return the attributes with a default if attributes are not provided.  The
+// attributes are normalized in the same way as OpenShift Cluster Manager, with surrounding whitespace,
+// empty attributes and repeated attributes removed, while preserving the order in which they are
+// tried.
 func getLDAPAttributes(attributes []string, def string) []string {
-	if len(attributes) == 0 {
+	normalized := []string{}
+	seen := map[string]bool{}
+
+	for _, attribute := range attributes {
+		attribute = strings.TrimSpace(attribute)
+		if attribute == "" || seen[attribute] {
+			continue
+		}
+
+		seen[attribute] = true
+		normalized = append(normalized, attribute)
+	}
+
+	if len(normalized) == 0 {
 		return []string{def}
 	}
 
-	return attributes
+	return normalized
 }
 
 func init() {
@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func TestLDAPIdentityProvider_EffectiveAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		attributes configv1.LDAPAttributeMapping
+		want       configv1.LDAPAttributeMapping
+	}{
+		{
+			name: "ensure omitted attributes are defaulted",
+			want: configv1.LDAPAttributeMapping{
+				ID:                []string{"dn"},
+				PreferredUsername: []string{"uid"},
+				Name:              []string{"cn"},
+				Email:             []string{"mail"},
+			},
+		},
+		{
+			name: "ensure requested attributes are normalized in order",
+			attributes: configv1.LDAPAttributeMapping{
+				ID:                []string{" uid ", "dn", "uid"},
+				PreferredUsername: []string{"", " "},
+				Name:              []string{"displayName", "cn"},
+				Email:             []string{"userPrincipalName"},
+			},
+			want: configv1.LDAPAttributeMapping{
+				ID:                []string{"uid", "dn"},
+				PreferredUsername: []string{"uid"},
+				Name:              []string{"displayName", "cn"},
+				Email:             []string{"userPrincipalName"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ldap := &LDAPIdentityProvider{}
+			ldap.Spec.Attributes = tt.attributes
+
+			if got := ldap.EffectiveAttributes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EffectiveAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
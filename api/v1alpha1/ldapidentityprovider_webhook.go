@@ -0,0 +1,285 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net/url"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	ldapSchemeInsecure = "ldap"
+	ldapSchemeSecure   = "ldaps"
+
+	maximumPort = 65535
+)
+
+// SetupWebhookWithManager sets up the defaulting, validating, collision and warning webhooks for the LDAPIdentityProvider with the Manager.
+// The identity providers of a cluster share their names regardless of their type, so an LDAPIdentityProvider
+// also collides with a GitLabIdentityProvider.  An LDAPIdentityProvider which does not set its ocmEnvironment
+// is checked for collisions in the default environment.
+func (ldap *LDAPIdentityProvider) SetupWebhookWithManager(mgr ctrl.Manager, defaultEnvironment string) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(ldap).Complete(); err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+
+	if err := setupCollisionWebhookWithManager(
+		mgr,
+		ldap,
+		defaultEnvironment,
+		&LDAPIdentityProviderList{},
+		&GitLabIdentityProviderList{},
+	); err != nil {
+		return err
+	}
+
+	return setupWarningWebhookWithManager(mgr, ldap)
+}
+
+//+kubebuilder:webhook:path=/collide-ocm-mobb-redhat-com-v1alpha1-ldapidentityprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=ldapidentityproviders,verbs=create,versions=v1alpha1,name=cldapidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ Collider = &LDAPIdentityProvider{}
+
+//+kubebuilder:webhook:path=/mutate-ocm-mobb-redhat-com-v1alpha1-ldapidentityprovider,mutating=true,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=ldapidentityproviders,verbs=create,versions=v1alpha1,name=mldapidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &LDAPIdentityProvider{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.  The spec.displayName
+// field defaults to the metadata.name field on creation, so that the name of the object in OpenShift
+// Cluster Manager is visible in the spec.  Existing objects are not defaulted, as the spec.displayName
+// field may not be changed once it has been set.
+func (ldap *LDAPIdentityProvider) Default() {
+	if ldap.Spec.DisplayName == "" {
+		ldap.Spec.DisplayName = ldap.GetName()
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-ocm-mobb-redhat-com-v1alpha1-ldapidentityprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=ldapidentityproviders;ldapidentityproviders/status,verbs=create;update,versions=v1alpha1,name=vldapidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &LDAPIdentityProvider{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.  The name
+// of the LDAPIdentityProvider in OpenShift Cluster Manager is only validated on creation.
+func (ldap *LDAPIdentityProvider) ValidateCreate() error {
+	errs := validateDisplayName(ldap.Spec.DisplayName, ldap.Name, identityProviderNamePattern, reservedIdentityProviderName)
+
+	return invalid("LDAPIdentityProvider", ldap.Name, append(errs, ldap.validateSpec()...))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.  An
+// unchanged spec is not validated again, so that objects which were admitted before a validation
+// rule was introduced may still be updated, for example by the controller to update their status.
+func (ldap *LDAPIdentityProvider) ValidateUpdate(old runtime.Object) error {
+	previous, err := convertOld[*LDAPIdentityProvider](old)
+	if err != nil {
+		return err
+	}
+
+	errs := ldap.validateImmutable(previous)
+
+	if !equality.Semantic.DeepEqual(ldap.Spec, previous.Spec) {
+		errs = append(errs, ldap.validateSpec()...)
+	}
+
+	return invalid("LDAPIdentityProvider", ldap.Name, errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.  Deletion
+// is not validated.
+func (ldap *LDAPIdentityProvider) ValidateDelete() error {
+	return nil
+}
+
+//+kubebuilder:webhook:path=/warn-ocm-mobb-redhat-com-v1alpha1-ldapidentityprovider,mutating=false,failurePolicy=ignore,sideEffects=None,groups=ocm.mobb.redhat.com,resources=ldapidentityproviders,verbs=create;update,versions=v1alpha1,name=wldapidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ Warner = &LDAPIdentityProvider{}
+
+// Warnings implements Warner so a warning webhook will be registered for the type.  An insecure
+// connection to the LDAP server is valid, but exposes the credentials of users to anyone who is able
+// to observe the traffic to the LDAP server.
+func (ldap *LDAPIdentityProvider) Warnings() []string {
+	warnings := []string{}
+
+	if ldap.Spec.Insecure {
+		warnings = append(warnings, "spec.insecure is true, so the passwords of users are sent to the "+
+			"LDAP server without TLS")
+	}
+
+	return warnings
+}
+
+// validate validates the LDAPIdentityProvider against the same rules that OpenShift Cluster Manager
+// enforces server-side, so that an invalid configuration is rejected at admission rather than
+// during reconciliation.
+func (ldap *LDAPIdentityProvider) validate() error {
+	return invalid("LDAPIdentityProvider", ldap.Name, ldap.validateSpec())
+}
+
+// validateSpec returns the field errors of the spec of the LDAPIdentityProvider.
+func (ldap *LDAPIdentityProvider) validateSpec() field.ErrorList {
+	spec := field.NewPath("spec")
+
+	errs := ldap.validateURL(spec.Child("url"))
+	errs = append(errs, ldap.validateBind(spec)...)
+	errs = append(errs, ldap.validateAttributes(spec.Child("attributes"))...)
+	errs = append(errs, ldap.validateReferences(spec)...)
+
+	return errs
+}
+
+// validateImmutable returns the field errors of the fields of the LDAPIdentityProvider which may not
+// be changed once they have been set.
+func (ldap *LDAPIdentityProvider) validateImmutable(old *LDAPIdentityProvider) field.ErrorList {
+	spec, status := field.NewPath("spec"), field.NewPath("status")
+
+	return validateImmutable(
+		immutableField{path: spec.Child("clusterName"), oldValue: old.Spec.ClusterName, newValue: ldap.Spec.ClusterName},
+		immutableField{path: spec.Child("clusterReference"), oldValue: old.Spec.ClusterReference, newValue: ldap.Spec.ClusterReference},
+		immutableField{path: spec.Child("ocmEnvironment"), oldValue: old.Spec.OCMEnvironment, newValue: ldap.Spec.OCMEnvironment},
+		immutableField{path: spec.Child("displayName"), oldValue: old.Spec.DisplayName, newValue: ldap.Spec.DisplayName},
+		immutableField{path: status.Child("clusterID"), oldValue: old.Status.ClusterID, newValue: ldap.Status.ClusterID, onceSet: true},
+	)
+}
+
+// validateURL validates the scheme, host and port of the LDAP URL along with its consistency
+// with the insecure and ca fields.
+func (ldap *LDAPIdentityProvider) validateURL(path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if ldap.Spec.URL == "" {
+		return append(errs, field.Required(path, "url is required"))
+	}
+
+	parsed, err := url.Parse(ldap.Spec.URL)
+	if err != nil {
+		return append(errs, field.Invalid(path, ldap.Spec.URL, err.Error()))
+	}
+
+	switch parsed.Scheme {
+	case ldapSchemeInsecure:
+	case ldapSchemeSecure:
+		if ldap.Spec.Insecure {
+			errs = append(errs, field.Invalid(
+				field.NewPath("spec", "insecure"),
+				ldap.Spec.Insecure,
+				"insecure must be false when using the ldaps:// scheme",
+			))
+		}
+	default:
+		errs = append(errs, field.Invalid(path, ldap.Spec.URL, "url must use the ldap:// or ldaps:// scheme"))
+	}
+
+	if parsed.Hostname() == "" {
+		errs = append(errs, field.Invalid(path, ldap.Spec.URL, "url must specify a host"))
+	}
+
+	if port := parsed.Port(); port != "" {
+		if number, err := strconv.Atoi(port); err != nil || number < 1 || number > maximumPort {
+			errs = append(errs, field.Invalid(path, ldap.Spec.URL, "url must specify a port between 1 and 65535"))
+		}
+	}
+
+	if ldap.Spec.Insecure && ldap.Spec.CA.Name != "" {
+		errs = append(errs, field.Invalid(
+			field.NewPath("spec", "ca", "name"),
+			ldap.Spec.CA.Name,
+			"ca must not be specified when insecure is true",
+		))
+	}
+
+	return errs
+}
+
+// validateBind validates that a bind password is provided when a bind dn is provided, and
+// vice versa.
+func (ldap *LDAPIdentityProvider) validateBind(path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if ldap.Spec.BindDN != "" && ldap.Spec.BindPassword.Name == "" {
+		errs = append(errs, field.Required(
+			path.Child("bindPassword", "name"),
+			"bindPassword must be specified when bindDN is specified",
+		))
+	}
+
+	if ldap.Spec.BindDN == "" && ldap.Spec.BindPassword.Name != "" {
+		errs = append(errs, field.Required(
+			path.Child("bindDN"),
+			"bindDN must be specified when bindPassword is specified",
+		))
+	}
+
+	return errs
+}
+
+// validateAttributes validates the attribute mappings.  Empty attribute mappings are allowed as
+// defaults are used in their place, however no mapping may contain an empty attribute.
+func (ldap *LDAPIdentityProvider) validateAttributes(path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for _, mapping := range []struct {
+		name       string
+		attributes []string
+	}{
+		{name: "id", attributes: ldap.Spec.Attributes.ID},
+		{name: "preferredUsername", attributes: ldap.Spec.Attributes.PreferredUsername},
+		{name: "name", attributes: ldap.Spec.Attributes.Name},
+		{name: "email", attributes: ldap.Spec.Attributes.Email},
+	} {
+		for i := range mapping.attributes {
+			if mapping.attributes[i] == "" {
+				errs = append(errs, field.Invalid(path.Child(mapping.name).Index(i), mapping.attributes[i], "attribute must not be empty"))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateReferences validates the kind and keys of the objects which contain the bind password
+// and ca data.
+func (ldap *LDAPIdentityProvider) validateReferences(path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for _, msg := range validation.IsConfigMapKey(ldap.GetBindPasswordKey()) {
+		errs = append(errs, field.Invalid(path.Child("bindPasswordKey"), ldap.Spec.BindPasswordKey, msg))
+	}
+
+	for _, msg := range validation.IsConfigMapKey(ldap.GetCAKey()) {
+		errs = append(errs, field.Invalid(path.Child("caKey"), ldap.Spec.CAKey, msg))
+	}
+
+	switch ldap.GetCAKind() {
+	case LDAPCAKindConfigMap, LDAPCAKindSecret:
+	default:
+		errs = append(errs, field.NotSupported(
+			path.Child("caKind"),
+			ldap.Spec.CAKind,
+			[]string{LDAPCAKindConfigMap, LDAPCAKindSecret},
+		))
+	}
+
+	return errs
+}
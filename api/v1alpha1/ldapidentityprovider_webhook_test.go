@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func TestLDAPIdentityProvider_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider configv1.LDAPIdentityProvider
+		spec     LDAPIdentityProviderSpec
+		wantErr  bool
+	}{
+		{
+			name:     "ensure a valid ldaps url passes",
+			provider: configv1.LDAPIdentityProvider{URL: "ldaps://ldap.example.com:636/ou=users,dc=example,dc=com?uid"},
+			wantErr:  false,
+		},
+		{
+			name:     "ensure a valid insecure ldap url passes",
+			provider: configv1.LDAPIdentityProvider{URL: "ldap://ldap.example.com/ou=users,dc=example,dc=com", Insecure: true},
+			wantErr:  false,
+		},
+		{
+			name:     "ensure a missing url fails",
+			provider: configv1.LDAPIdentityProvider{},
+			wantErr:  true,
+		},
+		{
+			name:     "ensure an unsupported scheme fails",
+			provider: configv1.LDAPIdentityProvider{URL: "https://ldap.example.com"},
+			wantErr:  true,
+		},
+		{
+			name:     "ensure a missing host fails",
+			provider: configv1.LDAPIdentityProvider{URL: "ldap:///ou=users,dc=example,dc=com"},
+			wantErr:  true,
+		},
+		{
+			name:     "ensure an invalid port fails",
+			provider: configv1.LDAPIdentityProvider{URL: "ldap://ldap.example.com:70000"},
+			wantErr:  true,
+		},
+		{
+			name:     "ensure insecure with the ldaps scheme fails",
+			provider: configv1.LDAPIdentityProvider{URL: "ldaps://ldap.example.com", Insecure: true},
+			wantErr:  true,
+		},
+		{
+			name: "ensure insecure with a ca fails",
+			provider: configv1.LDAPIdentityProvider{
+				URL:      "ldap://ldap.example.com",
+				Insecure: true,
+				CA:       configv1.ConfigMapNameReference{Name: "ldap-ca"},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "ensure a bind dn without a bind password fails",
+			provider: configv1.LDAPIdentityProvider{URL: "ldap://ldap.example.com", BindDN: "cn=admin"},
+			wantErr:  true,
+		},
+		{
+			name: "ensure an empty attribute fails",
+			provider: configv1.LDAPIdentityProvider{
+				URL:        "ldap://ldap.example.com",
+				Attributes: configv1.LDAPAttributeMapping{ID: []string{"dn", ""}},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "ensure a ca from a secret with a custom key passes",
+			provider: configv1.LDAPIdentityProvider{URL: "ldaps://ldap.example.com"},
+			spec:     LDAPIdentityProviderSpec{CAKind: LDAPCAKindSecret, CAKey: "tls.crt", BindPasswordKey: "password"},
+			wantErr:  false,
+		},
+		{
+			name:     "ensure an invalid key fails",
+			provider: configv1.LDAPIdentityProvider{URL: "ldaps://ldap.example.com"},
+			spec:     LDAPIdentityProviderSpec{BindPasswordKey: "bind/password"},
+			wantErr:  true,
+		},
+		{
+			name:     "ensure an unsupported ca kind fails",
+			provider: configv1.LDAPIdentityProvider{URL: "ldaps://ldap.example.com"},
+			spec:     LDAPIdentityProviderSpec{CAKind: "Pod"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ldap := &LDAPIdentityProvider{Spec: tt.spec}
+			ldap.Spec.LDAPIdentityProvider = tt.provider
+			if err := ldap.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("LDAPIdentityProvider.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
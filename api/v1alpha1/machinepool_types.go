@@ -17,16 +17,30 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"time"
+
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/schedule"
 )
 
+// ocmTaintEffects maps the effect of a taint to the effect which is sent to OpenShift Cluster Manager.
+// Taints with any other effect are rejected at admission.
+var ocmTaintEffects = map[corev1.TaintEffect]string{
+	corev1.TaintEffectNoSchedule:       "NoSchedule",
+	corev1.TaintEffectPreferNoSchedule: "PreferNoSchedule",
+	corev1.TaintEffectNoExecute:        "NoExecute",
+}
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// +kubebuilder:validation:XValidation:message="exactly one of clusterName or clusterReference must be set",rule=(has(self.clusterName) != has(self.clusterReference))
 // +kubebuilder:validation:XValidation:message="maximumNodesPerZone must be greater than or equal to minimumNodesPerZone",rule=(self.maximumNodesPerZone == 0 || self.minimumNodesPerZone <= self.maximumNodesPerZone)
 // MachinePoolSpec defines the desired state of MachinePool.
 //
@@ -35,7 +49,7 @@ type MachinePoolSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:message="clusterName is immutable",rule=(self == oldSelf)
 	// Cluster ID in OpenShift Cluster Manager by which this should be managed for.  The cluster ID
 	// can be obtained on the Clusters page for the individual cluster.  It may also be known as the
@@ -43,6 +57,16 @@ type MachinePoolSpec struct {
 	// where the 'x' represents any alphanumeric character.
 	ClusterName string `json:"clusterName,omitempty"`
 
+	LocalClusterReference `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=production;stage;integration
+	// +kubebuilder:validation:XValidation:message="ocmEnvironment is immutable",rule=(self == oldSelf)
+	// Environment of OpenShift Cluster Manager in which the cluster is managed.  The operator must be
+	// configured with a connection to the environment.  If this is empty, the default environment of
+	// the operator is used.
+	OCMEnvironment string `json:"ocmEnvironment,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=4
 	// +kubebuilder:validation:MaxLength=15
@@ -87,11 +111,107 @@ type MachinePoolSpec struct {
 	// +kubebuilder:validation:Optional
 	// Taints that should be applied to this machine pool.  For information please see
 	// https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/.
+	// Each taint must have a valid key and value, an effect of NoSchedule,
+	// PreferNoSchedule or NoExecute, and may only use each key once per effect.
 	Taints []corev1.Taint `json:"taints,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// Represents the AWS provider specific configuration options.
 	AWS MachinePoolProviderAWS `json:"aws,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+\.[0-9]+$`
+	// OpenShift version (e.g. '4.14.3') of the nodes within this MachinePool.  This allows
+	// the node pool to be upgraded independently of the control plane.  Only z-stream
+	// upgrades, which remain within the current minor version, are supported.  The version
+	// may not be newer than the control plane, nor more than 2 minor versions older.  If
+	// unset, the node pool is created with the version of the control plane and is not
+	// upgraded.  This field is only valid if the cluster is using hosted control plane and
+	// is ignored otherwise.
+	Version string `json:"version,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Whether nodes within this MachinePool which become unhealthy are automatically
+	// repaired (replaced).  If auto-repair is changed outside of this operator, it is
+	// reported as drift and restored.  This field is only valid if the cluster is
+	// using hosted control plane and is ignored otherwise.
+	AutoRepair *bool `json:"autoRepair,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Schedules which override the minimumNodesPerZone and maximumNodesPerZone fields
+	// while active (e.g. to scale to 0 nodes during nights or weekends).  If multiple
+	// schedules are active at the same time, the first active schedule in the list is used.
+	Schedules []MachinePoolSchedule `json:"schedules,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// Raw fields which are merged, as a json merge patch, into the body of the requests which
+	// create and update the machine pool in OpenShift Cluster Manager, so that fields which are
+	// not yet modeled by this operator may be set (e.g. aws.additional_security_group_ids).
+	// The fields use the names of the OpenShift Cluster Manager API.  The kind, id and href fields,
+	// and the fields which are modeled by this spec (e.g. instance_type or aws.spot_market_options),
+	// may not be set.  Raw overrides are only applied if they are enabled in the operator and are
+	// ignored otherwise.
+	RawOverrides *apiextensionsv1.JSON `json:"rawOverrides,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:message="maximumNodesPerZone must be greater than or equal to minimumNodesPerZone",rule=(!has(self.maximumNodesPerZone) || !has(self.minimumNodesPerZone) || self.minimumNodesPerZone <= self.maximumNodesPerZone)
+// MachinePoolSchedule represents a recurring window of time in which the node counts of
+// a MachinePool are overridden.
+//
+//nolint:lll
+type MachinePoolSchedule struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Name of the schedule.  The name of the currently active schedule is stored
+	// in status.activeSchedule.
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// Standard cron expression (e.g. '0 20 * * 1-5') which determines when this
+	// schedule becomes active.
+	Start string `json:"start,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// Amount of time (e.g. '12h') that the schedule remains active after the time
+	// determined by the start field.
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IANA time zone (e.g. 'America/New_York') in which the start field is evaluated.  If
+	// unset, the start field is evaluated in UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Minimum amount of nodes allowed per availability zone while this schedule is
+	// active.  Overrides spec.minimumNodesPerZone.
+	MinimumNodesPerZone int `json:"minimumNodesPerZone,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Maximum amount of nodes allowed per availability zone while this schedule is
+	// active.  Overrides spec.maximumNodesPerZone.  If this field is set, autoscaling
+	// will be enabled for this machine pool while this schedule is active.
+	MaximumNodesPerZone int `json:"maximumNodesPerZone,omitempty"`
+}
+
+// MachinePoolUpgradeStatus represents the progress of an upgrade of a node pool.
+type MachinePoolUpgradeStatus struct {
+	// Represents the version which the node pool is being upgraded to.
+	Version string `json:"version,omitempty"`
+
+	// Represents the state of the upgrade as reported by OpenShift Cluster Manager
+	// (e.g. scheduled, started).
+	State string `json:"state,omitempty"`
+
+	// Represents the description of the state of the upgrade as reported by
+	// OpenShift Cluster Manager.
+	Description string `json:"description,omitempty"`
+
+	// Represents the time at which the upgrade is scheduled to start.
+	NextRun *metav1.Time `json:"nextRun,omitempty"`
 }
 
 // MachinePoolProviderAWS represents the provider specific configuration for an AWS provider.
@@ -101,6 +221,24 @@ type MachinePoolProviderAWS struct {
 	// is not valid and is ignored if the cluster is using hosted
 	// control plane.
 	SpotInstances MachinePoolProviderAWSSpotInstances `json:"spotInstances,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^subnet-[0-9a-f]+$`
+	// +kubebuilder:validation:XValidation:message="aws.subnet is immutable",rule=(self == oldSelf)
+	// ID of an existing subnet (e.g. 'subnet-0123456789abcdef0') in which the nodes of this
+	// MachinePool are provisioned.  This allows the machine pool to be placed in an AWS Local
+	// Zone or on an AWS Outpost which is associated with the VPC of the cluster.  The nodes are
+	// provisioned in the single availability zone of the subnet.  This field is only valid if the
+	// cluster was installed into an existing VPC and is not using hosted control plane.
+	Subnet string `json:"subnet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:message="aws.tags cannot use the reserved aws:, red-hat-, kubernetes.io/cluster/ or sigs.k8s.io/cluster-api-provider-aws/ prefixes",rule=self.all(key, !key.startsWith('aws:') && !key.startsWith('red-hat-') && !key.startsWith('kubernetes.io/cluster/') && !key.startsWith('sigs.k8s.io/cluster-api-provider-aws/'))
+	// User-defined AWS tags to apply to the instances of this MachinePool.  Tags
+	// cannot use the reserved 'aws:', 'red-hat-', 'kubernetes.io/cluster/' or
+	// 'sigs.k8s.io/cluster-api-provider-aws/' prefixes.  AWS tags are only supported
+	// if the cluster is using hosted control plane and are ignored otherwise.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // MachinePoolProviderAWSSpotInstances represents the AWS Spot Intance configuration.
@@ -123,6 +261,38 @@ type MachinePoolProviderAWSSpotInstances struct {
 type MachinePoolStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Represents a bounded history of the conditions which have been replaced
+	// on this resource, ordered from oldest to newest.
+	ConditionHistory []metav1.Condition `json:"conditionHistory,omitempty"`
+
+	// Represents a bounded history of the operations which have been sent to OpenShift
+	// Cluster Manager for this resource, ordered from oldest to newest.
+	OperationHistory []OCMOperation `json:"operationHistory,omitempty"`
+
+	// Represents the duration of the most recent reconciliation of this resource, broken
+	// down by reconciliation phase, so that slow phases are visible without metrics or logs.
+	LastReconcile *ReconcileTiming `json:"lastReconcile,omitempty"`
+
+	// Represents the number of reconciliations of this resource which failed within each of the most
+	// recent hours, ordered from oldest to newest.  Failures are counted independently of the condition
+	// history, which only keeps a limited number of conditions.
+	ReconcileFailures []ReconcileFailureCount `json:"reconcileFailures,omitempty"`
+
+	// Represents the generation of the resource which was last applied to OpenShift
+	// Cluster Manager.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents a hash of the inputs, such as the desired spec and the versions of
+	// referenced secrets, which were last applied to OpenShift Cluster Manager.
+	// Reading OpenShift Cluster Manager is skipped while neither the generation nor
+	// the inputs have changed, until the drift interval has elapsed since the
+	// inputs were last applied.
+	InputHash string `json:"inputHash,omitempty"`
+
+	// Represents the time at which the inputs were last applied, after the state in
+	// OpenShift Cluster Manager was read and brought in line with them.
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
 	// +kubebuilder:validation:XValidation:message="status.clusterID is immutable",rule=(self == oldSelf)
 	// Represents the programmatic cluster ID of the cluster, as
 	// determined during reconciliation.  This is used to reduce
@@ -142,11 +312,80 @@ type MachinePoolStatus struct {
 	// +kubebuilder:validation:XValidation:message="status.Hosted is immutable",rule=(self == oldSelf)
 	// Whether this cluster is using a hosted control plane.
 	Hosted bool `json:"hosted,omitempty"`
+
+	// Represents the region of the cloud provider where the cluster is provisioned,
+	// as determined during reconciliation.
+	Region string `json:"region,omitempty"`
+
+	// Represents the cloud provider where the cluster is provisioned, as determined
+	// during reconciliation.
+	CloudProvider string `json:"cloudProvider,omitempty"`
+
+	// Represents the product of the cluster in OpenShift Cluster Manager, such as
+	// rosa or osd, as determined during reconciliation.
+	Product string `json:"product,omitempty"`
+
+	// Represents the name of the schedule from spec.schedules which is currently
+	// overriding the node counts of this machine pool.  Empty if no schedule is active.
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+
+	// Represents the number of nodes which were last observed in the cluster
+	// for this machine pool.
+	Replicas int `json:"replicas,omitempty"`
+
+	// Represents the number of nodes which were last observed in the cluster
+	// for this machine pool and are in a ready state.
+	ReadyReplicas int `json:"readyReplicas,omitempty"`
+
+	// Whether all nodes for this machine pool were last observed in a ready state.
+	Ready bool `json:"ready,omitempty"`
+
+	// Represents the ID of the machine pool, or node pool for hosted control plane
+	// clusters, in OpenShift Cluster Manager.
+	MachinePoolID string `json:"machinePoolID,omitempty"`
+
+	// Represents a hash of the raw overrides which were last applied to OpenShift
+	// Cluster Manager.
+	RawOverridesHash string `json:"rawOverridesHash,omitempty"`
+
+	// Represents the number of nodes which were last reported by OpenShift Cluster
+	// Manager for this machine pool.  Only reported for hosted control plane clusters.
+	OCMReplicas int `json:"ocmReplicas,omitempty"`
+
+	// Represents the last message reported by OpenShift Cluster Manager for this
+	// machine pool, such as the reason that nodes are not being provisioned.  Only
+	// reported for hosted control plane clusters.
+	OCMMessage string `json:"ocmMessage,omitempty"`
+
+	// Represents the OpenShift version of the nodes within this machine pool as last
+	// reported by OpenShift Cluster Manager.  Only reported for hosted control plane
+	// clusters.
+	Version string `json:"version,omitempty"`
+
+	// Represents the progress of the upgrade of this machine pool to the version
+	// requested by spec.version.  Empty if no upgrade is in progress.  Only reported
+	// for hosted control plane clusters.
+	Upgrade *MachinePoolUpgradeStatus `json:"upgrade,omitempty"`
+
+	// Represents the reasons that the upgrade of this machine pool to the version
+	// requested by spec.version is unable to proceed, such as the cluster not being
+	// ready or another upgrade already being scheduled.  Empty if the upgrade is not
+	// blocked.  Only reported for hosted control plane clusters.
+	BlockedReasons []string `json:"blockedReasons,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:validation:XValidation:message="metadata.name limited to 15 characters",rule=(self.metadata.name.size() <= 15)
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+//+kubebuilder:printcolumn:name="Instance Type",type=string,JSONPath=`.spec.instanceType`
+//+kubebuilder:printcolumn:name="Min",type=integer,JSONPath=`.spec.minimumNodesPerZone`,description="Minimum nodes per availability zone"
+//+kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maximumNodesPerZone`,description="Maximum nodes per availability zone"
+//+kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+//+kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`,priority=1
+//+kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.ocmMessage`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // MachinePool is the Schema for the machinepools API.
 type MachinePool struct {
@@ -183,9 +422,60 @@ func (machinePool *MachinePool) DesiredState() *MachinePool {
 	// that the current state should have these labels.
 	desiredState.SetMachinePoolLabels()
 
+	// schedules only exist on the object within the cluster and are applied to the
+	// desired state by the controller.  we remove them here so that they are not
+	// compared against the current state.
+	desiredState.Spec.Schedules = nil
+
+	// the version is applied by the controller as an upgrade of the node pool rather than
+	// being compared against the current state, so we remove it here as well.
+	desiredState.Spec.Version = ""
+
 	return desiredState
 }
 
+// ActiveSchedule returns the first schedule from the spec.schedules field which is active at a
+// given point in time, along with the next point in time in which the active schedule may
+// change.  A nil schedule is returned if no schedules are active.
+func (machinePool *MachinePool) ActiveSchedule(at time.Time) (*MachinePoolSchedule, time.Time, error) {
+	var active *MachinePoolSchedule
+
+	var next time.Time
+
+	for i := range machinePool.Spec.Schedules {
+		parsed, err := schedule.Parse(
+			machinePool.Spec.Schedules[i].Start,
+			machinePool.Spec.Schedules[i].TimeZone,
+			machinePool.Spec.Schedules[i].Duration.Duration,
+		)
+		if err != nil {
+			return nil, next, fmt.Errorf("invalid schedule [%s] - %w", machinePool.Spec.Schedules[i].Name, err)
+		}
+
+		// keep track of the earliest transition across all schedules so that the caller
+		// knows when to evaluate the schedules again
+		if transition := parsed.NextTransition(at); next.IsZero() || transition.Before(next) {
+			next = transition
+		}
+
+		if active == nil && parsed.Active(at) {
+			active = &machinePool.Spec.Schedules[i]
+		}
+	}
+
+	return active, next, nil
+}
+
+// ApplySchedule overrides the node counts of the object with the node counts of the schedule.
+func (machinePool *MachinePool) ApplySchedule(active *MachinePoolSchedule) {
+	if active == nil {
+		return
+	}
+
+	machinePool.Spec.MinimumNodesPerZone = active.MinimumNodesPerZone
+	machinePool.Spec.MaximumNodesPerZone = active.MaximumNodesPerZone
+}
+
 // GetConditions returns the status.conditions field from the object.  It is used to
 // satisfy the Workload interface.
 func (machinePool *MachinePool) GetConditions() []metav1.Condition {
@@ -198,14 +488,119 @@ func (machinePool *MachinePool) SetConditions(conditions []metav1.Condition) {
 	machinePool.Status.Conditions = conditions
 }
 
+// GetClusterName returns the spec.clusterName field from the object.  It is used to satisfy the
+// ClusterWorkload interface.
+func (machinePool *MachinePool) GetClusterName() string {
+	return machinePool.Spec.ClusterName
+}
+
+// GetOCMEnvironment returns the spec.ocmEnvironment field from the object.  It is used to satisfy the
+// EnvironmentWorkload interface.
+func (machinePool *MachinePool) GetOCMEnvironment() string {
+	return machinePool.Spec.OCMEnvironment
+}
+
+// GetClusterReferenceBinding returns the reference of the object to a cluster reference, bound to the
+// fields of the object which are resolved from the cluster reference.  It is used to satisfy the
+// ClusterReferencer interface.
+func (machinePool *MachinePool) GetClusterReferenceBinding() ClusterReferenceBinding {
+	return ClusterReferenceBinding{
+		LocalClusterReference: &machinePool.Spec.LocalClusterReference,
+		ClusterName:           &machinePool.Spec.ClusterName,
+		OCMEnvironment:        &machinePool.Spec.OCMEnvironment,
+		ClusterID:             &machinePool.Status.ClusterID,
+		Hosted:                &machinePool.Status.Hosted,
+	}
+}
+
+// GetConditionHistory returns the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (machinePool *MachinePool) GetConditionHistory() []metav1.Condition {
+	return machinePool.Status.ConditionHistory
+}
+
+// SetConditionHistory sets the status.conditionHistory field from the object.  It is used to
+// satisfy the HistoryWorkload interface.
+func (machinePool *MachinePool) SetConditionHistory(history []metav1.Condition) {
+	machinePool.Status.ConditionHistory = history
+}
+
+// GetOperationHistory returns the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (machinePool *MachinePool) GetOperationHistory() []OCMOperation {
+	return machinePool.Status.OperationHistory
+}
+
+// SetOperationHistory sets the status.operationHistory field from the object.  It is used to
+// satisfy the OperationWorkload interface.
+func (machinePool *MachinePool) SetOperationHistory(history []OCMOperation) {
+	machinePool.Status.OperationHistory = history
+}
+
+// GetLastReconcile returns the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (machinePool *MachinePool) GetLastReconcile() *ReconcileTiming {
+	return machinePool.Status.LastReconcile
+}
+
+// SetLastReconcile sets the status.lastReconcile field from the object.  It is used to
+// satisfy the TimedWorkload interface.
+func (machinePool *MachinePool) SetLastReconcile(timing *ReconcileTiming) {
+	machinePool.Status.LastReconcile = timing
+}
+
+// GetReconcileFailures returns the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (machinePool *MachinePool) GetReconcileFailures() []ReconcileFailureCount {
+	return machinePool.Status.ReconcileFailures
+}
+
+// SetReconcileFailures sets the status.reconcileFailures field from the object.  It is used to
+// satisfy the FailureCountWorkload interface.
+func (machinePool *MachinePool) SetReconcileFailures(failures []ReconcileFailureCount) {
+	machinePool.Status.ReconcileFailures = failures
+}
+
+// GetObservedGeneration returns the generation which was last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (machinePool *MachinePool) GetObservedGeneration() int64 {
+	return machinePool.Status.ObservedGeneration
+}
+
+// SetObservedGeneration sets the generation which was last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (machinePool *MachinePool) SetObservedGeneration(generation int64) {
+	machinePool.Status.ObservedGeneration = generation
+}
+
+// GetInputHash returns the hash of the inputs which were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (machinePool *MachinePool) GetInputHash() string {
+	return machinePool.Status.InputHash
+}
+
+// SetInputHash sets the hash of the inputs which were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (machinePool *MachinePool) SetInputHash(hash string) {
+	machinePool.Status.InputHash = hash
+}
+
+// GetLastAppliedTime returns the time at which the inputs were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (machinePool *MachinePool) GetLastAppliedTime() *metav1.Time {
+	return machinePool.Status.LastAppliedTime
+}
+
+// SetLastAppliedTime sets the time at which the inputs were last applied.  It is used to satisfy
+// the AppliedWorkload interface.
+func (machinePool *MachinePool) SetLastAppliedTime(at *metav1.Time) {
+	machinePool.Status.LastAppliedTime = at
+}
+
 // GetDisplayName returns the name for the OCM MachinePool.  It defaults to wanting to use
 // the spec.displayName field but returns the metadata.name field if unset.
 func (machinePool *MachinePool) GetDisplayName() string {
-	if machinePool.Spec.DisplayName == "" {
-		return machinePool.GetName()
-	}
-
-	return machinePool.Spec.DisplayName
+	return defaultDisplayName(machinePool.Spec.DisplayName, machinePool.GetName())
 }
 
 // SetMachinePoolLabels sets the required labels on the object.
@@ -237,6 +632,25 @@ func (machinePool *MachinePool) HasManagedLabels() bool {
 	return true
 }
 
+// IsDefault determines if the MachinePool object manages the default machine pool of a cluster which is
+// not using hosted control plane.  The default machine pool is created along with the cluster, rather
+// than by this controller, and may not be deleted.  Whether the cluster is using hosted control plane is
+// read from the status, so the result is only accurate once the cluster has been recorded in it.
+func (machinePool *MachinePool) IsDefault() bool {
+	return !machinePool.Status.Hosted && machinePool.Spec.DisplayName == ocm.DefaultMachinePoolName
+}
+
+// HasReservedAWSTags determines if the spec.aws.tags field contains any tags with a reserved prefix.
+func (machinePool *MachinePool) HasReservedAWSTags() bool {
+	for key := range machinePool.Spec.AWS.Tags {
+		if ocm.IsReservedAWSTag(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // CopyFromMachinePool copies an OCM MachinePool object into a MachinePool object that is recognizable by this
 // controller.
 func (machinePool *MachinePool) CopyFromMachinePool(source *clustersmgmtv1.MachinePool, clusterName string) error {
@@ -262,13 +676,93 @@ func (machinePool *MachinePool) CopyFromNodePool(source *clustersmgmtv1.NodePool
 	machinePool.Spec.Taints = copyTaints(source.Taints())
 	machinePool.Spec.MinimumNodesPerZone = copyNodePoolMinimumNodesPerZone(source)
 	machinePool.Spec.MaximumNodesPerZone = copyNodePoolMaximumNodesPerZone(source)
+	machinePool.Spec.AutoRepair = copyNodePoolAutoRepair(source)
+	machinePool.Status.Version = ocm.RawVersion(source.Version())
 
 	// spot instances for node pools are not an option
-	machinePool.Spec.AWS = MachinePoolProviderAWS{}
+	machinePool.Spec.AWS = MachinePoolProviderAWS{
+		Tags: copyAWSTags(source.AWSNodePool().Tags()),
+	}
 
 	return nil
 }
 
+// ImportFrom imports the fields which are stored in OpenShift Cluster Manager from the current state
+// of a machine pool into the spec.  Fields which only exist on the object within the cluster, such as
+// the schedules, are preserved and the labels which are managed by this controller are not imported.
+// Immutable fields, such as the instance type, are not imported as the update of the spec would be
+// rejected.  They are reported by ImportMismatches instead.
+func (machinePool *MachinePool) ImportFrom(current *MachinePool) {
+	machinePool.Spec.MinimumNodesPerZone = current.Spec.MinimumNodesPerZone
+	machinePool.Spec.MaximumNodesPerZone = current.Spec.MaximumNodesPerZone
+	machinePool.Spec.Taints = current.Spec.Taints
+	machinePool.Spec.AWS.Tags = current.Spec.AWS.Tags
+	machinePool.Spec.AutoRepair = current.Spec.AutoRepair
+
+	var labels map[string]string
+
+	for key, value := range current.Spec.Labels {
+		if key == ocm.LabelPrefixManaged || key == ocm.LabelPrefixName {
+			continue
+		}
+
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		labels[key] = value
+	}
+
+	machinePool.Spec.Labels = labels
+}
+
+// ImportMismatches returns the paths of the immutable fields of the spec which differ from the current
+// state of a machine pool, and so may not be imported.
+func (machinePool *MachinePool) ImportMismatches(current *MachinePool) (fields []string) {
+	if machinePool.Spec.InstanceType != current.Spec.InstanceType {
+		fields = append(fields, "instanceType")
+	}
+
+	if machinePool.Spec.AWS.SpotInstances != current.Spec.AWS.SpotInstances {
+		fields = append(fields, "aws.spotInstances")
+	}
+
+	return fields
+}
+
+// DesiredReplicas returns the number of nodes which are requested for the machine pool in OpenShift
+// Cluster Manager.  When the machine pool is autoscaling, this is the minimum number of nodes across
+// all availability zones.
+func (machinePool *MachinePool) DesiredReplicas() int {
+	if machinePool.Spec.MaximumNodesPerZone > 0 {
+		return machinePool.Spec.MinimumNodesPerZone * machinePool.AvailabilityZoneCount()
+	}
+
+	return machinePool.Spec.MinimumNodesPerZone
+}
+
+// MaximumReplicas returns the maximum number of nodes which are requested for the machine pool in
+// OpenShift Cluster Manager across all availability zones.  When the machine pool is not autoscaling,
+// this is the requested number of nodes.
+func (machinePool *MachinePool) MaximumReplicas() int {
+	if machinePool.Spec.MaximumNodesPerZone > 0 {
+		return machinePool.Spec.MaximumNodesPerZone * machinePool.AvailabilityZoneCount()
+	}
+
+	return machinePool.Spec.MinimumNodesPerZone
+}
+
+// AvailabilityZoneCount returns the number of availability zones in which the nodes of the machine pool
+// are provisioned.  A machine pool which is placed in a subnet is provisioned in the single
+// availability zone of the subnet, rather than in each availability zone of the cluster.
+func (machinePool *MachinePool) AvailabilityZoneCount() int {
+	if machinePool.Spec.AWS.Subnet != "" {
+		return 1
+	}
+
+	return len(machinePool.Status.AvailabilityZones)
+}
+
 // MachinePoolBuilder builds an OCM MachinePoolBuilder object.
 func (machinePool *MachinePool) MachinePoolBuilder() *clustersmgmtv1.MachinePoolBuilder {
 	builder := clustersmgmtv1.NewMachinePool().
@@ -293,7 +787,11 @@ func (machinePool *MachinePool) NodePoolBuilder() *clustersmgmtv1.NodePoolBuilde
 		ID(machinePool.Spec.DisplayName).
 		Labels(machinePool.Spec.Labels).
 		Taints(machinePool.convertTaints()...).
-		AWSNodePool(clustersmgmtv1.NewAWSNodePool().InstanceType(machinePool.Spec.InstanceType))
+		AWSNodePool(machinePool.convertAWSNodePool())
+
+	if machinePool.Spec.AutoRepair != nil {
+		builder = builder.AutoRepair(*machinePool.Spec.AutoRepair)
+	}
 
 	if machinePool.Spec.MaximumNodesPerZone > 0 {
 		builder = builder.Autoscaling(machinePool.convertNodePoolAutoscaling())
@@ -304,6 +802,30 @@ func (machinePool *MachinePool) NodePoolBuilder() *clustersmgmtv1.NodePoolBuilde
 	return builder
 }
 
+// NodePoolUpdateBuilder builds an OCM NodePoolBuilder object which updates a node pool from its current
+// state.  OpenShift Cluster Manager replaces the list-valued fields of a node pool as a whole, so the
+// AWS tags are sent as an empty set once all of them have been removed from the spec, rather than
+// being omitted, so that they are removed from the node pool.
+func (machinePool *MachinePool) NodePoolUpdateBuilder(current *MachinePool) *clustersmgmtv1.NodePoolBuilder {
+	builder := machinePool.NodePoolBuilder()
+
+	if len(machinePool.Spec.AWS.Tags) == 0 && len(current.Spec.AWS.Tags) > 0 {
+		builder = builder.AWSNodePool(machinePool.convertAWSNodePool().Tags(map[string]string{}))
+	}
+
+	return builder
+}
+
+func (machinePool *MachinePool) convertAWSNodePool() *clustersmgmtv1.AWSNodePoolBuilder {
+	builder := clustersmgmtv1.NewAWSNodePool().InstanceType(machinePool.Spec.InstanceType)
+
+	if len(machinePool.Spec.AWS.Tags) > 0 {
+		builder = builder.Tags(machinePool.Spec.AWS.Tags)
+	}
+
+	return builder
+}
+
 func (machinePool *MachinePool) convertTaints() (builders []*clustersmgmtv1.TaintBuilder) {
 	if len(machinePool.Spec.Taints) < 1 {
 		return builders
@@ -315,7 +837,7 @@ func (machinePool *MachinePool) convertTaints() (builders []*clustersmgmtv1.Tain
 		taints[i] = clustersmgmtv1.NewTaint().
 			Key(source.Key).
 			Value(source.Value).
-			Effect(string(source.Effect))
+			Effect(ocmTaintEffects[source.Effect])
 	}
 
 	return taints
@@ -324,8 +846,8 @@ func (machinePool *MachinePool) convertTaints() (builders []*clustersmgmtv1.Tain
 func (machinePool *MachinePool) convertMachinePoolAutoscaling() (builder *clustersmgmtv1.MachinePoolAutoscalingBuilder) {
 	if machinePool.Spec.MaximumNodesPerZone > 0 {
 		return clustersmgmtv1.NewMachinePoolAutoscaling().
-			MinReplicas(machinePool.Spec.MinimumNodesPerZone * machinePool.availabilityZoneCount()).
-			MaxReplicas(machinePool.Spec.MaximumNodesPerZone * machinePool.availabilityZoneCount())
+			MinReplicas(machinePool.Spec.MinimumNodesPerZone * machinePool.AvailabilityZoneCount()).
+			MaxReplicas(machinePool.Spec.MaximumNodesPerZone * machinePool.AvailabilityZoneCount())
 	}
 
 	return clustersmgmtv1.NewMachinePoolAutoscaling()
@@ -334,8 +856,8 @@ func (machinePool *MachinePool) convertMachinePoolAutoscaling() (builder *cluste
 func (machinePool *MachinePool) convertNodePoolAutoscaling() (builder *clustersmgmtv1.NodePoolAutoscalingBuilder) {
 	if machinePool.Spec.MaximumNodesPerZone > 0 {
 		return clustersmgmtv1.NewNodePoolAutoscaling().
-			MinReplica(machinePool.Spec.MinimumNodesPerZone * machinePool.availabilityZoneCount()).
-			MaxReplica(machinePool.Spec.MaximumNodesPerZone * machinePool.availabilityZoneCount())
+			MinReplica(machinePool.Spec.MinimumNodesPerZone * machinePool.AvailabilityZoneCount()).
+			MaxReplica(machinePool.Spec.MaximumNodesPerZone * machinePool.AvailabilityZoneCount())
 	}
 
 	return clustersmgmtv1.NewNodePoolAutoscaling()
@@ -358,10 +880,6 @@ func (machinePool *MachinePool) convertAWSMachinePool() (builder *clustersmgmtv1
 	return builder
 }
 
-func (machinePool *MachinePool) availabilityZoneCount() int {
-	return len(machinePool.Status.AvailabilityZones)
-}
-
 func copyTaints(source []*clustersmgmtv1.Taint) (taints []corev1.Taint) {
 	if len(source) < 1 {
 		return taints
@@ -394,6 +912,12 @@ func copyMachinePoolMaximumNodesPerZone(source *clustersmgmtv1.MachinePool) int
 	return 0
 }
 
+func copyNodePoolAutoRepair(source *clustersmgmtv1.NodePool) *bool {
+	autoRepair := source.AutoRepair()
+
+	return &autoRepair
+}
+
 func copyNodePoolMinimumNodesPerZone(source *clustersmgmtv1.NodePool) int {
 	if source.Autoscaling().MaxReplica() > 0 {
 		// TODO: if node pools are provisioned in multiple azs, this will break.  does
@@ -414,6 +938,24 @@ func copyNodePoolMaximumNodesPerZone(source *clustersmgmtv1.NodePool) int {
 	return 0
 }
 
+// copyAWSTags copies the user-defined AWS tags from an OCM object.  Tags with a reserved prefix are
+// applied by the system rather than a user, so they are not copied in order to prevent drift.
+func copyAWSTags(source map[string]string) (tags map[string]string) {
+	for key, value := range source {
+		if ocm.IsReservedAWSTag(key) {
+			continue
+		}
+
+		if tags == nil {
+			tags = map[string]string{}
+		}
+
+		tags[key] = value
+	}
+
+	return tags
+}
+
 func copyAWSConfig(source *clustersmgmtv1.AWSMachinePool) MachinePoolProviderAWS {
 	if source == nil {
 		return MachinePoolProviderAWS{}
@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestMachinePool_NodePoolUpdateBuilder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		desired   map[string]string
+		current   map[string]string
+		wantTags  map[string]interface{}
+		wantFound bool
+	}{
+		{
+			name:      "ensure the desired aws tags are sent",
+			desired:   map[string]string{"cost-center": "1234"},
+			current:   map[string]string{"cost-center": "5678", "team": "platform"},
+			wantTags:  map[string]interface{}{"cost-center": "1234"},
+			wantFound: true,
+		},
+		{
+			name:      "ensure empty aws tags are sent when every tag is removed",
+			desired:   nil,
+			current:   map[string]string{"cost-center": "1234"},
+			wantTags:  map[string]interface{}{},
+			wantFound: true,
+		},
+		{
+			name:      "ensure aws tags are omitted when none are set",
+			desired:   nil,
+			current:   nil,
+			wantTags:  nil,
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			desired := &MachinePool{Spec: MachinePoolSpec{DisplayName: "test", AWS: MachinePoolProviderAWS{Tags: tt.desired}}}
+			current := &MachinePool{Spec: MachinePoolSpec{DisplayName: "test", AWS: MachinePoolProviderAWS{Tags: tt.current}}}
+
+			nodePool, err := desired.NodePoolUpdateBuilder(current).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v, wantErr %v", err, false)
+			}
+
+			body := &bytes.Buffer{}
+			if err := clustersmgmtv1.MarshalNodePool(nodePool, body); err != nil {
+				t.Fatalf("MarshalNodePool() error = %v, wantErr %v", err, false)
+			}
+
+			var sent struct {
+				AWSNodePool map[string]interface{} `json:"aws_node_pool"`
+			}
+
+			if err := json.Unmarshal(body.Bytes(), &sent); err != nil {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, false)
+			}
+
+			tags, found := sent.AWSNodePool["tags"]
+			if found != tt.wantFound {
+				t.Fatalf("NodePoolUpdateBuilder() sent aws tags = %v, want %v", found, tt.wantFound)
+			}
+
+			if found && !reflect.DeepEqual(tags, interface{}(tt.wantTags)) {
+				t.Errorf("NodePoolUpdateBuilder() aws tags = %v, want %v", tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestMachinePool_ImportFrom(t *testing.T) {
+	t.Parallel()
+
+	spot := MachinePoolProviderAWSSpotInstances{Enabled: true, MaximumPrice: 1}
+
+	desired := &MachinePool{Spec: MachinePoolSpec{InstanceType: "m5.xlarge", MinimumNodesPerZone: 1}}
+	current := &MachinePool{Spec: MachinePoolSpec{
+		InstanceType:        "m5.2xlarge",
+		MinimumNodesPerZone: 3,
+		AWS:                 MachinePoolProviderAWS{SpotInstances: spot, Tags: map[string]string{"team": "platform"}},
+	}}
+
+	desired.ImportFrom(current)
+
+	if desired.Spec.MinimumNodesPerZone != 3 || desired.Spec.AWS.Tags["team"] != "platform" {
+		t.Errorf("ImportFrom() spec = %+v, want the mutable fields imported", desired.Spec)
+	}
+
+	if desired.Spec.InstanceType != "m5.xlarge" || desired.Spec.AWS.SpotInstances.Enabled {
+		t.Errorf("ImportFrom() spec = %+v, want the immutable fields preserved", desired.Spec)
+	}
+
+	if got, want := desired.ImportMismatches(current), []string{"instanceType", "aws.spotInstances"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportMismatches() = %v, want %v", got, want)
+	}
+
+	if got := current.ImportMismatches(current); len(got) != 0 {
+		t.Errorf("ImportMismatches() = %v, want none", got)
+	}
+}
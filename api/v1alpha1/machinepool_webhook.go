@@ -0,0 +1,260 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/schedule"
+)
+
+// autoscalingWarningRatio is the ratio of the maximum to the minimum nodes per zone of an autoscaling
+// MachinePool above which a warning is returned, as scaling far beyond the minimum may exceed the quota
+// of the organization in OpenShift Cluster Manager.
+const autoscalingWarningRatio = 10
+
+// SetupWebhookWithManager sets up the defaulting, validating, collision and warning webhooks for the MachinePool with the Manager.
+// A MachinePool which does not set its ocmEnvironment is checked for collisions in the default environment.
+func (pool *MachinePool) SetupWebhookWithManager(mgr ctrl.Manager, defaultEnvironment string) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(pool).Complete(); err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+
+	if err := setupCollisionWebhookWithManager(mgr, pool, defaultEnvironment, &MachinePoolList{}); err != nil {
+		return err
+	}
+
+	return setupWarningWebhookWithManager(mgr, pool)
+}
+
+//+kubebuilder:webhook:path=/collide-ocm-mobb-redhat-com-v1alpha1-machinepool,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=machinepools,verbs=create,versions=v1alpha1,name=cmachinepool.kb.io,admissionReviewVersions=v1
+
+var _ Collider = &MachinePool{}
+
+//+kubebuilder:webhook:path=/mutate-ocm-mobb-redhat-com-v1alpha1-machinepool,mutating=true,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=machinepools,verbs=create,versions=v1alpha1,name=mmachinepool.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &MachinePool{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.  The spec.displayName
+// field defaults to the metadata.name field on creation, so that the name of the object in OpenShift
+// Cluster Manager is visible in the spec.  Existing objects are not defaulted, as the spec.displayName
+// field may not be changed once it has been set.
+func (pool *MachinePool) Default() {
+	if pool.Spec.DisplayName == "" {
+		pool.Spec.DisplayName = pool.GetName()
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-ocm-mobb-redhat-com-v1alpha1-machinepool,mutating=false,failurePolicy=fail,sideEffects=None,groups=ocm.mobb.redhat.com,resources=machinepools;machinepools/status,verbs=create;update,versions=v1alpha1,name=vmachinepool.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &MachinePool{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.  The name
+// of the MachinePool in OpenShift Cluster Manager is only validated on creation.
+func (pool *MachinePool) ValidateCreate() error {
+	errs := validateDisplayName(pool.Spec.DisplayName, pool.Name, machinePoolNamePattern)
+
+	errs = append(errs, pool.validateTaints()...)
+	errs = append(errs, pool.validateSchedules()...)
+
+	return invalid("MachinePool", pool.Name, append(errs, pool.validateRawOverrides()...))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.  Unchanged
+// taints, schedules and raw overrides are not validated again, so that objects which were admitted
+// before they were validated may still be updated.
+func (pool *MachinePool) ValidateUpdate(old runtime.Object) error {
+	previous, err := convertOld[*MachinePool](old)
+	if err != nil {
+		return err
+	}
+
+	errs := pool.validateImmutable(previous)
+
+	if !equality.Semantic.DeepEqual(pool.Spec.Taints, previous.Spec.Taints) {
+		errs = append(errs, pool.validateTaints()...)
+	}
+
+	if !equality.Semantic.DeepEqual(pool.Spec.Schedules, previous.Spec.Schedules) {
+		errs = append(errs, pool.validateSchedules()...)
+	}
+
+	if !equality.Semantic.DeepEqual(pool.Spec.RawOverrides, previous.Spec.RawOverrides) {
+		errs = append(errs, pool.validateRawOverrides()...)
+	}
+
+	return invalid("MachinePool", pool.Name, errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.  Deletion
+// is not validated.
+func (pool *MachinePool) ValidateDelete() error {
+	return nil
+}
+
+//+kubebuilder:webhook:path=/warn-ocm-mobb-redhat-com-v1alpha1-machinepool,mutating=false,failurePolicy=ignore,sideEffects=None,groups=ocm.mobb.redhat.com,resources=machinepools,verbs=create;update,versions=v1alpha1,name=wmachinepool.kb.io,admissionReviewVersions=v1
+
+var _ Warner = &MachinePool{}
+
+// Warnings implements Warner so a warning webhook will be registered for the type.  A MachinePool
+// which does not run any nodes, which may autoscale far beyond its minimum, or which requests spot
+// instances at on-demand pricing is valid, but is likely to be a mistake.  Raw overrides are also
+// warned about, as they are sent to OpenShift Cluster Manager without being validated.
+func (pool *MachinePool) Warnings() []string {
+	warnings := []string{}
+
+	if pool.Spec.MinimumNodesPerZone == 0 && pool.Spec.MaximumNodesPerZone == 0 {
+		warnings = append(warnings, "spec.minimumNodesPerZone is 0 and autoscaling is disabled, so the "+
+			"machine pool will not run any nodes")
+	}
+
+	minimum := pool.Spec.MinimumNodesPerZone
+	if minimum < 1 {
+		minimum = 1
+	}
+
+	if pool.Spec.MaximumNodesPerZone > autoscalingWarningRatio*minimum {
+		warnings = append(warnings, fmt.Sprintf("spec.maximumNodesPerZone (%d) is more than %d times "+
+			"spec.minimumNodesPerZone (%d), ensure that the organization has enough quota to scale the "+
+			"machine pool to its maximum", pool.Spec.MaximumNodesPerZone, autoscalingWarningRatio, pool.Spec.MinimumNodesPerZone))
+	}
+
+	if pool.Spec.AWS.SpotInstances.Enabled && pool.Spec.AWS.SpotInstances.MaximumPrice == 0 {
+		warnings = append(warnings, "spec.aws.spotInstances.maximumPrice is not set, so spot instances "+
+			"default to on-demand pricing")
+	}
+
+	if pool.Spec.RawOverrides != nil {
+		warnings = append(warnings, "spec.rawOverrides is sent to OpenShift Cluster Manager without validation "+
+			"and takes precedence over the other fields of the spec")
+	}
+
+	return warnings
+}
+
+// validateTaints returns the field errors of the taints of the MachinePool, which are otherwise only
+// rejected by OpenShift Cluster Manager when the machine pool is applied.  The key and value of each
+// taint must be valid for a node, the effect must be supported by OpenShift Cluster Manager, and
+// each key may only be used once per effect.
+func (pool *MachinePool) validateTaints() field.ErrorList {
+	path := field.NewPath("spec", "taints")
+	errs := field.ErrorList{}
+	seen := map[string]bool{}
+
+	for i, taint := range pool.Spec.Taints {
+		for _, message := range validation.IsQualifiedName(taint.Key) {
+			errs = append(errs, field.Invalid(path.Index(i).Child("key"), taint.Key, message))
+		}
+
+		for _, message := range validation.IsValidLabelValue(taint.Value) {
+			errs = append(errs, field.Invalid(path.Index(i).Child("value"), taint.Value, message))
+		}
+
+		if _, ok := ocmTaintEffects[taint.Effect]; !ok {
+			errs = append(errs, field.NotSupported(path.Index(i).Child("effect"), taint.Effect, supportedTaintEffects()))
+		}
+
+		key := fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+		if seen[key] {
+			errs = append(errs, field.Duplicate(path.Index(i), key))
+		}
+
+		seen[key] = true
+	}
+
+	return errs
+}
+
+// validateSchedules returns the field errors of the schedules of the MachinePool.  Each schedule must
+// be able to be parsed, so that the controller is able to determine which schedule is active.
+func (pool *MachinePool) validateSchedules() field.ErrorList {
+	path := field.NewPath("spec", "schedules")
+	errs := field.ErrorList{}
+
+	for i := range pool.Spec.Schedules {
+		if _, err := schedule.Parse(
+			pool.Spec.Schedules[i].Start,
+			pool.Spec.Schedules[i].TimeZone,
+			pool.Spec.Schedules[i].Duration.Duration,
+		); err != nil {
+			errs = append(errs, field.Invalid(path.Index(i), pool.Spec.Schedules[i].Name, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// validateRawOverrides returns the field errors of the raw overrides of the MachinePool.  The raw
+// overrides may not set the fields which identify the machine pool in OpenShift Cluster Manager.
+func (pool *MachinePool) validateRawOverrides() field.ErrorList {
+	if pool.Spec.RawOverrides == nil {
+		return field.ErrorList{}
+	}
+
+	if err := ocm.ValidateOverrides(pool.Spec.RawOverrides.Raw); err != nil {
+		return field.ErrorList{
+			field.Invalid(field.NewPath("spec", "rawOverrides"), string(pool.Spec.RawOverrides.Raw), err.Error()),
+		}
+	}
+
+	return field.ErrorList{}
+}
+
+// supportedTaintEffects returns the taint effects which are supported by OpenShift Cluster Manager.
+func supportedTaintEffects() []string {
+	return []string{
+		string(corev1.TaintEffectNoSchedule),
+		string(corev1.TaintEffectPreferNoSchedule),
+		string(corev1.TaintEffectNoExecute),
+	}
+}
+
+// validateImmutable returns the field errors of the fields of the MachinePool which may not
+// be changed once they have been set.  Changing the availability zones or subnets of a machine
+// pool requires it to be recreated in OpenShift Cluster Manager.
+func (pool *MachinePool) validateImmutable(old *MachinePool) field.ErrorList {
+	spec, status := field.NewPath("spec"), field.NewPath("status")
+
+	return validateImmutable(
+		immutableField{path: spec.Child("clusterName"), oldValue: old.Spec.ClusterName, newValue: pool.Spec.ClusterName},
+		immutableField{path: spec.Child("clusterReference"), oldValue: old.Spec.ClusterReference, newValue: pool.Spec.ClusterReference},
+		immutableField{path: spec.Child("ocmEnvironment"), oldValue: old.Spec.OCMEnvironment, newValue: pool.Spec.OCMEnvironment},
+		immutableField{path: spec.Child("displayName"), oldValue: old.Spec.DisplayName, newValue: pool.Spec.DisplayName},
+		immutableField{path: spec.Child("instanceType"), oldValue: old.Spec.InstanceType, newValue: pool.Spec.InstanceType},
+		immutableField{path: spec.Child("aws", "spotInstances"), oldValue: old.Spec.AWS.SpotInstances, newValue: pool.Spec.AWS.SpotInstances},
+		immutableField{path: spec.Child("aws", "subnet"), oldValue: old.Spec.AWS.Subnet, newValue: pool.Spec.AWS.Subnet},
+		immutableField{path: status.Child("clusterID"), oldValue: old.Status.ClusterID, newValue: pool.Status.ClusterID, onceSet: true},
+		immutableField{
+			path:     status.Child("availabilityZones"),
+			oldValue: old.Status.AvailabilityZones,
+			newValue: pool.Status.AvailabilityZones,
+			onceSet:  true,
+		},
+		immutableField{path: status.Child("subnets"), oldValue: old.Status.Subnets, newValue: pool.Status.Subnets, onceSet: true},
+		immutableField{path: status.Child("hosted"), oldValue: old.Status.Hosted, newValue: pool.Status.Hosted, onceSet: true},
+	)
+}
@@ -0,0 +1,197 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMachinePool_validateTaints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		taints  []corev1.Taint
+		wantErr bool
+	}{
+		{
+			name: "ensure valid taints pass",
+			taints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "example.com/spot", Effect: corev1.TaintEffectPreferNoSchedule},
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ensure a missing effect fails",
+			taints:  []corev1.Taint{{Key: "dedicated", Value: "gpu"}},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an unsupported effect fails",
+			taints:  []corev1.Taint{{Key: "dedicated", Effect: "NoScheduled"}},
+			wantErr: true,
+		},
+		{
+			name:    "ensure a missing key fails",
+			taints:  []corev1.Taint{{Value: "gpu", Effect: corev1.TaintEffectNoSchedule}},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an invalid key fails",
+			taints:  []corev1.Taint{{Key: "dedicated node", Effect: corev1.TaintEffectNoSchedule}},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an invalid value fails",
+			taints:  []corev1.Taint{{Key: "dedicated", Value: "gpu/a100", Effect: corev1.TaintEffectNoSchedule}},
+			wantErr: true,
+		},
+		{
+			name: "ensure a duplicate key and effect fails",
+			taints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "cpu", Effect: corev1.TaintEffectNoSchedule},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := &MachinePool{Spec: MachinePoolSpec{Taints: tt.taints}}
+			if errs := pool.validateTaints(); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("MachinePool.validateTaints() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMachinePool_validateSchedules(t *testing.T) {
+	t.Parallel()
+
+	hour := metav1.Duration{Duration: time.Hour}
+
+	tests := []struct {
+		name      string
+		schedules []MachinePoolSchedule
+		wantErr   bool
+	}{
+		{
+			name:      "ensure missing schedules pass",
+			schedules: nil,
+			wantErr:   false,
+		},
+		{
+			name: "ensure valid schedules pass",
+			schedules: []MachinePoolSchedule{
+				{Name: "nights", Start: "0 20 * * *", Duration: hour},
+				{Name: "weekends", Start: "0 0 * * 6", Duration: hour, TimeZone: "America/New_York"},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ensure an invalid cron expression fails",
+			schedules: []MachinePoolSchedule{{Name: "nights", Start: "0 20 * *", Duration: hour}},
+			wantErr:   true,
+		},
+		{
+			name:      "ensure an unknown time zone fails",
+			schedules: []MachinePoolSchedule{{Name: "nights", Start: "0 20 * * *", Duration: hour, TimeZone: "Mars/Olympus"}},
+			wantErr:   true,
+		},
+		{
+			name:      "ensure a missing duration fails",
+			schedules: []MachinePoolSchedule{{Name: "nights", Start: "0 20 * * *"}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := &MachinePool{Spec: MachinePoolSpec{Schedules: tt.schedules}}
+			if errs := pool.validateSchedules(); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("MachinePool.validateSchedules() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMachinePool_validateRawOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		overrides *apiextensionsv1.JSON
+		wantErr   bool
+	}{
+		{
+			name:      "ensure missing raw overrides pass",
+			overrides: nil,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure raw overrides of fields which are not modeled pass",
+			overrides: &apiextensionsv1.JSON{Raw: []byte(`{"aws":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`)},
+			wantErr:   false,
+		},
+		{
+			name:      "ensure raw overrides of the id fail",
+			overrides: &apiextensionsv1.JSON{Raw: []byte(`{"id":"other"}`)},
+			wantErr:   true,
+		},
+		{
+			name:      "ensure raw overrides of a modeled field fail",
+			overrides: &apiextensionsv1.JSON{Raw: []byte(`{"replicas":3}`)},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := &MachinePool{Spec: MachinePoolSpec{RawOverrides: tt.overrides}}
+			if errs := pool.validateRawOverrides(); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("MachinePool.validateRawOverrides() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMachinePool_ValidateUpdate(t *testing.T) {
+	t.Parallel()
+
+	invalidTaints := []corev1.Taint{{Key: "dedicated", Effect: "NoScheduled"}}
+
+	previous := &MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       MachinePoolSpec{DisplayName: "test", Taints: invalidTaints},
+	}
+
+	unchanged := previous.DeepCopy()
+	unchanged.Spec.MinimumNodesPerZone = 3
+
+	if err := unchanged.ValidateUpdate(previous); err != nil {
+		t.Errorf("MachinePool.ValidateUpdate() error = %v, want unchanged taints to pass", err)
+	}
+
+	changed := previous.DeepCopy()
+	changed.Spec.Taints = append(changed.Spec.Taints, corev1.Taint{Key: "other", Effect: "Invalid"})
+
+	if err := changed.ValidateUpdate(previous); err == nil {
+		t.Errorf("MachinePool.ValidateUpdate() error = %v, want changed taints to fail", err)
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// IdentityProviderMigration defines an existing identity provider which is replaced by a new
+// identity provider.
+type IdentityProviderMigration struct {
+	// +kubebuilder:validation:Required
+	// name is the name of the LDAPIdentityProvider, in the same namespace as the resource,
+	// which is replaced by this identity provider.  The LDAPIdentityProvider, and therefore
+	// its identity provider in OpenShift Cluster Manager, is deleted only once this identity
+	// provider has been confirmed to exist in OpenShift Cluster Manager.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// lookupMapping sets the mapping method of this identity provider to lookup, rather than
+	// the mappingMethod field, so that users are only able to log in with an identity which
+	// has been explicitly mapped to an existing user.  This allows the identities of the
+	// replaced identity provider to be mapped to this identity provider ahead of the
+	// migration, so that existing users keep their permissions.
+	LookupMapping bool `json:"lookupMapping,omitempty"`
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// minimumDisplayNameLength and maximumDisplayNameLength are the limits of the length of the name of an
+	// object in OpenShift Cluster Manager.  These match the limits of the spec.displayName field.
+	minimumDisplayNameLength = 4
+	maximumDisplayNameLength = 15
+
+	// reservedIdentityProviderName is the name of the identity provider which is reserved for the
+	// cluster-admin user of a cluster.
+	reservedIdentityProviderName = "cluster-admin"
+)
+
+var (
+	// machinePoolNamePattern is the pattern which the name of a machine pool must match in OpenShift
+	// Cluster Manager.
+	machinePoolNamePattern = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+	// identityProviderNamePattern is the pattern which the name of an identity provider must match in
+	// OpenShift Cluster Manager.
+	identityProviderNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([-_a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+)
+
+// defaultDisplayName returns the display name of an object, which defaults to the metadata.name field
+// of the object if the spec.displayName field is unset.
+func defaultDisplayName(displayName, name string) string {
+	if displayName == "" {
+		return name
+	}
+
+	return displayName
+}
+
+// validateDisplayName returns the field errors of the name of an object in OpenShift Cluster Manager, which
+// is derived from the metadata.name field when the spec.displayName field is unset, so that a name which
+// is rejected by OpenShift Cluster Manager is rejected at admission rather than during reconciliation.
+func validateDisplayName(displayName, name string, pattern *regexp.Regexp, reserved ...string) field.ErrorList {
+	path := field.NewPath("spec", "displayName")
+
+	derived := defaultDisplayName(displayName, name)
+	if displayName == "" {
+		path = field.NewPath("metadata", "name")
+	}
+
+	errs := field.ErrorList{}
+
+	if len(derived) < minimumDisplayNameLength || len(derived) > maximumDisplayNameLength {
+		errs = append(errs, field.Invalid(path, derived, fmt.Sprintf(
+			"name in openshift cluster manager must be between %d and %d characters",
+			minimumDisplayNameLength,
+			maximumDisplayNameLength,
+		)))
+	}
+
+	if !pattern.MatchString(derived) {
+		errs = append(errs, field.Invalid(path, derived, fmt.Sprintf(
+			"name in openshift cluster manager must match the pattern [%s]",
+			pattern.String(),
+		)))
+	}
+
+	for _, reservedName := range reserved {
+		if derived == reservedName {
+			errs = append(errs, field.Invalid(path, derived, "name in openshift cluster manager is reserved"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,157 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_validateDisplayName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		displayName string
+		objectName  string
+		wantField   string
+	}{
+		{
+			name:        "ensure a valid display name passes",
+			displayName: "workers",
+			objectName:  "a-much-longer-object-name",
+		},
+		{
+			name:       "ensure a valid derived name passes",
+			objectName: "workers",
+		},
+		{
+			name:       "ensure a derived name which is too long fails on the metadata.name field",
+			objectName: "a-much-longer-object-name",
+			wantField:  "metadata.name",
+		},
+		{
+			name:        "ensure a display name which is too short fails on the spec.displayName field",
+			displayName: "abc",
+			objectName:  "workers",
+			wantField:   "spec.displayName",
+		},
+		{
+			name:       "ensure a derived name with an invalid character fails",
+			objectName: "workers.infra",
+			wantField:  "metadata.name",
+		},
+		{
+			name:       "ensure a derived name which starts with a number fails",
+			objectName: "1workers",
+			wantField:  "metadata.name",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := validateDisplayName(tt.displayName, tt.objectName, machinePoolNamePattern)
+
+			if tt.wantField == "" {
+				if len(errs) > 0 {
+					t.Errorf("validateDisplayName() = %v, want no errors", errs)
+				}
+
+				return
+			}
+
+			if len(errs) == 0 {
+				t.Fatalf("validateDisplayName() = %v, want errors on %v", errs, tt.wantField)
+			}
+
+			for _, err := range errs {
+				if err.Field != tt.wantField {
+					t.Errorf("validateDisplayName() field = %v, want %v", err.Field, tt.wantField)
+				}
+			}
+		})
+	}
+}
+
+func TestGitLabIdentityProvider_ValidateCreate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		displayName string
+		objectName  string
+		wantErr     bool
+	}{
+		{
+			name:       "ensure a derived name with an underscore passes",
+			objectName: "corp_gitlab",
+		},
+		{
+			name:        "ensure the reserved cluster-admin name fails",
+			displayName: "cluster-admin",
+			objectName:  "gitlab",
+			wantErr:     true,
+		},
+		{
+			name:       "ensure a derived name which ends with a hyphen fails",
+			objectName: "gitlab-",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gitlab := &GitLabIdentityProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.objectName},
+				Spec:       GitLabIdentityProviderSpec{DisplayName: tt.displayName},
+			}
+
+			if err := gitlab.ValidateCreate(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMachinePool_Default(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		displayName string
+		want        string
+	}{
+		{
+			name: "ensure an unset display name defaults to the object name",
+			want: "workers",
+		},
+		{
+			name:        "ensure a set display name is preserved",
+			displayName: "infra",
+			want:        "infra",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := &MachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "workers"},
+				Spec:       MachinePoolSpec{DisplayName: tt.displayName},
+			}
+
+			pool.Default()
+
+			if pool.Spec.DisplayName != tt.want {
+				t.Errorf("Default() = %v, want %v", pool.Spec.DisplayName, tt.want)
+			}
+		})
+	}
+}
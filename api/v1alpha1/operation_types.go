@@ -0,0 +1,52 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	OCMOperationCreate = "Create"
+	OCMOperationUpdate = "Update"
+	OCMOperationDelete = "Delete"
+)
+
+// OCMOperation represents a change which was made to an object in OpenShift Cluster Manager.
+type OCMOperation struct {
+	// +kubebuilder:validation:Required
+	// Represents the time at which the operation was sent.
+	Time metav1.Time `json:"time"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Create;Update;Delete
+	// Represents the type of operation, which is one of Create, Update or Delete.
+	Operation string `json:"operation"`
+
+	// +kubebuilder:validation:Optional
+	// Represents the generation of the resource from which the operation was sent.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Represents the HTTP status code which was returned by OpenShift Cluster Manager, or 0 if
+	// no response was received.
+	Status int `json:"status,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Represents the error returned by the operation, if it failed.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultReconcileReportWindowHours     = 24
+	defaultReconcileReportIntervalMinutes = 5
+)
+
+// ReconcileReportState represents the state of a resource which is summarized in a report.
+type ReconcileReportState string
+
+const (
+	// ReconcileReportStateInSync indicates that the resource was last reconciled successfully
+	// at its current generation.
+	ReconcileReportStateInSync ReconcileReportState = "InSync"
+
+	// ReconcileReportStateDrifted indicates that the resource has changed since it was last
+	// reconciled successfully.
+	ReconcileReportStateDrifted ReconcileReportState = "Drifted"
+
+	// ReconcileReportStateFailed indicates that the last reconciliation of the resource failed.
+	ReconcileReportStateFailed ReconcileReportState = "Failed"
+
+	// ReconcileReportStateUnknown indicates that the resource has never completed reconciliation.
+	ReconcileReportStateUnknown ReconcileReportState = "Unknown"
+)
+
+// ReconcileReportSpec defines the desired state of ReconcileReport
+type ReconcileReportSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=168
+	// +kubebuilder:default=24
+	// Number of hours, counting back from the time the report is generated, over which
+	// reconciliation errors are summarized.
+	WindowHours int `json:"windowHours,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// Interval, in minutes, at which the report is regenerated.
+	IntervalMinutes int `json:"intervalMinutes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Namespaces from which managed resources are summarized.  If this is empty, managed
+	// resources from all namespaces are summarized.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// ReconcileReportSummary defines the totals of a report by state.
+type ReconcileReportSummary struct {
+	// Total number of managed resources in the report.
+	Total int `json:"total"`
+
+	// Number of managed resources which are in sync.
+	InSync int `json:"inSync"`
+
+	// Number of managed resources which have drifted since they were last reconciled.
+	Drifted int `json:"drifted"`
+
+	// Number of managed resources whose last reconciliation failed.
+	Failed int `json:"failed"`
+
+	// Number of managed resources which have never completed reconciliation.
+	Unknown int `json:"unknown"`
+}
+
+// ReconcileReportResource defines the summary of an individual managed resource in a report.
+type ReconcileReportResource struct {
+	// Kind of the managed resource.
+	Kind string `json:"kind"`
+
+	// Namespace of the managed resource.
+	Namespace string `json:"namespace"`
+
+	// Name of the managed resource.
+	Name string `json:"name"`
+
+	// Name of the cluster in OpenShift Cluster Manager which the managed resource
+	// is reconciled against.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// +kubebuilder:validation:Enum=InSync;Drifted;Failed;Unknown
+	// State of the managed resource.  One of InSync, Drifted, Failed or Unknown.
+	State ReconcileReportState `json:"state"`
+
+	// Time at which the managed resource last completed reconciliation.
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// Number of reconciliation errors which were recorded for the managed resource within
+	// the report window.
+	Errors int `json:"errors,omitempty"`
+
+	// Message of the most recent reconciliation error which was recorded for the managed
+	// resource within the report window.
+	LastError string `json:"lastError,omitempty"`
+
+	// Time at which the most recent reconciliation error was recorded for the managed
+	// resource within the report window.
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+}
+
+// ReconcileReportStatus defines the observed state of ReconcileReport
+type ReconcileReportStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Time at which the report was last generated.
+	LastGeneratedTime *metav1.Time `json:"lastGeneratedTime,omitempty"`
+
+	// Totals of the managed resources in the report by state.
+	Summary ReconcileReportSummary `json:"summary,omitempty"`
+
+	// Summaries of the individual managed resources in the report.
+	Resources []ReconcileReportResource `json:"resources,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.summary.total`
+//+kubebuilder:printcolumn:name="In Sync",type=integer,JSONPath=`.status.summary.inSync`
+//+kubebuilder:printcolumn:name="Drifted",type=integer,JSONPath=`.status.summary.drifted`
+//+kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.summary.failed`
+//+kubebuilder:printcolumn:name="Generated",type=date,JSONPath=`.status.lastGeneratedTime`
+
+// ReconcileReport is the Schema for the reconcilereports API
+type ReconcileReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReconcileReportSpec   `json:"spec,omitempty"`
+	Status ReconcileReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReconcileReportList contains a list of ReconcileReport
+type ReconcileReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReconcileReport `json:"items"`
+}
+
+// GetConditions returns the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (report *ReconcileReport) GetConditions() []metav1.Condition {
+	return report.Status.Conditions
+}
+
+// SetConditions sets the status.conditions field from the object.  It is used to
+// satisfy the Workload interface.
+func (report *ReconcileReport) SetConditions(conditions []metav1.Condition) {
+	report.Status.Conditions = conditions
+}
+
+// Window returns the amount of time over which reconciliation errors are summarized.
+func (report *ReconcileReport) Window() time.Duration {
+	if report.Spec.WindowHours < 1 {
+		return defaultReconcileReportWindowHours * time.Hour
+	}
+
+	if report.Spec.WindowHours > ReconcileFailureHours {
+		return ReconcileFailureHours * time.Hour
+	}
+
+	return time.Duration(report.Spec.WindowHours) * time.Hour
+}
+
+// Interval returns the interval at which the report is regenerated.
+func (report *ReconcileReport) Interval() time.Duration {
+	if report.Spec.IntervalMinutes < 1 {
+		return defaultReconcileReportIntervalMinutes * time.Minute
+	}
+
+	return time.Duration(report.Spec.IntervalMinutes) * time.Minute
+}
+
+// Includes determines if managed resources from a namespace should be included in the report.
+func (report *ReconcileReport) Includes(namespace string) bool {
+	if len(report.Spec.Namespaces) == 0 {
+		return true
+	}
+
+	for i := range report.Spec.Namespaces {
+		if report.Spec.Namespaces[i] == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&ReconcileReport{}, &ReconcileReportList{})
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReconcileTiming represents the duration of a reconciliation of a resource, broken down by the
+// phases which were run.
+type ReconcileTiming struct {
+	// +kubebuilder:validation:Required
+	// Represents the time at which the reconciliation started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// +kubebuilder:validation:Required
+	// Represents the total duration of the reconciliation.
+	Duration metav1.Duration `json:"duration"`
+
+	// +kubebuilder:validation:Optional
+	// Represents the duration of each phase which was run during the reconciliation, in the order
+	// in which the phases were run.  A reconciliation which stopped early, for example due to an
+	// error, only reports the phases which were run.
+	Phases []PhaseTiming `json:"phases,omitempty"`
+}
+
+// PhaseTiming represents the duration of an individual phase of a reconciliation.
+type PhaseTiming struct {
+	// +kubebuilder:validation:Required
+	// Represents the name of the phase.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// Represents the duration of the phase.
+	Duration metav1.Duration `json:"duration"`
+}
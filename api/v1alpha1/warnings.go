@@ -0,0 +1,114 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Warner represents an object which warns of configurations which are valid, but which are risky, so
+// that a client such as kubectl surfaces advice when the object is applied without rejecting it.
+type Warner interface {
+	runtime.Object
+
+	Warnings() []string
+}
+
+// warningWebhook is an admission webhook which admits every object along with the warnings of the
+// object.  It is registered separately from the validating webhook of the object, as the validators of
+// this version of controller-runtime may not return warnings, and with a failure policy of ignore so
+// that the webhook may never block an object from being admitted.
+type warningWebhook struct {
+	warner  Warner
+	decoder *admission.Decoder
+}
+
+// setupWarningWebhookWithManager registers the warning webhook of an object with the Manager.
+func setupWarningWebhookWithManager(mgr ctrl.Manager, warner Warner) error {
+	gvk, err := apiutil.GVKForObject(warner, mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("unable to determine group version kind of warning webhook - %w", err)
+	}
+
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("unable to create decoder for warning webhook - %w", err)
+	}
+
+	path := "/warn-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+
+	mgr.GetWebhookServer().Register(path, &webhook.Admission{
+		Handler: &warningWebhook{warner: warner, decoder: decoder},
+	})
+
+	return nil
+}
+
+// Handle implements admission.Handler.  An update which does not change the spec of the object is not
+// warned of again, so that the controller is not warned each time that it updates the metadata of the
+// object.  An object which may not be decoded is admitted without warnings, as it is the responsibility
+// of the validating webhook to reject it.
+func (hook *warningWebhook) Handle(_ context.Context, req admission.Request) admission.Response {
+	allowed := admission.Allowed("")
+
+	switch req.Operation {
+	case admissionv1.Create:
+	case admissionv1.Update:
+		if !specChanged(req.OldObject.Raw, req.Object.Raw) {
+			return allowed
+		}
+	default:
+		return allowed
+	}
+
+	//nolint:forcetypeassert
+	object := hook.warner.DeepCopyObject().(Warner)
+	if err := hook.decoder.Decode(req, object); err != nil {
+		return allowed
+	}
+
+	return allowed.WithWarnings(object.Warnings()...)
+}
+
+// specChanged determines if the spec of an object has changed between its old and new raw
+// representations.
+func specChanged(oldRaw, newRaw []byte) bool {
+	var oldObject, newObject struct {
+		Spec map[string]interface{} `json:"spec"`
+	}
+
+	if err := json.Unmarshal(oldRaw, &oldObject); err != nil {
+		return true
+	}
+
+	if err := json.Unmarshal(newRaw, &newObject); err != nil {
+		return true
+	}
+
+	return !equality.Semantic.DeepEqual(oldObject.Spec, newObject.Spec)
+}
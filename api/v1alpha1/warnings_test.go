@@ -0,0 +1,186 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestMachinePool_Warnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		spec MachinePoolSpec
+		want int
+	}{
+		{
+			name: "ensure a fixed size machine pool does not warn",
+			spec: MachinePoolSpec{MinimumNodesPerZone: 2},
+			want: 0,
+		},
+		{
+			name: "ensure a machine pool without nodes warns",
+			spec: MachinePoolSpec{},
+			want: 1,
+		},
+		{
+			name: "ensure a machine pool which autoscales from zero does not warn",
+			spec: MachinePoolSpec{MaximumNodesPerZone: 3},
+			want: 0,
+		},
+		{
+			name: "ensure a machine pool which autoscales far beyond its minimum warns",
+			spec: MachinePoolSpec{MinimumNodesPerZone: 2, MaximumNodesPerZone: 50},
+			want: 1,
+		},
+		{
+			name: "ensure spot instances without a maximum price warn",
+			spec: MachinePoolSpec{
+				MinimumNodesPerZone: 2,
+				AWS:                 MachinePoolProviderAWS{SpotInstances: MachinePoolProviderAWSSpotInstances{Enabled: true}},
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := &MachinePool{Spec: tt.spec}
+			if got := pool.Warnings(); len(got) != tt.want {
+				t.Errorf("MachinePool.Warnings() = %v, want %d warnings", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLDAPIdentityProvider_Warnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider configv1.LDAPIdentityProvider
+		want     int
+	}{
+		{
+			name:     "ensure a secure provider does not warn",
+			provider: configv1.LDAPIdentityProvider{URL: "ldaps://ldap.example.com", BindDN: "cn=admin"},
+			want:     0,
+		},
+		{
+			name:     "ensure an insecure provider warns",
+			provider: configv1.LDAPIdentityProvider{URL: "ldap://ldap.example.com", BindDN: "cn=admin", Insecure: true},
+			want:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ldap := &LDAPIdentityProvider{}
+			ldap.Spec.LDAPIdentityProvider = tt.provider
+
+			if got := ldap.Warnings(); len(got) != tt.want {
+				t.Errorf("LDAPIdentityProvider.Warnings() = %v, want %d warnings", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarningWebhook_Handle(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	hook := &warningWebhook{warner: &MachinePool{}, decoder: decoder}
+
+	raw := func(pool *MachinePool) []byte {
+		pool.APIVersion, pool.Kind = GroupVersion.String(), "MachinePool"
+
+		data, err := json.Marshal(pool)
+		if err != nil {
+			t.Fatalf("unable to marshal machine pool - %v", err)
+		}
+
+		return data
+	}
+
+	empty := raw(&MachinePool{})
+	annotated := &MachinePool{}
+	annotated.SetAnnotations(map[string]string{"example.com/test": "true"})
+
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		object    []byte
+		oldObject []byte
+		want      int
+	}{
+		{
+			name:      "ensure a created object is warned of",
+			operation: admissionv1.Create,
+			object:    empty,
+			want:      1,
+		},
+		{
+			name:      "ensure an update which changes the spec is warned of",
+			operation: admissionv1.Update,
+			object:    empty,
+			oldObject: raw(&MachinePool{Spec: MachinePoolSpec{MinimumNodesPerZone: 2}}),
+			want:      1,
+		},
+		{
+			name:      "ensure an update which does not change the spec is not warned of",
+			operation: admissionv1.Update,
+			object:    raw(annotated),
+			oldObject: empty,
+			want:      0,
+		},
+		{
+			name:      "ensure an object which may not be decoded is admitted",
+			operation: admissionv1.Create,
+			object:    []byte("{"),
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: tt.operation,
+				Object:    runtime.RawExtension{Raw: tt.object},
+				OldObject: runtime.RawExtension{Raw: tt.oldObject},
+			}}
+
+			got := hook.Handle(context.Background(), req)
+			if !got.Allowed {
+				t.Fatalf("warningWebhook.Handle() allowed = %v, want %v", got.Allowed, true)
+			}
+
+			if len(got.Warnings) != tt.want {
+				t.Errorf("warningWebhook.Handle() warnings = %v, want %d warnings", got.Warnings, tt.want)
+			}
+		})
+	}
+}
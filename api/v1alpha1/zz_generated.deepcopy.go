@@ -22,13 +22,617 @@ limitations under the License.
 package v1alpha1
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitLabIdentityProvider) DeepCopyInto(out *GitLabIdentityProvider) {
+func (in *ClusterLabels) DeepCopyInto(out *ClusterLabels) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLabels.
+func (in *ClusterLabels) DeepCopy() *ClusterLabels {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLabels)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterLabels) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLabelsList) DeepCopyInto(out *ClusterLabelsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterLabels, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLabelsList.
+func (in *ClusterLabelsList) DeepCopy() *ClusterLabelsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLabelsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterLabelsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLabelsSpec) DeepCopyInto(out *ClusterLabelsSpec) {
+	*out = *in
+	out.LocalClusterReference = in.LocalClusterReference
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLabelsSpec.
+func (in *ClusterLabelsSpec) DeepCopy() *ClusterLabelsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLabelsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLabelsStatus) DeepCopyInto(out *ClusterLabelsStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]OCMOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileFailures != nil {
+		in, out := &in.ReconcileFailures, &out.ReconcileFailures
+		*out = make([]ReconcileFailureCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagedLabels != nil {
+		in, out := &in.ManagedLabels, &out.ManagedLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLabelsStatus.
+func (in *ClusterLabelsStatus) DeepCopy() *ClusterLabelsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLabelsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterManagementBinding) DeepCopyInto(out *ClusterManagementBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterManagementBinding.
+func (in *ClusterManagementBinding) DeepCopy() *ClusterManagementBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterManagementBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterManagementBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterManagementBindingList) DeepCopyInto(out *ClusterManagementBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterManagementBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterManagementBindingList.
+func (in *ClusterManagementBindingList) DeepCopy() *ClusterManagementBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterManagementBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterManagementBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterManagementBindingSpec) DeepCopyInto(out *ClusterManagementBindingSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterNames != nil {
+		in, out := &in.ClusterNames, &out.ClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterIDs != nil {
+		in, out := &in.ClusterIDs, &out.ClusterIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterManagementBindingSpec.
+func (in *ClusterManagementBindingSpec) DeepCopy() *ClusterManagementBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterManagementBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNotification) DeepCopyInto(out *ClusterNotification) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNotification.
+func (in *ClusterNotification) DeepCopy() *ClusterNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNotification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNotificationList) DeepCopyInto(out *ClusterNotificationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNotificationList.
+func (in *ClusterNotificationList) DeepCopy() *ClusterNotificationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNotificationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNotificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNotificationSpec) DeepCopyInto(out *ClusterNotificationSpec) {
+	*out = *in
+	out.LocalClusterReference = in.LocalClusterReference
+	if in.Contacts != nil {
+		in, out := &in.Contacts, &out.Contacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SupportCase != nil {
+		in, out := &in.SupportCase, &out.SupportCase
+		*out = new(ClusterNotificationSupportCase)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNotificationSpec.
+func (in *ClusterNotificationSpec) DeepCopy() *ClusterNotificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNotificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNotificationStatus) DeepCopyInto(out *ClusterNotificationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]OCMOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileFailures != nil {
+		in, out := &in.ReconcileFailures, &out.ReconcileFailures
+		*out = make([]ReconcileFailureCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNotificationStatus.
+func (in *ClusterNotificationStatus) DeepCopy() *ClusterNotificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNotificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNotificationSupportCase) DeepCopyInto(out *ClusterNotificationSupportCase) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNotificationSupportCase.
+func (in *ClusterNotificationSupportCase) DeepCopy() *ClusterNotificationSupportCase {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNotificationSupportCase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReference) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReferenceList) DeepCopyInto(out *ClusterReferenceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReferenceList.
+func (in *ClusterReferenceList) DeepCopy() *ClusterReferenceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReferenceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReferenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReferenceSpec) DeepCopyInto(out *ClusterReferenceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReferenceSpec.
+func (in *ClusterReferenceSpec) DeepCopy() *ClusterReferenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReferenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReferenceStatus) DeepCopyInto(out *ClusterReferenceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastResolvedTime != nil {
+		in, out := &in.LastResolvedTime, &out.LastResolvedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReferenceStatus.
+func (in *ClusterReferenceStatus) DeepCopy() *ClusterReferenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReferenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRegistration) DeepCopyInto(out *ClusterRegistration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistration.
+func (in *ClusterRegistration) DeepCopy() *ClusterRegistration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRegistration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRegistration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRegistrationList) DeepCopyInto(out *ClusterRegistrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRegistration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationList.
+func (in *ClusterRegistrationList) DeepCopy() *ClusterRegistrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRegistrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRegistrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRegistrationSpec) DeepCopyInto(out *ClusterRegistrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationSpec.
+func (in *ClusterRegistrationSpec) DeepCopy() *ClusterRegistrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRegistrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRegistrationStatus) DeepCopyInto(out *ClusterRegistrationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]OCMOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileFailures != nil {
+		in, out := &in.ReconcileFailures, &out.ReconcileFailures
+		*out = make([]ReconcileFailureCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PullSecretRotatedTime != nil {
+		in, out := &in.PullSecretRotatedTime, &out.PullSecretRotatedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationStatus.
+func (in *ClusterRegistrationStatus) DeepCopy() *ClusterRegistrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRegistrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionCheck) DeepCopyInto(out *ClusterVersionCheck) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -36,6 +640,117 @@ func (in *GitLabIdentityProvider) DeepCopyInto(out *GitLabIdentityProvider) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionCheck.
+func (in *ClusterVersionCheck) DeepCopy() *ClusterVersionCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterVersionCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionCheckList) DeepCopyInto(out *ClusterVersionCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterVersionCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionCheckList.
+func (in *ClusterVersionCheckList) DeepCopy() *ClusterVersionCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterVersionCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionCheckSpec) DeepCopyInto(out *ClusterVersionCheckSpec) {
+	*out = *in
+	out.LocalClusterReference = in.LocalClusterReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionCheckSpec.
+func (in *ClusterVersionCheckSpec) DeepCopy() *ClusterVersionCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionCheckStatus) DeepCopyInto(out *ClusterVersionCheckStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AvailableUpgrades != nil {
+		in, out := &in.AvailableUpgrades, &out.AvailableUpgrades
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastCheckedTime != nil {
+		in, out := &in.LastCheckedTime, &out.LastCheckedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionCheckStatus.
+func (in *ClusterVersionCheckStatus) DeepCopy() *ClusterVersionCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabIdentityProvider) DeepCopyInto(out *GitLabIdentityProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabIdentityProvider.
 func (in *GitLabIdentityProvider) DeepCopy() *GitLabIdentityProvider {
 	if in == nil {
@@ -89,36 +804,97 @@ func (in *GitLabIdentityProviderList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitLabIdentityProviderSpec) DeepCopyInto(out *GitLabIdentityProviderSpec) {
 	*out = *in
+	out.LocalClusterReference = in.LocalClusterReference
+	if in.Challenge != nil {
+		in, out := &in.Challenge, &out.Challenge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MigrateFrom != nil {
+		in, out := &in.MigrateFrom, &out.MigrateFrom
+		*out = new(IdentityProviderMigration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabIdentityProviderSpec.
+func (in *GitLabIdentityProviderSpec) DeepCopy() *GitLabIdentityProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabIdentityProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabIdentityProviderStatus) DeepCopyInto(out *GitLabIdentityProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]OCMOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileFailures != nil {
+		in, out := &in.ReconcileFailures, &out.ReconcileFailures
+		*out = make([]ReconcileFailureCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabIdentityProviderSpec.
-func (in *GitLabIdentityProviderSpec) DeepCopy() *GitLabIdentityProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabIdentityProviderStatus.
+func (in *GitLabIdentityProviderStatus) DeepCopy() *GitLabIdentityProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GitLabIdentityProviderSpec)
+	out := new(GitLabIdentityProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitLabIdentityProviderStatus) DeepCopyInto(out *GitLabIdentityProviderStatus) {
+func (in *IdentityProviderMigration) DeepCopyInto(out *IdentityProviderMigration) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabIdentityProviderStatus.
-func (in *GitLabIdentityProviderStatus) DeepCopy() *GitLabIdentityProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderMigration.
+func (in *IdentityProviderMigration) DeepCopy() *IdentityProviderMigration {
 	if in == nil {
 		return nil
 	}
-	out := new(GitLabIdentityProviderStatus)
+	out := new(IdentityProviderMigration)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -186,6 +962,17 @@ func (in *LDAPIdentityProviderList) DeepCopyObject() runtime.Object {
 func (in *LDAPIdentityProviderSpec) DeepCopyInto(out *LDAPIdentityProviderSpec) {
 	*out = *in
 	in.LDAPIdentityProvider.DeepCopyInto(&out.LDAPIdentityProvider)
+	out.LocalClusterReference = in.LocalClusterReference
+	if in.Challenge != nil {
+		in, out := &in.Challenge, &out.Challenge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPIdentityProviderSpec.
@@ -208,6 +995,41 @@ func (in *LDAPIdentityProviderStatus) DeepCopyInto(out *LDAPIdentityProviderStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]OCMOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileFailures != nil {
+		in, out := &in.ReconcileFailures, &out.ReconcileFailures
+		*out = make([]ReconcileFailureCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = new(configv1.LDAPAttributeMapping)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPIdentityProviderStatus.
@@ -220,6 +1042,21 @@ func (in *LDAPIdentityProviderStatus) DeepCopy() *LDAPIdentityProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalClusterReference) DeepCopyInto(out *LocalClusterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalClusterReference.
+func (in *LocalClusterReference) DeepCopy() *LocalClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 	*out = *in
@@ -283,6 +1120,13 @@ func (in *MachinePoolList) DeepCopyObject() runtime.Object {
 func (in *MachinePoolProviderAWS) DeepCopyInto(out *MachinePoolProviderAWS) {
 	*out = *in
 	out.SpotInstances = in.SpotInstances
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolProviderAWS.
@@ -310,9 +1154,26 @@ func (in *MachinePoolProviderAWSSpotInstances) DeepCopy() *MachinePoolProviderAW
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolSchedule) DeepCopyInto(out *MachinePoolSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolSchedule.
+func (in *MachinePoolSchedule) DeepCopy() *MachinePoolSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 	*out = *in
+	out.LocalClusterReference = in.LocalClusterReference
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -327,7 +1188,22 @@ func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.AWS = in.AWS
+	in.AWS.DeepCopyInto(&out.AWS)
+	if in.AutoRepair != nil {
+		in, out := &in.AutoRepair, &out.AutoRepair
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]MachinePoolSchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.RawOverrides != nil {
+		in, out := &in.RawOverrides, &out.RawOverrides
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolSpec.
@@ -350,6 +1226,36 @@ func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make([]OCMOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileFailures != nil {
+		in, out := &in.ReconcileFailures, &out.ReconcileFailures
+		*out = make([]ReconcileFailureCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
 	if in.AvailabilityZones != nil {
 		in, out := &in.AvailabilityZones, &out.AvailabilityZones
 		*out = make([]string, len(*in))
@@ -360,6 +1266,16 @@ func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(MachinePoolUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockedReasons != nil {
+		in, out := &in.BlockedReasons, &out.BlockedReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolStatus.
@@ -371,3 +1287,243 @@ func (in *MachinePoolStatus) DeepCopy() *MachinePoolStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolUpgradeStatus) DeepCopyInto(out *MachinePoolUpgradeStatus) {
+	*out = *in
+	if in.NextRun != nil {
+		in, out := &in.NextRun, &out.NextRun
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolUpgradeStatus.
+func (in *MachinePoolUpgradeStatus) DeepCopy() *MachinePoolUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMOperation) DeepCopyInto(out *OCMOperation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMOperation.
+func (in *OCMOperation) DeepCopy() *OCMOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseTiming) DeepCopyInto(out *PhaseTiming) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseTiming.
+func (in *PhaseTiming) DeepCopy() *PhaseTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseTiming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileFailureCount) DeepCopyInto(out *ReconcileFailureCount) {
+	*out = *in
+	in.Hour.DeepCopyInto(&out.Hour)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileFailureCount.
+func (in *ReconcileFailureCount) DeepCopy() *ReconcileFailureCount {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileFailureCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileReport) DeepCopyInto(out *ReconcileReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileReport.
+func (in *ReconcileReport) DeepCopy() *ReconcileReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReconcileReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileReportList) DeepCopyInto(out *ReconcileReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReconcileReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileReportList.
+func (in *ReconcileReportList) DeepCopy() *ReconcileReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReconcileReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileReportResource) DeepCopyInto(out *ReconcileReportResource) {
+	*out = *in
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileReportResource.
+func (in *ReconcileReportResource) DeepCopy() *ReconcileReportResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileReportResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileReportSpec) DeepCopyInto(out *ReconcileReportSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileReportSpec.
+func (in *ReconcileReportSpec) DeepCopy() *ReconcileReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileReportStatus) DeepCopyInto(out *ReconcileReportStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastGeneratedTime != nil {
+		in, out := &in.LastGeneratedTime, &out.LastGeneratedTime
+		*out = (*in).DeepCopy()
+	}
+	out.Summary = in.Summary
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ReconcileReportResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileReportStatus.
+func (in *ReconcileReportStatus) DeepCopy() *ReconcileReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileReportSummary) DeepCopyInto(out *ReconcileReportSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileReportSummary.
+func (in *ReconcileReportSummary) DeepCopy() *ReconcileReportSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileReportSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileTiming) DeepCopyInto(out *ReconcileTiming) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	out.Duration = in.Duration
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]PhaseTiming, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileTiming.
+func (in *ReconcileTiming) DeepCopy() *ReconcileTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileTiming)
+	in.DeepCopyInto(out)
+	return out
+}
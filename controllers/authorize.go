@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// The controllers must be able to read the cluster management bindings to determine which clusters
+// a namespace is allowed to manage.
+
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clustermanagementbindings,verbs=get;list;watch
+
+// ManagementAllowed determines if custom resources within a namespace are allowed to manage a
+// cluster in OpenShift Cluster Manager.  Management of all clusters is allowed from all namespaces
+// until the first cluster management binding is created, so that single tenant installations are
+// unaffected.
+func ManagementAllowed(ctx context.Context, r kubernetes.Client, namespace, clusterName, clusterID string) (bool, error) {
+	bindings := &ocmv1alpha1.ClusterManagementBindingList{}
+	if err := r.List(ctx, bindings); err != nil {
+		return false, fmt.Errorf("unable to list cluster management bindings - %w", err)
+	}
+
+	if len(bindings.Items) == 0 {
+		return true, nil
+	}
+
+	return bindings.Allows(namespace, clusterName, clusterID), nil
+}
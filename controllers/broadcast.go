@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	// DefaultConnectionCheckInterval is the default interval at which the connection to OpenShift
+	// Cluster Manager is checked by the connection monitor.
+	DefaultConnectionCheckInterval = time.Minute
+
+	broadcastObjectName = "ocm-operator-broadcast"
+)
+
+// Broadcaster notifies each subscribed controller that all of the objects which it manages should be
+// reconciled immediately, rather than waiting for their individual requeue intervals.
+type Broadcaster struct {
+	mutex       sync.Mutex
+	subscribers []chan event.GenericEvent
+}
+
+// NewBroadcaster returns a new broadcaster without any subscribers.
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{}
+}
+
+// Subscribe returns a source which receives each broadcast.  The source must be watched by a
+// controller with the EnqueueAll event handler.
+func (broadcaster *Broadcaster) Subscribe() source.Source {
+	channel := make(chan event.GenericEvent, 1)
+
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	broadcaster.subscribers = append(broadcaster.subscribers, channel)
+
+	return &source.Channel{Source: channel}
+}
+
+// Broadcast notifies each subscriber to reconcile all of its objects.  It does not block, so a broadcast
+// is dropped for a subscriber which has not yet received the previous broadcast.
+func (broadcaster *Broadcaster) Broadcast() {
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	for _, subscriber := range broadcaster.subscribers {
+		select {
+		case subscriber <- event.GenericEvent{Object: &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: broadcastObjectName},
+		}}:
+		default:
+		}
+	}
+}
+
+// BroadcastPredicate returns a predicate which only allows broadcast events.  It allows broadcast events
+// through the event filters of a controller, which otherwise ignore generic events.
+func BroadcastPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return e.Object.GetName() == broadcastObjectName
+		},
+	}
+}
+
+// EnqueueAll returns an event handler which enqueues every object of a list type upon a broadcast.
+func EnqueueAll(r kubernetes.Client, list client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ client.Object) []reconcile.Request {
+		objects, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return nil
+		}
+
+		if err := r.List(context.Background(), objects); err != nil {
+			return nil
+		}
+
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(items))
+
+		for _, item := range items {
+			object, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+		}
+
+		return requests
+	})
+}
+
+// ConnectionMonitor periodically checks the connection to an environment of OpenShift Cluster Manager
+// and broadcasts a reconciliation of all objects when the connection recovers, for example once an
+// outage is over or an expired token has been refreshed.
+type ConnectionMonitor struct {
+	// Environment is the name of the environment whose connection is checked.
+	Environment string
+
+	Connection  *sdk.Connection
+	Broadcaster *Broadcaster
+	Log         logr.Logger
+	Interval    time.Duration
+
+	// unhealthy tracks whether the previous connection check failed
+	unhealthy bool
+}
+
+// Start implements the manager.Runnable interface.  It checks the connection at each interval until
+// the context is cancelled.
+func (monitor *ConnectionMonitor) Start(ctx context.Context) error {
+	interval := monitor.Interval
+	if interval == 0 {
+		interval = DefaultConnectionCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if monitor.Observe(ocm.CheckConnection(ctx, monitor.Connection)) {
+			monitor.Log.Info("connection to openshift cluster manager recovered; reconciling all objects", "environment", monitor.Environment)
+			monitor.Broadcaster.Broadcast()
+		}
+	}
+}
+
+// Observe records the result of a connection check and returns whether the connection has recovered
+// since the previous check.
+func (monitor *ConnectionMonitor) Observe(err error) bool {
+	if err != nil {
+		if !monitor.unhealthy {
+			monitor.Log.Error(err, "connection to openshift cluster manager is unhealthy", "environment", monitor.Environment)
+		}
+
+		monitor.unhealthy = true
+
+		return false
+	}
+
+	recovered := monitor.unhealthy
+	monitor.unhealthy = false
+
+	return recovered
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+var errTestConnection = errors.New("connection refused")
+
+func TestConnectionMonitor_Observe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		checks []error
+		want   []bool
+	}{
+		{
+			name:   "ensure a healthy connection does not broadcast",
+			checks: []error{nil, nil},
+			want:   []bool{false, false},
+		},
+		{
+			name:   "ensure a recovered connection broadcasts once",
+			checks: []error{errTestConnection, errTestConnection, nil, nil},
+			want:   []bool{false, false, true, false},
+		},
+		{
+			name:   "ensure each recovery broadcasts",
+			checks: []error{errTestConnection, nil, errTestConnection, nil},
+			want:   []bool{false, true, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			monitor := &ConnectionMonitor{Log: logr.Discard()}
+
+			for i, check := range tt.checks {
+				if got := monitor.Observe(check); got != tt.want[i] {
+					t.Errorf("Observe() check %d = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBroadcaster_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	broadcaster := NewBroadcaster()
+
+	subscribers := []*source.Channel{}
+
+	for i := 0; i < 2; i++ {
+		subscriber, ok := broadcaster.Subscribe().(*source.Channel)
+		if !ok {
+			t.Fatalf("Subscribe() = %T, want %T", subscriber, &source.Channel{})
+		}
+
+		subscribers = append(subscribers, subscriber)
+	}
+
+	// a second broadcast must not block when the first has not yet been received
+	broadcaster.Broadcast()
+	broadcaster.Broadcast()
+
+	for i, subscriber := range subscribers {
+		select {
+		case received := <-subscriber.Source:
+			if !BroadcastPredicate().Generic(received) {
+				t.Errorf("BroadcastPredicate() subscriber %d = %v, want %v", i, false, true)
+			}
+		default:
+			t.Errorf("Broadcast() subscriber %d received = %v, want %v", i, false, true)
+		}
+
+		select {
+		case <-subscriber.Source:
+			t.Errorf("Broadcast() subscriber %d received duplicate = %v, want %v", i, true, false)
+		default:
+		}
+	}
+}
+
+func TestBroadcastPredicate(t *testing.T) {
+	t.Parallel()
+
+	object := &ocmv1alpha1.MachinePool{}
+	object.SetName("test")
+
+	if got := BroadcastPredicate().Generic(event.GenericEvent{Object: object}); got {
+		t.Errorf("BroadcastPredicate() = %v, want %v", got, false)
+	}
+
+	if got := BroadcastPredicate().Create(event.CreateEvent{Object: object}); got {
+		t.Errorf("BroadcastPredicate() = %v, want %v", got, false)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterinfo
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+)
+
+const (
+	defaultClusterInfoRequeue = 30 * time.Second
+
+	controllerName = "clusterinfo"
+)
+
+// Controller publishes the resolved details of the cluster of a ClusterReference object into a config
+// map, so that consumers outside of the operator, such as pipelines, may consume them without
+// credentials for OpenShift Cluster Manager.  The config map is owned by the cluster reference.  The
+// cluster itself is resolved by the cluster reference controller, so this controller never calls
+// OpenShift Cluster Manager.
+type Controller struct {
+	client.Client
+
+	controllers.Dependencies
+
+	Scheme *runtime.Scheme
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
+}
+
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusterreferences,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusterreferences/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+//nolint:wrapcheck
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return controllers.Reconcile(ctx, r, req)
+}
+
+// ReconcileCreate performs the reconciliation logic when a create event triggered
+// the reconciliation.
+func (r *Controller) ReconcileCreate(req controllers.Request) (ctrl.Result, error) {
+	// type cast the request to a cluster info request
+	request, ok := req.(*ClusterInfoRequest)
+	if !ok {
+		return controllers.RequeueAfter(r.requeue()), ErrClusterInfoRequestConvert
+	}
+
+	// execute the phases
+	return request.execute([]Phase{
+		{Name: "waitForResolution", Function: r.WaitForResolution},
+		{Name: "publish", Function: r.Publish},
+		{Name: "unpublish", Function: r.Unpublish},
+		{Name: "complete", Function: r.Complete},
+	}...)
+}
+
+// ReconcileUpdate performs the reconciliation logic when an update event triggered
+// the reconciliation.  In this instance, create and update share identical logic
+// so we are simply calling the ReconcileCreate method.
+func (r *Controller) ReconcileUpdate(req controllers.Request) (ctrl.Result, error) {
+	return r.ReconcileCreate(req)
+}
+
+// ReconcileDelete performs the reconciliation logic when a delete event triggered
+// the reconciliation.  The config map is owned by the cluster reference and is removed
+// by garbage collection, so there is nothing to clean up.
+func (r *Controller) ReconcileDelete(req controllers.Request) (ctrl.Result, error) {
+	return controllers.NoRequeue(), nil
+}
+
+// requeue returns the interval after which a failed or incomplete reconciliation is retried.
+func (r *Controller) requeue() time.Duration {
+	if r.Requeue == 0 {
+		return defaultClusterInfoRequeue
+	}
+
+	return r.Requeue
+}
+
+// SetupWithManager sets up the controller with the Manager.  The controller is named explicitly
+// as the cluster reference controller reconciles the same kind.  Unlike the other controllers, updates
+// to the status of a cluster reference which change the details of its cluster are allowed through, so
+// that the config map follows the cluster as soon as it is refreshed.  Config maps which are owned by a
+// cluster reference are watched so that a deleted config map is restored immediately.
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	managedBy := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options()).
+		Named(controllerName).
+		WithEventFilter(predicate.Or(
+			controllers.WorkloadPredicates(),
+			controllers.BroadcastPredicate(),
+			clusterInfoChangedPredicate(),
+		)).
+		For(&ocmv1alpha1.ClusterReference{}).
+		Owns(&corev1.ConfigMap{})
+
+	if r.Broadcaster != nil {
+		managedBy = managedBy.Watches(r.Broadcaster.Subscribe(), controllers.EnqueueAll(r, &ocmv1alpha1.ClusterReferenceList{}))
+	}
+
+	return managedBy.Complete(r)
+}
+
+// clusterInfoChangedPredicate returns a predicate which only allows updates of cluster references which
+// change the details of the cluster which are published in the config map.
+func clusterInfoChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old, ok := e.ObjectOld.(*ocmv1alpha1.ClusterReference)
+			if !ok {
+				return false
+			}
+
+			updated, ok := e.ObjectNew.(*ocmv1alpha1.ClusterReference)
+			if !ok {
+				return false
+			}
+
+			return !reflect.DeepEqual(old.ClusterInfo(), updated.ClusterInfo())
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package clusterinfo
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func TestClusterInfoChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	reference := func(version string, resolved metav1.Time) *ocmv1alpha1.ClusterReference {
+		return &ocmv1alpha1.ClusterReference{
+			Spec: ocmv1alpha1.ClusterReferenceSpec{ClusterName: "dev", ConfigMapName: "dev-info"},
+			Status: ocmv1alpha1.ClusterReferenceStatus{
+				ClusterID:        "abc123",
+				Version:          version,
+				LastResolvedTime: &resolved,
+			},
+		}
+	}
+
+	earlier, later := metav1.NewTime(time.Unix(0, 0)), metav1.NewTime(time.Unix(60, 0))
+
+	tests := []struct {
+		name string
+		old  client.Object
+		new  client.Object
+		want bool
+	}{
+		{
+			name: "ensure an upgraded cluster triggers reconciliation",
+			old:  reference("4.12.1", earlier),
+			new:  reference("4.12.2", later),
+			want: true,
+		},
+		{
+			name: "ensure a refreshed status without changes does not trigger reconciliation",
+			old:  reference("4.12.1", earlier),
+			new:  reference("4.12.1", later),
+			want: false,
+		},
+		{
+			name: "ensure other objects are not allowed",
+			old:  &ocmv1alpha1.MachinePool{},
+			new:  &ocmv1alpha1.MachinePool{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := clusterInfoChangedPredicate().Update(event.UpdateEvent{ObjectOld: tt.old, ObjectNew: tt.new}); got != tt.want {
+				t.Errorf("clusterInfoChangedPredicate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package clusterinfo
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*ClusterInfoRequest]
+
+// WaitForResolution waits for the cluster to be resolved from OpenShift Cluster Manager by the cluster
+// reference controller before its details are published.  A cluster reference which does not publish
+// the details of its cluster is not waited for.
+func (r *Controller) WaitForResolution(request *ClusterInfoRequest) (ctrl.Result, error) {
+	if request.Original.Spec.ConfigMapName == "" {
+		return controllers.NoRequeue(), nil
+	}
+
+	if !request.Original.Resolved() {
+		request.Log.V(controllers.LogLevelDebug).Info("waiting for cluster resolution", request.logValues()...)
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Publish stores the resolved details of the cluster in the config map of the cluster reference.  The
+// result of the publication is reported by the cluster info published condition.
+func (r *Controller) Publish(request *ClusterInfoRequest) (ctrl.Result, error) {
+	if request.Original.Spec.ConfigMapName == "" {
+		return controllers.NoRequeue(), nil
+	}
+
+	if err := kubernetes.ApplyConfigMapData(
+		request.Context,
+		r.Client,
+		r.Scheme,
+		request.Original,
+		request.Original.Spec.ConfigMapName,
+		request.Original.ClusterInfo(),
+	); err != nil {
+		err = fmt.Errorf("unable to publish cluster details - %w", err)
+
+		if conditionErr := conditions.Update(
+			request.Context,
+			request.Reconciler,
+			request.Original,
+			conditions.ClusterInfoPublishFailed(err),
+		); conditionErr != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating cluster info condition - %w", conditionErr)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.ClusterInfoPublished(request.Original.Spec.ConfigMapName),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating cluster info condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Unpublish deletes a config map which was previously published by the cluster reference and which is
+// no longer requested, because it was renamed or publishing was disabled.  It records the name of the
+// config map which is now published in the status.
+func (r *Controller) Unpublish(request *ClusterInfoRequest) (ctrl.Result, error) {
+	if !request.changed() {
+		return controllers.NoRequeue(), nil
+	}
+
+	if stale := request.Original.Status.ConfigMapName; stale != "" {
+		if err := r.deleteConfigMap(request, stale); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.ConfigMapName = request.Original.Spec.ConfigMapName
+
+	// store the name of the published config map in the status
+	if err := kubernetes.PatchStatus(request.Context, r, original, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to update status.configMapName=%s - %w",
+			request.Original.Status.ConfigMapName,
+			err,
+		)
+	}
+
+	if request.Original.Spec.ConfigMapName == "" {
+		if err := conditions.Update(
+			request.Context,
+			request.Reconciler,
+			request.Original,
+			conditions.ClusterInfoUnpublished(),
+		); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating cluster info condition - %w", err)
+		}
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// deleteConfigMap deletes a config map which was previously published by the cluster reference.  Only a
+// config map which is owned by the cluster reference is deleted, so that a config map which has since
+// been taken over by something else is left alone.
+func (r *Controller) deleteConfigMap(request *ClusterInfoRequest, name string) error {
+	request.Log.Info("deleting stale cluster info config map", append(request.logValues(), "stale", name)...)
+
+	configMap := &corev1.ConfigMap{}
+
+	if err := r.Get(request.Context, types.NamespacedName{
+		Namespace: request.Original.Namespace,
+		Name:      name,
+	}, configMap); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf(
+			"unable to retrieve config map [%s/%s] from cluster - %w",
+			request.Original.Namespace,
+			name,
+			err,
+		)
+	}
+
+	if !metav1.IsControlledBy(configMap, request.Original) {
+		return nil
+	}
+
+	if err := r.Delete(request.Context, configMap); err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf(
+			"unable to delete config map [%s/%s] from cluster - %w",
+			request.Original.Namespace,
+			name,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  The
+// config map is republished when the details of the cluster change, so there is no need to requeue.
+func (r *Controller) Complete(request *ClusterInfoRequest) (ctrl.Result, error) {
+	request.Log.Info("completed cluster info reconciliation", request.logValues()...)
+
+	return controllers.NoRequeue(), nil
+}
@@ -0,0 +1,94 @@
+package clusterinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var (
+	ErrClusterInfoRequestConvert = errors.New("unable to convert generic request to cluster info request")
+)
+
+// ClusterInfoRequest is an object that is unique to each reconciliation
+// request.
+type ClusterInfoRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ClusterReference
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ClusterReference{}
+
+	// get the object from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &ClusterInfoRequest{}, fmt.Errorf("unable to fetch cluster reference object - %w", err)
+		}
+
+		return &ClusterInfoRequest{}, err
+	}
+
+	return &ClusterInfoRequest{
+		Original:          original,
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+	}, nil
+}
+
+func (request *ClusterInfoRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *ClusterInfoRequest) bindContext(ctx context.Context) {
+	// the clients of the request are created from the bound context within each phase rather than
+	// being shared between phases, so binding the context binds each of them
+	request.Context = ctx
+}
+
+// execute executes a variety of different phases for the request.  Unlike the other controllers,
+// failures are not recorded in the reconciliation conditions of the object, as those belong to the
+// cluster reference controller.  They are instead reported by the cluster info published condition.
+func (request *ClusterInfoRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Unrecorded: true,
+	}, request, phases...)
+}
+
+// changed determines if the name of the config map in which the details of the cluster are published
+// has changed since they were last published, including when publishing is enabled or disabled.
+func (request *ClusterInfoRequest) changed() bool {
+	return request.Original.Status.ConfigMapName != request.Original.Spec.ConfigMapName
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *ClusterInfoRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", fmt.Sprintf("%s/%s", request.Original.Namespace, request.Original.Name),
+		"cluster", request.Original.Spec.ClusterName,
+		"configMap", request.Original.Spec.ConfigMapName,
+	}
+}
@@ -0,0 +1,290 @@
+package clusterlabels
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*ClusterLabelsRequest]
+
+// Begin begins the reconciliation state once we get the object (the desired state) from the cluster.
+// It is mainly used to set conditions of the controller and to let anyone who is viewiing the
+// custom resource know that we are currently reconciling.
+func (r *Controller) Begin(request *ClusterLabelsRequest) (ctrl.Result, error) {
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Authorize ensures that the namespace of the ClusterLabels resource has been granted management of its
+// cluster by a cluster management binding.  A forbidden resource is reported with a Forbidden condition and
+// a warning event, and the request is retried at the regular interval.  A forbidden resource which is
+// deleted is released without deleting anything from OpenShift Cluster Manager.
+func (r *Controller) Authorize(request *ClusterLabelsRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Desired.Spec.ClusterName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Desired.Spec.ClusterName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	// release the deleted object without touching openshift cluster manager, as the namespace is
+	// not allowed to manage the cluster
+	if request.Trigger == triggers.Delete {
+		if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// GetCurrentState gets the current state of the ClusterLabels resource.  The current state of the
+// ClusterLabels resource is the set of labels stored in OpenShift Cluster Manager for the subscription
+// of the cluster.  It will be compared against the desired state which exists within the OpenShift
+// cluster in which this controller is reconciling against.
+func (r *Controller) GetCurrentState(request *ClusterLabelsRequest) (ctrl.Result, error) {
+	// retrieve the cluster
+	clusterClient := ocm.NewClusterClient(request.Environment.Connection, request.Desired.Spec.ClusterName).
+		WithOrganizationGuard(request.Environment.Organizations).
+		WithContext(request.Context)
+
+	cluster, err := clusterClient.Get()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve cluster from ocm [name=%s] - %w",
+			request.Desired.Spec.ClusterName,
+			err,
+		)
+	}
+
+	request.Cluster = cluster
+
+	// store the cluster and subscription id in the status
+	if err := request.updateStatusCluster(); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	// get the subscription labels from ocm
+	request.OCMClient = ocm.NewSubscriptionLabelClient(request.Environment.Connection, request.Original.Status.SubscriptionID).
+		WithContext(request.Context)
+
+	labels, err := request.OCMClient.List()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve subscription labels from ocm - %w",
+			err,
+		)
+	}
+
+	request.Labels = labels
+
+	return controllers.NoRequeue(), nil
+}
+
+// ApplyLabels applies the desired state of the subscription labels to OCM.  Labels which are desired
+// but missing are created, labels with an undesired value are updated, and labels which were
+// previously managed by this resource but are no longer desired are removed.
+func (r *Controller) ApplyLabels(request *ClusterLabelsRequest) (ctrl.Result, error) {
+	missing, changed, extra := request.missingLabels(), request.changedLabels(), request.extraLabels()
+
+	// return if it is already in its desired state
+	if len(missing) == 0 && len(changed) == 0 && len(extra) == 0 {
+		request.Log.V(controllers.LogLevelDebug).Info("subscription labels already in desired state", request.logValues()...)
+
+		if err := request.updateStatusManagedLabels(request.ownedLabels(nil, nil)); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// create the missing labels
+	for _, key := range missing {
+		request.Log.Info(fmt.Sprintf("creating subscription label [%s]", key), request.logValues()...)
+
+		_, err := request.OCMClient.Create(key, request.Desired.Spec.Labels[key])
+		request.recordOperation(ocmv1alpha1.OCMOperationCreate, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to create subscription label [%s] in ocm - %w",
+				key,
+				err,
+			)
+		}
+
+		// create an event indicating that the label has been created
+		events.RegisterAction(events.Created, request.Original, r.Recorder, key, request.Original.Status.ClusterID)
+	}
+
+	// update the labels which do not have their desired value
+	for _, key := range changed {
+		request.Log.Info(fmt.Sprintf("updating subscription label [%s]", key), request.logValues()...)
+
+		_, err := request.OCMClient.Update(key, request.Desired.Spec.Labels[key])
+		request.recordOperation(ocmv1alpha1.OCMOperationUpdate, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to update subscription label [%s] in ocm - %w",
+				key,
+				err,
+			)
+		}
+
+		// create an event indicating that the label has been updated
+		events.RegisterAction(events.Updated, request.Original, r.Recorder, key, request.Original.Status.ClusterID)
+	}
+
+	// remove the labels which are no longer desired
+	for _, key := range extra {
+		request.Log.Info(fmt.Sprintf("deleting subscription label [%s]", key), request.logValues()...)
+
+		err := request.OCMClient.Delete(key)
+		request.recordOperation(ocmv1alpha1.OCMOperationDelete, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to delete subscription label [%s] from ocm - %w",
+				key,
+				err,
+			)
+		}
+
+		// create an event indicating that the label has been deleted
+		events.RegisterAction(events.Deleted, request.Original, r.Recorder, key, request.Original.Status.ClusterID)
+	}
+
+	// store the keys of the labels which were created or taken over by this resource
+	if err := request.updateStatusManagedLabels(request.ownedLabels(missing, changed)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Destroy will remove the managed subscription labels from OpenShift Cluster Manager.  Labels which
+// were not created or taken over by this resource are left untouched.
+func (r *Controller) Destroy(request *ClusterLabelsRequest) (ctrl.Result, error) {
+	// return immediately if we have already deleted the labels
+	if conditions.IsSet(conditions.ClusterLabelsDeleted(), request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	// leave the subscription labels in openshift cluster manager while its deletion is prevented,
+	// checking again after the requeue interval so that the deletion continues once the annotation is
+	// removed
+	if controllers.PreventDestroyRequested(request.Original) {
+		return controllers.PreventDestroy(&r.Dependencies, request.execution(), r.requeue())
+	}
+
+	// only remove the labels if we discovered the subscription, as no labels could have been
+	// created otherwise
+	if request.Original.Status.SubscriptionID != "" {
+		request.OCMClient = ocm.NewSubscriptionLabelClient(request.Environment.Connection, request.Original.Status.SubscriptionID).
+			WithContext(request.Context)
+
+		labels, err := request.OCMClient.List()
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to retrieve subscription labels from ocm - %w",
+				err,
+			)
+		}
+
+		request.Labels = labels
+
+		// delete the labels which are managed by this resource
+		for _, key := range request.managedLabels() {
+			err := request.OCMClient.Delete(key)
+			request.recordOperation(ocmv1alpha1.OCMOperationDelete, request.OCMClient.LastStatus(), err)
+
+			if err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+					"unable to delete subscription label [%s] from ocm - %w",
+					key,
+					err,
+				)
+			}
+
+			// create an event indicating that the label has been deleted
+			events.RegisterAction(events.Deleted, request.Original, r.Recorder, key, request.Original.Status.ClusterID)
+		}
+	}
+
+	// set the deleted condition
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.ClusterLabelsDeleted()); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating deleted condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  It will
+// requeue after the interval value requested by the controller configuration to ensure that the
+// object remains in its desired state at a specific interval.
+func (r *Controller) Complete(request *ClusterLabelsRequest) (ctrl.Result, error) {
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	request.Log.Info("completed cluster labels reconciliation", request.logValues()...)
+	request.Log.Info(fmt.Sprintf("reconciling again in %s", r.Interval.String()), request.logValues()...)
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// CompleteDestroy will perform all actions required to successful complete a reconciliation request.
+func (r *Controller) CompleteDestroy(request *ClusterLabelsRequest) (ctrl.Result, error) {
+	if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+	}
+
+	request.Log.Info("completed cluster labels deletion", request.logValues()...)
+
+	return controllers.NoRequeue(), nil
+}
@@ -0,0 +1,291 @@
+package clusterlabels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+	"github.com/rh-mobb/ocm-operator/pkg/utils"
+)
+
+var (
+	ErrMissingClusterID            = errors.New("unable to find cluster id")
+	ErrMissingSubscriptionID       = errors.New("unable to find subscription id")
+	ErrClusterLabelsRequestConvert = errors.New("unable to convert generic request to cluster labels request")
+)
+
+// ClusterLabelsRequest is an object that is unique to each reconciliation
+// request.
+type ClusterLabelsRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ClusterLabels
+	Desired           *ocmv1alpha1.ClusterLabels
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+	Environment       *ocm.Environment
+	OCMClient         *ocm.SubscriptionLabelClient
+
+	// data obtained during request reconciliation
+	Cluster *clustersmgmtv1.Cluster
+	Labels  []*accountsmgmtv1.Label
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ClusterLabels{}
+
+	// get the object (desired state) from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &ClusterLabelsRequest{}, fmt.Errorf("unable to fetch cluster object - %w", err)
+		}
+
+		return &ClusterLabelsRequest{}, err
+	}
+
+	// resolve the cluster of an object which references a cluster reference rather than naming
+	// its cluster
+	if err := controllers.ResolveClusterReference(ctx, r, original, r.requeue()); err != nil {
+		return &ClusterLabelsRequest{}, conditions.RecordWaiting(ctx, r, original, err)
+	}
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection, Organizations: r.Organizations},
+		original,
+	)
+	if err != nil {
+		return &ClusterLabelsRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
+	}
+
+	return &ClusterLabelsRequest{
+		Original:          original,
+		Desired:           original.DeepCopy(),
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+		Environment:       environment,
+	}, nil
+}
+
+func (request *ClusterLabelsRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *ClusterLabelsRequest) bindContext(ctx context.Context) {
+	request.Context = ctx
+
+	// the client which is shared between phases is bound to the context of each phase
+	if request.OCMClient != nil {
+		request.OCMClient.WithContext(ctx)
+	}
+}
+
+// execution returns the execution of the phases of the request.
+func (request *ClusterLabelsRequest) execution() *controllers.Execution {
+	return &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
+	}
+}
+
+// execute executes a variety of different phases for the request.
+func (request *ClusterLabelsRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, request.execution(), request, phases...)
+}
+
+// recordOperation records an operation which was sent to OCM in the operation history of the object.
+// Errors recording the operation are logged rather than returned so that the result of the operation
+// is not masked.
+func (request *ClusterLabelsRequest) recordOperation(operation string, status int, err error) {
+	if recordErr := controllers.RecordOperation(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		operation,
+		status,
+		err,
+	); recordErr != nil {
+		request.Log.V(controllers.LogLevelDebug).Info(
+			fmt.Sprintf("unable to record %s operation - %s", strings.ToLower(operation), recordErr),
+			request.logValues()...,
+		)
+	}
+}
+
+// updateStatusCluster updates fields related to the cluster in which the labels are managed for.
+func (request *ClusterLabelsRequest) updateStatusCluster() error {
+	// if the cluster or subscription id is missing return an error
+	if request.Cluster.ID() == "" {
+		return fmt.Errorf("missing cluster id in response - %w", ErrMissingClusterID)
+	}
+
+	if request.Cluster.Subscription().ID() == "" {
+		return fmt.Errorf("missing subscription id in response - %w", ErrMissingSubscriptionID)
+	}
+
+	// return if the status is already up to date
+	if request.Original.Status.ClusterID == request.Cluster.ID() &&
+		request.Original.Status.SubscriptionID == request.Cluster.Subscription().ID() {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.ClusterID = request.Cluster.ID()
+	request.Original.Status.SubscriptionID = request.Cluster.Subscription().ID()
+
+	// store the cluster and subscription id in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.clusterID=%s, status.subscriptionID=%s - %w",
+			request.Original.Status.ClusterID,
+			request.Original.Status.SubscriptionID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// updateStatusManagedLabels updates the keys of the labels which are managed by this resource in
+// the status.
+func (request *ClusterLabelsRequest) updateStatusManagedLabels(keys []string) error {
+	// return if the status is already up to date
+	if reflect.DeepEqual(request.Original.Status.ManagedLabels, keys) {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.ManagedLabels = keys
+
+	// store the managed label keys in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.managedLabels=%s - %w",
+			strings.Join(keys, ","),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *ClusterLabelsRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", fmt.Sprintf("%s/%s", request.Desired.Namespace, request.Desired.Name),
+		"cluster", request.Desired.Spec.ClusterName,
+	}
+}
+
+// missingLabels returns the keys of the desired labels which do not currently exist on the
+// subscription in OCM.
+func (request *ClusterLabelsRequest) missingLabels() (missing []string) {
+	for _, key := range request.Desired.DesiredKeys() {
+		if request.currentLabel(key) == nil {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing
+}
+
+// changedLabels returns the keys of the desired labels which currently exist on the subscription
+// in OCM with a value other than the desired value.
+func (request *ClusterLabelsRequest) changedLabels() (changed []string) {
+	for _, key := range request.Desired.DesiredKeys() {
+		if label := request.currentLabel(key); label != nil && label.Value() != request.Desired.Spec.Labels[key] {
+			changed = append(changed, key)
+		}
+	}
+
+	return changed
+}
+
+// extraLabels returns the keys of the labels which were previously managed by this resource and
+// currently exist on the subscription in OCM, but are no longer desired.  Labels which are not
+// managed by this resource are never returned.
+func (request *ClusterLabelsRequest) extraLabels() (extra []string) {
+	for _, key := range request.Original.Status.ManagedLabels {
+		if _, desired := request.Desired.Spec.Labels[key]; desired {
+			continue
+		}
+
+		if request.currentLabel(key) != nil {
+			extra = append(extra, key)
+		}
+	}
+
+	return extra
+}
+
+// managedLabels returns the keys of the labels which currently exist on the subscription in OCM
+// and are managed by this request, because they were created or taken over by it.  A desired label
+// which already existed with its desired value was never applied by this request and is not returned.
+func (request *ClusterLabelsRequest) managedLabels() (managed []string) {
+	for _, label := range request.Labels {
+		if utils.ContainsString(request.Original.Status.ManagedLabels, label.Key()) {
+			managed = append(managed, label.Key())
+		}
+	}
+
+	sort.Strings(managed)
+
+	return managed
+}
+
+// ownedLabels returns the keys of the desired labels which are managed by this request once the
+// missing labels have been created and the changed labels have been taken over.  Labels which were
+// previously managed remain managed while they are desired.
+func (request *ClusterLabelsRequest) ownedLabels(missing, changed []string) (owned []string) {
+	for _, key := range request.Desired.DesiredKeys() {
+		if utils.ContainsString(missing, key) ||
+			utils.ContainsString(changed, key) ||
+			utils.ContainsString(request.Original.Status.ManagedLabels, key) {
+			owned = append(owned, key)
+		}
+	}
+
+	return owned
+}
+
+// currentLabel returns the label with a particular key which currently exists on the subscription
+// in OCM, or nil if it does not exist.
+func (request *ClusterLabelsRequest) currentLabel(key string) *accountsmgmtv1.Label {
+	for _, label := range request.Labels {
+		if label.Key() == key {
+			return label
+		}
+	}
+
+	return nil
+}
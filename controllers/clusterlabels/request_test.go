@@ -0,0 +1,231 @@
+package clusterlabels
+
+import (
+	"reflect"
+	"testing"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func testLabel(t *testing.T, key, value string) *accountsmgmtv1.Label {
+	t.Helper()
+
+	label, err := accountsmgmtv1.NewLabel().Key(key).Value(value).Build()
+	if err != nil {
+		t.Fatalf("unable to build label [%s] - %s", key, err)
+	}
+
+	return label
+}
+
+func testRequest(desired map[string]string, managed []string, labels []*accountsmgmtv1.Label) *ClusterLabelsRequest {
+	object := &ocmv1alpha1.ClusterLabels{
+		Spec:   ocmv1alpha1.ClusterLabelsSpec{Labels: desired},
+		Status: ocmv1alpha1.ClusterLabelsStatus{ManagedLabels: managed},
+	}
+
+	return &ClusterLabelsRequest{
+		Original: object,
+		Desired:  object.DeepCopy(),
+		Labels:   labels,
+	}
+}
+
+func TestClusterLabelsRequest_missingLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired map[string]string
+		labels  []*accountsmgmtv1.Label
+		want    []string
+	}{
+		{
+			name:    "ensure all labels are missing when none exist",
+			desired: map[string]string{"team": "platform", "environment": "production"},
+			labels:  nil,
+			want:    []string{"environment", "team"},
+		},
+		{
+			name:    "ensure existing labels are not missing regardless of value",
+			desired: map[string]string{"team": "platform", "environment": "production"},
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "other"), testLabel(t, "environment", "production")},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := testRequest(tt.desired, nil, tt.labels)
+			if got := request.missingLabels(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterLabelsRequest.missingLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterLabelsRequest_changedLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired map[string]string
+		labels  []*accountsmgmtv1.Label
+		want    []string
+	}{
+		{
+			name:    "ensure labels with the desired value are not changed",
+			desired: map[string]string{"team": "platform"},
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "platform")},
+			want:    nil,
+		},
+		{
+			name:    "ensure labels with an undesired value are changed",
+			desired: map[string]string{"team": "platform", "environment": "production"},
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "other"), testLabel(t, "environment", "production")},
+			want:    []string{"team"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := testRequest(tt.desired, nil, tt.labels)
+			if got := request.changedLabels(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterLabelsRequest.changedLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterLabelsRequest_extraLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired map[string]string
+		managed []string
+		labels  []*accountsmgmtv1.Label
+		want    []string
+	}{
+		{
+			name:    "ensure previously managed labels which are no longer desired are extra",
+			desired: map[string]string{"team": "platform"},
+			managed: []string{"environment", "team"},
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "platform"), testLabel(t, "environment", "production")},
+			want:    []string{"environment"},
+		},
+		{
+			name:    "ensure labels which are not managed are never extra",
+			desired: map[string]string{"team": "platform"},
+			managed: []string{"team"},
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "platform"), testLabel(t, "owner", "fleet")},
+			want:    nil,
+		},
+		{
+			name:    "ensure previously managed labels which no longer exist are not extra",
+			desired: map[string]string{"team": "platform"},
+			managed: []string{"environment", "team"},
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "platform")},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := testRequest(tt.desired, tt.managed, tt.labels)
+			if got := request.extraLabels(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterLabelsRequest.extraLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterLabelsRequest_ownedLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired map[string]string
+		managed []string
+		missing []string
+		changed []string
+		want    []string
+	}{
+		{
+			name:    "ensure created and taken over labels are owned",
+			desired: map[string]string{"team": "platform", "environment": "production"},
+			missing: []string{"team"},
+			changed: []string{"environment"},
+			want:    []string{"environment", "team"},
+		},
+		{
+			name:    "ensure labels which already had their desired value are not owned",
+			desired: map[string]string{"team": "platform", "environment": "production"},
+			missing: []string{"team"},
+			want:    []string{"team"},
+		},
+		{
+			name:    "ensure previously managed labels remain owned while desired",
+			desired: map[string]string{"team": "platform"},
+			managed: []string{"environment", "team"},
+			want:    []string{"team"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := testRequest(tt.desired, tt.managed, nil)
+			if got := request.ownedLabels(tt.missing, tt.changed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterLabelsRequest.ownedLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterLabelsRequest_managedLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired map[string]string
+		managed []string
+		labels  []*accountsmgmtv1.Label
+		want    []string
+	}{
+		{
+			name:    "ensure only existing labels which were applied by the resource are managed",
+			desired: map[string]string{"team": "platform", "environment": "production"},
+			managed: []string{"team"},
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "platform"), testLabel(t, "environment", "production")},
+			want:    []string{"team"},
+		},
+		{
+			name:    "ensure desired labels which were not applied by the resource are not managed",
+			desired: map[string]string{"team": "platform"},
+			managed: nil,
+			labels:  []*accountsmgmtv1.Label{testLabel(t, "team", "platform")},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := testRequest(tt.desired, tt.managed, tt.labels)
+			if got := request.managedLabels(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterLabelsRequest.managedLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusternotification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	defaultClusterNotificationRequeue = 30 * time.Second
+)
+
+// Controller reconciles a ClusterNotification object
+type Controller struct {
+	client.Client
+
+	controllers.Dependencies
+
+	Scheme   *runtime.Scheme
+	Interval time.Duration
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
+}
+
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusternotifications,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusternotifications/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusternotifications/finalizers,verbs=update
+
+// RequiredAPIs returns the APIs of OpenShift Cluster Manager which the controller depends upon.  It
+// is used to satisfy the Compatible interface.
+func (r *Controller) RequiredAPIs() []ocm.API {
+	return []ocm.API{ocm.APIClustersMgmt, ocm.APIAccountsMgmt}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+//nolint:wrapcheck
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return controllers.Reconcile(ctx, r, req)
+}
+
+// ReconcileCreate performs the reconciliation logic when a create event triggered
+// the reconciliation.
+func (r *Controller) ReconcileCreate(req controllers.Request) (ctrl.Result, error) {
+	// type cast the request to a cluster notification request
+	request, ok := req.(*ClusterNotificationRequest)
+	if !ok {
+		return controllers.RequeueAfter(r.requeue()), ErrClusterNotificationRequestConvert
+	}
+
+	// add the finalizer
+	if err := controllers.AddFinalizer(request.Context, r, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to register delete hooks - %w", err)
+	}
+
+	// execute the phases
+	return request.execute([]Phase{
+		{Name: "begin", Function: r.Begin},
+		{Name: "authorize", Function: r.Authorize},
+		{Name: "getCurrentState", Function: r.GetCurrentState},
+		{Name: "applyContacts", Function: r.ApplyContacts},
+		{Name: "applySupportCase", Function: r.ApplySupportCase},
+		{Name: "complete", Function: r.Complete},
+	}...)
+}
+
+// ReconcileUpdate performs the reconciliation logic when an update event triggered
+// the reconciliation.  In this instance, create and update share identical logic
+// so we are simply calling the ReconcileCreate method.
+func (r *Controller) ReconcileUpdate(req controllers.Request) (ctrl.Result, error) {
+	return r.ReconcileCreate(req)
+}
+
+// ReconcileDelete performs the reconciliation logic when a delete event triggered
+// the reconciliation.
+func (r *Controller) ReconcileDelete(req controllers.Request) (ctrl.Result, error) {
+	// type cast the request to a cluster notification request
+	request, ok := req.(*ClusterNotificationRequest)
+	if !ok {
+		return controllers.RequeueAfter(r.requeue()), ErrClusterNotificationRequestConvert
+	}
+
+	// execute the phases
+	return request.execute([]Phase{
+		{Name: "begin", Function: r.Begin},
+		{Name: "authorize", Function: r.Authorize},
+		{Name: "destroy", Function: r.Destroy},
+		{Name: "completeDestroy", Function: r.CompleteDestroy},
+	}...)
+}
+
+// requeue returns the interval after which a failed or incomplete reconciliation is retried.
+func (r *Controller) requeue() time.Duration {
+	if r.Requeue == 0 {
+		return defaultClusterNotificationRequeue
+	}
+
+	return r.Requeue
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	// index the cluster, and the cluster reference, which each object targets, so that the objects of
+	// a cluster or of a cluster reference may be looked up
+	if err := controllers.SetupIndexes(
+		context.Background(),
+		mgr.GetFieldIndexer(),
+		&ocmv1alpha1.ClusterNotification{},
+		controllers.IndexBy(controllers.IndexClusterName, (*ocmv1alpha1.ClusterNotification).GetClusterName),
+		controllers.IndexBy(controllers.IndexClusterReference, controllers.ClusterReferenceOf[*ocmv1alpha1.ClusterNotification]),
+	); err != nil {
+		return fmt.Errorf("unable to index cluster notifications - %w", err)
+	}
+
+	managedBy := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options()).
+		WithEventFilter(predicate.Or(
+			controllers.WorkloadPredicates(),
+			controllers.BroadcastPredicate(),
+			controllers.ClusterReferencePredicate(),
+		)).
+		For(&ocmv1alpha1.ClusterNotification{}).
+		Watches(&source.Kind{Type: &ocmv1alpha1.ClusterReference{}}, controllers.EnqueueClusterReferences(r, &ocmv1alpha1.ClusterNotificationList{}))
+
+	if r.Broadcaster != nil {
+		managedBy = managedBy.Watches(r.Broadcaster.Subscribe(), controllers.EnqueueAll(r, &ocmv1alpha1.ClusterNotificationList{}))
+	}
+
+	return managedBy.Complete(r)
+}
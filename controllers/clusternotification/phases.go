@@ -0,0 +1,328 @@
+package clusternotification
+
+import (
+	"fmt"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*ClusterNotificationRequest]
+
+// Begin begins the reconciliation state once we get the object (the desired state) from the cluster.
+// It is mainly used to set conditions of the controller and to let anyone who is viewiing the
+// custom resource know that we are currently reconciling.
+func (r *Controller) Begin(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Authorize ensures that the namespace of the cluster notification has been granted management of its cluster
+// by a cluster management binding.  A forbidden cluster notification is reported with a Forbidden condition and
+// a warning event, and the request is retried at the regular interval.  A forbidden cluster notification which is
+// deleted is released without deleting anything from OpenShift Cluster Manager.
+func (r *Controller) Authorize(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Desired.Spec.ClusterName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Desired.Spec.ClusterName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	// release the deleted object without touching openshift cluster manager, as the namespace is
+	// not allowed to manage the cluster
+	if request.Trigger == triggers.Delete {
+		if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// GetCurrentState gets the current state of the ClusterNotification resource.  The current state of the
+// ClusterNotification resource is the set of notification contacts stored in OpenShift Cluster Manager
+// for the subscription of the cluster.  It will be compared against the desired state which exists
+// within the OpenShift cluster in which this controller is reconciling against.
+func (r *Controller) GetCurrentState(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	// retrieve the cluster.  the cluster is retrieved on each reconciliation so that the
+	// state of the cluster may be used to determine if a support case should be opened.
+	clusterClient := ocm.NewClusterClient(request.Environment.Connection, request.Desired.Spec.ClusterName).
+		WithOrganizationGuard(request.Environment.Organizations).
+		WithContext(request.Context)
+
+	cluster, err := clusterClient.Get()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve cluster from ocm [name=%s] - %w",
+			request.Desired.Spec.ClusterName,
+			err,
+		)
+	}
+
+	request.Cluster = cluster
+
+	// store the cluster and subscription id in the status
+	if err := request.updateStatusCluster(); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	// get the notification contacts from ocm
+	request.OCMClient = ocm.NewNotificationContactClient(request.Environment.Connection, request.Original.Status.SubscriptionID).
+		WithContext(request.Context)
+
+	contacts, err := request.OCMClient.List()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve notification contacts from ocm - %w",
+			err,
+		)
+	}
+
+	request.Contacts = contacts
+
+	return controllers.NoRequeue(), nil
+}
+
+// ApplyContacts applies the desired state of the notification contacts to OCM.  Contacts which
+// are desired but missing are created and contacts which exist but are not desired are removed.
+func (r *Controller) ApplyContacts(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	missing, extra := request.missingContacts(), request.extraContacts()
+
+	// return if it is already in its desired state
+	if len(missing) == 0 && len(extra) == 0 {
+		request.Log.V(controllers.LogLevelDebug).Info("notification contacts already in desired state", request.logValues()...)
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// create the missing notification contacts
+	for _, identifier := range missing {
+		request.Log.Info(fmt.Sprintf("creating notification contact [%s]", identifier), request.logValues()...)
+
+		_, err := request.OCMClient.Create(identifier)
+		request.recordOperation(ocmv1alpha1.OCMOperationCreate, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to create notification contact [%s] in ocm - %w",
+				identifier,
+				err,
+			)
+		}
+
+		// create an event indicating that the notification contact has been created
+		events.RegisterAction(events.Created, request.Original, r.Recorder, identifier, request.Original.Status.ClusterID)
+	}
+
+	// remove the notification contacts which are not desired
+	for _, contact := range extra {
+		request.Log.Info(fmt.Sprintf("deleting notification contact [%s]", contact.Username()), request.logValues()...)
+
+		err := request.OCMClient.Delete(contact.ID())
+		request.recordOperation(ocmv1alpha1.OCMOperationDelete, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to delete notification contact [%s] from ocm - %w",
+				contact.Username(),
+				err,
+			)
+		}
+
+		// create an event indicating that the notification contact has been deleted
+		events.RegisterAction(events.Deleted, request.Original, r.Recorder, contact.Username(), request.Original.Status.ClusterID)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// ApplySupportCase opens a support case in OCM when the cluster has entered an error state.  Only
+// a single support case is opened for each failure.
+func (r *Controller) ApplySupportCase(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	// return if support cases are not requested
+	if request.Desired.Spec.SupportCase == nil {
+		return controllers.NoRequeue(), nil
+	}
+
+	// clear the support case once the cluster has left the error state so that a new support
+	// case may be opened upon the next failure
+	if request.Cluster.State() != clustersmgmtv1.ClusterStateError {
+		if request.Original.Status.SupportCaseNumber == "" {
+			return controllers.NoRequeue(), nil
+		}
+
+		if err := request.updateStatusSupportCase(""); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// return if we have already opened a support case for this failure
+	if request.Original.Status.SupportCaseNumber != "" {
+		request.Log.V(controllers.LogLevelDebug).Info(
+			fmt.Sprintf("support case [%s] already opened for cluster", request.Original.Status.SupportCaseNumber),
+			request.logValues()...,
+		)
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// open the support case
+	request.Log.Info("opening support case for cluster in error state", request.logValues()...)
+
+	supportCase, err := ocm.NewSupportCaseClient(request.Environment.Connection).WithContext(request.Context).Create(
+		request.Desired.SupportCaseBuilder(request.Cluster.ExternalID()),
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to open support case in ocm - %w",
+			err,
+		)
+	}
+
+	if err := request.updateStatusSupportCase(supportCase.CaseNumber()); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	// create an event indicating that the support case has been opened
+	events.RegisterAction(
+		events.Created,
+		request.Original,
+		r.Recorder,
+		fmt.Sprintf("support case %s", supportCase.CaseNumber()),
+		request.Original.Status.ClusterID,
+	)
+
+	return controllers.NoRequeue(), nil
+}
+
+// Destroy will remove the managed notification contacts from OpenShift Cluster Manager.
+func (r *Controller) Destroy(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	// return immediately if we have already deleted the notification contacts
+	if conditions.IsSet(conditions.ClusterNotificationDeleted(), request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	// leave the notification contacts in openshift cluster manager while its deletion is prevented,
+	// checking again after the requeue interval so that the deletion continues once the annotation is
+	// removed
+	if controllers.PreventDestroyRequested(request.Original) {
+		return controllers.PreventDestroy(&r.Dependencies, request.execution(), r.requeue())
+	}
+
+	// only remove the notification contacts if we discovered the subscription, as no notification
+	// contacts could have been created otherwise
+	if request.Original.Status.SubscriptionID != "" {
+		request.OCMClient = ocm.NewNotificationContactClient(request.Environment.Connection, request.Original.Status.SubscriptionID).
+			WithContext(request.Context)
+
+		contacts, err := request.OCMClient.List()
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to retrieve notification contacts from ocm - %w",
+				err,
+			)
+		}
+
+		request.Contacts = contacts
+
+		// delete the notification contacts which are managed by this resource
+		for _, contact := range request.managedContacts() {
+			err := request.OCMClient.Delete(contact.ID())
+			request.recordOperation(ocmv1alpha1.OCMOperationDelete, request.OCMClient.LastStatus(), err)
+
+			if err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+					"unable to delete notification contact [%s] from ocm - %w",
+					contact.Username(),
+					err,
+				)
+			}
+
+			// create an event indicating that the notification contact has been deleted
+			events.RegisterAction(events.Deleted, request.Original, r.Recorder, contact.Username(), request.Original.Status.ClusterID)
+		}
+	}
+
+	// set the deleted condition
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.ClusterNotificationDeleted(),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating deleted condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  It will
+// requeue after the interval value requested by the controller configuration to ensure that the
+// object remains in its desired state at a specific interval.
+func (r *Controller) Complete(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	request.Log.Info("completed cluster notification reconciliation", request.logValues()...)
+	request.Log.Info(fmt.Sprintf("reconciling again in %s", r.Interval.String()), request.logValues()...)
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// CompleteDestroy will perform all actions required to successful complete a reconciliation request.
+func (r *Controller) CompleteDestroy(request *ClusterNotificationRequest) (ctrl.Result, error) {
+	if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+	}
+
+	request.Log.Info("completed cluster notification deletion", request.logValues()...)
+
+	return controllers.NoRequeue(), nil
+}
@@ -0,0 +1,266 @@
+package clusternotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var (
+	ErrMissingClusterID                  = errors.New("unable to find cluster id")
+	ErrMissingSubscriptionID             = errors.New("unable to find subscription id")
+	ErrClusterNotificationRequestConvert = errors.New("unable to convert generic request to cluster notification request")
+)
+
+// ClusterNotificationRequest is an object that is unique to each reconciliation
+// request.
+type ClusterNotificationRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ClusterNotification
+	Desired           *ocmv1alpha1.ClusterNotification
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+	Environment       *ocm.Environment
+	OCMClient         *ocm.NotificationContactClient
+
+	// data obtained during request reconciliation
+	Cluster  *clustersmgmtv1.Cluster
+	Contacts []*accountsmgmtv1.Account
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ClusterNotification{}
+
+	// get the object (desired state) from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &ClusterNotificationRequest{}, fmt.Errorf("unable to fetch cluster object - %w", err)
+		}
+
+		return &ClusterNotificationRequest{}, err
+	}
+
+	// resolve the cluster of an object which references a cluster reference rather than naming
+	// its cluster
+	if err := controllers.ResolveClusterReference(ctx, r, original, r.requeue()); err != nil {
+		return &ClusterNotificationRequest{}, conditions.RecordWaiting(ctx, r, original, err)
+	}
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection, Organizations: r.Organizations},
+		original,
+	)
+	if err != nil {
+		return &ClusterNotificationRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
+	}
+
+	return &ClusterNotificationRequest{
+		Original:          original,
+		Desired:           original.DeepCopy(),
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+		Environment:       environment,
+	}, nil
+}
+
+func (request *ClusterNotificationRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *ClusterNotificationRequest) bindContext(ctx context.Context) {
+	request.Context = ctx
+
+	// the client which is shared between phases is bound to the context of each phase
+	if request.OCMClient != nil {
+		request.OCMClient.WithContext(ctx)
+	}
+}
+
+// execution returns the execution of the phases of the request.
+func (request *ClusterNotificationRequest) execution() *controllers.Execution {
+	return &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
+	}
+}
+
+// execute executes a variety of different phases for the request.
+func (request *ClusterNotificationRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, request.execution(), request, phases...)
+}
+
+// recordOperation records an operation which was sent to OCM in the operation history of the object.
+// Errors recording the operation are logged rather than returned so that the result of the operation
+// is not masked.
+func (request *ClusterNotificationRequest) recordOperation(operation string, status int, err error) {
+	if recordErr := controllers.RecordOperation(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		operation,
+		status,
+		err,
+	); recordErr != nil {
+		request.Log.V(controllers.LogLevelDebug).Info(
+			fmt.Sprintf("unable to record %s operation - %s", strings.ToLower(operation), recordErr),
+			request.logValues()...,
+		)
+	}
+}
+
+// updateStatusCluster updates fields related to the cluster in which the notification contacts are
+// managed for.
+func (request *ClusterNotificationRequest) updateStatusCluster() error {
+	// if the cluster or subscription id is missing return an error
+	if request.Cluster.ID() == "" {
+		return fmt.Errorf("missing cluster id in response - %w", ErrMissingClusterID)
+	}
+
+	if request.Cluster.Subscription().ID() == "" {
+		return fmt.Errorf("missing subscription id in response - %w", ErrMissingSubscriptionID)
+	}
+
+	// return if the status is already up to date
+	if request.Original.Status.ClusterID == request.Cluster.ID() &&
+		request.Original.Status.SubscriptionID == request.Cluster.Subscription().ID() {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.ClusterID = request.Cluster.ID()
+	request.Original.Status.SubscriptionID = request.Cluster.Subscription().ID()
+
+	// store the cluster and subscription id in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.clusterID=%s, status.subscriptionID=%s - %w",
+			request.Original.Status.ClusterID,
+			request.Original.Status.SubscriptionID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// updateStatusSupportCase updates the support case number in the status.
+func (request *ClusterNotificationRequest) updateStatusSupportCase(caseNumber string) error {
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.SupportCaseNumber = caseNumber
+
+	// store the support case number in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.supportCaseNumber=%s - %w",
+			caseNumber,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *ClusterNotificationRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", fmt.Sprintf("%s/%s", request.Desired.Namespace, request.Desired.Name),
+		"cluster", request.Desired.Spec.ClusterName,
+	}
+}
+
+// missingContacts returns the desired contacts which are not currently registered as
+// notification contacts in OCM.
+func (request *ClusterNotificationRequest) missingContacts() (missing []string) {
+	for _, identifier := range request.Desired.Spec.Contacts {
+		found := false
+
+		for _, contact := range request.Contacts {
+			if matchesContact(contact, identifier) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, identifier)
+		}
+	}
+
+	return missing
+}
+
+// extraContacts returns the notification contacts which are currently registered in OCM
+// but are not desired.
+func (request *ClusterNotificationRequest) extraContacts() (extra []*accountsmgmtv1.Account) {
+	for _, contact := range request.Contacts {
+		found := false
+
+		for _, identifier := range request.Desired.Spec.Contacts {
+			if matchesContact(contact, identifier) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			extra = append(extra, contact)
+		}
+	}
+
+	return extra
+}
+
+// managedContacts returns the notification contacts which are currently registered in OCM
+// and are managed by this request.
+func (request *ClusterNotificationRequest) managedContacts() (managed []*accountsmgmtv1.Account) {
+	for _, contact := range request.Contacts {
+		for _, identifier := range request.Desired.Spec.Contacts {
+			if matchesContact(contact, identifier) {
+				managed = append(managed, contact)
+
+				break
+			}
+		}
+	}
+
+	return managed
+}
+
+// matchesContact determines if a notification contact matches an identifier, which may be
+// either a username or an email address.
+func matchesContact(contact *accountsmgmtv1.Account, identifier string) bool {
+	return strings.EqualFold(contact.Username(), identifier) || strings.EqualFold(contact.Email(), identifier)
+}
@@ -0,0 +1,111 @@
+package clusternotification
+
+import (
+	"reflect"
+	"testing"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func testAccount(t *testing.T, username, email string) *accountsmgmtv1.Account {
+	t.Helper()
+
+	account, err := accountsmgmtv1.NewAccount().ID(username).Username(username).Email(email).Build()
+	if err != nil {
+		t.Fatalf("unable to build account [%s] - %s", username, err)
+	}
+
+	return account
+}
+
+func TestClusterNotificationRequest_missingContacts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		desired  []string
+		contacts []*accountsmgmtv1.Account
+		want     []string
+	}{
+		{
+			name:     "ensure all contacts are missing when none exist",
+			desired:  []string{"alice", "bob@example.com"},
+			contacts: nil,
+			want:     []string{"alice", "bob@example.com"},
+		},
+		{
+			name:    "ensure contacts matching by username or email are not missing",
+			desired: []string{"alice", "BOB@example.com"},
+			contacts: []*accountsmgmtv1.Account{
+				testAccount(t, "alice", "alice@example.com"),
+				testAccount(t, "bob", "bob@example.com"),
+			},
+			want: nil,
+		},
+		{
+			name:    "ensure only unmatched contacts are missing",
+			desired: []string{"alice", "carol"},
+			contacts: []*accountsmgmtv1.Account{
+				testAccount(t, "alice", "alice@example.com"),
+			},
+			want: []string{"carol"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := &ClusterNotificationRequest{
+				Desired:  &ocmv1alpha1.ClusterNotification{Spec: ocmv1alpha1.ClusterNotificationSpec{Contacts: tt.desired}},
+				Contacts: tt.contacts,
+			}
+			if got := request.missingContacts(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterNotificationRequest.missingContacts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterNotificationRequest_extraContacts(t *testing.T) {
+	t.Parallel()
+
+	alice := testAccount(t, "alice", "alice@example.com")
+	bob := testAccount(t, "bob", "bob@example.com")
+
+	tests := []struct {
+		name     string
+		desired  []string
+		contacts []*accountsmgmtv1.Account
+		want     []*accountsmgmtv1.Account
+	}{
+		{
+			name:     "ensure no contacts are extra when all are desired",
+			desired:  []string{"alice", "bob@example.com"},
+			contacts: []*accountsmgmtv1.Account{alice, bob},
+			want:     nil,
+		},
+		{
+			name:     "ensure undesired contacts are extra",
+			desired:  []string{"alice"},
+			contacts: []*accountsmgmtv1.Account{alice, bob},
+			want:     []*accountsmgmtv1.Account{bob},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := &ClusterNotificationRequest{
+				Desired:  &ocmv1alpha1.ClusterNotification{Spec: ocmv1alpha1.ClusterNotificationSpec{Contacts: tt.desired}},
+				Contacts: tt.contacts,
+			}
+			if got := request.extraContacts(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterNotificationRequest.extraContacts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
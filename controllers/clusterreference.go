@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// ClusterReferencer represents a workload which may reference a cluster reference in its namespace
+// rather than naming its cluster.
+type ClusterReferencer interface {
+	Workload
+
+	GetClusterReferenceBinding() ocmv1alpha1.ClusterReferenceBinding
+}
+
+// ClusterReferenceOf returns the name of the cluster reference which an object references.  It is used
+// to index objects by their cluster reference.
+func ClusterReferenceOf[T ClusterReferencer](object T) string {
+	return object.GetClusterReferenceBinding().GetClusterReference()
+}
+
+// ClusterIDOf returns the id of the cluster which has been recorded in the status of an object.  It is
+// used to index objects by their cluster id.
+func ClusterIDOf[T ClusterReferencer](object T) string {
+	return *object.GetClusterReferenceBinding().ClusterID
+}
+
+// ResolveClusterReference resolves the cluster of an object which references a cluster reference.  The
+// cluster name, and the environment when it is not set, of the object are set in memory from the cluster
+// reference, so that the remainder of the reconciliation is unaware of the reference.  The cluster id, and
+// whether the cluster is hosted, are seeded in the status of the object from the cluster reference, so
+// that the object follows the cluster which the reference resolves without looking it up again.  An object which
+// does not reference a cluster reference is left unchanged.  A wait is returned while the cluster
+// reference does not exist or has not yet resolved its cluster, unless the object is being deleted, in
+// which case the object has never been reconciled against a cluster and is left unchanged.
+func ResolveClusterReference(ctx context.Context, r kubernetes.Client, object Workload, requeue time.Duration) error {
+	referencer, ok := object.(ClusterReferencer)
+	if !ok {
+		return nil
+	}
+
+	binding := referencer.GetClusterReferenceBinding()
+	if binding.GetClusterReference() == "" {
+		return nil
+	}
+
+	key := types.NamespacedName{Namespace: object.GetNamespace(), Name: binding.GetClusterReference()}
+	reference := &ocmv1alpha1.ClusterReference{}
+
+	if err := r.Get(ctx, key, reference); err != nil {
+		// a missing cluster reference must not be returned as is, otherwise the referencing object
+		// would be treated as though it had been deleted
+		if !apierrs.IsNotFound(err) {
+			return fmt.Errorf("unable to fetch cluster reference [%s] - %w", key, err)
+		}
+
+		reference = nil
+	}
+
+	if reference == nil || !reference.Resolved() {
+		if !object.GetDeletionTimestamp().IsZero() {
+			return nil
+		}
+
+		return WaitFor(
+			fmt.Sprintf("cluster reference [%s]", key),
+			WaitReasonClusterReferenceUnresolved,
+			requeue,
+		)
+	}
+
+	// the seeded status is stored before the cluster name is set in memory, as storing the status
+	// refreshes the object from the cluster
+	original, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return ErrConvertClientObject
+	}
+
+	if binding.SeedClusterStatus(reference) {
+		if err := kubernetes.PatchStatus(ctx, r, original, object); err != nil {
+			return fmt.Errorf("unable to seed status.clusterID=%s from cluster reference [%s] - %w", reference.Status.ClusterID, key, err)
+		}
+	}
+
+	binding.UseClusterReference(reference)
+
+	return nil
+}
+
+// ClusterReferencePredicate returns a predicate which only allows events for cluster references which
+// change the cluster that they resolve.  It allows the events of referenced cluster references through
+// the event filters of a controller, so that an object which is waiting for a cluster reference is
+// reconciled as soon as it resolves, and so that a renamed cluster is followed immediately.  Updates
+// which only refresh the status of a cluster reference are filtered out.
+func ClusterReferencePredicate() predicate.Predicate {
+	isReference := func(object client.Object) bool {
+		_, ok := object.(*ocmv1alpha1.ClusterReference)
+
+		return ok
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isReference(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old, ok := e.ObjectOld.(*ocmv1alpha1.ClusterReference)
+			if !ok {
+				return false
+			}
+
+			updated, ok := e.ObjectNew.(*ocmv1alpha1.ClusterReference)
+			if !ok {
+				return false
+			}
+
+			return old.Spec.ClusterName != updated.Spec.ClusterName || old.Status.ClusterID != updated.Status.ClusterID
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isReference(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// EnqueueClusterReferences returns an event handler which enqueues each object of a list type, in the
+// namespace of a cluster reference, which references the cluster reference.  It requires that the cluster
+// reference field of the type of object is indexed.
+func EnqueueClusterReferences(r kubernetes.Client, list client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(reference client.Object) []reconcile.Request {
+		objects, err := ListReferences(context.Background(), r, list, reference.GetNamespace(), reference.GetName(), IndexClusterReference)
+		if err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(objects))
+
+		for _, object := range objects {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+		}
+
+		return requests
+	})
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterreference
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	defaultClusterReferenceRequeue = 30 * time.Second
+)
+
+// Controller reconciles a ClusterReference object.  It only reads from OpenShift Cluster Manager, and
+// caches the cluster which it resolves in the status of the cluster reference so that the objects which
+// reference it do not each look up the cluster by name.
+type Controller struct {
+	client.Client
+
+	controllers.Dependencies
+
+	Scheme   *runtime.Scheme
+	Interval time.Duration
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
+}
+
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusterreferences,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusterreferences/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusterreferences/finalizers,verbs=update
+
+// RequiredAPIs returns the APIs of OpenShift Cluster Manager which the controller depends upon.  It
+// is used to satisfy the Compatible interface.
+func (r *Controller) RequiredAPIs() []ocm.API {
+	return []ocm.API{ocm.APIClustersMgmt}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+//nolint:wrapcheck
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return controllers.Reconcile(ctx, r, req)
+}
+
+// ReconcileCreate performs the reconciliation logic when a create event triggered
+// the reconciliation.
+func (r *Controller) ReconcileCreate(req controllers.Request) (ctrl.Result, error) {
+	// type cast the request to a cluster reference request
+	request, ok := req.(*ClusterReferenceRequest)
+	if !ok {
+		return controllers.RequeueAfter(r.requeue()), ErrClusterReferenceRequestConvert
+	}
+
+	// add the finalizer so that the cluster reference is not deleted while it is referenced
+	if err := controllers.AddFinalizer(request.Context, r, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to register delete hooks - %w", err)
+	}
+
+	// execute the phases
+	return request.execute([]Phase{
+		{Name: "begin", Function: r.Begin},
+		{Name: "authorize", Function: r.Authorize},
+		{Name: "resolve", Function: r.Resolve},
+		{Name: "complete", Function: r.Complete},
+	}...)
+}
+
+// ReconcileUpdate performs the reconciliation logic when an update event triggered
+// the reconciliation.  In this instance, create and update share identical logic
+// so we are simply calling the ReconcileCreate method.
+func (r *Controller) ReconcileUpdate(req controllers.Request) (ctrl.Result, error) {
+	return r.ReconcileCreate(req)
+}
+
+// ReconcileDelete performs the reconciliation logic when a delete event triggered
+// the reconciliation.  A cluster reference does not manage any objects in OpenShift
+// Cluster Manager, so it is only deleted once it is no longer referenced.
+func (r *Controller) ReconcileDelete(req controllers.Request) (ctrl.Result, error) {
+	// type cast the request to a cluster reference request
+	request, ok := req.(*ClusterReferenceRequest)
+	if !ok {
+		return controllers.RequeueAfter(r.requeue()), ErrClusterReferenceRequestConvert
+	}
+
+	// execute the phases
+	return request.execute([]Phase{
+		{Name: "waitUntilUnreferenced", Function: r.WaitUntilUnreferenced},
+		{Name: "complete", Function: r.CompleteDestroy},
+	}...)
+}
+
+// requeue returns the interval after which a failed or incomplete reconciliation is retried.
+func (r *Controller) requeue() time.Duration {
+	if r.Requeue == 0 {
+		return defaultClusterReferenceRequeue
+	}
+
+	return r.Requeue
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	// index the cluster which each object targets, by its name and by its id, so that the objects of a
+	// cluster may be looked up
+	if err := controllers.SetupIndexes(
+		context.Background(),
+		mgr.GetFieldIndexer(),
+		&ocmv1alpha1.ClusterReference{},
+		controllers.IndexBy(controllers.IndexClusterName, (*ocmv1alpha1.ClusterReference).GetClusterName),
+		controllers.IndexBy(controllers.IndexClusterID, func(reference *ocmv1alpha1.ClusterReference) string {
+			return reference.Status.ClusterID
+		}),
+	); err != nil {
+		return fmt.Errorf("unable to index cluster references - %w", err)
+	}
+
+	managedBy := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options()).
+		WithEventFilter(predicate.Or(
+			controllers.WorkloadPredicates(),
+			controllers.BroadcastPredicate(),
+		)).
+		For(&ocmv1alpha1.ClusterReference{})
+
+	if r.Broadcaster != nil {
+		managedBy = managedBy.Watches(r.Broadcaster.Subscribe(), controllers.EnqueueAll(r, &ocmv1alpha1.ClusterReferenceList{}))
+	}
+
+	return managedBy.Complete(r)
+}
@@ -0,0 +1,187 @@
+package clusterreference
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*ClusterReferenceRequest]
+
+// Begin begins the reconciliation state once we get the object from the cluster.
+// It is mainly used to set conditions of the controller and to let anyone who is viewiing the
+// custom resource know that we are currently reconciling.
+func (r *Controller) Begin(request *ClusterReferenceRequest) (ctrl.Result, error) {
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Authorize ensures that the namespace of the cluster reference has been granted management of its
+// cluster by a cluster management binding, so that the details of clusters are not disclosed to other
+// tenants.  A forbidden cluster reference is reported with a Forbidden condition and a warning event,
+// and the request is retried at the regular interval.
+func (r *Controller) Authorize(request *ClusterReferenceRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Original.Spec.ClusterName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Original.Spec.ClusterName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// Resolve retrieves the cluster of the cluster reference from OpenShift Cluster Manager and caches its
+// id and details in the status.  Once a cluster has been resolved, the cluster reference always resolves
+// the same cluster, so that a cluster which is renamed may be followed by updating the cluster name of
+// the cluster reference while a different cluster which is given the same name is never resolved.
+func (r *Controller) Resolve(request *ClusterReferenceRequest) (ctrl.Result, error) {
+	clusterClient := ocm.NewClusterClient(request.Environment.Connection, request.Original.Spec.ClusterName).
+		WithOrganizationGuard(request.Environment.Organizations).
+		WithContext(request.Context)
+
+	cluster, err := clusterClient.Get()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve cluster from ocm [name=%s] - %w",
+			request.Original.Spec.ClusterName,
+			err,
+		)
+	}
+
+	if cluster.ID() == "" {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"missing cluster id in response - %w",
+			ErrMissingClusterID,
+		)
+	}
+
+	if request.Original.Resolved() && request.Original.Status.ClusterID != cluster.ID() {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"cluster [%s] resolves to cluster id [%s] rather than [%s] - %w",
+			request.Original.Spec.ClusterName,
+			cluster.ID(),
+			request.Original.Status.ClusterID,
+			ErrClusterReplaced,
+		)
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	now := metav1.Now()
+
+	// only the details of the cluster are set, as status.configMapName is managed by the cluster info
+	// controller
+	request.Original.Status.ClusterID = cluster.ID()
+	request.Original.Status.ExternalID = cluster.ExternalID()
+	request.Original.Status.SubscriptionID = cluster.Subscription().ID()
+	request.Original.Status.Region = cluster.Region().ID()
+	request.Original.Status.Version = cluster.Version().RawID()
+	request.Original.Status.State = string(cluster.State())
+	request.Original.Status.Hosted = cluster.Hypershift().Enabled()
+	request.Original.Status.ConsoleURL = cluster.Console().URL()
+	request.Original.Status.APIURL = cluster.API().URL()
+	request.Original.Status.OIDCIssuer = cluster.AWS().STS().OIDCEndpointURL()
+	request.Original.Status.LastResolvedTime = &now
+
+	if !original.Resolved() {
+		request.Log.Info(fmt.Sprintf("resolved cluster id [%s]", cluster.ID()), request.logValues()...)
+	}
+
+	// store the cluster in the status
+	if err := kubernetes.PatchStatus(request.Context, r, original, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to update status.clusterID=%s - %w",
+			cluster.ID(),
+			err,
+		)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  It will
+// requeue after the interval value requested by the controller configuration so that the details of the
+// cluster are refreshed at a specific interval.
+func (r *Controller) Complete(request *ClusterReferenceRequest) (ctrl.Result, error) {
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	request.Log.Info("completed cluster reference reconciliation", request.logValues()...)
+	request.Log.Info(fmt.Sprintf("reconciling again in %s", r.Interval.String()), request.logValues()...)
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// WaitUntilUnreferenced waits until no objects in the namespace of the cluster reference reference it,
+// so that the objects which reference it are still able to resolve their cluster while they are deleted.
+func (r *Controller) WaitUntilUnreferenced(request *ClusterReferenceRequest) (ctrl.Result, error) {
+	references, err := request.references()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if len(references) > 0 {
+		request.Log.Info(
+			fmt.Sprintf("waiting for [%d] objects which reference the cluster reference to be deleted", len(references)),
+			request.logValues()...,
+		)
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// CompleteDestroy removes the finalizer so that the cluster reference may be deleted.
+func (r *Controller) CompleteDestroy(request *ClusterReferenceRequest) (ctrl.Result, error) {
+	if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+	}
+
+	request.Log.Info("completed cluster reference deletion", request.logValues()...)
+
+	return controllers.NoRequeue(), nil
+}
@@ -0,0 +1,138 @@
+package clusterreference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var (
+	ErrMissingClusterID               = errors.New("unable to find cluster id")
+	ErrClusterReplaced                = errors.New("cluster resolves to a different cluster id than was previously resolved")
+	ErrClusterReferenceRequestConvert = errors.New("unable to convert generic request to cluster reference request")
+)
+
+// referencingLists are the list types of the objects which may reference a cluster reference.
+func referencingLists() []client.ObjectList {
+	return []client.ObjectList{
+		&ocmv1alpha1.MachinePoolList{},
+		&ocmv1alpha1.LDAPIdentityProviderList{},
+		&ocmv1alpha1.GitLabIdentityProviderList{},
+		&ocmv1alpha1.ClusterLabelsList{},
+		&ocmv1alpha1.ClusterNotificationList{},
+		&ocmv1alpha1.ClusterVersionCheckList{},
+	}
+}
+
+// ClusterReferenceRequest is an object that is unique to each reconciliation
+// request.
+type ClusterReferenceRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ClusterReference
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+	Environment       *ocm.Environment
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ClusterReference{}
+
+	// get the object from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &ClusterReferenceRequest{}, fmt.Errorf("unable to fetch cluster object - %w", err)
+		}
+
+		return &ClusterReferenceRequest{}, err
+	}
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection, Organizations: r.Organizations},
+		original,
+	)
+	if err != nil {
+		return &ClusterReferenceRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
+	}
+
+	return &ClusterReferenceRequest{
+		Original:          original,
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+		Environment:       environment,
+	}, nil
+}
+
+func (request *ClusterReferenceRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// references returns the objects, in the namespace of the cluster reference, which reference the
+// cluster reference.
+func (request *ClusterReferenceRequest) references() ([]client.Object, error) {
+	var references []client.Object
+
+	for _, list := range referencingLists() {
+		objects, err := controllers.ListReferences(
+			request.Context,
+			request.Reconciler,
+			list,
+			request.Original.Namespace,
+			request.Original.Name,
+			controllers.IndexClusterReference,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list objects which reference cluster reference - %w", err)
+		}
+
+		references = append(references, objects...)
+	}
+
+	return references, nil
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *ClusterReferenceRequest) bindContext(ctx context.Context) {
+	// the clients of the request are created from the bound context within each phase rather than
+	// being shared between phases, so binding the context binds each of them
+	request.Context = ctx
+}
+
+// execute executes a variety of different phases for the request.
+func (request *ClusterReferenceRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
+	}, request, phases...)
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *ClusterReferenceRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", fmt.Sprintf("%s/%s", request.Original.Namespace, request.Original.Name),
+		"cluster", request.Original.Spec.ClusterName,
+	}
+}
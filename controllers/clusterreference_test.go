@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// clusterReferenceClient is a client which only returns the cluster references which it was created with.
+type clusterReferenceClient struct {
+	kubernetes.FakeClient
+
+	references map[string]*ocmv1alpha1.ClusterReference
+}
+
+func (c *clusterReferenceClient) Get(_ context.Context, key types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+	reference, ok := c.references[key.Name]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "clusterreferences"}, key.Name)
+	}
+
+	reference.DeepCopyInto(object.(*ocmv1alpha1.ClusterReference))
+
+	return nil
+}
+
+func TestResolveClusterReference(t *testing.T) {
+	t.Parallel()
+
+	references := map[string]*ocmv1alpha1.ClusterReference{
+		"resolved": {
+			Spec:   ocmv1alpha1.ClusterReferenceSpec{ClusterName: "dev"},
+			Status: ocmv1alpha1.ClusterReferenceStatus{ClusterID: "abc123"},
+		},
+		"unresolved": {
+			Spec: ocmv1alpha1.ClusterReferenceSpec{ClusterName: "prod"},
+		},
+	}
+
+	now := metav1.Now()
+
+	tests := []struct {
+		name            string
+		clusterName     string
+		reference       string
+		deleting        bool
+		wantClusterName string
+		wantWaiting     bool
+	}{
+		{
+			name:            "ensure an object without a cluster reference is unchanged",
+			clusterName:     "test",
+			wantClusterName: "test",
+			wantWaiting:     false,
+		},
+		{
+			name:            "ensure the cluster name of a resolved cluster reference is used",
+			reference:       "resolved",
+			wantClusterName: "dev",
+			wantWaiting:     false,
+		},
+		{
+			name:            "ensure an unresolved cluster reference is waited for",
+			reference:       "unresolved",
+			wantClusterName: "",
+			wantWaiting:     true,
+		},
+		{
+			name:            "ensure a missing cluster reference is waited for",
+			reference:       "missing",
+			wantClusterName: "",
+			wantWaiting:     true,
+		},
+		{
+			name:            "ensure a missing cluster reference is not waited for while the object is deleted",
+			reference:       "missing",
+			deleting:        true,
+			wantClusterName: "",
+			wantWaiting:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pool := &ocmv1alpha1.MachinePool{}
+			pool.Namespace = "ocm"
+			pool.Spec.ClusterName = tt.clusterName
+			pool.Spec.ClusterReference = tt.reference
+
+			if tt.deleting {
+				pool.DeletionTimestamp = &now
+			}
+
+			err := ResolveClusterReference(context.Background(), &clusterReferenceClient{references: references}, pool, time.Minute)
+
+			if _, waiting := AsWaiting(err); waiting != tt.wantWaiting {
+				t.Errorf("ResolveClusterReference() error = %v, wantWaiting %v", err, tt.wantWaiting)
+			}
+
+			if apierrors.IsNotFound(err) {
+				t.Errorf("ResolveClusterReference() error = %v, want an error which is not a not found error", err)
+			}
+
+			if pool.Spec.ClusterName != tt.wantClusterName {
+				t.Errorf("ResolveClusterReference() clusterName = %v, want %v", pool.Spec.ClusterName, tt.wantClusterName)
+			}
+		})
+	}
+}
+
+func TestClusterReferencePredicate(t *testing.T) {
+	t.Parallel()
+
+	reference := func(clusterName, clusterID string, resolved metav1.Time) *ocmv1alpha1.ClusterReference {
+		return &ocmv1alpha1.ClusterReference{
+			Spec:   ocmv1alpha1.ClusterReferenceSpec{ClusterName: clusterName},
+			Status: ocmv1alpha1.ClusterReferenceStatus{ClusterID: clusterID, LastResolvedTime: &resolved},
+		}
+	}
+
+	earlier, later := metav1.NewTime(time.Unix(0, 0)), metav1.NewTime(time.Unix(60, 0))
+
+	tests := []struct {
+		name string
+		old  client.Object
+		new  client.Object
+		want bool
+	}{
+		{
+			name: "ensure a renamed cluster triggers reconciliation",
+			old:  reference("dev", "abc123", earlier),
+			new:  reference("development", "abc123", earlier),
+			want: true,
+		},
+		{
+			name: "ensure a newly resolved cluster triggers reconciliation",
+			old:  reference("dev", "", earlier),
+			new:  reference("dev", "abc123", earlier),
+			want: true,
+		},
+		{
+			name: "ensure a refreshed status does not trigger reconciliation",
+			old:  reference("dev", "abc123", earlier),
+			new:  reference("dev", "abc123", later),
+			want: false,
+		},
+		{
+			name: "ensure other objects are not allowed",
+			old:  &ocmv1alpha1.MachinePool{},
+			new:  &ocmv1alpha1.MachinePool{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ClusterReferencePredicate().Update(event.UpdateEvent{ObjectOld: tt.old, ObjectNew: tt.new}); got != tt.want {
+				t.Errorf("ClusterReferencePredicate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package clusterregistration
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*ClusterRegistrationRequest]
+
+// Begin begins the reconciliation state once we get the object (the desired state) from the cluster.
+// It is mainly used to set conditions of the controller and to let anyone who is viewiing the
+// custom resource know that we are currently reconciling.
+func (r *Controller) Begin(request *ClusterRegistrationRequest) (ctrl.Result, error) {
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Authorize ensures that the namespace of the cluster registration has been granted management of its cluster
+// by a cluster management binding.  The cluster is identified by its display name, or by its cluster id once it
+// has been registered.  A forbidden cluster registration is reported with a Forbidden condition and a warning
+// event, and the request is retried at the regular interval.  A forbidden cluster registration which is deleted
+// is released without archiving the cluster in OpenShift Cluster Manager.
+func (r *Controller) Authorize(request *ClusterRegistrationRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Desired.Spec.DisplayName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Desired.Spec.DisplayName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	// release the deleted object without touching openshift cluster manager, as the namespace is
+	// not allowed to manage the cluster
+	if request.Trigger == triggers.Delete {
+		if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// GetCurrentState gets the current state of the ClusterRegistration resource.  The current state of the
+// ClusterRegistration resource is the subscription of the cluster in OpenShift Cluster Manager.  Once a
+// cluster has been registered, its subscription is retrieved by its id.  Otherwise, a subscription which
+// already exists for the cluster uuid is adopted so that the cluster is never registered twice.
+func (r *Controller) GetCurrentState(request *ClusterRegistrationRequest) (ctrl.Result, error) {
+	// retrieve the subscription of a cluster which this resource has already registered
+	if request.Original.Status.SubscriptionID != "" {
+		subscription, err := request.OCMClient.Get(request.Original.Status.SubscriptionID)
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to retrieve subscription [%s] from ocm - %w",
+				request.Original.Status.SubscriptionID,
+				err,
+			)
+		}
+
+		// the subscription id is immutable, so a cluster which has been archived outside of this
+		// resource may not be registered again by it
+		if subscription == nil || subscription.Status() == ocm.SubscriptionStatusArchived {
+			events.RegisterWarning(
+				request.Original,
+				r.Recorder,
+				"SubscriptionArchived",
+				fmt.Sprintf("subscription [%s] has been archived outside of this resource", request.Original.Status.SubscriptionID),
+			)
+
+			return controllers.RequeueAfter(r.Interval), conditions.WithReason(conditions.ReasonDriftDetected, fmt.Errorf(
+				"subscription [%s] for cluster [%s] - %w",
+				request.Original.Status.SubscriptionID,
+				request.Desired.Spec.ClusterUUID,
+				ErrSubscriptionArchived,
+			))
+		}
+
+		request.Subscription = subscription
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// find a subscription which already exists for the cluster
+	subscription, err := request.OCMClient.Find(request.Desired.Spec.ClusterUUID)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve subscription for cluster [%s] from ocm - %w",
+			request.Desired.Spec.ClusterUUID,
+			err,
+		)
+	}
+
+	if subscription == nil {
+		return controllers.NoRequeue(), nil
+	}
+
+	// ensure that a subscription which was not registered by the operator belongs to an allowed organization
+	if err := request.Environment.Organizations.CheckSubscription(request.Context, subscription); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	request.Subscription = subscription
+
+	// store the cluster and subscription id in the status, leaving the adopted subscription unregistered
+	// so that it is not archived when this resource is deleted
+	if err := request.updateStatusSubscription(false); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	// create an event indicating that the existing registration has been adopted
+	events.RegisterAction(events.Imported, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+
+	return controllers.NoRequeue(), nil
+}
+
+// ApplySubscription registers the cluster with OpenShift Cluster Manager if it has not yet been registered,
+// or updates its subscription if it is not in its desired state.
+func (r *Controller) ApplySubscription(request *ClusterRegistrationRequest) (ctrl.Result, error) {
+	// register the cluster
+	if request.Subscription == nil {
+		request.Log.Info("registering cluster", request.logValues()...)
+
+		subscription, err := request.OCMClient.Register(request.Desired.RegistrationBuilder())
+		request.recordOperation(ocmv1alpha1.OCMOperationCreate, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to register cluster [%s] in ocm - %w",
+				request.Desired.Spec.ClusterUUID,
+				err,
+			)
+		}
+
+		request.Subscription = subscription
+
+		if err := request.updateStatusSubscription(true); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+
+		// create an event indicating that the cluster has been registered
+		events.RegisterAction(events.Created, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// update the subscription if it is not in its desired state
+	if !request.Desired.SubscriptionDesired(request.Subscription) {
+		request.Log.Info("updating cluster subscription", request.logValues()...)
+
+		subscription, err := request.OCMClient.Update(request.Subscription.ID(), request.Desired.SubscriptionBuilder())
+		request.recordOperation(ocmv1alpha1.OCMOperationUpdate, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to update subscription [%s] in ocm - %w",
+				request.Subscription.ID(),
+				err,
+			)
+		}
+
+		request.Subscription = subscription
+
+		// create an event indicating that the subscription has been updated
+		events.RegisterAction(events.Updated, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	}
+
+	// keep the reported subscription status up to date
+	if err := request.updateStatusSubscription(false); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Destroy will archive the subscription of the registered cluster in OpenShift Cluster Manager.  A
+// subscription which existed before it was adopted by this resource belongs to whoever registered it,
+// so it is left in OpenShift Cluster Manager.
+func (r *Controller) Destroy(request *ClusterRegistrationRequest) (ctrl.Result, error) {
+	// return immediately if we have already archived the subscription
+	if conditions.IsSet(conditions.ClusterRegistrationDeleted(), request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	// leave the cluster subscription in openshift cluster manager while its deletion is prevented,
+	// checking again after the requeue interval so that the deletion continues once the annotation is
+	// removed
+	if controllers.PreventDestroyRequested(request.Original) {
+		return controllers.PreventDestroy(&r.Dependencies, request.execution(), r.requeue())
+	}
+
+	// only archive the subscription if we registered the cluster, as an adopted subscription was
+	// registered by someone else
+	switch {
+	case request.Original.Status.SubscriptionID == "":
+		// nothing has been registered or adopted
+	case !request.Original.Status.Registered:
+		request.Log.Info("leaving adopted cluster subscription in openshift cluster manager", request.logValues()...)
+	default:
+		request.Log.Info("archiving cluster subscription", request.logValues()...)
+
+		err := request.OCMClient.Archive(request.Original.Status.SubscriptionID)
+		request.recordOperation(ocmv1alpha1.OCMOperationDelete, request.OCMClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to archive subscription [%s] in ocm - %w",
+				request.Original.Status.SubscriptionID,
+				err,
+			)
+		}
+
+		// create an event indicating that the subscription has been archived
+		events.RegisterAction(events.Deleted, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	}
+
+	// set the deleted condition
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.ClusterRegistrationDeleted(),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating deleted condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  It will
+// requeue after the interval value requested by the controller configuration to ensure that the
+// object remains in its desired state at a specific interval.
+func (r *Controller) Complete(request *ClusterRegistrationRequest) (ctrl.Result, error) {
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	request.Log.Info("completed cluster registration reconciliation", request.logValues()...)
+	request.Log.Info(fmt.Sprintf("reconciling again in %s", r.Interval.String()), request.logValues()...)
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// CompleteDestroy will perform all actions required to successful complete a reconciliation request.
+func (r *Controller) CompleteDestroy(request *ClusterRegistrationRequest) (ctrl.Result, error) {
+	if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+	}
+
+	request.Log.Info("completed cluster registration deletion", request.logValues()...)
+
+	return controllers.NoRequeue(), nil
+}
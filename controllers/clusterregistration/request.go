@@ -0,0 +1,190 @@
+package clusterregistration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var (
+	ErrMissingSubscriptionID             = errors.New("unable to find subscription id")
+	ErrSubscriptionArchived              = errors.New("subscription has been archived")
+	ErrClusterRegistrationRequestConvert = errors.New("unable to convert generic request to cluster registration request")
+)
+
+// ClusterRegistrationRequest is an object that is unique to each reconciliation
+// request.
+type ClusterRegistrationRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ClusterRegistration
+	Desired           *ocmv1alpha1.ClusterRegistration
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+	Environment       *ocm.Environment
+	OCMClient         *ocm.SubscriptionClient
+
+	// data obtained during request reconciliation
+	Subscription *accountsmgmtv1.Subscription
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ClusterRegistration{}
+
+	// get the object (desired state) from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &ClusterRegistrationRequest{}, fmt.Errorf("unable to fetch cluster object - %w", err)
+		}
+
+		return &ClusterRegistrationRequest{}, err
+	}
+
+	// create the desired state of the request based on the inputs
+	desired := original.DeepCopy()
+	desired.Spec.DisplayName = desired.GetDisplayName()
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection, Organizations: r.Organizations},
+		original,
+	)
+	if err != nil {
+		return &ClusterRegistrationRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
+	}
+
+	return &ClusterRegistrationRequest{
+		Original:          original,
+		Desired:           desired,
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+		Environment:       environment,
+		OCMClient:         ocm.NewSubscriptionClient(environment.Connection).WithContext(ctx),
+	}, nil
+}
+
+func (request *ClusterRegistrationRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *ClusterRegistrationRequest) bindContext(ctx context.Context) {
+	request.Context = ctx
+
+	// the client which is shared between phases is bound to the context of each phase
+	if request.OCMClient != nil {
+		request.OCMClient.WithContext(ctx)
+	}
+}
+
+// execution returns the execution of the phases of the request.
+func (request *ClusterRegistrationRequest) execution() *controllers.Execution {
+	return &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
+	}
+}
+
+// execute executes a variety of different phases for the request.
+func (request *ClusterRegistrationRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, request.execution(), request, phases...)
+}
+
+// recordOperation records an operation which was sent to OCM in the operation history of the object.
+// Errors recording the operation are logged rather than returned so that the result of the operation
+// is not masked.
+func (request *ClusterRegistrationRequest) recordOperation(operation string, status int, err error) {
+	if recordErr := controllers.RecordOperation(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		operation,
+		status,
+		err,
+	); recordErr != nil {
+		request.Log.V(controllers.LogLevelDebug).Info(
+			fmt.Sprintf("unable to record %s operation - %s", strings.ToLower(operation), recordErr),
+			request.logValues()...,
+		)
+	}
+}
+
+// updateStatusSubscription updates fields related to the subscription which was created for the
+// registered cluster.  Once the subscription has been registered by this resource, rather than adopted,
+// it is recorded as registered so that it is archived when this resource is deleted.
+func (request *ClusterRegistrationRequest) updateStatusSubscription(registered bool) error {
+	// if the subscription id is missing return an error
+	if request.Subscription.ID() == "" {
+		return fmt.Errorf("missing subscription id in response - %w", ErrMissingSubscriptionID)
+	}
+
+	// the cluster id may not yet be assigned, in which case the stored cluster id is retained
+	clusterID := request.Subscription.ClusterID()
+	if clusterID == "" {
+		clusterID = request.Original.Status.ClusterID
+	}
+
+	registered = registered || request.Original.Status.Registered
+
+	// return if the status is already up to date
+	if request.Original.Status.ClusterID == clusterID &&
+		request.Original.Status.SubscriptionID == request.Subscription.ID() &&
+		request.Original.Status.SubscriptionStatus == request.Subscription.Status() &&
+		request.Original.Status.Registered == registered {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.ClusterID = clusterID
+	request.Original.Status.SubscriptionID = request.Subscription.ID()
+	request.Original.Status.SubscriptionStatus = request.Subscription.Status()
+	request.Original.Status.Registered = registered
+
+	// store the cluster and subscription id in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.clusterID=%s, status.subscriptionID=%s, status.subscriptionStatus=%s, status.registered=%t - %w",
+			request.Original.Status.ClusterID,
+			request.Original.Status.SubscriptionID,
+			request.Original.Status.SubscriptionStatus,
+			request.Original.Status.Registered,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *ClusterRegistrationRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", fmt.Sprintf("%s/%s", request.Desired.Namespace, request.Desired.Name),
+		"cluster", request.Desired.Spec.DisplayName,
+		"uuid", request.Desired.Spec.ClusterUUID,
+	}
+}
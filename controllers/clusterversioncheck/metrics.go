@@ -0,0 +1,21 @@
+package clusterversioncheck
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// availableUpgrades is the number of upgrades which are available to the cluster of each
+// cluster version check.  The number of clusters with pending upgrades may be queried with
+// count(ocm_cluster_available_upgrades > 0).
+var availableUpgrades = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "ocm_cluster_available_upgrades",
+		Help: "Number of upgrades which are available to a cluster in OpenShift Cluster Manager.",
+	},
+	[]string{"namespace", "name", "cluster"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(availableUpgrades)
+}
@@ -0,0 +1,193 @@
+package clusterversioncheck
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*ClusterVersionCheckRequest]
+
+// Begin begins the reconciliation state once we get the object from the cluster.
+// It is mainly used to set conditions of the controller and to let anyone who is viewiing the
+// custom resource know that we are currently reconciling.
+func (r *Controller) Begin(request *ClusterVersionCheckRequest) (ctrl.Result, error) {
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Authorize ensures that the namespace of the cluster version check has been granted management of its
+// cluster by a cluster management binding, so that the versions of clusters are not disclosed to other
+// tenants.  A forbidden cluster version check is reported with a Forbidden condition and a warning event,
+// and the request is retried at the regular interval.
+func (r *Controller) Authorize(request *ClusterVersionCheckRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Original.Spec.ClusterName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Original.Spec.ClusterName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// CheckUpgrades retrieves the current version of the cluster and the versions which it may be upgraded
+// to from OpenShift Cluster Manager, and publishes them in the status and as a metric.
+func (r *Controller) CheckUpgrades(request *ClusterVersionCheckRequest) (ctrl.Result, error) {
+	clusterClient := ocm.NewClusterClient(request.Environment.Connection, request.Original.Spec.ClusterName).WithContext(request.Context)
+
+	cluster, err := clusterClient.Get()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve cluster from ocm [name=%s] - %w",
+			request.Original.Spec.ClusterName,
+			err,
+		)
+	}
+
+	if cluster.ID() == "" {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"missing cluster id in response - %w",
+			ErrMissingClusterID,
+		)
+	}
+
+	if cluster.Version().ID() == "" {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"missing version in response for cluster [%s] - %w",
+			cluster.ID(),
+			ErrMissingVersion,
+		)
+	}
+
+	// the version of the cluster is retrieved directly, as the available upgrades are not
+	// included with the version in the cluster response
+	version, err := ocm.NewVersionClient(request.Environment.Connection).WithContext(request.Context).Get(cluster.Version().ID())
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	status := ocmv1alpha1.ClusterVersionCheckStatus{
+		Conditions:        request.Original.Status.Conditions,
+		ClusterID:         cluster.ID(),
+		Version:           version.RawID(),
+		ChannelGroup:      version.ChannelGroup(),
+		AvailableUpgrades: version.AvailableUpgrades(),
+	}
+
+	status.LatestZStreamUpgrade, status.LatestMinorUpgrade = ocm.LatestUpgrades(status.Version, status.AvailableUpgrades)
+
+	availableUpgrades.With(prometheus.Labels{
+		"namespace": request.Original.Namespace,
+		"name":      request.Original.Name,
+		"cluster":   request.Original.Spec.ClusterName,
+	}).Set(float64(len(status.AvailableUpgrades)))
+
+	// announce newly available upgrades
+	if status.LatestZStreamUpgrade != "" || status.LatestMinorUpgrade != "" {
+		if !reflect.DeepEqual(status.AvailableUpgrades, request.Original.Status.AvailableUpgrades) {
+			request.Log.Info(
+				fmt.Sprintf(
+					"upgrades available [version=%s, zStream=%s, minor=%s]",
+					status.Version,
+					status.LatestZStreamUpgrade,
+					status.LatestMinorUpgrade,
+				),
+				request.logValues()...,
+			)
+		}
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	now := metav1.Now()
+	status.LastCheckedTime = &now
+	request.Original.Status = status
+
+	// store the available upgrades in the status
+	if err := kubernetes.PatchStatus(request.Context, r, original, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to update status.availableUpgrades=%v - %w",
+			status.AvailableUpgrades,
+			err,
+		)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  It will
+// requeue after the interval value requested by the controller configuration so that the available
+// upgrades are checked again at a specific interval.
+func (r *Controller) Complete(request *ClusterVersionCheckRequest) (ctrl.Result, error) {
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	request.Log.Info("completed cluster version check", request.logValues()...)
+	request.Log.Info(fmt.Sprintf("checking again in %s", r.Interval.String()), request.logValues()...)
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// CompleteDestroy removes the metrics of the cluster version check and removes the finalizer so that
+// the cluster version check may be deleted.
+func (r *Controller) CompleteDestroy(request *ClusterVersionCheckRequest) (ctrl.Result, error) {
+	availableUpgrades.Delete(prometheus.Labels{
+		"namespace": request.Original.Namespace,
+		"name":      request.Original.Name,
+		"cluster":   request.Original.Spec.ClusterName,
+	})
+
+	if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+	}
+
+	request.Log.Info("completed cluster version check deletion", request.logValues()...)
+
+	return controllers.NoRequeue(), nil
+}
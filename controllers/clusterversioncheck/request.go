@@ -0,0 +1,108 @@
+package clusterversioncheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var (
+	ErrMissingClusterID                  = errors.New("unable to find cluster id")
+	ErrMissingVersion                    = errors.New("unable to find cluster version")
+	ErrClusterVersionCheckRequestConvert = errors.New("unable to convert generic request to cluster version check request")
+)
+
+// ClusterVersionCheckRequest is an object that is unique to each reconciliation
+// request.
+type ClusterVersionCheckRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ClusterVersionCheck
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+	Environment       *ocm.Environment
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ClusterVersionCheck{}
+
+	// get the object from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &ClusterVersionCheckRequest{}, fmt.Errorf("unable to fetch cluster object - %w", err)
+		}
+
+		return &ClusterVersionCheckRequest{}, err
+	}
+
+	// resolve the cluster of an object which references a cluster reference rather than naming
+	// its cluster
+	if err := controllers.ResolveClusterReference(ctx, r, original, r.requeue()); err != nil {
+		return &ClusterVersionCheckRequest{}, conditions.RecordWaiting(ctx, r, original, err)
+	}
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection},
+		original,
+	)
+	if err != nil {
+		return &ClusterVersionCheckRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
+	}
+
+	return &ClusterVersionCheckRequest{
+		Original:          original,
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+		Environment:       environment,
+	}, nil
+}
+
+func (request *ClusterVersionCheckRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *ClusterVersionCheckRequest) bindContext(ctx context.Context) {
+	// the clients of the request are created from the bound context within each phase rather than
+	// being shared between phases, so binding the context binds each of them
+	request.Context = ctx
+}
+
+// execute executes a variety of different phases for the request.
+func (request *ClusterVersionCheckRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
+	}, request, phases...)
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *ClusterVersionCheckRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", fmt.Sprintf("%s/%s", request.Original.Namespace, request.Original.Name),
+		"cluster", request.Original.Spec.ClusterName,
+	}
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Coalescer coalesces the reconciliation of rapid successive spec updates of an object, such as those
+// made by a GitOps sync, so that only the latest desired state is pushed to OpenShift Cluster Manager.
+// Each new generation of an object delays its reconciliation until the generation has been stable for
+// the coalesce window.  The first generation observed for an object is reconciled immediately, including
+// that of an object which has been recreated with the same name.
+type Coalescer struct {
+	Window time.Duration
+
+	mutex       sync.Mutex
+	generations map[string]observedGeneration
+}
+
+// observedGeneration is the latest generation which has been observed for an object.
+type observedGeneration struct {
+	uid        types.UID
+	generation int64
+
+	// changed is the time at which the generation was first observed, or the zero time if the
+	// generation was the first to be observed for the object
+	changed time.Time
+}
+
+// Coalesced represents a controller which coalesces rapid successive spec updates of its objects.
+type Coalesced interface {
+	GetCoalescer() *Coalescer
+}
+
+// NewCoalescer returns a new coalescer with a particular coalesce window.  Updates are not coalesced if
+// the window is zero.
+func NewCoalescer(window time.Duration) *Coalescer {
+	return &Coalescer{
+		Window:      window,
+		generations: map[string]observedGeneration{},
+	}
+}
+
+// Defer returns the amount of time by which the reconciliation of a generation of an object should be
+// deferred so that rapid successive updates are coalesced, and whether the generation is stale because
+// a newer generation has already been observed.  A stale generation should not be reconciled, as the
+// newer generation has already been queued.  The generations of an object are only compared with those
+// of the same uid, so that an object which is deleted and recreated with the same name, without its
+// deletion being observed, is not considered stale.
+func (coalescer *Coalescer) Defer(key string, uid types.UID, generation int64, now time.Time) (time.Duration, bool) {
+	if coalescer == nil || coalescer.Window == 0 {
+		return 0, false
+	}
+
+	coalescer.mutex.Lock()
+	defer coalescer.mutex.Unlock()
+
+	observed, found := coalescer.generations[key]
+	if !found || observed.uid != uid {
+		coalescer.generations[key] = observedGeneration{uid: uid, generation: generation}
+
+		return 0, false
+	}
+
+	switch {
+	case generation < observed.generation:
+		return 0, true
+	case generation > observed.generation:
+		coalescer.generations[key] = observedGeneration{uid: uid, generation: generation, changed: now}
+
+		return coalescer.Window, false
+	}
+
+	if observed.changed.IsZero() {
+		return 0, false
+	}
+
+	if remaining := coalescer.Window - now.Sub(observed.changed); remaining > 0 {
+		return remaining, false
+	}
+
+	return 0, false
+}
+
+// Forget removes the observed generation of an object, for example once it has been deleted.
+func (coalescer *Coalescer) Forget(key string) {
+	if coalescer == nil {
+		return
+	}
+
+	coalescer.mutex.Lock()
+	defer coalescer.mutex.Unlock()
+
+	delete(coalescer.generations, key)
+}
+
+// CoalescerKey returns the key by which the observed generation of an object which is reconciled by a
+// controller is tracked.  The type of the controller is included so that a single coalescer may be
+// shared across controllers.
+func CoalescerKey(controller Controller, req ctrl.Request) string {
+	return fmt.Sprintf("%T/%s", controller, req.NamespacedName)
+}
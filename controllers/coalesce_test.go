@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCoalescer_Defer(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+
+	type observation struct {
+		uid        types.UID
+		generation int64
+		after      time.Duration
+		wantDefer  time.Duration
+		wantStale  bool
+	}
+
+	tests := []struct {
+		name         string
+		window       time.Duration
+		observations []observation
+	}{
+		{
+			name:   "ensure the first generation is reconciled immediately",
+			window: 5 * time.Second,
+			observations: []observation{
+				{generation: 1, after: 0, wantDefer: 0},
+				{generation: 1, after: time.Second, wantDefer: 0},
+			},
+		},
+		{
+			name:   "ensure rapid successive generations are coalesced",
+			window: 5 * time.Second,
+			observations: []observation{
+				{generation: 1, after: 0, wantDefer: 0},
+				{generation: 2, after: time.Second, wantDefer: 5 * time.Second},
+				{generation: 3, after: 2 * time.Second, wantDefer: 5 * time.Second},
+				{generation: 3, after: 4 * time.Second, wantDefer: 3 * time.Second},
+				{generation: 3, after: 7 * time.Second, wantDefer: 0},
+			},
+		},
+		{
+			name:   "ensure a stale generation is skipped",
+			window: 5 * time.Second,
+			observations: []observation{
+				{generation: 2, after: 0, wantDefer: 0},
+				{generation: 1, after: time.Second, wantDefer: 0, wantStale: true},
+			},
+		},
+		{
+			name:   "ensure a recreated object is reconciled immediately",
+			window: 5 * time.Second,
+			observations: []observation{
+				{uid: "first", generation: 3, after: 0, wantDefer: 0},
+				{uid: "second", generation: 1, after: time.Second, wantDefer: 0},
+				{uid: "second", generation: 2, after: 2 * time.Second, wantDefer: 5 * time.Second},
+			},
+		},
+		{
+			name:   "ensure generations are not coalesced without a window",
+			window: 0,
+			observations: []observation{
+				{generation: 1, after: 0, wantDefer: 0},
+				{generation: 2, after: time.Second, wantDefer: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coalescer := NewCoalescer(tt.window)
+
+			for i, observed := range tt.observations {
+				deferral, stale := coalescer.Defer("test", observed.uid, observed.generation, start.Add(observed.after))
+				if deferral != observed.wantDefer {
+					t.Errorf("Defer() observation %d deferral = %v, want %v", i, deferral, observed.wantDefer)
+				}
+
+				if stale != observed.wantStale {
+					t.Errorf("Defer() observation %d stale = %v, want %v", i, stale, observed.wantStale)
+				}
+			}
+		})
+	}
+}
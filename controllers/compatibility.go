@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// Compatible represents a controller which depends upon APIs of OpenShift Cluster Manager whose
+// compatibility with the operator is probed in each environment when the operator starts.  A controller
+// which depends upon an API which is incompatible in the environment which an object targets is degraded
+// for the object, and does not reconcile it until the operator is upgraded, rather than failing
+// mid-reconcile.
+type Compatible interface {
+	GetCompatibility(object Workload) *ocm.Compatibility
+	RequiredAPIs() []ocm.API
+}
+
+// checkCompatibility checks that the APIs which a controller depends upon are compatible with the
+// operator in the environment which an object targets.  A controller which does not record the
+// compatibility of its APIs is always compatible.
+func checkCompatibility(controller Controller, object Workload) error {
+	compatible, ok := controller.(Compatible)
+	if !ok {
+		return nil
+	}
+
+	//nolint:wrapcheck
+	return compatible.GetCompatibility(object).Check(compatible.RequiredAPIs()...)
+}
@@ -1,12 +1,98 @@
 package controllers
 
+import "time"
+
 // Config represents the startup options used to start each of the controllers
 // in this operator.  These are the options used across all controllers in
 // the operator.
 type Config struct {
-	EnableLeaderElection  bool
-	MetricsAddress        string
-	ProbeAddress          string
-	TokenFile             string
-	PollerIntervalMinutes int
+	EnableLeaderElection           bool
+	EnableWebhooks                 bool
+	MetricsAddress                 string
+	MetricsCertDir                 string
+	MetricsClientCAFile            string
+	WebhookCertDir                 string
+	ProbeAddress                   string
+	TokenFile                      string
+	OCMEnvironment                 string
+	OCMEnvironmentTokenFiles       string
+	OCMRequestHeaders              string
+	OCMHTTPProxy                   string
+	OCMHTTPSProxy                  string
+	OCMNoProxy                     string
+	OCMTrustBundleConfigMap        string
+	OCMStatusServices              string
+	OCMReadTimeout                 time.Duration
+	OCMWriteTimeout                time.Duration
+	OCMFaults                      string
+	PollerIntervalMinutes          int
+	BlockInsecureIdentityProviders bool
+	EnableRawOverrides             bool
+	AllowedOrganizations           string
+	DisableOrganizationGuard       bool
+	CoalesceWindow                 time.Duration
+	ClusterConcurrency             int
+	MaxConcurrentReconciles        int
+	DeletionTimeout                time.Duration
+	PhaseTimeout                   time.Duration
+	DriftInterval                  time.Duration
+	ManageServiceMonitor           bool
+	ObjectMetricsHashBuckets       int
+	NotificationWebhookURL         string
+	NotificationWebhookFormat      string
+
+	// Controllers are the options of the individual controllers, indexed by the name of the
+	// controller.
+	Controllers map[string]*ControllerConfig
+
+	// EnvironmentAllowedOrganizations are the comma-separated ids of the organizations which the
+	// clusters in an individual environment of OpenShift Cluster Manager must belong to, indexed by the
+	// name of the environment.
+	EnvironmentAllowedOrganizations map[string]*string
+}
+
+// AllowedOrganizationsFor returns the comma-separated ids of the organizations which the clusters in an
+// environment of OpenShift Cluster Manager must belong to.  The allowed organizations of the operator
+// are used when they are not set for the environment.
+func (config *Config) AllowedOrganizationsFor(environment string) string {
+	if allowed, ok := config.EnvironmentAllowedOrganizations[environment]; ok && allowed != nil && *allowed != "" {
+		return *allowed
+	}
+
+	return config.AllowedOrganizations
+}
+
+// ControllerConfig represents the startup options of an individual controller, which allow
+// tuning how aggressively each resource type polls OpenShift Cluster Manager.
+type ControllerConfig struct {
+	// Interval is the interval at which an object is reconciled again once it is in its desired
+	// state.  The poller interval is used if this is zero.
+	Interval time.Duration
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
+
+	// PhaseTimeout overrides the phase timeout of the operator for the controller.  The phase timeout
+	// of the operator is used if this is zero.
+	PhaseTimeout time.Duration
+}
+
+// For returns the options of an individual controller, with the options which are not set for
+// the controller defaulted from the options of the operator.
+func (config *Config) For(controller string) ControllerConfig {
+	options := ControllerConfig{}
+	if configured, ok := config.Controllers[controller]; ok && configured != nil {
+		options = *configured
+	}
+
+	if options.Interval == 0 {
+		options.Interval = time.Duration(config.PollerIntervalMinutes) * time.Minute
+	}
+
+	if options.PhaseTimeout == 0 {
+		options.PhaseTimeout = config.PhaseTimeout
+	}
+
+	return options
 }
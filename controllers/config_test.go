@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_For(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		PollerIntervalMinutes: 5,
+		PhaseTimeout:          time.Minute,
+		Controllers: map[string]*ControllerConfig{
+			"tuned":   {Interval: time.Minute, Requeue: 10 * time.Second, PhaseTimeout: 10 * time.Second},
+			"requeue": {Requeue: time.Second},
+			"nil":     nil,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		controller string
+		want       ControllerConfig
+	}{
+		{
+			name:       "ensure the options of a tuned controller are returned",
+			controller: "tuned",
+			want:       ControllerConfig{Interval: time.Minute, Requeue: 10 * time.Second, PhaseTimeout: 10 * time.Second},
+		},
+		{
+			name:       "ensure the interval and phase timeout default to the options of the operator",
+			controller: "requeue",
+			want:       ControllerConfig{Interval: 5 * time.Minute, Requeue: time.Second, PhaseTimeout: time.Minute},
+		},
+		{
+			name:       "ensure a controller without options uses the defaults",
+			controller: "missing",
+			want:       ControllerConfig{Interval: 5 * time.Minute, PhaseTimeout: time.Minute},
+		},
+		{
+			name:       "ensure a controller with nil options uses the defaults",
+			controller: "nil",
+			want:       ControllerConfig{Interval: 5 * time.Minute, PhaseTimeout: time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := config.For(tt.controller); got != tt.want {
+				t.Errorf("For() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_AllowedOrganizationsFor(t *testing.T) {
+	t.Parallel()
+
+	stage, unset := "stage-org", ""
+
+	config := &Config{
+		AllowedOrganizations: "production-org",
+		EnvironmentAllowedOrganizations: map[string]*string{
+			"stage":       &stage,
+			"integration": &unset,
+			"nil":         nil,
+		},
+	}
+
+	for environment, want := range map[string]string{
+		"stage":       "stage-org",
+		"integration": "production-org",
+		"nil":         "production-org",
+		"production":  "production-org",
+	} {
+		if got := config.AllowedOrganizationsFor(environment); got != want {
+			t.Errorf("AllowedOrganizationsFor(%q) = %v, want %v", environment, got, want)
+		}
+	}
+}
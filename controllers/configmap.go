@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/utils"
+)
+
+const (
+	eventReasonReferencesEnqueued = "ReferencesEnqueued"
+)
+
+// ConfigMapPredicate returns a predicate which only allows events for config maps which are created, or
+// whose data changes.  It allows the events of referenced config maps through the event filters of a
+// controller, which otherwise ignore updates that do not change the generation of an object, so that an
+// object is reconciled as soon as the data which it references is rotated.
+func ConfigMapPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return configMapDataChanged(e.ObjectOld, e.ObjectNew)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			_, ok := e.Object.(*corev1.ConfigMap)
+
+			return ok
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// ConfigMapReferencer represents an object which references config maps in its namespace.
+type ConfigMapReferencer interface {
+	client.Object
+
+	// GetConfigMapNames returns the names of the referenced config maps.
+	GetConfigMapNames() []string
+}
+
+// EnqueueConfigMapReferences returns an event handler which enqueues each object of a list type, in the
+// namespace of a config map, which references the config map.  The objects are looked up by the indexed
+// fields which may reference a config map.  When the data of a config map changes, a single event is
+// recorded on the config map with the number of objects which were enqueued, so that the rotation of data
+// which is shared by many objects may be followed from one place.
+func EnqueueConfigMapReferences(
+	r kubernetes.Client,
+	list client.ObjectList,
+	recorder record.EventRecorder,
+	fields ...string,
+) handler.EventHandler {
+	enqueue := func(configMap client.Object, queue workqueue.RateLimitingInterface) int {
+		objects, err := ListReferences(context.Background(), r, list, configMap.GetNamespace(), configMap.GetName(), fields...)
+		if err != nil {
+			return 0
+		}
+
+		enqueued := 0
+
+		for _, item := range objects {
+			// an indexed field may also reference an object which is not a config map, so only enqueue an
+			// object which references the config map
+			object, ok := item.(ConfigMapReferencer)
+			if !ok || !utils.ContainsString(object.GetConfigMapNames(), configMap.GetName()) {
+				continue
+			}
+
+			queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+			enqueued++
+		}
+
+		return enqueued
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(e.Object, queue)
+		},
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			if !configMapDataChanged(e.ObjectOld, e.ObjectNew) {
+				return
+			}
+
+			enqueued := enqueue(e.ObjectNew, queue)
+			if enqueued == 0 || recorder == nil {
+				return
+			}
+
+			recorder.Event(e.ObjectNew, corev1.EventTypeNormal, eventReasonReferencesEnqueued, fmt.Sprintf(
+				"config map data changed; updating %d objects which reference it in ocm",
+				enqueued,
+			))
+		},
+	}
+}
+
+// configMapDataChanged determines if the data of a config map has changed between two versions of it.
+func configMapDataChanged(oldObject, newObject client.Object) bool {
+	oldConfigMap, ok := oldObject.(*corev1.ConfigMap)
+	if !ok {
+		return false
+	}
+
+	newConfigMap, ok := newObject.(*corev1.ConfigMap)
+	if !ok {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(oldConfigMap.Data, newConfigMap.Data) ||
+		!equality.Semantic.DeepEqual(oldConfigMap.BinaryData, newConfigMap.BinaryData)
+}
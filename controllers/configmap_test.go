@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestConfigMapPredicate(t *testing.T) {
+	t.Parallel()
+
+	configMap := func(data map[string]string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ca", Labels: labels}, Data: data}
+	}
+
+	tests := []struct {
+		name string
+		old  client.Object
+		new  client.Object
+		want bool
+	}{
+		{
+			name: "ensure a change to the data triggers reconciliation",
+			old:  configMap(map[string]string{"ca.crt": "old"}, nil),
+			new:  configMap(map[string]string{"ca.crt": "new"}, nil),
+			want: true,
+		},
+		{
+			name: "ensure a change to the metadata does not trigger reconciliation",
+			old:  configMap(map[string]string{"ca.crt": "old"}, nil),
+			new:  configMap(map[string]string{"ca.crt": "old"}, map[string]string{"example.com/test": "true"}),
+			want: false,
+		},
+		{
+			name: "ensure an object which is not a config map does not trigger reconciliation",
+			old:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca"}},
+			new:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca"}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ConfigMapPredicate().Update(event.UpdateEvent{ObjectOld: tt.old, ObjectNew: tt.new}); got != tt.want {
+				t.Errorf("ConfigMapPredicate().Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := ConfigMapPredicate().Create(event.CreateEvent{Object: configMap(nil, nil)}); !got {
+		t.Errorf("ConfigMapPredicate().Create() = %v, want %v", got, true)
+	}
+}
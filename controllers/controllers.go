@@ -69,19 +69,130 @@ func Reconcile(ctx context.Context, controller Controller, req ctrl.Request) (ct
 	// create the request
 	request, err := controller.NewRequest(ctx, req)
 	if err != nil {
+		// an object which is waiting for a dependency before its request may be created, such as a
+		// cluster reference, is requeued once the wait has elapsed
+		if waiting, ok := AsWaiting(err); ok {
+			return RequeueAfter(Jitter(waiting.Duration)), nil
+		}
+
 		if !apierrs.IsNotFound(err) {
 			return NoRequeue(), fmt.Errorf("unable to create request - %w", err)
 		}
 
+		coalescerFor(controller).Forget(CoalescerKey(controller, req))
+		objectMetricsFor(controller).Forget(ControllerName(controller), req)
+
 		return NoRequeue(), nil
 	}
 
 	// determine what triggered the reconcile request
 	trigger := triggers.GetTrigger(request.GetObject())
 
-	// run the reconciliation loop based on the event trigger
+	// do not create or update when the controller depends upon an api which is incompatible with the
+	// operator in the environment which the object targets.  deletions are still reconciled, so that a
+	// degraded controller does not block the removal of its objects and their finalizers.
+	if trigger.String() != triggers.DeleteString {
+		if err := checkCompatibility(controller, request.GetObject()); err != nil {
+			return NoRequeue(), ReconcileError(req, "controller is degraded", err)
+		}
+	}
+
+	// do not send any changes to openshift cluster manager while the environment which the object
+	// targets is under maintenance.  the object is reconciled again once the maintenance has finished.
+	maintenance := maintenanceFor(controller, request.GetObject())
+	if maintenance.Err() != nil {
+		return RequeueAfter(Jitter(DefaultMaintenanceBackoff)), nil
+	}
+
+	// release an object which requests to be retained rather than deleting it from openshift cluster
+	// manager, so that gitops pruning does not deprovision it
+	if trigger.String() == triggers.DeleteString {
+		retained, retainErr := retain(ctx, controller, request.GetObject())
+		if retainErr != nil {
+			return NoRequeue(), ReconcileError(req, "unable to retain object", retainErr)
+		}
+
+		if retained {
+			return NoRequeue(), nil
+		}
+	}
+
+	// coalesce rapid successive updates so that only the latest desired state is reconciled.  deletions
+	// are never deferred.
+	if trigger.String() != triggers.DeleteString {
+		deferral, stale := coalescerFor(controller).Defer(
+			CoalescerKey(controller, req),
+			request.GetObject().GetUID(),
+			request.GetObject().GetGeneration(),
+			time.Now(),
+		)
+
+		if stale {
+			return NoRequeue(), nil
+		}
+
+		if deferral > 0 {
+			return RequeueAfter(deferral), nil
+		}
+	}
+
+	// limit the number of objects which are reconciled against the same cluster at once, retrying
+	// shortly when the cluster is busy
+	throttle := throttleFor(controller)
+	key := CoalescerKey(controller, req)
+
+	if clustered, ok := request.GetObject().(ClusterWorkload); ok {
+		release, acquired := throttle.Acquire(clustered.GetClusterName())
+		if !acquired {
+			return RequeueAfter(throttle.Busy(key)), nil
+		}
+
+		defer release()
+	}
+
+	result, err := reconcileTrigger(controller, request, req, trigger.String())
+
+	// an error which results from maintenance which started during the reconcile is not a failure of
+	// the object, so back off rather than reporting it
+	if err != nil && maintenance.Err() != nil {
+		return RequeueAfter(Jitter(DefaultMaintenanceBackoff)), nil
+	}
+
+	// waiting for an external dependency is not a failure of the object, so requeue once the wait
+	// has elapsed rather than returning an error
+	if waiting, ok := AsWaiting(err); ok {
+		result, err = RequeueAfter(waiting.Duration), nil
+	}
+
+	// record the result so that objects which fail repeatedly are identifiable from monitoring
+	objectMetricsFor(controller).Observe(ControllerName(controller), req, err)
+
+	// escalate a deletion which has not completed within the deletion timeout, so that the object does
+	// not remain terminating forever
+	if trigger.String() == triggers.DeleteString && (err != nil || result.Requeue) {
+		forced, forceErr := forceDelete(ctx, controller, request.GetObject(), err, time.Now())
+		if forceErr != nil {
+			return result, ReconcileError(req, "unable to force deletion", forceErr)
+		}
+
+		if forced {
+			return NoRequeue(), nil
+		}
+	}
+
+	// stagger and jitter the requeue so that objects which are reconciled at the same interval are
+	// spread out rather than remaining synchronized
+	if result.RequeueAfter > 0 {
+		result.RequeueAfter = Jitter(throttle.Stagger(key, result.RequeueAfter))
+	}
+
+	return result, err
+}
+
+// reconcileTrigger runs the reconciliation loop of a controller based on the event trigger.
+func reconcileTrigger(controller Controller, request Request, req ctrl.Request, trigger string) (ctrl.Result, error) {
 	//nolint:wrapcheck
-	switch trigger.String() {
+	switch trigger {
 	case triggers.CreateString:
 		return controller.ReconcileCreate(request)
 	case triggers.UpdateString:
@@ -97,12 +208,42 @@ func Reconcile(ctx context.Context, controller Controller, req ctrl.Request) (ct
 	}
 }
 
+// coalescerFor returns the coalescer of a controller, or nil if the controller does not coalesce updates.
+func coalescerFor(controller Controller) *Coalescer {
+	if coalesced, ok := controller.(Coalesced); ok {
+		return coalesced.GetCoalescer()
+	}
+
+	return nil
+}
+
+// throttleFor returns the cluster throttle of a controller, or nil if the controller does not throttle
+// reconciliations.
+func throttleFor(controller Controller) *ClusterThrottle {
+	if throttled, ok := controller.(Throttled); ok {
+		return throttled.GetThrottle()
+	}
+
+	return nil
+}
+
 // RequeueAfter returns a requeue result to requeue after a specific
 // number of seconds.
 func RequeueAfter(seconds time.Duration) ctrl.Result {
 	return ctrl.Result{Requeue: true, RequeueAfter: seconds}
 }
 
+// RequeueHint returns a requeue result for an operation in OpenShift Cluster Manager which is still
+// progressing, requeuing after a hint which is derived from the state of the operation.  The hint is
+// capped at the interval of the controller, so that drift is still detected while waiting.
+func RequeueHint(hint, interval time.Duration) ctrl.Result {
+	if interval > 0 && (hint <= 0 || interval < hint) {
+		return RequeueAfter(interval)
+	}
+
+	return RequeueAfter(hint)
+}
+
 // NoRequeue returns a blank result to prevent a requeue.
 func NoRequeue() ctrl.Result {
 	return ctrl.Result{}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequeueHint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		hint     time.Duration
+		interval time.Duration
+		want     time.Duration
+	}{
+		{
+			name:     "ensure a hint shorter than the interval is used",
+			hint:     2 * time.Minute,
+			interval: 5 * time.Minute,
+			want:     2 * time.Minute,
+		},
+		{
+			name:     "ensure a hint longer than the interval is capped",
+			hint:     time.Hour,
+			interval: 5 * time.Minute,
+			want:     5 * time.Minute,
+		},
+		{
+			name:     "ensure a hint is used without an interval",
+			hint:     time.Hour,
+			interval: 0,
+			want:     time.Hour,
+		},
+		{
+			name:     "ensure the interval is used without a hint",
+			hint:     0,
+			interval: 5 * time.Minute,
+			want:     5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RequeueHint(tt.hint, tt.interval)
+			if !got.Requeue || got.RequeueAfter != tt.want {
+				t.Errorf("RequeueHint() = %+v, want requeue after %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// Results records the results of the phases of reconciliation in the conditions of a workload.  It is
+// implemented by the conditions package, which depends upon this package and is therefore provided to
+// the controllers rather than imported.
+type Results interface {
+	// IsNewTerminalFailure determines if an error which caused a phase to fail is terminal and has not
+	// already been recorded on the workload.
+	IsNewTerminalFailure(object Workload, phase string, err error) bool
+
+	// Record records the result of a phase on the workload, clearing a previously recorded failure
+	// when the error is nil.
+	Record(ctx context.Context, reconciler kubernetes.Client, object Workload, phase string, err error) error
+
+	// IsDestroyPrevented determines if the deletion of a workload from OpenShift Cluster Manager has
+	// already been recorded as prevented on the workload.
+	IsDestroyPrevented(object Workload) bool
+
+	// RecordDestroyPrevented records on the workload that its deletion from OpenShift Cluster Manager has
+	// been prevented by the prevent destroy annotation.
+	RecordDestroyPrevented(ctx context.Context, reconciler kubernetes.Client, object Workload) error
+}
+
+// Dependencies are the dependencies which are shared between the controllers.  They are embedded in
+// each controller, and a controller which does not use a particular dependency leaves it unset.
+type Dependencies struct {
+	Connection *sdk.Connection
+	Recorder   record.EventRecorder
+
+	// APIReader, when set, reads objects directly from the API rather than from the cache of the
+	// manager.  It is used to retrieve the latest version of an object whose status patch conflicts, as
+	// the cache may not yet have observed the version which caused the conflict.
+	APIReader client.Reader
+
+	// Results, when set, records the results of the phases of reconciliation in the conditions of each
+	// object, and notifies of terminal failures.
+	Results Results
+
+	// MaxConcurrentReconciles is the number of objects which the controller may reconcile at once.  A
+	// single object is reconciled at a time if this is zero.
+	MaxConcurrentReconciles int
+
+	// PhaseTimeout is the timeout of the deadline within which the phases of each request are
+	// executed.  See PhaseDeadline.
+	PhaseTimeout time.Duration
+
+	// Broadcaster, when set, triggers a reconciliation of all objects, for example when the
+	// connection to OpenShift Cluster Manager recovers.
+	Broadcaster *Broadcaster
+
+	// Coalescer, when set, coalesces rapid successive spec updates so that only the latest
+	// desired state is pushed to OpenShift Cluster Manager.
+	Coalescer *Coalescer
+
+	// Organizations, when set, ensures that clusters belong to an allowed organization before they
+	// are managed.
+	Organizations *ocm.OrganizationGuard
+
+	// Environments, when set, are the environments of OpenShift Cluster Manager which objects may
+	// target.  Objects which target the default environment are managed with the connection and
+	// organization guard of the controller.
+	Environments *ocm.Environments
+
+	// Throttle, when set, limits the number of objects which are reconciled against the same cluster
+	// at once and staggers their requeues.
+	Throttle *ClusterThrottle
+
+	// Metrics, when set, records the reconciliations and consecutive failures of each object.
+	Metrics *ObjectMetrics
+
+	// Compatibility, when set, degrades the controller when an API of the default environment of
+	// OpenShift Cluster Manager which it depends upon is incompatible with the operator.  Objects which
+	// target another environment use the compatibility of that environment.
+	Compatibility *ocm.Compatibility
+
+	// Maintenance, when set, backs off the controller while the default environment of OpenShift
+	// Cluster Manager is under maintenance.  Objects which target another environment use the
+	// maintenance of that environment.
+	Maintenance *ocm.Maintenance
+
+	// DeletionTimeout, when set, escalates the deletion of an object which has not completed within
+	// the timeout, removing its finalizer if the object allows it.
+	DeletionTimeout time.Duration
+
+	// DriftInterval, when set, is the amount of time after which the state of an object in OpenShift
+	// Cluster Manager is read again even though its inputs are unchanged since they were last applied.
+	// The state is read on every reconciliation if this is zero.
+	DriftInterval time.Duration
+}
+
+// GetCoalescer returns the coalescer of the controller.  It is used to satisfy the
+// Coalesced interface.
+func (dependencies *Dependencies) GetCoalescer() *Coalescer {
+	return dependencies.Coalescer
+}
+
+// GetThrottle returns the cluster throttle of the controller.  It is used to satisfy the
+// Throttled interface.
+func (dependencies *Dependencies) GetThrottle() *ClusterThrottle {
+	return dependencies.Throttle
+}
+
+// GetObjectMetrics returns the object metrics of the controller.  It is used to satisfy the
+// Metered interface.
+func (dependencies *Dependencies) GetObjectMetrics() *ObjectMetrics {
+	return dependencies.Metrics
+}
+
+// GetCompatibility returns the compatibility of the APIs of the environment of OpenShift Cluster Manager
+// which an object targets with the operator.  It is used to satisfy the Compatible interface.
+func (dependencies *Dependencies) GetCompatibility(object Workload) *ocm.Compatibility {
+	if environment := dependencies.environmentOf(object); environment != nil {
+		return environment.Compatibility
+	}
+
+	return dependencies.Compatibility
+}
+
+// GetMaintenance returns the maintenance of the environment of OpenShift Cluster Manager which an
+// object targets.  It is used to satisfy the Maintained interface.
+func (dependencies *Dependencies) GetMaintenance(object Workload) *ocm.Maintenance {
+	if environment := dependencies.environmentOf(object); environment != nil {
+		return environment.Maintenance
+	}
+
+	return dependencies.Maintenance
+}
+
+// environmentOf returns the environment of OpenShift Cluster Manager which an object targets, or nil
+// when it targets the default environment of the controller.  An object which targets an environment
+// which is not configured is not reconciled, so it is also considered to target the default.
+func (dependencies *Dependencies) environmentOf(object Workload) *ocm.Environment {
+	environment, err := EnvironmentFor(dependencies.Environments, nil, object)
+	if err != nil {
+		return nil
+	}
+
+	return environment
+}
+
+// GetDeletionTimeout returns the amount of time after which the deletion of an object which has not
+// completed is escalated.  It is used to satisfy the ForceDeletable interface.
+func (dependencies *Dependencies) GetDeletionTimeout() time.Duration {
+	return dependencies.DeletionTimeout
+}
+
+// GetAPIReader returns the reader which reads objects directly from the API.  It is used to satisfy the
+// kubernetes.LatestReader interface.
+func (dependencies *Dependencies) GetAPIReader() client.Reader {
+	return dependencies.APIReader
+}
+
+// GetRecorder returns the event recorder of the controller.  It is used to satisfy the
+// ForceDeletable interface.
+func (dependencies *Dependencies) GetRecorder() record.EventRecorder {
+	return dependencies.Recorder
+}
+
+// Options returns the options with which the controller is built by the manager.
+func (dependencies *Dependencies) Options() controller.Options {
+	return controller.Options{MaxConcurrentReconciles: dependencies.MaxConcurrentReconciles}
+}
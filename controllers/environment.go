@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// EnvironmentWorkload represents a workload which may target an environment of OpenShift Cluster
+// Manager other than the default environment of the operator.
+type EnvironmentWorkload interface {
+	GetOCMEnvironment() string
+}
+
+// EnvironmentFor returns the environment of OpenShift Cluster Manager which an object targets.  An
+// object which does not request an environment, or which requests the default environment, targets
+// the default environment of the controller.
+func EnvironmentFor(environments *ocm.Environments, defaultEnvironment *ocm.Environment, object Workload) (*ocm.Environment, error) {
+	targeted, ok := object.(EnvironmentWorkload)
+	if !ok || environments.IsDefault(targeted.GetOCMEnvironment()) {
+		return defaultEnvironment, nil
+	}
+
+	//nolint:wrapcheck
+	return environments.Get(targeted.GetOCMEnvironment())
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+func TestEnvironmentFor(t *testing.T) {
+	t.Parallel()
+
+	defaultEnvironment := &ocm.Environment{}
+	stage := &ocm.Environment{}
+
+	environments := ocm.NewEnvironments(ocm.EnvironmentProduction)
+	environments.Add(ocm.EnvironmentProduction, defaultEnvironment)
+	environments.Add(ocm.EnvironmentStage, stage)
+
+	pool := func(environment string) *ocmv1alpha1.MachinePool {
+		pool := &ocmv1alpha1.MachinePool{}
+		pool.Spec.OCMEnvironment = environment
+
+		return pool
+	}
+
+	tests := []struct {
+		name         string
+		environments *ocm.Environments
+		object       Workload
+		want         *ocm.Environment
+		wantErr      error
+	}{
+		{
+			name:         "ensure an object without an environment targets the default environment",
+			environments: environments,
+			object:       pool(""),
+			want:         defaultEnvironment,
+		},
+		{
+			name:         "ensure an object which requests the default environment targets the default environment",
+			environments: environments,
+			object:       pool(ocm.EnvironmentProduction),
+			want:         defaultEnvironment,
+		},
+		{
+			name:         "ensure an object which requests another environment targets the environment",
+			environments: environments,
+			object:       pool(ocm.EnvironmentStage),
+			want:         stage,
+		},
+		{
+			name:         "ensure an object which requests an environment which is not configured is rejected",
+			environments: environments,
+			object:       pool(ocm.EnvironmentIntegration),
+			wantErr:      ocm.ErrEnvironmentNotConfigured,
+		},
+		{
+			name:         "ensure an object which requests another environment is rejected without environments",
+			environments: nil,
+			object:       pool(ocm.EnvironmentStage),
+			wantErr:      ocm.ErrEnvironmentNotConfigured,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := EnvironmentFor(tt.environments, defaultEnvironment, tt.object)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EnvironmentFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("EnvironmentFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// RequestPhase represents an individual phase in the controller reconciliation process, whose function
+// is bound to the request of a particular controller when the phases are executed.
+type RequestPhase[R any] struct {
+	Name     string
+	Function func(R) (ctrl.Result, error)
+	Parallel bool
+}
+
+// Execution is the request of a controller whose phases are executed, along with the object upon which
+// the results of the phases are recorded.
+type Execution struct {
+	// Context is the context of the request, from which the context of each phase is derived.
+	Context context.Context
+
+	// Bind binds the context of a phase to the request, along with each client of the request which is
+	// shared between its phases.
+	Bind func(context.Context)
+
+	Reconciler kubernetes.Client
+	Object     Workload
+	Request    ctrl.Request
+	Log        logr.Logger
+	LogValues  func() []interface{}
+
+	// Unrecorded leaves the results and duration of the reconciliation unrecorded on the object, for a
+	// controller which reconciles an object whose reconciliation conditions belong to another controller.
+	Unrecorded bool
+}
+
+// Execute executes the phases of a request within the phase timeout of the controller.  The duration
+// of the reconciliation and of each of its phases is recorded on the object, along with the failure of
+// the phase which returned an error, which is cleared once all phases complete successfully.
+//
+//nolint:wrapcheck
+func Execute[R any](dependencies *Dependencies, execution *Execution, request R, phases ...RequestPhase[R]) (ctrl.Result, error) {
+	start := time.Now()
+
+	bound := make([]Phase, len(phases))
+	for i := range phases {
+		function := phases[i].Function
+
+		bound[i] = Phase{
+			Name:     phases[i].Name,
+			Parallel: phases[i].Parallel,
+			Function: func() (ctrl.Result, error) { return function(request) },
+		}
+	}
+
+	deadline := PhaseDeadline{
+		Timeout: dependencies.PhaseTimeout,
+		Context: execution.Context,
+		Bind:    execution.Bind,
+	}
+
+	// run each phase function and return if we receive any errors
+	phase, result, timings, err := ExecuteTimedPhasesWithin(deadline, bound...)
+	if !execution.Unrecorded {
+		execution.recordLastReconcile(start, timings)
+
+		if err != nil {
+			execution.recordFailure(dependencies, phase.Name, err)
+		}
+	}
+
+	if phase != nil {
+		return result, ReconcileError(
+			execution.Request,
+			fmt.Sprintf("%s phase reconciliation error", phase.Name),
+			err,
+		)
+	}
+
+	if !execution.Unrecorded {
+		execution.recordSuccess(dependencies)
+	}
+
+	return NoRequeue(), nil
+}
+
+// recordFailure records a failed reconciliation phase on the object so that failures are visible
+// in its status, and notifies of a terminal failure when it is first recorded.  Errors recording the
+// failure are logged rather than returned so that the original error is not masked.  A failure while
+// the environment of OpenShift Cluster Manager which the object targets is under maintenance is neither
+// recorded nor notified, as it results from the maintenance rather than the object, and the object is
+// reconciled again once it has finished.
+func (execution *Execution) recordFailure(dependencies *Dependencies, phase string, err error) {
+	if dependencies.Results == nil || dependencies.GetMaintenance(execution.Object).Err() != nil {
+		return
+	}
+
+	if dependencies.Recorder != nil && dependencies.Results.IsNewTerminalFailure(execution.Object, phase, err) {
+		events.RegisterFailure(execution.Object, dependencies.Recorder, phase, err)
+	}
+
+	if recordErr := dependencies.Results.Record(
+		execution.Context,
+		execution.Reconciler,
+		execution.Object,
+		phase,
+		err,
+	); recordErr != nil {
+		execution.debug(fmt.Sprintf("unable to record reconciliation failure - %s", recordErr))
+	}
+}
+
+// recordSuccess clears a previously recorded reconciliation failure from the object.
+func (execution *Execution) recordSuccess(dependencies *Dependencies) {
+	if dependencies.Results == nil {
+		return
+	}
+
+	if err := dependencies.Results.Record(
+		execution.Context,
+		execution.Reconciler,
+		execution.Object,
+		"",
+		nil,
+	); err != nil {
+		execution.debug(fmt.Sprintf("unable to record reconciliation success - %s", err))
+	}
+}
+
+// recordLastReconcile records the duration of the reconciliation, and of each of its phases, on the
+// object so that slow phases are visible in its status.
+func (execution *Execution) recordLastReconcile(start time.Time, timings []ocmv1alpha1.PhaseTiming) {
+	object, ok := execution.Object.(TimedWorkload)
+	if !ok {
+		return
+	}
+
+	if err := RecordLastReconcile(
+		execution.Context,
+		execution.Reconciler,
+		object,
+		start,
+		timings,
+	); err != nil {
+		execution.debug(fmt.Sprintf("unable to record reconciliation duration - %s", err))
+	}
+}
+
+// debug logs a message at the debug level along with the log values of the request.
+func (execution *Execution) debug(message string) {
+	execution.Log.V(LogLevelDebug).Info(message, execution.logValues()...)
+}
+
+// info logs a message at the info level along with the log values of the request.
+func (execution *Execution) info(message string) {
+	execution.Log.Info(message, execution.logValues()...)
+}
+
+// logValues returns the log values of the request.
+func (execution *Execution) logValues() []interface{} {
+	if execution.LogValues == nil {
+		return nil
+	}
+
+	return execution.LogValues()
+}
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// testResults records the phases whose results have been recorded, and whether the deletion of the
+// object has been recorded as prevented.
+type testResults struct {
+	recorded  []string
+	prevented bool
+}
+
+func (results *testResults) IsNewTerminalFailure(_ Workload, _ string, _ error) bool {
+	return false
+}
+
+func (results *testResults) Record(_ context.Context, _ kubernetes.Client, _ Workload, phase string, _ error) error {
+	results.recorded = append(results.recorded, phase)
+
+	return nil
+}
+
+func (results *testResults) IsDestroyPrevented(_ Workload) bool {
+	return results.prevented
+}
+
+func (results *testResults) RecordDestroyPrevented(_ context.Context, _ kubernetes.Client, _ Workload) error {
+	results.prevented = true
+
+	return nil
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	underMaintenance := &ocm.Maintenance{}
+	underMaintenance.Set(true, "scheduled maintenance")
+
+	// the stage environment is under maintenance while the integration environment is not
+	environments := ocm.NewEnvironments(ocm.EnvironmentProduction)
+	environments.Add(ocm.EnvironmentStage, &ocm.Environment{Maintenance: underMaintenance})
+	environments.Add(ocm.EnvironmentIntegration, &ocm.Environment{Maintenance: &ocm.Maintenance{}})
+
+	for _, tt := range []struct {
+		name         string
+		err          error
+		maintenance  *ocm.Maintenance
+		environment  string
+		unrecorded   bool
+		wantRecorded []string
+	}{
+		{
+			name:         "ensure a failed phase is recorded",
+			err:          errTestPhase,
+			wantRecorded: []string{"apply"},
+		},
+		{
+			name:         "ensure a success is recorded",
+			wantRecorded: []string{""},
+		},
+		{
+			name:        "ensure a failed phase is not recorded during maintenance",
+			err:         errTestPhase,
+			maintenance: underMaintenance,
+		},
+		{
+			name:        "ensure a failed phase is not recorded during maintenance of the environment of the object",
+			err:         errTestPhase,
+			environment: ocm.EnvironmentStage,
+		},
+		{
+			name:         "ensure a failed phase is recorded during maintenance of another environment",
+			err:          errTestPhase,
+			maintenance:  underMaintenance,
+			environment:  ocm.EnvironmentIntegration,
+			wantRecorded: []string{"apply"},
+		},
+		{
+			name:       "ensure a failed phase is not recorded for an unrecorded execution",
+			err:        errTestPhase,
+			unrecorded: true,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := &testResults{}
+			dependencies := &Dependencies{Results: results, Maintenance: tt.maintenance, Environments: environments}
+
+			object := &ocmv1alpha1.MachinePool{}
+			object.Spec.OCMEnvironment = tt.environment
+
+			execution := &Execution{
+				Context:    context.Background(),
+				Reconciler: &kubernetes.FakeClient{},
+				Object:     object,
+				Unrecorded: tt.unrecorded,
+			}
+
+			phase := RequestPhase[error]{
+				Name:     "apply",
+				Function: func(err error) (ctrl.Result, error) { return NoRequeue(), err },
+			}
+
+			if _, err := Execute(dependencies, execution, tt.err, phase); (err != nil) != (tt.err != nil) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.err)
+			}
+
+			if len(results.recorded) != len(tt.wantRecorded) {
+				t.Fatalf("Execute() recorded = %v, want %v", results.recorded, tt.wantRecorded)
+			}
+
+			for i := range tt.wantRecorded {
+				if results.recorded[i] != tt.wantRecorded[i] {
+					t.Errorf("Execute() recorded = %v, want %v", results.recorded, tt.wantRecorded)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// DefaultDriftInterval is the default amount of time after which the state of an object in OpenShift
+// Cluster Manager is read again, to detect drift, even though neither its generation nor its inputs have
+// changed since they were last applied.
+const DefaultDriftInterval = 30 * time.Minute
+
+// AppliedWorkload represents a workload which records the generation, a fingerprint of the inputs such as
+// the desired spec and the versions of referenced secrets, and the time at which they were last applied.
+// This allows the phases of a reconciliation which read OpenShift Cluster Manager to be skipped when
+// nothing has changed since, until the drift interval has elapsed.
+type AppliedWorkload interface {
+	Workload
+
+	GetObservedGeneration() int64
+	SetObservedGeneration(int64)
+	GetInputHash() string
+	SetInputHash(string)
+	GetLastAppliedTime() *metav1.Time
+	SetLastAppliedTime(*metav1.Time)
+}
+
+// UnchangedRequest represents a request which determines, before OpenShift Cluster Manager is read, whether
+// its inputs are unchanged since they were last applied.
+type UnchangedRequest interface {
+	InputsUnchanged() bool
+}
+
+// Fingerprint returns a hash of a set of inputs to a reconciliation.  Each input must be able to be
+// encoded as JSON.
+func Fingerprint(inputs ...interface{}) (string, error) {
+	hash := sha256.New()
+
+	encoder := json.NewEncoder(hash)
+	for _, input := range inputs {
+		if err := encoder.Encode(input); err != nil {
+			return "", fmt.Errorf("unable to fingerprint reconciliation inputs - %w", err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Applied determines if neither the generation of a workload nor the fingerprint of its inputs have
+// changed since they were last applied.
+func Applied(object AppliedWorkload, fingerprint string) bool {
+	return fingerprint != "" &&
+		object.GetObservedGeneration() == object.GetGeneration() &&
+		object.GetInputHash() == fingerprint
+}
+
+// Unchanged determines if the inputs of a workload have been applied, and were last applied within the
+// drift interval, so that its state in OpenShift Cluster Manager does not need to be read again.  A
+// workload is never unchanged if the drift interval is zero.
+func Unchanged(object AppliedWorkload, fingerprint string, driftInterval time.Duration, now time.Time) bool {
+	applied := object.GetLastAppliedTime()
+
+	return driftInterval > 0 &&
+		applied != nil &&
+		now.Sub(applied.Time) < driftInterval &&
+		Applied(object, fingerprint)
+}
+
+// SkipUnchanged returns the function of a phase which is skipped when the inputs of the request are
+// unchanged since they were last applied.  It wraps the phases which read or write OpenShift Cluster
+// Manager.
+func SkipUnchanged[R UnchangedRequest](function func(R) (ctrl.Result, error)) func(R) (ctrl.Result, error) {
+	return func(request R) (ctrl.Result, error) {
+		if request.InputsUnchanged() {
+			return NoRequeue(), nil
+		}
+
+		return function(request)
+	}
+}
+
+// RecordApplied records the generation of a workload, the fingerprint of its inputs and the time at which
+// they were applied, once its state in OpenShift Cluster Manager has been read and brought in line with
+// them.  Nothing is recorded without a fingerprint, such as when the phases which apply the workload were
+// skipped.
+func RecordApplied(ctx context.Context, reconciler kubernetes.Client, object AppliedWorkload, fingerprint string) error {
+	if fingerprint == "" {
+		return nil
+	}
+
+	// create a copy of the original and convert to a client object
+	original, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return ErrConvertClientObject
+	}
+
+	now := metav1.Now()
+
+	object.SetObservedGeneration(object.GetGeneration())
+	object.SetInputHash(fingerprint)
+	object.SetLastAppliedTime(&now)
+
+	//nolint:wrapcheck
+	return kubernetes.PatchStatus(ctx, reconciler, original, object)
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	spec := ocmv1alpha1.MachinePoolSpec{DisplayName: "test", MinimumNodesPerZone: 1}
+
+	want, err := Fingerprint(spec, "secret")
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		inputs []interface{}
+		equal  bool
+	}{
+		{
+			name:   "ensure identical inputs produce the same fingerprint",
+			inputs: []interface{}{spec, "secret"},
+			equal:  true,
+		},
+		{
+			name:   "ensure changed secret content produces a different fingerprint",
+			inputs: []interface{}{spec, "rotated"},
+			equal:  false,
+		},
+		{
+			name:   "ensure changed state produces a different fingerprint",
+			inputs: []interface{}{ocmv1alpha1.MachinePoolSpec{DisplayName: "test", MinimumNodesPerZone: 2}, "secret"},
+			equal:  false,
+		},
+		{
+			name:   "ensure inputs are not concatenated ambiguously",
+			inputs: []interface{}{spec, "sec", "ret"},
+			equal:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Fingerprint(tt.inputs...)
+			if err != nil {
+				t.Fatalf("Fingerprint() error = %v", err)
+			}
+
+			if (got == want) != tt.equal {
+				t.Errorf("Fingerprint() = %v, want equal %v to %v", got, tt.equal, want)
+			}
+		})
+	}
+}
+
+func TestUnchanged(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	pool := func(generation, observedGeneration int64, inputHash string, lastApplied time.Duration) *ocmv1alpha1.MachinePool {
+		applied := metav1.NewTime(now.Add(-lastApplied))
+
+		return &ocmv1alpha1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Status: ocmv1alpha1.MachinePoolStatus{
+				ObservedGeneration: observedGeneration,
+				InputHash:          inputHash,
+				LastAppliedTime:    &applied,
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		object        AppliedWorkload
+		fingerprint   string
+		driftInterval time.Duration
+		want          bool
+	}{
+		{
+			name:          "ensure an object with the same generation and fingerprint is unchanged",
+			object:        pool(2, 2, "abc", time.Minute),
+			fingerprint:   "abc",
+			driftInterval: DefaultDriftInterval,
+			want:          true,
+		},
+		{
+			name:          "ensure an object with a new generation is changed",
+			object:        pool(3, 2, "abc", time.Minute),
+			fingerprint:   "abc",
+			driftInterval: DefaultDriftInterval,
+			want:          false,
+		},
+		{
+			name:          "ensure an object with a new fingerprint is changed",
+			object:        pool(2, 2, "abc", time.Minute),
+			fingerprint:   "def",
+			driftInterval: DefaultDriftInterval,
+			want:          false,
+		},
+		{
+			name:          "ensure an object which has never been applied is changed",
+			object:        &ocmv1alpha1.MachinePool{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+			fingerprint:   "",
+			driftInterval: DefaultDriftInterval,
+			want:          false,
+		},
+		{
+			name:          "ensure an object which was last applied before the drift interval is changed",
+			object:        pool(2, 2, "abc", time.Hour),
+			fingerprint:   "abc",
+			driftInterval: DefaultDriftInterval,
+			want:          false,
+		},
+		{
+			name:          "ensure an object is always changed without a drift interval",
+			object:        pool(2, 2, "abc", time.Minute),
+			fingerprint:   "abc",
+			driftInterval: 0,
+			want:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Unchanged(tt.object, tt.fingerprint, tt.driftInterval, now); got != tt.want {
+				t.Errorf("Unchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	// AnnotationForceDelete is the annotation which allows the finalizer of an object to be removed when
+	// its deletion from OpenShift Cluster Manager has not completed within the deletion timeout.
+	AnnotationForceDelete = "ocm.mobb.redhat.com/force-delete"
+
+	annotationForceDeleteEnabled = "true"
+
+	// DefaultDeletionTimeout is the default amount of time after which the deletion of an object which
+	// has not completed is escalated.
+	DefaultDeletionTimeout = 30 * time.Minute
+
+	eventReasonDeletionStuck  = "DeletionStuck"
+	eventReasonDeletionForced = "DeletionForced"
+)
+
+// ForceDeletable represents a controller whose objects may have their finalizer removed when their
+// deletion from OpenShift Cluster Manager is unable to complete, for example because the cluster has
+// already been removed or OpenShift Cluster Manager rejects the deletion indefinitely, so that the
+// objects do not remain terminating forever.
+type ForceDeletable interface {
+	kubernetes.Client
+
+	GetDeletionTimeout() time.Duration
+	GetRecorder() record.EventRecorder
+}
+
+// ForceDeleteRequested determines if an object has allowed its finalizer to be removed when its
+// deletion has not completed within the deletion timeout.
+func ForceDeleteRequested(object client.Object) bool {
+	return object.GetAnnotations()[AnnotationForceDelete] == annotationForceDeleteEnabled
+}
+
+// deletionTimedOut determines if the deletion of an object has not completed within a timeout.  A zero
+// timeout never times out.
+func deletionTimedOut(object client.Object, timeout time.Duration, now time.Time) bool {
+	deleted := object.GetDeletionTimestamp()
+	if timeout <= 0 || deleted == nil {
+		return false
+	}
+
+	return now.Sub(deleted.Time) >= timeout
+}
+
+// forceDelete escalates the deletion of an object which has not completed within the deletion timeout
+// of its controller.  The failure of the deletion is recorded as an event on the object, and, if the
+// object allows it, the finalizer is removed so that the object is deleted while anything which remains
+// in OpenShift Cluster Manager is orphaned.  It returns whether the finalizer was removed.
+func forceDelete(ctx context.Context, controller Controller, object client.Object, cause error, now time.Time) (bool, error) {
+	// an object whose deletion is prevented is intentionally left terminating, so it is never escalated
+	deletable, ok := controller.(ForceDeletable)
+	if !ok || PreventDestroyRequested(object) || !deletionTimedOut(object, deletable.GetDeletionTimeout(), now) {
+		return false, nil
+	}
+
+	reason := "deletion has not completed"
+	if cause != nil {
+		reason = fmt.Sprintf("%s - %s", reason, cause)
+	}
+
+	if !ForceDeleteRequested(object) {
+		events.RegisterWarning(object, deletable.GetRecorder(), eventReasonDeletionStuck, fmt.Sprintf(
+			"%s after %s; set the %s=%s annotation to remove the finalizer",
+			reason,
+			deletable.GetDeletionTimeout(),
+			AnnotationForceDelete,
+			annotationForceDeleteEnabled,
+		))
+
+		return false, nil
+	}
+
+	if err := RemoveFinalizer(ctx, deletable, object); err != nil {
+		return false, fmt.Errorf("unable to force removal of finalizer - %w", err)
+	}
+
+	message := fmt.Sprintf("%s after %s; removed the finalizer, orphaning any objects remaining in ocm", reason, deletable.GetDeletionTimeout())
+
+	log.FromContext(ctx).Info(message, "resource", fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName()))
+	events.RegisterWarning(object, deletable.GetRecorder(), eventReasonDeletionForced, message)
+
+	return true, nil
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_deletionTimedOut(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	deleted := func(ago time.Duration) *metav1.Time {
+		deletedAt := metav1.NewTime(now.Add(-ago))
+
+		return &deletedAt
+	}
+
+	tests := []struct {
+		name    string
+		deleted *metav1.Time
+		timeout time.Duration
+		want    bool
+	}{
+		{
+			name:    "ensure an object which is not being deleted has not timed out",
+			deleted: nil,
+			timeout: time.Minute,
+			want:    false,
+		},
+		{
+			name:    "ensure a recent deletion has not timed out",
+			deleted: deleted(time.Minute),
+			timeout: DefaultDeletionTimeout,
+			want:    false,
+		},
+		{
+			name:    "ensure a deletion beyond the timeout has timed out",
+			deleted: deleted(time.Hour),
+			timeout: DefaultDeletionTimeout,
+			want:    true,
+		},
+		{
+			name:    "ensure a deletion never times out without a timeout",
+			deleted: deleted(24 * time.Hour),
+			timeout: 0,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", DeletionTimestamp: tt.deleted}}
+			if got := deletionTimedOut(object, tt.timeout, now); got != tt.want {
+				t.Errorf("deletionTimedOut() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForceDeleteRequested(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]bool{
+		"true":  true,
+		"false": false,
+		"":      false,
+	} {
+		object := testImportObject(map[string]string{AnnotationForceDelete: value})
+		if got := ForceDeleteRequested(object); got != want {
+			t.Errorf("ForceDeleteRequested(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -21,15 +21,16 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/nukleros/operator-builder-tools/pkg/controller/predicates"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
 )
 
 const (
@@ -40,16 +41,26 @@ const (
 type Controller struct {
 	client.Client
 
-	Scheme     *runtime.Scheme
-	Connection *sdk.Connection
-	Recorder   record.EventRecorder
-	Interval   time.Duration
+	controllers.Dependencies
+
+	Scheme   *runtime.Scheme
+	Interval time.Duration
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
 }
 
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=gitlabidentityproviders,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=gitlabidentityproviders/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=gitlabidentityproviders/finalizers,verbs=update
 
+// RequiredAPIs returns the APIs of OpenShift Cluster Manager which the controller depends upon.  It
+// is used to satisfy the Compatible interface.
+func (r *Controller) RequiredAPIs() []ocm.API {
+	return []ocm.API{ocm.APIClustersMgmt, ocm.APIAccountsMgmt}
+}
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
@@ -64,20 +75,26 @@ func (r *Controller) ReconcileCreate(req controllers.Request) (ctrl.Result, erro
 	// type cast the request to a gitlab identity provider request
 	request, ok := req.(*GitLabIdentityProviderRequest)
 	if !ok {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), ErrGitLabIdentityProviderRequestConvert
+		return controllers.RequeueAfter(r.requeue()), ErrGitLabIdentityProviderRequestConvert
 	}
 
 	// add the finalizer
 	if err := controllers.AddFinalizer(request.Context, r, request.Original); err != nil {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf("unable to register delete hooks - %w", err)
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to register delete hooks - %w", err)
 	}
 
 	// execute the phases
 	return request.execute([]Phase{
 		{Name: "begin", Function: r.Begin},
-		{Name: "getCurrentState", Function: r.GetCurrentState},
-		{Name: "applyGitLab", Function: r.ApplyGitLab},
-		{Name: "applyIdentityProvider", Function: r.ApplyIdentityProvider},
+		{Name: "authorize", Function: r.Authorize},
+		{Name: "waitForSecrets", Function: r.WaitForSecrets},
+		{Name: "checkInputs", Function: r.CheckInputs},
+		{Name: "getCurrentState", Function: controllers.SkipUnchanged(r.GetCurrentState)},
+		{Name: "import", Function: controllers.SkipUnchanged(r.Import)},
+		{Name: "applyGitLab", Function: controllers.SkipUnchanged(r.ApplyGitLab)},
+		{Name: "applyIdentityProvider", Function: controllers.SkipUnchanged(r.ApplyIdentityProvider)},
+		{Name: "publishCallbackURL", Function: r.PublishCallbackURL},
+		{Name: "migrate", Function: controllers.SkipUnchanged(r.Migrate)},
 		{Name: "complete", Function: r.Complete},
 	}...)
 }
@@ -95,21 +112,65 @@ func (r *Controller) ReconcileDelete(req controllers.Request) (ctrl.Result, erro
 	// type cast the request to a gitlab identity provider request
 	request, ok := req.(*GitLabIdentityProviderRequest)
 	if !ok {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), ErrGitLabIdentityProviderRequestConvert
+		return controllers.RequeueAfter(r.requeue()), ErrGitLabIdentityProviderRequestConvert
 	}
 
 	// execute the phases
 	return request.execute([]Phase{
 		{Name: "begin", Function: r.Begin},
+		{Name: "authorize", Function: r.Authorize},
 		{Name: "destroy", Function: r.Destroy},
 		{Name: "complete", Function: r.Complete},
 	}...)
 }
 
+// requeue returns the interval after which a failed or incomplete reconciliation is retried.
+func (r *Controller) requeue() time.Duration {
+	if r.Requeue == 0 {
+		return defaultGitLabIdentityProviderRequeue
+	}
+
+	return r.Requeue
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicates.WorkloadPredicates()).
+	// index the objects which are referenced by each identity provider, so that the identity providers
+	// which reference a secret are all updated when it changes
+	if err := controllers.SetupIndexes(
+		context.Background(),
+		mgr.GetFieldIndexer(),
+		&ocmv1alpha1.GitLabIdentityProvider{},
+		controllers.IndexBy(controllers.IndexClusterName, (*ocmv1alpha1.GitLabIdentityProvider).GetClusterName),
+		controllers.IndexBy(controllers.IndexClusterReference, controllers.ClusterReferenceOf[*ocmv1alpha1.GitLabIdentityProvider]),
+		controllers.IndexBy(controllers.IndexClusterID, controllers.ClusterIDOf[*ocmv1alpha1.GitLabIdentityProvider]),
+		controllers.IndexBy(controllers.IndexAccessTokenSecret, func(gitlab *ocmv1alpha1.GitLabIdentityProvider) string {
+			return gitlab.Spec.AccessTokenSecret
+		}),
+	); err != nil {
+		return fmt.Errorf("unable to index gitlab identity providers - %w", err)
+	}
+
+	managedBy := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options()).
+		WithEventFilter(predicate.Or(
+			controllers.WorkloadPredicates(),
+			controllers.ImportPredicate(),
+			controllers.BroadcastPredicate(),
+			controllers.ClusterReferencePredicate(),
+			controllers.SecretPredicate(),
+		)).
 		For(&ocmv1alpha1.GitLabIdentityProvider{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &ocmv1alpha1.ClusterReference{}}, controllers.EnqueueClusterReferences(r, &ocmv1alpha1.GitLabIdentityProviderList{})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, controllers.EnqueueSecretReferences(
+			r,
+			&ocmv1alpha1.GitLabIdentityProviderList{},
+			controllers.IndexAccessTokenSecret,
+		))
+
+	if r.Broadcaster != nil {
+		managedBy = managedBy.Watches(r.Broadcaster.Subscribe(), controllers.EnqueueAll(r, &ocmv1alpha1.GitLabIdentityProviderList{}))
+	}
+
+	return managedBy.Complete(r)
 }
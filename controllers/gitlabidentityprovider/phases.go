@@ -3,32 +3,186 @@ package gitlabidentityprovider
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	gitlab "github.com/xanzy/go-gitlab"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
 	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
 	"github.com/rh-mobb/ocm-operator/pkg/identityprovider"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
 )
 
 var (
-	ErrGitLabApplicationDrift = errors.New("gitlab application is immutable but differs from the desired state configuration")
+	ErrGitLabApplicationDrift   = errors.New("gitlab application is immutable but differs from the desired state configuration")
+	ErrMigrationClusterMismatch = errors.New("replaced identity provider must belong to the same cluster")
 )
 
 // Phase defines an individual phase in the controller reconciliation process.
-type Phase struct {
-	Name     string
-	Function func(*GitLabIdentityProviderRequest) (ctrl.Result, error)
-}
+type Phase = controllers.RequestPhase[*GitLabIdentityProviderRequest]
 
 // Begin begins the reconciliation state once we get the object (the desired state) from the cluster.
 // It is mainly used to set conditions of the controller and to let anyone who is viewiing the
 // custom resource know that we are currently reconciling.
 func (r *Controller) Begin(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
-	if err := request.updateCondition(conditions.Reconciling(request.Trigger)); err != nil {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf("error updating reconciling condition - %w", err)
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Authorize ensures that the namespace of the identity provider has been granted management of its cluster
+// by a cluster management binding.  A forbidden identity provider is reported with a Forbidden condition and
+// a warning event, and the request is retried at the regular interval.  A forbidden identity provider which is
+// deleted is released without deleting anything from OpenShift Cluster Manager.
+func (r *Controller) Authorize(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Desired.Spec.ClusterName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Desired.Spec.ClusterName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	// release the deleted object without touching openshift cluster manager, as the namespace is
+	// not allowed to manage the cluster
+	if request.Trigger == triggers.Delete {
+		if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// WaitForSecrets waits until each secret referenced by the GitLabIdentityProvider exists and contains the required
+// keys.  This allows the secrets to be created asynchronously, for example by an ExternalSecret or a
+// SealedSecret, without failing the reconciliation.  A waiting identity provider is reported with a
+// SecretsAvailable condition and a Waiting condition, and is reconciled again once a referenced secret
+// changes.
+func (r *Controller) WaitForSecrets(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
+	name, missing, err := controllers.MissingSecret(request.Context, r, request.Original)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if name != "" {
+		condition := conditions.WaitingForSecret(request.Original.Namespace, name, missing)
+
+		if !conditions.IsSet(condition, request.Original) {
+			request.Log.Info(condition.Message, request.logValues()...)
+		}
+
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating secrets available condition - %w", err)
+		}
+
+		return controllers.NoRequeue(), controllers.WaitFor(
+			fmt.Sprintf("secret [%s/%s]", request.Original.Namespace, name),
+			controllers.WaitReasonSecretUnavailable,
+			r.requeue(),
+		)
+	}
+
+	if conditions.IsWaitingForSecret(request.Original) {
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.SecretsAvailable()); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating secrets available condition - %w", err)
+		}
+	}
+
+	// get the secret access token data from the cluster
+	accessToken, err := kubernetes.GetSecretData(
+		request.Context,
+		r,
+		request.Original.Spec.AccessTokenSecret,
+		request.Original.Namespace,
+		ocmv1alpha1.GitLabAccessTokenKey,
+	)
+	if accessToken == "" {
+		if err == nil {
+			return controllers.RequeueAfter(r.requeue()), accessTokenError(request.Original, ErrMissingAccessToken)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), accessTokenError(request.Original, err)
+	}
+
+	// create the api client used to interact with gitlab
+	gitlabClient, err := gitlab.NewClient(accessToken, gitlab.WithBaseURL(request.Original.Spec.URL+"/api/v4"))
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error creating gitlab api client - %w", err)
+	}
+
+	// record the version of the access token secret so that a rotated token is detected
+	accessTokenVersion, err := kubernetes.GetSecretVersion(
+		request.Context,
+		r,
+		request.Original.Spec.AccessTokenSecret,
+		request.Original.Namespace,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), accessTokenError(request.Original, err)
+	}
+
+	request.AccessToken = accessToken
+	request.AccessTokenVersion = accessTokenVersion
+	request.GitLabClient = &identityprovider.GitLab{Client: gitlabClient}
+
+	return controllers.NoRequeue(), nil
+}
+
+// CheckInputs determines if the inputs of the GitLab identity provider, including the version of the access
+// token secret, are unchanged since they were last applied.  The phases which read the state of the identity
+// provider in OCM and GitLab are skipped while they are unchanged, until the drift interval has elapsed since
+// they were last applied.
+func (r *Controller) CheckInputs(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
+	inputs, err := request.fingerprint()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	request.Inputs = inputs
+	request.Unchanged = controllers.Unchanged(request.Original, inputs, r.DriftInterval, time.Now())
+	if request.Unchanged {
+		request.Log.V(controllers.LogLevelDebug).Info("gitlab identity provider unchanged since last applied", request.logValues()...)
 	}
 
 	return controllers.NoRequeue(), nil
@@ -41,22 +195,27 @@ func (r *Controller) Begin(request *GitLabIdentityProviderRequest) (ctrl.Result,
 //
 //nolint:cyclop
 func (r *Controller) GetCurrentState(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
-	// retrieve the cluster id
-	clusterID := request.Original.Status.ClusterID
-	if clusterID == "" {
-		if err := request.updateStatusCluster(); err != nil {
-			return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), err
-		}
-
-		clusterID = request.Original.Status.ClusterID
+	// retrieve the cluster id along with the callback url.  the cluster is retrieved on every request,
+	// rather than only until its id has been recorded, so that the organization of the cluster is checked
+	// on every reconcile.
+	if err := request.updateStatusCluster(); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
 	}
 
+	clusterID := request.Original.Status.ClusterID
+
 	// get the gitlab identity provider from ocm
-	request.OCMClient = ocm.NewGitLabIdentityProviderClient(request.Reconciler.Connection, request.Desired.Spec.DisplayName, clusterID)
+	request.OCMClient = ocm.NewGitLabIdentityProviderClient(
+		request.Environment.Connection,
+		request.Desired.Spec.DisplayName,
+		clusterID,
+	).WithContext(request.Context).
+		WithMappingMethod(request.Desired.GetMappingMethod()).
+		WithLoginOptions(request.Desired.Spec.Challenge, request.Desired.Spec.Login)
 
-	idp, err := request.OCMClient.Get()
+	idp, err := request.OCMClient.GetIdentityProvider()
 	if err != nil {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf(
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"unable to retrieve gitlab identity provider from ocm - %w",
 			err,
 		)
@@ -72,7 +231,69 @@ func (r *Controller) GetCurrentState(request *GitLabIdentityProviderRequest) (ct
 	request.Current.Spec.ClusterName = request.Desired.Spec.ClusterName
 	request.Current.Spec.DisplayName = request.Desired.Spec.DisplayName
 	request.Current.Spec.AccessTokenSecret = request.Desired.Spec.AccessTokenSecret
-	request.Current.CopyFrom(idp)
+	request.Current.Spec.MigrateFrom = request.Desired.Spec.MigrateFrom
+	request.Current.Spec.Challenge, request.Current.Spec.Login = ocm.LoginOptions(idp)
+	request.Current.CopyFrom(idp.Gitlab())
+
+	return controllers.NoRequeue(), nil
+}
+
+// Import imports the current state of the GitLab identity provider from OpenShift Cluster Manager into the
+// spec when the import annotation is set, and removes the annotation so that the import only happens
+// once.  This allows identity providers which were created outside of this controller to be onboarded
+// when they are also adopted.
+func (r *Controller) Import(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
+	if !controllers.ImportRequested(request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	// identity providers carry no marker that they are managed by this operator, so one which exists
+	// may only be imported when it is explicitly adopted
+	if request.Current != nil && !controllers.AdoptRequested(request.Original) {
+		return controllers.RequeueAfter(r.requeue()), conditions.WithReason(
+			conditions.ReasonValidationRejected,
+			fmt.Errorf(
+				"gitlab identity provider [%s] requires the [%s=true] annotation - %w",
+				request.Desired.Spec.DisplayName,
+				controllers.AnnotationAdopt,
+				controllers.ErrImportNotAdopted,
+			),
+		)
+	}
+
+	imported := request.Original.DeepCopy()
+	if request.Current != nil {
+		imported.ImportFrom(request.Current)
+	}
+
+	if err := controllers.Import(request.Context, r, request.Original, imported); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	request.Original = imported
+
+	if request.Current == nil {
+		request.Log.Info("gitlab identity provider not found in openshift cluster manager; skipping import", request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, "ImportSkipped", "gitlab identity provider not found in openshift cluster manager")
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// update the desired state to reflect the imported spec
+	request.Desired.ImportFrom(request.Current)
+
+	// report the immutable fields which were not imported
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Imported(imported.ImportMismatches(request.Current)),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	// create an event indicating that the gitlab identity provider has been imported
+	events.RegisterAction(events.Imported, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
 
 	return controllers.NoRequeue(), nil
 }
@@ -84,7 +305,7 @@ func (r *Controller) ApplyGitLab(request *GitLabIdentityProviderRequest) (ctrl.R
 	// application to search for
 	application, err := request.GitLabClient.GetApplication(request.Desired.Spec.DisplayName)
 	if err != nil {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf(
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"unable to retrieve application from gitlab - %w",
 			err,
 		)
@@ -95,7 +316,7 @@ func (r *Controller) ApplyGitLab(request *GitLabIdentityProviderRequest) (ctrl.R
 	if application == nil {
 		application, err := request.GitLabClient.CreateApplication(request.Desired.Spec.DisplayName, request.Original.Status.CallbackURL)
 		if err != nil {
-			return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf(
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 				"unable to create oauth application in gitlab - %w",
 				err,
 			)
@@ -118,15 +339,19 @@ func (r *Controller) ApplyGitLab(request *GitLabIdentityProviderRequest) (ctrl.R
 	}
 
 	// return an error as we will not allow updates to the gitlab application
-	return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), ErrGitLabApplicationDrift
+	return controllers.RequeueAfter(r.requeue()), conditions.WithReason(conditions.ReasonDriftDetected, ErrGitLabApplicationDrift)
 }
 
 // ApplyIdentityProvider applies the GitLab identity provider state to OCM.  This includes creating and/or updating
-// the identity provider based on the provided attributes from the custom resource.
+// the identity provider based on the provided attributes from the custom resource.  The write is only skipped
+// when the identity provider is already in its desired state.
 func (r *Controller) ApplyIdentityProvider(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
+	applied := request.Inputs
+
 	// return if it is already in its desired state
 	if request.desired() {
 		request.Log.V(controllers.LogLevelDebug).Info("gitlab identity provider already in desired state", request.logValues()...)
+		request.Fingerprint = applied
 
 		return controllers.NoRequeue(), nil
 	}
@@ -136,8 +361,10 @@ func (r *Controller) ApplyIdentityProvider(request *GitLabIdentityProviderReques
 	// create the identity provider if it does not exist
 	if request.Current == nil {
 		_, err := request.OCMClient.Create(builder)
+		request.recordOperation(ocmv1alpha1.OCMOperationCreate, request.OCMClient.LastStatus(), err)
+
 		if err != nil {
-			return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf(
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 				"unable to create gitlab identity provider in ocm - %w",
 				err,
 			)
@@ -146,13 +373,17 @@ func (r *Controller) ApplyIdentityProvider(request *GitLabIdentityProviderReques
 
 	// update the identity provider if it does exist
 	_, err := request.OCMClient.Update(builder)
+	request.recordOperation(ocmv1alpha1.OCMOperationUpdate, request.OCMClient.LastStatus(), err)
+
 	if err != nil {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf(
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"unable to update gitlab identity provider in ocm - %w",
 			err,
 		)
 	}
 
+	request.Fingerprint = applied
+
 	return controllers.NoRequeue(), nil
 }
 
@@ -168,12 +399,137 @@ func (r *Controller) Destroy(request *GitLabIdentityProviderRequest) (ctrl.Resul
 	return controllers.NoRequeue(), nil
 }
 
+// The controller must be able to create and update the config maps which store the callback url.
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// PublishCallbackURL writes the OAuth callback URL of the identity provider to a config map, if one is
+// requested, so that it may be consumed programmatically by teams who register OAuth applications.
+func (r *Controller) PublishCallbackURL(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
+	if request.Desired.Spec.CallbackURLConfigMap == "" || request.Original.Status.CallbackURL == "" {
+		return controllers.NoRequeue(), nil
+	}
+
+	if err := kubernetes.ApplyConfigMapData(
+		request.Context,
+		r.Client,
+		r.Scheme,
+		request.Original,
+		request.Desired.Spec.CallbackURLConfigMap,
+		map[string]string{ocmv1alpha1.GitLabCallbackURLKey: request.Original.Status.CallbackURL},
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to publish callback url - %w",
+			err,
+		)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// The controller must be able to delete the LDAP identity providers which are replaced by a GitLab identity
+// provider.
+
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=ldapidentityproviders,verbs=get;list;watch;delete
+
+// Migrate removes the LDAPIdentityProvider which is replaced by the GitLab identity provider, if one is
+// requested.  The LDAPIdentityProvider is only deleted once the GitLab identity provider has been confirmed
+// to exist in OpenShift Cluster Manager, so that users are always able to log in to the cluster.  Deleting
+// the LDAPIdentityProvider removes its identity provider from OpenShift Cluster Manager.
+func (r *Controller) Migrate(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
+	migration := request.Desired.Spec.MigrateFrom
+	if migration == nil {
+		return controllers.NoRequeue(), nil
+	}
+
+	// confirm that the replacement identity provider exists before removing the replaced identity
+	// provider
+	idp, err := request.OCMClient.Get()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to confirm gitlab identity provider in ocm - %w",
+			err,
+		)
+	}
+
+	if idp == nil {
+		condition := conditions.Migrating(request.Original.Namespace, migration.Name)
+
+		if !conditions.IsSet(condition, request.Original) {
+			request.Log.Info(condition.Message, request.logValues()...)
+		}
+
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating migrated condition - %w", err)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	replaced := &ocmv1alpha1.LDAPIdentityProvider{}
+
+	if err := r.Get(request.Context, types.NamespacedName{Namespace: request.Original.Namespace, Name: migration.Name}, replaced); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to retrieve replaced ldap identity provider [%s/%s] - %w",
+				request.Original.Namespace,
+				migration.Name,
+				err,
+			)
+		}
+
+		replaced = nil
+	}
+
+	if replaced != nil && replaced.Spec.ClusterName != request.Desired.Spec.ClusterName {
+		return controllers.RequeueAfter(r.requeue()), conditions.WithReason(conditions.ReasonValidationRejected, fmt.Errorf(
+			"unable to replace ldap identity provider [%s/%s] of cluster [%s] - %w",
+			replaced.Namespace,
+			replaced.Name,
+			replaced.Spec.ClusterName,
+			ErrMigrationClusterMismatch,
+		))
+	}
+
+	// delete the replaced identity provider, whose controller removes it from openshift cluster manager
+	if replaced != nil && replaced.DeletionTimestamp.IsZero() {
+		request.Log.Info(fmt.Sprintf("deleting replaced ldap identity provider [%s]", replaced.Name), request.logValues()...)
+
+		if err := r.Delete(request.Context, replaced); err != nil && !apierrs.IsNotFound(err) {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"unable to delete replaced ldap identity provider [%s/%s] - %w",
+				replaced.Namespace,
+				replaced.Name,
+				err,
+			)
+		}
+
+		// create an event indicating that the replaced identity provider has been deleted
+		events.RegisterAction(events.Deleted, request.Original, r.Recorder, replaced.Name, request.Original.Status.ClusterID)
+	}
+
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Migrated(request.Original.Namespace, migration.Name),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating migrated condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
 // Complete will perform all actions required to successful complete a reconciliation request.  It will
 // requeue after the interval value requested by the controller configuration to ensure that the
 // object remains in its desired state at a specific interval.
 func (r *Controller) Complete(request *GitLabIdentityProviderRequest) (ctrl.Result, error) {
-	if err := request.updateCondition(conditions.Reconciled(request.Trigger)); err != nil {
-		return controllers.RequeueAfter(defaultGitLabIdentityProviderRequeue), fmt.Errorf("error updating reconciled condition - %w", err)
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	if err := controllers.RecordApplied(request.Context, r, request.Original, request.Fingerprint); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error recording applied inputs - %w", err)
 	}
 
 	request.Log.Info("completed gitlab identity provider reconciliation", request.logValues()...)
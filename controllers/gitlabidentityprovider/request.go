@@ -4,18 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
-	gitlab "github.com/xanzy/go-gitlab"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
 	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/diff"
 	"github.com/rh-mobb/ocm-operator/pkg/identityprovider"
 	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
@@ -41,13 +40,24 @@ type GitLabIdentityProviderRequest struct {
 	Log               logr.Logger
 	Trigger           triggers.Trigger
 	Reconciler        *Controller
+	Environment       *ocm.Environment
 	GitLabClient      *identityprovider.GitLab
 	OCMClient         *ocm.GitLabIdentityProviderClient
 
 	// data obtained during request reconciliation
-	AccessToken  string
-	ClientID     string
-	ClientSecret string
+	AccessToken        string
+	AccessTokenVersion string
+	ClientID           string
+	ClientSecret       string
+
+	// Inputs is the fingerprint of the inputs of the request, and Unchanged determines if they are
+	// unchanged since they were last applied, in which case the state of the identity provider in OCM
+	// and gitlab is not read again until the drift interval has elapsed.
+	Inputs    string
+	Unchanged bool
+
+	// Fingerprint is the fingerprint of the inputs which have been applied during this request.
+	Fingerprint string
 }
 
 func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
@@ -63,26 +73,24 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 		return &GitLabIdentityProviderRequest{}, err
 	}
 
-	// get the secret access token data from the cluster
-	accessToken, err := kubernetes.GetSecretData(ctx, r, original.Spec.AccessTokenSecret, req.Namespace, ocmv1alpha1.GitLabAccessTokenKey)
-	if accessToken == "" {
-		if err == nil {
-			return &GitLabIdentityProviderRequest{}, accessTokenError(original, ErrMissingAccessToken)
-		}
-
-		return &GitLabIdentityProviderRequest{}, accessTokenError(original, err)
-	}
-
-	// create the api client used to interact with gitlab
-	gitlabClient, err := gitlab.NewClient(accessToken, gitlab.WithBaseURL(original.Spec.URL+"/api/v4"))
-	if err != nil {
-		return &GitLabIdentityProviderRequest{}, fmt.Errorf("error creating gitlab api client - %w", err)
+	// resolve the cluster of an object which references a cluster reference rather than naming
+	// its cluster
+	if err := controllers.ResolveClusterReference(ctx, r, original, r.requeue()); err != nil {
+		return &GitLabIdentityProviderRequest{}, conditions.RecordWaiting(ctx, r, original, err)
 	}
 
 	// create the desired state of the request based on the inputs
 	desired := original.DeepCopy()
-	if desired.Spec.DisplayName == "" {
-		desired.Spec.DisplayName = desired.Name
+	desired.Spec.DisplayName = desired.GetDisplayName()
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection, Organizations: r.Organizations},
+		original,
+	)
+	if err != nil {
+		return &GitLabIdentityProviderRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
 	}
 
 	return &GitLabIdentityProviderRequest{
@@ -93,10 +101,7 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 		Log:               log.Log,
 		Trigger:           triggers.GetTrigger(original),
 		Reconciler:        r,
-		GitLabClient:      &identityprovider.GitLab{Client: gitlabClient},
-
-		// data obtained from cluster
-		AccessToken: accessToken,
+		Environment:       environment,
 	}, nil
 }
 
@@ -104,44 +109,58 @@ func (request *GitLabIdentityProviderRequest) GetObject() controllers.Workload {
 	return request.Original
 }
 
-// execute executes a variety of different phases for the request.
-//
-//nolint:wrapcheck
-func (request *GitLabIdentityProviderRequest) execute(phases ...Phase) (ctrl.Result, error) {
-	for execute := range phases {
-		// run each phase function and return if we receive any errors
-		result, err := phases[execute].Function(request)
-		if err != nil || result.Requeue {
-			return result, controllers.ReconcileError(
-				request.ControllerRequest,
-				fmt.Sprintf("%s phase reconciliation error", phases[execute].Name),
-				err,
-			)
-		}
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *GitLabIdentityProviderRequest) bindContext(ctx context.Context) {
+	request.Context = ctx
+
+	// the client which is shared between phases is bound to the context of each phase
+	if request.OCMClient != nil {
+		request.OCMClient.WithContext(ctx)
 	}
+}
 
-	return controllers.NoRequeue(), nil
+// execute executes a variety of different phases for the request.
+func (request *GitLabIdentityProviderRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
+	}, request, phases...)
 }
 
-// TODO: centralize this function into controllers or conditions package.
-func (request *GitLabIdentityProviderRequest) updateCondition(condition *metav1.Condition) error {
-	if err := conditions.Update(
+// recordOperation records an operation which was sent to OCM in the operation history of the object.
+// Errors recording the operation are logged rather than returned so that the result of the operation
+// is not masked.
+func (request *GitLabIdentityProviderRequest) recordOperation(operation string, status int, err error) {
+	if recordErr := controllers.RecordOperation(
 		request.Context,
 		request.Reconciler,
 		request.Original,
-		condition,
-	); err != nil {
-		return fmt.Errorf("unable to update condition - %w", err)
+		operation,
+		status,
+		err,
+	); recordErr != nil {
+		request.Log.V(controllers.LogLevelDebug).Info(
+			fmt.Sprintf("unable to record %s operation - %s", strings.ToLower(operation), recordErr),
+			request.logValues()...,
+		)
 	}
-
-	return nil
 }
 
 // updateStatusCluster updates fields related to the cluster in which the gitlab identity provider resides in.
+// The cluster id is recorded once, while the callback url is refreshed whenever the cluster is the recorded
+// cluster, for example when the cluster id was seeded from a cluster reference.  The status is only patched
+// when it has changed.
 // TODO: centralize this function into controllers or conditions package.
 func (request *GitLabIdentityProviderRequest) updateStatusCluster() error {
 	// retrieve the cluster id
-	clusterClient := ocm.NewClusterClient(request.Reconciler.Connection, request.Desired.Spec.ClusterName)
+	clusterClient := ocm.NewClusterClient(request.Environment.Connection, request.Desired.Spec.ClusterName).
+		WithOrganizationGuard(request.Environment.Organizations).
+		WithContext(request.Context)
 	cluster, err := clusterClient.Get()
 	if err != nil {
 		return fmt.Errorf(
@@ -158,8 +177,13 @@ func (request *GitLabIdentityProviderRequest) updateStatusCluster() error {
 
 	// keep track of the original object
 	original := request.Original.DeepCopy()
-	request.Original.Status.ClusterID = cluster.ID()
-	request.Original.Status.CallbackURL = ocm.GetCallbackURL(cluster, request.Desired.Spec.DisplayName)
+	if request.Original.Status.ClusterID == "" {
+		request.Original.Status.ClusterID = cluster.ID()
+	}
+
+	if request.Original.Status.ClusterID == cluster.ID() {
+		request.Original.Status.CallbackURL = ocm.GetCallbackURL(cluster, request.Desired.Spec.DisplayName)
+	}
 
 	// store the cluster id in the status
 	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
@@ -188,18 +212,41 @@ func (request *GitLabIdentityProviderRequest) desired() bool {
 		return false
 	}
 
-	return reflect.DeepEqual(
-		request.Desired.Spec,
-		request.Current.Spec,
-	)
+	desired, current := request.Desired.Spec, request.Current.Spec
+
+	return diff.New().
+		Field("clusterName", diff.Values(desired.ClusterName, current.ClusterName)).
+		Field("displayName", diff.Values(desired.DisplayName, current.DisplayName)).
+		Field("mappingMethod", diff.Fold(desired.MappingMethod, current.MappingMethod)).
+		Field("challenge", diff.Requested(desired.Challenge, current.Challenge)).
+		Field("login", diff.Requested(desired.Login, current.Login)).
+		Field("url", diff.URLs(desired.URL, current.URL)).
+		Field("ca", diff.Values(desired.CA, current.CA)).
+		Field("accessTokenSecret", diff.Values(desired.AccessTokenSecret, current.AccessTokenSecret)).
+		Field("callbackURLConfigMap", diff.Values(desired.CallbackURLConfigMap, current.CallbackURLConfigMap)).
+		Field("migrateFrom", diff.Pointers(desired.MigrateFrom, current.MigrateFrom)).
+		Equal()
+}
+
+// fingerprint returns the fingerprint of the inputs of the request.  The version of the access token
+// secret is included rather than the access token itself, so that the fingerprint reveals nothing about
+// the token.
+func (request *GitLabIdentityProviderRequest) fingerprint() (string, error) {
+	return controllers.Fingerprint(request.Desired.Spec, request.AccessTokenVersion)
+}
+
+// InputsUnchanged determines if the inputs of the request are unchanged since they were last applied.  It
+// is used to satisfy the controllers.UnchangedRequest interface.
+func (request *GitLabIdentityProviderRequest) InputsUnchanged() bool {
+	return request.Unchanged
 }
 
 func accessTokenError(from *ocmv1alpha1.GitLabIdentityProvider, err error) error {
-	return fmt.Errorf(
+	return conditions.WithReason(conditions.ReasonSecretNotFound, fmt.Errorf(
 		"unable to retrieve access token from [%s/%s] at key [%s] - %w",
 		from.Namespace,
 		from.Spec.AccessTokenSecret,
 		ocmv1alpha1.GitLabAccessTokenKey,
 		err,
-	)
+	))
 }
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	// AnnotationImport is the annotation which requests that the current state of an object in
+	// OpenShift Cluster Manager is imported into the spec of the object within the cluster.
+	AnnotationImport = "ocm.mobb.redhat.com/import"
+
+	// AnnotationAdopt is the annotation which allows an object to import, and then manage, an object in
+	// OpenShift Cluster Manager which is not marked as managed by this operator, such as a machine pool
+	// without the managed labels or any identity provider.
+	AnnotationAdopt = "ocm.mobb.redhat.com/adopt"
+
+	annotationImportEnabled = "true"
+	annotationAdoptEnabled  = "true"
+)
+
+var ErrImportNotAdopted = errors.New("object is not managed by this operator and may only be imported when adopted")
+
+// AdoptRequested determines if an object has explicitly requested to take over an object in OpenShift
+// Cluster Manager which is not marked as managed by this operator.
+func AdoptRequested(object client.Object) bool {
+	return object.GetAnnotations()[AnnotationAdopt] == annotationAdoptEnabled
+}
+
+// ImportRequested determines if an object has requested that its current state in OpenShift
+// Cluster Manager is imported into its spec.
+func ImportRequested(object client.Object) bool {
+	return object.GetAnnotations()[AnnotationImport] == annotationImportEnabled
+}
+
+// ImportPredicate returns the filter which triggers reconciliation when the import annotation is
+// added to an existing object.  Adding an annotation does not change the generation of an object, so
+// the event is otherwise filtered out by the workload predicates.
+func ImportPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !ImportRequested(e.ObjectOld) && ImportRequested(e.ObjectNew)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// Import writes the imported spec of an object back to the cluster and removes the import
+// annotation so that the import only happens once.
+func Import(ctx context.Context, r kubernetes.Client, original, imported client.Object) error {
+	annotations := imported.GetAnnotations()
+	delete(annotations, AnnotationImport)
+	imported.SetAnnotations(annotations)
+
+	if err := r.Patch(ctx, imported, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("unable to import spec - %w", err)
+	}
+
+	return nil
+}
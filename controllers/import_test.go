@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func testImportObject(annotations map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: annotations}}
+}
+
+func TestImportPredicate(t *testing.T) {
+	t.Parallel()
+
+	requested := map[string]string{AnnotationImport: "true"}
+
+	tests := []struct {
+		name string
+		old  map[string]string
+		new  map[string]string
+		want bool
+	}{
+		{
+			name: "ensure adding the import annotation triggers reconciliation",
+			old:  nil,
+			new:  requested,
+			want: true,
+		},
+		{
+			name: "ensure an existing import annotation does not trigger reconciliation",
+			old:  requested,
+			new:  requested,
+			want: false,
+		},
+		{
+			name: "ensure removing the import annotation does not trigger reconciliation",
+			old:  requested,
+			new:  nil,
+			want: false,
+		},
+		{
+			name: "ensure an import annotation which is not true does not trigger reconciliation",
+			old:  nil,
+			new:  map[string]string{AnnotationImport: "false"},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ImportPredicate().Update(event.UpdateEvent{
+				ObjectOld: testImportObject(tt.old),
+				ObjectNew: testImportObject(tt.new),
+			}); got != tt.want {
+				t.Errorf("ImportPredicate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdoptRequested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name:        "ensure the adopt annotation requests adoption",
+			annotations: map[string]string{AnnotationImport: "true", AnnotationAdopt: "true"},
+			want:        true,
+		},
+		{
+			name:        "ensure the import annotation alone does not request adoption",
+			annotations: map[string]string{AnnotationImport: "true"},
+			want:        false,
+		},
+		{
+			name:        "ensure an adopt annotation which is not true does not request adoption",
+			annotations: map[string]string{AnnotationAdopt: "false"},
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := AdoptRequested(testImportObject(tt.annotations)); got != tt.want {
+				t.Errorf("AdoptRequested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
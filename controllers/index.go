@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	// IndexClusterName is the field index of the name of the cluster which an object targets.
+	IndexClusterName = "spec.clusterName"
+
+	// IndexClusterReference is the field index of the name of the cluster reference which an object
+	// references in place of the name of its cluster.
+	IndexClusterReference = "spec.clusterReference"
+
+	// IndexClusterID is the field index of the id of the cluster which has been recorded in the status
+	// of an object.
+	IndexClusterID = "status.clusterID"
+
+	// IndexBindPasswordName is the field index of the name of the secret which contains the bind
+	// password of an identity provider.
+	IndexBindPasswordName = "spec.bindPassword.name"
+
+	// IndexCAName is the field index of the name of the secret or config map which contains the ca data
+	// of an identity provider.
+	IndexCAName = "spec.ca.name"
+
+	// IndexAccessTokenSecret is the field index of the name of the secret which contains the access
+	// token of an identity provider.
+	IndexAccessTokenSecret = "spec.accessTokenSecret"
+)
+
+// Indexer is a field index of a type of object, which extracts the value of the field from an object.
+type Indexer struct {
+	Field string
+	Value func(client.Object) string
+}
+
+// IndexBy returns an indexer of a field of a type of object.  Objects of other types are not indexed.
+func IndexBy[T client.Object](field string, value func(T) string) Indexer {
+	return Indexer{
+		Field: field,
+		Value: func(object client.Object) string {
+			typed, ok := object.(T)
+			if !ok {
+				return ""
+			}
+
+			return value(typed)
+		},
+	}
+}
+
+// SetupIndexes registers the field indexes of a type of object with a field indexer, so that the
+// objects which reference another object may be looked up from the cache by the name of the referenced
+// object, rather than listing every object and filtering them.  An object whose field is empty is not
+// indexed.
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer, object client.Object, indexers ...Indexer) error {
+	for i := range indexers {
+		index := indexers[i]
+
+		if err := indexer.IndexField(ctx, object, index.Field, func(object client.Object) []string {
+			if value := index.Value(object); value != "" {
+				return []string{value}
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("unable to index field [%s] - %w", index.Field, err)
+		}
+	}
+
+	return nil
+}
+
+// ListReferences returns the objects of a list type, in a namespace, whose indexed fields match the
+// name of a referenced object.  Each object is returned once, even when more than one of its fields
+// match.  An object is not returned when it does not reference the object by any of the fields, so an
+// empty list of fields returns no objects.
+func ListReferences(
+	ctx context.Context,
+	r kubernetes.Client,
+	list client.ObjectList,
+	namespace, name string,
+	fields ...string,
+) ([]client.Object, error) {
+	seen := map[client.ObjectKey]bool{}
+	references := []client.Object{}
+
+	for _, field := range fields {
+		objects, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return nil, ErrConvertClientObject
+		}
+
+		if err := r.List(ctx, objects, client.InNamespace(namespace), client.MatchingFields{field: name}); err != nil {
+			return nil, fmt.Errorf("unable to list objects by field [%s] - %w", field, err)
+		}
+
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract objects by field [%s] - %w", field, err)
+		}
+
+		for _, item := range items {
+			object, ok := item.(client.Object)
+			if !ok || seen[client.ObjectKeyFromObject(object)] {
+				continue
+			}
+
+			seen[client.ObjectKeyFromObject(object)] = true
+			references = append(references, object)
+		}
+	}
+
+	return references, nil
+}
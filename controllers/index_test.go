@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+// builderIndexer is a field indexer which registers the field indexes with a fake client builder.
+type builderIndexer struct {
+	builder *fake.ClientBuilder
+}
+
+func (indexer *builderIndexer) IndexField(_ context.Context, object client.Object, field string, extract client.IndexerFunc) error {
+	indexer.builder = indexer.builder.WithIndex(object, field, extract)
+
+	return nil
+}
+
+func newIndexedClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := ocmv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	indexer := &builderIndexer{builder: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...)}
+
+	if err := SetupIndexes(
+		context.Background(),
+		indexer,
+		&ocmv1alpha1.LDAPIdentityProvider{},
+		IndexBy(IndexClusterName, (*ocmv1alpha1.LDAPIdentityProvider).GetClusterName),
+		IndexBy(IndexBindPasswordName, func(ldap *ocmv1alpha1.LDAPIdentityProvider) string {
+			return ldap.Spec.BindPassword.Name
+		}),
+		IndexBy(IndexCAName, func(ldap *ocmv1alpha1.LDAPIdentityProvider) string {
+			return ldap.Spec.CA.Name
+		}),
+	); err != nil {
+		t.Fatalf("SetupIndexes() error = %v", err)
+	}
+
+	return indexer.builder.Build()
+}
+
+func newIndexedLDAP(namespace, name, cluster, bindPassword, ca string) *ocmv1alpha1.LDAPIdentityProvider {
+	ldap := &ocmv1alpha1.LDAPIdentityProvider{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	ldap.Spec.ClusterName = cluster
+	ldap.Spec.BindPassword = configv1.SecretNameReference{Name: bindPassword}
+	ldap.Spec.CA = configv1.ConfigMapNameReference{Name: ca}
+
+	return ldap
+}
+
+func TestIndexBy(t *testing.T) {
+	t.Parallel()
+
+	indexer := IndexBy(IndexClusterName, (*ocmv1alpha1.LDAPIdentityProvider).GetClusterName)
+
+	if got := indexer.Value(newIndexedLDAP("ocm", "ldap", "cluster", "", "")); got != "cluster" {
+		t.Errorf("IndexBy().Value() = %v, want %v", got, "cluster")
+	}
+
+	if got := indexer.Value(&ocmv1alpha1.GitLabIdentityProvider{}); got != "" {
+		t.Errorf("IndexBy().Value() = %v, want %v", got, "")
+	}
+}
+
+func TestListReferences(t *testing.T) {
+	t.Parallel()
+
+	r := newIndexedClient(t,
+		newIndexedLDAP("ocm", "bind", "cluster", "shared", "other"),
+		newIndexedLDAP("ocm", "both", "cluster", "shared", "shared"),
+		newIndexedLDAP("ocm", "ca", "other", "other", "shared"),
+		newIndexedLDAP("ocm", "unrelated", "cluster", "other", "other"),
+		newIndexedLDAP("other", "bind", "cluster", "shared", "shared"),
+	)
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   []string
+	}{
+		{
+			name:   "ensure objects which reference the name by a field are returned",
+			fields: []string{IndexBindPasswordName},
+			want:   []string{"bind", "both"},
+		},
+		{
+			name:   "ensure an object which references the name by many fields is returned once",
+			fields: []string{IndexBindPasswordName, IndexCAName},
+			want:   []string{"bind", "both", "ca"},
+		},
+		{
+			name:   "ensure no objects are returned without any fields",
+			fields: nil,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			objects, err := ListReferences(context.Background(), r, &ocmv1alpha1.LDAPIdentityProviderList{}, "ocm", "shared", tt.fields...)
+			if err != nil {
+				t.Fatalf("ListReferences() error = %v", err)
+			}
+
+			got := []string{}
+			for _, object := range objects {
+				got = append(got, object.GetName())
+			}
+
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
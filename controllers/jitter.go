@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRequeueJitter is the fraction of a requeue interval by which the requeue of an object is
+	// randomly shortened or lengthened, so that objects which were created at the same time, such as
+	// by a GitOps sync, do not remain synchronized and cause periodic spikes of requests to OpenShift
+	// Cluster Manager.
+	DefaultRequeueJitter = 0.1
+)
+
+var (
+	jitterMutex  sync.Mutex
+	jitterRandom = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+)
+
+// Jitter returns a requeue interval which is randomly shortened or lengthened by up to the default
+// requeue jitter.
+func Jitter(requeue time.Duration) time.Duration {
+	return jitter(requeue, DefaultRequeueJitter)
+}
+
+// jitter returns a requeue interval which is randomly shortened or lengthened by up to a fraction of
+// the interval.
+func jitter(requeue time.Duration, fraction float64) time.Duration {
+	if requeue <= 0 || fraction <= 0 {
+		return requeue
+	}
+
+	jitterMutex.Lock()
+	factor := jitterRandom.Float64()*2 - 1
+	jitterMutex.Unlock()
+
+	return requeue + time.Duration(factor*fraction*float64(requeue))
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_jitter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		requeue  time.Duration
+		fraction float64
+		wantMin  time.Duration
+		wantMax  time.Duration
+	}{
+		{
+			name:     "ensure a requeue is jittered within the fraction",
+			requeue:  time.Minute,
+			fraction: DefaultRequeueJitter,
+			wantMin:  54 * time.Second,
+			wantMax:  66 * time.Second,
+		},
+		{
+			name:     "ensure a requeue is not jittered without a fraction",
+			requeue:  time.Minute,
+			fraction: 0,
+			wantMin:  time.Minute,
+			wantMax:  time.Minute,
+		},
+		{
+			name:     "ensure an immediate requeue is not jittered",
+			requeue:  0,
+			fraction: DefaultRequeueJitter,
+			wantMin:  0,
+			wantMax:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 100; i++ {
+				if got := jitter(tt.requeue, tt.fraction); got < tt.wantMin || got > tt.wantMax {
+					t.Fatalf("jitter() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
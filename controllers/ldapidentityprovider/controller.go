@@ -21,31 +21,46 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/nukleros/operator-builder-tools/pkg/controller/predicates"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
 )
 
 // Controller reconciles a LDAPIdentityProvider object
 type Controller struct {
 	client.Client
 
-	Scheme     *runtime.Scheme
-	Connection *sdk.Connection
-	Recorder   record.EventRecorder
-	Interval   time.Duration
+	controllers.Dependencies
+
+	Scheme   *runtime.Scheme
+	Interval time.Duration
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
+
+	// BlockInsecure prevents identity providers which communicate over an insecure transport from
+	// being applied to OpenShift Cluster Manager.
+	BlockInsecure bool
 }
 
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=ldapidentityproviders,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=ldapidentityproviders/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=ldapidentityproviders/finalizers,verbs=update
 
+// RequiredAPIs returns the APIs of OpenShift Cluster Manager which the controller depends upon.  It
+// is used to satisfy the Compatible interface.
+func (r *Controller) RequiredAPIs() []ocm.API {
+	return []ocm.API{ocm.APIClustersMgmt, ocm.APIAccountsMgmt}
+}
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
@@ -60,19 +75,26 @@ func (r *Controller) ReconcileCreate(req controllers.Request) (ctrl.Result, erro
 	// type cast the request to a ldap identity provider request
 	request, ok := req.(*LDAPIdentityProviderRequest)
 	if !ok {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), ErrLDAPIdentityProviderRequestConvert
+		return controllers.RequeueAfter(r.requeue()), ErrLDAPIdentityProviderRequestConvert
 	}
 
 	// add the finalizer
 	if err := controllers.AddFinalizer(request.Context, r, request.Original); err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf("unable to register delete hooks - %w", err)
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to register delete hooks - %w", err)
 	}
 
 	// execute the phases
 	return request.execute([]Phase{
 		{Name: "begin", Function: r.Begin},
-		{Name: "getCurrentState", Function: r.GetCurrentState},
-		{Name: "applyOCM", Function: r.ApplyIdentityProvider},
+		{Name: "authorize", Function: r.Authorize},
+		{Name: "waitForSecrets", Function: r.WaitForSecrets},
+		{Name: "checkInputs", Function: r.CheckInputs},
+		{Name: "getCurrentState", Function: controllers.SkipUnchanged(r.GetCurrentState), Parallel: true},
+		{Name: "getDesiredSecrets", Function: controllers.SkipUnchanged(r.GetDesiredSecrets), Parallel: true},
+		{Name: "import", Function: controllers.SkipUnchanged(r.Import)},
+		{Name: "checkTransport", Function: r.CheckTransport},
+		{Name: "validateConnection", Function: controllers.SkipUnchanged(r.ValidateConnection)},
+		{Name: "applyOCM", Function: controllers.SkipUnchanged(r.ApplyIdentityProvider)},
 		{Name: "complete", Function: r.Complete},
 	}...)
 }
@@ -90,21 +112,76 @@ func (r *Controller) ReconcileDelete(req controllers.Request) (ctrl.Result, erro
 	// type cast the request to a ldap identity provider request
 	request, ok := req.(*LDAPIdentityProviderRequest)
 	if !ok {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), ErrLDAPIdentityProviderRequestConvert
+		return controllers.RequeueAfter(r.requeue()), ErrLDAPIdentityProviderRequestConvert
 	}
 
 	// execute the phases
 	return request.execute([]Phase{
 		{Name: "begin", Function: r.Begin},
+		{Name: "authorize", Function: r.Authorize},
 		{Name: "destroy", Function: r.Destroy},
 		{Name: "complete", Function: r.CompleteDestroy},
 	}...)
 }
 
+// requeue returns the interval after which a failed or incomplete reconciliation is retried.
+func (r *Controller) requeue() time.Duration {
+	if r.Requeue == 0 {
+		return defaultLDAPIdentityProviderRequeue
+	}
+
+	return r.Requeue
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicates.WorkloadPredicates()).
+	// index the objects which are referenced by each identity provider, so that the identity providers
+	// which reference a secret or config map, such as a shared ca bundle, are all updated when it changes
+	if err := controllers.SetupIndexes(
+		context.Background(),
+		mgr.GetFieldIndexer(),
+		&ocmv1alpha1.LDAPIdentityProvider{},
+		controllers.IndexBy(controllers.IndexClusterName, (*ocmv1alpha1.LDAPIdentityProvider).GetClusterName),
+		controllers.IndexBy(controllers.IndexClusterReference, controllers.ClusterReferenceOf[*ocmv1alpha1.LDAPIdentityProvider]),
+		controllers.IndexBy(controllers.IndexClusterID, controllers.ClusterIDOf[*ocmv1alpha1.LDAPIdentityProvider]),
+		controllers.IndexBy(controllers.IndexBindPasswordName, func(ldap *ocmv1alpha1.LDAPIdentityProvider) string {
+			return ldap.Spec.BindPassword.Name
+		}),
+		controllers.IndexBy(controllers.IndexCAName, func(ldap *ocmv1alpha1.LDAPIdentityProvider) string {
+			return ldap.Spec.CA.Name
+		}),
+	); err != nil {
+		return fmt.Errorf("unable to index ldap identity providers - %w", err)
+	}
+
+	managedBy := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options()).
+		WithEventFilter(predicate.Or(
+			controllers.WorkloadPredicates(),
+			controllers.ImportPredicate(),
+			controllers.BroadcastPredicate(),
+			controllers.ClusterReferencePredicate(),
+			controllers.SecretPredicate(),
+			controllers.ConfigMapPredicate(),
+		)).
 		For(&ocmv1alpha1.LDAPIdentityProvider{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &ocmv1alpha1.ClusterReference{}}, controllers.EnqueueClusterReferences(r, &ocmv1alpha1.LDAPIdentityProviderList{})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, controllers.EnqueueSecretReferences(
+			r,
+			&ocmv1alpha1.LDAPIdentityProviderList{},
+			controllers.IndexBindPasswordName,
+			controllers.IndexCAName,
+		)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, controllers.EnqueueConfigMapReferences(
+			r,
+			&ocmv1alpha1.LDAPIdentityProviderList{},
+			r.Recorder,
+			controllers.IndexCAName,
+		))
+
+	if r.Broadcaster != nil {
+		managedBy = managedBy.Watches(r.Broadcaster.Subscribe(), controllers.EnqueueAll(r, &ocmv1alpha1.LDAPIdentityProviderList{}))
+	}
+
+	return managedBy.Complete(r)
 }
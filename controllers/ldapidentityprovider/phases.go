@@ -1,15 +1,19 @@
 package ldapidentityprovider
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
 	"github.com/rh-mobb/ocm-operator/pkg/conditions"
 	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/identityprovider"
 	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -18,17 +22,150 @@ const (
 )
 
 // Phase defines an individual phase in the controller reconciliation process.
-type Phase struct {
-	Name     string
-	Function func(*LDAPIdentityProviderRequest) (ctrl.Result, error)
-}
+type Phase = controllers.RequestPhase[*LDAPIdentityProviderRequest]
 
 // Begin begins the reconciliation state once we get the object (the desired state) from the cluster.
 // It is mainly used to set conditions of the controller and to let anyone who is viewiing the
 // custom resource know that we are currently reconciling.
 func (r *Controller) Begin(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
-	if err := request.updateCondition(conditions.Reconciling(request.Trigger)); err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf("error updating reconciling condition - %w", err)
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Authorize ensures that the namespace of the identity provider has been granted management of its cluster
+// by a cluster management binding.  A forbidden identity provider is reported with a Forbidden condition and
+// a warning event, and the request is retried at the regular interval.  A forbidden identity provider which is
+// deleted is released without deleting anything from OpenShift Cluster Manager.
+func (r *Controller) Authorize(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Desired.Spec.ClusterName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Desired.Spec.ClusterName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	// release the deleted object without touching openshift cluster manager, as the namespace is
+	// not allowed to manage the cluster
+	if request.Trigger == triggers.Delete {
+		if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.Interval), nil
+}
+
+// WaitForSecrets waits until each secret referenced by the LDAPIdentityProvider exists and contains the required
+// keys.  This allows the secrets to be created asynchronously, for example by an ExternalSecret or a
+// SealedSecret, without failing the reconciliation.  A waiting identity provider is reported with a
+// SecretsAvailable condition and a Waiting condition, and is reconciled again once a referenced secret
+// changes.
+func (r *Controller) WaitForSecrets(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	name, missing, err := controllers.MissingSecret(request.Context, r, request.Original)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if name != "" {
+		condition := conditions.WaitingForSecret(request.Original.Namespace, name, missing)
+
+		if !conditions.IsSet(condition, request.Original) {
+			request.Log.Info(condition.Message, request.logValues()...)
+		}
+
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating secrets available condition - %w", err)
+		}
+
+		return controllers.NoRequeue(), controllers.WaitFor(
+			fmt.Sprintf("secret [%s/%s]", request.Original.Namespace, name),
+			controllers.WaitReasonSecretUnavailable,
+			r.requeue(),
+		)
+	}
+
+	if conditions.IsWaitingForSecret(request.Original) {
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.SecretsAvailable()); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating secrets available condition - %w", err)
+		}
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// CheckInputs determines if the inputs of the LDAP identity provider, including the versions of the secrets
+// and config maps from which the bind password and ca data are obtained, are unchanged since they were
+// last applied.  The phases which read the state of the identity provider in OCM are skipped while they
+// are unchanged, until the drift interval has elapsed since they were last applied.
+func (r *Controller) CheckInputs(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	desired := request.Desired
+
+	// record the versions of the secrets and config maps so that rotated data is detected
+	bindPasswordVersion, err := kubernetes.GetSecretVersion(request.Context, r, desired.Spec.BindPassword.Name, desired.Namespace)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	var caVersion string
+	if desired.Spec.CA.Name != "" {
+		if desired.GetCAKind() == ocmv1alpha1.LDAPCAKindSecret {
+			caVersion, err = kubernetes.GetSecretVersion(request.Context, r, desired.Spec.CA.Name, desired.Namespace)
+		} else {
+			caVersion, err = kubernetes.GetConfigMapVersion(request.Context, r, desired.Spec.CA.Name, desired.Namespace)
+		}
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+	}
+
+	request.DesiredBindPasswordVersion = bindPasswordVersion
+	request.DesiredCAVersion = caVersion
+
+	inputs, err := request.fingerprint()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	request.Inputs = inputs
+	request.Unchanged = controllers.Unchanged(request.Original, inputs, r.DriftInterval, time.Now())
+	if request.Unchanged {
+		request.Log.V(controllers.LogLevelDebug).Info("ldap identity provider unchanged since last applied", request.logValues()...)
 	}
 
 	return controllers.NoRequeue(), nil
@@ -38,23 +175,27 @@ func (r *Controller) Begin(request *LDAPIdentityProviderRequest) (ctrl.Result, e
 // is stored in OpenShift Cluster Manager.  It will be compared against the desired state which exists
 // within the OpenShift cluster in which this controller is reconciling against.
 func (r *Controller) GetCurrentState(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	// retrieve the cluster on every request, rather than only until its id has been recorded, so that
+	// the organization of the cluster is checked on every reconcile
+	clusterClient := ocm.NewClusterClient(request.Environment.Connection, request.Desired.Spec.ClusterName).
+		WithOrganizationGuard(request.Environment.Organizations).
+		WithContext(request.Context)
+
+	cluster, err := clusterClient.Get()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve cluster from ocm [name=%s] - %w",
+			request.Desired.Spec.ClusterName,
+			err,
+		)
+	}
+
 	// retrieve the cluster id
 	clusterID := request.Original.Status.ClusterID
 	if clusterID == "" {
-		// retrieve the cluster id
-		clusterClient := ocm.NewClusterClient(request.Reconciler.Connection, request.Desired.Spec.ClusterName)
-		cluster, err := clusterClient.Get()
-		if err != nil {
-			return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf(
-				"unable to retrieve cluster from ocm [name=%s] - %w",
-				request.Desired.Spec.ClusterName,
-				err,
-			)
-		}
-
 		// if the cluster id is missing return an error
 		if cluster.ID() == "" {
-			return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf(
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 				"missing cluster id in response - %w",
 				ErrMissingClusterID,
 			)
@@ -64,11 +205,12 @@ func (r *Controller) GetCurrentState(request *LDAPIdentityProviderRequest) (ctrl
 	}
 
 	// get the generic identity provider object from ocm
-	request.OCMClient = ocm.NewIdentityProviderClient(request.Reconciler.Connection, request.Desired.Spec.DisplayName, clusterID)
+	request.OCMClient = ocm.NewIdentityProviderClient(request.Environment.Connection, request.Desired.Spec.DisplayName, clusterID).
+		WithContext(request.Context)
 
 	idp, err := request.OCMClient.Get()
 	if err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf(
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"unable to retrieve identity provider from ocm - %w",
 			err,
 		)
@@ -85,7 +227,7 @@ func (r *Controller) GetCurrentState(request *LDAPIdentityProviderRequest) (ctrl
 	request.Original.Status.ProviderID = idp.ID()
 
 	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf(
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"unable to update status.providerID=%s - %w",
 			idp.ID(),
 			err,
@@ -99,18 +241,212 @@ func (r *Controller) GetCurrentState(request *LDAPIdentityProviderRequest) (ctrl
 	request.Current.Spec.BindPassword.Name = request.Desired.Spec.BindPassword.Name
 	request.Current.Spec.CA.Name = request.Desired.Spec.CA.Name
 	request.Current.Spec.MappingMethod = string(idp.MappingMethod())
+	request.Current.Spec.Challenge, request.Current.Spec.Login = ocm.LoginOptions(idp)
+	request.Current.Spec.ValidateConnection = request.Desired.Spec.ValidateConnection
+	request.Current.Spec.BindPasswordKey = request.Desired.Spec.BindPasswordKey
+	request.Current.Spec.CAKind = request.Desired.Spec.CAKind
+	request.Current.Spec.CAKey = request.Desired.Spec.CAKey
 	request.Current.CopyFrom(idp.LDAP())
 
 	return controllers.NoRequeue(), nil
 }
 
+// GetDesiredSecrets gets the bind password and CA certificate data from the secrets and config maps
+// which are referenced by the desired state.  It only reads from the desired state so that it may run
+// in parallel with retrieving the current state from OpenShift Cluster Manager.
+func (r *Controller) GetDesiredSecrets(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	desired := request.Desired
+
+	// get the bind password data from the cluster
+	bindPassword, err := kubernetes.GetSecretData(
+		request.Context,
+		r,
+		desired.Spec.BindPassword.Name,
+		desired.Namespace,
+		desired.GetBindPasswordKey(),
+	)
+
+	// remove trailing newlines which are commonly added when a secret is created from a file
+	bindPassword = strings.TrimRight(bindPassword, "\r\n")
+	if bindPassword == "" {
+		if err != nil {
+			request.Log.Error(err, "error retrieving bind password", request.logValues()...)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), bindPasswordError(desired)
+	}
+
+	// get the ca config data from the cluster
+	var ca string
+	if desired.Spec.CA.Name != "" {
+		if desired.GetCAKind() == ocmv1alpha1.LDAPCAKindSecret {
+			ca, err = kubernetes.GetSecretData(request.Context, r, desired.Spec.CA.Name, desired.Namespace, desired.GetCAKey())
+		} else {
+			ca, err = kubernetes.GetConfigMapData(request.Context, r, desired.Spec.CA.Name, desired.Namespace, desired.GetCAKey())
+		}
+
+		if ca == "" {
+			if err != nil {
+				request.Log.Error(err, "error retrieving ca data", request.logValues()...)
+			}
+
+			return controllers.RequeueAfter(r.requeue()), caCertError(desired)
+		}
+	}
+
+	request.DesiredBindPassword = bindPassword
+	request.DesiredCA = ca
+
+	return controllers.NoRequeue(), nil
+}
+
+// Import imports the current state of the LDAP identity provider from OpenShift Cluster Manager into the
+// spec when the import annotation is set, and removes the annotation so that the import only happens
+// once.  This allows identity providers which were created outside of this controller to be onboarded
+// when they are also adopted.
+func (r *Controller) Import(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	if !controllers.ImportRequested(request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	// identity providers carry no marker that they are managed by this operator, so one which exists
+	// may only be imported when it is explicitly adopted
+	if request.Current != nil && !controllers.AdoptRequested(request.Original) {
+		return controllers.RequeueAfter(r.requeue()), conditions.WithReason(
+			conditions.ReasonValidationRejected,
+			fmt.Errorf(
+				"ldap identity provider [%s] requires the [%s=true] annotation - %w",
+				request.Desired.Spec.DisplayName,
+				controllers.AnnotationAdopt,
+				controllers.ErrImportNotAdopted,
+			),
+		)
+	}
+
+	imported := request.Original.DeepCopy()
+	if request.Current != nil {
+		imported.ImportFrom(request.Current)
+	}
+
+	if err := controllers.Import(request.Context, r, request.Original, imported); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	request.Original = imported
+
+	if request.Current == nil {
+		request.Log.Info("ldap identity provider not found in openshift cluster manager; skipping import", request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, "ImportSkipped", "ldap identity provider not found in openshift cluster manager")
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// update the desired state to reflect the imported spec
+	request.Desired.ImportFrom(request.Current)
+
+	// create an event indicating that the ldap identity provider has been imported
+	events.RegisterAction(events.Imported, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+
+	return controllers.NoRequeue(), nil
+}
+
+// CheckTransport reports whether the LDAP identity provider communicates over an insecure transport.  An
+// insecure identity provider is allowed, but is reported with a persistent InsecureTransport condition and a
+// warning event.  When the operator blocks insecure identity providers, the identity provider is not applied
+// and the request is retried at the regular interval.
+func (r *Controller) CheckTransport(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	if !request.Desired.Spec.Insecure {
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.SecureTransport()); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating transport condition - %w", err)
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.InsecureTransport(r.BlockInsecure)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating transport condition - %w", err)
+	}
+
+	if r.BlockInsecure {
+		return controllers.RequeueAfter(r.Interval), nil
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// ValidateConnection validates that a connection can be established to the LDAP server from the
+// operator before the identity provider is applied to OCM.  It only runs when requested via the
+// spec.validateConnection field.
+func (r *Controller) ValidateConnection(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
+	if !request.Desired.Spec.ValidateConnection {
+		return controllers.NoRequeue(), nil
+	}
+
+	// skip the validation if nothing has changed since the connection was last validated and applied
+	if controllers.Applied(request.Original, request.Inputs) {
+		return controllers.NoRequeue(), nil
+	}
+
+	server := &identityprovider.LDAP{
+		URL:          request.Desired.Spec.URL,
+		BindDN:       request.Desired.Spec.BindDN,
+		BindPassword: request.DesiredBindPassword,
+		CA:           request.DesiredCA,
+		Insecure:     request.Desired.Spec.Insecure,
+	}
+
+	if err := server.Validate(); err != nil {
+		if updateErr := conditions.Update(
+			request.Context,
+			request.Reconciler,
+			request.Original,
+			conditions.LDAPConnectionFailed(ldapConnectionFailedReason(err), err),
+		); updateErr != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+				"error updating ldap connection condition - %w",
+				updateErr,
+			)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to validate connection to ldap server [%s] - %w",
+			request.Desired.Spec.URL,
+			err,
+		)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.LDAPConnectionValidated()); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"error updating ldap connection condition - %w",
+			err,
+		)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
 // ApplyIdentityProvider applies the desired state of the LDAP identity provider to OCM.
 func (r *Controller) ApplyIdentityProvider(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
-	// return if it is already in its desired state
+	applied := request.Inputs
+
+	// return if it is already in its desired state, unless the bind password or ca data have been
+	// rotated, as they are unable to be compared against the identity provider in ocm
 	if request.desired() {
-		request.Log.V(controllers.LogLevelDebug).Info("ldap identity provider already in desired state", request.logValues()...)
+		if !request.rotated(applied) {
+			request.Log.V(controllers.LogLevelDebug).Info("ldap identity provider already in desired state", request.logValues()...)
+			request.Fingerprint = applied
 
-		return controllers.NoRequeue(), nil
+			return controllers.NoRequeue(), nil
+		}
+
+		request.Log.Info("ldap bind password or ca data changed since last applied", request.logValues()...)
 	}
 
 	builder := request.Desired.Builder(request.DesiredCA, request.DesiredBindPassword)
@@ -119,8 +455,10 @@ func (r *Controller) ApplyIdentityProvider(request *LDAPIdentityProviderRequest)
 	if request.Current == nil {
 		request.Log.Info("creating ldap identity provider", request.logValues()...)
 		_, err := request.OCMClient.Create(builder)
+		request.recordOperation(ocmv1alpha1.OCMOperationCreate, request.OCMClient.LastStatus(), err)
+
 		if err != nil {
-			return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf(
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 				"unable to create ldap identity provider in ocm - %w",
 				err,
 			)
@@ -128,6 +466,7 @@ func (r *Controller) ApplyIdentityProvider(request *LDAPIdentityProviderRequest)
 
 		// create an event indicating that the ldap identity provider has been created
 		events.RegisterAction(events.Created, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+		request.Fingerprint = applied
 
 		return controllers.NoRequeue(), nil
 	}
@@ -135,8 +474,10 @@ func (r *Controller) ApplyIdentityProvider(request *LDAPIdentityProviderRequest)
 	// update the identity provider if it does exist
 	request.Log.Info("updating ldap identity provider", request.logValues()...)
 	_, err := request.OCMClient.Update(builder)
+	request.recordOperation(ocmv1alpha1.OCMOperationUpdate, request.OCMClient.LastStatus(), err)
+
 	if err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf(
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"unable to update ldap identity provider in ocm - %w",
 			err,
 		)
@@ -144,6 +485,7 @@ func (r *Controller) ApplyIdentityProvider(request *LDAPIdentityProviderRequest)
 
 	// create an event indicating that the ldap identity provider has been updated
 	events.RegisterAction(events.Updated, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	request.Fingerprint = applied
 
 	return controllers.NoRequeue(), nil
 }
@@ -155,30 +497,102 @@ func (r *Controller) Destroy(request *LDAPIdentityProviderRequest) (ctrl.Result,
 		return controllers.NoRequeue(), nil
 	}
 
-	ocmClient := ocm.NewIdentityProviderClient(request.Reconciler.Connection, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	// leave the identity provider in openshift cluster manager while its deletion is prevented,
+	// checking again after the requeue interval so that the deletion continues once the annotation is
+	// removed
+	if controllers.PreventDestroyRequested(request.Original) {
+		return controllers.PreventDestroy(&r.Dependencies, request.execution(), r.requeue())
+	}
+
+	ocmClient := ocm.NewIdentityProviderClient(request.Environment.Connection, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID).
+		WithContext(request.Context)
 
-	// delete the object
-	if err := ocmClient.Delete(request.Original.Status.ProviderID); err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), nil
+	// ensure that the provider id in the status refers to the identity provider which this resource manages
+	// before deleting it, so that a polluted status never causes another identity provider to be deleted
+	deletable, err := r.verifyProvider(request, ocmClient)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
 	}
 
-	// create an event indicating that the ldap identity provider has been deleted
-	events.RegisterAction(events.Deleted, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	if deletable {
+		// delete the object
+		err := ocmClient.Delete(request.Original.Status.ProviderID)
+		request.recordOperation(ocmv1alpha1.OCMOperationDelete, ocmClient.LastStatus(), err)
+
+		if err != nil {
+			return controllers.RequeueAfter(r.requeue()), nil
+		}
+
+		// create an event indicating that the ldap identity provider has been deleted
+		events.RegisterAction(events.Deleted, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	}
 
 	// set the deleted condition
-	if err := request.updateCondition(conditions.MachinePoolDeleted()); err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf("error updating reconciling condition - %w", err)
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.MachinePoolDeleted()); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
 	}
 
 	return controllers.NoRequeue(), nil
 }
 
+// verifyProvider re-fetches the identity provider in the status of the resource from OpenShift Cluster Manager
+// and determines if it may be deleted.  It may not be deleted if it no longer exists, or if the cluster of the
+// resource no longer exists, as there is nothing left to delete.  An error is returned, along with a warning
+// event, if the identity provider does not match the name, type and cluster of the resource.
+func (r *Controller) verifyProvider(request *LDAPIdentityProviderRequest, ocmClient *ocm.IdentityProviderClient) (bool, error) {
+	if request.Original.Status.ProviderID == "" || request.Original.Status.ClusterID == "" {
+		return false, nil
+	}
+
+	// retrieve the cluster by its name to ensure that the cluster id in the status is its cluster
+	cluster, err := ocm.NewClusterClient(request.Environment.Connection, request.Desired.Spec.ClusterName).
+		WithOrganizationGuard(request.Environment.Organizations).
+		WithContext(request.Context).
+		Get()
+	if err != nil {
+		if errors.Is(err, ocm.ErrClusterNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to retrieve cluster from ocm [name=%s] - %w", request.Desired.Spec.ClusterName, err)
+	}
+
+	idp, err := ocmClient.GetByID(request.Original.Status.ProviderID)
+	if err != nil {
+		return false, fmt.Errorf(
+			"unable to retrieve identity provider [%s] from ocm - %w",
+			request.Original.Status.ProviderID,
+			err,
+		)
+	}
+
+	if idp == nil {
+		return false, nil
+	}
+
+	if err := verifyProvider(idp, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID, cluster.ID()); err != nil {
+		events.RegisterWarning(request.Original, r.Recorder, "ProviderMismatch", err.Error())
+
+		return false, conditions.WithReason(conditions.ReasonDriftDetected, err)
+	}
+
+	return true, nil
+}
+
 // Complete will perform all actions required to successful complete a reconciliation request.  It will
 // requeue after the interval value requested by the controller configuration to ensure that the
 // object remains in its desired state at a specific interval.
 func (r *Controller) Complete(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
-	if err := request.updateCondition(conditions.Reconciled(request.Trigger)); err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf("error updating reconciled condition - %w", err)
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	if err := request.updateStatusAttributes(); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error recording effective attributes - %w", err)
+	}
+
+	if err := controllers.RecordApplied(request.Context, r, request.Original, request.Fingerprint); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error recording applied inputs - %w", err)
 	}
 
 	request.Log.Info("completed ldap identity provider reconciliation", request.logValues()...)
@@ -190,7 +604,7 @@ func (r *Controller) Complete(request *LDAPIdentityProviderRequest) (ctrl.Result
 // CompleteDestroy will perform all actions required to successful complete a reconciliation request.
 func (r *Controller) CompleteDestroy(request *LDAPIdentityProviderRequest) (ctrl.Result, error) {
 	if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
-		return controllers.RequeueAfter(defaultLDAPIdentityProviderRequeue), fmt.Errorf("unable to remove finalizers - %w", err)
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
 	}
 
 	request.Log.Info("completed ldap identity provider deletion", request.logValues()...)
@@ -4,17 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
 	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/diff"
+	"github.com/rh-mobb/ocm-operator/pkg/identityprovider"
 	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
 	"github.com/rh-mobb/ocm-operator/pkg/triggers"
@@ -25,6 +28,7 @@ var (
 	ErrMissingBindPassword                = errors.New("unable to locate ldap bind password data")
 	ErrMissingCA                          = errors.New("ca specified but unable to locate ca data")
 	ErrLDAPIdentityProviderRequestConvert = errors.New("unable to convert generic request to ldap identity provider request")
+	ErrProviderMismatch                   = errors.New("identity provider in status does not belong to this resource")
 )
 
 // LDAPIdentityProviderRequest is an object that is unique to each reconciliation
@@ -38,6 +42,7 @@ type LDAPIdentityProviderRequest struct {
 	Log               logr.Logger
 	Trigger           triggers.Trigger
 	Reconciler        *Controller
+	Environment       *ocm.Environment
 	OCMClient         *ocm.IdentityProviderClient
 
 	// data obtained during request reconciliation
@@ -49,6 +54,20 @@ type LDAPIdentityProviderRequest struct {
 	CurrentCA           string
 	DesiredBindPassword string
 	DesiredCA           string
+
+	// versions of the secrets and config maps from which the desired bind password and ca data
+	// were obtained during request reconciliation
+	DesiredBindPasswordVersion string
+	DesiredCAVersion           string
+
+	// Inputs is the fingerprint of the inputs of the request, and Unchanged determines if they are
+	// unchanged since they were last applied, in which case the state of the identity provider in OCM
+	// is not read again until the drift interval has elapsed.
+	Inputs    string
+	Unchanged bool
+
+	// Fingerprint is the fingerprint of the inputs which have been applied during this request.
+	Fingerprint string
 }
 
 // This controller must have the ability to pull secrets and configmaps which store the
@@ -70,42 +89,28 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 		return &LDAPIdentityProviderRequest{}, nil
 	}
 
-	// get the bind password data from the cluster
-	bindPassword, err := kubernetes.GetSecretData(ctx, r, original.Spec.BindPassword.Name, req.Namespace, ocmv1alpha1.LDAPBindPasswordKey)
-	if bindPassword == "" {
-		if err != nil {
-			log.Log.Error(err, "error retrieving bind password")
-		}
-
-		return &LDAPIdentityProviderRequest{}, bindPasswordError(original)
-	}
-
-	// get the ca config data from the cluster
-	var ca string
-	if original.Spec.CA.Name != "" {
-		ca, err = kubernetes.GetConfigMapData(ctx, r, original.Spec.CA.Name, req.Namespace, ocmv1alpha1.LDAPCAKey)
-		if ca == "" {
-			if err != nil {
-				log.Log.Error(err, "error retrieving ca data")
-			}
-
-			return &LDAPIdentityProviderRequest{}, caCertError(original)
-		}
+	// resolve the cluster of an object which references a cluster reference rather than naming
+	// its cluster
+	if err := controllers.ResolveClusterReference(ctx, r, original, r.requeue()); err != nil {
+		return &LDAPIdentityProviderRequest{}, conditions.RecordWaiting(ctx, r, original, err)
 	}
 
 	// create the desired state of the request based on the inputs
 	desired := original.DeepCopy()
-	if desired.Spec.DisplayName == "" {
-		desired.Spec.DisplayName = desired.Name
-	}
+	desired.Spec.DisplayName = desired.GetDisplayName()
 
 	// ensure the attributes are defaulted
-	desired.Spec.Attributes = ocmv1alpha1.LDAPAttributesToOpenShift(
-		desired.Spec.Attributes.ID,
-		desired.Spec.Attributes.Name,
-		desired.Spec.Attributes.Email,
-		desired.Spec.Attributes.PreferredUsername,
+	desired.Spec.Attributes = desired.EffectiveAttributes()
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection, Organizations: r.Organizations},
+		original,
 	)
+	if err != nil {
+		return &LDAPIdentityProviderRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
+	}
 
 	return &LDAPIdentityProviderRequest{
 		Original:          original,
@@ -115,10 +120,7 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 		Log:               log.Log,
 		Trigger:           triggers.GetTrigger(original),
 		Reconciler:        r,
-
-		// data obtained from cluster
-		DesiredBindPassword: bindPassword,
-		DesiredCA:           ca,
+		Environment:       environment,
 	}, nil
 }
 
@@ -126,37 +128,51 @@ func (request *LDAPIdentityProviderRequest) GetObject() controllers.Workload {
 	return request.Original
 }
 
-// execute executes a variety of different phases for the request.
-//
-//nolint:wrapcheck
-func (request *LDAPIdentityProviderRequest) execute(phases ...Phase) (ctrl.Result, error) {
-	for execute := range phases {
-		// run each phase function and return if we receive any errors
-		result, err := phases[execute].Function(request)
-		if err != nil || result.Requeue {
-			return result, controllers.ReconcileError(
-				request.ControllerRequest,
-				fmt.Sprintf("%s phase reconciliation error", phases[execute].Name),
-				err,
-			)
-		}
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *LDAPIdentityProviderRequest) bindContext(ctx context.Context) {
+	request.Context = ctx
+
+	// the client which is shared between phases is bound to the context of each phase
+	if request.OCMClient != nil {
+		request.OCMClient.WithContext(ctx)
 	}
+}
 
-	return controllers.NoRequeue(), nil
+// execution returns the execution of the phases of the request.
+func (request *LDAPIdentityProviderRequest) execution() *controllers.Execution {
+	return &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
+	}
 }
 
-// TODO: centralize this function into controllers or conditions package.
-func (request *LDAPIdentityProviderRequest) updateCondition(condition *metav1.Condition) error {
-	if err := conditions.Update(
+// execute executes a variety of different phases for the request.
+func (request *LDAPIdentityProviderRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, request.execution(), request, phases...)
+}
+
+// recordOperation records an operation which was sent to OCM in the operation history of the object.
+// Errors recording the operation are logged rather than returned so that the result of the operation
+// is not masked.
+func (request *LDAPIdentityProviderRequest) recordOperation(operation string, status int, err error) {
+	if recordErr := controllers.RecordOperation(
 		request.Context,
 		request.Reconciler,
 		request.Original,
-		condition,
-	); err != nil {
-		return fmt.Errorf("unable to update condition - %w", err)
+		operation,
+		status,
+		err,
+	); recordErr != nil {
+		request.Log.V(controllers.LogLevelDebug).Info(
+			fmt.Sprintf("unable to record %s operation - %s", strings.ToLower(operation), recordErr),
+			request.logValues()...,
+		)
 	}
-
-	return nil
 }
 
 // logValues produces a consistent set of log values for this request.
@@ -189,28 +205,136 @@ func (request *LDAPIdentityProviderRequest) desired() bool {
 	// 	return false
 	// }
 
-	return reflect.DeepEqual(
-		request.Desired.Spec,
-		request.Current.Spec,
-	)
+	desired, current := request.Desired.Spec, request.Current.Spec
+
+	// openshift cluster manager defaults each of the attributes which are not requested
+	attributes := request.Desired.EffectiveAttributes()
+
+	return diff.New().
+		Field("clusterName", diff.Values(desired.ClusterName, current.ClusterName)).
+		Field("displayName", diff.Values(desired.DisplayName, current.DisplayName)).
+		Field("mappingMethod", diff.Fold(desired.MappingMethod, current.MappingMethod)).
+		Field("challenge", diff.Requested(desired.Challenge, current.Challenge)).
+		Field("login", diff.Requested(desired.Login, current.Login)).
+		Field("url", diff.URLs(desired.URL, current.URL)).
+		Field("bindDN", diff.Values(desired.BindDN, current.BindDN)).
+		Field("bindPassword", diff.Values(desired.BindPassword, current.BindPassword)).
+		Field("bindPasswordKey", diff.Values(desired.BindPasswordKey, current.BindPasswordKey)).
+		Field("insecure", diff.Values(desired.Insecure, current.Insecure)).
+		Field("ca", diff.Values(desired.CA, current.CA)).
+		Field("caKind", diff.Values(desired.CAKind, current.CAKind)).
+		Field("caKey", diff.Values(desired.CAKey, current.CAKey)).
+		Field("validateConnection", diff.Values(desired.ValidateConnection, current.ValidateConnection)).
+		Field("attributes.id", diff.Lists(attributes.ID, current.Attributes.ID)).
+		Field("attributes.name", diff.Lists(attributes.Name, current.Attributes.Name)).
+		Field("attributes.email", diff.Lists(attributes.Email, current.Attributes.Email)).
+		Field("attributes.preferredUsername", diff.Lists(attributes.PreferredUsername, current.Attributes.PreferredUsername)).
+		Equal()
+}
+
+// fingerprint returns the fingerprint of the inputs of the request.  The versions of the bind password
+// and ca data are included as the data is not able to be retrieved from OCM and compared against its
+// desired value.  The versions are used rather than the data itself, so that the fingerprint reveals
+// nothing about the bind password.
+func (request *LDAPIdentityProviderRequest) fingerprint() (string, error) {
+	return controllers.Fingerprint(request.Desired.Spec, request.DesiredBindPasswordVersion, request.DesiredCAVersion)
+}
+
+// InputsUnchanged determines if the inputs of the request are unchanged since they were last applied.  It
+// is used to satisfy the controllers.UnchangedRequest interface.
+func (request *LDAPIdentityProviderRequest) InputsUnchanged() bool {
+	return request.Unchanged
+}
+
+// rotated determines if the bind password or ca data have changed since the identity provider was
+// last applied.  It must only be called when the identity provider is otherwise in its desired state.
+func (request *LDAPIdentityProviderRequest) rotated(fingerprint string) bool {
+	return request.Original.GetInputHash() != "" && request.Original.GetInputHash() != fingerprint
+}
+
+// updateStatusAttributes records the effective attributes of the identity provider in the status, so
+// that the attributes which were defaulted because they were not requested are visible.
+func (request *LDAPIdentityProviderRequest) updateStatusAttributes() error {
+	attributes := request.Desired.EffectiveAttributes()
+
+	// return if the status is already up to date
+	if equality.Semantic.DeepEqual(request.Original.Status.Attributes, &attributes) {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.Attributes = &attributes
+
+	// store the effective attributes in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf("unable to update status.attributes - %w", err)
+	}
+
+	return nil
+}
+
+// verifyProvider ensures that an identity provider, which was retrieved from OCM by the provider id
+// in the status of the resource, is the ldap identity provider with the desired name on the cluster of
+// the resource.  The status of a resource may be polluted when it is restored from a backup or copied
+// between clusters, in which case its provider id refers to an identity provider which it does not
+// manage.
+func verifyProvider(idp *clustersmgmtv1.IdentityProvider, name, clusterID, expectedClusterID string) error {
+	if clusterID != expectedClusterID {
+		return fmt.Errorf(
+			"identity provider [%s] belongs to cluster [%s] but expected cluster [%s] - %w",
+			idp.ID(),
+			clusterID,
+			expectedClusterID,
+			ErrProviderMismatch,
+		)
+	}
+
+	if idp.Name() != name || idp.Type() != clustersmgmtv1.IdentityProviderTypeLDAP {
+		return fmt.Errorf(
+			"identity provider [%s] is of type [%s] with name [%s] but expected type [%s] with name [%s] - %w",
+			idp.ID(),
+			idp.Type(),
+			idp.Name(),
+			clustersmgmtv1.IdentityProviderTypeLDAP,
+			name,
+			ErrProviderMismatch,
+		)
+	}
+
+	return nil
 }
 
 func bindPasswordError(from *ocmv1alpha1.LDAPIdentityProvider) error {
-	return fmt.Errorf(
+	return conditions.WithReason(conditions.ReasonSecretNotFound, fmt.Errorf(
 		"unable to retrieve bind password from [%s/%s] at key [%s] - %w",
 		from.Namespace,
 		from.Spec.BindPassword.Name,
-		ocmv1alpha1.LDAPBindPasswordKey,
+		from.GetBindPasswordKey(),
 		ErrMissingBindPassword,
-	)
+	))
 }
 
 func caCertError(from *ocmv1alpha1.LDAPIdentityProvider) error {
-	return fmt.Errorf(
-		"unable to retrieve ca cert from [%s/%s] at key [%s] - %w",
+	return conditions.WithReason(conditions.ReasonSecretNotFound, fmt.Errorf(
+		"unable to retrieve ca cert from %s [%s/%s] at key [%s] - %w",
+		strings.ToLower(from.GetCAKind()),
 		from.Namespace,
 		from.Spec.CA.Name,
-		ocmv1alpha1.LDAPCAKey,
+		from.GetCAKey(),
 		ErrMissingCA,
-	)
+	))
+}
+
+// ldapConnectionFailedReason returns the reason for a failed ldap connection condition based
+// upon the stage at which the connection failed.
+func ldapConnectionFailedReason(err error) string {
+	switch {
+	case errors.Is(err, identityprovider.ErrLDAPBind):
+		return "BindFailed"
+	case errors.Is(err, identityprovider.ErrLDAPTLS), errors.Is(err, identityprovider.ErrLDAPInvalidCA):
+		return "TLSFailed"
+	default:
+		return "ConnectionFailed"
+	}
 }
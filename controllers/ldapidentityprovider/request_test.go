@@ -0,0 +1,151 @@
+package ldapidentityprovider
+
+import (
+	"errors"
+	"testing"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func testProvider(t *testing.T, name string, idpType clustersmgmtv1.IdentityProviderType) *clustersmgmtv1.IdentityProvider {
+	t.Helper()
+
+	idp, err := clustersmgmtv1.NewIdentityProvider().ID("provider").Name(name).Type(idpType).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", err, false)
+	}
+
+	return idp
+}
+
+func Test_verifyProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		idp               *clustersmgmtv1.IdentityProvider
+		clusterID         string
+		expectedClusterID string
+		wantErr           bool
+	}{
+		{
+			name:              "ensure a matching identity provider is verified",
+			idp:               testProvider(t, "corporate-ldap", clustersmgmtv1.IdentityProviderTypeLDAP),
+			clusterID:         "cluster",
+			expectedClusterID: "cluster",
+			wantErr:           false,
+		},
+		{
+			name:              "ensure an identity provider with another name is not verified",
+			idp:               testProvider(t, "other-ldap", clustersmgmtv1.IdentityProviderTypeLDAP),
+			clusterID:         "cluster",
+			expectedClusterID: "cluster",
+			wantErr:           true,
+		},
+		{
+			name:              "ensure an identity provider of another type is not verified",
+			idp:               testProvider(t, "corporate-ldap", clustersmgmtv1.IdentityProviderTypeGitlab),
+			clusterID:         "cluster",
+			expectedClusterID: "cluster",
+			wantErr:           true,
+		},
+		{
+			name:              "ensure an identity provider of another cluster is not verified",
+			idp:               testProvider(t, "corporate-ldap", clustersmgmtv1.IdentityProviderTypeLDAP),
+			clusterID:         "restored",
+			expectedClusterID: "cluster",
+			wantErr:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := verifyProvider(tt.idp, "corporate-ldap", tt.clusterID, tt.expectedClusterID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrProviderMismatch) {
+				t.Errorf("verifyProvider() error = %v, want %v", err, ErrProviderMismatch)
+			}
+		})
+	}
+}
+
+func TestLDAPIdentityProviderRequest_desired(t *testing.T) {
+	t.Parallel()
+
+	object := &ocmv1alpha1.LDAPIdentityProvider{}
+	object.Spec.ClusterName = "test"
+	object.Spec.DisplayName = "corporate-ldap"
+	object.Spec.MappingMethod = "claim"
+	object.Spec.URL = "ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid"
+
+	source, err := clustersmgmtv1.NewLDAPIdentityProvider().URL(object.Spec.URL).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", err, false)
+	}
+
+	current := object.DeepCopy()
+	current.CopyFrom(source)
+
+	tests := []struct {
+		name    string
+		desired func() *ocmv1alpha1.LDAPIdentityProvider
+		want    bool
+	}{
+		{
+			name:    "ensure defaulted attributes reflect desired state",
+			desired: object.DeepCopy,
+			want:    true,
+		},
+		{
+			name: "ensure a differently cased host reflects desired state",
+			desired: func() *ocmv1alpha1.LDAPIdentityProvider {
+				desired := object.DeepCopy()
+				desired.Spec.URL = "ldaps://LDAP.example.com/ou=users,dc=example,dc=com?uid"
+
+				return desired
+			},
+			want: true,
+		},
+		{
+			name: "ensure attributes which are normalized by ocm reflect desired state",
+			desired: func() *ocmv1alpha1.LDAPIdentityProvider {
+				desired := object.DeepCopy()
+				desired.Spec.Attributes.ID = []string{" dn", "dn", ""}
+				desired.Spec.Attributes.Email = []string{""}
+
+				return desired
+			},
+			want: true,
+		},
+		{
+			name: "ensure changed attributes do not reflect desired state",
+			desired: func() *ocmv1alpha1.LDAPIdentityProvider {
+				desired := object.DeepCopy()
+				desired.Spec.Attributes.Email = []string{"userPrincipalName"}
+
+				return desired
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := &LDAPIdentityProviderRequest{Current: current, Desired: tt.desired()}
+			if got := request.desired(); got != tt.want {
+				t.Errorf("LDAPIdentityProviderRequest.desired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
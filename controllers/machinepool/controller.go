@@ -21,15 +21,15 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/nukleros/operator-builder-tools/pkg/controller/predicates"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
 )
 
 const (
@@ -40,16 +40,30 @@ const (
 type Controller struct {
 	client.Client
 
-	Scheme     *runtime.Scheme
-	Connection *sdk.Connection
-	Recorder   record.EventRecorder
-	Interval   time.Duration
+	controllers.Dependencies
+
+	Scheme   *runtime.Scheme
+	Interval time.Duration
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
+
+	// EnableRawOverrides merges the raw overrides of a machine pool into the requests which create and
+	// update it in OpenShift Cluster Manager.  Raw overrides are ignored if this is not set.
+	EnableRawOverrides bool
 }
 
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=machinepools,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=machinepools/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=machinepools/finalizers,verbs=update
 
+// RequiredAPIs returns the APIs of OpenShift Cluster Manager which the controller depends upon.  It
+// is used to satisfy the Compatible interface.
+func (r *Controller) RequiredAPIs() []ocm.API {
+	return []ocm.API{ocm.APIClustersMgmt, ocm.APIAccountsMgmt}
+}
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
@@ -64,19 +78,28 @@ func (r *Controller) ReconcileCreate(req controllers.Request) (ctrl.Result, erro
 	// type cast the request to a machine pool request
 	request, ok := req.(*MachinePoolRequest)
 	if !ok {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), ErrMachinePoolRequestConvert
+		return controllers.RequeueAfter(r.requeue()), ErrMachinePoolRequestConvert
 	}
 
 	// add the finalizer
 	if err := controllers.AddFinalizer(request.Context, r, request.Original); err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("unable to register delete hooks - %w", err)
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to register delete hooks - %w", err)
 	}
 
 	// execute the phases
 	return request.execute([]Phase{
 		{Name: "begin", Function: r.Begin},
-		{Name: "getCurrentState", Function: r.GetCurrentState},
-		{Name: "applyState", Function: r.Apply},
+		{Name: "authorize", Function: r.Authorize},
+		{Name: "validateSchedules", Function: r.ValidateSchedules},
+		{Name: "checkInputs", Function: r.CheckInputs},
+		{Name: "getCurrentState", Function: controllers.SkipUnchanged(r.GetCurrentState)},
+		{Name: "import", Function: controllers.SkipUnchanged(r.Import)},
+		{Name: "checkCapabilities", Function: controllers.SkipUnchanged(r.CheckCapabilities)},
+		{Name: "validateAutoscaling", Function: controllers.SkipUnchanged(r.ValidateAutoscaling)},
+		{Name: "validateCapacity", Function: controllers.SkipUnchanged(r.ValidateCapacity)},
+		{Name: "validateVersion", Function: controllers.SkipUnchanged(r.ValidateVersion)},
+		{Name: "applyState", Function: controllers.SkipUnchanged(r.Apply)},
+		{Name: "applyVersion", Function: controllers.SkipUnchanged(r.ApplyVersion)},
 		{Name: "waitUntilReady", Function: r.WaitUntilReady},
 		{Name: "complete", Function: r.Complete},
 	}...)
@@ -95,24 +118,59 @@ func (r *Controller) ReconcileDelete(req controllers.Request) (ctrl.Result, erro
 	// type cast the request to a machine pool request
 	request, ok := req.(*MachinePoolRequest)
 	if !ok {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), ErrMachinePoolRequestConvert
+		return controllers.RequeueAfter(r.requeue()), ErrMachinePoolRequestConvert
 	}
 
 	// execute the phases
 	return request.execute([]Phase{
 		{Name: "begin", Function: r.Begin},
+		{Name: "authorize", Function: r.Authorize},
 		{Name: "destroy", Function: r.Destroy},
 		{Name: "waitUntilMissing", Function: r.WaitUntilMissing},
 		{Name: "complete", Function: r.CompleteDestroy},
 	}...)
 }
 
+// requeue returns the interval after which a failed or incomplete reconciliation is retried.
+func (r *Controller) requeue() time.Duration {
+	if r.Requeue == 0 {
+		return defaultMachinePoolRequeue
+	}
+
+	return r.Requeue
+}
+
 // SetupWithManager sets up the controller with the Manager.
 //
 //nolint:wrapcheck
 func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicates.WorkloadPredicates()).
+	// index the cluster, by its name and by its id, and the cluster reference, which each object targets,
+	// so that the objects of a cluster or of a cluster reference may be looked up
+	if err := controllers.SetupIndexes(
+		context.Background(),
+		mgr.GetFieldIndexer(),
+		&ocmv1alpha1.MachinePool{},
+		controllers.IndexBy(controllers.IndexClusterName, (*ocmv1alpha1.MachinePool).GetClusterName),
+		controllers.IndexBy(controllers.IndexClusterReference, controllers.ClusterReferenceOf[*ocmv1alpha1.MachinePool]),
+		controllers.IndexBy(controllers.IndexClusterID, controllers.ClusterIDOf[*ocmv1alpha1.MachinePool]),
+	); err != nil {
+		return fmt.Errorf("unable to index machine pools - %w", err)
+	}
+
+	managedBy := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options()).
+		WithEventFilter(predicate.Or(
+			controllers.WorkloadPredicates(),
+			controllers.ImportPredicate(),
+			controllers.BroadcastPredicate(),
+			controllers.ClusterReferencePredicate(),
+		)).
 		For(&ocmv1alpha1.MachinePool{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &ocmv1alpha1.ClusterReference{}}, controllers.EnqueueClusterReferences(r, &ocmv1alpha1.MachinePoolList{}))
+
+	if r.Broadcaster != nil {
+		managedBy = managedBy.Watches(r.Broadcaster.Subscribe(), controllers.EnqueueAll(r, &ocmv1alpha1.MachinePoolList{}))
+	}
+
+	return managedBy.Complete(r)
 }
@@ -0,0 +1,68 @@
+package machinepool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ReplicasMetric is the name of the metric which exposes the number of nodes of each machine
+	// pool, by the source which the number of nodes was observed from.
+	ReplicasMetric = "ocm_machine_pool_replicas"
+
+	// ReplicasTypeDesired is the number of nodes which are requested for a machine pool.  When the
+	// machine pool is autoscaling, this is the minimum number of nodes.
+	ReplicasTypeDesired = "desired"
+
+	// ReplicasTypeOCM is the number of nodes which OpenShift Cluster Manager reports for a machine
+	// pool.  It is only reported for hosted control plane clusters.
+	ReplicasTypeOCM = "ocm"
+
+	// ReplicasTypeReady is the number of nodes of a machine pool which are ready in the cluster.
+	ReplicasTypeReady = "ready"
+)
+
+// replicas is the number of nodes of each machine pool, so that capacity shortfalls across the fleet
+// may be alerted upon.  Machine pools with fewer ready nodes than requested may be queried with
+// ocm_machine_pool_replicas{type="ready"} < ignoring(type) ocm_machine_pool_replicas{type="desired"}.
+var replicas = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: ReplicasMetric,
+		Help: "Number of nodes of a machine pool which are desired, reported by OpenShift Cluster Manager and ready.",
+	},
+	[]string{"namespace", "name", "cluster", "type"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(replicas)
+}
+
+// observeReplicas records the node counts of a machine pool in the replicas metric.
+func (request *MachinePoolRequest) observeReplicas() {
+	labels := prometheus.Labels{
+		"namespace": request.Original.GetNamespace(),
+		"name":      request.Original.GetName(),
+		"cluster":   request.Desired.Spec.ClusterName,
+	}
+
+	for replicasType, count := range map[string]int{
+		ReplicasTypeDesired: request.Desired.DesiredReplicas(),
+		ReplicasTypeReady:   request.Original.Status.ReadyReplicas,
+	} {
+		labels["type"] = replicasType
+		replicas.With(labels).Set(float64(count))
+	}
+
+	if request.Original.Status.Hosted {
+		labels["type"] = ReplicasTypeOCM
+		replicas.With(labels).Set(float64(request.Original.Status.OCMReplicas))
+	}
+}
+
+// forgetReplicas removes the series of a machine pool from the replicas metric.
+func (request *MachinePoolRequest) forgetReplicas() {
+	replicas.DeletePartialMatch(prometheus.Labels{
+		"namespace": request.Original.GetNamespace(),
+		"name":      request.Original.GetName(),
+	})
+}
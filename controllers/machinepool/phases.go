@@ -1,11 +1,14 @@
 package machinepool
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
@@ -14,25 +17,79 @@ import (
 	"github.com/rh-mobb/ocm-operator/pkg/events"
 	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+	"github.com/rh-mobb/ocm-operator/pkg/utils"
 )
 
 // Phase defines an individual phase in the controller reconciliation process.
-type Phase struct {
-	Name     string
-	Function func(*MachinePoolRequest) (ctrl.Result, error)
-}
+type Phase = controllers.RequestPhase[*MachinePoolRequest]
 
 // Begin begins the reconciliation state once we get the object (the desired state) from the cluster.
 // It is mainly used to set conditions of the controller and to let anyone who is viewiing the
 // custom resource know that we are currently reconciling.
 func (r *Controller) Begin(request *MachinePoolRequest) (ctrl.Result, error) {
-	if err := request.updateCondition(conditions.Reconciling(request.Trigger)); err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("error updating reconciling condition - %w", err)
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
 	}
 
 	return controllers.NoRequeue(), nil
 }
 
+// Authorize ensures that the namespace of the machine pool has been granted management of its cluster
+// by a cluster management binding.  A forbidden machine pool is reported with a Forbidden condition and
+// a warning event, and the request is retried at the regular interval.  A forbidden machine pool which is
+// deleted is released without deleting anything from OpenShift Cluster Manager.
+func (r *Controller) Authorize(request *MachinePoolRequest) (ctrl.Result, error) {
+	allowed, err := controllers.ManagementAllowed(
+		request.Context,
+		r,
+		request.Original.Namespace,
+		request.Desired.Spec.ClusterName,
+		request.Original.Status.ClusterID,
+	)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if allowed {
+		if conditions.IsForbidden(request.Original) {
+			if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Permitted()); err != nil {
+				return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+			}
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := conditions.Forbidden(request.Original.Namespace, request.Desired.Spec.ClusterName)
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	// release the deleted object without touching openshift cluster manager, as the namespace is
+	// not allowed to manage the cluster
+	if request.Trigger == triggers.Delete {
+		if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating forbidden condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(request.requeueInterval()), nil
+}
+
 // GetCurrentState gets the current state of the MachinePool resoruce.  The current state of the MachinePool resource
 // is stored in OpenShift Cluster Manager.  It will be compared against the desired state which exists
 // within the OpenShift cluster in which this controller is reconciling against.
@@ -40,31 +97,40 @@ func (r *Controller) Begin(request *MachinePoolRequest) (ctrl.Result, error) {
 //
 //nolint:cyclop
 func (r *Controller) GetCurrentState(request *MachinePoolRequest) (ctrl.Result, error) {
-	// retrieve the cluster id
-	clusterID := request.Original.Status.ClusterID
-	if clusterID == "" {
-		if err := request.updateStatusCluster(); err != nil {
-			return controllers.RequeueAfter(defaultMachinePoolRequeue), err
+	// retrieve the cluster id along with the details of the cluster.  the cluster is retrieved once per
+	// request, and is needed to validate the capacity of the machine pool, so the details are refreshed
+	// on every request rather than inferring from an empty detail that they were never recorded.
+	if err := request.updateStatusCluster(); err != nil {
+		if errors.Is(err, ErrClusterInstalling) {
+			request.Log.Info("waiting for cluster installation", request.logValues()...)
+
+			return controllers.NoRequeue(), controllers.WaitFor(
+				fmt.Sprintf("cluster [%s]", request.Desired.Spec.ClusterName),
+				controllers.WaitReasonClusterInstalling,
+				controllers.RequeueHint(ocm.RequeueClusterInstalling, request.requeueInterval()).RequeueAfter,
+			)
 		}
 
-		clusterID = request.Original.Status.ClusterID
+		return controllers.RequeueAfter(r.requeue()), err
 	}
 
+	clusterID := request.Original.Status.ClusterID
+
 	// retrieve the machine pool (or node pool for hosted control plane clusters)
 	var pool interface{}
 
 	var err error
 
 	if request.Original.Status.Hosted {
-		poolClient := ocm.NewNodePoolClient(r.Connection, request.Desired.Spec.DisplayName, clusterID)
+		poolClient := ocm.NewNodePoolClient(request.Environment.Connection, request.Desired.Spec.DisplayName, clusterID).WithContext(request.Context)
 		pool, err = poolClient.Get()
 	} else {
-		poolClient := ocm.NewMachinePoolClient(r.Connection, request.Desired.Spec.DisplayName, clusterID)
+		poolClient := ocm.NewMachinePoolClient(request.Environment.Connection, request.Desired.Spec.DisplayName, clusterID).WithContext(request.Context)
 		pool, err = poolClient.Get()
 	}
 
 	if err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf(
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"unable to retrieve machine pool from ocm [name=%s, clusterName=%s] - %w",
 			request.Desired.Spec.DisplayName,
 			request.Desired.Spec.ClusterName,
@@ -79,33 +145,59 @@ func (r *Controller) GetCurrentState(request *MachinePoolRequest) (ctrl.Result,
 	}
 
 	// copy the machine pool object from ocm into a
-	// new object.
+	// new object and store the state reported by ocm in the status.
+	var statusErr error
+
 	request.Current = &ocmv1alpha1.MachinePool{}
 	if request.Original.Status.Hosted {
 		nodePool, ok := pool.(*clustersmgmtv1.NodePool)
 		if !ok {
-			return controllers.RequeueAfter(defaultMachinePoolRequeue), ocm.ErrConvertNodePool
+			return controllers.RequeueAfter(r.requeue()), ocm.ErrConvertNodePool
 		}
 
 		err = request.Current.CopyFromNodePool(nodePool, request.Desired.Spec.ClusterName)
+		statusErr = request.updateStatusOCM(nodePool.ID(), nodePool.Status().CurrentReplicas(), nodePool.Status().Message())
 	} else {
 		machinePool, ok := pool.(*clustersmgmtv1.MachinePool)
 		if !ok {
-			return controllers.RequeueAfter(defaultMachinePoolRequeue), ocm.ErrConvertMachinePool
+			return controllers.RequeueAfter(r.requeue()), ocm.ErrConvertMachinePool
 		}
 
 		err = request.Current.CopyFromMachinePool(machinePool, request.Desired.Spec.ClusterName)
+		statusErr = request.updateStatusOCM(machinePool.ID(), 0, "")
+
+		// openshift cluster manager reports the subnets of every machine pool in a cluster which was
+		// installed into an existing vpc, so the subnet of the machine pool is only known when it is the
+		// requested subnet or the only subnet which is reported.  an unknown subnet is not compared.
+		request.Current.Spec.AWS.Subnet = currentSubnet(request.Desired.Spec.AWS.Subnet, machinePool.Subnets())
+	}
+
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to copy ocm machine pool object - %w", err)
 	}
 
+	if statusErr != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating ocm machine pool state - %w", statusErr)
+	}
+
+	// the raw overrides may not be observed in ocm, so the current state carries the raw overrides of
+	// the desired state once they have been applied
+	hash, err := request.overridesHash()
 	if err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("unable to copy ocm machine pool object - %w", err)
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if hash == request.Original.Status.RawOverridesHash {
+		request.Current.Spec.RawOverrides = request.Desired.Spec.RawOverrides
 	}
 
 	// ensure that we have the required labels for the machine pool
 	// we found.  we do this to ensure we are not managing something that
-	// may have been created by another process.
-	if !request.Current.HasManagedLabels() {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf(
+	// may have been created by another process, unless we have explicitly
+	// requested to adopt it.  the default machine pool is created along with
+	// the cluster and so is always managed without being adopted.
+	if !request.Current.HasManagedLabels() && !controllers.AdoptRequested(request.Original) && !request.Original.IsDefault() {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
 			"missing managed labels [%+v] - %w",
 			request.Current.Spec.Labels,
 			ErrMachinePoolReservedLabel,
@@ -115,6 +207,289 @@ func (r *Controller) GetCurrentState(request *MachinePoolRequest) (ctrl.Result,
 	return controllers.NoRequeue(), nil
 }
 
+// Import imports the current state of the machine pool from OpenShift Cluster Manager into the spec
+// when the import annotation is set, and removes the annotation so that the import only happens once.
+// This allows machine pools which were created outside of this controller to be onboarded.  A machine
+// pool without the managed labels must also set the adopt annotation, which is checked when the current
+// state is retrieved.  An imported machine pool is labeled as managed by this controller when its
+// desired state is applied.
+func (r *Controller) Import(request *MachinePoolRequest) (ctrl.Result, error) {
+	if !controllers.ImportRequested(request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	imported := request.Original.DeepCopy()
+	if request.Current != nil {
+		imported.ImportFrom(request.Current)
+	}
+
+	if err := controllers.Import(request.Context, r, request.Original, imported); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	request.Original = imported
+
+	if request.Current == nil {
+		request.Log.Info("machine pool not found in openshift cluster manager; skipping import", request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, "ImportSkipped", "machine pool not found in openshift cluster manager")
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// update the desired state to reflect the imported spec
+	request.Desired.ImportFrom(request.Current)
+	request.Desired.SetMachinePoolLabels()
+	request.Desired.ApplySchedule(request.Schedule)
+
+	// report the immutable fields which were not imported
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Imported(imported.ImportMismatches(request.Current)),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	// create an event indicating that the machine pool has been imported
+	events.RegisterAction(events.Imported, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+
+	return controllers.NoRequeue(), nil
+}
+
+// CheckInputs determines if the inputs of the machine pool are unchanged since they were last applied,
+// before the desired state is adjusted against the capabilities of the cluster.  The phases which read
+// the state of the machine pool in OCM are skipped while they are unchanged, until the drift interval
+// has elapsed since they were last applied.
+func (r *Controller) CheckInputs(request *MachinePoolRequest) (ctrl.Result, error) {
+	inputs, err := request.fingerprint()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	request.Inputs = inputs
+	request.Unchanged = controllers.Unchanged(request.Original, inputs, r.DriftInterval, time.Now())
+	if request.Unchanged {
+		request.Log.V(controllers.LogLevelDebug).Info("machine pool unchanged since last applied", request.logValues()...)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// ValidateSchedules reports a schedule of the machine pool which is unable to be evaluated with a
+// SchedulesValid condition and a warning event.  The machine pool is not applied while a schedule is
+// invalid, as its node counts would otherwise be applied without the schedule which overrides them, and
+// the request is retried at the regular interval rather than immediately, as it cannot succeed until the
+// schedule is corrected.
+func (r *Controller) ValidateSchedules(request *MachinePoolRequest) (ctrl.Result, error) {
+	condition := conditions.SchedulesValid()
+	if request.ScheduleError != nil {
+		condition = conditions.ScheduleInvalid(request.ScheduleError)
+	}
+
+	// only report valid schedules on a machine pool which has schedules, or which previously reported
+	// an invalid schedule
+	previous := conditions.NewManager(request.Original).Get(conditions.MachinePoolConditionTypeSchedulesValid)
+	if len(request.Original.Spec.Schedules) == 0 && previous == nil {
+		return controllers.NoRequeue(), nil
+	}
+
+	if !conditions.IsSet(condition, request.Original) {
+		// only register a warning event when the invalid schedule is first observed
+		if condition.Status == metav1.ConditionFalse {
+			request.Log.Info(condition.Message, request.logValues()...)
+			events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+		}
+
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating schedules condition - %w", err)
+		}
+	}
+
+	if request.ScheduleError != nil {
+		return controllers.RequeueAfter(request.requeueInterval()), nil
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// CheckCapabilities determines if the features requested by the machine pool are supported by the
+// cluster.  Unsupported features are ignored when the machine pool is applied and are reported with
+// an Unsupported condition and a warning event, rather than being retried.  An unsupported subnet is
+// not ignored, as the machine pool would otherwise be provisioned outside of the requested local
+// zone or outpost, so the machine pool is not applied until either it or the cluster changes.  The
+// instance type of the default machine pool is also unsupported, as it may only be chosen when the
+// cluster is installed.
+func (r *Controller) CheckCapabilities(request *MachinePoolRequest) (ctrl.Result, error) {
+	// the instance type of the default machine pool may not be changed, so the current instance type
+	// is kept rather than attempting to apply it
+	if request.Original.IsDefault() && request.Current != nil {
+		request.Desired.Spec.InstanceType = request.Current.Spec.InstanceType
+	}
+
+	// keep the condition if the current generation was rejected by openshift cluster manager, as we
+	// cannot determine if the cluster now supports it until it is applied again
+	if conditions.IsRejected(request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	condition := request.capabilityCondition()
+	if !conditions.IsSet(condition, request.Original) {
+		// only register a warning event when the unsupported feature is first observed
+		if condition.Status == metav1.ConditionTrue {
+			request.Log.Info(condition.Message, request.logValues()...)
+			events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+		}
+
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating unsupported condition - %w", err)
+		}
+	}
+
+	if condition.Reason == conditionReasonSubnetUnsupported {
+		return controllers.RequeueAfter(request.requeueInterval()), nil
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Unsupported handles a request to apply the machine pool which was rejected by OpenShift Cluster
+// Manager as invalid or unsupported for the cluster.  The rejection is reported with an Unsupported
+// condition and a warning event, and the request is retried at the regular interval rather than
+// immediately, as it cannot succeed until either the machine pool or the cluster changes.
+func (r *Controller) Unsupported(request *MachinePoolRequest, err error) (ctrl.Result, error) {
+	condition := conditions.Unsupported(conditions.ConditionReasonRejectedByOCM, err.Error())
+
+	if !conditions.IsSet(condition, request.Original) {
+		request.Log.Info("machine pool rejected by openshift cluster manager", append(request.logValues(), "error", err.Error())...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating unsupported condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(request.requeueInterval()), nil
+}
+
+//+kubebuilder:rbac:groups=autoscaling.openshift.io,resources=clusterautoscalers,verbs=get;list;watch
+
+// ValidateAutoscaling warns when the autoscaling configuration of the machine pool is inconsistent
+// with the cluster autoscaler, either because the machine pool maximum exceeds the maxNodesTotal of
+// the cluster autoscaler, or because autoscaling is enabled without a cluster autoscaler.  An
+// inconsistency does not prevent the machine pool from being applied.  Hosted control plane clusters
+// do not use an in-cluster cluster autoscaler and are skipped.
+func (r *Controller) ValidateAutoscaling(request *MachinePoolRequest) (ctrl.Result, error) {
+	if request.Original.Status.Hosted || request.Desired.Spec.MaximumNodesPerZone == 0 {
+		return controllers.NoRequeue(), nil
+	}
+
+	maxNodesTotal, found, err := kubernetes.GetClusterAutoscalerMaxNodesTotal(request.Context, r)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	condition := request.autoscalingCondition(maxNodesTotal, found)
+	if condition == nil || conditions.IsSet(condition, request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	// only register a warning event when the inconsistency is first observed
+	if condition.Status == metav1.ConditionFalse {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating autoscaling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// ValidateCapacity warns when the machine pool is never able to fully schedule within the cluster.  This
+// is the case when the instance type of the machine pool is unavailable to the cluster, when the maximum
+// number of compute nodes of the cluster, including the machine pool, exceeds the maximum which the
+// cluster supports, or when the maximum number of nodes of the cluster exceeds the number of nodes which
+// its network is able to allocate, as derived from the pod and machine cidrs of the cluster.  The
+// capacity is validated upon every reconcile, as the other machine pools of the cluster may change, and
+// does not prevent the machine pool from being applied.
+func (r *Controller) ValidateCapacity(request *MachinePoolRequest) (ctrl.Result, error) {
+	cluster, err := request.cluster()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	machineType, err := ocm.NewMachineTypeClient(request.Environment.Connection).
+		WithContext(request.Context).
+		Get(cluster.CloudProvider().ID(), request.Desired.Spec.InstanceType)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	nodes, err := request.computeNodes(cluster)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	condition := request.instanceTypeCondition(cluster, machineType)
+	if condition == nil {
+		condition = capacityCondition(cluster, nodes+request.maximumReplicas())
+	}
+
+	if conditions.IsSet(condition, request.Original) {
+		return controllers.NoRequeue(), nil
+	}
+
+	// only register a warning event when the shortfall is first observed
+	if condition.Status == metav1.ConditionFalse {
+		request.Log.Info(condition.Message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, condition.Reason, condition.Message)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating capacity condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// ValidateVersion validates that the version requested by a node pool is within the allowed skew of the
+// version of the control plane before the node pool is applied, so that a version which would be rejected
+// by OpenShift Cluster Manager fails early with the range of versions which are allowed.  A node pool which
+// already runs the requested version is not validated again, so that it may still be scaled after the
+// control plane has been upgraded beyond its skew.  An upgrade to a version which is newer than the control
+// plane is not rejected, as it waits for the control plane to be upgraded in the ApplyVersion phase.
+func (r *Controller) ValidateVersion(request *MachinePoolRequest) (ctrl.Result, error) {
+	version := request.Original.Spec.Version
+	if !request.Original.Status.Hosted || version == "" {
+		return controllers.NoRequeue(), nil
+	}
+
+	if request.Current != nil && request.Current.Status.Version == version {
+		return controllers.NoRequeue(), nil
+	}
+
+	cluster, err := request.cluster()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	controlPlane := ocm.RawVersion(cluster.Version())
+	if request.Current != nil && ocm.IsNewerVersion(version, controlPlane) {
+		return controllers.NoRequeue(), nil
+	}
+
+	if err := ocm.ValidateNodePoolVersion(version, controlPlane); err != nil {
+		return controllers.RequeueAfter(r.requeue()), conditions.WithReason(
+			conditions.ReasonValidationRejected,
+			fmt.Errorf("unable to apply node pool version - %w", err),
+		)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
 // Apply will create an OpenShift Cluster Manager machine pool if it does not exist,
 // or update an OpenShift Cluster Manager machine pool if it does exist.
 //
@@ -123,6 +498,7 @@ func (r *Controller) Apply(request *MachinePoolRequest) (ctrl.Result, error) {
 	// return if it is already in its desired state
 	if request.desired() {
 		request.Log.V(controllers.LogLevelDebug).Info("machine pool already in desired state", request.logValues()...)
+		request.Fingerprint = request.Inputs
 
 		return controllers.NoRequeue(), nil
 	}
@@ -132,16 +508,16 @@ func (r *Controller) Apply(request *MachinePoolRequest) (ctrl.Result, error) {
 
 	if request.Original.Status.Hosted {
 		poolClient = ocm.NewNodePoolClient(
-			r.Connection,
+			request.Environment.Connection,
 			request.Desired.Spec.DisplayName,
 			request.Original.Status.ClusterID,
-		)
+		).WithContext(request.Context).WithOverrides(request.overrides())
 	} else {
 		poolClient = ocm.NewMachinePoolClient(
-			r.Connection,
+			request.Environment.Connection,
 			request.Desired.Spec.DisplayName,
 			request.Original.Status.ClusterID,
-		)
+		).WithContext(request.Context).WithOverrides(request.overrides())
 	}
 
 	// build the request
@@ -168,18 +544,39 @@ func (r *Controller) Apply(request *MachinePoolRequest) (ctrl.Result, error) {
 					request.logValues()...,
 				)
 
-				return controllers.RequeueAfter(defaultMachinePoolRequeue), nil
+				return controllers.RequeueAfter(r.requeue()), nil
 			}
 
-			return controllers.RequeueAfter(defaultMachinePoolRequeue), createErr
+			if ocm.IsUnsupported(createErr) {
+				return r.Unsupported(request, createErr)
+			}
+
+			return controllers.RequeueAfter(r.requeue()), createErr
+		}
+
+		if err := request.updateStatusRawOverrides(); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
 		}
 
 		// create an event indicating that the machine pool has been created
 		events.RegisterAction(events.Created, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+		request.Fingerprint = request.Inputs
 
 		return controllers.NoRequeue(), nil
 	}
 
+	// report auto-repair which has been changed outside of this operator before it is restored
+	if request.autoRepairDrifted() {
+		message := fmt.Sprintf(
+			"node pool auto-repair drifted from desired state [desired=%t, current=%t]; restoring",
+			*request.Desired.Spec.AutoRepair,
+			*request.Current.Spec.AutoRepair,
+		)
+
+		request.Log.Info(message, request.logValues()...)
+		events.RegisterWarning(request.Original, r.Recorder, "AutoRepairDrift", message)
+	}
+
 	// update the object
 	var updateErr error
 
@@ -191,15 +588,199 @@ func (r *Controller) Apply(request *MachinePoolRequest) (ctrl.Result, error) {
 	}
 
 	if updateErr != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), updateErr
+		if ocm.IsUnsupported(updateErr) {
+			return r.Unsupported(request, updateErr)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), updateErr
+	}
+
+	if err := request.updateStatusRawOverrides(); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
 	}
 
 	// create an event indicating that the machine pool has been updated
 	events.RegisterAction(events.Updated, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	request.Fingerprint = request.Inputs
 
 	return controllers.NoRequeue(), nil
 }
 
+// ApplyVersion upgrades the node pool of a hosted control plane cluster to the version requested by
+// spec.version, independently of the control plane.  Only z-stream upgrades to a version which is an
+// available upgrade of the node pool are supported.  The progress of the upgrade is tracked in the
+// status and the request is requeued until the upgrade has finished.
+func (r *Controller) ApplyVersion(request *MachinePoolRequest) (ctrl.Result, error) {
+	// return if we do not have a node pool which requests a version.  a node pool which was just
+	// created has been created with the requested version.
+	if !request.Original.Status.Hosted || request.Original.Spec.Version == "" || request.Current == nil {
+		return controllers.NoRequeue(), nil
+	}
+
+	version, current := request.Original.Spec.Version, request.Current.Status.Version
+
+	policyClient := ocm.NewNodePoolUpgradePolicyClient(
+		request.Environment.Connection,
+		request.Original.Status.ClusterID,
+		request.Desired.Spec.DisplayName,
+	).WithContext(request.Context)
+
+	policies, err := policyClient.List()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve node pool upgrade policies from ocm - %w",
+			err,
+		)
+	}
+
+	// track the progress of an upgrade which has not yet finished.  an upgrade to a different version
+	// blocks the requested upgrade until it has finished.
+	if pending := ocm.PendingNodePoolUpgrade(policies); pending != nil {
+		if err := request.updateStatusUpgrade(current, pending); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+
+		var blocked []string
+		if pending.Version != version {
+			blocked = []string{fmt.Sprintf(upgradeBlockedScheduled, pending.Version)}
+		}
+
+		if err := request.updateStatusBlockedReasons(blocked); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+
+		request.Log.Info(
+			fmt.Sprintf("waiting for node pool upgrade [version=%s, nextRun=%s]", pending.Version, pending.NextRun),
+			request.logValues()...,
+		)
+
+		return controllers.RequeueHint(ocm.RequeueForUpgrade(pending, time.Now()), request.requeueInterval()), nil
+	}
+
+	// return if the node pool is already at its desired version
+	if current == version {
+		// an upgrade which was being tracked in the status has completed
+		completed := request.Original.Status.Upgrade != nil
+
+		if err := request.updateStatusUpgrade(current, nil); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+
+		if err := request.updateStatusBlockedReasons(nil); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+
+		if completed {
+			events.RegisterAction(events.UpgradeCompleted, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+		}
+
+		request.Log.V(controllers.LogLevelDebug).Info("node pool already at desired version", request.logValues()...)
+
+		return controllers.NoRequeue(), nil
+	}
+
+	// surface an upgrade to the requested version which failed rather than scheduling it again straight
+	// away, which would fail repeatedly, and schedule it again once the backoff has elapsed
+	if failed := ocm.FailedNodePoolUpgrade(policies, version); failed != nil {
+		if retry := failed.NextRun.Add(nodePoolUpgradeRetryBackoff); time.Now().Before(retry) {
+			return r.upgradeFailed(request, current, failed, retry)
+		}
+	}
+
+	// determine whether anything blocks the upgrade, so that each blocker is visible in the status
+	cluster, err := request.cluster()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	available, err := ocm.NewVersionClient(request.Environment.Connection).WithContext(request.Context).Get(ocm.VersionID(current))
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	blocked := upgradeBlockers(current, version, cluster, available.AvailableUpgrades())
+	if err := request.updateStatusBlockedReasons(blocked); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if !ocm.IsZStreamUpgrade(current, version) {
+		return controllers.RequeueAfter(r.requeue()), conditions.WithReason(conditions.ReasonValidationRejected, fmt.Errorf(
+			"unable to upgrade node pool from [%s] to [%s] - %w",
+			current,
+			version,
+			ErrMachinePoolUpgrade,
+		))
+	}
+
+	if !utils.ContainsString(available.AvailableUpgrades(), version) {
+		return controllers.RequeueAfter(r.requeue()), conditions.WithReason(conditions.ReasonValidationRejected, fmt.Errorf(
+			"unable to upgrade node pool from [%s] to [%s] - %w",
+			current,
+			version,
+			ErrMachinePoolVersionUnavailable,
+		))
+	}
+
+	// the remaining blockers are resolved once the cluster is ready, or once the control plane has
+	// been upgraded, so wait for them rather than failing
+	if len(blocked) > 0 {
+		return controllers.NoRequeue(), controllers.WaitFor(
+			fmt.Sprintf("cluster [%s]", request.Desired.Spec.ClusterName),
+			controllers.WaitReasonUpgradeBlocked,
+			r.requeue(),
+		)
+	}
+
+	// schedule the upgrade
+	request.Log.Info(fmt.Sprintf("upgrading node pool [from=%s, to=%s]", current, version), request.logValues()...)
+
+	policy, err := policyClient.Create(version, time.Now().Add(nodePoolUpgradeDelay))
+	request.recordOperation(ocmv1alpha1.OCMOperationUpdate, policyClient.LastStatus(), err)
+
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to create node pool upgrade policy in ocm - %w",
+			err,
+		)
+	}
+
+	if err := request.updateStatusUpgrade(current, policy); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	// create an event indicating that the node pool upgrade has been scheduled
+	events.RegisterAction(events.Upgraded, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+
+	return controllers.RequeueHint(ocm.RequeueForUpgrade(policy, time.Now()), request.requeueInterval()), nil
+}
+
+// upgradeFailed reports a failed upgrade of the node pool in the status, along with the time after which
+// it is retried, and returns the failure so that it is recorded on the machine pool.
+func (r *Controller) upgradeFailed(
+	request *MachinePoolRequest,
+	current string,
+	failed *ocm.NodePoolUpgradePolicy,
+	retry time.Time,
+) (ctrl.Result, error) {
+	if err := request.updateStatusUpgrade(current, failed); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	retryAt := retry.UTC().Format(time.RFC3339)
+
+	if err := request.updateStatusBlockedReasons([]string{fmt.Sprintf(upgradeBlockedFailed, failed.Version, retryAt)}); err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	return controllers.RequeueAfter(time.Until(retry)), fmt.Errorf(
+		"unable to upgrade node pool to [%s] [description=%s, retryAfter=%s] - %w",
+		failed.Version,
+		failed.State.Description,
+		retryAt,
+		ErrMachinePoolUpgradeFailed,
+	)
+}
+
 // Destroy will destroy an OpenShift Cluster Manager machine pool.
 //
 //nolint:forcetypeassert
@@ -209,46 +790,127 @@ func (r *Controller) Destroy(request *MachinePoolRequest) (ctrl.Result, error) {
 		return controllers.NoRequeue(), nil
 	}
 
+	// leave the machine pool in openshift cluster manager while its deletion is prevented, checking
+	// again after the requeue interval so that the deletion continues once the annotation is removed
+	if controllers.PreventDestroyRequested(request.Original) {
+		return controllers.PreventDestroy(&r.Dependencies, request.execution(), r.requeue())
+	}
+
+	// the default machine pool may not be deleted, so it is left in its current state in openshift
+	// cluster manager and is no longer managed
+	isDefault, err := request.isDefault()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if isDefault {
+		request.Log.Info("default machine pool may not be deleted; releasing", request.logValues()...)
+		events.RegisterWarning(
+			request.Original,
+			r.Recorder,
+			"DefaultMachinePoolRetained",
+			"default machine pool may not be deleted and has been left in openshift cluster manager",
+		)
+
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.MachinePoolDeleted()); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+		}
+
+		return controllers.NoRequeue(), nil
+	}
+
 	// get the client
 	var poolClient interface{}
 
 	if request.Original.Status.Hosted {
 		poolClient = ocm.NewNodePoolClient(
-			r.Connection,
+			request.Environment.Connection,
 			request.Desired.Spec.DisplayName,
 			request.Original.Status.ClusterID,
-		)
+		).WithContext(request.Context)
 	} else {
 		poolClient = ocm.NewMachinePoolClient(
-			r.Connection,
+			request.Environment.Connection,
 			request.Desired.Spec.DisplayName,
 			request.Original.Status.ClusterID,
-		)
+		).WithContext(request.Context)
 	}
 
-	// delete the object
-	var deleteErr error
+	// request the deletion of the object, unless it has already been requested and openshift cluster
+	// manager is still deprovisioning it
+	deprovisioning := conditions.NewManager(request.Original).Get(conditions.MachinePoolConditionTypeDeprovisioning)
+
+	if deprovisioning == nil {
+		request.Log.Info("deleting machine pool", request.logValues()...)
+
+		if err := r.deletePool(request, poolClient); err != nil {
+			return controllers.RequeueAfter(r.requeue()), err
+		}
+	}
 
-	request.Log.Info("deleting machine pool", request.logValues()...)
+	return r.waitForDeprovision(request, poolClient, deprovisioning)
+}
+
+// deletePool requests the deletion of the machine pool or node pool from OCM.
+func (r *Controller) deletePool(request *MachinePoolRequest, poolClient interface{}) error {
 	if request.Original.Status.Hosted {
-		deleteErr = request.deleteNodePool(poolClient.(*ocm.NodePoolClient))
-	} else {
-		deleteErr = request.deleteMachinePool(poolClient.(*ocm.MachinePoolClient))
+		return request.deleteNodePool(poolClient.(*ocm.NodePoolClient))
+	}
+
+	return request.deleteMachinePool(poolClient.(*ocm.MachinePoolClient))
+}
+
+// waitForDeprovision waits for openshift cluster manager to finish deprovisioning a machine pool whose
+// deletion has been requested, so that the finalizer is not removed while its nodes are still being
+// torn down.  The progress of the deprovisioning is reported in the status until the machine pool is
+// gone.
+func (r *Controller) waitForDeprovision(
+	request *MachinePoolRequest,
+	poolClient interface{},
+	deprovisioning *metav1.Condition,
+) (ctrl.Result, error) {
+	exists, progress, err := request.deprovisionProgress(poolClient)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
 	}
 
-	if deleteErr != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), deleteErr
+	if !exists {
+		// create an event indicating that the machine pool has been deleted
+		events.RegisterAction(events.Deleted, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+
+		// set the deleted condition
+		if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.MachinePoolDeleted()); err != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+		}
+
+		return controllers.NoRequeue(), nil
 	}
 
-	// create an event indicating that the machine pool has been deleted
-	events.RegisterAction(events.Deleted, request.Original, r.Recorder, request.Desired.Spec.DisplayName, request.Original.Status.ClusterID)
+	condition := conditions.MachinePoolDeprovisioning(progress)
+
+	// request the deletion again, once, for a machine pool which has not been deprovisioned in time
+	if deprovisioning != nil {
+		if elapsed := time.Since(deprovisioning.LastTransitionTime.Time); elapsed > deprovisionTimeout {
+			condition = conditions.MachinePoolDeprovisionStalled(elapsed, progress)
+
+			if deprovisioning.Reason != conditions.MachinePoolReasonDeprovisionStalled {
+				request.Log.Info("machine pool deprovisioning stalled; deleting again", request.logValues()...)
+				events.RegisterWarning(request.Original, r.Recorder, conditions.MachinePoolReasonDeprovisionStalled, condition.Message)
 
-	// set the deleted condition
-	if err := request.updateCondition(conditions.MachinePoolDeleted()); err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("error updating reconciling condition - %w", err)
+				if err := r.deletePool(request, poolClient); err != nil {
+					return controllers.RequeueAfter(r.requeue()), err
+				}
+			}
+		}
 	}
 
-	return controllers.NoRequeue(), nil
+	request.Log.V(controllers.LogLevelDebug).Info("waiting for machine pool to be deprovisioned", request.logValues()...)
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, condition); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating deprovisioning condition - %w", err)
+	}
+
+	return controllers.RequeueAfter(r.requeue()), nil
 }
 
 //+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
@@ -266,17 +928,35 @@ func (r *Controller) Destroy(request *MachinePoolRequest) (ctrl.Result, error) {
 func (r *Controller) WaitUntilReady(request *MachinePoolRequest) (ctrl.Result, error) {
 	nodes, err := kubernetes.GetLabeledNodes(request.Context, r, request.Desired.Spec.Labels)
 	if err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("unable to get labeled nodes - %w", err)
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to get labeled nodes - %w", err)
 	}
 
-	// return if we cannot find any nodes
+	// store the observed node counts in the status.  a machine pool which is allowed to have zero
+	// nodes (e.g. an active schedule has scaled the machine pool down) is ready without any nodes.
+	allowZero := request.Desired.Spec.MinimumNodesPerZone == 0
+	ready := kubernetes.NodesAreReady(nodes.Items...) || (allowZero && len(nodes.Items) < 1)
+
+	if err := request.updateStatusReplicas(len(nodes.Items), kubernetes.ReadyNodes(nodes.Items...), ready); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating observed replicas - %w", err)
+	}
+
+	request.observeReplicas()
+
+	// return if we cannot find any nodes.  if the machine pool is allowed to have zero nodes we do
+	// not need to wait for nodes.
 	if len(nodes.Items) < 1 {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), nil
+		if allowZero {
+			request.Log.Info("machine pool allows zero nodes; skipping node readiness", request.logValues()...)
+
+			return controllers.NoRequeue(), nil
+		}
+
+		return controllers.RequeueHint(ocm.RequeueMachinePoolProvisioning, request.requeueInterval()), nil
 	}
 
 	// ensure all nodes are ready
-	if !kubernetes.NodesAreReady(nodes.Items...) {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), nil
+	if !ready {
+		return controllers.RequeueHint(ocm.RequeueMachinePoolProvisioning, request.requeueInterval()), nil
 	}
 
 	request.Log.Info("nodes are ready", request.logValues()...)
@@ -287,14 +967,24 @@ func (r *Controller) WaitUntilReady(request *MachinePoolRequest) (ctrl.Result, e
 // WaitUntilMissing will requeue until the reconciler determines that the nodes
 // no longer exist in the cluster.
 func (r *Controller) WaitUntilMissing(request *MachinePoolRequest) (ctrl.Result, error) {
+	// the nodes of the default machine pool are not removed, as it is not deleted
+	isDefault, err := request.isDefault()
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if isDefault {
+		return controllers.NoRequeue(), nil
+	}
+
 	nodes, err := kubernetes.GetLabeledNodes(request.Context, r, request.Desired.Spec.Labels)
 	if err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("unable to get labeled nodes - %w", err)
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to get labeled nodes - %w", err)
 	}
 
 	// return if we cannot find any nodes
 	if len(nodes.Items) > 0 {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), nil
+		return controllers.RequeueHint(ocm.RequeueMachinePoolProvisioning, request.requeueInterval()), nil
 	}
 
 	request.Log.Info("nodes have been removed", request.logValues()...)
@@ -305,23 +995,38 @@ func (r *Controller) WaitUntilMissing(request *MachinePoolRequest) (ctrl.Result,
 // Complete will perform all actions required to successful complete a reconciliation request.  It will
 // requeue after the interval value requested by the controller configuration to ensure that the
 // object remains in its desired state at a specific interval.
+//
+// If the active schedule of the machine pool changes prior to the interval, it will requeue when
+// the active schedule changes instead.
 func (r *Controller) Complete(request *MachinePoolRequest) (ctrl.Result, error) {
-	if err := request.updateCondition(conditions.Reconciled(request.Trigger)); err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("error updating reconciled condition - %w", err)
+	if err := request.updateStatusSchedule(); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating active schedule - %w", err)
+	}
+
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
 	}
 
+	if err := controllers.RecordApplied(request.Context, r, request.Original, request.Fingerprint); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error recording applied inputs - %w", err)
+	}
+
+	interval := request.requeueInterval()
+
 	request.Log.Info("completed machine pool reconciliation", request.logValues()...)
-	request.Log.Info(fmt.Sprintf("reconciling again in %s", r.Interval.String()), request.logValues()...)
+	request.Log.Info(fmt.Sprintf("reconciling again in %s", interval.String()), request.logValues()...)
 
-	return controllers.RequeueAfter(r.Interval), nil
+	return controllers.RequeueAfter(interval), nil
 }
 
 // CompleteDestroy will perform all actions required to successful complete a reconciliation request.
 func (r *Controller) CompleteDestroy(request *MachinePoolRequest) (ctrl.Result, error) {
 	if err := controllers.RemoveFinalizer(request.Context, r, request.Original); err != nil {
-		return controllers.RequeueAfter(defaultMachinePoolRequeue), fmt.Errorf("unable to remove finalizers - %w", err)
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("unable to remove finalizers - %w", err)
 	}
 
+	request.forgetReplicas()
+
 	request.Log.Info("completed machine pool deletion", request.logValues()...)
 
 	return controllers.NoRequeue(), nil
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -15,17 +19,49 @@ import (
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
 	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/diff"
 	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
 	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+	"github.com/rh-mobb/ocm-operator/pkg/utils"
 )
 
 const (
 	maximumNameLength = 15
+
+	// nodePoolUpgradeDelay is the amount of time after which an upgrade of a node pool is scheduled
+	// to start, as openshift cluster manager requires that upgrades are scheduled in the future.
+	nodePoolUpgradeDelay = 5 * time.Minute
+
+	// nodePoolUpgradeRetryBackoff is the amount of time, after a failed upgrade of a node pool was
+	// scheduled to start, before the upgrade is scheduled again.
+	nodePoolUpgradeRetryBackoff = time.Hour
+
+	// reasons that an upgrade of a node pool to the version requested by spec.version is blocked,
+	// which are reported in status.blockedReasons
+	upgradeBlockedClusterNotReady = "cluster is not ready [state=%s]"
+	upgradeBlockedControlPlane    = "version [%s] is newer than the control plane version [%s]"
+	upgradeBlockedNotZStream      = "upgrade from [%s] to [%s] is not a z-stream upgrade"
+	upgradeBlockedUnavailable     = "version [%s] is not an available upgrade of version [%s]"
+	upgradeBlockedScheduled       = "an upgrade to version [%s] is already scheduled"
+	upgradeBlockedFailed          = "an upgrade to version [%s] failed and is retried after [%s]"
+
+	// deprovisionTimeout is the amount of time after which a machine pool which is still being
+	// deprovisioned by openshift cluster manager is reported as stalled, and its deletion is requested
+	// again in case the original request was lost.
+	deprovisionTimeout = 30 * time.Minute
+
+	conditionReasonSpotInstancesUnsupported = "SpotInstancesUnsupported"
+	conditionReasonAWSTagsUnsupported       = "AWSTagsUnsupported"
+	conditionReasonSubnetUnsupported        = "SubnetUnsupported"
+
+	conditionReasonDefaultInstanceTypeUnsupported = "DefaultInstanceTypeUnsupported"
+	conditionReasonRawOverridesDisabled           = "RawOverridesDisabled"
 )
 
 var (
 	ErrMissingClusterID          = errors.New("unable to find cluster id")
+	ErrClusterInstalling         = errors.New("cluster is still being installed")
 	ErrMachinePoolRequestConvert = errors.New("unable to convert generic request to machine pool request")
 	ErrMachinePoolNameLength     = fmt.Errorf("machine pool name exceeds maximum length of %d characters", maximumNameLength)
 	ErrMachinePoolReservedLabel  = fmt.Errorf(
@@ -33,6 +69,13 @@ var (
 		ocm.LabelPrefixManaged,
 		ocm.LabelPrefixName,
 	)
+	ErrMachinePoolUpgrade            = errors.New("only z-stream upgrades of node pools are supported")
+	ErrMachinePoolVersionUnavailable = errors.New("version is not an available upgrade for node pool")
+	ErrMachinePoolUpgradeFailed      = errors.New("node pool upgrade failed")
+	ErrMachinePoolReservedAWSTag     = fmt.Errorf(
+		"problem with system reserved aws tag prefixes: %s",
+		strings.Join(ocm.ReservedAWSTagPrefixes, ", "),
+	)
 )
 
 // MachinePoolRequest is an object that is unique to each reconciliation
@@ -49,6 +92,29 @@ type MachinePoolRequest struct {
 	Log               logr.Logger
 	Trigger           triggers.Trigger
 	Reconciler        *Controller
+	Environment       *ocm.Environment
+
+	// data obtained during request reconciliation
+	Cluster                *clustersmgmtv1.Cluster
+	Schedule               *ocmv1alpha1.MachinePoolSchedule
+	NextScheduleTransition time.Time
+
+	// ScheduleError is the error which prevented the schedules of the machine pool from being
+	// evaluated.  It is reported with a condition rather than failing the request, so that a machine
+	// pool with an invalid schedule may still be deleted.
+	ScheduleError error
+
+	// Inputs is the fingerprint of the inputs of the request, taken before the desired state is
+	// adjusted against the capabilities of the cluster.
+	Inputs string
+
+	// Unchanged determines if the inputs of the request are unchanged since they were last applied, in
+	// which case the state of the machine pool in OCM is not read again until the drift interval has
+	// elapsed.
+	Unchanged bool
+
+	// Fingerprint is the fingerprint of the inputs which have been applied during this request.
+	Fingerprint string
 }
 
 func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
@@ -64,6 +130,12 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 		return &MachinePoolRequest{}, err
 	}
 
+	// resolve the cluster of an object which references a cluster reference rather than naming
+	// its cluster
+	if err := controllers.ResolveClusterReference(ctx, r, original, r.requeue()); err != nil {
+		return &MachinePoolRequest{}, conditions.RecordWaiting(ctx, r, original, err)
+	}
+
 	// ensure the our managed labels do not conflict with what was submitted
 	// to the cluster
 	//
@@ -78,6 +150,19 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 		)
 	}
 
+	// ensure the our aws tags do not use reserved prefixes.
+	//
+	// NOTE: this is implemented via CRD CEL validations, however leaving in
+	// place for clusters that may not have this feature gate enabled as CEL
+	// is in beta currently.
+	if original.HasReservedAWSTags() {
+		return &MachinePoolRequest{}, fmt.Errorf(
+			"spec.aws.tags cannot contain reserved tags [%+v] - %w",
+			original.Spec.AWS.Tags,
+			ErrMachinePoolReservedAWSTag,
+		)
+	}
+
 	// ensure the name is less than 15 characters.  this is due to a limitation in the downstream
 	// API.
 	//
@@ -96,11 +181,48 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 	}
 
 	// if we have a hosted control plane, ensure that we ignore the aws
-	// configuration as it is invalid for a hosted control plane.  this is
-	// only relevant so that the desired state does not drift and constanatly
-	// require an update.
+	// spot instance configuration as it is invalid for a hosted control plane,
+	// and that auto-repair defaults to enabled for objects created before it
+	// existed.  otherwise, ensure that we ignore the aws tags and auto-repair as
+	// they are only supported for a hosted control plane.  this is only relevant
+	// so that the desired state does not drift and constanatly require an update.
 	if desired.Status.Hosted {
-		desired.Spec.AWS = ocmv1alpha1.MachinePoolProviderAWS{}
+		desired.Spec.AWS = ocmv1alpha1.MachinePoolProviderAWS{Tags: desired.Spec.AWS.Tags}
+
+		if desired.Spec.AutoRepair == nil {
+			autoRepair := true
+			desired.Spec.AutoRepair = &autoRepair
+		}
+	} else {
+		desired.Spec.AWS.Tags = nil
+		desired.Spec.AutoRepair = nil
+	}
+
+	// override the node counts of the desired state if we have an active schedule.  the schedules are
+	// not evaluated for a machine pool which is being deleted, as its node counts are never applied.
+	var schedule *ocmv1alpha1.MachinePoolSchedule
+
+	var next time.Time
+
+	var scheduleErr error
+
+	if original.GetDeletionTimestamp().IsZero() {
+		schedule, next, scheduleErr = original.ActiveSchedule(time.Now())
+		if scheduleErr != nil {
+			schedule, next = nil, time.Time{}
+		}
+
+		desired.ApplySchedule(schedule)
+	}
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection, Organizations: r.Organizations},
+		original,
+	)
+	if err != nil {
+		return &MachinePoolRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
 	}
 
 	return &MachinePoolRequest{
@@ -111,6 +233,12 @@ func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controll
 		Log:               log.Log,
 		Trigger:           triggers.GetTrigger(original),
 		Reconciler:        r,
+		Environment:       environment,
+
+		// data obtained from the schedules
+		Schedule:               schedule,
+		NextScheduleTransition: next,
+		ScheduleError:          scheduleErr,
 	}, nil
 }
 
@@ -118,23 +246,29 @@ func (request *MachinePoolRequest) GetObject() controllers.Workload {
 	return request.Original
 }
 
-// execute executes a variety of different phases for the request.
-//
-//nolint:wrapcheck
-func (request *MachinePoolRequest) execute(phases ...Phase) (ctrl.Result, error) {
-	for execute := range phases {
-		// run each phase function and return if we receive any errors
-		result, err := phases[execute].Function(request)
-		if err != nil || result.Requeue {
-			return result, controllers.ReconcileError(
-				request.ControllerRequest,
-				fmt.Sprintf("%s phase reconciliation error", phases[execute].Name),
-				err,
-			)
-		}
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *MachinePoolRequest) bindContext(ctx context.Context) {
+	// the clients of the request are created from the bound context within each phase rather than
+	// being shared between phases, so binding the context binds each of them
+	request.Context = ctx
+}
+
+// execution returns the execution of the phases of the request.
+func (request *MachinePoolRequest) execution() *controllers.Execution {
+	return &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Log:        request.Log,
+		LogValues:  request.logValues,
 	}
+}
 
-	return controllers.NoRequeue(), nil
+// execute executes a variety of different phases for the request.
+func (request *MachinePoolRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, request.execution(), request, phases...)
 }
 
 func (request *MachinePoolRequest) desired() bool {
@@ -142,23 +276,79 @@ func (request *MachinePoolRequest) desired() bool {
 		return false
 	}
 
-	return reflect.DeepEqual(
-		request.Desired.Spec,
-		request.Current.Spec,
-	)
+	desired, current := request.Desired.Spec, request.Current.Spec
+
+	return diff.New().
+		Field("clusterName", diff.Values(desired.ClusterName, current.ClusterName)).
+		Field("displayName", diff.Values(desired.DisplayName, current.DisplayName)).
+		Field("minimumNodesPerZone", diff.Values(desired.MinimumNodesPerZone, current.MinimumNodesPerZone)).
+		Field("maximumNodesPerZone", diff.Values(desired.MaximumNodesPerZone, current.MaximumNodesPerZone)).
+		Field("instanceType", diff.Values(desired.InstanceType, current.InstanceType)).
+		Field("labels", diff.Maps(desired.Labels, current.Labels)).
+		Field("taints", diff.SetsBy(desired.Taints, current.Taints, taintKey)).
+		Field("aws.spotInstances", diff.Values(desired.AWS.SpotInstances, current.AWS.SpotInstances)).
+		Field("aws.subnet", diff.Known(desired.AWS.Subnet, current.AWS.Subnet)).
+		Field("aws.tags", diff.Maps(desired.AWS.Tags, current.AWS.Tags)).
+		Field("version", diff.Values(desired.Version, current.Version)).
+		Field("autoRepair", diff.Pointers(desired.AutoRepair, current.AutoRepair)).
+		Field("schedules", diff.Semantic(desired.Schedules, current.Schedules)).
+		Field("rawOverrides", diff.Semantic(desired.RawOverrides, current.RawOverrides)).
+		Equal()
+}
+
+// taintKey identifies a taint by its key, value and effect, as openshift cluster manager does not
+// preserve the order of the taints, nor their time added.
+func taintKey(taint corev1.Taint) string {
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+// currentSubnet returns the subnet which a machine pool has been placed in, given the subnets which
+// openshift cluster manager reports for it, or an empty string when it is not known.  The subnet is
+// only known when a subnet was requested, and is either reported or is the only subnet which is
+// reported, as the subnets of every machine pool in a cluster which was installed into an existing vpc
+// are reported.
+func currentSubnet(requested string, subnets []string) string {
+	switch {
+	case requested == "":
+		return ""
+	case utils.ContainsString(subnets, requested):
+		return requested
+	case len(subnets) == 1:
+		return subnets[0]
+	default:
+		return ""
+	}
 }
 
-func (request *MachinePoolRequest) updateCondition(condition *metav1.Condition) error {
-	if err := conditions.Update(
+// fingerprint returns the fingerprint of the inputs of the request.  The desired state is used as it may
+// differ from the generation of the machine pool when a schedule is active.
+func (request *MachinePoolRequest) fingerprint() (string, error) {
+	return controllers.Fingerprint(request.Desired.Spec)
+}
+
+// InputsUnchanged determines if the inputs of the request are unchanged since they were last applied.  It
+// is used to satisfy the controllers.UnchangedRequest interface.
+func (request *MachinePoolRequest) InputsUnchanged() bool {
+	return request.Unchanged
+}
+
+// recordOperation records an operation which was sent to OCM in the operation history of the object.
+// Errors recording the operation are logged rather than returned so that the result of the operation
+// is not masked.
+func (request *MachinePoolRequest) recordOperation(operation string, status int, err error) {
+	if recordErr := controllers.RecordOperation(
 		request.Context,
 		request.Reconciler,
 		request.Original,
-		condition,
-	); err != nil {
-		return fmt.Errorf("unable to update condition - %w", err)
+		operation,
+		status,
+		err,
+	); recordErr != nil {
+		request.Log.V(controllers.LogLevelDebug).Info(
+			fmt.Sprintf("unable to record %s operation - %s", strings.ToLower(operation), recordErr),
+			request.logValues()...,
+		)
 	}
-
-	return nil
 }
 
 // logValues produces a consistent set of log values for this request.
@@ -170,19 +360,63 @@ func (request *MachinePoolRequest) logValues() []interface{} {
 	}
 }
 
-// updateStatusCluster updates fields related to the cluster in which the machine pool resides in.
-func (request *MachinePoolRequest) updateStatusCluster() error {
-	// retrieve the cluster id
-	clusterClient := ocm.NewClusterClient(request.Reconciler.Connection, request.Desired.Spec.ClusterName)
-	cluster, err := clusterClient.Get()
+// cluster returns the cluster in which the machine pool resides.  The cluster is retrieved from
+// OpenShift Cluster Manager once per request, and is shared between the phases of the request.
+func (request *MachinePoolRequest) cluster() (*clustersmgmtv1.Cluster, error) {
+	if request.Cluster != nil {
+		return request.Cluster, nil
+	}
+
+	cluster, err := ocm.NewClusterClient(request.Environment.Connection, request.Desired.Spec.ClusterName).
+		WithOrganizationGuard(request.Environment.Organizations).
+		WithContext(request.Context).
+		Get()
 	if err != nil {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"unable to retrieve cluster from ocm [name=%s] - %w",
 			request.Desired.Spec.ClusterName,
 			err,
 		)
 	}
 
+	request.Cluster = cluster
+
+	return cluster, nil
+}
+
+// isDefault determines if the machine pool manages the default machine pool of a cluster which is not
+// using hosted control plane.  Whether the cluster is using hosted control plane is derived from the
+// cluster in openshift cluster manager when it has not yet been recorded in the status, for example when
+// the machine pool is deleted before its current state was retrieved.  A cluster which no longer exists
+// has no default machine pool.
+func (request *MachinePoolRequest) isDefault() (bool, error) {
+	if request.Original.Spec.DisplayName != ocm.DefaultMachinePoolName || request.Original.Status.ClusterID != "" {
+		return request.Original.IsDefault(), nil
+	}
+
+	cluster, err := request.cluster()
+	if err != nil {
+		if errors.Is(err, ocm.ErrClusterNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !cluster.Hypershift().Enabled(), nil
+}
+
+// updateStatusCluster updates fields related to the cluster in which the machine pool resides in.  The
+// cluster id, and whether the cluster is hosted, are recorded once, while the details of the cluster are
+// refreshed from the cluster which is retrieved for the request whenever it is the recorded cluster.  The
+// status is only patched when it has changed.
+func (request *MachinePoolRequest) updateStatusCluster() error {
+	// retrieve the cluster id
+	cluster, err := request.cluster()
+	if err != nil {
+		return err
+	}
+
 	// if the cluster id is missing return an error
 	if cluster.ID() == "" {
 		return fmt.Errorf("missing cluster id in response - %w", ErrMissingClusterID)
@@ -190,10 +424,25 @@ func (request *MachinePoolRequest) updateStatusCluster() error {
 
 	// keep track of the original object
 	original := request.Original.DeepCopy()
-	request.Original.Status.ClusterID = cluster.ID()
-	request.Original.Status.AvailabilityZones = cluster.Nodes().AvailabilityZones()
-	request.Original.Status.Subnets = cluster.AWS().SubnetIDs()
-	request.Original.Status.Hosted = cluster.Hypershift().Enabled()
+
+	if request.Original.Status.ClusterID == "" {
+		// machine pools may not be created until the cluster has been installed.  the cluster is not
+		// stored in the status so that it is retrieved again once the installation has finished.
+		if ocm.ClusterInstalling(cluster.State()) {
+			return fmt.Errorf("cluster state is [%s] - %w", cluster.State(), ErrClusterInstalling)
+		}
+
+		request.Original.Status.ClusterID = cluster.ID()
+		request.Original.Status.Hosted = cluster.Hypershift().Enabled()
+	}
+
+	if request.Original.Status.ClusterID == cluster.ID() {
+		request.Original.Status.AvailabilityZones = cluster.Nodes().AvailabilityZones()
+		request.Original.Status.Subnets = cluster.AWS().SubnetIDs()
+		request.Original.Status.Region = cluster.Region().ID()
+		request.Original.Status.CloudProvider = cluster.CloudProvider().ID()
+		request.Original.Status.Product = cluster.Product().ID()
+	}
 
 	// store the cluster id in the status
 	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
@@ -207,9 +456,438 @@ func (request *MachinePoolRequest) updateStatusCluster() error {
 	return nil
 }
 
+// updateStatusSchedule updates the name of the active schedule in the status.
+func (request *MachinePoolRequest) updateStatusSchedule() error {
+	var name string
+	if request.Schedule != nil {
+		name = request.Schedule.Name
+	}
+
+	// return if the active schedule is already stored in the status
+	if request.Original.Status.ActiveSchedule == name {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.ActiveSchedule = name
+
+	// store the active schedule in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.activeSchedule=%s - %w",
+			name,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// updateStatusReplicas updates the observed node counts in the status.
+func (request *MachinePoolRequest) updateStatusReplicas(replicas, readyReplicas int, ready bool) error {
+	// return if the observed node counts are already stored in the status
+	if request.Original.Status.Replicas == replicas &&
+		request.Original.Status.ReadyReplicas == readyReplicas &&
+		request.Original.Status.Ready == ready {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.Replicas = replicas
+	request.Original.Status.ReadyReplicas = readyReplicas
+	request.Original.Status.Ready = ready
+
+	// store the observed node counts in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.replicas=%d, status.readyReplicas=%d, status.ready=%t - %w",
+			replicas,
+			readyReplicas,
+			ready,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// updateStatusOCM updates the status with the state of the machine pool which was last reported
+// by OpenShift Cluster Manager.
+func (request *MachinePoolRequest) updateStatusOCM(id string, replicas int, message string) error {
+	// return if the reported state is already stored in the status
+	if request.Original.Status.MachinePoolID == id &&
+		request.Original.Status.OCMReplicas == replicas &&
+		request.Original.Status.OCMMessage == message {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.MachinePoolID = id
+	request.Original.Status.OCMReplicas = replicas
+	request.Original.Status.OCMMessage = message
+
+	// store the reported state in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.machinePoolID=%s, status.ocmReplicas=%d, status.ocmMessage=%s - %w",
+			id,
+			replicas,
+			message,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// autoRepairDrifted determines if auto-repair of the node pool in ocm differs from the desired state,
+// such as when it has been disabled manually.
+func (request *MachinePoolRequest) autoRepairDrifted() bool {
+	if request.Current == nil || request.Desired.Spec.AutoRepair == nil || request.Current.Spec.AutoRepair == nil {
+		return false
+	}
+
+	return *request.Desired.Spec.AutoRepair != *request.Current.Spec.AutoRepair
+}
+
+// upgradeBlockers returns the reasons that an upgrade of a node pool from its current version to a
+// desired version is unable to proceed.  An empty list is returned when the upgrade may be scheduled.
+func upgradeBlockers(current, desired string, cluster *clustersmgmtv1.Cluster, available []string) []string {
+	var blocked []string
+
+	if state := cluster.State(); state != clustersmgmtv1.ClusterStateReady {
+		blocked = append(blocked, fmt.Sprintf(upgradeBlockedClusterNotReady, state))
+	}
+
+	if controlPlane := ocm.RawVersion(cluster.Version()); ocm.IsNewerVersion(desired, controlPlane) {
+		blocked = append(blocked, fmt.Sprintf(upgradeBlockedControlPlane, desired, controlPlane))
+	}
+
+	if !ocm.IsZStreamUpgrade(current, desired) {
+		blocked = append(blocked, fmt.Sprintf(upgradeBlockedNotZStream, current, desired))
+	} else if !utils.ContainsString(available, desired) {
+		blocked = append(blocked, fmt.Sprintf(upgradeBlockedUnavailable, desired, current))
+	}
+
+	return blocked
+}
+
+// updateStatusBlockedReasons updates the reasons that the upgrade of the node pool is blocked.
+func (request *MachinePoolRequest) updateStatusBlockedReasons(blocked []string) error {
+	// return if the reasons are already stored in the status
+	if equality.Semantic.DeepEqual(request.Original.Status.BlockedReasons, blocked) {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.BlockedReasons = blocked
+
+	// store the reasons in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf("unable to update status.blockedReasons=%v - %w", blocked, err)
+	}
+
+	return nil
+}
+
+// updateStatusUpgrade stores the version of the node pool, and the progress of its upgrade, as
+// reported by ocm in the status.  A nil upgrade policy clears the progress of the upgrade.
+func (request *MachinePoolRequest) updateStatusUpgrade(version string, policy *ocm.NodePoolUpgradePolicy) error {
+	var upgrade *ocmv1alpha1.MachinePoolUpgradeStatus
+
+	if policy != nil {
+		// the time is truncated to the precision which is able to be stored in the status so that
+		// it may be compared against the stored value
+		nextRun := metav1.NewTime(policy.NextRun.Truncate(time.Second))
+		upgrade = &ocmv1alpha1.MachinePoolUpgradeStatus{
+			Version: policy.Version,
+			NextRun: &nextRun,
+		}
+
+		if policy.State != nil {
+			upgrade.State = policy.State.Value
+			upgrade.Description = policy.State.Description
+		}
+	}
+
+	// return if the reported state is already stored in the status
+	if request.Original.Status.Version == version && equality.Semantic.DeepEqual(request.Original.Status.Upgrade, upgrade) {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.Version = version
+	request.Original.Status.Upgrade = upgrade
+
+	// store the reported state in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.version=%s, status.upgrade=%+v - %w",
+			version,
+			upgrade,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// autoscalingCondition returns the condition which reflects the consistency of the autoscaling
+// configuration of the desired state with the cluster autoscaler.  A nil condition is returned
+// if the machine pool is not autoscaling.
+func (request *MachinePoolRequest) autoscalingCondition(maxNodesTotal int64, found bool) *metav1.Condition {
+	if request.Desired.Spec.MaximumNodesPerZone == 0 {
+		return nil
+	}
+
+	if !found {
+		return conditions.ClusterAutoscalerMissing()
+	}
+
+	// a cluster autoscaler which does not set a maximum does not limit the machine pool
+	if maxNodesTotal == 0 {
+		return conditions.AutoscalingConsistent()
+	}
+
+	zones := request.Original.AvailabilityZoneCount()
+	if zones < 1 {
+		zones = 1
+	}
+
+	maximum := int64(request.Desired.Spec.MaximumNodesPerZone * zones)
+	if maximum > maxNodesTotal {
+		return conditions.AutoscalingMaximumExceeded(maximum, maxNodesTotal)
+	}
+
+	return conditions.AutoscalingConsistent()
+}
+
+// computeNodes returns the maximum number of compute nodes of the cluster, excluding the machine pool
+// of the request.  These are the nodes of each other machine pool (or node pool for hosted control
+// plane clusters).
+func (request *MachinePoolRequest) computeNodes(cluster *clustersmgmtv1.Cluster) (int, error) {
+	var nodes int
+
+	if request.Original.Status.Hosted {
+		pools, err := ocm.NewNodePoolClient(request.Environment.Connection, request.Desired.Spec.DisplayName, cluster.ID()).
+			WithContext(request.Context).
+			List()
+		if err != nil {
+			return 0, fmt.Errorf("unable to list node pools from ocm - %w", err)
+		}
+
+		for _, pool := range pools {
+			if pool.ID() != request.Desired.Spec.DisplayName {
+				nodes += ocm.NodePoolMaximumReplicas(pool)
+			}
+		}
+
+		return nodes, nil
+	}
+
+	pools, err := ocm.NewMachinePoolClient(request.Environment.Connection, request.Desired.Spec.DisplayName, cluster.ID()).
+		WithContext(request.Context).
+		List()
+	if err != nil {
+		return 0, fmt.Errorf("unable to list machine pools from ocm - %w", err)
+	}
+
+	for _, pool := range pools {
+		if pool.ID() != request.Desired.Spec.DisplayName {
+			nodes += ocm.MachinePoolMaximumReplicas(pool)
+		}
+	}
+
+	return nodes, nil
+}
+
+// maximumReplicas returns the maximum number of nodes of the desired state of the machine pool.  The
+// availability zones of the cluster are taken from the original object, as they are only set on the
+// desired state once it is applied.
+func (request *MachinePoolRequest) maximumReplicas() int {
+	desired := request.Desired.DeepCopy()
+	desired.Status.AvailabilityZones = request.Original.Status.AvailabilityZones
+
+	return desired.MaximumReplicas()
+}
+
+// instanceTypeCondition returns the condition which reflects that the instance type of the machine pool
+// is unavailable to the cluster, either because OpenShift Cluster Manager does not offer it for the cloud
+// provider of the cluster, or because it is only offered for customer cloud subscription clusters.  A nil
+// condition is returned if the instance type is available.
+func (request *MachinePoolRequest) instanceTypeCondition(
+	cluster *clustersmgmtv1.Cluster,
+	machineType *clustersmgmtv1.MachineType,
+) *metav1.Condition {
+	if ocm.MachineTypeAvailable(machineType, cluster) {
+		return nil
+	}
+
+	return conditions.InstanceTypeUnavailable(request.Desired.Spec.InstanceType, cluster.CloudProvider().ID())
+}
+
+// capacityCondition returns the condition which reflects whether the maximum number of compute nodes of
+// a cluster fits within the maximum which the cluster supports, and whether the maximum number of nodes
+// of the cluster, including its control plane and infrastructure nodes, fits within the number of nodes
+// which the network of the cluster is able to allocate.  The pod cidr is checked before the machine cidr,
+// as it is typically exhausted first.
+func capacityCondition(cluster *clustersmgmtv1.Cluster, computeNodes int) *metav1.Condition {
+	if maximum := ocm.MaximumComputeNodes(cluster); computeNodes > maximum {
+		return conditions.ComputeNodesExceeded(computeNodes, maximum)
+	}
+
+	network, nodes := cluster.Network(), computeNodes+ocm.ControlPlaneNodes(cluster)
+
+	if capacity, ok := ocm.PodCIDRNodeCapacity(network); ok && nodes > capacity {
+		return conditions.CapacityExceeded(conditions.MachinePoolReasonPodCIDRExhausted, "pod cidr", nodes, capacity)
+	}
+
+	if capacity, ok := ocm.MachineCIDRNodeCapacity(network); ok && nodes > capacity {
+		return conditions.CapacityExceeded(conditions.MachinePoolReasonMachineCIDRExhausted, "machine cidr", nodes, capacity)
+	}
+
+	return conditions.CapacityAvailable()
+}
+
+// capabilityCondition returns the condition which reflects whether the features requested by the
+// machine pool are supported by the cluster.  A subnet may only be requested for a cluster which was
+// installed into an existing vpc without hosted control plane, as the local zone or outpost of the
+// subnet must be associated with the vpc of the cluster.
+func (request *MachinePoolRequest) capabilityCondition() *metav1.Condition {
+	if request.Original.Spec.AWS.Subnet != "" {
+		if request.Original.Status.Hosted {
+			return conditions.Unsupported(
+				conditionReasonSubnetUnsupported,
+				"aws subnets for local zones and outposts are not supported for clusters using hosted control plane",
+			)
+		}
+
+		if len(request.Original.Status.Subnets) == 0 {
+			return conditions.Unsupported(
+				conditionReasonSubnetUnsupported,
+				"aws subnets for local zones and outposts are only supported for clusters installed into an existing vpc",
+			)
+		}
+	}
+
+	if request.Original.Status.Hosted && request.Original.Spec.AWS.SpotInstances.Enabled {
+		return conditions.Unsupported(
+			conditionReasonSpotInstancesUnsupported,
+			"aws spot instances are not supported for clusters using hosted control plane and are ignored",
+		)
+	}
+
+	if request.Original.IsDefault() && request.Current != nil &&
+		request.Original.Spec.InstanceType != request.Current.Spec.InstanceType {
+		return conditions.Unsupported(
+			conditionReasonDefaultInstanceTypeUnsupported,
+			fmt.Sprintf(
+				"instance type of the default machine pool may only be chosen when the cluster is installed and is ignored [current=%s]",
+				request.Current.Spec.InstanceType,
+			),
+		)
+	}
+
+	if !request.Original.Status.Hosted && len(request.Original.Spec.AWS.Tags) > 0 {
+		return conditions.Unsupported(
+			conditionReasonAWSTagsUnsupported,
+			"aws tags are only supported for clusters using hosted control plane and are ignored",
+		)
+	}
+
+	if request.Original.Spec.RawOverrides != nil && !request.Reconciler.EnableRawOverrides {
+		return conditions.Unsupported(
+			conditionReasonRawOverridesDisabled,
+			"raw overrides are not enabled in the operator and are ignored",
+		)
+	}
+
+	return conditions.Supported()
+}
+
+// overrides returns the raw overrides which are merged into the requests which create and update the
+// machine pool.  No raw overrides are returned if they are not enabled in the operator.
+func (request *MachinePoolRequest) overrides() []byte {
+	if !request.Reconciler.EnableRawOverrides || request.Desired.Spec.RawOverrides == nil {
+		return nil
+	}
+
+	return request.Desired.Spec.RawOverrides.Raw
+}
+
+// overridesHash returns the hash of the raw overrides which are merged into the requests which create
+// and update the machine pool.  An empty hash is returned if there are no raw overrides.
+func (request *MachinePoolRequest) overridesHash() (string, error) {
+	overrides := request.overrides()
+	if overrides == nil {
+		return "", nil
+	}
+
+	return controllers.Fingerprint(overrides)
+}
+
+// updateStatusRawOverrides stores the hash of the raw overrides which have been applied in the status,
+// as the raw overrides may not be observed in OCM.
+func (request *MachinePoolRequest) updateStatusRawOverrides() error {
+	hash, err := request.overridesHash()
+	if err != nil {
+		return err
+	}
+
+	// return if the hash is already stored in the status
+	if request.Original.Status.RawOverridesHash == hash {
+		return nil
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status.RawOverridesHash = hash
+
+	// store the hash in the status
+	if err := kubernetes.PatchStatus(request.Context, request.Reconciler, original, request.Original); err != nil {
+		return fmt.Errorf("unable to update status.rawOverridesHash=%s - %w", hash, err)
+	}
+
+	return nil
+}
+
+// requeueInterval returns the interval in which the request should be reconciled again.  This
+// is the interval of the controller, unless the active schedule changes sooner.
+func (request *MachinePoolRequest) requeueInterval() time.Duration {
+	if request.NextScheduleTransition.IsZero() {
+		return request.Reconciler.Interval
+	}
+
+	if untilTransition := time.Until(request.NextScheduleTransition); untilTransition < request.Reconciler.Interval {
+		// ensure that we do not requeue immediately if the transition has already passed
+		if untilTransition < time.Second {
+			return time.Second
+		}
+
+		return untilTransition
+	}
+
+	return request.Reconciler.Interval
+}
+
 // createMachinePool creates a machine pool object in OCM.
 func (request *MachinePoolRequest) createMachinePool(poolClient *ocm.MachinePoolClient) error {
-	if _, err := poolClient.Create(request.Desired.MachinePoolBuilder()); err != nil {
+	builder := request.Desired.MachinePoolBuilder()
+
+	// the subnet of a machine pool may only be set when it is created
+	if request.Desired.Spec.AWS.Subnet != "" {
+		builder = builder.Subnets(request.Desired.Spec.AWS.Subnet)
+	}
+
+	_, err := poolClient.Create(builder)
+	request.recordOperation(ocmv1alpha1.OCMOperationCreate, poolClient.LastStatus(), err)
+
+	if err != nil {
 		return fmt.Errorf("unable to create machine pool - %w", err)
 	}
 
@@ -218,7 +896,18 @@ func (request *MachinePoolRequest) createMachinePool(poolClient *ocm.MachinePool
 
 // createNodePool creates a node pool object in OCM (hosted control plane).
 func (request *MachinePoolRequest) createNodePool(poolClient *ocm.NodePoolClient) error {
-	if _, err := poolClient.Create(request.Desired.NodePoolBuilder()); err != nil {
+	builder := request.Desired.NodePoolBuilder()
+
+	// the version is removed from the desired state as it is applied as an upgrade once the node
+	// pool exists, so it is requested directly when creating the node pool.
+	if request.Original.Spec.Version != "" {
+		builder = builder.Version(clustersmgmtv1.NewVersion().ID(ocm.VersionID(request.Original.Spec.Version)))
+	}
+
+	_, err := poolClient.Create(builder)
+	request.recordOperation(ocmv1alpha1.OCMOperationCreate, poolClient.LastStatus(), err)
+
+	if err != nil {
 		return fmt.Errorf("unable to create node pool - %w", err)
 	}
 
@@ -227,7 +916,10 @@ func (request *MachinePoolRequest) createNodePool(poolClient *ocm.NodePoolClient
 
 // updateMachinePool updates a machine pool object in OCM.
 func (request *MachinePoolRequest) updateMachinePool(poolClient *ocm.MachinePoolClient) error {
-	if _, err := poolClient.Update(request.Desired.MachinePoolBuilder()); err != nil {
+	_, err := poolClient.Update(request.Desired.MachinePoolBuilder())
+	request.recordOperation(ocmv1alpha1.OCMOperationUpdate, poolClient.LastStatus(), err)
+
+	if err != nil {
 		return fmt.Errorf("unable to update machine pool - %w", err)
 	}
 
@@ -236,7 +928,10 @@ func (request *MachinePoolRequest) updateMachinePool(poolClient *ocm.MachinePool
 
 // updateNodePool updates a node pool object in OCM.
 func (request *MachinePoolRequest) updateNodePool(poolClient *ocm.NodePoolClient) error {
-	if _, err := poolClient.Update(request.Desired.NodePoolBuilder()); err != nil {
+	_, err := poolClient.Update(request.Desired.NodePoolUpdateBuilder(request.Current))
+	request.recordOperation(ocmv1alpha1.OCMOperationUpdate, poolClient.LastStatus(), err)
+
+	if err != nil {
 		return fmt.Errorf("unable to update node pool - %w", err)
 	}
 
@@ -245,16 +940,55 @@ func (request *MachinePoolRequest) updateNodePool(poolClient *ocm.NodePoolClient
 
 // deleteMachinePool deletes a machine pool object in OCM.
 func (request *MachinePoolRequest) deleteMachinePool(poolClient *ocm.MachinePoolClient) error {
-	if err := poolClient.Delete(request.Desired.Spec.DisplayName); err != nil {
+	err := poolClient.Delete(request.Desired.Spec.DisplayName)
+	request.recordOperation(ocmv1alpha1.OCMOperationDelete, poolClient.LastStatus(), err)
+
+	if err != nil {
 		return fmt.Errorf("unable to update machine pool - %w", err)
 	}
 
 	return nil
 }
 
+// deprovisionProgress retrieves the machine pool or node pool from OCM after its deletion has been
+// requested, returning whether it still exists and a description of its progress.
+func (request *MachinePoolRequest) deprovisionProgress(poolClient interface{}) (exists bool, progress string, err error) {
+	if request.Original.Status.Hosted {
+		nodePool, err := poolClient.(*ocm.NodePoolClient).Get()
+		if err != nil {
+			return true, "", fmt.Errorf("unable to retrieve node pool - %w", err)
+		}
+
+		if nodePool == nil {
+			return false, "", nil
+		}
+
+		progress = fmt.Sprintf("%d nodes remaining", nodePool.Status().CurrentReplicas())
+		if message := nodePool.Status().Message(); message != "" {
+			progress = fmt.Sprintf("%s (%s)", progress, message)
+		}
+
+		return true, progress, nil
+	}
+
+	machinePool, err := poolClient.(*ocm.MachinePoolClient).Get()
+	if err != nil {
+		return true, "", fmt.Errorf("unable to retrieve machine pool - %w", err)
+	}
+
+	if machinePool == nil {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("%d nodes requested", machinePool.Replicas()), nil
+}
+
 // deleteNodePool deletes a node pool object in OCM.
 func (request *MachinePoolRequest) deleteNodePool(poolClient *ocm.NodePoolClient) error {
-	if err := poolClient.Delete(request.Desired.Spec.DisplayName); err != nil {
+	err := poolClient.Delete(request.Desired.Spec.DisplayName)
+	request.recordOperation(ocmv1alpha1.OCMOperationDelete, poolClient.LastStatus(), err)
+
+	if err != nil {
 		return fmt.Errorf("unable to delete node pool - %w", err)
 	}
 
@@ -1,11 +1,23 @@
 package machinepool
 
 import (
+	"context"
+	"reflect"
 	"testing"
+	"time"
 
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
 )
 
 func TestMachinePoolRequest_desired(t *testing.T) {
@@ -56,6 +68,92 @@ func TestMachinePoolRequest_desired(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "ensure reordered taints reflect desired state",
+			fields: fields{
+				Current: func() *ocmv1alpha1.MachinePool {
+					current := object.DeepCopy()
+					current.Spec.Taints = append(
+						[]corev1.Taint{{Key: "other", Value: "taint", Effect: corev1.TaintEffectNoExecute}},
+						current.Spec.Taints...,
+					)
+
+					return current
+				}(),
+				Desired: func() *ocmv1alpha1.MachinePool {
+					desired := object.DeepCopy()
+					desired.Spec.Taints = append(
+						desired.Spec.Taints,
+						corev1.Taint{Key: "other", Value: "taint", Effect: corev1.TaintEffectNoExecute},
+					)
+
+					return desired
+				}(),
+			},
+			want: true,
+		},
+		{
+			name: "ensure empty labels reflect desired state",
+			fields: fields{
+				Current: func() *ocmv1alpha1.MachinePool {
+					current := object.DeepCopy()
+					current.Spec.Labels = map[string]string{}
+
+					return current
+				}(),
+				Desired: func() *ocmv1alpha1.MachinePool {
+					desired := object.DeepCopy()
+					desired.Spec.Labels = nil
+
+					return desired
+				}(),
+			},
+			want: true,
+		},
+		{
+			name: "ensure changed aws tags do not reflect desired state",
+			fields: fields{
+				Current: object.DeepCopy(),
+				Desired: func() *ocmv1alpha1.MachinePool {
+					desired := object.DeepCopy()
+					desired.Spec.AWS.Tags = map[string]string{"cost-center": "1234"}
+
+					return desired
+				}(),
+			},
+			want: false,
+		},
+		{
+			name: "ensure an unknown subnet reflects desired state",
+			fields: fields{
+				Current: object.DeepCopy(),
+				Desired: func() *ocmv1alpha1.MachinePool {
+					desired := object.DeepCopy()
+					desired.Spec.AWS.Subnet = "subnet-0a"
+
+					return desired
+				}(),
+			},
+			want: true,
+		},
+		{
+			name: "ensure a different known subnet does not reflect desired state",
+			fields: fields{
+				Current: func() *ocmv1alpha1.MachinePool {
+					current := object.DeepCopy()
+					current.Spec.AWS.Subnet = "subnet-0b"
+
+					return current
+				}(),
+				Desired: func() *ocmv1alpha1.MachinePool {
+					desired := object.DeepCopy()
+					desired.Spec.AWS.Subnet = "subnet-0a"
+
+					return desired
+				}(),
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -72,3 +170,656 @@ func TestMachinePoolRequest_desired(t *testing.T) {
 		})
 	}
 }
+
+func TestMachinePoolRequest_autoscalingCondition(t *testing.T) {
+	t.Parallel()
+
+	object := &ocmv1alpha1.MachinePool{
+		Spec: ocmv1alpha1.MachinePoolSpec{
+			MinimumNodesPerZone: 1,
+			MaximumNodesPerZone: 3,
+		},
+		Status: ocmv1alpha1.MachinePoolStatus{
+			AvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+		},
+	}
+
+	static := object.DeepCopy()
+	static.Spec.MaximumNodesPerZone = 0
+
+	subnet := object.DeepCopy()
+	subnet.Spec.AWS.Subnet = "subnet-0a"
+
+	tests := []struct {
+		name          string
+		object        *ocmv1alpha1.MachinePool
+		maxNodesTotal int64
+		found         bool
+		wantNil       bool
+		wantReason    string
+	}{
+		{
+			name:    "ensure a machine pool which is not autoscaling returns no condition",
+			object:  static,
+			found:   false,
+			wantNil: true,
+		},
+		{
+			name:       "ensure a missing cluster autoscaler is reported",
+			object:     object,
+			found:      false,
+			wantReason: conditions.MachinePoolReasonClusterAutoscalerMissing,
+		},
+		{
+			name:          "ensure a maximum exceeding the cluster limit is reported",
+			object:        object,
+			maxNodesTotal: 8,
+			found:         true,
+			wantReason:    conditions.MachinePoolReasonMaximumExceedsClusterLimit,
+		},
+		{
+			name:          "ensure a maximum within the cluster limit is consistent",
+			object:        object,
+			maxNodesTotal: 9,
+			found:         true,
+			wantReason:    conditions.AutoscalingConsistent().Reason,
+		},
+		{
+			name:          "ensure a machine pool in a subnet only counts a single availability zone",
+			object:        subnet,
+			maxNodesTotal: 3,
+			found:         true,
+			wantReason:    conditions.AutoscalingConsistent().Reason,
+		},
+		{
+			name:          "ensure a cluster autoscaler without a limit is consistent",
+			object:        object,
+			maxNodesTotal: 0,
+			found:         true,
+			wantReason:    conditions.AutoscalingConsistent().Reason,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := &MachinePoolRequest{
+				Original: tt.object,
+				Desired:  tt.object,
+			}
+			got := request.autoscalingCondition(tt.maxNodesTotal, tt.found)
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("MachinePoolRequest.autoscalingCondition() = %v, wantNil %v", got, tt.wantNil)
+			}
+			if got != nil && got.Reason != tt.wantReason {
+				t.Errorf("MachinePoolRequest.autoscalingCondition() reason = %v, want %v", got.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestMachinePoolRequest_capabilityCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		hosted      bool
+		subnets     []string
+		displayName string
+		current     *ocmv1alpha1.MachinePool
+		aws         ocmv1alpha1.MachinePoolProviderAWS
+		overrides   *apiextensionsv1.JSON
+		enabled     bool
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+	}{
+		{
+			name:       "ensure a classic machine pool without unsupported features is supported",
+			hosted:     false,
+			aws:        ocmv1alpha1.MachinePoolProviderAWS{SpotInstances: ocmv1alpha1.MachinePoolProviderAWSSpotInstances{Enabled: true}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.Supported().Reason,
+		},
+		{
+			name:       "ensure spot instances are unsupported for hosted control plane",
+			hosted:     true,
+			aws:        ocmv1alpha1.MachinePoolProviderAWS{SpotInstances: ocmv1alpha1.MachinePoolProviderAWSSpotInstances{Enabled: true}},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditionReasonSpotInstancesUnsupported,
+		},
+		{
+			name:       "ensure aws tags are unsupported without hosted control plane",
+			hosted:     false,
+			aws:        ocmv1alpha1.MachinePoolProviderAWS{Tags: map[string]string{"team": "platform"}},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditionReasonAWSTagsUnsupported,
+		},
+		{
+			name:       "ensure aws tags are supported for hosted control plane",
+			hosted:     true,
+			aws:        ocmv1alpha1.MachinePoolProviderAWS{Tags: map[string]string{"team": "platform"}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.Supported().Reason,
+		},
+		{
+			name:       "ensure a subnet is supported for a cluster in an existing vpc",
+			hosted:     false,
+			subnets:    []string{"subnet-0a", "subnet-0b"},
+			aws:        ocmv1alpha1.MachinePoolProviderAWS{Subnet: "subnet-0c"},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.Supported().Reason,
+		},
+		{
+			name:       "ensure a subnet is unsupported for a cluster without an existing vpc",
+			hosted:     false,
+			aws:        ocmv1alpha1.MachinePoolProviderAWS{Subnet: "subnet-0c"},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditionReasonSubnetUnsupported,
+		},
+		{
+			name:       "ensure a subnet is unsupported for hosted control plane",
+			hosted:     true,
+			subnets:    []string{"subnet-0a"},
+			aws:        ocmv1alpha1.MachinePoolProviderAWS{Subnet: "subnet-0c"},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditionReasonSubnetUnsupported,
+		},
+		{
+			name:        "ensure the instance type of the default machine pool is unsupported",
+			hosted:      false,
+			displayName: ocm.DefaultMachinePoolName,
+			current:     &ocmv1alpha1.MachinePool{Spec: ocmv1alpha1.MachinePoolSpec{InstanceType: "m5.2xlarge"}},
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  conditionReasonDefaultInstanceTypeUnsupported,
+		},
+		{
+			name:        "ensure the current instance type of the default machine pool is supported",
+			hosted:      false,
+			displayName: ocm.DefaultMachinePoolName,
+			current:     &ocmv1alpha1.MachinePool{Spec: ocmv1alpha1.MachinePoolSpec{InstanceType: "m5.xlarge"}},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  conditions.Supported().Reason,
+		},
+		{
+			name:        "ensure a node pool named after the default machine pool is not the default",
+			hosted:      true,
+			displayName: ocm.DefaultMachinePoolName,
+			current:     &ocmv1alpha1.MachinePool{Spec: ocmv1alpha1.MachinePoolSpec{InstanceType: "m5.2xlarge"}},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  conditions.Supported().Reason,
+		},
+		{
+			name:       "ensure raw overrides are unsupported when they are not enabled",
+			overrides:  &apiextensionsv1.JSON{Raw: []byte(`{"aws":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`)},
+			enabled:    false,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditionReasonRawOverridesDisabled,
+		},
+		{
+			name:       "ensure raw overrides are supported when they are enabled",
+			overrides:  &apiextensionsv1.JSON{Raw: []byte(`{"aws":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`)},
+			enabled:    true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.Supported().Reason,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := &MachinePoolRequest{
+				Original: &ocmv1alpha1.MachinePool{
+					Spec: ocmv1alpha1.MachinePoolSpec{
+						DisplayName:  tt.displayName,
+						InstanceType: "m5.xlarge",
+						AWS:          tt.aws,
+						RawOverrides: tt.overrides,
+					},
+					Status: ocmv1alpha1.MachinePoolStatus{Hosted: tt.hosted, Subnets: tt.subnets},
+				},
+				Current:    tt.current,
+				Reconciler: &Controller{EnableRawOverrides: tt.enabled},
+			}
+			got := request.capabilityCondition()
+			if got.Status != tt.wantStatus {
+				t.Errorf("MachinePoolRequest.capabilityCondition() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if got.Reason != tt.wantReason {
+				t.Errorf("MachinePoolRequest.capabilityCondition() reason = %v, want %v", got.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestMachinePoolRequest_isDefault(t *testing.T) {
+	t.Parallel()
+
+	testCluster := func(hosted bool) *clustersmgmtv1.Cluster {
+		cluster, err := clustersmgmtv1.NewCluster().Hypershift(clustersmgmtv1.NewHypershift().Enabled(hosted)).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		return cluster
+	}
+
+	tests := []struct {
+		name        string
+		displayName string
+		status      ocmv1alpha1.MachinePoolStatus
+		cluster     *clustersmgmtv1.Cluster
+		want        bool
+	}{
+		{
+			name:        "ensure a machine pool with another name is not the default",
+			displayName: "workers",
+			want:        false,
+		},
+		{
+			name:        "ensure the recorded status of a classic cluster is the default",
+			displayName: ocm.DefaultMachinePoolName,
+			status:      ocmv1alpha1.MachinePoolStatus{ClusterID: "test"},
+			want:        true,
+		},
+		{
+			name:        "ensure the recorded status of a hosted cluster is not the default",
+			displayName: ocm.DefaultMachinePoolName,
+			status:      ocmv1alpha1.MachinePoolStatus{ClusterID: "test", Hosted: true},
+			want:        false,
+		},
+		{
+			name:        "ensure an unrecorded hosted cluster is resolved and is not the default",
+			displayName: ocm.DefaultMachinePoolName,
+			cluster:     testCluster(true),
+			want:        false,
+		},
+		{
+			name:        "ensure an unrecorded classic cluster is resolved and is the default",
+			displayName: ocm.DefaultMachinePoolName,
+			cluster:     testCluster(false),
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			request := &MachinePoolRequest{
+				Original: &ocmv1alpha1.MachinePool{
+					Spec:   ocmv1alpha1.MachinePoolSpec{DisplayName: tt.displayName},
+					Status: tt.status,
+				},
+				Cluster: tt.cluster,
+			}
+
+			got, err := request.isDefault()
+			if err != nil {
+				t.Fatalf("MachinePoolRequest.isDefault() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("MachinePoolRequest.isDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_currentSubnet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		requested string
+		subnets   []string
+		want      string
+	}{
+		{
+			name:    "ensure the subnet is unknown when no subnet is requested",
+			subnets: []string{"subnet-0a"},
+			want:    "",
+		},
+		{
+			name:      "ensure the requested subnet is known when it is reported",
+			requested: "subnet-0a",
+			subnets:   []string{"subnet-0a", "subnet-0b"},
+			want:      "subnet-0a",
+		},
+		{
+			name:      "ensure the only reported subnet is known",
+			requested: "subnet-0a",
+			subnets:   []string{"subnet-0b"},
+			want:      "subnet-0b",
+		},
+		{
+			name:      "ensure the subnet is unknown when several other subnets are reported",
+			requested: "subnet-0a",
+			subnets:   []string{"subnet-0b", "subnet-0c"},
+			want:      "",
+		},
+		{
+			name:      "ensure the subnet is unknown when no subnets are reported",
+			requested: "subnet-0a",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := currentSubnet(tt.requested, tt.subnets); got != tt.want {
+				t.Errorf("currentSubnet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_upgradeBlockers(t *testing.T) {
+	t.Parallel()
+
+	testCluster := func(state clustersmgmtv1.ClusterState, version string) *clustersmgmtv1.Cluster {
+		cluster, err := clustersmgmtv1.NewCluster().
+			State(state).
+			Version(clustersmgmtv1.NewVersion().RawID(version)).
+			Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		return cluster
+	}
+
+	tests := []struct {
+		name      string
+		current   string
+		desired   string
+		cluster   *clustersmgmtv1.Cluster
+		available []string
+		want      []string
+	}{
+		{
+			name:      "ensure an available z-stream upgrade of a ready cluster is not blocked",
+			current:   "4.14.3",
+			desired:   "4.14.5",
+			cluster:   testCluster(clustersmgmtv1.ClusterStateReady, "4.14.5"),
+			available: []string{"4.14.5"},
+			want:      nil,
+		},
+		{
+			name:      "ensure a cluster which is not ready blocks the upgrade",
+			current:   "4.14.3",
+			desired:   "4.14.5",
+			cluster:   testCluster(clustersmgmtv1.ClusterStateHibernating, "4.14.5"),
+			available: []string{"4.14.5"},
+			want:      []string{"cluster is not ready [state=hibernating]"},
+		},
+		{
+			name:      "ensure a version newer than the control plane blocks the upgrade",
+			current:   "4.14.3",
+			desired:   "4.14.5",
+			cluster:   testCluster(clustersmgmtv1.ClusterStateReady, "4.14.4"),
+			available: []string{"4.14.5"},
+			want:      []string{"version [4.14.5] is newer than the control plane version [4.14.4]"},
+		},
+		{
+			name:      "ensure each blocker is reported",
+			current:   "4.14.3",
+			desired:   "4.15.0",
+			cluster:   testCluster(clustersmgmtv1.ClusterStateInstalling, "4.14.5"),
+			available: []string{"4.15.0"},
+			want: []string{
+				"cluster is not ready [state=installing]",
+				"version [4.15.0] is newer than the control plane version [4.14.5]",
+				"upgrade from [4.14.3] to [4.15.0] is not a z-stream upgrade",
+			},
+		},
+		{
+			name:      "ensure a version which is not an available upgrade blocks the upgrade",
+			current:   "4.14.3",
+			desired:   "4.14.5",
+			cluster:   testCluster(clustersmgmtv1.ClusterStateReady, "4.14.5"),
+			available: []string{"4.14.4"},
+			want:      []string{"version [4.14.5] is not an available upgrade of version [4.14.3]"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := upgradeBlockers(tt.current, tt.desired, tt.cluster, tt.available); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("upgradeBlockers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testCapacityCluster(t *testing.T, hosted bool, network *clustersmgmtv1.NetworkBuilder) *clustersmgmtv1.Cluster {
+	t.Helper()
+
+	cluster, err := clustersmgmtv1.NewCluster().
+		Hypershift(clustersmgmtv1.NewHypershift().Enabled(hosted)).
+		Nodes(clustersmgmtv1.NewClusterNodes().Master(3).Infra(2)).
+		Network(network).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	return cluster
+}
+
+func Test_capacityCondition(t *testing.T) {
+	t.Parallel()
+
+	network := clustersmgmtv1.NewNetwork().
+		MachineCIDR("10.0.0.0/24").
+		PodCIDR("10.128.0.0/16").
+		HostPrefix(23)
+
+	tests := []struct {
+		name       string
+		cluster    *clustersmgmtv1.Cluster
+		nodes      int
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "ensure nodes within the capacity of the network are available",
+			cluster:    testCapacityCluster(t, false, network),
+			nodes:      123,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditions.CapacityAvailable().Reason,
+		},
+		{
+			name:       "ensure control plane and infrastructure nodes count against the capacity of the pod cidr",
+			cluster:    testCapacityCluster(t, false, network),
+			nodes:      124,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.MachinePoolReasonPodCIDRExhausted,
+		},
+		{
+			name:       "ensure hosted control plane nodes do not count against the capacity of the pod cidr",
+			cluster:    testCapacityCluster(t, true, network),
+			nodes:      128,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditions.CapacityAvailable().Reason,
+		},
+		{
+			name: "ensure compute nodes beyond the maximum of the cluster are exceeded",
+			cluster: testCapacityCluster(t, false, clustersmgmtv1.NewNetwork().
+				MachineCIDR("10.0.0.0/16").
+				PodCIDR("10.128.0.0/14").
+				HostPrefix(23),
+			),
+			nodes:      ocm.MaximumClassicComputeNodes + 1,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.MachinePoolReasonComputeNodesExceeded,
+		},
+		{
+			name: "ensure the machine cidr is exhausted when the pod cidr has spare capacity",
+			cluster: testCapacityCluster(t, true, clustersmgmtv1.NewNetwork().
+				MachineCIDR("10.0.0.0/28").
+				PodCIDR("10.128.0.0/14").
+				HostPrefix(23),
+			),
+			nodes:      17,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.MachinePoolReasonMachineCIDRExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := capacityCondition(tt.cluster, tt.nodes)
+			if got.Status != tt.wantStatus || got.Reason != tt.wantReason {
+				t.Errorf("capacityCondition() = %v/%v, want %v/%v", got.Status, got.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestMachinePoolRequest_instanceTypeCondition(t *testing.T) {
+	t.Parallel()
+
+	build := func(ccsOnly bool) *clustersmgmtv1.MachineType {
+		machineType, err := clustersmgmtv1.NewMachineType().ID("m5.xlarge").CCSOnly(ccsOnly).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		return machineType
+	}
+
+	cluster := func(ccs bool) *clustersmgmtv1.Cluster {
+		cluster, err := clustersmgmtv1.NewCluster().
+			CloudProvider(clustersmgmtv1.NewCloudProvider().ID("aws")).
+			CCS(clustersmgmtv1.NewCCS().Enabled(ccs)).
+			Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		return cluster
+	}
+
+	tests := []struct {
+		name        string
+		cluster     *clustersmgmtv1.Cluster
+		machineType *clustersmgmtv1.MachineType
+		want        bool
+	}{
+		{
+			name:        "ensure an offered instance type is available",
+			cluster:     cluster(false),
+			machineType: build(false),
+			want:        true,
+		},
+		{
+			name:    "ensure an instance type which is not offered is unavailable",
+			cluster: cluster(true),
+			want:    false,
+		},
+		{
+			name:        "ensure a customer cloud subscription instance type is unavailable to other clusters",
+			cluster:     cluster(false),
+			machineType: build(true),
+			want:        false,
+		},
+		{
+			name:        "ensure a customer cloud subscription instance type is available to customer cloud subscription clusters",
+			cluster:     cluster(true),
+			machineType: build(true),
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := &MachinePoolRequest{
+				Desired: &ocmv1alpha1.MachinePool{Spec: ocmv1alpha1.MachinePoolSpec{InstanceType: "m5.xlarge"}},
+			}
+
+			got := request.instanceTypeCondition(tt.cluster, tt.machineType)
+			if (got == nil) != tt.want {
+				t.Errorf("instanceTypeCondition() = %v, want available %v", got, tt.want)
+			}
+
+			if got != nil && got.Reason != conditions.MachinePoolReasonInstanceTypeUnavailable {
+				t.Errorf("instanceTypeCondition() reason = %v, want %v", got.Reason, conditions.MachinePoolReasonInstanceTypeUnavailable)
+			}
+		})
+	}
+}
+
+func TestController_NewRequest_Schedules(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := ocmv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	now := metav1.Now()
+
+	tests := []struct {
+		name              string
+		deletionTimestamp *metav1.Time
+		wantScheduleErr   bool
+	}{
+		{
+			name:            "ensure an invalid schedule is reported rather than failing the request",
+			wantScheduleErr: true,
+		},
+		{
+			name:              "ensure the schedules of a machine pool which is being deleted are not evaluated",
+			deletionTimestamp: &now,
+			wantScheduleErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := &ocmv1alpha1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "test",
+					Name:              "test",
+					DeletionTimestamp: tt.deletionTimestamp,
+					Finalizers:        []string{"test"},
+				},
+			}
+			pool.Spec.ClusterName = "test"
+			pool.Spec.Schedules = []ocmv1alpha1.MachinePoolSchedule{
+				{Name: "nights", Start: "0 20 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+			}
+
+			r := &Controller{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool).Build()}
+
+			request, err := r.NewRequest(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "test"}})
+			if err != nil {
+				t.Fatalf("Controller.NewRequest() error = %v", err)
+			}
+
+			machinePoolRequest, ok := request.(*MachinePoolRequest)
+			if !ok {
+				t.Fatalf("Controller.NewRequest() = %T, want %T", request, &MachinePoolRequest{})
+			}
+
+			if (machinePoolRequest.ScheduleError != nil) != tt.wantScheduleErr {
+				t.Errorf("Controller.NewRequest() schedule error = %v, want %v", machinePoolRequest.ScheduleError, tt.wantScheduleErr)
+			}
+		})
+	}
+}
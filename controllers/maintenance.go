@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	// DefaultMaintenanceCheckInterval is the default interval at which OpenShift Cluster Manager is
+	// checked for an announcement of maintenance by the maintenance monitor.
+	DefaultMaintenanceCheckInterval = time.Minute
+
+	// DefaultMaintenanceBackoff is the amount of time after which the reconciliation of an object is
+	// retried while OpenShift Cluster Manager is under maintenance.
+	DefaultMaintenanceBackoff = 5 * time.Minute
+)
+
+// Maintained represents a controller which backs off while the environment of OpenShift Cluster Manager
+// which an object targets is under maintenance.  Objects are not reconciled during the maintenance, so
+// that no changes are sent to OpenShift Cluster Manager and the errors which would result are not
+// reported as failures of the objects.
+type Maintained interface {
+	GetMaintenance(object Workload) *ocm.Maintenance
+}
+
+// maintenanceFor returns the maintenance of the environment which an object of a controller targets.  A
+// controller which does not back off during maintenance returns a nil maintenance, which is never active.
+func maintenanceFor(controller Controller, object Workload) *ocm.Maintenance {
+	if maintained, ok := controller.(Maintained); ok {
+		return maintained.GetMaintenance(object)
+	}
+
+	return nil
+}
+
+// MaintenanceMonitor periodically checks whether an environment of OpenShift Cluster Manager has
+// announced maintenance and records it, so that the controllers back off the objects which target the
+// environment while the maintenance is active.  A reconciliation of all objects is broadcast once the
+// maintenance has finished.
+type MaintenanceMonitor struct {
+	// Environment is the name of the environment which is checked for maintenance.
+	Environment string
+
+	Connection  *sdk.Connection
+	Broadcaster *Broadcaster
+	Log         logr.Logger
+	Interval    time.Duration
+
+	// Services are the full names of the services in the status board of OpenShift Cluster Manager
+	// which are checked for maintenance.  Only the API of OpenShift Cluster Manager is checked if
+	// this is empty.
+	Services []string
+
+	// Maintenance records whether maintenance is active.
+	Maintenance *ocm.Maintenance
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.  Maintenance is checked
+// by every replica of the operator, so that a replica which becomes the leader during the maintenance
+// backs off immediately.
+func (monitor *MaintenanceMonitor) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements the manager.Runnable interface.  It checks for maintenance on startup and at each
+// interval until the context is cancelled.
+func (monitor *MaintenanceMonitor) Start(ctx context.Context) error {
+	interval := monitor.Interval
+	if interval == 0 {
+		interval = DefaultMaintenanceCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		active, message, err := ocm.ProbeMaintenance(ctx, monitor.Connection, monitor.Services...)
+		if err != nil {
+			monitor.Log.Error(err, "unable to check openshift cluster manager for maintenance", "environment", monitor.Environment)
+		} else if monitor.Observe(active, message) {
+			monitor.Broadcaster.Broadcast()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Observe records whether maintenance is active, logging when the maintenance starts or finishes.  It
+// returns whether the maintenance has finished since the previous check, and so whether all objects
+// should be reconciled.
+func (monitor *MaintenanceMonitor) Observe(active bool, message string) bool {
+	if !monitor.Maintenance.Set(active, message) {
+		return false
+	}
+
+	if active {
+		monitor.Log.Info(
+			"openshift cluster manager is under maintenance; backing off",
+			"environment", monitor.Environment,
+			"announcement", message,
+		)
+
+		return false
+	}
+
+	monitor.Log.Info("openshift cluster manager maintenance has finished; reconciling all objects", "environment", monitor.Environment)
+
+	return true
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+func TestMaintenanceMonitor_Observe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		checks []bool
+		want   []bool
+	}{
+		{
+			name:   "ensure an available api does not broadcast",
+			checks: []bool{false, false},
+			want:   []bool{false, false},
+		},
+		{
+			name:   "ensure a finished maintenance broadcasts once",
+			checks: []bool{true, true, false, false},
+			want:   []bool{false, false, true, false},
+		},
+		{
+			name:   "ensure each finished maintenance broadcasts",
+			checks: []bool{true, false, true, false},
+			want:   []bool{false, true, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			monitor := &MaintenanceMonitor{Log: logr.Discard(), Maintenance: &ocm.Maintenance{}}
+
+			for i, check := range tt.checks {
+				if got := monitor.Observe(check, "ocm api is unavailable"); got != tt.want[i] {
+					t.Errorf("Observe() check %d = %v, want %v", i, got, tt.want[i])
+				}
+
+				if active, _ := monitor.Maintenance.Active(); active != check {
+					t.Errorf("Maintenance.Active() check %d = %v, want %v", i, active, check)
+				}
+			}
+		})
+	}
+}
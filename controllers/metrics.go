@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// ObjectReconcileTotalMetric is the name of the metric which counts the reconciliations of each
+	// object, by the result of the reconciliation.
+	ObjectReconcileTotalMetric = "ocm_object_reconcile_total"
+
+	// ObjectConsecutiveFailuresMetric is the name of the metric which exposes the number of times in
+	// a row that the reconciliation of each object has failed.
+	ObjectConsecutiveFailuresMetric = "ocm_object_reconcile_consecutive_failures"
+
+	// ReconcileResultSuccess and ReconcileResultError are the results by which the reconciliations of
+	// an object are counted.
+	ReconcileResultSuccess = "success"
+	ReconcileResultError   = "error"
+)
+
+// Metered represents a controller which records the reconciliations of each of its objects.
+type Metered interface {
+	GetObjectMetrics() *ObjectMetrics
+}
+
+// ObjectMetrics records the reconciliations and consecutive failures of each object, so that noisy or
+// stuck objects in a large fleet may be identified from monitoring.  Objects are labeled by their
+// namespace and name, unless the metrics are hashed into a fixed number of buckets, which bounds the
+// number of series regardless of the number of objects.  The consecutive failures are counted for each
+// object, even when the objects are hashed, and the consecutive failures of a hash bucket are those of
+// its object which has failed the most times in a row, so that an object which succeeds does not reset
+// the failures of another object in the same bucket.
+type ObjectMetrics struct {
+	hashBuckets int
+
+	total    *prometheus.CounterVec
+	failures *prometheus.GaugeVec
+
+	// consecutive is the number of consecutive failures of each object which is failing, by the
+	// controller and object label of the series which it is exposed in.
+	consecutive map[objectSeries]map[types.NamespacedName]int
+	mutex       sync.Mutex
+}
+
+// objectSeries identifies the series of the metrics in which an object is exposed.
+type objectSeries struct {
+	controller string
+	object     string
+}
+
+// NewObjectMetrics returns object metrics which are registered with a registerer.  Objects are hashed
+// into a number of buckets when hash buckets is greater than 0.
+func NewObjectMetrics(registerer prometheus.Registerer, hashBuckets int) (*ObjectMetrics, error) {
+	metrics := &ObjectMetrics{
+		hashBuckets: hashBuckets,
+		consecutive: map[objectSeries]map[types.NamespacedName]int{},
+		total: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: ObjectReconcileTotalMetric,
+				Help: "Number of reconciliations of an object, partitioned by controller, object and result.",
+			},
+			[]string{"controller", "object", "result"},
+		),
+		failures: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: ObjectConsecutiveFailuresMetric,
+				Help: "Number of consecutive failed reconciliations of an object, partitioned by controller and object.",
+			},
+			[]string{"controller", "object"},
+		),
+	}
+
+	for _, collector := range []prometheus.Collector{metrics.total, metrics.failures} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("unable to register object reconcile metrics - %w", err)
+		}
+	}
+
+	return metrics, nil
+}
+
+// Object returns the value of the object label of an object.  It is the namespace and name of the
+// object, or its hash bucket when the metrics are hashed.
+func (metrics *ObjectMetrics) Object(req ctrl.Request) string {
+	if metrics.hashBuckets <= 0 {
+		return req.NamespacedName.String()
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(req.NamespacedName.String()))
+
+	return fmt.Sprintf("bucket-%d", hash.Sum32()%uint32(metrics.hashBuckets))
+}
+
+// Observe records the result of a reconciliation of an object.
+func (metrics *ObjectMetrics) Observe(controller string, req ctrl.Request, err error) {
+	if metrics == nil {
+		return
+	}
+
+	series := objectSeries{controller: controller, object: metrics.Object(req)}
+
+	if err != nil {
+		metrics.total.WithLabelValues(series.controller, series.object, ReconcileResultError).Inc()
+	} else {
+		metrics.total.WithLabelValues(series.controller, series.object, ReconcileResultSuccess).Inc()
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if err != nil {
+		metrics.count(series, req.NamespacedName)
+	} else {
+		metrics.reset(series, req.NamespacedName)
+	}
+
+	metrics.failures.WithLabelValues(series.controller, series.object).Set(float64(metrics.highest(series)))
+}
+
+// Forget removes the series of an object which no longer exists.  The series of a hash bucket are
+// shared with other objects, so they are left in place, with the consecutive failures of the object
+// no longer counted towards the bucket.
+func (metrics *ObjectMetrics) Forget(controller string, req ctrl.Request) {
+	if metrics == nil {
+		return
+	}
+
+	series := objectSeries{controller: controller, object: metrics.Object(req)}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.reset(series, req.NamespacedName)
+
+	if metrics.hashBuckets > 0 {
+		metrics.failures.WithLabelValues(series.controller, series.object).Set(float64(metrics.highest(series)))
+
+		return
+	}
+
+	labels := prometheus.Labels{"controller": series.controller, "object": series.object}
+
+	metrics.total.DeletePartialMatch(labels)
+	metrics.failures.DeletePartialMatch(labels)
+}
+
+// count counts a failed reconciliation of an object.  The mutex must be held.
+func (metrics *ObjectMetrics) count(series objectSeries, name types.NamespacedName) {
+	if metrics.consecutive[series] == nil {
+		metrics.consecutive[series] = map[types.NamespacedName]int{}
+	}
+
+	metrics.consecutive[series][name]++
+}
+
+// reset resets the consecutive failures of an object, so that only objects which are failing are
+// retained.  The mutex must be held.
+func (metrics *ObjectMetrics) reset(series objectSeries, name types.NamespacedName) {
+	delete(metrics.consecutive[series], name)
+
+	if len(metrics.consecutive[series]) == 0 {
+		delete(metrics.consecutive, series)
+	}
+}
+
+// highest returns the consecutive failures of the object of a series which has failed the most times in
+// a row.  The mutex must be held.
+func (metrics *ObjectMetrics) highest(series objectSeries) int {
+	highest := 0
+
+	for _, failures := range metrics.consecutive[series] {
+		if failures > highest {
+			highest = failures
+		}
+	}
+
+	return highest
+}
+
+// objectMetricsFor returns the object metrics of a controller, or nil if the controller does not
+// record the reconciliations of its objects.
+func objectMetricsFor(controller Controller) *ObjectMetrics {
+	if metered, ok := controller.(Metered); ok {
+		return metered.GetObjectMetrics()
+	}
+
+	return nil
+}
+
+// ControllerName returns the name by which a controller is labeled in metrics, which is the name of
+// the package of the controller (e.g. machinepool).
+func ControllerName(controller Controller) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", controller), "*")
+
+	if index := strings.Index(name, "."); index > 0 {
+		return name[:index]
+	}
+
+	return name
+}
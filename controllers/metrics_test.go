@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var errTestReconcile = errors.New("reconcile failed")
+
+func TestObjectMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics, err := NewObjectMetrics(prometheus.NewRegistry(), 0)
+	if err != nil {
+		t.Fatalf("NewObjectMetrics() error = %v", err)
+	}
+
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "ocm", Name: "pool"}}
+
+	metrics.Observe("machinepool", req, errTestReconcile)
+	metrics.Observe("machinepool", req, errTestReconcile)
+
+	if got := testutil.ToFloat64(metrics.failures.WithLabelValues("machinepool", "ocm/pool")); got != 2 {
+		t.Errorf("ObjectMetrics.Observe() consecutive failures = %v, want %v", got, 2)
+	}
+
+	metrics.Observe("machinepool", req, nil)
+
+	if got := testutil.ToFloat64(metrics.failures.WithLabelValues("machinepool", "ocm/pool")); got != 0 {
+		t.Errorf("ObjectMetrics.Observe() consecutive failures = %v, want %v", got, 0)
+	}
+
+	if got := testutil.ToFloat64(metrics.total.WithLabelValues("machinepool", "ocm/pool", ReconcileResultError)); got != 2 {
+		t.Errorf("ObjectMetrics.Observe() errors = %v, want %v", got, 2)
+	}
+
+	metrics.Forget("machinepool", req)
+
+	if got := testutil.CollectAndCount(metrics.total) + testutil.CollectAndCount(metrics.failures); got != 0 {
+		t.Errorf("ObjectMetrics.Forget() series = %v, want %v", got, 0)
+	}
+
+	// a nil set of object metrics records nothing
+	var unset *ObjectMetrics
+	unset.Observe("machinepool", req, nil)
+	unset.Forget("machinepool", req)
+}
+
+func TestObjectMetrics_Hashed(t *testing.T) {
+	t.Parallel()
+
+	metrics, err := NewObjectMetrics(prometheus.NewRegistry(), 1)
+	if err != nil {
+		t.Fatalf("NewObjectMetrics() error = %v", err)
+	}
+
+	failing := ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "ocm", Name: "failing"}}
+	healthy := ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "ocm", Name: "healthy"}}
+	bucket := metrics.failures.WithLabelValues("machinepool", metrics.Object(failing))
+
+	metrics.Observe("machinepool", failing, errTestReconcile)
+	metrics.Observe("machinepool", failing, errTestReconcile)
+	metrics.Observe("machinepool", healthy, errTestReconcile)
+	metrics.Observe("machinepool", healthy, nil)
+
+	// the success of an object does not reset the failures of another object in the same bucket
+	if got := testutil.ToFloat64(bucket); got != 2 {
+		t.Errorf("ObjectMetrics.Observe() consecutive failures = %v, want %v", got, 2)
+	}
+
+	metrics.Forget("machinepool", failing)
+
+	if got := testutil.ToFloat64(bucket); got != 0 {
+		t.Errorf("ObjectMetrics.Forget() consecutive failures = %v, want %v", got, 0)
+	}
+
+	if got := len(metrics.consecutive); got != 0 {
+		t.Errorf("ObjectMetrics.Forget() failing objects = %v, want %v", got, 0)
+	}
+}
+
+func TestObjectMetrics_Object(t *testing.T) {
+	t.Parallel()
+
+	hashed := &ObjectMetrics{hashBuckets: 4}
+	buckets := map[string]bool{}
+
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		req := ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "ocm", Name: name}}
+
+		object := hashed.Object(req)
+		if !strings.HasPrefix(object, "bucket-") {
+			t.Errorf("ObjectMetrics.Object() = %v, want a bucket", object)
+		}
+
+		if object != hashed.Object(req) {
+			t.Errorf("ObjectMetrics.Object() = %v, want a stable bucket", object)
+		}
+
+		buckets[object] = true
+	}
+
+	if len(buckets) > 4 {
+		t.Errorf("ObjectMetrics.Object() buckets = %v, want at most %v", len(buckets), 4)
+	}
+
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "ocm", Name: "pool"}}
+	if got := (&ObjectMetrics{}).Object(req); got != "ocm/pool" {
+		t.Errorf("ObjectMetrics.Object() = %v, want %v", got, "ocm/pool")
+	}
+}
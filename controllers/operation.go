@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	// DefaultOperationHistoryLimit is the number of operations which are kept in the operation
+	// history of a workload.
+	DefaultOperationHistoryLimit = 10
+)
+
+// OperationWorkload represents a workload which keeps a history of the operations which have
+// been sent to OpenShift Cluster Manager on its behalf.
+type OperationWorkload interface {
+	Workload
+
+	GetOperationHistory() []ocmv1alpha1.OCMOperation
+	SetOperationHistory([]ocmv1alpha1.OCMOperation)
+}
+
+// RecordOperation records an operation which was sent to OpenShift Cluster Manager, along with the
+// generation of the workload which triggered it and the HTTP status of the response, in the operation
+// history of a workload.  Only the most recent operations, up to the history limit, are kept.
+func RecordOperation(
+	ctx context.Context,
+	reconciler kubernetes.Client,
+	object OperationWorkload,
+	operation string,
+	status int,
+	err error,
+) error {
+	// create a copy of the original and convert to a client object
+	original, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return ErrConvertClientObject
+	}
+
+	object.SetOperationHistory(appendOperation(
+		object.GetOperationHistory(),
+		newOperation(object, operation, status, err),
+		DefaultOperationHistoryLimit,
+	))
+
+	//nolint:wrapcheck
+	return kubernetes.PatchStatus(ctx, reconciler, original, object)
+}
+
+func newOperation(object client.Object, operation string, status int, err error) ocmv1alpha1.OCMOperation {
+	recorded := ocmv1alpha1.OCMOperation{
+		Time:               metav1.Now(),
+		Operation:          operation,
+		ObservedGeneration: object.GetGeneration(),
+		Status:             status,
+	}
+
+	if err != nil {
+		recorded.Error = err.Error()
+	}
+
+	return recorded
+}
+
+// appendOperation appends an operation to a history of operations, dropping the oldest operations
+// so that the history does not exceed a limit.
+func appendOperation(history []ocmv1alpha1.OCMOperation, operation ocmv1alpha1.OCMOperation, limit int) []ocmv1alpha1.OCMOperation {
+	history = append(history, operation)
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	return history
+}
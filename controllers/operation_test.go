@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+var errTestOperation = errors.New("test operation error")
+
+func Test_appendOperation(t *testing.T) {
+	t.Parallel()
+
+	operations := func(generations ...int64) []ocmv1alpha1.OCMOperation {
+		history := make([]ocmv1alpha1.OCMOperation, len(generations))
+		for i, generation := range generations {
+			history[i] = ocmv1alpha1.OCMOperation{Operation: ocmv1alpha1.OCMOperationUpdate, ObservedGeneration: generation}
+		}
+
+		return history
+	}
+
+	tests := []struct {
+		name      string
+		history   []ocmv1alpha1.OCMOperation
+		operation ocmv1alpha1.OCMOperation
+		limit     int
+		want      []ocmv1alpha1.OCMOperation
+	}{
+		{
+			name:      "ensure an operation is appended to an empty history",
+			history:   nil,
+			operation: operations(1)[0],
+			limit:     3,
+			want:      operations(1),
+		},
+		{
+			name:      "ensure an operation is appended to a history below the limit",
+			history:   operations(1, 2),
+			operation: operations(3)[0],
+			limit:     3,
+			want:      operations(1, 2, 3),
+		},
+		{
+			name:      "ensure the oldest operations are dropped at the limit",
+			history:   operations(1, 2, 3),
+			operation: operations(4)[0],
+			limit:     3,
+			want:      operations(2, 3, 4),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := appendOperation(tt.history, tt.operation, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appendOperation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newOperation(t *testing.T) {
+	t.Parallel()
+
+	object := &ocmv1alpha1.MachinePool{ObjectMeta: metav1.ObjectMeta{Generation: 4}}
+
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   ocmv1alpha1.OCMOperation
+	}{
+		{
+			name:   "ensure a successful operation is recorded without an error",
+			status: 201,
+			want: ocmv1alpha1.OCMOperation{
+				Operation:          ocmv1alpha1.OCMOperationCreate,
+				ObservedGeneration: 4,
+				Status:             201,
+			},
+		},
+		{
+			name:   "ensure a failed operation is recorded with its error",
+			status: 400,
+			err:    errTestOperation,
+			want: ocmv1alpha1.OCMOperation{
+				Operation:          ocmv1alpha1.OCMOperationCreate,
+				ObservedGeneration: 4,
+				Status:             400,
+				Error:              errTestOperation.Error(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := newOperation(object, ocmv1alpha1.OCMOperationCreate, tt.status, tt.err)
+			if got.Time.IsZero() {
+				t.Errorf("newOperation() time is unset")
+			}
+
+			got.Time = metav1.Time{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newOperation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
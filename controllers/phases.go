@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+// Phase represents an individual phase in the controller reconciliation process, whose
+// function has been bound to the request that is being reconciled.
+type Phase struct {
+	Name     string
+	Function func() (ctrl.Result, error)
+
+	// Parallel marks the phase as independent of any neighbouring phases which are also
+	// marked as parallel, allowing them to run concurrently.  Parallel phases must not
+	// modify any request data which is used by their neighbouring parallel phases.
+	Parallel bool
+}
+
+// DefaultPhaseTimeout is the default amount of time after which a phase of reconciliation which has
+// not completed is abandoned.  It is longer than the timeouts of requests to OpenShift Cluster
+// Manager, so that a phase which sends a few slow requests is not abandoned.
+const DefaultPhaseTimeout = 5 * time.Minute
+
+var ErrPhaseTimeout = errors.New("phase timed out")
+
+// PhaseDeadline bounds the amount of time for which each phase of reconciliation may run, so that a
+// phase which is stuck, for example on a request to OpenShift Cluster Manager or a lookup of a
+// secret, fails the reconciliation rather than holding the worker.  Phases are run synchronously, so
+// a phase is only abandoned if it honors the context which is bound to the request.  A phase which
+// ignores the context runs to completion, and its error is only reported as a phase timeout if it
+// fails after the timeout is exceeded.
+type PhaseDeadline struct {
+	// Timeout is the amount of time after which a phase is abandoned.  Consecutive parallel phases
+	// share a single timeout.  Phases are not limited if this is zero.
+	Timeout time.Duration
+
+	// Context is the context of the request, from which the context of each phase is derived.
+	Context context.Context
+
+	// Bind binds the context of a phase to the request before the phase is run, so that the
+	// requests which are sent by the phase are abandoned once its timeout is exceeded.  The
+	// context of the request is bound again once the phase has run.
+	Bind func(context.Context)
+}
+
+// bind binds the context of a group of phases to the request, and returns the context along with
+// the function which releases it.  A nil context is returned when phases are not limited.
+func (deadline PhaseDeadline) bind() (context.Context, context.CancelFunc) {
+	if deadline.Timeout <= 0 || deadline.Context == nil || deadline.Bind == nil {
+		return nil, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(deadline.Context, deadline.Timeout)
+	deadline.Bind(ctx)
+
+	return ctx, func() {
+		cancel()
+		deadline.Bind(deadline.Context)
+	}
+}
+
+// phaseResult is the result of running the function of an individual phase.
+type phaseResult struct {
+	result   ctrl.Result
+	err      error
+	duration time.Duration
+}
+
+// ExecuteTimedPhasesWithin executes phases in the order provided.  Consecutive phases which are marked
+// as parallel are executed concurrently and all of them are allowed to complete.  Execution stops at
+// the first phase, in the order provided, which returns an error or requests a requeue, and that phase
+// is returned along with its result and error.  A nil phase is returned when all phases complete
+// successfully.  The duration of each phase which was run is also returned, in the order provided.
+// Phases which were not run, because execution stopped at an earlier phase, are not returned.
+//
+// Each phase which does not complete within the timeout of the deadline is abandoned, and returns an
+// error which wraps ErrPhaseTimeout.  A phase is abandoned by cancelling its context rather than by
+// returning while it runs, so the functions of phases must send their requests with the context which
+// is bound to the request.
+func ExecuteTimedPhasesWithin(
+	deadline PhaseDeadline,
+	phases ...Phase,
+) (*Phase, ctrl.Result, []ocmv1alpha1.PhaseTiming, error) {
+	timings := make([]ocmv1alpha1.PhaseTiming, 0, len(phases))
+
+	for start := 0; start < len(phases); {
+		end := start + 1
+
+		if phases[start].Parallel {
+			for end < len(phases) && phases[end].Parallel {
+				end++
+			}
+		}
+
+		results := runPhases(deadline, phases[start:end])
+
+		for i := range results {
+			timings = append(timings, ocmv1alpha1.PhaseTiming{
+				Name:     phases[start+i].Name,
+				Duration: metav1.Duration{Duration: results[i].duration},
+			})
+		}
+
+		for i := range results {
+			if results[i].err != nil || results[i].result.Requeue {
+				return &phases[start+i], results[i].result, timings, results[i].err
+			}
+		}
+
+		start = end
+	}
+
+	return nil, NoRequeue(), timings, nil
+}
+
+// runPhases runs a group of phases within the timeout of the deadline.  A single phase is run
+// directly while multiple phases are run concurrently.  The results are returned in the same order
+// as the phases.
+func runPhases(deadline PhaseDeadline, phases []Phase) []phaseResult {
+	results := make([]phaseResult, len(phases))
+
+	// the context is bound before any phase of the group is started, so that parallel phases do not
+	// bind the request concurrently
+	ctx, release := deadline.bind()
+	defer release()
+
+	defer func() {
+		for i := range results {
+			results[i].err = timedOut(ctx, deadline.Timeout, phases[i], results[i].err)
+		}
+	}()
+
+	if len(phases) == 1 {
+		results[0] = runPhase(phases[0])
+
+		return results
+	}
+
+	var group errgroup.Group
+
+	for i := range phases {
+		i := i
+
+		group.Go(func() error {
+			results[i] = runPhase(phases[i])
+
+			// errors are returned per phase in the results rather than from the group so that
+			// every phase in the group is allowed to complete
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	return results
+}
+
+// runPhase runs the function of an individual phase and measures its duration.  The function is run
+// synchronously and is not interrupted once its context is cancelled.
+func runPhase(phase Phase) phaseResult {
+	start := time.Now()
+	result, err := phase.Function()
+
+	return phaseResult{result: result, err: err, duration: time.Since(start)}
+}
+
+// timedOut returns the error of a phase which failed once its context exceeded the timeout of the
+// phase as a phase timeout, so that it is reported as a timeout rather than as the error of the
+// request which was abandoned.
+func timedOut(ctx context.Context, timeout time.Duration, phase Phase, err error) error {
+	if err == nil || ctx == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return fmt.Errorf("phase [%s] did not complete within [%s] (%v) - %w", phase.Name, timeout, err, ErrPhaseTimeout)
+}
@@ -0,0 +1,398 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var errTestPhase = errors.New("test phase error")
+
+// testPhases records the names of the phases which have run.
+type testPhases struct {
+	mutex sync.Mutex
+	ran   map[string]bool
+}
+
+func (phases *testPhases) phase(name string, parallel bool, result ctrl.Result, err error) Phase {
+	return Phase{
+		Name:     name,
+		Parallel: parallel,
+		Function: func() (ctrl.Result, error) {
+			phases.mutex.Lock()
+			defer phases.mutex.Unlock()
+
+			phases.ran[name] = true
+
+			return result, err
+		},
+	}
+}
+
+func TestExecuteTimedPhasesWithin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		phases    func(*testPhases) []Phase
+		wantPhase string
+		wantErr   bool
+		wantRan   []string
+		wantSkip  []string
+	}{
+		{
+			name: "ensure all phases run when no phase fails",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", false, NoRequeue(), nil),
+					p.phase("two", true, NoRequeue(), nil),
+					p.phase("three", true, NoRequeue(), nil),
+					p.phase("four", false, NoRequeue(), nil),
+				}
+			},
+			wantPhase: "",
+			wantErr:   false,
+			wantRan:   []string{"one", "two", "three", "four"},
+		},
+		{
+			name: "ensure a failed phase stops the remaining phases",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", false, RequeueAfter(time.Second), errTestPhase),
+					p.phase("two", false, NoRequeue(), nil),
+				}
+			},
+			wantPhase: "one",
+			wantErr:   true,
+			wantRan:   []string{"one"},
+			wantSkip:  []string{"two"},
+		},
+		{
+			name: "ensure a requeue stops the remaining phases",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", false, RequeueAfter(time.Second), nil),
+					p.phase("two", false, NoRequeue(), nil),
+				}
+			},
+			wantPhase: "one",
+			wantErr:   false,
+			wantRan:   []string{"one"},
+			wantSkip:  []string{"two"},
+		},
+		{
+			name: "ensure all parallel phases complete when one of them fails",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", true, RequeueAfter(time.Second), errTestPhase),
+					p.phase("two", true, NoRequeue(), nil),
+					p.phase("three", false, NoRequeue(), nil),
+				}
+			},
+			wantPhase: "one",
+			wantErr:   true,
+			wantRan:   []string{"one", "two"},
+			wantSkip:  []string{"three"},
+		},
+		{
+			name: "ensure the first failed parallel phase in order is returned",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", true, NoRequeue(), nil),
+					p.phase("two", true, RequeueAfter(time.Second), nil),
+					p.phase("three", true, RequeueAfter(time.Second), errTestPhase),
+				}
+			},
+			wantPhase: "two",
+			wantErr:   false,
+			wantRan:   []string{"one", "two", "three"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ran := &testPhases{ran: map[string]bool{}}
+
+			phase, _, _, err := ExecuteTimedPhasesWithin(PhaseDeadline{}, tt.phases(ran)...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecuteTimedPhasesWithin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotPhase string
+			if phase != nil {
+				gotPhase = phase.Name
+			}
+
+			if gotPhase != tt.wantPhase {
+				t.Errorf("ExecuteTimedPhasesWithin() phase = %v, want %v", gotPhase, tt.wantPhase)
+			}
+
+			for _, name := range tt.wantRan {
+				if !ran.ran[name] {
+					t.Errorf("ExecuteTimedPhasesWithin() phase %s did not run", name)
+				}
+			}
+
+			for _, name := range tt.wantSkip {
+				if ran.ran[name] {
+					t.Errorf("ExecuteTimedPhasesWithin() phase %s ran, want skipped", name)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteTimedPhasesWithin_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	// each phase waits for the other to start, which only succeeds when they run concurrently
+	started := make(chan struct{})
+
+	wait := func() (ctrl.Result, error) {
+		select {
+		case started <- struct{}{}:
+			return NoRequeue(), nil
+		case <-started:
+			return NoRequeue(), nil
+		case <-time.After(5 * time.Second):
+			return RequeueAfter(time.Second), errTestPhase
+		}
+	}
+
+	phase, _, _, err := ExecuteTimedPhasesWithin(
+		PhaseDeadline{},
+		Phase{Name: "one", Function: wait, Parallel: true},
+		Phase{Name: "two", Function: wait, Parallel: true},
+	)
+	if phase != nil || err != nil {
+		t.Errorf("ExecuteTimedPhasesWithin() phase = %v, error = %v, want parallel execution", phase, err)
+	}
+}
+
+func TestExecuteTimedPhasesWithin_Timings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		phases func(*testPhases) []Phase
+		want   []string
+	}{
+		{
+			name: "ensure every phase is timed when no phase fails",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", false, NoRequeue(), nil),
+					p.phase("two", true, NoRequeue(), nil),
+					p.phase("three", true, NoRequeue(), nil),
+					p.phase("four", false, NoRequeue(), nil),
+				}
+			},
+			want: []string{"one", "two", "three", "four"},
+		},
+		{
+			name: "ensure skipped phases are not timed",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", false, RequeueAfter(time.Second), errTestPhase),
+					p.phase("two", false, NoRequeue(), nil),
+				}
+			},
+			want: []string{"one"},
+		},
+		{
+			name: "ensure all parallel phases are timed when one of them fails",
+			phases: func(p *testPhases) []Phase {
+				return []Phase{
+					p.phase("one", true, RequeueAfter(time.Second), errTestPhase),
+					p.phase("two", true, NoRequeue(), nil),
+					p.phase("three", false, NoRequeue(), nil),
+				}
+			},
+			want: []string{"one", "two"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, timings, _ := ExecuteTimedPhasesWithin(PhaseDeadline{}, tt.phases(&testPhases{ran: map[string]bool{}})...)
+
+			got := make([]string, len(timings))
+			for i := range timings {
+				got[i] = timings[i].Name
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExecuteTimedPhasesWithin() phases = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteTimedPhasesWithin_Deadline(t *testing.T) {
+	t.Parallel()
+
+	// stuck waits for the context which is bound to the request to be abandoned
+	stuck := func(request *context.Context) func() (ctrl.Result, error) {
+		return func() (ctrl.Result, error) {
+			select {
+			case <-(*request).Done():
+				return RequeueAfter(time.Second), fmt.Errorf("unable to get cluster - %w", (*request).Err())
+			case <-time.After(5 * time.Second):
+				return NoRequeue(), nil
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		phases      func(request *context.Context) []Phase
+		wantPhase   string
+		wantErr     bool
+		wantTimeout bool
+	}{
+		{
+			name:    "ensure a stuck phase times out",
+			timeout: 10 * time.Millisecond,
+			phases: func(request *context.Context) []Phase {
+				return []Phase{{Name: "stuck", Function: stuck(request)}}
+			},
+			wantPhase:   "stuck",
+			wantErr:     true,
+			wantTimeout: true,
+		},
+		{
+			name:    "ensure stuck parallel phases time out",
+			timeout: 10 * time.Millisecond,
+			phases: func(request *context.Context) []Phase {
+				return []Phase{
+					{Name: "one", Function: stuck(request), Parallel: true},
+					{Name: "two", Function: stuck(request), Parallel: true},
+				}
+			},
+			wantPhase:   "one",
+			wantErr:     true,
+			wantTimeout: true,
+		},
+		{
+			name:    "ensure a phase which ignores its context runs to completion",
+			timeout: 10 * time.Millisecond,
+			phases: func(request *context.Context) []Phase {
+				return []Phase{{Name: "ignored", Function: func() (ctrl.Result, error) {
+					time.Sleep(50 * time.Millisecond)
+
+					return NoRequeue(), nil
+				}}}
+			},
+			wantPhase:   "",
+			wantErr:     false,
+			wantTimeout: false,
+		},
+		{
+			name:    "ensure a phase which ignores its context and fails once it has timed out times out",
+			timeout: 10 * time.Millisecond,
+			phases: func(request *context.Context) []Phase {
+				return []Phase{{Name: "ignored", Function: func() (ctrl.Result, error) {
+					time.Sleep(50 * time.Millisecond)
+
+					return RequeueAfter(time.Second), errTestPhase
+				}}}
+			},
+			wantPhase:   "ignored",
+			wantErr:     true,
+			wantTimeout: true,
+		},
+		{
+			name:    "ensure a failed phase which did not time out returns its error",
+			timeout: time.Minute,
+			phases: func(request *context.Context) []Phase {
+				return []Phase{{Name: "failed", Function: func() (ctrl.Result, error) {
+					return RequeueAfter(time.Second), fmt.Errorf("unable to get cluster - %w", context.DeadlineExceeded)
+				}}}
+			},
+			wantPhase:   "failed",
+			wantErr:     true,
+			wantTimeout: false,
+		},
+		{
+			name:    "ensure a phase which completes within the timeout succeeds",
+			timeout: time.Minute,
+			phases: func(request *context.Context) []Phase {
+				return []Phase{{Name: "complete", Function: func() (ctrl.Result, error) {
+					if _, found := (*request).Deadline(); !found {
+						return RequeueAfter(time.Second), errTestPhase
+					}
+
+					return NoRequeue(), nil
+				}}}
+			},
+			wantPhase:   "",
+			wantErr:     false,
+			wantTimeout: false,
+		},
+		{
+			name:    "ensure phases are not limited without a timeout",
+			timeout: 0,
+			phases: func(request *context.Context) []Phase {
+				return []Phase{{Name: "unlimited", Function: func() (ctrl.Result, error) {
+					if _, found := (*request).Deadline(); found {
+						return RequeueAfter(time.Second), errTestPhase
+					}
+
+					return NoRequeue(), nil
+				}}}
+			},
+			wantPhase:   "",
+			wantErr:     false,
+			wantTimeout: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parent := context.Background()
+			request := parent
+
+			phase, _, _, err := ExecuteTimedPhasesWithin(PhaseDeadline{
+				Timeout: tt.timeout,
+				Context: parent,
+				Bind:    func(ctx context.Context) { request = ctx },
+			}, tt.phases(&request)...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecuteTimedPhasesWithin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if errors.Is(err, ErrPhaseTimeout) != tt.wantTimeout {
+				t.Errorf("ExecuteTimedPhasesWithin() error = %v, want timeout %v", err, tt.wantTimeout)
+			}
+
+			gotPhase := ""
+			if phase != nil {
+				gotPhase = phase.Name
+			}
+
+			if gotPhase != tt.wantPhase {
+				t.Errorf("ExecuteTimedPhasesWithin() phase = %v, want %v", gotPhase, tt.wantPhase)
+			}
+
+			if request != parent {
+				t.Errorf("ExecuteTimedPhasesWithin() bound context = %v, want the context of the request", request)
+			}
+		})
+	}
+}
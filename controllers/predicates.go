@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// WorkloadPredicates returns the filters which are used to filter out the common reconcile events
+// prior to reconciling a workload.  Updates are only reconciled when the generation or the labels of
+// the workload have changed, so that the status patches which a controller makes to its own workloads
+// do not immediately trigger another reconciliation.  Generic events are filtered out, and must be
+// allowed explicitly by another predicate such as the BroadcastPredicate.
+func WorkloadPredicates() predicate.Predicate {
+	return predicate.And(
+		predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}),
+		predicate.Funcs{
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return !e.DeleteStateUnknown
+			},
+			GenericFunc: func(e event.GenericEvent) bool {
+				return false
+			},
+		},
+	)
+}
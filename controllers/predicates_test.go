@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func testPredicateObject(generation int64, labels map[string]string, phase corev1.NamespacePhase) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: generation, Labels: labels},
+		Status:     corev1.NamespaceStatus{Phase: phase},
+	}
+}
+
+func TestWorkloadPredicates(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"test": "true"}
+
+	tests := []struct {
+		name string
+		old  *corev1.Namespace
+		new  *corev1.Namespace
+		want bool
+	}{
+		{
+			name: "ensure a generation change triggers reconciliation",
+			old:  testPredicateObject(1, nil, corev1.NamespaceActive),
+			new:  testPredicateObject(2, nil, corev1.NamespaceActive),
+			want: true,
+		},
+		{
+			name: "ensure a label change triggers reconciliation",
+			old:  testPredicateObject(1, nil, corev1.NamespaceActive),
+			new:  testPredicateObject(1, labels, corev1.NamespaceActive),
+			want: true,
+		},
+		{
+			name: "ensure a status only change does not trigger reconciliation",
+			old:  testPredicateObject(1, labels, corev1.NamespaceActive),
+			new:  testPredicateObject(1, labels, corev1.NamespaceTerminating),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := WorkloadPredicates().Update(event.UpdateEvent{ObjectOld: tt.old, ObjectNew: tt.new}); got != tt.want {
+				t.Errorf("WorkloadPredicates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkloadPredicates_Events(t *testing.T) {
+	t.Parallel()
+
+	object := testPredicateObject(1, nil, corev1.NamespaceActive)
+
+	if !WorkloadPredicates().Create(event.CreateEvent{Object: object}) {
+		t.Errorf("WorkloadPredicates() create = %v, want %v", false, true)
+	}
+
+	if !WorkloadPredicates().Delete(event.DeleteEvent{Object: object}) {
+		t.Errorf("WorkloadPredicates() delete = %v, want %v", false, true)
+	}
+
+	if WorkloadPredicates().Delete(event.DeleteEvent{Object: object, DeleteStateUnknown: true}) {
+		t.Errorf("WorkloadPredicates() unknown delete = %v, want %v", true, false)
+	}
+
+	if WorkloadPredicates().Generic(event.GenericEvent{Object: object}) {
+		t.Errorf("WorkloadPredicates() generic = %v, want %v", true, false)
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+)
+
+const (
+	// AnnotationPreventDestroy is the annotation which prevents an object from being deleted from
+	// OpenShift Cluster Manager when its custom resource is deleted.  The custom resource remains
+	// terminating until the annotation is removed, at which point the deletion continues.
+	AnnotationPreventDestroy = "ocm.mobb.redhat.com/prevent-destroy"
+
+	annotationPreventDestroyEnabled = "true"
+
+	// EventReasonDestroyPrevented is the reason of the event which is recorded when the deletion of an
+	// object from OpenShift Cluster Manager is prevented.
+	EventReasonDestroyPrevented = "DestroyPrevented"
+
+	// MessageDestroyPrevented is the message with which the deletion of an object from OpenShift
+	// Cluster Manager being prevented is reported, both in its condition and its event.
+	MessageDestroyPrevented = "deletion from openshift cluster manager is prevented by the " + AnnotationPreventDestroy +
+		" annotation; remove the annotation to continue the deletion"
+)
+
+// PreventDestroyRequested determines if an object has requested that it is never deleted from
+// OpenShift Cluster Manager, as a guardrail against the accidental deletion of its custom resource.
+func PreventDestroyRequested(object client.Object) bool {
+	return strings.EqualFold(object.GetAnnotations()[AnnotationPreventDestroy], annotationPreventDestroyEnabled)
+}
+
+// PreventDestroy records that the deletion of the object of a request from OpenShift Cluster Manager has
+// been prevented by the prevent destroy annotation, along with a warning event, and requeues the request
+// so that the deletion continues once the annotation is removed.  The prevention is only recorded once.
+func PreventDestroy(dependencies *Dependencies, execution *Execution, requeue time.Duration) (ctrl.Result, error) {
+	if dependencies.Results == nil || dependencies.Results.IsDestroyPrevented(execution.Object) {
+		return RequeueAfter(requeue), nil
+	}
+
+	execution.info("deletion is prevented; leaving object in openshift cluster manager")
+
+	if dependencies.Recorder != nil {
+		events.RegisterWarning(execution.Object, dependencies.Recorder, EventReasonDestroyPrevented, MessageDestroyPrevented)
+	}
+
+	if err := dependencies.Results.RecordDestroyPrevented(execution.Context, execution.Reconciler, execution.Object); err != nil {
+		return RequeueAfter(requeue), fmt.Errorf("error updating destroy prevented condition - %w", err)
+	}
+
+	return RequeueAfter(requeue), nil
+}
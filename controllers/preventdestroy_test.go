@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+func TestPreventDestroyRequested(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]bool{
+		"true":  true,
+		"True":  true,
+		"false": false,
+		"":      false,
+	} {
+		object := testImportObject(map[string]string{AnnotationPreventDestroy: value})
+		if got := PreventDestroyRequested(object); got != want {
+			t.Errorf("PreventDestroyRequested(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestPreventDestroy(t *testing.T) {
+	t.Parallel()
+
+	results := &testResults{}
+	recorder := record.NewFakeRecorder(2)
+	dependencies := &Dependencies{Results: results, Recorder: recorder}
+
+	execution := &Execution{
+		Context:    context.Background(),
+		Reconciler: &kubernetes.FakeClient{},
+		Object:     &ocmv1alpha1.MachinePool{},
+		Log:        logr.Discard(),
+	}
+
+	// the prevention is recorded once, however many times the deletion is requeued
+	for i := 0; i < 2; i++ {
+		result, err := PreventDestroy(dependencies, execution, time.Minute)
+		if err != nil {
+			t.Fatalf("PreventDestroy() error = %v", err)
+		}
+
+		if result.RequeueAfter != time.Minute {
+			t.Errorf("PreventDestroy() requeue after = %v, want %v", result.RequeueAfter, time.Minute)
+		}
+	}
+
+	if !results.prevented {
+		t.Errorf("PreventDestroy() prevented = %v, want %v", results.prevented, true)
+	}
+
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("PreventDestroy() events = %v, want %v", got, 1)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullsecret
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	defaultPullSecretRequeue = 30 * time.Second
+
+	controllerName = "pullsecret"
+)
+
+// Controller keeps the pull secret of a ClusterRegistration object in sync with OpenShift Cluster
+// Manager.  The pull secret is stored in a secret which is owned by the cluster registration and is
+// rotated at the interval requested by the cluster registration.  The subscription of the cluster
+// itself is managed by the cluster registration controller.
+type Controller struct {
+	client.Client
+
+	controllers.Dependencies
+
+	Scheme *runtime.Scheme
+
+	// Requeue is the interval after which a failed or incomplete reconciliation is retried.  The
+	// default requeue interval of the controller is used if this is zero.
+	Requeue time.Duration
+}
+
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusterregistrations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clusterregistrations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// RequiredAPIs returns the APIs of OpenShift Cluster Manager which the controller depends upon.  It
+// is used to satisfy the Compatible interface.
+func (r *Controller) RequiredAPIs() []ocm.API {
+	return []ocm.API{ocm.APIAccountsMgmt}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+//nolint:wrapcheck
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return controllers.Reconcile(ctx, r, req)
+}
+
+// ReconcileCreate performs the reconciliation logic when a create event triggered
+// the reconciliation.
+func (r *Controller) ReconcileCreate(req controllers.Request) (ctrl.Result, error) {
+	// type cast the request to a pull secret request
+	request, ok := req.(*PullSecretRequest)
+	if !ok {
+		return controllers.RequeueAfter(r.requeue()), ErrPullSecretRequestConvert
+	}
+
+	// execute the phases
+	return request.execute([]Phase{
+		{Name: "waitForRegistration", Function: r.WaitForRegistration},
+		{Name: "getCurrentState", Function: r.GetCurrentState},
+		{Name: "rotate", Function: r.Rotate},
+		{Name: "complete", Function: r.Complete},
+	}...)
+}
+
+// ReconcileUpdate performs the reconciliation logic when an update event triggered
+// the reconciliation.  In this instance, create and update share identical logic
+// so we are simply calling the ReconcileCreate method.
+func (r *Controller) ReconcileUpdate(req controllers.Request) (ctrl.Result, error) {
+	return r.ReconcileCreate(req)
+}
+
+// ReconcileDelete performs the reconciliation logic when a delete event triggered
+// the reconciliation.  The secret is owned by the cluster registration and is removed
+// by garbage collection, so there is nothing to clean up.
+func (r *Controller) ReconcileDelete(req controllers.Request) (ctrl.Result, error) {
+	return controllers.NoRequeue(), nil
+}
+
+// requeue returns the interval after which a failed or incomplete reconciliation is retried.
+func (r *Controller) requeue() time.Duration {
+	if r.Requeue == 0 {
+		return defaultPullSecretRequeue
+	}
+
+	return r.Requeue
+}
+
+// SetupWithManager sets up the controller with the Manager.  The controller is named explicitly
+// as the cluster registration controller reconciles the same kind.  Secrets which are owned by a
+// cluster registration are watched so that a deleted pull secret is restored immediately.
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	managedBy := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options()).
+		Named(controllerName).
+		WithEventFilter(predicate.Or(controllers.WorkloadPredicates(), controllers.BroadcastPredicate())).
+		For(&ocmv1alpha1.ClusterRegistration{}).
+		Owns(&corev1.Secret{})
+
+	if r.Broadcaster != nil {
+		managedBy = managedBy.Watches(r.Broadcaster.Subscribe(), controllers.EnqueueAll(r, &ocmv1alpha1.ClusterRegistrationList{}))
+	}
+
+	return managedBy.Complete(r)
+}
@@ -0,0 +1,159 @@
+package pullsecret
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*PullSecretRequest]
+
+// WaitForRegistration waits for the cluster to be registered with OpenShift Cluster Manager by the
+// cluster registration controller before its pull secret is retrieved.
+func (r *Controller) WaitForRegistration(request *PullSecretRequest) (ctrl.Result, error) {
+	if request.Original.Status.SubscriptionID == "" {
+		request.Log.V(controllers.LogLevelDebug).Info("waiting for cluster registration", request.logValues()...)
+
+		return controllers.RequeueAfter(r.requeue()), nil
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// GetCurrentState gets the current state of the pull secret.  The current state of the pull secret is
+// the secret in which it is stored.  A secret which does not exist is created by the rotate phase.
+func (r *Controller) GetCurrentState(request *PullSecretRequest) (ctrl.Result, error) {
+	secret := &corev1.Secret{}
+
+	if err := r.Get(request.Context, types.NamespacedName{
+		Namespace: request.Original.Namespace,
+		Name:      request.Original.GetPullSecretName(),
+	}, secret); err != nil {
+		if apierrs.IsNotFound(err) {
+			return controllers.NoRequeue(), nil
+		}
+
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to retrieve secret [%s/%s] from cluster - %w",
+			request.Original.Namespace,
+			request.Original.GetPullSecretName(),
+			err,
+		)
+	}
+
+	request.Secret = secret
+
+	return controllers.NoRequeue(), nil
+}
+
+// Rotate retrieves the pull secret from OpenShift Cluster Manager and stores it in the secret if the
+// rotation interval has elapsed, or if the secret is missing or has been renamed.  The result of the
+// rotation is reported by the pull secret synced condition.
+func (r *Controller) Rotate(request *PullSecretRequest) (ctrl.Result, error) {
+	if due, _ := request.rotationDue(time.Now()); !due {
+		return controllers.NoRequeue(), nil
+	}
+
+	request.Log.Info("rotating pull secret", request.logValues()...)
+
+	if err := r.rotate(request); err != nil {
+		if conditionErr := conditions.Update(
+			request.Context,
+			request.Reconciler,
+			request.Original,
+			conditions.PullSecretSyncFailed(err),
+		); conditionErr != nil {
+			return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating pull secret condition - %w", conditionErr)
+		}
+
+		return controllers.RequeueAfter(r.requeue()), err
+	}
+
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.PullSecretSynced(request.Original.GetPullSecretName()),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating pull secret condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// rotate retrieves the pull secret from OpenShift Cluster Manager, stores it in the secret and records
+// the time of the rotation in the status.
+func (r *Controller) rotate(request *PullSecretRequest) error {
+	data, err := request.OCMClient.PullSecret()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve pull secret from ocm - %w", err)
+	}
+
+	if err := kubernetes.ApplySecretData(
+		request.Context,
+		r.Client,
+		r.Scheme,
+		request.Original,
+		request.Original.GetPullSecretName(),
+		corev1.SecretTypeDockerConfigJson,
+		map[string][]byte{corev1.DockerConfigJsonKey: data},
+	); err != nil {
+		return fmt.Errorf("unable to store pull secret - %w", err)
+	}
+
+	// create an event indicating that the pull secret has changed
+	if request.Secret == nil || !bytes.Equal(request.Secret.Data[corev1.DockerConfigJsonKey], data) {
+		events.RegisterAction(
+			events.Rotated,
+			request.Original,
+			r.Recorder,
+			request.Original.GetPullSecretName(),
+			request.Original.Status.ClusterID,
+		)
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	now := metav1.Now()
+	request.Original.Status.PullSecretName = request.Original.GetPullSecretName()
+	request.Original.Status.PullSecretRotatedTime = &now
+
+	// store the name of the secret and the time of the rotation in the status
+	if err := kubernetes.PatchStatus(request.Context, r, original, request.Original); err != nil {
+		return fmt.Errorf(
+			"unable to update status.pullSecretName=%s, status.pullSecretRotatedTime=%s - %w",
+			request.Original.Status.PullSecretName,
+			now.UTC().Format(time.RFC3339),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  It will
+// requeue when the next rotation of the pull secret is due.
+func (r *Controller) Complete(request *PullSecretRequest) (ctrl.Result, error) {
+	_, next := rotationDue(
+		request.Original.Status.PullSecretRotatedTime,
+		request.Original.PullSecretRotationInterval(),
+		time.Now(),
+	)
+
+	request.Log.Info("completed pull secret reconciliation", request.logValues()...)
+	request.Log.Info(fmt.Sprintf("rotating pull secret in %s", next.String()), request.logValues()...)
+
+	return controllers.RequeueAfter(next), nil
+}
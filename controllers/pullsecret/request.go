@@ -0,0 +1,140 @@
+package pullsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var (
+	ErrPullSecretRequestConvert = errors.New("unable to convert generic request to pull secret request")
+)
+
+// PullSecretRequest is an object that is unique to each reconciliation
+// request.
+type PullSecretRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ClusterRegistration
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+	Environment       *ocm.Environment
+	OCMClient         *ocm.SubscriptionClient
+
+	// data obtained during request reconciliation
+	Secret *corev1.Secret
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ClusterRegistration{}
+
+	// get the object from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &PullSecretRequest{}, fmt.Errorf("unable to fetch cluster object - %w", err)
+		}
+
+		return &PullSecretRequest{}, err
+	}
+
+	// determine the environment of openshift cluster manager which the object targets
+	environment, err := controllers.EnvironmentFor(
+		r.Environments,
+		&ocm.Environment{Connection: r.Connection},
+		original,
+	)
+	if err != nil {
+		return &PullSecretRequest{}, fmt.Errorf("unable to determine ocm environment - %w", err)
+	}
+
+	return &PullSecretRequest{
+		Original:          original,
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+		Environment:       environment,
+		OCMClient:         ocm.NewSubscriptionClient(environment.Connection).WithContext(ctx),
+	}, nil
+}
+
+func (request *PullSecretRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *PullSecretRequest) bindContext(ctx context.Context) {
+	request.Context = ctx
+
+	// the client which is shared between phases is bound to the context of each phase
+	if request.OCMClient != nil {
+		request.OCMClient.WithContext(ctx)
+	}
+}
+
+// execute executes a variety of different phases for the request.  Unlike the other controllers,
+// failures are not recorded in the reconciliation conditions of the object, as those belong to the
+// cluster registration controller.  They are instead reported by the pull secret synced condition.
+func (request *PullSecretRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Unrecorded: true,
+	}, request, phases...)
+}
+
+// rotationDue determines if the pull secret should be retrieved from OpenShift Cluster Manager
+// again.  It also returns the duration until the next rotation is due.
+func (request *PullSecretRequest) rotationDue(now time.Time) (bool, time.Duration) {
+	// the pull secret is due if the secret is missing, was emptied, or has been renamed
+	if request.Secret == nil ||
+		len(request.Secret.Data[corev1.DockerConfigJsonKey]) == 0 ||
+		request.Original.Status.PullSecretName != request.Original.GetPullSecretName() {
+		return true, request.Original.PullSecretRotationInterval()
+	}
+
+	return rotationDue(request.Original.Status.PullSecretRotatedTime, request.Original.PullSecretRotationInterval(), now)
+}
+
+// rotationDue determines if a pull secret which was last rotated at a particular time is due to be
+// rotated given its rotation interval.  It also returns the duration until the next rotation is due.
+func rotationDue(rotated *metav1.Time, interval time.Duration, now time.Time) (bool, time.Duration) {
+	if rotated == nil {
+		return true, interval
+	}
+
+	remaining := rotated.Add(interval).Sub(now)
+	if remaining <= 0 {
+		return true, interval
+	}
+
+	return false, remaining
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *PullSecretRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", fmt.Sprintf("%s/%s", request.Original.Namespace, request.Original.Name),
+		"cluster", request.Original.GetDisplayName(),
+		"secret", request.Original.GetPullSecretName(),
+	}
+}
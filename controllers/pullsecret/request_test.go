@@ -0,0 +1,105 @@
+package pullsecret
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func TestPullSecretRequest_rotationDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	rotated := metav1.NewTime(now.Add(-1 * time.Hour))
+
+	secret := &corev1.Secret{
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}
+
+	registration := func(name string, hours int) *ocmv1alpha1.ClusterRegistration {
+		return &ocmv1alpha1.ClusterRegistration{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       ocmv1alpha1.ClusterRegistrationSpec{PullSecretRotationHours: hours},
+			Status: ocmv1alpha1.ClusterRegistrationStatus{
+				PullSecretName:        name,
+				PullSecretRotatedTime: &rotated,
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		registration *ocmv1alpha1.ClusterRegistration
+		secret       *corev1.Secret
+		want         bool
+		wantNext     time.Duration
+	}{
+		{
+			name:         "ensure a recently rotated pull secret is not due",
+			registration: registration("test-pull-secret", 24),
+			secret:       secret,
+			want:         false,
+			wantNext:     23 * time.Hour,
+		},
+		{
+			name:         "ensure a pull secret is due once the rotation interval has elapsed",
+			registration: registration("test-pull-secret", 1),
+			secret:       secret,
+			want:         true,
+			wantNext:     time.Hour,
+		},
+		{
+			name:         "ensure a missing secret is due",
+			registration: registration("test-pull-secret", 24),
+			secret:       nil,
+			want:         true,
+			wantNext:     24 * time.Hour,
+		},
+		{
+			name:         "ensure an emptied secret is due",
+			registration: registration("test-pull-secret", 24),
+			secret:       &corev1.Secret{},
+			want:         true,
+			wantNext:     24 * time.Hour,
+		},
+		{
+			name:         "ensure a renamed secret is due",
+			registration: registration("renamed", 24),
+			secret:       secret,
+			want:         true,
+			wantNext:     24 * time.Hour,
+		},
+		{
+			name: "ensure a pull secret which was never rotated is due",
+			registration: &ocmv1alpha1.ClusterRegistration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Status:     ocmv1alpha1.ClusterRegistrationStatus{PullSecretName: "test-pull-secret"},
+			},
+			secret:   secret,
+			want:     true,
+			wantNext: 24 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := &PullSecretRequest{Original: tt.registration, Secret: tt.secret}
+
+			got, gotNext := request.rotationDue(now)
+			if got != tt.want {
+				t.Errorf("rotationDue() got = %v, want %v", got, tt.want)
+			}
+
+			if gotNext != tt.wantNext {
+				t.Errorf("rotationDue() gotNext = %v, want %v", gotNext, tt.wantNext)
+			}
+		})
+	}
+}
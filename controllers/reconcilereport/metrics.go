@@ -0,0 +1,45 @@
+package reconcilereport
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+const (
+	// ResourcesMetric is the name of the metric which exposes the number of managed resources
+	// in each state of a report.
+	ResourcesMetric = "ocm_reconcile_report_resources"
+)
+
+// resources is the number of managed resources in each state of each report, so that resources
+// which have drifted from their last reconciled state may be alerted upon.
+var resources = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: ResourcesMetric,
+		Help: "Number of resources managed by the operator in each state of a reconcile report.",
+	},
+	[]string{"report", "state"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(resources)
+}
+
+// observe records the summary of a report in the resources metric.
+func observe(report string, summary ocmv1alpha1.ReconcileReportSummary) {
+	for state, count := range map[ocmv1alpha1.ReconcileReportState]int{
+		ocmv1alpha1.ReconcileReportStateInSync:  summary.InSync,
+		ocmv1alpha1.ReconcileReportStateDrifted: summary.Drifted,
+		ocmv1alpha1.ReconcileReportStateFailed:  summary.Failed,
+		ocmv1alpha1.ReconcileReportStateUnknown: summary.Unknown,
+	} {
+		resources.WithLabelValues(report, string(state)).Set(float64(count))
+	}
+}
+
+// forget removes the series of a report from the resources metric.
+func forget(report string) {
+	resources.DeletePartialMatch(prometheus.Labels{"report": report})
+}
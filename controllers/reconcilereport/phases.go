@@ -0,0 +1,85 @@
+package reconcilereport
+
+import (
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// Phase defines an individual phase in the controller reconciliation process.
+type Phase = controllers.RequestPhase[*ReconcileReportRequest]
+
+// Begin begins the reconciliation state once we get the object from the cluster.
+// It is mainly used to set conditions of the controller and to let anyone who is viewiing the
+// custom resource know that we are currently reconciling.
+func (r *Controller) Begin(request *ReconcileReportRequest) (ctrl.Result, error) {
+	if err := conditions.Update(
+		request.Context,
+		request.Reconciler,
+		request.Original,
+		conditions.Reconciling(request.Trigger),
+	); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciling condition - %w", err)
+	}
+
+	return controllers.NoRequeue(), nil
+}
+
+// Generate generates the report by summarizing each of the resources managed by this operator
+// and storing the summary in the status of the report.
+func (r *Controller) Generate(request *ReconcileReportRequest) (ctrl.Result, error) {
+	resources, err := listManagedResources(request.Context, r)
+	if err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to list managed resources - %w",
+			err,
+		)
+	}
+
+	// keep track of the original object
+	original := request.Original.DeepCopy()
+	request.Original.Status = summarize(request.Original, resources, time.Now())
+
+	// store the report in the status
+	if err := kubernetes.PatchStatus(request.Context, r, original, request.Original); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf(
+			"unable to update report status - %w",
+			err,
+		)
+	}
+
+	observe(request.Original.GetName(), request.Original.Status.Summary)
+
+	request.Log.V(controllers.LogLevelDebug).Info(
+		fmt.Sprintf(
+			"generated report [total=%d, inSync=%d, drifted=%d, failed=%d, unknown=%d]",
+			request.Original.Status.Summary.Total,
+			request.Original.Status.Summary.InSync,
+			request.Original.Status.Summary.Drifted,
+			request.Original.Status.Summary.Failed,
+			request.Original.Status.Summary.Unknown,
+		),
+		request.logValues()...,
+	)
+
+	return controllers.NoRequeue(), nil
+}
+
+// Complete will perform all actions required to successful complete a reconciliation request.  It will
+// requeue after the interval value requested by the report to ensure that the report is regenerated
+// at a specific interval.
+func (r *Controller) Complete(request *ReconcileReportRequest) (ctrl.Result, error) {
+	if err := conditions.Update(request.Context, request.Reconciler, request.Original, conditions.Reconciled(request.Trigger)); err != nil {
+		return controllers.RequeueAfter(r.requeue()), fmt.Errorf("error updating reconciled condition - %w", err)
+	}
+
+	request.Log.Info("completed reconcile report generation", request.logValues()...)
+	request.Log.Info(fmt.Sprintf("generating again in %s", request.Original.Interval().String()), request.logValues()...)
+
+	return controllers.RequeueAfter(request.Original.Interval()), nil
+}
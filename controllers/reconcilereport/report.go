@@ -0,0 +1,205 @@
+package reconcilereport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// managedResource represents an individual resource, managed by this operator, which is
+// summarized in a report.
+type managedResource struct {
+	kind        string
+	clusterName string
+	object      controllers.Workload
+}
+
+// listManagedResources lists all of the resources managed by this operator.
+func listManagedResources(ctx context.Context, c kubernetes.Client) ([]managedResource, error) {
+	resources := []managedResource{}
+
+	machinePools := &ocmv1alpha1.MachinePoolList{}
+	if err := c.List(ctx, machinePools); err != nil {
+		return nil, fmt.Errorf("unable to list machine pools - %w", err)
+	}
+
+	for i := range machinePools.Items {
+		resources = append(resources, managedResource{
+			kind:        "MachinePool",
+			clusterName: machinePools.Items[i].Spec.ClusterName,
+			object:      &machinePools.Items[i],
+		})
+	}
+
+	gitlabIdentityProviders := &ocmv1alpha1.GitLabIdentityProviderList{}
+	if err := c.List(ctx, gitlabIdentityProviders); err != nil {
+		return nil, fmt.Errorf("unable to list gitlab identity providers - %w", err)
+	}
+
+	for i := range gitlabIdentityProviders.Items {
+		resources = append(resources, managedResource{
+			kind:        "GitLabIdentityProvider",
+			clusterName: gitlabIdentityProviders.Items[i].Spec.ClusterName,
+			object:      &gitlabIdentityProviders.Items[i],
+		})
+	}
+
+	ldapIdentityProviders := &ocmv1alpha1.LDAPIdentityProviderList{}
+	if err := c.List(ctx, ldapIdentityProviders); err != nil {
+		return nil, fmt.Errorf("unable to list ldap identity providers - %w", err)
+	}
+
+	for i := range ldapIdentityProviders.Items {
+		resources = append(resources, managedResource{
+			kind:        "LDAPIdentityProvider",
+			clusterName: ldapIdentityProviders.Items[i].Spec.ClusterName,
+			object:      &ldapIdentityProviders.Items[i],
+		})
+	}
+
+	clusterNotifications := &ocmv1alpha1.ClusterNotificationList{}
+	if err := c.List(ctx, clusterNotifications); err != nil {
+		return nil, fmt.Errorf("unable to list cluster notifications - %w", err)
+	}
+
+	for i := range clusterNotifications.Items {
+		resources = append(resources, managedResource{
+			kind:        "ClusterNotification",
+			clusterName: clusterNotifications.Items[i].Spec.ClusterName,
+			object:      &clusterNotifications.Items[i],
+		})
+	}
+
+	clusterRegistrations := &ocmv1alpha1.ClusterRegistrationList{}
+	if err := c.List(ctx, clusterRegistrations); err != nil {
+		return nil, fmt.Errorf("unable to list cluster registrations - %w", err)
+	}
+
+	for i := range clusterRegistrations.Items {
+		resources = append(resources, managedResource{
+			kind:        "ClusterRegistration",
+			clusterName: clusterRegistrations.Items[i].GetDisplayName(),
+			object:      &clusterRegistrations.Items[i],
+		})
+	}
+
+	clusterLabels := &ocmv1alpha1.ClusterLabelsList{}
+	if err := c.List(ctx, clusterLabels); err != nil {
+		return nil, fmt.Errorf("unable to list cluster labels - %w", err)
+	}
+
+	for i := range clusterLabels.Items {
+		resources = append(resources, managedResource{
+			kind:        "ClusterLabels",
+			clusterName: clusterLabels.Items[i].Spec.ClusterName,
+			object:      &clusterLabels.Items[i],
+		})
+	}
+
+	return resources, nil
+}
+
+// summarize produces the report status for a set of managed resources at a given point in time.
+func summarize(report *ocmv1alpha1.ReconcileReport, resources []managedResource, now time.Time) ocmv1alpha1.ReconcileReportStatus {
+	generated := metav1.NewTime(now)
+
+	status := ocmv1alpha1.ReconcileReportStatus{
+		Conditions:        report.Status.Conditions,
+		LastGeneratedTime: &generated,
+		Resources:         []ocmv1alpha1.ReconcileReportResource{},
+	}
+
+	for i := range resources {
+		if !report.Includes(resources[i].object.GetNamespace()) {
+			continue
+		}
+
+		summary := summarizeResource(&resources[i], now.Add(-report.Window()))
+
+		switch summary.State {
+		case ocmv1alpha1.ReconcileReportStateInSync:
+			status.Summary.InSync++
+		case ocmv1alpha1.ReconcileReportStateDrifted:
+			status.Summary.Drifted++
+		case ocmv1alpha1.ReconcileReportStateFailed:
+			status.Summary.Failed++
+		default:
+			status.Summary.Unknown++
+		}
+
+		status.Resources = append(status.Resources, summary)
+	}
+
+	status.Summary.Total = len(status.Resources)
+
+	// sort the resources so that the report is stable between generations
+	sort.Slice(status.Resources, func(i, j int) bool {
+		if status.Resources[i].Kind != status.Resources[j].Kind {
+			return status.Resources[i].Kind < status.Resources[j].Kind
+		}
+
+		if status.Resources[i].Namespace != status.Resources[j].Namespace {
+			return status.Resources[i].Namespace < status.Resources[j].Namespace
+		}
+
+		return status.Resources[i].Name < status.Resources[j].Name
+	})
+
+	return status
+}
+
+// summarizeResource produces the report summary for an individual managed resource.  Reconciliation
+// errors which were recorded before the since time are not included in the summary, although errors
+// are counted per hour, so those within the hour of the since time are included.
+func summarizeResource(resource *managedResource, since time.Time) ocmv1alpha1.ReconcileReportResource {
+	summary := ocmv1alpha1.ReconcileReportResource{
+		Kind:        resource.kind,
+		Namespace:   resource.object.GetNamespace(),
+		Name:        resource.object.GetName(),
+		ClusterName: resource.clusterName,
+		State:       ocmv1alpha1.ReconcileReportStateUnknown,
+	}
+
+	// summarize the errors within the report window.  the failed conditions are only kept within the
+	// bounded condition history, so the errors are counted from the failure counts of resources which
+	// keep them, unless fewer failures were counted than remain in the history, as is the case for
+	// failures which occurred before the resource kept its failure counts.
+	if failures := conditions.Failures(resource.object, since); len(failures) > 0 {
+		last := failures[len(failures)-1]
+
+		summary.Errors = len(failures)
+		summary.LastError = last.Message
+		summary.LastErrorTime = &last.LastTransitionTime
+	}
+
+	if count, ok := conditions.FailureCount(resource.object, since); ok && count > summary.Errors {
+		summary.Errors = count
+	}
+
+	// determine the state of the resource
+	reconciled := conditions.LastReconciled(resource.object)
+	if reconciled != nil {
+		summary.LastSuccessTime = &reconciled.LastTransitionTime
+	}
+
+	switch {
+	case conditions.IsFailed(resource.object):
+		summary.State = ocmv1alpha1.ReconcileReportStateFailed
+	case reconciled == nil:
+		summary.State = ocmv1alpha1.ReconcileReportStateUnknown
+	case reconciled.ObservedGeneration != 0 && reconciled.ObservedGeneration != resource.object.GetGeneration():
+		summary.State = ocmv1alpha1.ReconcileReportStateDrifted
+	default:
+		summary.State = ocmv1alpha1.ReconcileReportStateInSync
+	}
+
+	return summary
+}
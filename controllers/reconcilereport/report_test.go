@@ -0,0 +1,154 @@
+package reconcilereport
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var errTestOCM = errors.New("ocm unavailable")
+
+func testMachinePool(t *testing.T, generation int64, history []metav1.Condition, current ...*metav1.Condition) *ocmv1alpha1.MachinePool {
+	t.Helper()
+
+	machinePool := &ocmv1alpha1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: generation},
+		Status:     ocmv1alpha1.MachinePoolStatus{ConditionHistory: history},
+	}
+
+	for _, condition := range current {
+		machinePool.Status.Conditions = append(machinePool.Status.Conditions, *condition)
+	}
+
+	return machinePool
+}
+
+func testAt(condition *metav1.Condition, at time.Time, generation int64) *metav1.Condition {
+	condition.LastTransitionTime = metav1.NewTime(at)
+	condition.ObservedGeneration = generation
+
+	return condition
+}
+
+func testFailures(machinePool *ocmv1alpha1.MachinePool, counts map[time.Time]int) *ocmv1alpha1.MachinePool {
+	for hour, count := range counts {
+		machinePool.Status.ReconcileFailures = append(machinePool.Status.ReconcileFailures, ocmv1alpha1.ReconcileFailureCount{
+			Hour:  metav1.NewTime(hour.Truncate(time.Hour)),
+			Count: count,
+		})
+	}
+
+	return machinePool
+}
+
+func Test_summarizeResource(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	since := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		object     *ocmv1alpha1.MachinePool
+		wantState  ocmv1alpha1.ReconcileReportState
+		wantErrors int
+	}{
+		{
+			name:       "ensure a resource which has never reconciled is unknown",
+			object:     testMachinePool(t, 1, nil),
+			wantState:  ocmv1alpha1.ReconcileReportStateUnknown,
+			wantErrors: 0,
+		},
+		{
+			name: "ensure a resource reconciled at its current generation is in sync",
+			object: testMachinePool(t, 1, nil,
+				testAt(conditions.Reconciled(triggers.Create), now, 1),
+			),
+			wantState:  ocmv1alpha1.ReconcileReportStateInSync,
+			wantErrors: 0,
+		},
+		{
+			name: "ensure a resource reconciled at a previous generation has drifted",
+			object: testMachinePool(t, 2, nil,
+				testAt(conditions.Reconciled(triggers.Create), now, 1),
+			),
+			wantState:  ocmv1alpha1.ReconcileReportStateDrifted,
+			wantErrors: 0,
+		},
+		{
+			name: "ensure a resource which is reconciling uses the reconciled condition from its history",
+			object: testMachinePool(t, 1,
+				[]metav1.Condition{*testAt(conditions.Reconciled(triggers.Create), now.Add(-time.Minute), 1)},
+				testAt(conditions.Reconciling(triggers.Update), now, 1),
+			),
+			wantState:  ocmv1alpha1.ReconcileReportStateInSync,
+			wantErrors: 0,
+		},
+		{
+			name: "ensure a resource whose last reconciliation failed is failed",
+			object: testMachinePool(t, 1,
+				[]metav1.Condition{*testAt(conditions.ReconcileFailed("begin", errTestOCM), now.Add(-48*time.Hour), 1)},
+				testAt(conditions.Reconciled(triggers.Create), now, 1),
+				testAt(conditions.ReconcileFailed("getCurrentState", errTestOCM), now, 1),
+			),
+			wantState:  ocmv1alpha1.ReconcileReportStateFailed,
+			wantErrors: 1,
+		},
+		{
+			name: "ensure the failures counted within the window are reported beyond the condition history",
+			object: testFailures(testMachinePool(t, 1, nil,
+				testAt(conditions.ReconcileFailed("getCurrentState", errTestOCM), now, 1),
+			), map[time.Time]int{
+				now.Add(-48 * time.Hour): 5,
+				now.Add(-2 * time.Hour):  7,
+				now:                      30,
+			}),
+			wantState:  ocmv1alpha1.ReconcileReportStateFailed,
+			wantErrors: 37,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := summarizeResource(&managedResource{kind: "MachinePool", object: tt.object}, since)
+			if got.State != tt.wantState {
+				t.Errorf("summarizeResource() state = %v, want %v", got.State, tt.wantState)
+			}
+			if got.Errors != tt.wantErrors {
+				t.Errorf("summarizeResource() errors = %v, want %v", got.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func Test_summarize(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	included := testMachinePool(t, 1, nil, testAt(conditions.Reconciled(triggers.Create), now, 1))
+	excluded := testMachinePool(t, 1, nil)
+	excluded.Namespace = "other"
+
+	report := &ocmv1alpha1.ReconcileReport{
+		Spec: ocmv1alpha1.ReconcileReportSpec{Namespaces: []string{"test"}},
+	}
+
+	got := summarize(report, []managedResource{
+		{kind: "MachinePool", object: excluded},
+		{kind: "MachinePool", object: included},
+	}, now)
+
+	want := ocmv1alpha1.ReconcileReportSummary{Total: 1, InSync: 1}
+	if got.Summary != want {
+		t.Errorf("summarize() = %v, want %v", got.Summary, want)
+	}
+}
@@ -0,0 +1,84 @@
+package reconcilereport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+var (
+	ErrReconcileReportRequestConvert = errors.New("unable to convert generic request to reconcile report request")
+)
+
+// ReconcileReportRequest is an object that is unique to each reconciliation
+// request.
+type ReconcileReportRequest struct {
+	Context           context.Context
+	ControllerRequest ctrl.Request
+	Original          *ocmv1alpha1.ReconcileReport
+	Log               logr.Logger
+	Trigger           triggers.Trigger
+	Reconciler        *Controller
+}
+
+func (r *Controller) NewRequest(ctx context.Context, req ctrl.Request) (controllers.Request, error) {
+	original := &ocmv1alpha1.ReconcileReport{}
+
+	// get the object from the cluster
+	//nolint:wrapcheck
+	if err := r.Get(ctx, req.NamespacedName, original); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return &ReconcileReportRequest{}, fmt.Errorf("unable to fetch cluster object - %w", err)
+		}
+
+		return &ReconcileReportRequest{}, err
+	}
+
+	return &ReconcileReportRequest{
+		Original:          original,
+		ControllerRequest: req,
+		Context:           ctx,
+		Log:               log.Log,
+		Trigger:           triggers.GetTrigger(original),
+		Reconciler:        r,
+	}, nil
+}
+
+func (request *ReconcileReportRequest) GetObject() controllers.Workload {
+	return request.Original
+}
+
+// bindContext binds the context with which the requests of a phase are sent to the request.
+func (request *ReconcileReportRequest) bindContext(ctx context.Context) {
+	// the clients of the request are created from the bound context within each phase rather than
+	// being shared between phases, so binding the context binds each of them
+	request.Context = ctx
+}
+
+// execute executes a variety of different phases for the request.
+func (request *ReconcileReportRequest) execute(phases ...Phase) (ctrl.Result, error) {
+	return controllers.Execute(&request.Reconciler.Dependencies, &controllers.Execution{
+		Context:    request.Context,
+		Bind:       request.bindContext,
+		Reconciler: request.Reconciler,
+		Object:     request.Original,
+		Request:    request.ControllerRequest,
+		Unrecorded: true,
+	}, request, phases...)
+}
+
+// logValues produces a consistent set of log values for this request.
+func (request *ReconcileReportRequest) logValues() []interface{} {
+	return []interface{}{
+		"resource", request.Original.Name,
+	}
+}
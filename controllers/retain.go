@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	// AnnotationDeletionPolicy is the annotation which determines what happens to an object in
+	// OpenShift Cluster Manager when its custom resource is deleted.
+	AnnotationDeletionPolicy = "ocm.mobb.redhat.com/deletion-policy"
+
+	// DeletionPolicyRetain is the deletion policy which leaves an object in OpenShift Cluster Manager
+	// when its custom resource is deleted.
+	DeletionPolicyRetain = "retain"
+
+	eventReasonRetained = "Retained"
+)
+
+// Retainable represents a controller whose objects may be released from the operator without deleting
+// them from OpenShift Cluster Manager.
+type Retainable interface {
+	kubernetes.Client
+
+	GetRecorder() record.EventRecorder
+}
+
+// RetainRequested determines if an object has requested that it is left in OpenShift Cluster Manager
+// when its custom resource is deleted.  Only the deletion policy annotation of the operator is honored,
+// rather than the annotations with which ArgoCD and Flux are told never to delete or prune an object,
+// as those also apply to a custom resource which is deleted deliberately, which would otherwise leave
+// its object orphaned in OpenShift Cluster Manager.
+func RetainRequested(object client.Object) bool {
+	return strings.EqualFold(object.GetAnnotations()[AnnotationDeletionPolicy], DeletionPolicyRetain)
+}
+
+// retain releases an object which has requested to be retained by removing its finalizer without
+// deleting it from OpenShift Cluster Manager.  It returns whether the object was released.
+func retain(ctx context.Context, controller Controller, object client.Object) (bool, error) {
+	retainable, ok := controller.(Retainable)
+	if !ok || !RetainRequested(object) {
+		return false, nil
+	}
+
+	if err := RemoveFinalizer(ctx, retainable, object); err != nil {
+		return false, fmt.Errorf("unable to release retained object - %w", err)
+	}
+
+	message := "deletion policy requests that the object is retained; removed the finalizer, leaving the object in ocm"
+
+	log.FromContext(ctx).Info(message, "resource", fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName()))
+	events.RegisterWarning(object, retainable.GetRecorder(), eventReasonRetained, message)
+
+	return true, nil
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import "testing"
+
+func TestRetainRequested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name:        "ensure an object without annotations is not retained",
+			annotations: nil,
+			want:        false,
+		},
+		{
+			name:        "ensure the retain deletion policy is retained",
+			annotations: map[string]string{AnnotationDeletionPolicy: "Retain"},
+			want:        true,
+		},
+		{
+			name:        "ensure the delete deletion policy is not retained",
+			annotations: map[string]string{AnnotationDeletionPolicy: "delete"},
+			want:        false,
+		},
+		{
+			name:        "ensure the argocd delete sync option alone is not retained",
+			annotations: map[string]string{"argocd.argoproj.io/sync-options": "Prune=false, Delete=false"},
+			want:        false,
+		},
+		{
+			name:        "ensure disabled flux pruning alone is not retained",
+			annotations: map[string]string{"kustomize.toolkit.fluxcd.io/prune": "disabled"},
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := RetainRequested(testImportObject(tt.annotations)); got != tt.want {
+				t.Errorf("RetainRequested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// SecretReferencer represents an object which references secrets in its namespace.
+type SecretReferencer interface {
+	client.Object
+
+	// GetSecretKeys returns the keys which are required from each referenced secret, indexed by the
+	// name of the secret.
+	GetSecretKeys() map[string][]string
+}
+
+// MissingSecret returns the name of the first secret, in alphabetical order, referenced by an object
+// which does not yet exist or does not yet contain the required keys, along with the missing keys.  An
+// empty name is returned when all referenced secrets are available.
+func MissingSecret(ctx context.Context, r kubernetes.Client, object SecretReferencer) (string, []string, error) {
+	references := object.GetSecretKeys()
+
+	names := make([]string, 0, len(references))
+	for name := range references {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		missing, err := kubernetes.MissingSecretKeys(ctx, r, name, object.GetNamespace(), references[name]...)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if len(missing) > 0 {
+			return name, missing, nil
+		}
+	}
+
+	return "", nil, nil
+}
+
+// SecretPredicate returns a predicate which only allows events for secrets.  It allows the events of
+// referenced secrets through the event filters of a controller, which otherwise ignore updates that do
+// not change the generation of an object, so that an object which is waiting for a secret is reconciled
+// as soon as the secret is populated.
+func SecretPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		_, ok := object.(*corev1.Secret)
+
+		return ok
+	})
+}
+
+// EnqueueSecretReferences returns an event handler which enqueues each object of a list type, in the
+// namespace of a secret, which references the secret.  The objects are looked up by the indexed fields
+// which may reference a secret.
+func EnqueueSecretReferences(r kubernetes.Client, list client.ObjectList, fields ...string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(secret client.Object) []reconcile.Request {
+		objects, err := ListReferences(context.Background(), r, list, secret.GetNamespace(), secret.GetName(), fields...)
+		if err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+
+		for _, item := range objects {
+			// an indexed field may also reference an object which is not a secret, so only enqueue an
+			// object which requires keys from the secret
+			object, ok := item.(SecretReferencer)
+			if !ok {
+				continue
+			}
+
+			if _, references := object.GetSecretKeys()[secret.GetName()]; references {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+			}
+		}
+
+		return requests
+	})
+}
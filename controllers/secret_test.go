@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// secretClient is a client which only returns the secrets which it was created with.
+type secretClient struct {
+	kubernetes.FakeClient
+
+	secrets map[string]*corev1.Secret
+}
+
+func (c *secretClient) Get(_ context.Context, key types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+	secret, ok := c.secrets[key.Name]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+	}
+
+	secret.DeepCopyInto(object.(*corev1.Secret))
+
+	return nil
+}
+
+func TestMissingSecret(t *testing.T) {
+	t.Parallel()
+
+	ldap := &ocmv1alpha1.LDAPIdentityProvider{}
+	ldap.Namespace = "ocm"
+	ldap.Spec.BindPassword = configv1.SecretNameReference{Name: "bind"}
+	ldap.Spec.CA = configv1.ConfigMapNameReference{Name: "ca"}
+	ldap.Spec.CAKind = ocmv1alpha1.LDAPCAKindSecret
+
+	tests := []struct {
+		name        string
+		secrets     map[string]*corev1.Secret
+		wantName    string
+		wantMissing []string
+	}{
+		{
+			name: "ensure no secret is returned when all secrets are available",
+			secrets: map[string]*corev1.Secret{
+				"bind": {Data: map[string][]byte{ocmv1alpha1.LDAPBindPasswordKey: []byte("password")}},
+				"ca":   {Data: map[string][]byte{ocmv1alpha1.LDAPCAKey: []byte("cert")}},
+			},
+			wantName:    "",
+			wantMissing: nil,
+		},
+		{
+			name:        "ensure the first secret which does not exist is returned",
+			secrets:     map[string]*corev1.Secret{},
+			wantName:    "bind",
+			wantMissing: []string{ocmv1alpha1.LDAPBindPasswordKey},
+		},
+		{
+			name: "ensure a secret without the required key is returned",
+			secrets: map[string]*corev1.Secret{
+				"bind": {Data: map[string][]byte{ocmv1alpha1.LDAPBindPasswordKey: []byte("password")}},
+				"ca":   {Data: map[string][]byte{"other": []byte("cert")}},
+			},
+			wantName:    "ca",
+			wantMissing: []string{ocmv1alpha1.LDAPCAKey},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			name, missing, err := MissingSecret(context.Background(), &secretClient{secrets: tt.secrets}, ldap)
+			if err != nil {
+				t.Fatalf("MissingSecret() error = %v", err)
+			}
+
+			if name != tt.wantName {
+				t.Errorf("MissingSecret() name = %v, want %v", name, tt.wantName)
+			}
+
+			if !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("MissingSecret() missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultClusterConcurrency is the default number of objects which may be reconciled against the
+	// same cluster in OpenShift Cluster Manager at once.
+	DefaultClusterConcurrency = 5
+
+	// DefaultMaxConcurrentReconciles is the default number of objects which each controller may
+	// reconcile at once.  It is greater than the default cluster concurrency, so that the objects of a
+	// single busy cluster do not occupy every worker of a controller.
+	DefaultMaxConcurrentReconciles = 10
+
+	// DefaultThrottleRequeue is the minimum amount of time after which the reconciliation of an object
+	// is retried when too many objects are already being reconciled against its cluster.
+	DefaultThrottleRequeue = 2 * time.Second
+
+	// staggerFraction is the maximum fraction of a requeue interval by which the requeue of an object
+	// is delayed, so that objects which are reconciled at the same interval are spread out.
+	staggerFraction = 0.1
+
+	// staggerBuckets is the number of distinct offsets by which the requeue of an object is delayed.
+	staggerBuckets = 1000
+)
+
+// ClusterWorkload represents a workload which manages an object within a cluster in OpenShift Cluster
+// Manager.
+type ClusterWorkload interface {
+	Workload
+
+	GetClusterName() string
+}
+
+// Throttled represents a controller which throttles the reconciliation of objects which target the
+// same cluster.
+type Throttled interface {
+	GetThrottle() *ClusterThrottle
+}
+
+// ClusterThrottle throttles the reconciliation of objects which target the same cluster in OpenShift
+// Cluster Manager, so that dozens of objects targeting one cluster do not all send requests to
+// OpenShift Cluster Manager in the same second.  It limits the number of objects which may be
+// reconciled against each cluster at once, across all controllers, and staggers the requeue of each
+// object by a deterministic offset derived from the object so that objects which were created together
+// do not remain in lockstep at each interval.
+type ClusterThrottle struct {
+	// Limit is the number of objects which may be reconciled against the same cluster at once.  The
+	// number of objects is not limited if this is zero, although requeues are still staggered.
+	Limit int
+
+	mutex    sync.Mutex
+	inFlight map[string]int
+}
+
+// NewClusterThrottle returns a new throttle which allows a limited number of objects to be reconciled
+// against the same cluster at once.
+func NewClusterThrottle(limit int) *ClusterThrottle {
+	return &ClusterThrottle{
+		Limit:    limit,
+		inFlight: map[string]int{},
+	}
+}
+
+// Acquire reserves a slot to reconcile an object against a cluster.  It returns whether a slot was
+// available, along with a function which releases the slot once the reconciliation has finished.  A nil
+// throttle, or a workload without a cluster, always has a slot available.
+func (throttle *ClusterThrottle) Acquire(cluster string) (func(), bool) {
+	if throttle == nil || throttle.Limit < 1 || cluster == "" {
+		return func() {}, true
+	}
+
+	throttle.mutex.Lock()
+	defer throttle.mutex.Unlock()
+
+	if throttle.inFlight[cluster] >= throttle.Limit {
+		return func() {}, false
+	}
+
+	throttle.inFlight[cluster]++
+
+	return func() {
+		throttle.mutex.Lock()
+		defer throttle.mutex.Unlock()
+
+		if throttle.inFlight[cluster]--; throttle.inFlight[cluster] < 1 {
+			delete(throttle.inFlight, cluster)
+		}
+	}, true
+}
+
+// Stagger returns the requeue interval of an object delayed by a deterministic offset of up to a tenth
+// of the interval, derived from the key of the object.  A nil throttle does not stagger requeues.
+func (throttle *ClusterThrottle) Stagger(key string, requeue time.Duration) time.Duration {
+	if throttle == nil || requeue <= 0 {
+		return requeue
+	}
+
+	return requeue + staggerOffset(key, time.Duration(float64(requeue)*staggerFraction))
+}
+
+// Busy returns the amount of time after which the reconciliation of an object is retried when too many
+// objects are already being reconciled against its cluster.
+func (throttle *ClusterThrottle) Busy(key string) time.Duration {
+	return DefaultThrottleRequeue + staggerOffset(key, DefaultThrottleRequeue)
+}
+
+// staggerOffset returns a deterministic offset within a window which is derived from a key.
+func staggerOffset(key string, window time.Duration) time.Duration {
+	hash := fnv.New32a()
+
+	//nolint:errcheck
+	hash.Write([]byte(key))
+
+	return window * time.Duration(hash.Sum32()%staggerBuckets) / staggerBuckets
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClusterThrottle_Acquire(t *testing.T) {
+	t.Parallel()
+
+	throttle := NewClusterThrottle(2)
+
+	releaseFirst, ok := throttle.Acquire("cluster-a")
+	if !ok {
+		t.Fatalf("ClusterThrottle.Acquire() ok = %v, want %v", ok, true)
+	}
+
+	if _, ok := throttle.Acquire("cluster-a"); !ok {
+		t.Fatalf("ClusterThrottle.Acquire() ok = %v, want %v", ok, true)
+	}
+
+	// the limit applies per cluster
+	if _, ok := throttle.Acquire("cluster-a"); ok {
+		t.Errorf("ClusterThrottle.Acquire() ok = %v, want %v", ok, false)
+	}
+
+	if _, ok := throttle.Acquire("cluster-b"); !ok {
+		t.Errorf("ClusterThrottle.Acquire() ok = %v, want %v", ok, true)
+	}
+
+	// releasing a slot allows another object to be reconciled
+	releaseFirst()
+
+	if _, ok := throttle.Acquire("cluster-a"); !ok {
+		t.Errorf("ClusterThrottle.Acquire() ok = %v, want %v", ok, true)
+	}
+
+	// a nil or unlimited throttle always has a slot available
+	for _, unlimited := range []*ClusterThrottle{nil, NewClusterThrottle(0)} {
+		for i := 0; i < 3; i++ {
+			if _, ok := unlimited.Acquire("cluster-a"); !ok {
+				t.Errorf("ClusterThrottle.Acquire() ok = %v, want %v", ok, true)
+			}
+		}
+	}
+}
+
+func TestClusterThrottle_Stagger(t *testing.T) {
+	t.Parallel()
+
+	throttle := NewClusterThrottle(1)
+
+	tests := []struct {
+		name     string
+		throttle *ClusterThrottle
+		requeue  time.Duration
+	}{
+		{
+			name:     "ensure a requeue is staggered by up to a tenth of the interval",
+			throttle: throttle,
+			requeue:  5 * time.Minute,
+		},
+		{
+			name:     "ensure a nil throttle does not stagger",
+			throttle: nil,
+			requeue:  5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.throttle.Stagger("default/test", tt.requeue)
+
+			if tt.throttle == nil {
+				if got != tt.requeue {
+					t.Errorf("ClusterThrottle.Stagger() = %v, want %v", got, tt.requeue)
+				}
+
+				return
+			}
+
+			if got < tt.requeue || got >= tt.requeue+tt.requeue/10 {
+				t.Errorf("ClusterThrottle.Stagger() = %v, want within a tenth of %v", got, tt.requeue)
+			}
+
+			if again := tt.throttle.Stagger("default/test", tt.requeue); again != got {
+				t.Errorf("ClusterThrottle.Stagger() = %v, want deterministic %v", again, got)
+			}
+		})
+	}
+
+	// objects which are reconciled at the same interval are spread out
+	if throttle.Stagger("default/a", time.Minute) == throttle.Stagger("default/b", time.Minute) {
+		t.Errorf("ClusterThrottle.Stagger() did not spread out objects")
+	}
+}
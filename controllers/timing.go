@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// TimedWorkload represents a workload which reports the duration of its most recent reconciliation,
+// broken down by reconciliation phase, in its status.
+type TimedWorkload interface {
+	Workload
+
+	GetLastReconcile() *ocmv1alpha1.ReconcileTiming
+	SetLastReconcile(*ocmv1alpha1.ReconcileTiming)
+}
+
+// lastReconcilePrecision is the precision at which the recorded durations of a reconciliation are
+// compared, so that a reconciliation whose duration only differs by a fraction of the precision from
+// the recorded reconciliation does not write the status.
+const lastReconcilePrecision = time.Second
+
+// RecordLastReconcile records the duration of a reconciliation which began at the start time, along
+// with the duration of each phase which was run, in the status of the workload.  The status is only
+// patched when the phases which were run, or their durations at the precision of a second, differ from
+// the recorded reconciliation, so that a steady state reconciliation does not write the status.
+func RecordLastReconcile(
+	ctx context.Context,
+	reconciler kubernetes.Client,
+	object TimedWorkload,
+	start time.Time,
+	phases []ocmv1alpha1.PhaseTiming,
+) error {
+	// create a copy of the original and convert to a client object
+	original, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return ErrConvertClientObject
+	}
+
+	timing := newReconcileTiming(start, time.Now(), phases)
+	if sameTiming(object.GetLastReconcile(), timing) {
+		return nil
+	}
+
+	object.SetLastReconcile(timing)
+
+	//nolint:wrapcheck
+	return kubernetes.PatchStatus(ctx, reconciler, original, object)
+}
+
+func newReconcileTiming(start, end time.Time, phases []ocmv1alpha1.PhaseTiming) *ocmv1alpha1.ReconcileTiming {
+	return &ocmv1alpha1.ReconcileTiming{
+		StartTime: metav1.NewTime(start),
+		Duration:  metav1.Duration{Duration: end.Sub(start)},
+		Phases:    phases,
+	}
+}
+
+// sameTiming determines if two reconciliations ran the same phases for the same durations, at the
+// precision at which the durations are compared.
+func sameTiming(recorded, timing *ocmv1alpha1.ReconcileTiming) bool {
+	if recorded == nil || len(recorded.Phases) != len(timing.Phases) || !sameDuration(recorded.Duration, timing.Duration) {
+		return false
+	}
+
+	for i := range timing.Phases {
+		if recorded.Phases[i].Name != timing.Phases[i].Name || !sameDuration(recorded.Phases[i].Duration, timing.Phases[i].Duration) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameDuration determines if two durations are equal at the precision at which they are compared.
+func sameDuration(recorded, duration metav1.Duration) bool {
+	return recorded.Round(lastReconcilePrecision) == duration.Round(lastReconcilePrecision)
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func Test_sameTiming(t *testing.T) {
+	t.Parallel()
+
+	timing := func(total time.Duration, phases ...string) *ocmv1alpha1.ReconcileTiming {
+		timings := make([]ocmv1alpha1.PhaseTiming, len(phases))
+		for i := range phases {
+			timings[i] = ocmv1alpha1.PhaseTiming{Name: phases[i], Duration: metav1.Duration{Duration: total}}
+		}
+
+		return &ocmv1alpha1.ReconcileTiming{Duration: metav1.Duration{Duration: total}, Phases: timings}
+	}
+
+	tests := []struct {
+		name     string
+		recorded *ocmv1alpha1.ReconcileTiming
+		timing   *ocmv1alpha1.ReconcileTiming
+		want     bool
+	}{
+		{
+			name:     "ensure a timing which has not been recorded is not the same",
+			recorded: nil,
+			timing:   timing(time.Second, "begin"),
+			want:     false,
+		},
+		{
+			name:     "ensure durations which differ by less than the precision are the same",
+			recorded: timing(1200*time.Millisecond, "begin", "complete"),
+			timing:   timing(1300*time.Millisecond, "begin", "complete"),
+			want:     true,
+		},
+		{
+			name:     "ensure durations which differ by more than the precision are not the same",
+			recorded: timing(time.Second, "begin", "complete"),
+			timing:   timing(3*time.Second, "begin", "complete"),
+			want:     false,
+		},
+		{
+			name:     "ensure different phases are not the same",
+			recorded: timing(time.Second, "begin", "complete"),
+			timing:   timing(time.Second, "begin"),
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := sameTiming(tt.recorded, tt.timing); got != tt.want {
+				t.Errorf("sameTiming() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
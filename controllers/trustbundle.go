@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	// DefaultTrustBundleInterval is the default interval at which the trust bundle of the connection to
+	// OpenShift Cluster Manager is checked for changes.
+	DefaultTrustBundleInterval = 30 * time.Second
+
+	// DefaultTrustBundleKey is the default key of the trust bundle within its config map.  This matches
+	// the key of the trusted CA bundle which is injected by OpenShift into a config map labeled with
+	// config.openshift.io/inject-trusted-cabundle=true.
+	DefaultTrustBundleKey = "ca-bundle.crt"
+)
+
+// TrustBundleWatcher periodically reads the trust bundle of the connection to OpenShift Cluster Manager
+// from a config map, and reloads the transport of the connection when the trust bundle changes, so that
+// a rotated bundle is trusted without restarting the operator.
+type TrustBundleWatcher struct {
+	Client    kubernetes.Client
+	Transport *ocm.ProxyTransport
+	Log       logr.Logger
+	Interval  time.Duration
+
+	// Namespace, Name and Key locate the trust bundle within its config map.
+	Namespace string
+	Name      string
+	Key       string
+
+	// failing tracks whether the previous reload failed, so that a failure is only logged once
+	failing bool
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.  Every replica of the
+// operator maintains its own connection to OpenShift Cluster Manager.
+func (watcher *TrustBundleWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements the manager.Runnable interface.  It reloads the trust bundle on startup and at each
+// interval until the context is cancelled.
+func (watcher *TrustBundleWatcher) Start(ctx context.Context) error {
+	interval := watcher.Interval
+	if interval == 0 {
+		interval = DefaultTrustBundleInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		watcher.Reload(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reload reads the trust bundle from its config map and reloads the transport when it has changed.
+// The previous trust bundle remains in use when the config map can not be read or is invalid.
+func (watcher *TrustBundleWatcher) Reload(ctx context.Context) {
+	if err := watcher.reload(ctx); err != nil {
+		if !watcher.failing {
+			watcher.Log.Error(err, "unable to reload trust bundle")
+		}
+
+		watcher.failing = true
+
+		return
+	}
+
+	watcher.failing = false
+}
+
+func (watcher *TrustBundleWatcher) reload(ctx context.Context) error {
+	bundle, err := kubernetes.GetConfigMapData(ctx, watcher.Client, watcher.Name, watcher.Namespace, watcher.Key)
+	if err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+
+	if bundle == "" {
+		return fmt.Errorf(
+			"missing key [%s] in configmap [%s/%s] - %w",
+			watcher.Key,
+			watcher.Namespace,
+			watcher.Name,
+			ocm.ErrInvalidTrustBundle,
+		)
+	}
+
+	changed, err := watcher.Transport.SetTrustBundle([]byte(bundle))
+	if err != nil {
+		return fmt.Errorf(
+			"invalid trust bundle in configmap [%s/%s] key [%s] - %w",
+			watcher.Namespace,
+			watcher.Name,
+			watcher.Key,
+			err,
+		)
+	}
+
+	if changed {
+		watcher.Log.Info("reloaded trust bundle", "namespace", watcher.Namespace, "name", watcher.Name)
+	}
+
+	return nil
+}
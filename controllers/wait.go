@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// WaitReasonClusterInstalling is the reason for waiting on a cluster which is still being installed.
+	WaitReasonClusterInstalling = "ClusterInstalling"
+
+	// WaitReasonClusterReferenceUnresolved is the reason for waiting on a cluster reference which does
+	// not yet exist or has not yet resolved its cluster.
+	WaitReasonClusterReferenceUnresolved = "ClusterReferenceUnresolved"
+
+	// WaitReasonSecretUnavailable is the reason for waiting on a secret which does not yet exist or does
+	// not yet contain the required keys.
+	WaitReasonSecretUnavailable = "SecretUnavailable"
+
+	// WaitReasonUpgradeBlocked is the reason for waiting on a cluster which blocks an upgrade, for
+	// example because the cluster is not ready or its control plane has not yet been upgraded.
+	WaitReasonUpgradeBlocked = "UpgradeBlocked"
+)
+
+// Waiting is returned from a phase of reconciliation which is unable to continue until an external
+// dependency, such as a cluster or a secret, becomes ready.  It is not a failure of the object, so
+// the object is reported as waiting on the dependency and requeued after the duration rather than
+// retried as an error.
+type Waiting struct {
+	Dependency string
+	Reason     string
+	Duration   time.Duration
+}
+
+// WaitFor returns an error to indicate that reconciliation is waiting for a dependency to become
+// ready, for a reason, and should be requeued after a duration.  The dependency identifies the
+// object which is being waited for (e.g. cluster [my-cluster]).
+func WaitFor(dependency, reason string, duration time.Duration) error {
+	return &Waiting{
+		Dependency: dependency,
+		Reason:     reason,
+		Duration:   duration,
+	}
+}
+
+// Error returns the message of a wait for a dependency.
+func (waiting *Waiting) Error() string {
+	return fmt.Sprintf("waiting for %s (%s)", waiting.Dependency, waiting.Reason)
+}
+
+// AsWaiting returns the wait for a dependency from an error, if the error was returned by WaitFor.
+func AsWaiting(err error) (*Waiting, bool) {
+	var waiting *Waiting
+	if errors.As(err, &waiting) {
+		return waiting, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAsWaiting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want *Waiting
+	}{
+		{
+			name: "ensure a wrapped wait is returned",
+			err: fmt.Errorf("getCurrentState phase reconciliation error - %w",
+				WaitFor("cluster [test]", WaitReasonClusterInstalling, time.Minute),
+			),
+			want: &Waiting{Dependency: "cluster [test]", Reason: WaitReasonClusterInstalling, Duration: time.Minute},
+		},
+		{
+			name: "ensure an error which is not a wait is not returned",
+			err:  errors.New("cluster not found"),
+			want: nil,
+		},
+		{
+			name: "ensure a nil error is not returned",
+			err:  nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := AsWaiting(tt.err)
+			if ok != (tt.want != nil) {
+				t.Fatalf("AsWaiting() ok = %v, want %v", ok, tt.want != nil)
+			}
+
+			if tt.want != nil && *got != *tt.want {
+				t.Errorf("AsWaiting() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
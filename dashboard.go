@@ -0,0 +1,53 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rh-mobb/ocm-operator/pkg/dashboard"
+)
+
+const (
+	dashboardCommand = "dashboard"
+)
+
+// runDashboard runs the dashboard subcommand, which writes a grafana dashboard for the metrics of
+// the operator to standard output.  It returns the exit code of the subcommand.
+func runDashboard(args []string) int {
+	flags := flag.NewFlagSet(dashboardCommand, flag.ExitOnError)
+
+	//nolint:errcheck
+	flags.Parse(args)
+
+	out, err := dashboard.NewDashboard(dashboard.Panels()...).JSON()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	if _, err := os.Stdout.Write(out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	return 0
+}
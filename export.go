@@ -0,0 +1,91 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/rh-mobb/ocm-operator/pkg/export"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	exportCommand = "export"
+)
+
+// runExport runs the export subcommand, which writes the manifests for the objects of an existing
+// cluster in OpenShift Cluster Manager to standard output.  It returns the exit code of the
+// subcommand.
+func runExport(args []string) int {
+	flags := flag.NewFlagSet(exportCommand, flag.ExitOnError)
+
+	clusterName := flags.String("cluster", "", "The name of the cluster in OpenShift Cluster Manager to export.")
+	namespace := flags.String("namespace", "default", "The namespace of the exported manifests.")
+	tokenFile := flags.String("ocm-token-file", "/tmp/ocm.json", "The OCM JSON Token file to use for the OCM Connection")
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flags)
+
+	//nolint:errcheck
+	flags.Parse(args)
+
+	// log to standard error so that logs are not mixed with the exported manifests
+	log := zap.New(zap.UseFlagOptions(&opts), zap.WriteTo(os.Stderr)).WithName(exportCommand)
+	ctrl.SetLogger(log)
+
+	if *clusterName == "" {
+		log.Info("missing required flag", "flag", "--cluster")
+		flags.Usage()
+
+		return 1
+	}
+
+	connection, err := newConnection(*tokenFile, "", nil, ocm.Timeouts{Read: ocm.DefaultReadTimeout, Write: ocm.DefaultWriteTimeout}, nil)
+	if err != nil {
+		log.Error(err, "unable to create ocm client", "file", *tokenFile)
+
+		return 1
+	}
+	defer connection.Close()
+
+	exporter := &export.Exporter{
+		Connection:  connection,
+		ClusterName: *clusterName,
+		Namespace:   *namespace,
+		Log:         log,
+	}
+
+	objects, err := exporter.Objects()
+	if err != nil {
+		log.Error(err, "unable to export cluster", "cluster", *clusterName)
+
+		return 1
+	}
+
+	if err := export.Write(os.Stdout, objects...); err != nil {
+		log.Error(err, "unable to write manifests")
+
+		return 1
+	}
+
+	return 0
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -29,21 +32,56 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
 	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/controllers/clusterinfo"
+	"github.com/rh-mobb/ocm-operator/controllers/clusterlabels"
+	"github.com/rh-mobb/ocm-operator/controllers/clusternotification"
+	"github.com/rh-mobb/ocm-operator/controllers/clusterreference"
+	"github.com/rh-mobb/ocm-operator/controllers/clusterregistration"
+	"github.com/rh-mobb/ocm-operator/controllers/clusterversioncheck"
 	"github.com/rh-mobb/ocm-operator/controllers/gitlabidentityprovider"
 	"github.com/rh-mobb/ocm-operator/controllers/ldapidentityprovider"
 	"github.com/rh-mobb/ocm-operator/controllers/machinepool"
+	"github.com/rh-mobb/ocm-operator/controllers/pullsecret"
+	"github.com/rh-mobb/ocm-operator/controllers/reconcilereport"
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+	"github.com/rh-mobb/ocm-operator/pkg/health"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	metricsserver "github.com/rh-mobb/ocm-operator/pkg/metrics"
+	"github.com/rh-mobb/ocm-operator/pkg/migration"
+	"github.com/rh-mobb/ocm-operator/pkg/monitoring"
+	"github.com/rh-mobb/ocm-operator/pkg/notify"
 	"github.com/rh-mobb/ocm-operator/pkg/ocm"
 	//+kubebuilder:scaffold:imports
 )
 
 const (
 	defaultPollerIntervalMinutes = 5
+	defaultCoalesceWindow        = 5 * time.Second
+	compatibilityProbeTimeout    = 30 * time.Second
+)
+
+// names of the controllers, used as the prefix of the flags which tune each individual controller.
+const (
+	machinePoolController            = "machinepool"
+	gitLabIdentityProviderController = "gitlab"
+	ldapIdentityProviderController   = "ldap"
+	clusterNotificationController    = "clusternotification"
+	clusterRegistrationController    = "clusterregistration"
+	clusterLabelsController          = "clusterlabels"
+	clusterVersionCheckController    = "clusterversioncheck"
+	clusterReferenceController       = "clusterreference"
+	clusterInfoController            = "clusterinfo"
+	pullSecretController             = "pullsecret"
+	reconcileReportController        = "reconcilereport"
 )
 
 var (
@@ -59,16 +97,111 @@ func init() {
 }
 
 func main() {
+	// run a subcommand if one was requested rather than the operator
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case exportCommand:
+			os.Exit(runExport(os.Args[2:]))
+		case validateCommand:
+			os.Exit(runValidate(os.Args[2:]))
+		case alertsCommand:
+			os.Exit(runAlerts(os.Args[2:]))
+		case dashboardCommand:
+			os.Exit(runDashboard(os.Args[2:]))
+		}
+	}
+
 	config := controllers.Config{}
 
 	flag.StringVar(&config.MetricsAddress, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&config.MetricsCertDir, "metrics-cert-dir", "", "The directory containing the tls.crt and tls.key files "+
+		"used to serve the metric endpoint over HTTPS.  The metric endpoint is served over HTTP if this is not set.")
+	flag.StringVar(&config.MetricsClientCAFile, "metrics-client-ca-file", "", "The CA file used to verify the client "+
+		"certificates of metric scrapers.  Client certificates are only required if this is set.")
 	flag.StringVar(&config.ProbeAddress, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&config.EnableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&config.EnableWebhooks, "enable-webhooks", true,
+		"Enable the admission webhooks which validate custom resources before they are persisted.")
+	flag.StringVar(&config.WebhookCertDir, "webhook-cert-dir", defaultWebhookCertDir(), "The directory containing the "+
+		"tls.crt and tls.key files used by the webhook server.  Certificates are reloaded when they are rotated.")
 	flag.StringVar(&config.TokenFile, "ocm-token-file", "/tmp/ocm.json", "The OCM JSON Token file to use for the OCM Connection")
+	flag.StringVar(&config.OCMEnvironment, "ocm-environment", ocm.EnvironmentProduction, "The OCM environment which the "+
+		"token file connects to, and which objects target when they do not set spec.ocmEnvironment.  One of '"+
+		ocm.EnvironmentProduction+"', '"+ocm.EnvironmentStage+"' or '"+ocm.EnvironmentIntegration+"'.")
+	flag.StringVar(&config.OCMEnvironmentTokenFiles, "ocm-environment-token-files", "", "A comma-separated list of "+
+		"environment=file pairs of the OCM JSON token files used to connect to additional OCM environments, for example "+
+		"stage=/tmp/ocm-stage.json, so that objects may target them with spec.ocmEnvironment.")
+	flag.StringVar(&config.OCMRequestHeaders, "ocm-request-headers", "", "A comma-separated list of key=value headers to "+
+		"inject into each request to OCM, for example to allow OCM support to trace requests.")
+	flag.StringVar(&config.OCMHTTPProxy, "ocm-http-proxy", "", "The proxy used for http requests to OCM.  The "+
+		"HTTP_PROXY environment variable is used if this is not set.")
+	flag.StringVar(&config.OCMHTTPSProxy, "ocm-https-proxy", "", "The proxy used for https requests to OCM.  The "+
+		"HTTPS_PROXY environment variable is used if this is not set.")
+	flag.StringVar(&config.OCMNoProxy, "ocm-no-proxy", "", "A comma-separated list of hosts which are requested "+
+		"without a proxy.  The NO_PROXY environment variable is used if this is not set.")
+	flag.StringVar(&config.OCMTrustBundleConfigMap, "ocm-trust-bundle-configmap", "", "The name of a configmap, in the "+
+		"namespace of the operator, containing a CA bundle in the "+controllers.DefaultTrustBundleKey+" key which is trusted "+
+		"for requests to OCM.  The bundle is reloaded when the configmap changes.")
+	flag.StringVar(&config.OCMStatusServices, "ocm-status-services", "", "A comma-separated list of the full names of "+
+		"services in the OCM status board which are checked for maintenance.  Controllers back off while the OCM API, "+
+		"or any of these services, is under maintenance.")
+	flag.DurationVar(&config.OCMReadTimeout, "ocm-read-timeout", ocm.DefaultReadTimeout, "The amount of time after "+
+		"which a request which reads from OCM is abandoned.  Requests are not limited if this is 0.")
+	flag.DurationVar(&config.OCMWriteTimeout, "ocm-write-timeout", ocm.DefaultWriteTimeout, "The amount of time after "+
+		"which a request which creates, updates or deletes an object in OCM is abandoned.  Requests are not limited "+
+		"if this is 0.")
+	flag.StringVar(&config.OCMFaults, "ocm-faults", "", "A comma-separated list of key=value pairs of faults which are "+
+		"injected into requests to OCM, for debugging how the operator handles an unreliable OCM, for example "+
+		"error-rate=0.1,malformed-rate=0.05,latency=500ms,seed=42.  This must never be set in production.")
 	flag.IntVar(&config.PollerIntervalMinutes, "poller-interval", defaultPollerIntervalMinutes, "Default interval, in minutes, by "+
 		"which the controller should reconcile desired state.")
+	flag.DurationVar(&config.CoalesceWindow, "coalesce-window", defaultCoalesceWindow, "The amount of time for which the "+
+		"spec of an object must be unchanged before it is reconciled, so that rapid successive updates are applied to OCM "+
+		"once.  Updates are not coalesced if this is 0.")
+	flag.DurationVar(&config.DeletionTimeout, "deletion-timeout", controllers.DefaultDeletionTimeout, "The amount of time "+
+		"after which the deletion of an object from OCM which has not completed is escalated.  The finalizer of an "+
+		"object which has the "+controllers.AnnotationForceDelete+"=true annotation is then removed.  Deletions are not "+
+		"escalated if this is 0.")
+	flag.DurationVar(&config.PhaseTimeout, "phase-timeout", controllers.DefaultPhaseTimeout, "The amount of time after "+
+		"which a phase of reconciliation which has not completed, for example because a request to OCM or a lookup of a "+
+		"secret is stuck, is abandoned and the reconciliation is retried.  Phases are not limited if this is 0.")
+	flag.DurationVar(&config.DriftInterval, "drift-interval", controllers.DefaultDriftInterval, "The amount of time "+
+		"after which the state of an object in OCM is read again, to detect drift, even though neither its generation "+
+		"nor its inputs, such as referenced secrets, have changed since they were last applied.  The state is read on "+
+		"every reconciliation if this is 0.")
+	flag.IntVar(&config.ClusterConcurrency, "cluster-concurrency", controllers.DefaultClusterConcurrency, "The number of "+
+		"objects which may be reconciled against the same OCM cluster at once, across all controllers.  Requeues "+
+		"are staggered so that objects targeting the same cluster are spread out.  The number of objects is not "+
+		"limited if this is 0.")
+	flag.IntVar(&config.MaxConcurrentReconciles, "max-concurrent-reconciles", controllers.DefaultMaxConcurrentReconciles, "The "+
+		"number of objects which each controller may reconcile at once.  It should be greater than --cluster-concurrency "+
+		"so that the objects of a single busy cluster do not occupy every worker of a controller.")
+	flag.StringVar(&config.AllowedOrganizations, "allowed-organizations", "", "A comma-separated list of OCM organization "+
+		"ids which managed clusters must belong to.  Clusters must belong to the organization of the authenticated OCM "+
+		"account if this is not set.")
+	flag.BoolVar(&config.DisableOrganizationGuard, "disable-organization-guard", false,
+		"Allow clusters which belong to any OCM organization visible to the authenticated account to be managed.")
+	flag.BoolVar(&config.BlockInsecureIdentityProviders, "block-insecure-identity-providers", false,
+		"Prevent identity providers which communicate over an insecure transport from being applied to OCM.")
+	flag.BoolVar(&config.EnableRawOverrides, "enable-raw-overrides", false,
+		"Merge the raw overrides of machine pools into the requests which create and update them in OCM.  Raw "+
+			"overrides are ignored if this is not set.")
+	flag.BoolVar(&config.ManageServiceMonitor, "manage-service-monitor", false,
+		"Create a metrics service and a prometheus-operator service monitor for the metrics endpoint in the "+
+			"namespace of the operator.")
+	flag.IntVar(&config.ObjectMetricsHashBuckets, "object-metrics-hash-buckets", 0, "The number of buckets which objects "+
+		"are hashed into in the per-object reconcile metrics, bounding the number of series in large fleets.  Objects are "+
+		"labeled by their namespace and name if this is 0.")
+	flag.StringVar(&config.NotificationWebhookURL, "notification-webhook-url", "", "The URL of a webhook which is "+
+		"notified when managed resources are created, deleted, finish upgrading or fail terminally.  Notifications are "+
+		"not sent if this is not set.")
+	flag.StringVar(&config.NotificationWebhookFormat, "notification-webhook-format", notify.FormatGeneric, "The format "+
+		"of the notifications which are sent to the notification webhook.  One of '"+notify.FormatGeneric+"' or '"+
+		notify.FormatSlack+"'.")
+	bindControllerFlags(&config)
+	bindEnvironmentFlags(&config)
 	opts := zap.Options{
 		Development: true,
 	}
@@ -77,10 +210,18 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// the metric endpoint of the manager only supports http, so it is disabled in favor of our own
+	// server when the metric endpoint is served over https
+	metricsAddress := config.MetricsAddress
+	if config.MetricsCertDir != "" {
+		metricsAddress = "0"
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
-		MetricsBindAddress:     config.MetricsAddress,
+		MetricsBindAddress:     metricsAddress,
 		Port:                   9443,
+		CertDir:                config.WebhookCertDir,
 		HealthProbeBindAddress: config.ProbeAddress,
 		LeaderElection:         config.EnableLeaderElection,
 		LeaderElectionID:       "453df18d.mobb.redhat.com",
@@ -101,54 +242,429 @@ func main() {
 		os.Exit(1)
 	}
 
+	// create the hooks which log, measure and inject headers into each ocm request
+	headerHook, err := ocm.NewHeaderHook(config.OCMRequestHeaders)
+	if err != nil {
+		setupLog.Error(err, "unable to parse ocm request headers")
+		os.Exit(1)
+	}
+
+	metricsHook, err := ocm.NewMetricsHook(metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to create ocm request metrics")
+		os.Exit(1)
+	}
+
+	objectMetrics, err := controllers.NewObjectMetrics(metrics.Registry, config.ObjectMetricsHashBuckets)
+	if err != nil {
+		setupLog.Error(err, "unable to create object reconcile metrics")
+		os.Exit(1)
+	}
+
+	loggingHook := &ocm.LoggingHook{Log: ctrl.Log.WithName("ocm").V(controllers.LogLevelDebug)}
+
+	// send requests to ocm through the configured proxy
+	proxyTransport, err := ocm.NewProxyTransport(ocm.ProxyConfig{
+		HTTPProxy:  config.OCMHTTPProxy,
+		HTTPSProxy: config.OCMHTTPSProxy,
+		NoProxy:    config.OCMNoProxy,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create ocm proxy transport")
+		os.Exit(1)
+	}
+
 	// load the token and create the ocm client
-	token, err := ocm.NewToken(config.TokenFile)
+	timeouts := ocm.Timeouts{Read: config.OCMReadTimeout, Write: config.OCMWriteTimeout}
+
+	// inject faults into ocm requests when debugging how the operator handles an unreliable ocm
+	faults, err := ocm.ParseFaults(config.OCMFaults)
 	if err != nil {
-		setupLog.Error(err, "unable to load token", "file", config.TokenFile)
+		setupLog.Error(err, "unable to parse ocm faults")
 		os.Exit(1)
 	}
 
-	// create the connection
-	connection, err := sdk.NewConnectionBuilder().
-		Tokens(token.RefreshToken).
-		Build()
+	var injector *ocm.FaultInjector
+	if faults.Enabled() {
+		injector = ocm.NewFaultInjector(faults)
+		setupLog.Info("injecting faults into ocm requests", "faults", config.OCMFaults)
+	}
+
+	url, err := ocm.EnvironmentURL(config.OCMEnvironment)
+	if err != nil {
+		setupLog.Error(err, "unable to determine ocm environment")
+		os.Exit(1)
+	}
+
+	hooks := []ocm.TransportHook{headerHook, metricsHook, loggingHook}
+
+	connection, err := newConnection(config.TokenFile, url, proxyTransport, timeouts, injector, hooks...)
 	if err != nil {
 		setupLog.Error(err, "unable to create ocm client", "file", config.TokenFile)
 		os.Exit(1)
 	}
 
-	if err = (&machinepool.Controller{
+	// degrade the controllers which depend upon an ocm api which is incompatible with the operator
+	compatibility := probeCompatibility(config.OCMEnvironment, connection)
+
+	// back off all controllers while ocm is under maintenance, reconciling all objects once it finishes
+	maintenance := &ocm.Maintenance{}
+
+	// trust a custom ca bundle for requests to ocm, reloading it when it changes
+	if config.OCMTrustBundleConfigMap != "" {
+		if err := mgr.Add(&controllers.TrustBundleWatcher{
+			Client:    mgr.GetClient(),
+			Transport: proxyTransport,
+			Log:       ctrl.Log.WithName("trust-bundle"),
+			Interval:  controllers.DefaultTrustBundleInterval,
+			Namespace: os.Getenv(kubernetes.OperatorNamespaceEnv),
+			Name:      config.OCMTrustBundleConfigMap,
+			Key:       controllers.DefaultTrustBundleKey,
+		}); err != nil {
+			setupLog.Error(err, "unable to create ocm trust bundle watcher")
+			os.Exit(1)
+		}
+	}
+
+	// log and expose the ocm account, and organization, which the operator is authenticated as
+	accountInfo, err := health.NewAccountInfo(metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to create ocm account metrics")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&health.AccountMonitor{
 		Connection: connection,
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		Recorder:   mgr.GetEventRecorderFor("machinepool-controller"),
-		Interval:   time.Duration(config.PollerIntervalMinutes) * time.Minute,
+		Log:        ctrl.Log.WithName("account"),
+		Interval:   health.DefaultAccountInterval,
+		Info:       accountInfo,
+	}); err != nil {
+		setupLog.Error(err, "unable to create ocm account monitor")
+		os.Exit(1)
+	}
+
+	// ensure that managed clusters belong to an allowed ocm organization
+	var organizations *ocm.OrganizationGuard
+	if !config.DisableOrganizationGuard {
+		organizations = ocm.NewOrganizationGuard(connection, config.AllowedOrganizationsFor(config.OCMEnvironment))
+	}
+
+	// connect to the additional ocm environments which objects may target
+	environments, err := newEnvironments(
+		&config,
+		&ocm.Environment{
+			Connection:    connection,
+			Organizations: organizations,
+			Compatibility: compatibility,
+			Maintenance:   maintenance,
+		},
+		proxyTransport,
+		timeouts,
+		injector,
+		hooks...,
+	)
+	if err != nil {
+		setupLog.Error(err, "unable to connect to ocm environments", "environments", config.OCMEnvironmentTokenFiles)
+		os.Exit(1)
+	}
+
+	// reconcile all objects immediately when the connection to an ocm environment recovers, or once the
+	// maintenance of an environment has finished
+	broadcaster := controllers.NewBroadcaster()
+
+	if err := addEnvironmentMonitors(mgr, &config, environments, broadcaster); err != nil {
+		setupLog.Error(err, "unable to create ocm environment monitors")
+		os.Exit(1)
+	}
+
+	// push significant lifecycle events of the managed resources to a webhook, through the same proxy
+	// and trust bundle as the requests to ocm
+	var notifier events.Notifier
+
+	if config.NotificationWebhookURL != "" {
+		webhook, err := notify.NewWebhook(
+			config.NotificationWebhookURL,
+			config.NotificationWebhookFormat,
+			proxyTransport,
+			ctrl.Log.WithName("notify"),
+		)
+		if err != nil {
+			setupLog.Error(err, "unable to create notification webhook")
+			os.Exit(1)
+		}
+
+		notifier = webhook
+	}
+
+	eventRecorderFor := func(name string) record.EventRecorder {
+		return notify.NewRecorder(mgr.GetEventRecorderFor(name), notifier)
+	}
+
+	// coalesce rapid successive spec updates across the controllers which manage objects in ocm
+	coalescer := controllers.NewCoalescer(config.CoalesceWindow)
+
+	// throttle the reconciliation of objects which target the same ocm cluster across the controllers
+	throttle := controllers.NewClusterThrottle(config.ClusterConcurrency)
+
+	// dependenciesFor returns the dependencies of a controller which reconciles objects against ocm, with
+	// the event recorder and phase timeout of the controller.  A controller which does not use one of the
+	// dependencies clears it.
+	dependenciesFor := func(controller, recorder string) controllers.Dependencies {
+		return controllers.Dependencies{
+			APIReader:               mgr.GetAPIReader(),
+			Connection:              connection,
+			Recorder:                eventRecorderFor(recorder),
+			Results:                 conditions.Results{},
+			MaxConcurrentReconciles: config.MaxConcurrentReconciles,
+			PhaseTimeout:            config.For(controller).PhaseTimeout,
+			Broadcaster:             broadcaster,
+			Coalescer:               coalescer,
+			Organizations:           organizations,
+			Environments:            environments,
+			Throttle:                throttle,
+			Metrics:                 objectMetrics,
+			Compatibility:           compatibility,
+			Maintenance:             maintenance,
+			DeletionTimeout:         config.DeletionTimeout,
+			DriftInterval:           config.DriftInterval,
+		}
+	}
+
+	if err = (&machinepool.Controller{
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		Interval:           config.For(machinePoolController).Interval,
+		Requeue:            config.For(machinePoolController).Requeue,
+		EnableRawOverrides: config.EnableRawOverrides,
+		Dependencies:       dependenciesFor(machinePoolController, "machinepool-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachinePool")
 		os.Exit(1)
 	}
 	if err = (&gitlabidentityprovider.Controller{
-		Connection: connection,
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		Recorder:   mgr.GetEventRecorderFor("gitlab-idp-controller"),
-		Interval:   time.Duration(config.PollerIntervalMinutes) * time.Minute,
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Interval:     config.For(gitLabIdentityProviderController).Interval,
+		Requeue:      config.For(gitLabIdentityProviderController).Requeue,
+		Dependencies: dependenciesFor(gitLabIdentityProviderController, "gitlab-idp-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GitLabIdentityProvider")
 		os.Exit(1)
 	}
 	if err = (&ldapidentityprovider.Controller{
-		Connection: connection,
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		Recorder:   mgr.GetEventRecorderFor("ldap-idp-controller"),
-		Interval:   time.Duration(config.PollerIntervalMinutes) * time.Minute,
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		Interval:      config.For(ldapIdentityProviderController).Interval,
+		Requeue:       config.For(ldapIdentityProviderController).Requeue,
+		BlockInsecure: config.BlockInsecureIdentityProviders,
+		Dependencies:  dependenciesFor(ldapIdentityProviderController, "ldap-idp-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LDAPIdentityProvider")
 		os.Exit(1)
 	}
+	if err = (&clusternotification.Controller{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Interval:     config.For(clusterNotificationController).Interval,
+		Requeue:      config.For(clusterNotificationController).Requeue,
+		Dependencies: dependenciesFor(clusterNotificationController, "cluster-notification-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterNotification")
+		os.Exit(1)
+	}
+
+	registrationDependencies := dependenciesFor(clusterRegistrationController, "cluster-registration-controller")
+	registrationDependencies.Throttle = nil
+	if err = (&clusterregistration.Controller{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Interval:     config.For(clusterRegistrationController).Interval,
+		Requeue:      config.For(clusterRegistrationController).Requeue,
+		Dependencies: registrationDependencies,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterRegistration")
+		os.Exit(1)
+	}
+	if err = (&clusterlabels.Controller{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Interval:     config.For(clusterLabelsController).Interval,
+		Requeue:      config.For(clusterLabelsController).Requeue,
+		Dependencies: dependenciesFor(clusterLabelsController, "cluster-labels-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterLabels")
+		os.Exit(1)
+	}
+
+	pullSecretDependencies := dependenciesFor(pullSecretController, "pull-secret-controller")
+	pullSecretDependencies.Results = nil
+	pullSecretDependencies.Coalescer = nil
+	pullSecretDependencies.Organizations = nil
+	pullSecretDependencies.Throttle = nil
+	pullSecretDependencies.DeletionTimeout = 0
+	if err = (&pullsecret.Controller{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Requeue:      config.For(pullSecretController).Requeue,
+		Dependencies: pullSecretDependencies,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PullSecret")
+		os.Exit(1)
+	}
+
+	versionCheckDependencies := dependenciesFor(clusterVersionCheckController, "cluster-version-check-controller")
+	versionCheckDependencies.Coalescer = nil
+	versionCheckDependencies.Organizations = nil
+	if err = (&clusterversioncheck.Controller{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Interval:     config.For(clusterVersionCheckController).Interval,
+		Requeue:      config.For(clusterVersionCheckController).Requeue,
+		Dependencies: versionCheckDependencies,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterVersionCheck")
+		os.Exit(1)
+	}
+
+	referenceDependencies := dependenciesFor(clusterReferenceController, "cluster-reference-controller")
+	referenceDependencies.Coalescer = nil
+	referenceDependencies.Throttle = nil
+	if err = (&clusterreference.Controller{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Interval:     config.For(clusterReferenceController).Interval,
+		Requeue:      config.For(clusterReferenceController).Requeue,
+		Dependencies: referenceDependencies,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterReference")
+		os.Exit(1)
+	}
+	if err = (&clusterinfo.Controller{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Requeue: config.For(clusterInfoController).Requeue,
+		Dependencies: controllers.Dependencies{
+			APIReader:               mgr.GetAPIReader(),
+			MaxConcurrentReconciles: config.MaxConcurrentReconciles,
+			PhaseTimeout:            config.For(clusterInfoController).PhaseTimeout,
+			Broadcaster:             broadcaster,
+			Metrics:                 objectMetrics,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterInfo")
+		os.Exit(1)
+	}
+	if err = (&reconcilereport.Controller{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Requeue: config.For(reconcileReportController).Requeue,
+		Dependencies: controllers.Dependencies{
+			APIReader:               mgr.GetAPIReader(),
+			Recorder:                eventRecorderFor("reconcile-report-controller"),
+			MaxConcurrentReconciles: config.MaxConcurrentReconciles,
+			PhaseTimeout:            config.For(reconcileReportController).PhaseTimeout,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ReconcileReport")
+		os.Exit(1)
+	}
+	if config.EnableWebhooks {
+		if err = (&ocmv1alpha1.MachinePool{}).SetupWebhookWithManager(mgr, config.OCMEnvironment); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MachinePool")
+			os.Exit(1)
+		}
+		if err = (&ocmv1alpha1.GitLabIdentityProvider{}).SetupWebhookWithManager(mgr, config.OCMEnvironment); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GitLabIdentityProvider")
+			os.Exit(1)
+		}
+		if err = (&ocmv1alpha1.LDAPIdentityProvider{}).SetupWebhookWithManager(mgr, config.OCMEnvironment); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "LDAPIdentityProvider")
+			os.Exit(1)
+		}
+		if err = (&ocmv1alpha1.ClusterNotification{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterNotification")
+			os.Exit(1)
+		}
+		if err = (&ocmv1alpha1.ClusterRegistration{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterRegistration")
+			os.Exit(1)
+		}
+		if err = (&ocmv1alpha1.ClusterLabels{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterLabels")
+			os.Exit(1)
+		}
+		if err = (&ocmv1alpha1.ClusterVersionCheck{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterVersionCheck")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if config.MetricsCertDir != "" {
+		if err := mgr.Add(&metricsserver.Server{
+			Address:      config.MetricsAddress,
+			Gatherer:     metrics.Registry,
+			Log:          ctrl.Log.WithName("metrics"),
+			CertDir:      config.MetricsCertDir,
+			ClientCAFile: config.MetricsClientCAFile,
+		}); err != nil {
+			setupLog.Error(err, "unable to create metrics server")
+			os.Exit(1)
+		}
+	}
+
+	if config.ManageServiceMonitor {
+		if err := mgr.Add(&monitoring.Installer{
+			Client:    mgr.GetClient(),
+			Log:       ctrl.Log.WithName("monitoring"),
+			Name:      monitoring.DefaultName,
+			Namespace: os.Getenv(kubernetes.OperatorNamespaceEnv),
+			Address:   config.MetricsAddress,
+			Secure:    config.MetricsCertDir != "",
+			Selector:  monitoring.DefaultSelector(),
+		}); err != nil {
+			setupLog.Error(err, "unable to create metrics service monitor installer")
+			os.Exit(1)
+		}
+	}
+
+	// migrate custom resources which are stored at an older version of the api to the storage version
+	if err := mgr.Add(&migration.Migrator{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("migration"),
+		Group:    ocmv1alpha1.GroupVersion.Group,
+		PageSize: migration.DefaultPageSize,
+	}); err != nil {
+		setupLog.Error(err, "unable to create storage version migrator")
+		os.Exit(1)
+	}
+
+	// report the health of the operator when it is installed by operator lifecycle manager
+	if name := os.Getenv(kubernetes.OperatorConditionNameEnv); name != "" {
+		reporter := &health.Reporter{
+			Client:        mgr.GetClient(),
+			Connection:    connection,
+			Gatherer:      metrics.Registry,
+			Log:           ctrl.Log.WithName("health"),
+			Interval:      health.DefaultInterval,
+			Namespace:     os.Getenv(kubernetes.OperatorNamespaceEnv),
+			Name:          name,
+			Compatibility: compatibility,
+			Maintenance:   maintenance,
+		}
+
+		if config.EnableWebhooks {
+			reporter.CertDir = config.WebhookCertDir
+		}
+
+		if err := mgr.Add(reporter); err != nil {
+			setupLog.Error(err, "unable to create operator health reporter")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("operator condition not found, operator health will not be reported", "env", kubernetes.OperatorConditionNameEnv)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -158,14 +674,260 @@ func main() {
 		os.Exit(1)
 	}
 
+	// only report ready once the operator is able to reconcile
+	readiness := &health.Readiness{
+		Reader:       mgr.GetAPIReader(),
+		Environments: environments,
+		Log:          ctrl.Log.WithName("readiness"),
+		Group:        ocmv1alpha1.GroupVersion.Group,
+	}
+
+	if config.EnableWebhooks {
+		readiness.CertDir = config.WebhookCertDir
+
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
+	}
+
+	if err := mgr.AddReadyzCheck("startup", readiness.Check); err != nil {
+		setupLog.Error(err, "unable to set up startup ready check")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 
-		if err := connection.Close(); err != nil {
-			setupLog.Error(err, "unable to close ocm connection")
+		if err := environments.Close(); err != nil {
+			setupLog.Error(err, "unable to close ocm connections")
 		}
 
 		os.Exit(1)
 	}
 }
+
+// bindControllerFlags binds the flags which tune how aggressively each individual controller polls
+// OCM.  The reconcile report and cluster info controllers do not poll OCM, and the pull secret controller
+// polls OCM at the rotation interval of each cluster registration, so only their requeue intervals are
+// tunable.
+func bindControllerFlags(config *controllers.Config) {
+	config.Controllers = map[string]*controllers.ControllerConfig{}
+
+	for _, name := range []string{
+		machinePoolController,
+		gitLabIdentityProviderController,
+		ldapIdentityProviderController,
+		clusterNotificationController,
+		clusterRegistrationController,
+		clusterLabelsController,
+		clusterVersionCheckController,
+		clusterReferenceController,
+		clusterInfoController,
+		pullSecretController,
+		reconcileReportController,
+	} {
+		controllerConfig := &controllers.ControllerConfig{}
+		config.Controllers[name] = controllerConfig
+
+		if name != reconcileReportController && name != pullSecretController && name != clusterInfoController {
+			flag.DurationVar(&controllerConfig.Interval, name+"-interval", 0, "Interval by which the "+name+" controller "+
+				"should reconcile desired state.  The poller interval is used if this is 0.")
+		}
+
+		flag.DurationVar(&controllerConfig.Requeue, name+"-requeue", 0, "Interval after which the "+name+" controller "+
+			"should retry a failed or incomplete reconciliation.  The controller default of 30s is used if this is 0.")
+		flag.DurationVar(&controllerConfig.PhaseTimeout, name+"-phase-timeout", 0, "The amount of time after which a "+
+			"phase of reconciliation of the "+name+" controller is abandoned.  The value of --phase-timeout is used if this is 0.")
+	}
+}
+
+// bindEnvironmentFlags binds the flags which set the allowed organizations of each individual environment
+// of OCM, as the organizations of an account differ between environments.
+func bindEnvironmentFlags(config *controllers.Config) {
+	config.EnvironmentAllowedOrganizations = map[string]*string{}
+
+	for _, name := range []string{ocm.EnvironmentProduction, ocm.EnvironmentStage, ocm.EnvironmentIntegration} {
+		allowed := new(string)
+		config.EnvironmentAllowedOrganizations[name] = allowed
+
+		flag.StringVar(allowed, name+"-allowed-organizations", "", "A comma-separated list of OCM organization ids which "+
+			"clusters in the "+name+" OCM environment must belong to.  The value of --allowed-organizations is used if "+
+			"this is not set.")
+	}
+}
+
+// defaultWebhookCertDir returns the default certificate directory of the webhook server.
+func defaultWebhookCertDir() string {
+	return filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+}
+
+// newConnection loads the token from a file and creates the connection to OpenShift Cluster Manager.
+// The connection is made to the url of an environment of OpenShift Cluster Manager, or to the default
+// url when it is empty.  The hooks are called for each request which is sent over the connection, each
+// request is bounded by its timeout, faults are injected into requests when the fault injector is set,
+// and requests are sent with the proxy transport when it is set.
+func newConnection(
+	tokenFile, url string,
+	transport *ocm.ProxyTransport,
+	timeouts ocm.Timeouts,
+	injector *ocm.FaultInjector,
+	hooks ...ocm.TransportHook,
+) (*sdk.Connection, error) {
+	token, err := ocm.NewToken(tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load token - %w", err)
+	}
+
+	builder := sdk.NewConnectionBuilder().
+		Tokens(token.RefreshToken).
+		TransportWrapper(ocm.NewTransportWrapper(hooks...)).
+		TransportWrapper(ocm.NewTimeoutWrapper(timeouts))
+
+	if url != "" {
+		builder = builder.URL(url)
+	}
+
+	// faults are injected beneath the hooks and timeouts, so that they observe the injected faults
+	if injector != nil {
+		builder = builder.TransportWrapper(injector.Wrapper())
+	}
+
+	// the proxy transport replaces the transport of the connection, so it must be the last wrapper
+	if transport != nil {
+		builder = builder.TransportWrapper(transport.Wrapper())
+	}
+
+	connection, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build ocm connection - %w", err)
+	}
+
+	return connection, nil
+}
+
+// newEnvironments creates the connections to the additional environments of OpenShift Cluster Manager
+// which objects may target, along with the default environment which the token file connects to.  The
+// clusters in each environment are guarded with the allowed organizations of the environment, unless the
+// organization guard is disabled, and the compatibility of the apis of each environment is probed.
+func newEnvironments(
+	config *controllers.Config,
+	defaultEnvironment *ocm.Environment,
+	transport *ocm.ProxyTransport,
+	timeouts ocm.Timeouts,
+	injector *ocm.FaultInjector,
+	hooks ...ocm.TransportHook,
+) (*ocm.Environments, error) {
+	tokenFiles, err := ocm.ParseEnvironmentTokenFiles(config.OCMEnvironmentTokenFiles)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ocm environment token files - %w", err)
+	}
+
+	environments := ocm.NewEnvironments(config.OCMEnvironment)
+	environments.Add(config.OCMEnvironment, defaultEnvironment)
+
+	for name, tokenFile := range tokenFiles {
+		if environments.IsDefault(name) {
+			return nil, fmt.Errorf(
+				"environment [%s] is already connected to with the ocm token file - %w",
+				name,
+				ocm.ErrEnvironmentDuplicate,
+			)
+		}
+
+		url, err := ocm.EnvironmentURL(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine url of environment [%s] - %w", name, err)
+		}
+
+		connection, err := newConnection(tokenFile, url, transport, timeouts, injector, hooks...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to environment [%s] with token file [%s] - %w", name, tokenFile, err)
+		}
+
+		environment := &ocm.Environment{
+			Connection:    connection,
+			Compatibility: probeCompatibility(name, connection),
+			Maintenance:   &ocm.Maintenance{},
+		}
+
+		if !config.DisableOrganizationGuard {
+			environment.Organizations = ocm.NewOrganizationGuard(connection, config.AllowedOrganizationsFor(name))
+		}
+
+		environments.Add(name, environment)
+		setupLog.Info("connected to ocm environment", "environment", name, "url", url)
+	}
+
+	return environments, nil
+}
+
+// addEnvironmentMonitors adds the monitors of the connection to, and maintenance of, each environment of
+// ocm to the manager.  Each monitor broadcasts a reconciliation of all objects once its environment has
+// recovered.
+func addEnvironmentMonitors(
+	mgr ctrl.Manager,
+	config *controllers.Config,
+	environments *ocm.Environments,
+	broadcaster *controllers.Broadcaster,
+) error {
+	var addErr error
+
+	environments.Each(func(name string, environment *ocm.Environment) {
+		if addErr != nil {
+			return
+		}
+
+		if err := mgr.Add(&controllers.ConnectionMonitor{
+			Environment: name,
+			Connection:  environment.Connection,
+			Broadcaster: broadcaster,
+			Log:         ctrl.Log.WithName("connection"),
+			Interval:    controllers.DefaultConnectionCheckInterval,
+		}); err != nil {
+			addErr = fmt.Errorf("unable to create connection monitor of environment [%s] - %w", name, err)
+
+			return
+		}
+
+		if err := mgr.Add(&controllers.MaintenanceMonitor{
+			Environment: name,
+			Connection:  environment.Connection,
+			Broadcaster: broadcaster,
+			Log:         ctrl.Log.WithName("maintenance"),
+			Interval:    controllers.DefaultMaintenanceCheckInterval,
+			Services:    ocm.StatusServices(config.OCMStatusServices),
+			Maintenance: environment.Maintenance,
+		}); err != nil {
+			addErr = fmt.Errorf("unable to create maintenance monitor of environment [%s] - %w", name, err)
+		}
+	})
+
+	return addErr
+}
+
+// probeCompatibility probes the compatibility of the apis of an environment of ocm with the operator,
+// logging the apis which are deprecated or incompatible.
+func probeCompatibility(environment string, connection *sdk.Connection) *ocm.Compatibility {
+	ctx, cancel := context.WithTimeout(context.Background(), compatibilityProbeTimeout)
+	defer cancel()
+
+	compatibility := ocm.ProbeCompatibility(ctx, connection, ocm.APIClustersMgmt, ocm.APIAccountsMgmt)
+
+	for _, status := range compatibility.Statuses() {
+		switch {
+		case status.Err != nil:
+			setupLog.Error(status.Err, "ocm api is incompatible with the operator; dependent controllers are degraded",
+				"environment", environment, "api", status.API.Name, "sdkVersion", ocm.SDKVersion)
+		case status.Deprecation != "":
+			setupLog.Info("ocm api is deprecated", "environment", environment, "api", status.API.Name,
+				"deprecation", status.Deprecation, "serverVersion", status.ServerVersion, "sdkVersion", ocm.SDKVersion)
+		default:
+			setupLog.Info("ocm api is compatible", "environment", environment, "api", status.API.Name,
+				"serverVersion", status.ServerVersion, "sdkVersion", ocm.SDKVersion)
+		}
+	}
+
+	return compatibility
+}
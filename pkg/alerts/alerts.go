@@ -0,0 +1,174 @@
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers/machinepool"
+	"github.com/rh-mobb/ocm-operator/controllers/reconcilereport"
+	"github.com/rh-mobb/ocm-operator/pkg/metrics"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	severityWarning  = "warning"
+	severityCritical = "critical"
+
+	ruleGroupName = "ocm-operator.rules"
+)
+
+// Alert is a template for an alert on the metrics of the operator.
+type Alert struct {
+	Name        string
+	Expr        string
+	For         time.Duration
+	Severity    string
+	Summary     string
+	Description string
+}
+
+// Alerts returns the alerts which are shipped with the operator.  The alerts are defined from the
+// names of the metrics in the code so that they stay in sync as the metrics evolve.
+func Alerts() []Alert {
+	return []Alert{
+		{
+			Name: "OCMOperatorReconcileFailureRate",
+			Expr: fmt.Sprintf(
+				"sum by (controller) (rate(%s[15m])) / sum by (controller) (rate(%s[15m])) > 0.5",
+				metrics.ReconcileErrorsMetric,
+				metrics.ReconcileTotalMetric,
+			),
+			For:      15 * time.Minute,
+			Severity: severityWarning,
+			Summary:  "OCM operator reconciliations are failing.",
+			Description: "More than half of the reconciliations of the {{ $labels.controller }} controller " +
+				"have failed over the last 15 minutes.",
+		},
+		{
+			Name: "OCMOperatorSustainedDrift",
+			Expr: fmt.Sprintf(
+				"%s{state=%q} > 0",
+				reconcilereport.ResourcesMetric,
+				ocmv1alpha1.ReconcileReportStateDrifted,
+			),
+			For:      1 * time.Hour,
+			Severity: severityWarning,
+			Summary:  "OCM operator resources have drifted from their reconciled state.",
+			Description: "{{ $value }} resources in reconcile report {{ $labels.report }} have not been " +
+				"reconciled to their latest state for over an hour.",
+		},
+		{
+			Name: "OCMOperatorMachinePoolCapacityShortfall",
+			Expr: fmt.Sprintf(
+				"%s{type=%q} < ignoring(type) %s{type=%q}",
+				machinepool.ReplicasMetric,
+				machinepool.ReplicasTypeReady,
+				machinepool.ReplicasMetric,
+				machinepool.ReplicasTypeDesired,
+			),
+			For:      30 * time.Minute,
+			Severity: severityWarning,
+			Summary:  "OCM operator machine pools have fewer ready nodes than requested.",
+			Description: "Machine pool {{ $labels.namespace }}/{{ $labels.name }} in cluster {{ $labels.cluster }} " +
+				"has had fewer ready nodes than requested for over 30 minutes.",
+		},
+		{
+			Name: "OCMOperatorAuthenticationFailures",
+			Expr: fmt.Sprintf(
+				"sum(rate(%s_count{code=~\"401|403\"}[5m])) > 0",
+				ocm.RequestDurationMetric,
+			),
+			For:      10 * time.Minute,
+			Severity: severityCritical,
+			Summary:  "OCM operator is unable to authenticate to OpenShift Cluster Manager.",
+			Description: "Requests to OpenShift Cluster Manager are being rejected as unauthorized.  The OCM " +
+				"token used by the operator may have expired or been revoked.",
+		},
+	}
+}
+
+// PrometheusRule represents a prometheus-operator PrometheusRule object.  Only the fields which are
+// needed to ship the alerts of the operator are represented, so that the prometheus-operator API
+// is not required as a dependency.
+type PrometheusRule struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   RuleMetadata       `json:"metadata"`
+	Spec       PrometheusRuleSpec `json:"spec"`
+}
+
+// RuleMetadata represents the metadata of a PrometheusRule object.
+type RuleMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// PrometheusRuleSpec represents the spec of a PrometheusRule object.
+type PrometheusRuleSpec struct {
+	Groups []RuleGroup `json:"groups"`
+}
+
+// RuleGroup represents a group of rules within a PrometheusRule object.
+type RuleGroup struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// Rule represents an alerting rule within a PrometheusRule object.
+type Rule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// NewPrometheusRule returns a PrometheusRule object containing alerting rules generated from a set of
+// alert templates.
+func NewPrometheusRule(name, namespace string, labels map[string]string, alerts ...Alert) *PrometheusRule {
+	rules := make([]Rule, len(alerts))
+
+	for i, alert := range alerts {
+		rules[i] = Rule{
+			Alert:  alert.Name,
+			Expr:   alert.Expr,
+			Labels: map[string]string{"severity": alert.Severity},
+			Annotations: map[string]string{
+				"summary":     alert.Summary,
+				"description": alert.Description,
+			},
+		}
+
+		if alert.For > 0 {
+			// prometheus durations do not support the compound units of a go duration (e.g. 1h0m0s)
+			rules[i].For = fmt.Sprintf("%dm", int(alert.For.Minutes()))
+		}
+	}
+
+	return &PrometheusRule{
+		APIVersion: "monitoring.coreos.com/v1",
+		Kind:       "PrometheusRule",
+		Metadata: RuleMetadata{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: PrometheusRuleSpec{
+			Groups: []RuleGroup{{Name: ruleGroupName, Rules: rules}},
+		},
+	}
+}
+
+// YAML returns the YAML representation of a PrometheusRule object.
+func (rule *PrometheusRule) YAML() ([]byte, error) {
+	out, err := yaml.Marshal(rule)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal prometheus rule - %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]bool{}
+
+	for _, alert := range Alerts() {
+		if names[alert.Name] {
+			t.Errorf("Alerts() duplicate alert %v", alert.Name)
+		}
+
+		names[alert.Name] = true
+
+		if alert.Expr == "" || alert.Severity == "" || alert.Summary == "" || alert.Description == "" {
+			t.Errorf("Alerts() alert %v is missing required fields", alert.Name)
+		}
+	}
+}
+
+func TestNewPrometheusRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		alert   Alert
+		wantFor string
+	}{
+		{
+			name:    "ensure a duration in minutes is formatted for prometheus",
+			alert:   Alert{Name: "Test", For: 15 * time.Minute},
+			wantFor: "15m",
+		},
+		{
+			name:    "ensure a duration in hours is formatted for prometheus",
+			alert:   Alert{Name: "Test", For: time.Hour},
+			wantFor: "60m",
+		},
+		{
+			name:    "ensure a missing duration is omitted",
+			alert:   Alert{Name: "Test"},
+			wantFor: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := NewPrometheusRule("test", "test", nil, tt.alert)
+			if got := rule.Spec.Groups[0].Rules[0].For; got != tt.wantFor {
+				t.Errorf("NewPrometheusRule() for = %v, want %v", got, tt.wantFor)
+			}
+		})
+	}
+}
+
+func TestPrometheusRule_YAML(t *testing.T) {
+	t.Parallel()
+
+	out, err := NewPrometheusRule("test", "test", nil, Alerts()...).YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v, wantErr %v", err, false)
+	}
+
+	for _, want := range []string{"kind: PrometheusRule", "alert: OCMOperatorSustainedDrift"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("YAML() = %v, want %v", string(out), want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package conditions
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	clusterInfoConditionTypePublished = "ClusterInfoPublished"
+	clusterInfoReasonPublished        = "Published"
+	clusterInfoReasonFailed           = "PublishFailed"
+	clusterInfoReasonUnpublished      = "Unpublished"
+	clusterInfoMessagePublished       = "cluster details have been published in config map [%s]"
+	clusterInfoMessageUnpublished     = "cluster details are not published as spec.configMapName is not set"
+)
+
+// ClusterInfoPublished returns a condition indicating that the resolved details of the cluster of a
+// cluster reference have been published in its config map.
+func ClusterInfoPublished(name string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               clusterInfoConditionTypePublished,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             clusterInfoReasonPublished,
+		Message:            fmt.Sprintf(clusterInfoMessagePublished, name),
+	}
+}
+
+// ClusterInfoPublishFailed returns a condition indicating that the resolved details of the cluster of
+// a cluster reference could not be published in its config map.
+func ClusterInfoPublishFailed(err error) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               clusterInfoConditionTypePublished,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterInfoReasonFailed,
+		Message:            err.Error(),
+	}
+}
+
+// ClusterInfoUnpublished returns a condition indicating that the details of the cluster of a cluster
+// reference are no longer published, as publishing has been disabled.
+func ClusterInfoUnpublished() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               clusterInfoConditionTypePublished,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterInfoReasonUnpublished,
+		Message:            clusterInfoMessageUnpublished,
+	}
+}
@@ -0,0 +1,24 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+const (
+	clusterLabelsConditionTypeDeleted = "ClusterLabelsDeleted"
+	clusterLabelsMessageDeleted       = "managed subscription labels have been deleted from openshift cluster manager"
+)
+
+// ClusterLabelsDeleted return a condition indicating that the managed subscription labels have
+// been deleted from OpenShift Cluster Manager.
+func ClusterLabelsDeleted() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               clusterLabelsConditionTypeDeleted,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             triggers.Delete.String(),
+		Message:            clusterLabelsMessageDeleted,
+	}
+}
@@ -0,0 +1,24 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+const (
+	clusterNotificationConditionTypeDeleted = "ClusterNotificationDeleted"
+	clusterNotificationMessageDeleted       = "notification contacts have been deleted from openshift cluster manager"
+)
+
+// ClusterNotificationDeleted return a condition indicating that the notification contacts
+// have been deleted from OpenShift Cluster Manager.
+func ClusterNotificationDeleted() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               clusterNotificationConditionTypeDeleted,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             triggers.Delete.String(),
+		Message:            clusterNotificationMessageDeleted,
+	}
+}
@@ -0,0 +1,24 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/pkg/triggers"
+)
+
+const (
+	clusterRegistrationConditionTypeDeleted = "ClusterRegistrationDeleted"
+	clusterRegistrationMessageDeleted       = "cluster subscription has been archived in openshift cluster manager"
+)
+
+// ClusterRegistrationDeleted return a condition indicating that the subscription of the registered
+// cluster has been archived in OpenShift Cluster Manager.
+func ClusterRegistrationDeleted() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               clusterRegistrationConditionTypeDeleted,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             triggers.Delete.String(),
+		Message:            clusterRegistrationMessageDeleted,
+	}
+}
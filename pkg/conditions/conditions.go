@@ -3,6 +3,7 @@ package conditions
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +18,17 @@ const (
 	conditionTypeReconciling         = "Reconciling"
 	conditionMessageReconcilingStart = "beginning reconciliation"
 	conditionMessageReconcilingStop  = "ending reconciliation"
+
+	conditionTypeUnsupported     = "Unsupported"
+	conditionReasonSupported     = "Supported"
+	conditionMessageSupported    = "all requested features are supported by the cluster"
+	ConditionReasonRejectedByOCM = "RejectedByOCM"
+
+	conditionTypeForbidden     = "Forbidden"
+	conditionReasonGranted     = "ClusterGranted"
+	conditionMessageGranted    = "namespace has been granted management of the cluster"
+	ConditionReasonNotGranted  = "ClusterNotGranted"
+	conditionMessageNotGranted = "namespace [%s] has not been granted management of cluster [%s] by a cluster management binding"
 )
 
 var (
@@ -47,15 +59,93 @@ func Reconciled(trigger triggers.Trigger) *metav1.Condition {
 	}
 }
 
-// Update updates the conditions on a workload.
+// Unsupported returns a condition indicating that a feature which was requested is not supported
+// by the cluster.  The reason indicates the feature which is not supported.
+func Unsupported(reason, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeUnsupported,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// Supported returns a condition indicating that all of the features which were requested are
+// supported by the cluster.
+func Supported() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeUnsupported,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             conditionReasonSupported,
+		Message:            conditionMessageSupported,
+	}
+}
+
+// Forbidden returns a condition indicating that the namespace of a workload has not been granted
+// management of its cluster by a cluster management binding.
+func Forbidden(namespace, clusterName string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeForbidden,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             ConditionReasonNotGranted,
+		Message:            fmt.Sprintf(conditionMessageNotGranted, namespace, clusterName),
+	}
+}
+
+// Permitted returns a condition indicating that the namespace of a workload has been granted
+// management of its cluster.
+func Permitted() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeForbidden,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             conditionReasonGranted,
+		Message:            conditionMessageGranted,
+	}
+}
+
+// IsForbidden determines if a workload has previously been forbidden from managing its cluster.
+func IsForbidden(object controllers.Workload) bool {
+	for _, condition := range object.GetConditions() {
+		if condition.Type == conditionTypeForbidden {
+			return condition.Status == metav1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// IsRejected determines if a workload was rejected by OpenShift Cluster Manager at its current
+// generation.
+func IsRejected(object controllers.Workload) bool {
+	for _, condition := range object.GetConditions() {
+		if condition.Type == conditionTypeUnsupported {
+			return condition.Status == metav1.ConditionTrue &&
+				condition.Reason == ConditionReasonRejectedByOCM &&
+				condition.ObservedGeneration == object.GetGeneration()
+		}
+	}
+
+	return false
+}
+
+// Update updates the conditions on a workload.  The last transition time of an existing
+// condition is preserved if its status has not changed, and replaced conditions are kept
+// in the condition history of workloads which support it.  A condition which is already set
+// is updated when it was observed for a previous generation of the workload, so that the
+// observed generation of a condition which is set on every successful reconciliation, such
+// as the Reconciled condition, does not lag behind the spec.
 func Update(
 	ctx context.Context,
 	reconciler kubernetes.Client,
 	object controllers.Workload,
 	condition *metav1.Condition,
 ) error {
-	// return if we already have the condition set
-	if IsSet(condition, object) {
+	// return if we already have the condition set for the current generation
+	if isCurrent(condition, object) {
 		return nil
 	}
 
@@ -66,7 +156,9 @@ func Update(
 	}
 
 	// set the new condition
-	object.SetConditions(addCondition(object.GetConditions(), condition))
+	if !NewManager(object).SetCondition(condition) {
+		return nil
+	}
 
 	// run the patch
 	//nolint:wrapcheck
@@ -84,24 +176,21 @@ func IsSet(condition *metav1.Condition, on controllers.Workload) bool {
 	return false
 }
 
-func addCondition(existing []metav1.Condition, newCondition *metav1.Condition) []metav1.Condition {
-	if len(existing) < 1 {
-		return []metav1.Condition{*newCondition}
+// isCurrent determines if a workload has a condition already set for its current generation, or for
+// the generation of the condition when it is set explicitly.
+func isCurrent(condition *metav1.Condition, on controllers.Workload) bool {
+	generation := condition.ObservedGeneration
+	if generation == 0 {
+		generation = on.GetGeneration()
 	}
 
-	for condition := range existing {
-		if existing[condition].Type == newCondition.Type {
-			if equalCondition(existing[condition], *newCondition) {
-				return existing
-			}
-
-			existing[condition] = *newCondition
-
-			return existing
+	for _, existing := range on.GetConditions() {
+		if equalCondition(*condition, existing) && existing.ObservedGeneration == generation {
+			return true
 		}
 	}
 
-	return append(existing, *newCondition)
+	return false
 }
 
 // equalCondition determines if two conditions are equal.
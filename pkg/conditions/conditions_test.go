@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -47,10 +48,11 @@ func testObject(t metav1.Time) *ocmv1alpha1.MachinePool {
 	}
 }
 
-func Test_addCondition(t *testing.T) {
+func Test_setCondition(t *testing.T) {
 	t.Parallel()
 
 	now := metav1.Now()
+	later := metav1.NewTime(now.Add(time.Minute))
 
 	type args struct {
 		current []metav1.Condition
@@ -94,14 +96,62 @@ func Test_addCondition(t *testing.T) {
 			},
 			want: []metav1.Condition{*testConditionReconciled(now)},
 		},
+		{
+			name: "ensure differing condition uses the new transition time when status changes",
+			args: args{
+				current: []metav1.Condition{*testConditionReconciling(now)},
+				new:     testConditionReconciled(later),
+			},
+			want: []metav1.Condition{*testConditionReconciled(later)},
+		},
+		{
+			name: "ensure differing condition preserves the transition time when status is unchanged",
+			args: args{
+				current: []metav1.Condition{*testConditionReconciled(now)},
+				new: func() *metav1.Condition {
+					condition := Reconciled(triggers.Update)
+					condition.LastTransitionTime = later
+
+					return condition
+				}(),
+			},
+			want: []metav1.Condition{
+				func() metav1.Condition {
+					condition := Reconciled(triggers.Update)
+					condition.LastTransitionTime = now
+
+					return *condition
+				}(),
+			},
+		},
+		{
+			name: "ensure an unchanged condition observed for a previous generation is updated in place",
+			args: args{
+				current: []metav1.Condition{*testConditionReconciled(now)},
+				new: func() *metav1.Condition {
+					condition := testConditionReconciled(later)
+					condition.ObservedGeneration = 2
+
+					return condition
+				}(),
+			},
+			want: []metav1.Condition{
+				func() metav1.Condition {
+					condition := testConditionReconciled(now)
+					condition.ObservedGeneration = 2
+
+					return *condition
+				}(),
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := addCondition(tt.args.current, tt.args.new); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("addCondition() = %v, want %v", got, tt.want)
+			if got, _, _ := setCondition(tt.args.current, tt.args.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setCondition() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -158,3 +208,128 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate_ObservedGeneration(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+
+	object := testObject(now)
+	object.Generation = 3
+
+	if err := Update(context.TODO(), &kubernetes.FakeClient{}, object, Reconciled(triggers.Create)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if got := object.Status.Conditions; len(got) != 1 || got[0].ObservedGeneration != 3 || !got[0].LastTransitionTime.Equal(&now) {
+		t.Errorf("Update() conditions = %v, want observed generation %v at %v", got, 3, now)
+	}
+
+	if got := len(object.GetConditionHistory()); got != 0 {
+		t.Errorf("Update() history = %v, want %v", got, 0)
+	}
+}
+
+func TestRecordResult_Waiting(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	object := testObject(metav1.Now())
+	manager := NewManager(object)
+
+	waiting := controllers.WaitFor("cluster [test]", controllers.WaitReasonClusterInstalling, time.Minute)
+	if err := RecordResult(ctx, &kubernetes.FakeClient{}, object, "getCurrentState", waiting); err != nil {
+		t.Fatalf("RecordResult() error = %v", err)
+	}
+
+	if condition := manager.Get(conditionTypeWaiting); condition == nil ||
+		condition.Status != metav1.ConditionTrue ||
+		condition.Reason != controllers.WaitReasonClusterInstalling {
+		t.Errorf("RecordResult() waiting condition = %v, want reason %v", condition, controllers.WaitReasonClusterInstalling)
+	}
+
+	if manager.IsTrue(conditionTypeReconcileFailed) {
+		t.Errorf("RecordResult() set failed condition for a wait")
+	}
+
+	if err := RecordResult(ctx, &kubernetes.FakeClient{}, object, "", nil); err != nil {
+		t.Fatalf("RecordResult() error = %v", err)
+	}
+
+	if manager.IsTrue(conditionTypeWaiting) {
+		t.Errorf("RecordResult() did not clear waiting condition on success")
+	}
+}
+
+func TestRecordResult_Failures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	object := testObject(metav1.Now())
+
+	// record more identical failures than the condition history keeps
+	for i := 0; i < DefaultHistoryLimit+2; i++ {
+		if err := RecordResult(ctx, &kubernetes.FakeClient{}, object, "getCurrentState", errTestReason); err != nil {
+			t.Fatalf("RecordResult() error = %v", err)
+		}
+	}
+
+	if got, _ := FailureCount(object, time.Now().Add(-time.Hour)); got != DefaultHistoryLimit+2 {
+		t.Errorf("FailureCount() = %v, want %v", got, DefaultHistoryLimit+2)
+	}
+
+	if !IsFailed(object) {
+		t.Errorf("RecordResult() did not set failed condition")
+	}
+}
+
+func Test_countFailure(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC)
+	hour := func(ago int) metav1.Time {
+		return metav1.NewTime(now.Truncate(time.Hour).Add(-time.Duration(ago) * time.Hour))
+	}
+
+	tests := []struct {
+		name   string
+		counts []ocmv1alpha1.ReconcileFailureCount
+		want   []ocmv1alpha1.ReconcileFailureCount
+	}{
+		{
+			name: "ensure the first failure is counted",
+			want: []ocmv1alpha1.ReconcileFailureCount{{Hour: hour(0), Count: 1}},
+		},
+		{
+			name:   "ensure a failure within the same hour increments the count",
+			counts: []ocmv1alpha1.ReconcileFailureCount{{Hour: hour(1), Count: 2}, {Hour: hour(0), Count: 1}},
+			want:   []ocmv1alpha1.ReconcileFailureCount{{Hour: hour(1), Count: 2}, {Hour: hour(0), Count: 2}},
+		},
+		{
+			name:   "ensure a failure within a new hour starts a new count",
+			counts: []ocmv1alpha1.ReconcileFailureCount{{Hour: hour(1), Count: 2}},
+			want:   []ocmv1alpha1.ReconcileFailureCount{{Hour: hour(1), Count: 2}, {Hour: hour(0), Count: 1}},
+		},
+		{
+			name: "ensure the counts of hours which are no longer counted are dropped",
+			counts: []ocmv1alpha1.ReconcileFailureCount{
+				{Hour: hour(ocmv1alpha1.ReconcileFailureHours), Count: 3},
+				{Hour: hour(ocmv1alpha1.ReconcileFailureHours - 1), Count: 2},
+			},
+			want: []ocmv1alpha1.ReconcileFailureCount{
+				{Hour: hour(ocmv1alpha1.ReconcileFailureHours - 1), Count: 2},
+				{Hour: hour(0), Count: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := countFailure(tt.counts, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("countFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+)
+
+const (
+	conditionTypeDestroyPrevented   = "DestroyPrevented"
+	conditionReasonDestroyPrevented = "PreventDestroyAnnotation"
+)
+
+// DestroyPrevented returns a condition indicating that the deletion of an object from OpenShift Cluster
+// Manager has been prevented by the prevent destroy annotation.
+func DestroyPrevented() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeDestroyPrevented,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             conditionReasonDestroyPrevented,
+		Message:            controllers.MessageDestroyPrevented,
+	}
+}
@@ -1,6 +1,8 @@
 package conditions
 
 import (
+	"fmt"
+
 	"github.com/rh-mobb/ocm-operator/pkg/triggers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -8,6 +10,22 @@ import (
 const (
 	identityProviderConditionTypeDeleted = "IdentityProviderDeleted"
 	identityProviderMessageDeleted       = "identity provider has been deleted from openshift cluster manager"
+
+	ldapConditionTypeConnectionValidated = "LDAPConnectionValidated"
+	ldapReasonConnectionValidated        = "Succeeded"
+	ldapMessageConnectionValidated       = "successfully connected to ldap server from the operator"
+
+	identityProviderConditionTypeInsecureTransport = "InsecureTransport"
+	identityProviderReasonSecureTransport          = "Secure"
+	identityProviderMessageSecureTransport         = "identity provider communicates over a secure transport"
+	IdentityProviderReasonInsecureAllowed          = "InsecureAllowed"
+	identityProviderMessageInsecureAllowed         = "identity provider communicates over an insecure transport; credentials are sent in plain text"
+	IdentityProviderReasonInsecureBlocked          = "InsecureBlocked"
+	identityProviderMessageInsecureBlocked         = "identity provider communicates over an insecure transport, which has been blocked by the operator"
+
+	identityProviderConditionTypeMigrated = "Migrated"
+	identityProviderReasonMigrated        = "Migrated"
+	IdentityProviderReasonMigrating       = "Migrating"
 )
 
 // IdentityProviderDeleted return a condition indicating that the identity provider has
@@ -22,3 +40,85 @@ func IdentityProviderDeleted() *metav1.Condition {
 		Message:            identityProviderMessageDeleted,
 	}
 }
+
+// LDAPConnectionValidated returns a condition indicating that a connection to the LDAP server
+// was successfully validated from the operator.
+func LDAPConnectionValidated() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ldapConditionTypeConnectionValidated,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             ldapReasonConnectionValidated,
+		Message:            ldapMessageConnectionValidated,
+	}
+}
+
+// LDAPConnectionFailed returns a condition indicating that a connection to the LDAP server
+// could not be validated from the operator.  The reason indicates the stage at which the
+// connection failed.
+func LDAPConnectionFailed(reason string, err error) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ldapConditionTypeConnectionValidated,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+	}
+}
+
+// InsecureTransport returns a condition indicating that an identity provider communicates over
+// an insecure transport.  The reason indicates whether the operator has blocked the identity
+// provider from being applied.
+func InsecureTransport(blocked bool) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               identityProviderConditionTypeInsecureTransport,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             IdentityProviderReasonInsecureAllowed,
+		Message:            identityProviderMessageInsecureAllowed,
+	}
+
+	if blocked {
+		condition.Reason = IdentityProviderReasonInsecureBlocked
+		condition.Message = identityProviderMessageInsecureBlocked
+	}
+
+	return condition
+}
+
+// SecureTransport returns a condition indicating that an identity provider communicates over a
+// secure transport.
+func SecureTransport() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               identityProviderConditionTypeInsecureTransport,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             identityProviderReasonSecureTransport,
+		Message:            identityProviderMessageSecureTransport,
+	}
+}
+
+// Migrating returns a condition indicating that an identity provider is replacing an existing
+// identity provider, which is not removed until the new identity provider has been confirmed to
+// exist in OpenShift Cluster Manager.
+func Migrating(namespace, name string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               identityProviderConditionTypeMigrated,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             IdentityProviderReasonMigrating,
+		Message:            fmt.Sprintf("waiting to replace identity provider [%s/%s]", namespace, name),
+	}
+}
+
+// Migrated returns a condition indicating that an identity provider has replaced an existing
+// identity provider, which has been removed.
+func Migrated(namespace, name string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               identityProviderConditionTypeMigrated,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             identityProviderReasonMigrated,
+		Message:            fmt.Sprintf("replaced identity provider [%s/%s]", namespace, name),
+	}
+}
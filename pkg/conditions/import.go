@@ -0,0 +1,51 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	importConditionTypeMismatch = "ImportMismatch"
+	importReasonMatched         = "Matched"
+	importMessageMatched        = "the immutable fields of the spec match openshift cluster manager"
+	ImportReasonMismatched      = "ImmutableFieldsDiffer"
+	importMessageMismatched     = "immutable fields [%s] differ from openshift cluster manager and were not imported"
+)
+
+// ImportMismatch returns a condition indicating that immutable fields of the spec of an imported
+// workload differ from the object in OpenShift Cluster Manager.  Immutable fields may not be updated
+// by an import, so they must be changed to match, or the workload recreated.
+func ImportMismatch(fields []string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               importConditionTypeMismatch,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             ImportReasonMismatched,
+		Message:            fmt.Sprintf(importMessageMismatched, strings.Join(fields, ", ")),
+	}
+}
+
+// ImportMatched returns a condition indicating that the immutable fields of the spec of an imported
+// workload match the object in OpenShift Cluster Manager.
+func ImportMatched() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               importConditionTypeMismatch,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             importReasonMatched,
+		Message:            importMessageMatched,
+	}
+}
+
+// Imported returns the condition which reports whether the immutable fields of the spec of an imported
+// workload match the object in OpenShift Cluster Manager.
+func Imported(mismatches []string) *metav1.Condition {
+	if len(mismatches) > 0 {
+		return ImportMismatch(mismatches)
+	}
+
+	return ImportMatched()
+}
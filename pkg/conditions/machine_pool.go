@@ -1,6 +1,9 @@
 package conditions
 
 import (
+	"fmt"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/rh-mobb/ocm-operator/pkg/triggers"
@@ -9,6 +12,33 @@ import (
 const (
 	machinePoolConditionTypeDeleted = "MachinePoolDeleted"
 	machinePoolMessageDeleted       = "machine pool has been deleted from openshift cluster manager"
+
+	machinePoolConditionTypeAutoscalingConsistent = "AutoscalingConsistent"
+	machinePoolReasonAutoscalingConsistent        = "Succeeded"
+	machinePoolMessageAutoscalingConsistent       = "machine pool autoscaling is consistent with the cluster autoscaler"
+	machinePoolMessageClusterAutoscalerMissing    = "machine pool has autoscaling enabled without a cluster autoscaler configured"
+
+	machinePoolConditionTypeCapacityAvailable = "CapacityAvailable"
+	machinePoolReasonCapacityAvailable        = "Succeeded"
+	machinePoolMessageCapacityAvailable       = "instance type is available and maximum nodes of the cluster fit within the limits of the cluster"
+
+	machinePoolReasonSchedulesValid  = "Succeeded"
+	machinePoolMessageSchedulesValid = "machine pool schedules are valid"
+)
+
+const (
+	MachinePoolConditionTypeDeprovisioning = "Deprovisioning"
+	MachinePoolConditionTypeSchedulesValid = "SchedulesValid"
+
+	MachinePoolReasonClusterAutoscalerMissing   = "ClusterAutoscalerMissing"
+	MachinePoolReasonMaximumExceedsClusterLimit = "MaximumExceedsClusterLimit"
+	MachinePoolReasonDeprovisioning             = "Deprovisioning"
+	MachinePoolReasonDeprovisionStalled         = "DeprovisionStalled"
+	MachinePoolReasonPodCIDRExhausted           = "PodCIDRExhausted"
+	MachinePoolReasonMachineCIDRExhausted       = "MachineCIDRExhausted"
+	MachinePoolReasonComputeNodesExceeded       = "ComputeNodesExceeded"
+	MachinePoolReasonInstanceTypeUnavailable    = "InstanceTypeUnavailable"
+	MachinePoolReasonScheduleInvalid            = "ScheduleInvalid"
 )
 
 // MachinePoolDeleted return a condition indicating that the machine pool has
@@ -22,3 +52,159 @@ func MachinePoolDeleted() *metav1.Condition {
 		Message:            machinePoolMessageDeleted,
 	}
 }
+
+// MachinePoolDeprovisioning returns a condition indicating that the deletion of the machine pool has
+// been requested, but that openshift cluster manager is still deprovisioning its nodes.
+func MachinePoolDeprovisioning(progress string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               MachinePoolConditionTypeDeprovisioning,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             MachinePoolReasonDeprovisioning,
+		Message:            "machine pool is being deprovisioned by openshift cluster manager: " + progress,
+	}
+}
+
+// MachinePoolDeprovisionStalled returns a condition indicating that openshift cluster manager has not
+// finished deprovisioning the machine pool within the expected amount of time.
+func MachinePoolDeprovisionStalled(elapsed time.Duration, progress string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               MachinePoolConditionTypeDeprovisioning,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             MachinePoolReasonDeprovisionStalled,
+		Message: fmt.Sprintf(
+			"machine pool has not been deprovisioned by openshift cluster manager after %s: %s",
+			elapsed.Round(time.Second),
+			progress,
+		),
+	}
+}
+
+// SchedulesValid returns a condition indicating that the schedules of the machine pool are able to be
+// evaluated.
+func SchedulesValid() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               MachinePoolConditionTypeSchedulesValid,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             machinePoolReasonSchedulesValid,
+		Message:            machinePoolMessageSchedulesValid,
+	}
+}
+
+// ScheduleInvalid returns a condition indicating that a schedule of the machine pool is unable to be
+// evaluated, so that the machine pool is not applied until the schedule is corrected.
+func ScheduleInvalid(err error) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               MachinePoolConditionTypeSchedulesValid,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             MachinePoolReasonScheduleInvalid,
+		Message:            err.Error(),
+	}
+}
+
+// AutoscalingConsistent returns a condition indicating that the autoscaling configuration of the
+// machine pool is consistent with the cluster autoscaler.
+func AutoscalingConsistent() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               machinePoolConditionTypeAutoscalingConsistent,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             machinePoolReasonAutoscalingConsistent,
+		Message:            machinePoolMessageAutoscalingConsistent,
+	}
+}
+
+// ClusterAutoscalerMissing returns a condition indicating that the machine pool has autoscaling
+// enabled but the cluster does not have a cluster autoscaler configured.
+func ClusterAutoscalerMissing() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               machinePoolConditionTypeAutoscalingConsistent,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             MachinePoolReasonClusterAutoscalerMissing,
+		Message:            machinePoolMessageClusterAutoscalerMissing,
+	}
+}
+
+// CapacityAvailable returns a condition indicating that the instance type of the machine pool is
+// available to the cluster, and that the maximum number of nodes of the cluster, including the machine
+// pool, fits within both the maximum compute nodes of the cluster and the capacity of its network.
+func CapacityAvailable() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               machinePoolConditionTypeCapacityAvailable,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             machinePoolReasonCapacityAvailable,
+		Message:            machinePoolMessageCapacityAvailable,
+	}
+}
+
+// CapacityExceeded returns a condition indicating that the maximum number of nodes of the cluster,
+// including the machine pool, exceeds the number of nodes which a network of the cluster is able to
+// allocate, so that the machine pool is never able to fully schedule.
+func CapacityExceeded(reason, network string, nodes, capacity int) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               machinePoolConditionTypeCapacityAvailable,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message: fmt.Sprintf(
+			"cluster would have up to %d nodes with the machine pool, which exceeds the %s capacity of %d nodes",
+			nodes,
+			network,
+			capacity,
+		),
+	}
+}
+
+// ComputeNodesExceeded returns a condition indicating that the maximum number of compute nodes of the
+// cluster, including the machine pool, exceeds the maximum number of compute nodes which the cluster
+// supports, so that the machine pool is never able to fully schedule.
+func ComputeNodesExceeded(nodes, maximum int) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               machinePoolConditionTypeCapacityAvailable,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             MachinePoolReasonComputeNodesExceeded,
+		Message: fmt.Sprintf(
+			"cluster would have up to %d compute nodes with the machine pool, which exceeds the maximum of %d compute nodes",
+			nodes,
+			maximum,
+		),
+	}
+}
+
+// InstanceTypeUnavailable returns a condition indicating that the instance type of the machine pool is
+// unavailable to the cluster, so that the machine pool is never able to schedule.
+func InstanceTypeUnavailable(instanceType, cloudProvider string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               machinePoolConditionTypeCapacityAvailable,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             MachinePoolReasonInstanceTypeUnavailable,
+		Message: fmt.Sprintf(
+			"instance type [%s] is unavailable to the cluster on cloud provider [%s]",
+			instanceType,
+			cloudProvider,
+		),
+	}
+}
+
+// AutoscalingMaximumExceeded returns a condition indicating that the maximum number of nodes of the
+// machine pool exceeds the maximum number of nodes allowed by the cluster autoscaler.
+func AutoscalingMaximumExceeded(maximum, maxNodesTotal int64) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               machinePoolConditionTypeAutoscalingConsistent,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             MachinePoolReasonMaximumExceedsClusterLimit,
+		Message: fmt.Sprintf(
+			"machine pool maximum of %d nodes exceeds the cluster autoscaler maxNodesTotal of %d nodes",
+			maximum,
+			maxNodesTotal,
+		),
+	}
+}
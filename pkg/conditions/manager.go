@@ -0,0 +1,176 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+)
+
+const (
+	// DefaultHistoryLimit is the default number of previous conditions which are kept in the
+	// condition history of a workload.
+	DefaultHistoryLimit = 10
+)
+
+// HistoryWorkload represents a workload which keeps a history of the previous conditions
+// that have been set on it.
+type HistoryWorkload interface {
+	controllers.Workload
+
+	GetConditionHistory() []metav1.Condition
+	SetConditionHistory([]metav1.Condition)
+}
+
+// Manager manages the conditions of a workload.  It ensures that the last transition time
+// of a condition is only changed when the status of the condition changes, that identical
+// conditions are not set multiple times and, for workloads which support it, that replaced
+// conditions are kept in a bounded history.
+type Manager struct {
+	object       controllers.Workload
+	historyLimit int
+}
+
+// NewManager returns a new condition manager for a workload.
+func NewManager(object controllers.Workload) *Manager {
+	return &Manager{
+		object:       object,
+		historyLimit: DefaultHistoryLimit,
+	}
+}
+
+// WithHistoryLimit sets the number of previous conditions which are kept in the condition history.
+func (manager *Manager) WithHistoryLimit(limit int) *Manager {
+	manager.historyLimit = limit
+
+	return manager
+}
+
+// Get returns the condition of a given type, or nil if the condition is not set.
+func (manager *Manager) Get(conditionType string) *metav1.Condition {
+	for _, existing := range manager.object.GetConditions() {
+		if existing.Type == conditionType {
+			condition := existing
+
+			return &condition
+		}
+	}
+
+	return nil
+}
+
+// IsTrue determines if the condition of a given type is set with a true status.
+func (manager *Manager) IsTrue(conditionType string) bool {
+	condition := manager.Get(conditionType)
+
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// SetCondition sets a condition on the workload.  It returns true if the conditions of the workload
+// were changed and false if the condition was already set for the current generation of the workload.
+func (manager *Manager) SetCondition(condition *metav1.Condition) bool {
+	newCondition := *condition
+
+	if newCondition.ObservedGeneration == 0 {
+		newCondition.ObservedGeneration = manager.object.GetGeneration()
+	}
+
+	if newCondition.LastTransitionTime.IsZero() {
+		newCondition.LastTransitionTime = metav1.Now()
+	}
+
+	conditions, replaced, changed := setCondition(manager.object.GetConditions(), &newCondition)
+	if !changed {
+		return false
+	}
+
+	manager.object.SetConditions(conditions)
+
+	// store the replaced condition in the history
+	if replaced != nil {
+		manager.record(replaced)
+	}
+
+	return true
+}
+
+// MarkTrue sets a condition of a given type with a true status on the workload.
+func (manager *Manager) MarkTrue(conditionType, reason, message string) bool {
+	return manager.mark(conditionType, metav1.ConditionTrue, reason, message)
+}
+
+// MarkFalse sets a condition of a given type with a false status on the workload.
+func (manager *Manager) MarkFalse(conditionType, reason, message string) bool {
+	return manager.mark(conditionType, metav1.ConditionFalse, reason, message)
+}
+
+// MarkUnknown sets a condition of a given type with an unknown status on the workload.
+func (manager *Manager) MarkUnknown(conditionType, reason, message string) bool {
+	return manager.mark(conditionType, metav1.ConditionUnknown, reason, message)
+}
+
+func (manager *Manager) mark(conditionType string, status metav1.ConditionStatus, reason, message string) bool {
+	return manager.SetCondition(&metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// record records a replaced condition in the condition history of the workload, if the workload
+// supports it.  Only the most recent conditions, up to the history limit, are kept.
+func (manager *Manager) record(condition *metav1.Condition) {
+	workload, ok := manager.object.(HistoryWorkload)
+	if !ok || manager.historyLimit < 1 {
+		return
+	}
+
+	history := append(workload.GetConditionHistory(), *condition)
+	if len(history) > manager.historyLimit {
+		history = history[len(history)-manager.historyLimit:]
+	}
+
+	workload.SetConditionHistory(history)
+}
+
+// setCondition sets a new condition within a set of existing conditions.  It returns the resulting
+// conditions, the condition which was replaced (if any) and whether the conditions were changed.  The
+// last transition time of an existing condition is preserved if its status has not changed, and an
+// existing condition which differs only by its observed generation is updated without being replaced.
+func setCondition(existing []metav1.Condition, newCondition *metav1.Condition) (
+	conditions []metav1.Condition,
+	replaced *metav1.Condition,
+	changed bool,
+) {
+	for i := range existing {
+		if existing[i].Type != newCondition.Type {
+			continue
+		}
+
+		// return if the condition is already set for the same generation
+		if equalCondition(existing[i], *newCondition) {
+			if existing[i].ObservedGeneration == newCondition.ObservedGeneration {
+				return existing, nil, false
+			}
+
+			// only the observed generation is updated for a condition which is otherwise unchanged, so
+			// that it is not recorded in the history
+			existing[i].ObservedGeneration = newCondition.ObservedGeneration
+
+			return existing, nil, true
+		}
+
+		previous := existing[i]
+
+		// preserve the last transition time if the status has not transitioned
+		if previous.Status == newCondition.Status {
+			newCondition.LastTransitionTime = previous.LastTransitionTime
+		}
+
+		existing[i] = *newCondition
+
+		return existing, &previous, true
+	}
+
+	return append(existing, *newCondition), nil, true
+}
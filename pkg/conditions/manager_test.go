@@ -0,0 +1,82 @@
+package conditions
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func TestManager_SetCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		marks       []metav1.ConditionStatus
+		limit       int
+		wantChanged bool
+		wantHistory int
+	}{
+		{
+			name:        "ensure a new condition is set without history",
+			marks:       []metav1.ConditionStatus{metav1.ConditionTrue},
+			limit:       DefaultHistoryLimit,
+			wantChanged: true,
+			wantHistory: 0,
+		},
+		{
+			name:        "ensure an identical condition is not changed",
+			marks:       []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionTrue},
+			limit:       DefaultHistoryLimit,
+			wantChanged: false,
+			wantHistory: 0,
+		},
+		{
+			name:        "ensure a replaced condition is recorded in the history",
+			marks:       []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse},
+			limit:       DefaultHistoryLimit,
+			wantChanged: true,
+			wantHistory: 1,
+		},
+		{
+			name: "ensure the history is bounded by the history limit",
+			marks: []metav1.ConditionStatus{
+				metav1.ConditionTrue,
+				metav1.ConditionFalse,
+				metav1.ConditionUnknown,
+				metav1.ConditionTrue,
+			},
+			limit:       2,
+			wantChanged: true,
+			wantHistory: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			object := &ocmv1alpha1.MachinePool{}
+			manager := NewManager(object).WithHistoryLimit(tt.limit)
+
+			var changed bool
+			for _, status := range tt.marks {
+				changed = manager.mark("Ready", status, "Test", "test condition")
+			}
+
+			if changed != tt.wantChanged {
+				t.Errorf("Manager.SetCondition() = %v, want %v", changed, tt.wantChanged)
+			}
+
+			if got := len(object.GetConditionHistory()); got != tt.wantHistory {
+				t.Errorf("Manager.SetCondition() history = %v, want %v", got, tt.wantHistory)
+			}
+
+			if got := manager.Get("Ready"); got == nil || got.Status != tt.marks[len(tt.marks)-1] {
+				t.Errorf("Manager.Get() = %v, want status %v", got, tt.marks[len(tt.marks)-1])
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	operatorConditionTypeOCMConnected = "OCMConnected"
+	operatorReasonOCMConnected        = "Connected"
+	operatorReasonOCMUnreachable      = "Unreachable"
+	operatorMessageOCMConnected       = "operator is able to authenticate with openshift cluster manager"
+
+	operatorConditionTypeOCMAccount = "OCMAccount"
+	operatorReasonOCMAccountKnown   = "Authenticated"
+	operatorReasonOCMAccountUnknown = "Unknown"
+
+	operatorConditionTypeWebhookCertificateValid = "WebhookCertificateValid"
+	operatorReasonWebhookCertificateValid        = "Valid"
+	operatorReasonWebhookCertificateInvalid      = "Invalid"
+	operatorMessageWebhookCertificateValid       = "webhook serving certificate is valid"
+
+	operatorConditionTypeControllersHealthy = "ControllersHealthy"
+	operatorReasonControllersHealthy        = "Healthy"
+	operatorReasonControllersFailing        = "Failing"
+	operatorMessageControllersHealthy       = "all controllers are reconciling successfully"
+
+	operatorConditionTypeOCMAPICompatible = "OCMAPICompatible"
+	operatorReasonOCMAPICompatible        = "Compatible"
+	operatorReasonOCMAPIIncompatible      = "Incompatible"
+	operatorMessageOCMAPICompatible       = "openshift cluster manager apis are compatible with the operator"
+
+	operatorConditionTypeOCMMaintenance = "OCMMaintenance"
+	operatorReasonOCMMaintenance        = "Maintenance"
+	operatorReasonOCMAvailable          = "Available"
+	operatorMessageOCMAvailable         = "openshift cluster manager is not under maintenance"
+)
+
+// OCMConnected returns a condition indicating whether the operator is able to authenticate with
+// OpenShift Cluster Manager.  A nil error indicates that the operator is connected.
+func OCMConnected(err error) metav1.Condition {
+	if err != nil {
+		return operatorCondition(operatorConditionTypeOCMConnected, metav1.ConditionFalse, operatorReasonOCMUnreachable, err.Error())
+	}
+
+	return operatorCondition(operatorConditionTypeOCMConnected, metav1.ConditionTrue, operatorReasonOCMConnected, operatorMessageOCMConnected)
+}
+
+// OCMAccount returns a condition indicating the account, and the organization of the account, which
+// the operator is authenticated with OpenShift Cluster Manager as.  A nil error indicates that the
+// account is known.
+func OCMAccount(account string, err error) metav1.Condition {
+	if err != nil {
+		return operatorCondition(operatorConditionTypeOCMAccount, metav1.ConditionFalse, operatorReasonOCMAccountUnknown, err.Error())
+	}
+
+	return operatorCondition(
+		operatorConditionTypeOCMAccount,
+		metav1.ConditionTrue,
+		operatorReasonOCMAccountKnown,
+		fmt.Sprintf("operator is authenticated with openshift cluster manager as %s", account),
+	)
+}
+
+// WebhookCertificateValid returns a condition indicating whether the serving certificate of the
+// webhook server is valid.  A nil error indicates that the certificate is valid.
+func WebhookCertificateValid(err error) metav1.Condition {
+	if err != nil {
+		return operatorCondition(
+			operatorConditionTypeWebhookCertificateValid,
+			metav1.ConditionFalse,
+			operatorReasonWebhookCertificateInvalid,
+			err.Error(),
+		)
+	}
+
+	return operatorCondition(
+		operatorConditionTypeWebhookCertificateValid,
+		metav1.ConditionTrue,
+		operatorReasonWebhookCertificateValid,
+		operatorMessageWebhookCertificateValid,
+	)
+}
+
+// ControllersHealthy returns a condition indicating whether the controllers of the operator are
+// reconciling successfully.  The failing controllers are those which have only returned errors
+// since the health of the operator was last reported.
+func ControllersHealthy(failing []string) metav1.Condition {
+	if len(failing) > 0 {
+		return operatorCondition(
+			operatorConditionTypeControllersHealthy,
+			metav1.ConditionFalse,
+			operatorReasonControllersFailing,
+			fmt.Sprintf("controllers are failing to reconcile: %s", strings.Join(failing, ", ")),
+		)
+	}
+
+	return operatorCondition(
+		operatorConditionTypeControllersHealthy,
+		metav1.ConditionTrue,
+		operatorReasonControllersHealthy,
+		operatorMessageControllersHealthy,
+	)
+}
+
+// OCMAPICompatible returns a condition indicating whether the APIs of OpenShift Cluster Manager are
+// compatible with the operator, as probed when the operator started.  The controllers which depend
+// upon an incompatible API are degraded.
+func OCMAPICompatible(incompatible []string) metav1.Condition {
+	if len(incompatible) > 0 {
+		return operatorCondition(
+			operatorConditionTypeOCMAPICompatible,
+			metav1.ConditionFalse,
+			operatorReasonOCMAPIIncompatible,
+			fmt.Sprintf("openshift cluster manager apis are incompatible with the operator: %s", strings.Join(incompatible, ", ")),
+		)
+	}
+
+	return operatorCondition(
+		operatorConditionTypeOCMAPICompatible,
+		metav1.ConditionTrue,
+		operatorReasonOCMAPICompatible,
+		operatorMessageOCMAPICompatible,
+	)
+}
+
+// OCMMaintenance returns a condition indicating whether OpenShift Cluster Manager is under maintenance,
+// along with the announcement of the maintenance.  The controllers back off while the maintenance is
+// active.
+func OCMMaintenance(active bool, message string) metav1.Condition {
+	if active {
+		return operatorCondition(
+			operatorConditionTypeOCMMaintenance,
+			metav1.ConditionTrue,
+			operatorReasonOCMMaintenance,
+			fmt.Sprintf("controllers are backing off while openshift cluster manager is under maintenance: %s", message),
+		)
+	}
+
+	return operatorCondition(
+		operatorConditionTypeOCMMaintenance,
+		metav1.ConditionFalse,
+		operatorReasonOCMAvailable,
+		operatorMessageOCMAvailable,
+	)
+}
+
+func operatorCondition(conditionType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               conditionType,
+		LastTransitionTime: metav1.Now(),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	}
+}
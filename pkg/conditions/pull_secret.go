@@ -0,0 +1,38 @@
+package conditions
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	pullSecretConditionTypeSynced = "PullSecretSynced"
+	pullSecretReasonSynced        = "Synced"
+	pullSecretReasonFailed        = "SyncFailed"
+	pullSecretMessageSynced       = "pull secret has been retrieved from openshift cluster manager and stored in secret [%s]"
+)
+
+// PullSecretSynced returns a condition indicating that the pull secret of a registered cluster has
+// been retrieved from OpenShift Cluster Manager and stored in its secret.
+func PullSecretSynced(name string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               pullSecretConditionTypeSynced,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             pullSecretReasonSynced,
+		Message:            fmt.Sprintf(pullSecretMessageSynced, name),
+	}
+}
+
+// PullSecretSyncFailed returns a condition indicating that the pull secret of a registered cluster
+// could not be retrieved from OpenShift Cluster Manager or stored in its secret.
+func PullSecretSyncFailed(err error) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               pullSecretConditionTypeSynced,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             pullSecretReasonFailed,
+		Message:            err.Error(),
+	}
+}
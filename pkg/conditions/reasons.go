@@ -0,0 +1,123 @@
+package conditions
+
+import (
+	"errors"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// Reason codes which are set on the ReconcileFailed condition of a workload when a phase of
+// reconciliation fails.  These reason codes are part of the API of the operator and will not be
+// renamed, so that automation may branch on them programmatically.
+const (
+	// ReasonClusterNotFound indicates that the cluster of a workload could not be found in
+	// OpenShift Cluster Manager.
+	ReasonClusterNotFound = "ClusterNotFound"
+
+	// ReasonOCMAuthFailed indicates that OpenShift Cluster Manager rejected the credentials of
+	// the operator, or that the operator is not permitted to perform a request.
+	ReasonOCMAuthFailed = "OCMAuthFailed"
+
+	// ReasonValidationRejected indicates that the desired state of a workload was rejected as
+	// invalid, either by OpenShift Cluster Manager or by the operator.  Reconciliation will not
+	// succeed until the workload is changed.
+	ReasonValidationRejected = "ValidationRejected"
+
+	// ReasonQuotaExceeded indicates that the organization of the operator does not have enough
+	// quota in OpenShift Cluster Manager to fulfil the desired state of a workload.
+	ReasonQuotaExceeded = "QuotaExceeded"
+
+	// ReasonDriftDetected indicates that an object which is managed by a workload differs from
+	// its desired state and may not be updated in place.
+	ReasonDriftDetected = "DriftDetected"
+
+	// ReasonSecretNotFound indicates that a secret, or a key within a secret, which is referenced
+	// by a workload could not be found.
+	ReasonSecretNotFound = "SecretNotFound"
+
+	// ReasonOrganizationNotAllowed indicates that the cluster of a workload does not belong to an
+	// organization which the operator is allowed to manage.
+	ReasonOrganizationNotAllowed = "OrganizationNotAllowed"
+
+	// ReasonOCMUnavailable indicates that OpenShift Cluster Manager was unable to process a
+	// request due to a server side failure.  Reconciliation is retried.
+	ReasonOCMUnavailable = "OCMUnavailable"
+
+	// ReasonPhaseTimeout indicates that a phase of reconciliation did not complete within the phase
+	// timeout of the controller, for example because OpenShift Cluster Manager did not respond.
+	// Reconciliation is retried.
+	ReasonPhaseTimeout = "PhaseTimeout"
+
+	// ReasonReconcileError indicates a failure which does not match any other reason code.
+	ReasonReconcileError = "ReconcileError"
+)
+
+// ReasonError is an error which carries the reason code that is reported when it causes a phase of
+// reconciliation to fail.
+type ReasonError struct {
+	Reason string
+	Err    error
+}
+
+// WithReason annotates an error with the reason code that is reported when it causes a phase of
+// reconciliation to fail.  It is used for errors which may not be classified by ReasonFor, such as
+// the sentinel errors of an individual controller.
+func WithReason(reason string, err error) error {
+	return &ReasonError{Reason: reason, Err: err}
+}
+
+func (reasonErr *ReasonError) Error() string {
+	return reasonErr.Err.Error()
+}
+
+func (reasonErr *ReasonError) Unwrap() error {
+	return reasonErr.Err
+}
+
+// ReasonFor returns the reason code for an error which caused a phase of reconciliation to fail.  A
+// reason which was annotated on the error with WithReason takes precedence over the reason which is
+// inferred from the error.
+func ReasonFor(err error) string {
+	var reasonErr *ReasonError
+	if errors.As(err, &reasonErr) {
+		return reasonErr.Reason
+	}
+
+	switch {
+	case errors.Is(err, controllers.ErrPhaseTimeout):
+		return ReasonPhaseTimeout
+	case errors.Is(err, ocm.ErrClusterNotFound):
+		return ReasonClusterNotFound
+	case errors.Is(err, ocm.ErrClusterOrganization):
+		return ReasonOrganizationNotAllowed
+	case ocm.IsQuotaExceeded(err):
+		return ReasonQuotaExceeded
+	case ocm.IsUnauthorized(err):
+		return ReasonOCMAuthFailed
+	case ocm.IsUnsupported(err):
+		return ReasonValidationRejected
+	case ocm.IsUnavailable(err):
+		return ReasonOCMUnavailable
+	default:
+		return ReasonReconcileError
+	}
+}
+
+// IsTerminal determines if an error which caused a phase of reconciliation to fail will not succeed by
+// retrying the reconciliation, and requires the workload, or the environment of the operator, to change.
+func IsTerminal(err error) bool {
+	switch ReasonFor(err) {
+	case ReasonValidationRejected, ReasonDriftDetected, ReasonQuotaExceeded, ReasonOrganizationNotAllowed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNewTerminalFailure determines if an error which caused a phase of reconciliation to fail is terminal
+// and has not already been recorded on the workload, so that a terminal failure is only reported once
+// rather than each time that reconciliation is retried.
+func IsNewTerminalFailure(object controllers.Workload, phase string, err error) bool {
+	return IsTerminal(err) && !IsSet(ReconcileFailed(phase, err), object)
+}
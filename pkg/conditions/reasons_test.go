@@ -0,0 +1,147 @@
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+var errTestReason = errors.New("test reason error")
+
+func testOCMError(t *testing.T, status int, reason string) error {
+	t.Helper()
+
+	err, buildErr := ocmerrors.NewError().Status(status).Reason(reason).Build()
+	if buildErr != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", buildErr, false)
+	}
+
+	return err
+}
+
+func TestReasonFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "ensure an annotated reason takes precedence",
+			err:  fmt.Errorf("unable to apply - %w", WithReason(ReasonDriftDetected, testOCMError(t, http.StatusBadRequest, "test"))),
+			want: ReasonDriftDetected,
+		},
+		{
+			name: "ensure a missing cluster is classified",
+			err:  fmt.Errorf("unable to get cluster - %w", ocm.ErrClusterNotFound),
+			want: ReasonClusterNotFound,
+		},
+		{
+			name: "ensure a disallowed organization is classified",
+			err:  fmt.Errorf("unable to get cluster - %w", ocm.ErrClusterOrganization),
+			want: ReasonOrganizationNotAllowed,
+		},
+		{
+			name: "ensure an unauthorized response is classified",
+			err:  testOCMError(t, http.StatusUnauthorized, "test"),
+			want: ReasonOCMAuthFailed,
+		},
+		{
+			name: "ensure a forbidden quota response is classified as quota",
+			err:  testOCMError(t, http.StatusForbidden, "Insufficient quota for cluster"),
+			want: ReasonQuotaExceeded,
+		},
+		{
+			name: "ensure a bad request response is classified",
+			err:  testOCMError(t, http.StatusBadRequest, "test"),
+			want: ReasonValidationRejected,
+		},
+		{
+			name: "ensure a server error response is classified",
+			err:  testOCMError(t, http.StatusServiceUnavailable, "test"),
+			want: ReasonOCMUnavailable,
+		},
+		{
+			name: "ensure a phase timeout is classified",
+			err:  fmt.Errorf("phase [applyState] did not complete - %w", controllers.ErrPhaseTimeout),
+			want: ReasonPhaseTimeout,
+		},
+		{
+			name: "ensure an unknown error is classified as a generic error",
+			err:  errTestReason,
+			want: ReasonReconcileError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ReasonFor(tt.err); got != tt.want {
+				t.Errorf("ReasonFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithReason(t *testing.T) {
+	t.Parallel()
+
+	err := WithReason(ReasonSecretNotFound, errTestReason)
+
+	if !errors.Is(err, errTestReason) {
+		t.Errorf("WithReason() = %v, want wrapped %v", err, errTestReason)
+	}
+
+	if err.Error() != errTestReason.Error() {
+		t.Errorf("WithReason() message = %v, want %v", err.Error(), errTestReason.Error())
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "ensure a rejected desired state is terminal",
+			err:  WithReason(ReasonValidationRejected, errTestReason),
+			want: true,
+		},
+		{
+			name: "ensure detected drift is terminal",
+			err:  WithReason(ReasonDriftDetected, errTestReason),
+			want: true,
+		},
+		{
+			name: "ensure an unavailable ocm is not terminal",
+			err:  testOCMError(t, http.StatusServiceUnavailable, "test"),
+			want: false,
+		},
+		{
+			name: "ensure an unknown error is not terminal",
+			err:  errTestReason,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsTerminal(tt.err); got != tt.want {
+				t.Errorf("IsTerminal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
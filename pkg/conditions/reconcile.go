@@ -0,0 +1,259 @@
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	conditionTypeReconcileFailed       = "ReconcileFailed"
+	conditionReasonReconcileSuccess    = "Succeeded"
+	conditionMessageReconcileSucceeded = "reconciliation succeeded"
+	conditionMessageReconcileFailed    = "%s phase failed - %s"
+)
+
+// FailureCountWorkload represents a workload which counts its failed reconciliations within each of the
+// most recent hours in its status, independently of its condition history.
+type FailureCountWorkload interface {
+	controllers.Workload
+
+	GetReconcileFailures() []ocmv1alpha1.ReconcileFailureCount
+	SetReconcileFailures([]ocmv1alpha1.ReconcileFailureCount)
+}
+
+// ReconcileFailed returns a condition indicating that a phase of reconciliation has
+// failed with an error.  The reason is one of the documented reason codes, as returned
+// by ReasonFor, and the message includes the phase which failed.
+func ReconcileFailed(phase string, err error) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeReconcileFailed,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonFor(err),
+		Message:            fmt.Sprintf(conditionMessageReconcileFailed, phase, err),
+	}
+}
+
+// ReconcileSucceeded returns a condition indicating that all phases of reconciliation
+// have completed without an error.
+func ReconcileSucceeded() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeReconcileFailed,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             conditionReasonReconcileSuccess,
+		Message:            conditionMessageReconcileSucceeded,
+	}
+}
+
+// RecordResult records the result of a reconciliation phase on a workload.  A failed condition
+// is set when an error was returned, and is cleared once reconciliation succeeds.  A phase which
+// is waiting for an external dependency sets a waiting condition rather than a failed condition,
+// which is likewise cleared once reconciliation succeeds.  Each failure is also counted on workloads
+// which count their failures, even when the failed condition is already set.
+func RecordResult(
+	ctx context.Context,
+	reconciler kubernetes.Client,
+	object controllers.Workload,
+	phase string,
+	err error,
+) error {
+	if waiting, ok := controllers.AsWaiting(err); ok {
+		return Update(ctx, reconciler, object, Waiting(waiting))
+	}
+
+	if err != nil {
+		if workload, ok := object.(FailureCountWorkload); ok {
+			return recordFailure(ctx, reconciler, workload, ReconcileFailed(phase, err), time.Now())
+		}
+
+		return Update(ctx, reconciler, object, ReconcileFailed(phase, err))
+	}
+
+	// only clear the waiting and failed conditions if they were previously set to avoid updating
+	// the status of workloads which have never waited or failed
+	manager := NewManager(object)
+
+	if manager.IsTrue(conditionTypeWaiting) {
+		if err := Update(ctx, reconciler, object, NotWaiting()); err != nil {
+			return err
+		}
+	}
+
+	if !manager.IsTrue(conditionTypeReconcileFailed) {
+		return nil
+	}
+
+	return Update(ctx, reconciler, object, ReconcileSucceeded())
+}
+
+// recordFailure sets a failed condition on a workload and counts the failure within the hour in which it
+// occurred.  Unlike the condition, which is only changed when the failure changes, the count changes
+// upon every failure, so the status of the workload is patched each time.
+func recordFailure(
+	ctx context.Context,
+	reconciler kubernetes.Client,
+	object FailureCountWorkload,
+	condition *metav1.Condition,
+	now time.Time,
+) error {
+	// create a copy of the original and convert to a client object
+	original, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return ErrConvertClientObject
+	}
+
+	if !IsSet(condition, object) {
+		NewManager(object).SetCondition(condition)
+	}
+
+	object.SetReconcileFailures(countFailure(object.GetReconcileFailures(), now))
+
+	// run the patch
+	//nolint:wrapcheck
+	return kubernetes.PatchStatus(ctx, reconciler, original, object)
+}
+
+// countFailure counts a failure which occurred at a point in time within a set of hourly failure counts.
+// Only the counts of the hours which are still counted, as bounded by ReconcileFailureHours, are kept.
+func countFailure(counts []ocmv1alpha1.ReconcileFailureCount, at time.Time) []ocmv1alpha1.ReconcileFailureCount {
+	hour := at.UTC().Truncate(time.Hour)
+
+	if last := len(counts) - 1; last >= 0 && counts[last].Hour.Time.Equal(hour) {
+		counted := append([]ocmv1alpha1.ReconcileFailureCount{}, counts...)
+		counted[last].Count++
+
+		return counted
+	}
+
+	counted := []ocmv1alpha1.ReconcileFailureCount{}
+	cutoff := hour.Add(-(ocmv1alpha1.ReconcileFailureHours - 1) * time.Hour)
+
+	for i := range counts {
+		if !counts[i].Hour.Time.Before(cutoff) {
+			counted = append(counted, counts[i])
+		}
+	}
+
+	return append(counted, ocmv1alpha1.ReconcileFailureCount{Hour: metav1.NewTime(hour), Count: 1})
+}
+
+// FailureCount returns the number of failed reconciliations of a workload within the hours which ended
+// after a given point in time, and whether the workload counts its failures at all.  As failures are
+// counted per hour, the failures within the hour in which the point in time falls are included.
+func FailureCount(object controllers.Workload, since time.Time) (int, bool) {
+	workload, ok := object.(FailureCountWorkload)
+	if !ok {
+		return 0, false
+	}
+
+	var count int
+
+	for _, failures := range workload.GetReconcileFailures() {
+		if failures.Hour.Add(time.Hour).After(since) {
+			count += failures.Count
+		}
+	}
+
+	return count, true
+}
+
+// LastReconciled returns the condition which was set when reconciliation last completed for a workload.
+// Both the conditions and the condition history of the workload are inspected, so that a condition is
+// returned even while the workload is in the middle of reconciliation.  A nil condition is returned if
+// the workload has never completed reconciliation.
+func LastReconciled(object controllers.Workload) *metav1.Condition {
+	var last *metav1.Condition
+
+	for _, condition := range allConditions(object) {
+		if condition.Type != conditionTypeReconciling || condition.Message != conditionMessageReconcilingStop {
+			continue
+		}
+
+		if last == nil || !condition.LastTransitionTime.Before(&last.LastTransitionTime) {
+			reconciled := condition
+			last = &reconciled
+		}
+	}
+
+	return last
+}
+
+// Failures returns the failed reconciliation conditions, from both the conditions and the
+// condition history of a workload, which transitioned after a given point in time.  The
+// failures are returned from oldest to newest.
+func Failures(object controllers.Workload, since time.Time) []metav1.Condition {
+	var failures []metav1.Condition
+
+	for _, condition := range allConditions(object) {
+		if condition.Type != conditionTypeReconcileFailed || condition.Status != metav1.ConditionTrue {
+			continue
+		}
+
+		if condition.LastTransitionTime.Time.Before(since) {
+			continue
+		}
+
+		failures = append(failures, condition)
+	}
+
+	return failures
+}
+
+// IsFailed determines if the most recent reconciliation of a workload has failed.
+func IsFailed(object controllers.Workload) bool {
+	return NewManager(object).IsTrue(conditionTypeReconcileFailed)
+}
+
+// allConditions returns the condition history followed by the current conditions of a workload.
+func allConditions(object controllers.Workload) []metav1.Condition {
+	var all []metav1.Condition
+
+	if workload, ok := object.(HistoryWorkload); ok {
+		all = append(all, workload.GetConditionHistory()...)
+	}
+
+	return append(all, object.GetConditions()...)
+}
+
+// Results records the results of the phases of reconciliation in the conditions of workloads.  It is
+// provided to the controllers, which are unable to import this package, to satisfy the
+// controllers.Results interface.
+type Results struct{}
+
+// IsNewTerminalFailure determines if an error which caused a phase of reconciliation to fail is terminal
+// and has not already been recorded on the workload.
+func (Results) IsNewTerminalFailure(object controllers.Workload, phase string, err error) bool {
+	return IsNewTerminalFailure(object, phase, err)
+}
+
+// Record records the result of a reconciliation phase on a workload.
+func (Results) Record(
+	ctx context.Context,
+	reconciler kubernetes.Client,
+	object controllers.Workload,
+	phase string,
+	err error,
+) error {
+	return RecordResult(ctx, reconciler, object, phase, err)
+}
+
+// IsDestroyPrevented determines if the deletion of a workload from OpenShift Cluster Manager has already
+// been recorded as prevented on the workload.
+func (Results) IsDestroyPrevented(object controllers.Workload) bool {
+	return IsSet(DestroyPrevented(), object)
+}
+
+// RecordDestroyPrevented records that the deletion of a workload from OpenShift Cluster Manager has been
+// prevented on the workload.
+func (Results) RecordDestroyPrevented(ctx context.Context, reconciler kubernetes.Client, object controllers.Workload) error {
+	return Update(ctx, reconciler, object, DestroyPrevented())
+}
@@ -0,0 +1,58 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+)
+
+const (
+	secretConditionTypeAvailable = "SecretsAvailable"
+	secretReasonAvailable        = "Available"
+	secretMessageAvailable       = "all referenced secrets are available"
+	SecretReasonWaiting          = "WaitingForSecret"
+)
+
+// WaitingForSecret returns a condition indicating that a referenced secret does not yet exist or
+// does not yet contain the required keys.  This is expected when the secret is created
+// asynchronously, for example by an ExternalSecret or a SealedSecret.
+func WaitingForSecret(namespace, name string, missing []string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               secretConditionTypeAvailable,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             SecretReasonWaiting,
+		Message: fmt.Sprintf(
+			"waiting for secret [%s/%s] to contain keys [%s]",
+			namespace,
+			name,
+			strings.Join(missing, ", "),
+		),
+	}
+}
+
+// SecretsAvailable returns a condition indicating that all referenced secrets exist and contain
+// the required keys.
+func SecretsAvailable() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               secretConditionTypeAvailable,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             secretReasonAvailable,
+		Message:            secretMessageAvailable,
+	}
+}
+
+// IsWaitingForSecret determines if a workload has previously been waiting for a referenced secret.
+func IsWaitingForSecret(object controllers.Workload) bool {
+	for _, condition := range object.GetConditions() {
+		if condition.Type == secretConditionTypeAvailable {
+			return condition.Status == metav1.ConditionFalse
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	conditionTypeWaiting       = "Waiting"
+	conditionReasonNotWaiting  = "DependenciesReady"
+	conditionMessageWaiting    = "waiting for %s"
+	conditionMessageNotWaiting = "all external dependencies are ready"
+)
+
+// Waiting returns a condition indicating that reconciliation is waiting for an external dependency,
+// such as a cluster or a secret, to become ready.  The reason is the reason for the wait and the
+// message identifies the dependency.
+func Waiting(waiting *controllers.Waiting) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeWaiting,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionTrue,
+		Reason:             waiting.Reason,
+		Message:            fmt.Sprintf(conditionMessageWaiting, waiting.Dependency),
+	}
+}
+
+// NotWaiting returns a condition indicating that reconciliation is no longer waiting for an external
+// dependency.
+func NotWaiting() *metav1.Condition {
+	return &metav1.Condition{
+		Type:               conditionTypeWaiting,
+		LastTransitionTime: metav1.Now(),
+		Status:             metav1.ConditionFalse,
+		Reason:             conditionReasonNotWaiting,
+		Message:            conditionMessageNotWaiting,
+	}
+}
+
+// RecordWaiting records a wait for an external dependency which is returned before the phases of
+// reconciliation begin, such as while a cluster reference is resolved, on a workload.  The original
+// error is returned so that the wait is still handled by the reconciler, and an error which is not a
+// wait is returned unchanged.
+func RecordWaiting(ctx context.Context, reconciler kubernetes.Client, object controllers.Workload, err error) error {
+	waiting, ok := controllers.AsWaiting(err)
+	if !ok {
+		return err
+	}
+
+	if updateErr := Update(ctx, reconciler, object, Waiting(waiting)); updateErr != nil {
+		return fmt.Errorf("unable to update waiting condition - %w", updateErr)
+	}
+
+	return err
+}
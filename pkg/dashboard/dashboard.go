@@ -0,0 +1,232 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rh-mobb/ocm-operator/controllers/reconcilereport"
+	"github.com/rh-mobb/ocm-operator/pkg/metrics"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	dashboardUID   = "ocm-operator"
+	dashboardTitle = "OCM Operator"
+
+	// schemaVersion is the version of the grafana dashboard json model which is generated.
+	schemaVersion = 37
+
+	datasourceVariable = "datasource"
+
+	panelWidth  = 12
+	panelHeight = 8
+	gridColumns = 24
+)
+
+// Panel is a template for a time series panel of the operator dashboard.
+type Panel struct {
+	Title   string
+	Unit    string
+	Queries []Query
+}
+
+// Query is a prometheus query which is graphed on a panel.
+type Query struct {
+	Expr   string
+	Legend string
+}
+
+// Panels returns the panels of the operator dashboard.  The panels are defined from the names of
+// the metrics in the code so that the dashboard stays in sync as the metrics evolve.
+func Panels() []Panel {
+	return []Panel{
+		{
+			Title: "Reconcile Latency (p95)",
+			Unit:  "s",
+			Queries: []Query{
+				{
+					Expr: fmt.Sprintf(
+						"histogram_quantile(0.95, sum by (controller, le) (rate(%s_bucket[5m])))",
+						metrics.ReconcileTimeMetric,
+					),
+					Legend: "{{controller}}",
+				},
+			},
+		},
+		{
+			Title: "Reconcile Error Rate",
+			Unit:  "percentunit",
+			Queries: []Query{
+				{
+					Expr: fmt.Sprintf(
+						"sum by (controller) (rate(%s[5m])) / sum by (controller) (rate(%s[5m]))",
+						metrics.ReconcileErrorsMetric,
+						metrics.ReconcileTotalMetric,
+					),
+					Legend: "{{controller}}",
+				},
+			},
+		},
+		{
+			Title: "OCM API Latency (p95)",
+			Unit:  "s",
+			Queries: []Query{
+				{
+					Expr: fmt.Sprintf(
+						"histogram_quantile(0.95, sum by (method, endpoint, le) (rate(%s_bucket[5m])))",
+						ocm.RequestDurationMetric,
+					),
+					Legend: "{{method}} {{endpoint}}",
+				},
+			},
+		},
+		{
+			Title: "OCM API Requests by Status Code",
+			Unit:  "reqps",
+			Queries: []Query{
+				{
+					Expr:   fmt.Sprintf("sum by (code) (rate(%s_count[5m]))", ocm.RequestDurationMetric),
+					Legend: "{{code}}",
+				},
+			},
+		},
+		{
+			Title: "Managed Resources by State",
+			Unit:  "short",
+			Queries: []Query{
+				{
+					Expr:   fmt.Sprintf("sum by (state) (%s)", reconcilereport.ResourcesMetric),
+					Legend: "{{state}}",
+				},
+			},
+		},
+	}
+}
+
+// Dashboard represents a grafana dashboard json model.  Only the fields which are needed to render
+// the panels of the operator are represented.
+type Dashboard struct {
+	UID           string       `json:"uid"`
+	Title         string       `json:"title"`
+	Tags          []string     `json:"tags"`
+	SchemaVersion int          `json:"schemaVersion"`
+	Editable      bool         `json:"editable"`
+	Refresh       string       `json:"refresh"`
+	Time          timeRange    `json:"time"`
+	Templating    templating   `json:"templating"`
+	Panels        []panelModel `json:"panels"`
+}
+
+type timeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type templating struct {
+	List []variable `json:"list"`
+}
+
+type variable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+type datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type gridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type fieldConfig struct {
+	Defaults fieldDefaults `json:"defaults"`
+}
+
+type fieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type target struct {
+	RefID        string     `json:"refId"`
+	Datasource   datasource `json:"datasource"`
+	Expr         string     `json:"expr"`
+	LegendFormat string     `json:"legendFormat"`
+}
+
+type panelModel struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	Datasource  datasource  `json:"datasource"`
+	GridPos     gridPos     `json:"gridPos"`
+	FieldConfig fieldConfig `json:"fieldConfig"`
+	Targets     []target    `json:"targets"`
+}
+
+// NewDashboard returns a grafana dashboard which graphs a set of panels.  The panels are laid out
+// in a grid of two columns and query the prometheus datasource which is selected in the dashboard.
+func NewDashboard(panels ...Panel) *Dashboard {
+	source := datasource{Type: "prometheus", UID: fmt.Sprintf("${%s}", datasourceVariable)}
+
+	dashboard := &Dashboard{
+		UID:           dashboardUID,
+		Title:         dashboardTitle,
+		Tags:          []string{"ocm", "operator"},
+		SchemaVersion: schemaVersion,
+		Editable:      true,
+		Refresh:       "30s",
+		Time:          timeRange{From: "now-6h", To: "now"},
+		Templating: templating{
+			List: []variable{
+				{Name: datasourceVariable, Label: "Data Source", Type: "datasource", Query: "prometheus"},
+			},
+		},
+		Panels: make([]panelModel, len(panels)),
+	}
+
+	for i, panel := range panels {
+		targets := make([]target, len(panel.Queries))
+		for j, query := range panel.Queries {
+			targets[j] = target{
+				RefID:        string(rune('A' + j)),
+				Datasource:   source,
+				Expr:         query.Expr,
+				LegendFormat: query.Legend,
+			}
+		}
+
+		dashboard.Panels[i] = panelModel{
+			ID:         i + 1,
+			Type:       "timeseries",
+			Title:      panel.Title,
+			Datasource: source,
+			GridPos: gridPos{
+				X: (i * panelWidth) % gridColumns,
+				Y: (i * panelWidth) / gridColumns * panelHeight,
+				W: panelWidth,
+				H: panelHeight,
+			},
+			FieldConfig: fieldConfig{Defaults: fieldDefaults{Unit: panel.Unit}},
+			Targets:     targets,
+		}
+	}
+
+	return dashboard
+}
+
+// JSON returns the indented JSON representation of a dashboard.
+func (dashboard *Dashboard) JSON() ([]byte, error) {
+	out, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal dashboard - %w", err)
+	}
+
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,88 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewDashboard(t *testing.T) {
+	t.Parallel()
+
+	panels := []Panel{
+		{Title: "first", Queries: []Query{{Expr: "a"}, {Expr: "b"}}},
+		{Title: "second", Queries: []Query{{Expr: "c"}}},
+		{Title: "third", Queries: []Query{{Expr: "d"}}},
+	}
+
+	dashboard := NewDashboard(panels...)
+
+	tests := []struct {
+		name       string
+		panel      int
+		wantX      int
+		wantY      int
+		wantRefIDs []string
+	}{
+		{
+			name:       "ensure the first panel is placed in the first column of the first row",
+			panel:      0,
+			wantX:      0,
+			wantY:      0,
+			wantRefIDs: []string{"A", "B"},
+		},
+		{
+			name:       "ensure the second panel is placed in the second column of the first row",
+			panel:      1,
+			wantX:      panelWidth,
+			wantY:      0,
+			wantRefIDs: []string{"A"},
+		},
+		{
+			name:       "ensure the third panel is placed in the first column of the second row",
+			panel:      2,
+			wantX:      0,
+			wantY:      panelHeight,
+			wantRefIDs: []string{"A"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := dashboard.Panels[tt.panel]
+			if got.GridPos.X != tt.wantX || got.GridPos.Y != tt.wantY {
+				t.Errorf("NewDashboard() gridPos = %v, want x=%v y=%v", got.GridPos, tt.wantX, tt.wantY)
+			}
+
+			if len(got.Targets) != len(tt.wantRefIDs) {
+				t.Fatalf("NewDashboard() targets = %v, want %v", len(got.Targets), len(tt.wantRefIDs))
+			}
+
+			for i, target := range got.Targets {
+				if target.RefID != tt.wantRefIDs[i] {
+					t.Errorf("NewDashboard() refId = %v, want %v", target.RefID, tt.wantRefIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDashboard_JSON(t *testing.T) {
+	t.Parallel()
+
+	out, err := NewDashboard(Panels()...).JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v, wantErr %v", err, false)
+	}
+
+	decoded := map[string]interface{}{}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("JSON() is not valid json - %v", err)
+	}
+
+	if got, ok := decoded["panels"].([]interface{}); !ok || len(got) != len(Panels()) {
+		t.Errorf("JSON() panels = %v, want %v", decoded["panels"], len(Panels()))
+	}
+}
@@ -0,0 +1,176 @@
+package diff
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Diff records the fields of a desired state which differ from the current state of an object in
+// OpenShift Cluster Manager.  Each field is compared with the helper which matches the way that
+// OpenShift Cluster Manager stores it, so that a value which OpenShift Cluster Manager normalizes,
+// for example by reordering a list, is not reported as a difference on every reconcile.
+type Diff struct {
+	fields []string
+}
+
+// New returns a new diff without any differences.
+func New() *Diff {
+	return &Diff{}
+}
+
+// Field records the comparison of a field, identified by its path within the spec.  It returns the
+// diff so that comparisons may be chained.
+func (diff *Diff) Field(path string, equal bool) *Diff {
+	if !equal {
+		diff.fields = append(diff.fields, path)
+	}
+
+	return diff
+}
+
+// Equal determines if every field which was compared is equal.
+func (diff *Diff) Equal() bool {
+	return len(diff.fields) == 0
+}
+
+// Fields returns the paths of the fields which are not equal, in the order in which they were
+// compared.
+func (diff *Diff) Fields() []string {
+	return diff.fields
+}
+
+// Values determines if two comparable values are equal.
+func Values[T comparable](compare, with T) bool {
+	return compare == with
+}
+
+// Fold determines if two strings are equal, ignoring case, for values which OpenShift Cluster Manager
+// stores in a normalized case.
+func Fold(compare, with string) bool {
+	return strings.EqualFold(compare, with)
+}
+
+// URLs determines if two urls are equal, ignoring the case of the scheme and host and a trailing
+// slash on the path, which OpenShift Cluster Manager does not preserve.  Values which are not valid
+// urls are compared as strings.
+func URLs(compare, with string) bool {
+	compareURL, compareErr := url.Parse(compare)
+	withURL, withErr := url.Parse(with)
+
+	if compareErr != nil || withErr != nil {
+		return compare == with
+	}
+
+	for _, parsed := range []*url.URL{compareURL, withURL} {
+		parsed.Scheme = strings.ToLower(parsed.Scheme)
+		parsed.Host = strings.ToLower(parsed.Host)
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	}
+
+	return compareURL.String() == withURL.String()
+}
+
+// Pointers determines if two pointers are either both nil or point to equal values.
+func Pointers[T comparable](compare, with *T) bool {
+	if compare == nil || with == nil {
+		return compare == with
+	}
+
+	return *compare == *with
+}
+
+// Requested determines if a requested value is either nil, leaving the value to the default of
+// OpenShift Cluster Manager, or is equal to the current value.  A value which is no longer requested
+// is left as it is in OpenShift Cluster Manager, so it is not reported as a difference.
+func Requested[T comparable](requested, current *T) bool {
+	return requested == nil || Pointers(requested, current)
+}
+
+// Known determines if a value is equal to the current value, when the current value is known.  A current
+// value which is the zero value is not known, for example when OpenShift Cluster Manager does not report
+// it, so it is not reported as a difference.
+func Known[T comparable](compare, current T) bool {
+	var unknown T
+
+	return current == unknown || compare == current
+}
+
+// Lists determines if two lists contain equal items in the same order.  A nil list is equal to an
+// empty list.
+func Lists[T comparable](compare, with []T) bool {
+	if len(compare) != len(with) {
+		return false
+	}
+
+	for i := range compare {
+		if compare[i] != with[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Sets determines if two lists contain the same items, ignoring order and duplicates, for lists
+// which OpenShift Cluster Manager does not store in the order that they were requested.
+func Sets(compare, with []string) bool {
+	return Lists(uniqueSorted(compare), uniqueSorted(with))
+}
+
+// SetsBy determines if two lists contain the same items, ignoring order and duplicates, where each
+// item is identified by a key.  Fields of an item which are not part of its key are not compared.
+func SetsBy[T any](compare, with []T, key func(T) string) bool {
+	keys := func(items []T) []string {
+		result := make([]string, len(items))
+		for i := range items {
+			result[i] = key(items[i])
+		}
+
+		return result
+	}
+
+	return Sets(keys(compare), keys(with))
+}
+
+// Maps determines if two maps contain equal keys and values.  A nil map is equal to an empty map.
+func Maps[K, V comparable](compare, with map[K]V) bool {
+	if len(compare) != len(with) {
+		return false
+	}
+
+	for key, value := range compare {
+		if withValue, ok := with[key]; !ok || withValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Semantic determines if two values are semantically equal, for fields which are not sent to
+// OpenShift Cluster Manager and so are not normalized.
+func Semantic(compare, with interface{}) bool {
+	return equality.Semantic.DeepEqual(compare, with)
+}
+
+// uniqueSorted returns the sorted, unique items of a list.
+func uniqueSorted(items []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+
+	for _, item := range items {
+		if seen[item] {
+			continue
+		}
+
+		seen[item] = true
+		result = append(result, item)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
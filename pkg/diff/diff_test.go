@@ -0,0 +1,348 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	diff := New().
+		Field("displayName", Values("test", "test")).
+		Field("labels", Maps(map[string]string{"this": "that"}, nil)).
+		Field("url", URLs("ldap://example.com", "ldaps://example.com"))
+
+	if diff.Equal() {
+		t.Errorf("Diff.Equal() = %v, want %v", true, false)
+	}
+
+	if got := diff.Fields(); !Lists(got, []string{"labels", "url"}) {
+		t.Errorf("Diff.Fields() = %v, want %v", got, []string{"labels", "url"})
+	}
+
+	if !New().Field("displayName", true).Equal() {
+		t.Errorf("Diff.Equal() = %v, want %v", false, true)
+	}
+}
+
+func TestURLs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		compare string
+		with    string
+		want    bool
+	}{
+		{
+			name:    "ensure equal urls are equal",
+			compare: "https://gitlab.example.com/group",
+			with:    "https://gitlab.example.com/group",
+			want:    true,
+		},
+		{
+			name:    "ensure the case of the scheme and host is ignored",
+			compare: "HTTPS://GitLab.Example.com/group",
+			with:    "https://gitlab.example.com/group",
+			want:    true,
+		},
+		{
+			name:    "ensure a trailing slash is ignored",
+			compare: "https://gitlab.example.com/",
+			with:    "https://gitlab.example.com",
+			want:    true,
+		},
+		{
+			name:    "ensure the case of the path is not ignored",
+			compare: "https://gitlab.example.com/Group",
+			with:    "https://gitlab.example.com/group",
+			want:    false,
+		},
+		{
+			name:    "ensure the query is not ignored",
+			compare: "ldap://ldap.example.com/ou=users?uid",
+			with:    "ldap://ldap.example.com/ou=users?cn",
+			want:    false,
+		},
+		{
+			name:    "ensure invalid urls are compared as strings",
+			compare: "://invalid",
+			with:    "://invalid",
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := URLs(tt.compare, tt.with); got != tt.want {
+				t.Errorf("URLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPointers(t *testing.T) {
+	t.Parallel()
+
+	enabled, disabled, alsoEnabled := true, false, true
+
+	tests := []struct {
+		name    string
+		compare *bool
+		with    *bool
+		want    bool
+	}{
+		{
+			name: "ensure nil pointers are equal",
+			want: true,
+		},
+		{
+			name:    "ensure a nil pointer is not equal to a set pointer",
+			compare: &enabled,
+			want:    false,
+		},
+		{
+			name:    "ensure pointers to equal values are equal",
+			compare: &enabled,
+			with:    &alsoEnabled,
+			want:    true,
+		},
+		{
+			name:    "ensure pointers to different values are not equal",
+			compare: &enabled,
+			with:    &disabled,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Pointers(tt.compare, tt.with); got != tt.want {
+				t.Errorf("Pointers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequested(t *testing.T) {
+	t.Parallel()
+
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name      string
+		requested *bool
+		current   *bool
+		want      bool
+	}{
+		{
+			name:    "ensure a value which is not requested is equal",
+			current: &enabled,
+			want:    true,
+		},
+		{
+			name:      "ensure a requested value which is not set is not equal",
+			requested: &enabled,
+			want:      false,
+		},
+		{
+			name:      "ensure a requested value which differs is not equal",
+			requested: &disabled,
+			current:   &enabled,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Requested(tt.requested, tt.current); got != tt.want {
+				t.Errorf("Requested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKnown(t *testing.T) {
+	t.Parallel()
+
+	if !Known("subnet-a", "") {
+		t.Errorf("Known() = %v, want %v", false, true)
+	}
+
+	if !Known("subnet-a", "subnet-a") {
+		t.Errorf("Known() = %v, want %v", false, true)
+	}
+
+	if Known("subnet-a", "subnet-b") {
+		t.Errorf("Known() = %v, want %v", true, false)
+	}
+}
+
+func TestLists(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		compare []string
+		with    []string
+		want    bool
+	}{
+		{
+			name:    "ensure a nil list is equal to an empty list",
+			compare: nil,
+			with:    []string{},
+			want:    true,
+		},
+		{
+			name:    "ensure equal lists are equal",
+			compare: []string{"uid", "cn"},
+			with:    []string{"uid", "cn"},
+			want:    true,
+		},
+		{
+			name:    "ensure the order of a list is not ignored",
+			compare: []string{"uid", "cn"},
+			with:    []string{"cn", "uid"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Lists(tt.compare, tt.with); got != tt.want {
+				t.Errorf("Lists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		compare []string
+		with    []string
+		want    bool
+	}{
+		{
+			name:    "ensure a nil set is equal to an empty set",
+			compare: nil,
+			with:    []string{},
+			want:    true,
+		},
+		{
+			name:    "ensure the order of a set is ignored",
+			compare: []string{"us-east-1a", "us-east-1b"},
+			with:    []string{"us-east-1b", "us-east-1a"},
+			want:    true,
+		},
+		{
+			name:    "ensure duplicates in a set are ignored",
+			compare: []string{"us-east-1a", "us-east-1a"},
+			with:    []string{"us-east-1a"},
+			want:    true,
+		},
+		{
+			name:    "ensure different sets are not equal",
+			compare: []string{"us-east-1a"},
+			with:    []string{"us-east-1b"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Sets(tt.compare, tt.with); got != tt.want {
+				t.Errorf("Sets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetsBy(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		key   string
+		extra string
+	}
+
+	key := func(i item) string { return i.key }
+
+	if !SetsBy([]item{{key: "a", extra: "1"}, {key: "b"}}, []item{{key: "b"}, {key: "a", extra: "2"}}, key) {
+		t.Errorf("SetsBy() = %v, want %v", false, true)
+	}
+
+	if SetsBy([]item{{key: "a"}}, []item{{key: "b"}}, key) {
+		t.Errorf("SetsBy() = %v, want %v", true, false)
+	}
+}
+
+func TestMaps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		compare map[string]string
+		with    map[string]string
+		want    bool
+	}{
+		{
+			name:    "ensure a nil map is equal to an empty map",
+			compare: nil,
+			with:    map[string]string{},
+			want:    true,
+		},
+		{
+			name:    "ensure equal maps are equal",
+			compare: map[string]string{"this": "that"},
+			with:    map[string]string{"this": "that"},
+			want:    true,
+		},
+		{
+			name:    "ensure a changed value is not equal",
+			compare: map[string]string{"this": "that"},
+			with:    map[string]string{"this": "other"},
+			want:    false,
+		},
+		{
+			name:    "ensure a changed key is not equal",
+			compare: map[string]string{"this": "that"},
+			with:    map[string]string{"other": "that"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Maps(tt.compare, tt.with); got != tt.want {
+				t.Errorf("Maps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	t.Parallel()
+
+	if !Fold("Claim", "claim") {
+		t.Errorf("Fold() = %v, want %v", false, true)
+	}
+
+	if Fold("claim", "lookup") {
+		t.Errorf("Fold() = %v, want %v", true, false)
+	}
+}
@@ -15,47 +15,100 @@ const (
 	Created
 	Updated
 	Deleted
+	Imported
+	Upgraded
+	Rotated
+	UpgradeCompleted
+	Failed
 )
 
 const (
-	UnknownString = "Unknown"
-	CreatedString = "Created"
-	UpdatedString = "Updated"
-	DeletedString = "Deleted"
+	UnknownString          = "Unknown"
+	CreatedString          = "Created"
+	UpdatedString          = "Updated"
+	DeletedString          = "Deleted"
+	ImportedString         = "Imported"
+	UpgradedString         = "Upgraded"
+	RotatedString          = "Rotated"
+	UpgradeCompletedString = "UpgradeCompleted"
+	FailedString           = "Failed"
 )
 
 // String returns the string value of a machine pool event.
 func (event Event) String() string {
 	return map[Event]string{
-		Unknown: UnknownString,
-		Created: CreatedString,
-		Updated: UpdatedString,
-		Deleted: DeletedString,
+		Unknown:          UnknownString,
+		Created:          CreatedString,
+		Updated:          UpdatedString,
+		Deleted:          DeletedString,
+		Imported:         ImportedString,
+		Upgraded:         UpgradedString,
+		Rotated:          RotatedString,
+		UpgradeCompleted: UpgradeCompletedString,
+		Failed:           FailedString,
 	}[event]
 }
 
 // Type returns the type of machine pool event.
 func (event Event) Type() string {
 	return map[Event]string{
-		Unknown: UnknownString,
-		Created: corev1.EventTypeNormal,
-		Updated: corev1.EventTypeNormal,
-		Deleted: corev1.EventTypeNormal,
+		Unknown:          UnknownString,
+		Created:          corev1.EventTypeNormal,
+		Updated:          corev1.EventTypeNormal,
+		Deleted:          corev1.EventTypeNormal,
+		Imported:         corev1.EventTypeNormal,
+		Upgraded:         corev1.EventTypeNormal,
+		Rotated:          corev1.EventTypeNormal,
+		UpgradeCompleted: corev1.EventTypeNormal,
+		Failed:           corev1.EventTypeWarning,
 	}[event]
 }
 
+// Notifier is implemented by event recorders which also push the actions that are registered on an
+// object to an external system, such as a webhook.
+type Notifier interface {
+	Notify(event Event, object client.Object, message string)
+}
+
 // Register registers an event.
 func RegisterAction(event Event, object client.Object, recorder record.EventRecorder, name, cluster string) {
+	message := fmt.Sprintf(
+		"%s %s '%s' in cluster '%s'",
+		object.GetObjectKind().GroupVersionKind(),
+		event.String(),
+		name,
+		cluster,
+	)
+
 	recorder.Event(
 		object,
 		event.Type(),
 		fmt.Sprintf("%s%s", object.GetObjectKind().GroupVersionKind(), event.String()),
-		fmt.Sprintf(
-			"%s %s '%s' in cluster '%s'",
-			object.GetObjectKind().GroupVersionKind(),
-			event.String(),
-			name,
-			cluster,
-		),
+		message,
 	)
+
+	notify(recorder, event, object, message)
+}
+
+// RegisterFailure registers a warning event for a phase of reconciliation which has failed in a way which
+// will not succeed by retrying it, for example because the desired state was rejected by OpenShift Cluster
+// Manager.
+func RegisterFailure(object client.Object, recorder record.EventRecorder, phase string, err error) {
+	message := fmt.Sprintf("%s phase failed - %s", phase, err)
+
+	recorder.Event(object, Failed.Type(), "Reconcile"+Failed.String(), message)
+
+	notify(recorder, Failed, object, message)
+}
+
+// RegisterWarning registers a warning event.
+func RegisterWarning(object client.Object, recorder record.EventRecorder, reason, message string) {
+	recorder.Event(object, corev1.EventTypeWarning, reason, message)
+}
+
+// notify pushes an action to the external system of a recorder, if it supports it.
+func notify(recorder record.EventRecorder, event Event, object client.Object, message string) {
+	if notifier, ok := recorder.(Notifier); ok {
+		notifier.Notify(event, object, message)
+	}
 }
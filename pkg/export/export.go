@@ -0,0 +1,214 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/controllers"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Exporter exports the objects of an existing cluster in OpenShift Cluster Manager as manifests
+// in the format of the custom resources which are managed by this operator.
+type Exporter struct {
+	Connection  *sdk.Connection
+	ClusterName string
+	Namespace   string
+	Log         logr.Logger
+}
+
+// Objects returns the machine pools, or node pools for hosted control plane clusters, and the
+// identity providers of the cluster as custom resources.  Each object is annotated for import so
+// that it is adopted by the operator when it is applied.  Identity providers which are not managed
+// by this operator are skipped.
+func (exporter *Exporter) Objects() ([]client.Object, error) {
+	cluster, err := ocm.NewClusterClient(exporter.Connection, exporter.ClusterName).Get()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve cluster from ocm [name=%s] - %w", exporter.ClusterName, err)
+	}
+
+	machinePools, err := exporter.machinePools(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	identityProviders, err := exporter.identityProviders(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(machinePools, identityProviders...), nil
+}
+
+// machinePools returns the machine pools of the cluster as custom resources.
+func (exporter *Exporter) machinePools(cluster *clustersmgmtv1.Cluster) ([]client.Object, error) {
+	objects := []client.Object{}
+
+	if cluster.Hypershift().Enabled() {
+		nodePools, err := ocm.NewNodePoolClient(exporter.Connection, "", cluster.ID()).List()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list node pools from ocm [clusterName=%s] - %w", exporter.ClusterName, err)
+		}
+
+		for i := range nodePools {
+			current := &ocmv1alpha1.MachinePool{}
+			if err := current.CopyFromNodePool(nodePools[i], exporter.ClusterName); err != nil {
+				return nil, fmt.Errorf("unable to copy ocm node pool object [name=%s] - %w", nodePools[i].ID(), err)
+			}
+
+			objects = append(objects, exporter.machinePool(current))
+		}
+
+		return objects, nil
+	}
+
+	machinePools, err := ocm.NewMachinePoolClient(exporter.Connection, "", cluster.ID()).List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list machine pools from ocm [clusterName=%s] - %w", exporter.ClusterName, err)
+	}
+
+	for i := range machinePools {
+		current := &ocmv1alpha1.MachinePool{}
+		if err := current.CopyFromMachinePool(machinePools[i], exporter.ClusterName); err != nil {
+			return nil, fmt.Errorf("unable to copy ocm machine pool object [name=%s] - %w", machinePools[i].ID(), err)
+		}
+
+		objects = append(objects, exporter.machinePool(current))
+	}
+
+	return objects, nil
+}
+
+// machinePool returns the custom resource for the current state of a machine pool.
+func (exporter *Exporter) machinePool(current *ocmv1alpha1.MachinePool) *ocmv1alpha1.MachinePool {
+	machinePool := &ocmv1alpha1.MachinePool{}
+	machinePool.SetGroupVersionKind(ocmv1alpha1.GroupVersion.WithKind("MachinePool"))
+	exporter.setMetadata(machinePool, current.Spec.DisplayName)
+
+	machinePool.Spec.ClusterName = current.Spec.ClusterName
+	machinePool.Spec.DisplayName = current.Spec.DisplayName
+	machinePool.Spec.InstanceType = current.Spec.InstanceType
+	machinePool.Spec.AWS = current.Spec.AWS
+	machinePool.ImportFrom(current)
+
+	return machinePool
+}
+
+// identityProviders returns the identity providers of the cluster as custom resources.  Secrets
+// are not returned from OpenShift Cluster Manager, so references to secrets are named after the
+// identity provider and must be created separately.
+func (exporter *Exporter) identityProviders(cluster *clustersmgmtv1.Cluster) ([]client.Object, error) {
+	identityProviders, err := ocm.NewIdentityProviderClient(exporter.Connection, "", cluster.ID()).List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list identity providers from ocm [clusterName=%s] - %w", exporter.ClusterName, err)
+	}
+
+	objects := []client.Object{}
+
+	for _, idp := range identityProviders {
+		switch idp.Type() {
+		case clustersmgmtv1.IdentityProviderTypeLDAP:
+			objects = append(objects, exporter.ldapIdentityProvider(idp))
+		case clustersmgmtv1.IdentityProviderTypeGitlab:
+			objects = append(objects, exporter.gitlabIdentityProvider(idp))
+		default:
+			exporter.Log.Info("skipping unsupported identity provider", "name", idp.Name(), "type", idp.Type())
+		}
+	}
+
+	return objects, nil
+}
+
+// ldapIdentityProvider returns the custom resource for an LDAP identity provider.
+func (exporter *Exporter) ldapIdentityProvider(idp *clustersmgmtv1.IdentityProvider) *ocmv1alpha1.LDAPIdentityProvider {
+	ldap := &ocmv1alpha1.LDAPIdentityProvider{}
+	ldap.SetGroupVersionKind(ocmv1alpha1.GroupVersion.WithKind("LDAPIdentityProvider"))
+	exporter.setMetadata(ldap, idp.Name())
+
+	ldap.Spec.ClusterName = exporter.ClusterName
+	ldap.Spec.DisplayName = idp.Name()
+	ldap.Spec.MappingMethod = string(idp.MappingMethod())
+	ldap.Spec.Challenge, ldap.Spec.Login = ocm.LoginOptions(idp)
+	ldap.CopyFrom(idp.LDAP())
+
+	if ldap.Spec.BindDN != "" {
+		ldap.Spec.BindPassword.Name = ldap.Name + "-bind-password"
+	}
+
+	if idp.LDAP().CA() != "" {
+		ldap.Spec.CA.Name = ldap.Name + "-ca"
+	}
+
+	return ldap
+}
+
+// gitlabIdentityProvider returns the custom resource for a GitLab identity provider.
+func (exporter *Exporter) gitlabIdentityProvider(idp *clustersmgmtv1.IdentityProvider) *ocmv1alpha1.GitLabIdentityProvider {
+	gitlab := &ocmv1alpha1.GitLabIdentityProvider{}
+	gitlab.SetGroupVersionKind(ocmv1alpha1.GroupVersion.WithKind("GitLabIdentityProvider"))
+	exporter.setMetadata(gitlab, idp.Name())
+
+	gitlab.Spec.ClusterName = exporter.ClusterName
+	gitlab.Spec.DisplayName = idp.Name()
+	gitlab.Spec.MappingMethod = string(idp.MappingMethod())
+	gitlab.Spec.Challenge, gitlab.Spec.Login = ocm.LoginOptions(idp)
+	gitlab.Spec.AccessTokenSecret = gitlab.Name + "-access-token"
+	gitlab.CopyFrom(idp.Gitlab())
+
+	return gitlab
+}
+
+// setMetadata sets the name, namespace and import annotations of an exported object.  Exported objects
+// are adopted as they were read from OpenShift Cluster Manager in order to be onboarded.
+func (exporter *Exporter) setMetadata(object client.Object, name string) {
+	object.SetName(objectName(name))
+	object.SetNamespace(exporter.Namespace)
+	object.SetAnnotations(map[string]string{
+		controllers.AnnotationImport: "true",
+		controllers.AnnotationAdopt:  "true",
+	})
+}
+
+// Write writes objects to a writer as a multi-document YAML stream.  The status and creation
+// timestamp are removed from each object as they are not relevant to an applied manifest.
+func Write(w io.Writer, objects ...client.Object) error {
+	for _, object := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return fmt.Errorf("unable to convert object [name=%s] - %w", object.GetName(), err)
+		}
+
+		unstructured.RemoveNestedField(content, "status")
+		unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+
+		manifest, err := yaml.Marshal(content)
+		if err != nil {
+			return fmt.Errorf("unable to marshal object [name=%s] - %w", object.GetName(), err)
+		}
+
+		if _, err := fmt.Fprintf(w, "---\n%s", manifest); err != nil {
+			return fmt.Errorf("unable to write object [name=%s] - %w", object.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// objectName returns a valid kubernetes object name from the name of an object in OpenShift
+// Cluster Manager.
+func objectName(name string) string {
+	return strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
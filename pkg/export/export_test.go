@@ -0,0 +1,73 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func Test_objectName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "ensure a valid name is unchanged",
+			in:   "worker-1",
+			want: "worker-1",
+		},
+		{
+			name: "ensure a name is lowercased and invalid characters are replaced",
+			in:   "Corp LDAP_1",
+			want: "corp-ldap-1",
+		},
+		{
+			name: "ensure leading and trailing separators are removed",
+			in:   "_ldap_",
+			want: "ldap",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := objectName(tt.in); got != tt.want {
+				t.Errorf("objectName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	machinePool := &ocmv1alpha1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec:       ocmv1alpha1.MachinePoolSpec{ClusterName: "test", MinimumNodesPerZone: 1},
+	}
+	machinePool.SetGroupVersionKind(ocmv1alpha1.GroupVersion.WithKind("MachinePool"))
+
+	var out bytes.Buffer
+	if err := Write(&out, machinePool, machinePool); err != nil {
+		t.Fatalf("Write() error = %v, wantErr %v", err, false)
+	}
+
+	got := out.String()
+	if count := strings.Count(got, "---\n"); count != 2 {
+		t.Errorf("Write() documents = %v, want %v", count, 2)
+	}
+
+	for _, unwanted := range []string{"status:", "creationTimestamp:"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Write() = %v, want no %v", got, unwanted)
+		}
+	}
+}
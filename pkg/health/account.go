@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	// DefaultAccountInterval is the default interval at which the account which the operator is
+	// authenticated as is retrieved from OpenShift Cluster Manager.
+	DefaultAccountInterval = 10 * time.Minute
+
+	// AccountInfoMetric is the name of the metric which exposes the account, and the organization
+	// of the account, which the operator is authenticated as.
+	AccountInfoMetric = "ocm_account_info"
+)
+
+// AccountMonitor periodically retrieves the account, and the organization of the account, which the
+// operator is authenticated with OpenShift Cluster Manager as.  The account is logged when it is first
+// retrieved and when it changes, and is exposed as an info metric, so that users may verify that the
+// operator is bound to the expected organization before it mutates any resources.
+type AccountMonitor struct {
+	Connection *sdk.Connection
+	Log        logr.Logger
+	Interval   time.Duration
+
+	// Info is the info metric which exposes the account.  The account is not exposed as a metric if
+	// this is nil.
+	Info *prometheus.GaugeVec
+
+	// account is the account which was previously retrieved
+	account *ocm.Account
+}
+
+// NewAccountInfo returns the info metric which exposes the account which the operator is authenticated
+// as.  The metric is registered with the registerer.
+func NewAccountInfo(registerer prometheus.Registerer) (*prometheus.GaugeVec, error) {
+	info := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: AccountInfoMetric,
+			Help: "Account, and organization of the account, which the operator is authenticated with OCM as.",
+		},
+		[]string{"username", "organization_id", "organization_name", "organization_external_id"},
+	)
+
+	if err := registerer.Register(info); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.  The account is logged
+// by every replica of the operator.
+func (monitor *AccountMonitor) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements the manager.Runnable interface.  It retrieves the account on startup and at each
+// interval until the context is cancelled.
+func (monitor *AccountMonitor) Start(ctx context.Context) error {
+	interval := monitor.Interval
+	if interval == 0 {
+		interval = DefaultAccountInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		account, err := ocm.GetAccount(ctx, monitor.Connection)
+		if err != nil {
+			monitor.Log.Error(err, "unable to retrieve ocm account")
+		} else {
+			monitor.Observe(account)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Observe records a retrieved account.  The account is logged and the info metric is updated when the
+// account differs from the previously retrieved account.  It returns whether the account has changed.
+func (monitor *AccountMonitor) Observe(account *ocm.Account) bool {
+	if monitor.account != nil && *monitor.account == *account {
+		return false
+	}
+
+	monitor.Log.Info(
+		"authenticated with ocm",
+		"username", account.Username,
+		"organizationID", account.OrganizationID,
+		"organizationName", account.OrganizationName,
+		"organizationExternalID", account.OrganizationExternalID,
+	)
+
+	if monitor.Info != nil {
+		monitor.Info.Reset()
+		monitor.Info.WithLabelValues(
+			account.Username,
+			account.OrganizationID,
+			account.OrganizationName,
+			account.OrganizationExternalID,
+		).Set(1)
+	}
+
+	monitor.account = account
+
+	return true
+}
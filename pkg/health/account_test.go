@@ -0,0 +1,65 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+func TestAccountMonitor_Observe(t *testing.T) {
+	t.Parallel()
+
+	first := &ocm.Account{Username: "first", OrganizationID: "1", OrganizationName: "first"}
+	second := &ocm.Account{Username: "second", OrganizationID: "2", OrganizationName: "second"}
+
+	tests := []struct {
+		name     string
+		accounts []*ocm.Account
+		want     []bool
+	}{
+		{
+			name:     "ensure the first account is observed as changed",
+			accounts: []*ocm.Account{first},
+			want:     []bool{true},
+		},
+		{
+			name:     "ensure an unchanged account is not observed as changed",
+			accounts: []*ocm.Account{first, {Username: "first", OrganizationID: "1", OrganizationName: "first"}},
+			want:     []bool{true, false},
+		},
+		{
+			name:     "ensure a different account is observed as changed",
+			accounts: []*ocm.Account{first, second},
+			want:     []bool{true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := NewAccountInfo(prometheus.NewRegistry())
+			if err != nil {
+				t.Fatalf("NewAccountInfo() error = %v, wantErr %v", err, false)
+			}
+
+			monitor := &AccountMonitor{Log: logr.Discard(), Info: info}
+
+			for i, account := range tt.accounts {
+				if got := monitor.Observe(account); got != tt.want[i] {
+					t.Errorf("Observe() = %v, want %v", got, tt.want[i])
+				}
+			}
+
+			// only the latest account is exposed
+			if got := testutil.CollectAndCount(info); got != 1 {
+				t.Errorf("Observe() series = %v, want %v", got, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh-mobb/ocm-operator/pkg/conditions"
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	// DefaultInterval is the default interval at which the health of the operator is reported.
+	DefaultInterval = time.Minute
+
+	// WebhookCertificateFile is the name of the serving certificate file within the certificate
+	// directory of the webhook server.
+	WebhookCertificateFile = "tls.crt"
+
+	reconcileTotalMetric = "controller_runtime_reconcile_total"
+	reconcileResultError = "error"
+)
+
+var (
+	ErrCertificateInvalid = errors.New("invalid certificate")
+	ErrCertificateExpired = errors.New("certificate is not currently valid")
+)
+
+// The operator must be able to update the operator condition which is created for it by
+// Operator Lifecycle Manager.
+
+//+kubebuilder:rbac:groups=operators.coreos.com,resources=operatorconditions,verbs=get;list;watch;update;patch
+
+// Reporter periodically reports the health of the operator as conditions on the operator condition
+// which is created for the operator by Operator Lifecycle Manager.  This allows cluster administrators
+// to see the degradation of the operator without reading its logs.
+type Reporter struct {
+	Client     kubernetes.Client
+	Connection *sdk.Connection
+	Gatherer   prometheus.Gatherer
+	Log        logr.Logger
+	Interval   time.Duration
+
+	// Namespace and Name are the namespace and name of the operator condition.
+	Namespace string
+	Name      string
+
+	// CertDir is the certificate directory of the webhook server.  The webhook certificate is not
+	// checked if this is empty.
+	CertDir string
+
+	// Compatibility is the compatibility of the APIs of OpenShift Cluster Manager with the operator.
+	// Every API is reported as compatible if this is nil.
+	Compatibility *ocm.Compatibility
+
+	// Maintenance records whether OpenShift Cluster Manager is under maintenance.  Maintenance is
+	// never reported as active if this is nil.
+	Maintenance *ocm.Maintenance
+
+	// reconciles are the reconciliation totals, by controller and result, from the previous report
+	reconciles map[string]map[string]float64
+}
+
+// Start implements the manager.Runnable interface.  It reports the health of the operator at each
+// interval until the context is cancelled.
+func (reporter *Reporter) Start(ctx context.Context) error {
+	interval := reporter.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := reporter.Report(ctx); err != nil {
+			reporter.Log.Error(err, "unable to report operator health")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report checks the health of the operator and sets the resulting conditions on the operator condition.
+func (reporter *Reporter) Report(ctx context.Context) error {
+	account, err := ocm.GetAccount(ctx, reporter.Connection)
+
+	var accountString string
+	if err == nil {
+		accountString = account.String()
+	}
+
+	healthConditions := []metav1.Condition{
+		conditions.OCMConnected(err),
+		conditions.OCMAccount(accountString, err),
+	}
+
+	if reporter.CertDir != "" {
+		healthConditions = append(healthConditions, conditions.WebhookCertificateValid(
+			CheckCertificate(filepath.Join(reporter.CertDir, WebhookCertificateFile), time.Now()),
+		))
+	}
+
+	failing, err := reporter.failingControllers()
+	if err != nil {
+		return err
+	}
+
+	healthConditions = append(
+		healthConditions,
+		conditions.ControllersHealthy(failing),
+		conditions.OCMAPICompatible(reporter.Compatibility.Incompatible()),
+		conditions.OCMMaintenance(reporter.Maintenance.Active()),
+	)
+
+	//nolint:wrapcheck
+	return kubernetes.SetOperatorConditions(ctx, reporter.Client, reporter.Namespace, reporter.Name, healthConditions...)
+}
+
+// failingControllers returns the controllers which have only returned errors since the previous
+// report, based upon the reconciliation metrics of the controllers.
+func (reporter *Reporter) failingControllers() ([]string, error) {
+	families, err := reporter.Gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("unable to gather controller metrics - %w", err)
+	}
+
+	current := map[string]map[string]float64{}
+
+	for _, family := range families {
+		if family.GetName() != reconcileTotalMetric {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			var controller, result string
+
+			for _, label := range metric.GetLabel() {
+				switch label.GetName() {
+				case "controller":
+					controller = label.GetValue()
+				case "result":
+					result = label.GetValue()
+				}
+			}
+
+			if current[controller] == nil {
+				current[controller] = map[string]float64{}
+			}
+
+			current[controller][result] = metric.GetCounter().GetValue()
+		}
+	}
+
+	failing := FailingControllers(reporter.reconciles, current)
+
+	reporter.reconciles = current
+
+	return failing, nil
+}
+
+// FailingControllers returns the sorted names of the controllers which have returned reconciliation
+// errors, without any other reconciliation results, between a previous and current set of
+// reconciliation totals by controller and result.
+func FailingControllers(previous, current map[string]map[string]float64) []string {
+	failing := []string{}
+
+	for controller, results := range current {
+		var errored, succeeded bool
+
+		for result, total := range results {
+			if total <= previous[controller][result] {
+				continue
+			}
+
+			if result == reconcileResultError {
+				errored = true
+			} else {
+				succeeded = true
+			}
+		}
+
+		if errored && !succeeded {
+			failing = append(failing, controller)
+		}
+	}
+
+	sort.Strings(failing)
+
+	return failing
+}
+
+// CheckCertificate checks that the PEM encoded certificate in a file is valid at a given time.
+func CheckCertificate(file string, now time.Time) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("unable to read certificate [%s] - %w", file, err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return fmt.Errorf("unable to decode certificate [%s] - %w", file, ErrCertificateInvalid)
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("unable to parse certificate [%s] - %w", file, err)
+	}
+
+	if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+		return fmt.Errorf(
+			"certificate [%s] is valid from [%s] until [%s] - %w",
+			file,
+			certificate.NotBefore.Format(time.RFC3339),
+			certificate.NotAfter.Format(time.RFC3339),
+			ErrCertificateExpired,
+		)
+	}
+
+	return nil
+}
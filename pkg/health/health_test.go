@@ -0,0 +1,142 @@
+package health
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testCertificate(t *testing.T, notBefore, notAfter time.Time) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v, wantErr %v", err, false)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "webhook-service"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v, wantErr %v", err, false)
+	}
+
+	file := filepath.Join(t.TempDir(), WebhookCertificateFile)
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v, wantErr %v", err, false)
+	}
+
+	return file
+}
+
+func TestCheckCertificate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	invalid := filepath.Join(t.TempDir(), WebhookCertificateFile)
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v, wantErr %v", err, false)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{
+			name:    "ensure a current certificate is valid",
+			file:    testCertificate(t, now.Add(-time.Hour), now.Add(time.Hour)),
+			wantErr: false,
+		},
+		{
+			name:    "ensure an expired certificate is invalid",
+			file:    testCertificate(t, now.Add(-2*time.Hour), now.Add(-time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:    "ensure a certificate which is not yet valid is invalid",
+			file:    testCertificate(t, now.Add(time.Hour), now.Add(2*time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:    "ensure a file which is not a certificate is invalid",
+			file:    invalid,
+			wantErr: true,
+		},
+		{
+			name:    "ensure a missing certificate is invalid",
+			file:    filepath.Join(t.TempDir(), WebhookCertificateFile),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckCertificate(tt.file, now); (err != nil) != tt.wantErr {
+				t.Errorf("CheckCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFailingControllers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		previous map[string]map[string]float64
+		current  map[string]map[string]float64
+		want     []string
+	}{
+		{
+			name:     "ensure a controller with only errors is failing",
+			previous: map[string]map[string]float64{"machinepool": {"error": 1, "success": 2}},
+			current:  map[string]map[string]float64{"machinepool": {"error": 3, "success": 2}},
+			want:     []string{"machinepool"},
+		},
+		{
+			name:     "ensure a controller with errors and successes is not failing",
+			previous: map[string]map[string]float64{"machinepool": {"error": 1, "success": 2}},
+			current:  map[string]map[string]float64{"machinepool": {"error": 3, "success": 3}},
+			want:     []string{},
+		},
+		{
+			name:     "ensure a controller without reconciles is not failing",
+			previous: map[string]map[string]float64{"machinepool": {"error": 1}},
+			current:  map[string]map[string]float64{"machinepool": {"error": 1}},
+			want:     []string{},
+		},
+		{
+			name:    "ensure failing controllers are found without a previous report",
+			current: map[string]map[string]float64{"machinepool": {"error": 1}, "gitlabidentityprovider": {"error": 2}},
+			want:    []string{"gitlabidentityprovider", "machinepool"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FailingControllers(tt.previous, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FailingControllers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/migration"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+var (
+	ErrDefinitionsMissing        = errors.New("custom resource definitions are not installed")
+	ErrDefinitionsNotEstablished = errors.New("custom resource definitions are not established")
+)
+
+// Readiness is a readiness check which only reports the operator as ready once it is able to reconcile,
+// rather than as soon as its probe endpoint is served.  The operator is ready once the custom resource
+// definitions of the operator are established, the webhook certificate has been loaded and the
+// connection to each environment of OpenShift Cluster Manager has been validated.  Once the operator is ready it remains
+// ready, so that the operator is not removed from service when OpenShift Cluster Manager is briefly
+// unavailable, which is instead reported by the Reporter.
+type Readiness struct {
+	// Reader reads the custom resource definitions of the operator.  It should read directly from the
+	// API, as the custom resource definitions are not cached by the operator.
+	Reader       client.Reader
+	Environments *ocm.Environments
+	Log          logr.Logger
+
+	// Group is the API group of the custom resource definitions of the operator.
+	Group string
+
+	// CertDir is the certificate directory of the webhook server.  The webhook certificate is not
+	// checked if this is empty.
+	CertDir string
+
+	mutex sync.Mutex
+	ready bool
+}
+
+// Check implements the healthz.Checker interface.
+func (readiness *Readiness) Check(req *http.Request) error {
+	readiness.mutex.Lock()
+	defer readiness.mutex.Unlock()
+
+	if readiness.ready {
+		return nil
+	}
+
+	if err := CheckDefinitions(req.Context(), readiness.Reader, readiness.Group); err != nil {
+		return err
+	}
+
+	if readiness.CertDir != "" {
+		if err := CheckCertificate(filepath.Join(readiness.CertDir, WebhookCertificateFile), time.Now()); err != nil {
+			return err
+		}
+	}
+
+	var connectionErr error
+
+	readiness.Environments.Each(func(name string, environment *ocm.Environment) {
+		if connectionErr != nil {
+			return
+		}
+
+		if err := ocm.CheckConnection(req.Context(), environment.Connection); err != nil {
+			connectionErr = fmt.Errorf("environment [%s] - %w", name, err)
+		}
+	})
+
+	if connectionErr != nil {
+		return connectionErr
+	}
+
+	readiness.Log.Info("operator is ready to reconcile")
+	readiness.ready = true
+
+	return nil
+}
+
+// CheckDefinitions checks that the custom resource definitions in an API group are installed and have
+// been established, so that their custom resources may be served.
+func CheckDefinitions(ctx context.Context, reader client.Reader, group string) error {
+	definitions := &unstructured.UnstructuredList{}
+	definitions.SetGroupVersionKind(migration.CustomResourceDefinitionListGroupVersionKind)
+
+	if err := reader.List(ctx, definitions); err != nil {
+		return fmt.Errorf("unable to list custom resource definitions - %w", err)
+	}
+
+	var found int
+
+	for i := range definitions.Items {
+		if definitionGroup, _, _ := unstructured.NestedString(definitions.Items[i].Object, "spec", "group"); definitionGroup != group {
+			continue
+		}
+
+		found++
+
+		if !established(&definitions.Items[i]) {
+			return fmt.Errorf("custom resource definition [%s] - %w", definitions.Items[i].GetName(), ErrDefinitionsNotEstablished)
+		}
+	}
+
+	if found == 0 {
+		return fmt.Errorf("custom resource definitions in group [%s] - %w", group, ErrDefinitionsMissing)
+	}
+
+	return nil
+}
+
+// established determines if a custom resource definition has been established.
+func established(definition *unstructured.Unstructured) bool {
+	statusConditions, _, _ := unstructured.NestedSlice(definition.Object, "status", "conditions")
+
+	for _, condition := range statusConditions {
+		fields, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if fields["type"] == "Established" && fields["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
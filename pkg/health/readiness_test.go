@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const testGroup = "ocm.mobb.redhat.com"
+
+// definitionClient is a fake client which lists a set of custom resource definitions.
+type definitionClient struct {
+	kubernetes.FakeClient
+
+	definitions []unstructured.Unstructured
+}
+
+func (c *definitionClient) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	if unstructuredList, ok := list.(*unstructured.UnstructuredList); ok {
+		unstructuredList.Items = c.definitions
+	}
+
+	return nil
+}
+
+func testDefinition(name, group, established string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name},
+		"spec":     map[string]interface{}{"group": group},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+				map[string]interface{}{"type": "Established", "status": established},
+			},
+		},
+	}}
+}
+
+func TestCheckDefinitions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		definitions []unstructured.Unstructured
+		want        error
+	}{
+		{
+			name: "ensure established definitions are ready",
+			definitions: []unstructured.Unstructured{
+				testDefinition("machinepools."+testGroup, testGroup, "True"),
+				testDefinition("clusterlabels."+testGroup, testGroup, "True"),
+			},
+			want: nil,
+		},
+		{
+			name: "ensure a definition which is not established is not ready",
+			definitions: []unstructured.Unstructured{
+				testDefinition("machinepools."+testGroup, testGroup, "True"),
+				testDefinition("clusterlabels."+testGroup, testGroup, "False"),
+			},
+			want: ErrDefinitionsNotEstablished,
+		},
+		{
+			name: "ensure definitions in another group are ignored",
+			definitions: []unstructured.Unstructured{
+				testDefinition("machinepools."+testGroup, testGroup, "True"),
+				testDefinition("widgets.example.com", "example.com", "False"),
+			},
+			want: nil,
+		},
+		{
+			name: "ensure missing definitions are not ready",
+			definitions: []unstructured.Unstructured{
+				testDefinition("widgets.example.com", "example.com", "True"),
+			},
+			want: ErrDefinitionsMissing,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckDefinitions(context.Background(), &definitionClient{definitions: tt.definitions}, testGroup)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("CheckDefinitions() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadiness_Check(t *testing.T) {
+	t.Parallel()
+
+	readiness := &Readiness{
+		Reader: &definitionClient{definitions: []unstructured.Unstructured{
+			testDefinition("machinepools."+testGroup, testGroup, "False"),
+		}},
+		Group: testGroup,
+	}
+
+	if err := readiness.Check(httptest.NewRequest("GET", "/readyz", nil)); !errors.Is(err, ErrDefinitionsNotEstablished) {
+		t.Errorf("Readiness.Check() error = %v, want %v", err, ErrDefinitionsNotEstablished)
+	}
+
+	// once the operator is ready it remains ready without being checked again
+	readiness.ready = true
+
+	if err := readiness.Check(httptest.NewRequest("GET", "/readyz", nil)); err != nil {
+		t.Errorf("Readiness.Check() error = %v, want %v", err, nil)
+	}
+}
@@ -0,0 +1,136 @@
+package identityprovider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	defaultLDAPTimeout = 10 * time.Second
+
+	ldapSchemeSecure = "ldaps"
+)
+
+var (
+	ErrLDAPInvalidCA  = errors.New("unable to parse ldap ca data")
+	ErrLDAPConnection = errors.New("unable to connect to ldap server")
+	ErrLDAPTLS        = errors.New("unable to establish tls connection to ldap server")
+	ErrLDAPBind       = errors.New("unable to bind to ldap server")
+)
+
+// LDAP represents the connection details of an LDAP server which is used as an identity provider.
+type LDAP struct {
+	URL          string
+	BindDN       string
+	BindPassword string
+	CA           string
+	Insecure     bool
+	Timeout      time.Duration
+}
+
+// Validate validates that a connection can be established to the LDAP server with the same
+// semantics as OpenShift uses for an LDAP identity provider.  An ldaps:// URL connects using TLS, an
+// ldap:// URL is upgraded to TLS using StartTLS unless insecure is set, and a bind is attempted
+// when a bind DN is provided.
+func (l *LDAP) Validate() error {
+	parsed, err := url.Parse(l.URL)
+	if err != nil {
+		return fmt.Errorf("unable to parse ldap url [%s] - %w", l.URL, err)
+	}
+
+	tlsConfig, err := l.tlsConfig(parsed.Hostname())
+	if err != nil {
+		return err
+	}
+
+	timeout := l.Timeout
+	if timeout == 0 {
+		timeout = defaultLDAPTimeout
+	}
+
+	// connect to the ldap server.  the url is rebuilt from the scheme and host only, as the
+	// path and query of an identity provider url contain search parameters.
+	conn, err := ldap.DialURL(
+		(&url.URL{Scheme: parsed.Scheme, Host: parsed.Host}).String(),
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		if parsed.Scheme == ldapSchemeSecure && isTLSError(err) {
+			return fmt.Errorf("%s - %w", err, ErrLDAPTLS)
+		}
+
+		return fmt.Errorf("%s - %w", err, ErrLDAPConnection)
+	}
+	defer conn.Close()
+
+	conn.SetTimeout(timeout)
+
+	// upgrade the connection to tls if we are not using ldaps and have not requested an insecure
+	// connection
+	if parsed.Scheme != ldapSchemeSecure && !l.Insecure {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("%s - %w", err, ErrLDAPTLS)
+		}
+	}
+
+	// bind to the ldap server if we have a bind dn
+	if l.BindDN != "" {
+		if err := conn.Bind(l.BindDN, l.BindPassword); err != nil {
+			return fmt.Errorf("%s - %w", err, ErrLDAPBind)
+		}
+	}
+
+	return nil
+}
+
+// tlsConfig returns the tls configuration used to connect to the ldap server.  The system roots are
+// used unless a ca is provided.
+func (l *LDAP) tlsConfig(serverName string) (*tls.Config, error) {
+	//nolint:gosec
+	config := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if l.CA == "" {
+		return config, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(l.CA)) {
+		return nil, ErrLDAPInvalidCA
+	}
+
+	config.RootCAs = pool
+
+	return config, nil
+}
+
+// isTLSError determines if an error was returned as a result of a failed tls handshake.
+func isTLSError(err error) bool {
+	// the ldap error does not support unwrapping, so we must unwrap the underlying error ourselves
+	var ldapErr *ldap.Error
+	if errors.As(err, &ldapErr) && ldapErr.Err != nil {
+		err = ldapErr.Err
+	}
+
+	var (
+		recordErr   tls.RecordHeaderError
+		unknownErr  x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		invalidErr  x509.CertificateInvalidError
+	)
+
+	return errors.As(err, &recordErr) ||
+		errors.As(err, &unknownErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
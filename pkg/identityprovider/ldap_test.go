@@ -0,0 +1,38 @@
+package identityprovider
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLDAP_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ldap *LDAP
+		want error
+	}{
+		{
+			name: "ensure an invalid ca is reported",
+			ldap: &LDAP{URL: "ldaps://127.0.0.1:1", CA: "invalid"},
+			want: ErrLDAPInvalidCA,
+		},
+		{
+			name: "ensure an unreachable server is reported as a connection error",
+			ldap: &LDAP{URL: "ldap://127.0.0.1:1/dc=example,dc=com?uid", Insecure: true, Timeout: time.Second},
+			want: ErrLDAPConnection,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.ldap.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("LDAP.Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
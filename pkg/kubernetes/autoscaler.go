@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ClusterAutoscalerName is the name of the cluster autoscaler.  OpenShift only honors a single
+	// cluster autoscaler with this name.
+	ClusterAutoscalerName = "default"
+)
+
+// ClusterAutoscalerGroupVersionKind is the group, version and kind of the OpenShift cluster autoscaler.
+var ClusterAutoscalerGroupVersionKind = schema.GroupVersionKind{
+	Group:   "autoscaling.openshift.io",
+	Version: "v1",
+	Kind:    "ClusterAutoscaler",
+}
+
+// GetClusterAutoscalerMaxNodesTotal returns the spec.resourceLimits.maxNodesTotal field of the cluster
+// autoscaler.  The cluster autoscaler is retrieved as an unstructured object so that we do not depend
+// upon the OpenShift APIs.  The returned boolean is false when the cluster autoscaler does not exist, and
+// a maximum of 0 indicates that the cluster autoscaler does not limit the total number of nodes.
+func GetClusterAutoscalerMaxNodesTotal(ctx context.Context, c Client) (int64, bool, error) {
+	autoscaler := &unstructured.Unstructured{}
+	autoscaler.SetGroupVersionKind(ClusterAutoscalerGroupVersionKind)
+
+	if err := c.Get(ctx, types.NamespacedName{Name: ClusterAutoscalerName}, autoscaler); err != nil {
+		// a missing custom resource definition is treated the same as a missing cluster autoscaler
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return 0, false, nil
+		}
+
+		return 0, false, fmt.Errorf(
+			"unable to retrieve cluster autoscaler [%s] from cluster - %w",
+			ClusterAutoscalerName,
+			err,
+		)
+	}
+
+	maxNodesTotal, _, err := unstructured.NestedInt64(autoscaler.Object, "spec", "resourceLimits", "maxNodesTotal")
+	if err != nil {
+		return 0, true, fmt.Errorf(
+			"unable to read spec.resourceLimits.maxNodesTotal from cluster autoscaler [%s] - %w",
+			ClusterAutoscalerName,
+			err,
+		)
+	}
+
+	return maxNodesTotal, true, nil
+}
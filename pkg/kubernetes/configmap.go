@@ -5,7 +5,11 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func GetConfigMapData(ctx context.Context, c Client, name, namespace, key string) (string, error) {
@@ -23,9 +27,61 @@ func GetConfigMapData(ctx context.Context, c Client, name, namespace, key string
 		)
 	}
 
-	if configMap.Data == nil || len(configMap.Data[key]) == 0 {
-		return "", nil
+	// fall back to the binary data field for data which is not valid utf-8 text
+	if len(configMap.Data[key]) > 0 {
+		return configMap.Data[key], nil
 	}
 
-	return string(configMap.Data[key]), nil
+	return string(configMap.BinaryData[key]), nil
+}
+
+// GetConfigMapVersion returns an identifier of the version of a config map which changes whenever its
+// data may have changed, so that a change to the data may be detected without recording the data itself.
+func GetConfigMapVersion(ctx context.Context, c Client, name, namespace string) (string, error) {
+	return version(ctx, c, &corev1.ConfigMap{}, name, namespace)
+}
+
+// ApplyConfigMapData creates or updates a config map so that it contains the provided data.  The
+// config map is owned by the owner object so that it is deleted along with it.  A config map which
+// already exists without a controller reference to the owner is left alone and an error is returned.
+func ApplyConfigMapData(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	name string,
+	data map[string]string,
+) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+		},
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, configMap, func() error {
+		if err := ensureControlledBy(configMap, owner); err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+
+		for key, value := range data {
+			configMap.Data[key] = value
+		}
+
+		//nolint:wrapcheck
+		return controllerutil.SetControllerReference(owner, configMap, scheme)
+	}); err != nil {
+		return fmt.Errorf(
+			"unable to apply configmap [%s/%s] to cluster - %w",
+			owner.GetNamespace(),
+			name,
+			err,
+		)
+	}
+
+	return nil
 }
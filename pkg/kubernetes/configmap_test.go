@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testOwner returns an object which owns the objects applied in tests.
+func testOwner() *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "test", UID: types.UID("owner-uid")},
+	}
+}
+
+// ownedBy returns the owner references which mark an object as controlled by the owner.
+func ownedBy(owner client.Object) []metav1.OwnerReference {
+	return []metav1.OwnerReference{*metav1.NewControllerRef(owner, corev1.SchemeGroupVersion.WithKind("Namespace"))}
+}
+
+func TestApplyConfigMapData(t *testing.T) {
+	t.Parallel()
+
+	owner := testOwner()
+
+	for _, tt := range []struct {
+		name     string
+		existing []client.Object
+		wantErr  error
+		wantData string
+	}{
+		{
+			name:     "ensure a missing config map is created",
+			wantData: "new",
+		},
+		{
+			name: "ensure a config map controlled by the owner is updated",
+			existing: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "info", Namespace: "test", OwnerReferences: ownedBy(owner)},
+				Data:       map[string]string{"key": "old"},
+			}},
+			wantData: "new",
+		},
+		{
+			name: "ensure a config map not controlled by the owner is not adopted",
+			existing: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "info", Namespace: "test"},
+				Data:       map[string]string{"key": "old"},
+			}},
+			wantErr:  ErrNotOwned,
+			wantData: "old",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(tt.existing...).Build()
+
+			err := ApplyConfigMapData(context.Background(), c, clientgoscheme.Scheme, owner, "info", map[string]string{"key": "new"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyConfigMapData() error = %v, want %v", err, tt.wantErr)
+			}
+
+			configMap := &corev1.ConfigMap{}
+			if err := c.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "info"}, configMap); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if got := configMap.Data["key"]; got != tt.wantData {
+				t.Errorf("ApplyConfigMapData() data = %v, want %v", got, tt.wantData)
+			}
+		})
+	}
+}
@@ -2,10 +2,17 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -13,6 +20,10 @@ const (
 	optimisticLockErrorMessage = "the object has been modified; please apply your changes to the latest version and try again"
 )
 
+// ErrNotOwned is returned when an object which is applied on behalf of an owner already exists without
+// a controller reference to that owner.  Such an object belongs to someone else and is never adopted.
+var ErrNotOwned = errors.New("object exists and is not controlled by its owner")
+
 type Client interface {
 	Get(context.Context, types.NamespacedName, client.Object, ...client.GetOption) error
 	List(context.Context, client.ObjectList, ...client.ListOption) error
@@ -20,24 +31,158 @@ type Client interface {
 	Status() client.SubResourceWriter
 }
 
-// PatchStatus patches the status of a kubernetes resource.
+// LatestReader is a client which is able to read the latest version of an object directly from the API,
+// rather than from a cache which may not yet have observed it.
+type LatestReader interface {
+	GetAPIReader() client.Reader
+}
+
+// PatchStatus patches the status of a kubernetes resource.  The changes between the current and patched
+// objects are applied with optimistic concurrency.  If the resource has been modified since it was
+// retrieved, the latest version is retrieved, directly from the API when the reconciler is a LatestReader,
+// and the same status changes are re-applied to it, with an exponential backoff between attempts, so
+// that a transient conflict does not fail the reconciliation.
+// A status which is unchanged is not patched, so that objects which are reconciled at an interval do
+// not generate a write for every reconciliation.
 func PatchStatus(
 	ctx context.Context,
 	reconciler Client,
 	current, patched client.Object,
 ) error {
-	// run the patch
-	if err := reconciler.Status().Patch(ctx, patched, client.MergeFrom(current)); err != nil {
-		if isOptimisticLockError(err) {
-			return nil
+	if statusUnchanged(current, patched) {
+		return nil
+	}
+
+	// calculate the status changes once so that they may be re-applied to the latest version
+	changes, err := client.MergeFrom(current).Data(patched)
+	if err != nil {
+		return fmt.Errorf("unable to calculate status patch - %w", err)
+	}
+
+	resourceVersion := current.GetResourceVersion()
+
+	if err := retry.OnError(retry.DefaultBackoff, isConflict, func() error {
+		patch, err := optimisticPatch(changes, resourceVersion)
+		if err != nil {
+			return err
+		}
+
+		patchErr := reconciler.Status().Patch(ctx, patched, patch)
+		if patchErr == nil || !isConflict(patchErr) {
+			return patchErr
 		}
 
+		// retrieve the latest version of the object so that the changes may be re-applied to it
+		latest, ok := current.DeepCopyObject().(client.Object)
+		if !ok {
+			return patchErr
+		}
+
+		if err := latestReader(reconciler).Get(ctx, client.ObjectKeyFromObject(current), latest); err != nil {
+			return fmt.Errorf("unable to retrieve latest version of object - %w", err)
+		}
+
+		resourceVersion = latest.GetResourceVersion()
+
+		return patchErr
+	}); err != nil {
 		return fmt.Errorf("unable to patch status - %w", err)
 	}
 
 	return nil
 }
 
+// latestReader returns the reader from which the latest version of an object is retrieved.  The cache of
+// the reconciler is only used when it is unable to read directly from the API, as a cached object may be
+// older than the version which caused a conflict, in which case each retry would conflict again.
+func latestReader(reconciler Client) client.Reader {
+	if latest, ok := reconciler.(LatestReader); ok && latest.GetAPIReader() != nil {
+		return latest.GetAPIReader()
+	}
+
+	return reconciler
+}
+
+// statusUnchanged determines if the status of a patched object is deeply equal to the status of the
+// current object.  An object whose status may not be compared is considered to have changed.
+func statusUnchanged(current, patched client.Object) bool {
+	currentStatus, err := status(current)
+	if err != nil {
+		return false
+	}
+
+	patchedStatus, err := status(patched)
+	if err != nil {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(currentStatus, patchedStatus)
+}
+
+// status returns the status of an object in its unstructured form.
+func status(object client.Object) (interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert object to unstructured - %w", err)
+	}
+
+	return content["status"], nil
+}
+
+// optimisticPatch returns a merge patch containing a set of changes which only succeeds if the object
+// is at the provided resource version.
+func optimisticPatch(changes []byte, resourceVersion string) (client.Patch, error) {
+	if resourceVersion == "" {
+		return client.RawPatch(types.MergePatchType, changes), nil
+	}
+
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(changes, &patch); err != nil {
+		return nil, fmt.Errorf("unable to decode status patch - %w", err)
+	}
+
+	metadata, ok := patch["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+	}
+
+	metadata["resourceVersion"] = resourceVersion
+	patch["metadata"] = metadata
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode status patch - %w", err)
+	}
+
+	return client.RawPatch(types.MergePatchType, data), nil
+}
+
+// isConflict determines if an error was caused by the object being modified since it was retrieved.
+func isConflict(err error) bool {
+	return apierrors.IsConflict(err) || isOptimisticLockError(err)
+}
+
 func isOptimisticLockError(err error) bool {
 	return strings.Contains(err.Error(), optimisticLockErrorMessage)
 }
+
+// ensureControlledBy returns an error if an object which already exists in the cluster is not controlled
+// by the owner.  An object which has not yet been created has no resource version and may be claimed.
+func ensureControlledBy(object, owner client.Object) error {
+	if object.GetResourceVersion() == "" || metav1.IsControlledBy(object, owner) {
+		return nil
+	}
+
+	return fmt.Errorf("refusing to adopt object for owner [%s/%s] - %w", owner.GetNamespace(), owner.GetName(), ErrNotOwned)
+}
+
+// version returns an identifier of the version of an object in the cluster, which changes whenever the
+// object is recreated or updated.  It reveals nothing about the content of the object, so that it may be
+// recorded in place of the content of a secret.
+func version(ctx context.Context, c Client, object client.Object, name, namespace string) (string, error) {
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, object); err != nil {
+		return "", fmt.Errorf("unable to retrieve [%s/%s] from cluster - %w", namespace, name, err)
+	}
+
+	return fmt.Sprintf("%s/%s", object.GetUID(), object.GetResourceVersion()), nil
+}
@@ -0,0 +1,222 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var errTestPatch = errors.New("patch failed")
+
+// conflictClient is a client which returns a conflict error for a number of status patches before
+// either succeeding or returning a final error.
+type conflictClient struct {
+	FakeClient
+
+	conflicts int
+	finalErr  error
+
+	latestVersion    string
+	patchedVersions  []string
+	patchedPhases    []string
+	latestRetrievals int
+}
+
+func (c *conflictClient) Get(_ context.Context, _ types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+	c.latestRetrievals++
+	object.SetResourceVersion(c.latestVersion)
+
+	return nil
+}
+
+func (c *conflictClient) Status() client.SubResourceWriter {
+	return &conflictStatusWriter{client: c}
+}
+
+type conflictStatusWriter struct {
+	fakeStatusWriter
+
+	client *conflictClient
+}
+
+func (w *conflictStatusWriter) Patch(_ context.Context, object client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+	data, err := patch.Data(object)
+	if err != nil {
+		return err
+	}
+
+	body := struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+		Status   map[string]string `json:"status"`
+	}{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	w.client.patchedVersions = append(w.client.patchedVersions, body.Metadata.ResourceVersion)
+	w.client.patchedPhases = append(w.client.patchedPhases, body.Status["phase"])
+
+	if w.client.conflicts > 0 {
+		w.client.conflicts--
+
+		return apierrors.NewConflict(schema.GroupResource{Resource: "namespaces"}, object.GetName(), errTestPatch)
+	}
+
+	return w.client.finalErr
+}
+
+func TestPatchStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		client        *conflictClient
+		wantErr       bool
+		wantVersions  []string
+		wantRetrieved int
+	}{
+		{
+			name:          "ensure a patch without a conflict is applied once",
+			client:        &conflictClient{latestVersion: "2"},
+			wantErr:       false,
+			wantVersions:  []string{"1"},
+			wantRetrieved: 0,
+		},
+		{
+			name:          "ensure a conflicting patch is re-applied to the latest version",
+			client:        &conflictClient{conflicts: 2, latestVersion: "2"},
+			wantErr:       false,
+			wantVersions:  []string{"1", "2", "2"},
+			wantRetrieved: 2,
+		},
+		{
+			name:          "ensure a patch which always conflicts returns an error",
+			client:        &conflictClient{conflicts: 100, latestVersion: "2"},
+			wantErr:       true,
+			wantVersions:  []string{"1", "2", "2", "2"},
+			wantRetrieved: 4,
+		},
+		{
+			name:          "ensure a patch which fails without a conflict is not retried",
+			client:        &conflictClient{finalErr: errTestPatch, latestVersion: "2"},
+			wantErr:       true,
+			wantVersions:  []string{"1"},
+			wantRetrieved: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			current := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "1"},
+			}
+
+			patched := current.DeepCopy()
+			patched.Status.Phase = corev1.NamespaceTerminating
+
+			err := PatchStatus(context.Background(), tt.client, current, patched)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PatchStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(tt.client.patchedVersions) != len(tt.wantVersions) {
+				t.Fatalf("PatchStatus() patches = %v, want %v", tt.client.patchedVersions, tt.wantVersions)
+			}
+
+			for i := range tt.wantVersions {
+				if tt.client.patchedVersions[i] != tt.wantVersions[i] {
+					t.Errorf("PatchStatus() patches = %v, want %v", tt.client.patchedVersions, tt.wantVersions)
+				}
+
+				if tt.client.patchedPhases[i] != string(corev1.NamespaceTerminating) {
+					t.Errorf("PatchStatus() phases = %v, want %v", tt.client.patchedPhases, corev1.NamespaceTerminating)
+				}
+			}
+
+			if tt.client.latestRetrievals != tt.wantRetrieved {
+				t.Errorf("PatchStatus() retrievals = %v, want %v", tt.client.latestRetrievals, tt.wantRetrieved)
+			}
+		})
+	}
+}
+
+func TestPatchStatus_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	reconciler := &conflictClient{latestVersion: "2"}
+
+	current := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "1"},
+		Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+	}
+
+	// a change to the metadata is not applied by a status patch, so the status is unchanged
+	patched := current.DeepCopy()
+	patched.Labels = map[string]string{"changed": "true"}
+
+	if err := PatchStatus(context.Background(), reconciler, current, patched); err != nil {
+		t.Errorf("PatchStatus() error = %v, want nil", err)
+	}
+
+	if len(reconciler.patchedVersions) != 0 {
+		t.Errorf("PatchStatus() patches = %v, want no patches", reconciler.patchedVersions)
+	}
+}
+
+// cachedConflictClient is a conflict client whose cache has not observed the latest version of the
+// object, which is only retrieved from its api reader.
+type cachedConflictClient struct {
+	*conflictClient
+
+	cachedVersion string
+}
+
+func (c *cachedConflictClient) Get(_ context.Context, _ types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+	object.SetResourceVersion(c.cachedVersion)
+
+	return nil
+}
+
+func (c *cachedConflictClient) GetAPIReader() client.Reader {
+	return c.conflictClient
+}
+
+func TestPatchStatus_LatestReader(t *testing.T) {
+	t.Parallel()
+
+	reconciler := &cachedConflictClient{
+		conflictClient: &conflictClient{conflicts: 1, latestVersion: "2"},
+		cachedVersion:  "1",
+	}
+
+	current := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "1"},
+	}
+
+	patched := current.DeepCopy()
+	patched.Status.Phase = corev1.NamespaceTerminating
+
+	if err := PatchStatus(context.Background(), reconciler, current, patched); err != nil {
+		t.Fatalf("PatchStatus() error = %v", err)
+	}
+
+	if want := []string{"1", "2"}; !reflect.DeepEqual(reconciler.patchedVersions, want) {
+		t.Errorf("PatchStatus() patches = %v, want %v", reconciler.patchedVersions, want)
+	}
+
+	if reconciler.latestRetrievals != 1 {
+		t.Errorf("PatchStatus() retrievals = %v, want %v", reconciler.latestRetrievals, 1)
+	}
+}
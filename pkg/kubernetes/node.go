@@ -47,3 +47,16 @@ func NodesAreReady(nodes ...corev1.Node) bool {
 
 	return true
 }
+
+// ReadyNodes returns the number of nodes which are in a ready state.
+//
+//nolint:gocritic
+func ReadyNodes(nodes ...corev1.Node) (ready int) {
+	for _, node := range nodes {
+		if NodesAreReady(node) {
+			ready++
+		}
+	}
+
+	return ready
+}
@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OperatorConditionNameEnv is the environment variable which Operator Lifecycle Manager sets
+	// to the name of the operator condition of the operator.  It is unset when the operator is
+	// not installed by Operator Lifecycle Manager.
+	OperatorConditionNameEnv = "OPERATOR_CONDITION_NAME"
+
+	// OperatorNamespaceEnv is the environment variable which is set to the namespace that the
+	// operator is running in.
+	OperatorNamespaceEnv = "OPERATOR_NAMESPACE"
+)
+
+// OperatorConditionGroupVersionKind is the group, version and kind of the Operator Lifecycle Manager
+// operator condition.
+var OperatorConditionGroupVersionKind = schema.GroupVersionKind{
+	Group:   "operators.coreos.com",
+	Version: "v2",
+	Kind:    "OperatorCondition",
+}
+
+// SetOperatorConditions sets conditions in the spec.conditions field of an operator condition.  The
+// operator condition is retrieved as an unstructured object so that we do not depend upon the
+// Operator Lifecycle Manager APIs.  The last transition time of an existing condition is only changed
+// when its status changes.
+func SetOperatorConditions(ctx context.Context, c Client, namespace, name string, conditions ...metav1.Condition) error {
+	operatorCondition := &unstructured.Unstructured{}
+	operatorCondition.SetGroupVersionKind(OperatorConditionGroupVersionKind)
+
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, operatorCondition); err != nil {
+		return fmt.Errorf("unable to retrieve operator condition [%s/%s] from cluster - %w", namespace, name, err)
+	}
+
+	original := operatorCondition.DeepCopy()
+
+	// convert the existing conditions so that they may be compared to the new conditions
+	existing := []metav1.Condition{}
+
+	content, _, err := unstructured.NestedSlice(operatorCondition.Object, "spec", "conditions")
+	if err != nil {
+		return fmt.Errorf("unable to read spec.conditions from operator condition [%s/%s] - %w", namespace, name, err)
+	}
+
+	for i := range content {
+		condition := metav1.Condition{}
+
+		fields, ok := content[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(fields, &condition); err != nil {
+			return fmt.Errorf("unable to convert condition from operator condition [%s/%s] - %w", namespace, name, err)
+		}
+
+		existing = append(existing, condition)
+	}
+
+	for i := range conditions {
+		meta.SetStatusCondition(&existing, conditions[i])
+	}
+
+	// convert the conditions back so that they may be stored in the unstructured object
+	content = make([]interface{}, len(existing))
+
+	for i := range existing {
+		fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&existing[i])
+		if err != nil {
+			return fmt.Errorf("unable to convert condition [%s] for operator condition [%s/%s] - %w", existing[i].Type, namespace, name, err)
+		}
+
+		content[i] = fields
+	}
+
+	if err := unstructured.SetNestedSlice(operatorCondition.Object, content, "spec", "conditions"); err != nil {
+		return fmt.Errorf("unable to set spec.conditions on operator condition [%s/%s] - %w", namespace, name, err)
+	}
+
+	if err := c.Patch(ctx, operatorCondition, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("unable to patch operator condition [%s/%s] - %w", namespace, name, err)
+	}
+
+	return nil
+}
@@ -5,9 +5,17 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// GetSecretData returns the value of a key in a secret.  An empty value is returned without an error if
+// the key is missing from the secret, while an error is returned if the secret itself may not be
+// retrieved.
 func GetSecretData(ctx context.Context, c Client, name, namespace, key string) (string, error) {
 	secret := &corev1.Secret{}
 
@@ -23,9 +31,102 @@ func GetSecretData(ctx context.Context, c Client, name, namespace, key string) (
 		)
 	}
 
-	if secret.Data == nil || len(secret.Data[key]) == 0 {
-		return "", nil
+	// prefer the data field, as the string data field is write-only and is merged into the data
+	// field by the api server when the secret is persisted.  the string data field is only set on
+	// secrets which the api server has not persisted, such as those which are stored by a fake client.
+	if len(secret.Data[key]) > 0 {
+		return string(secret.Data[key]), nil
 	}
 
-	return string(secret.Data[key]), nil
+	return secret.StringData[key], nil
+}
+
+// GetSecretVersion returns an identifier of the version of a secret which changes whenever its data may
+// have changed, so that a change to the data may be detected without recording the data itself.
+func GetSecretVersion(ctx context.Context, c Client, name, namespace string) (string, error) {
+	return version(ctx, c, &corev1.Secret{}, name, namespace)
+}
+
+// MissingSecretKeys returns the keys which are missing or empty in a secret.  All keys are returned
+// without an error if the secret does not exist, so that a secret which is created asynchronously,
+// for example by an ExternalSecret or a SealedSecret, may be waited for.
+func MissingSecretKeys(ctx context.Context, c Client, name, namespace string, keys ...string) ([]string, error) {
+	secret := &corev1.Secret{}
+
+	if err := c.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return keys, nil
+		}
+
+		return nil, fmt.Errorf(
+			"unable to retrieve secret [%s/%s] from cluster - %w",
+			namespace,
+			name,
+			err,
+		)
+	}
+
+	var missing []string
+
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 && secret.StringData[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing, nil
+}
+
+// ApplySecretData creates or updates a secret of a particular type so that it contains the provided
+// data.  The secret is owned by the owner object so that it is deleted along with it.  A secret which
+// already exists without a controller reference to the owner is left alone and an error is returned.
+func ApplySecretData(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	name string,
+	secretType corev1.SecretType,
+	data map[string][]byte,
+) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+		},
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		if err := ensureControlledBy(secret, owner); err != nil {
+			return err
+		}
+
+		// the type of a secret is immutable and is only set when the secret is created
+		if secret.CreationTimestamp.IsZero() {
+			secret.Type = secretType
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		for key, value := range data {
+			secret.Data[key] = value
+		}
+
+		//nolint:wrapcheck
+		return controllerutil.SetControllerReference(owner, secret, scheme)
+	}); err != nil {
+		return fmt.Errorf(
+			"unable to apply secret [%s/%s] to cluster - %w",
+			owner.GetNamespace(),
+			name,
+			err,
+		)
+	}
+
+	return nil
 }
@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplySecretData(t *testing.T) {
+	t.Parallel()
+
+	owner := testOwner()
+
+	for _, tt := range []struct {
+		name     string
+		existing []client.Object
+		wantErr  error
+		wantData string
+	}{
+		{
+			name:     "ensure a missing secret is created",
+			wantData: "new",
+		},
+		{
+			name: "ensure a secret controlled by the owner is updated",
+			existing: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: "test", OwnerReferences: ownedBy(owner)},
+				Data:       map[string][]byte{"key": []byte("old")},
+			}},
+			wantData: "new",
+		},
+		{
+			name: "ensure a secret not controlled by the owner is not adopted",
+			existing: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: "test"},
+				Data:       map[string][]byte{"key": []byte("old")},
+			}},
+			wantErr:  ErrNotOwned,
+			wantData: "old",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(tt.existing...).Build()
+
+			err := ApplySecretData(
+				context.Background(),
+				c,
+				clientgoscheme.Scheme,
+				owner,
+				"pull-secret",
+				corev1.SecretTypeOpaque,
+				map[string][]byte{"key": []byte("new")},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplySecretData() error = %v, want %v", err, tt.wantErr)
+			}
+
+			secret := &corev1.Secret{}
+			if err := c.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "pull-secret"}, secret); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if got := string(secret.Data["key"]); got != tt.wantData {
+				t.Errorf("ApplySecretData() data = %v, want %v", got, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestGetSecretVersion(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bind-password", Namespace: "test", UID: types.UID("secret-uid")},
+		Data:       map[string][]byte{"bindPassword": []byte("hunter2")},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+
+	before, err := GetSecretVersion(context.Background(), c, "bind-password", "test")
+	if err != nil {
+		t.Fatalf("GetSecretVersion() error = %v", err)
+	}
+
+	if strings.Contains(before, "hunter2") {
+		t.Errorf("GetSecretVersion() = %v, want no secret content", before)
+	}
+
+	secret.Data["bindPassword"] = []byte("rotated")
+	if err := c.Update(context.Background(), secret); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	after, err := GetSecretVersion(context.Background(), c, "bind-password", "test")
+	if err != nil {
+		t.Fatalf("GetSecretVersion() error = %v", err)
+	}
+
+	if before == after {
+		t.Errorf("GetSecretVersion() = %v after rotation, want a changed version", after)
+	}
+}
@@ -0,0 +1,10 @@
+package metrics
+
+const (
+	// ReconcileTotalMetric, ReconcileErrorsMetric and ReconcileTimeMetric are the names of the metrics
+	// which are exposed by controller-runtime for each controller of the operator.  They are partitioned
+	// by the controller label.
+	ReconcileTotalMetric  = "controller_runtime_reconcile_total"
+	ReconcileErrorsMetric = "controller_runtime_reconcile_errors_total"
+	ReconcileTimeMetric   = "controller_runtime_reconcile_time_seconds"
+)
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+)
+
+const (
+	// CertificateFile and KeyFile are the names of the serving certificate and key files within
+	// the certificate directory.  These match the keys of a kubernetes TLS secret.
+	CertificateFile = "tls.crt"
+	KeyFile         = "tls.key"
+
+	metricsPath       = "/metrics"
+	readHeaderTimeout = 30 * time.Second
+	shutdownTimeout   = 30 * time.Second
+)
+
+var ErrInvalidClientCA = errors.New("no certificates found in client ca file")
+
+// Server serves the metrics endpoint over HTTPS.  The serving certificate is reloaded when it changes
+// on disk so that it may be rotated without restarting the operator, and clients may optionally be
+// required to present a certificate which is signed by a trusted certificate authority.
+type Server struct {
+	Address  string
+	Gatherer prometheus.Gatherer
+	Log      logr.Logger
+
+	// CertDir is the directory which contains the serving certificate and key.
+	CertDir string
+
+	// ClientCAFile is the file which contains the PEM encoded certificate authorities which client
+	// certificates must be signed by.  Client certificates are not required if this is empty.
+	ClientCAFile string
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.  Metrics are served by
+// every replica of the operator.
+func (server *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements the manager.Runnable interface.  It serves the metrics endpoint until the context
+// is cancelled.
+func (server *Server) Start(ctx context.Context) error {
+	watcher, err := certwatcher.New(
+		filepath.Join(server.CertDir, CertificateFile),
+		filepath.Join(server.CertDir, KeyFile),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to load metrics serving certificate from [%s] - %w", server.CertDir, err)
+	}
+
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			server.Log.Error(err, "unable to watch metrics serving certificate for changes")
+		}
+	}()
+
+	tlsConfig, err := TLSConfig(server.ClientCAFile)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig.GetCertificate = watcher.GetCertificate
+
+	listener, err := tls.Listen("tcp", server.Address, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("unable to listen for metrics on [%s] - %w", server.Address, err)
+	}
+
+	return server.serve(ctx, listener)
+}
+
+// serve serves the metrics endpoint on a listener until the context is cancelled.
+func (server *Server) serve(ctx context.Context, listener net.Listener) error {
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(server.Gatherer, promhttp.HandlerOpts{}))
+
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			server.Log.Error(err, "unable to shutdown metrics server")
+		}
+	}()
+
+	server.Log.Info("serving metrics over https", "address", listener.Addr().String(), "clientAuth", server.ClientCAFile != "")
+
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("unable to serve metrics - %w", err)
+	}
+
+	return nil
+}
+
+// TLSConfig returns the TLS configuration of the metrics server.  When a client CA file is provided,
+// clients are required to present a certificate which is signed by one of its certificate authorities.
+func TLSConfig(clientCAFile string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if clientCAFile == "" {
+		return config, nil
+	}
+
+	content, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client ca file [%s] - %w", clientCAFile, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("unable to load client ca file [%s] - %w", clientCAFile, ErrInvalidClientCA)
+	}
+
+	config.ClientCAs = pool
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return config, nil
+}
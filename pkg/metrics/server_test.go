@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func testCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v, wantErr %v", err, false)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "metrics"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v, wantErr %v", err, false)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey() error = %v, wantErr %v", err, false)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func testFile(t *testing.T, content []byte) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(file, content, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v, wantErr %v", err, false)
+	}
+
+	return file
+}
+
+func TestTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	certPEM, _ := testCertificate(t)
+
+	tests := []struct {
+		name         string
+		clientCAFile string
+		want         tls.ClientAuthType
+		wantErr      bool
+	}{
+		{
+			name:         "ensure client certificates are not required without a client ca",
+			clientCAFile: "",
+			want:         tls.NoClientCert,
+			wantErr:      false,
+		},
+		{
+			name:         "ensure client certificates are required with a client ca",
+			clientCAFile: testFile(t, certPEM),
+			want:         tls.RequireAndVerifyClientCert,
+			wantErr:      false,
+		},
+		{
+			name:         "ensure an invalid client ca returns an error",
+			clientCAFile: testFile(t, []byte("not a certificate")),
+			wantErr:      true,
+		},
+		{
+			name:         "ensure a missing client ca returns an error",
+			clientCAFile: filepath.Join(t.TempDir(), "missing.crt"),
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := TLSConfig(tt.clientCAFile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TLSConfig() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if err == nil && got.ClientAuth != tt.want {
+				t.Errorf("TLSConfig() ClientAuth = %v, want %v", got.ClientAuth, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_serve(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := testCertificate(t)
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("X509KeyPair() error = %v, wantErr %v", err, false)
+	}
+
+	config, err := TLSConfig(testFile(t, certPEM))
+	if err != nil {
+		t.Fatalf("TLSConfig() error = %v, wantErr %v", err, false)
+	}
+
+	config.Certificates = []tls.Certificate{certificate}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatalf("Listen() error = %v, wantErr %v", err, false)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := &Server{Gatherer: prometheus.NewRegistry(), Log: logr.Discard()}
+
+	go func() {
+		if err := server.serve(ctx, listener); err != nil {
+			t.Errorf("serve() error = %v, wantErr %v", err, false)
+		}
+	}()
+
+	url := "https://" + listener.Addr().String() + metricsPath
+
+	tests := []struct {
+		name         string
+		certificates []tls.Certificate
+		wantErr      bool
+	}{
+		{
+			name:         "ensure a client with a trusted certificate is allowed",
+			certificates: []tls.Certificate{certificate},
+			wantErr:      false,
+		},
+		{
+			name:         "ensure a client without a certificate is rejected",
+			certificates: nil,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion:   tls.VersionTLS12,
+					Certificates: tt.certificates,
+					// the server certificate is self-signed and only the client certificate is under test
+					//nolint:gosec
+					InsecureSkipVerify: true,
+				},
+			},
+		}
+
+		response, err := client.Get(url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Get() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("%s: Get() status = %v, want %v", tt.name, response.StatusCode, http.StatusOK)
+		}
+	}
+}
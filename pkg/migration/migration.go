@@ -0,0 +1,221 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	// DefaultPageSize is the number of objects which are retrieved per request while they are
+	// migrated.
+	DefaultPageSize = 100
+)
+
+// CustomResourceDefinitionListGroupVersionKind is the group, version and kind of a list of custom
+// resource definitions.  Custom resource definitions are retrieved as unstructured objects, so that
+// they are read directly from the API rather than being cached by the operator.
+var CustomResourceDefinitionListGroupVersionKind = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinitionList",
+}
+
+// The operator must be able to read its custom resource definitions, and to remove the versions
+// which are no longer stored from their status once its custom resources have been migrated.
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;patch
+
+// The operator must also be able to rewrite its custom resources, most of which are already patched by
+// their controllers.  Cluster management bindings are otherwise only read by the operator.
+
+//+kubebuilder:rbac:groups=ocm.mobb.redhat.com,resources=clustermanagementbindings,verbs=patch
+
+// Migrator migrates the stored custom resources of the operator to the current storage version of
+// their custom resource definition when the operator starts.  When the API of the operator evolves,
+// the custom resources which were stored at an older version remain stored at that version until
+// they are written again, so every custom resource is rewritten in place to store it at the current
+// storage version.  The older versions are then removed from the stored versions in the status of
+// the custom resource definition, so that they may safely be removed from a later release.
+type Migrator struct {
+	Client kubernetes.Client
+	Log    logr.Logger
+
+	// Group is the API group of the custom resource definitions which are migrated.
+	Group string
+
+	// PageSize is the number of objects which are retrieved per request while they are migrated.
+	PageSize int64
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.  The custom resources
+// are only migrated by the leader.
+func (migrator *Migrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements the manager.Runnable interface.  Failing to migrate the custom resources is logged
+// rather than returned, as the custom resources remain readable at their stored version and the
+// migration is attempted again when the operator next starts.
+func (migrator *Migrator) Start(ctx context.Context) error {
+	if err := migrator.Migrate(ctx); err != nil {
+		migrator.Log.Error(err, "unable to migrate custom resources to their storage version")
+	}
+
+	return nil
+}
+
+// Migrate migrates the custom resources of each custom resource definition in the group which has
+// versions other than its storage version in its stored versions.
+func (migrator *Migrator) Migrate(ctx context.Context) error {
+	definitions := &unstructured.UnstructuredList{}
+	definitions.SetGroupVersionKind(CustomResourceDefinitionListGroupVersionKind)
+
+	if err := migrator.Client.List(ctx, definitions); err != nil {
+		return fmt.Errorf("unable to list custom resource definitions - %w", err)
+	}
+
+	for i := range definitions.Items {
+		definition := &definitions.Items[i]
+
+		if group, _, _ := unstructured.NestedString(definition.Object, "spec", "group"); group != migrator.Group {
+			continue
+		}
+
+		storage := storageVersion(definition)
+		if storage == "" {
+			continue
+		}
+
+		stale := staleVersions(definition, storage)
+		if len(stale) == 0 {
+			continue
+		}
+
+		migrator.Log.Info(
+			"migrating custom resources to storage version",
+			"crd", definition.GetName(),
+			"from", stale,
+			"to", storage,
+		)
+
+		if err := migrator.migrateDefinition(ctx, definition, storage); err != nil {
+			return err
+		}
+
+		migrator.Log.Info("migrated custom resources to storage version", "crd", definition.GetName(), "version", storage)
+	}
+
+	return nil
+}
+
+// migrateDefinition rewrites each custom resource of a custom resource definition so that it is stored
+// at the storage version, and then removes the other versions from the stored versions of the custom
+// resource definition.
+func (migrator *Migrator) migrateDefinition(ctx context.Context, definition *unstructured.Unstructured, storage string) error {
+	listKind, _, _ := unstructured.NestedString(definition.Object, "spec", "names", "listKind")
+
+	pageSize := migrator.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	for token := ""; ; {
+		objects := &unstructured.UnstructuredList{}
+		objects.SetGroupVersionKind(schema.GroupVersionKind{Group: migrator.Group, Version: storage, Kind: listKind})
+
+		if err := migrator.Client.List(ctx, objects, client.Limit(pageSize), client.Continue(token)); err != nil {
+			return fmt.Errorf("unable to list custom resources of [%s] - %w", definition.GetName(), err)
+		}
+
+		for i := range objects.Items {
+			if err := migrator.rewrite(ctx, &objects.Items[i]); err != nil {
+				return fmt.Errorf(
+					"unable to migrate custom resource [%s/%s] of [%s] - %w",
+					objects.Items[i].GetNamespace(),
+					objects.Items[i].GetName(),
+					definition.GetName(),
+					err,
+				)
+			}
+		}
+
+		if token = objects.GetContinue(); token == "" {
+			break
+		}
+	}
+
+	// only the storage version is stored once every custom resource has been rewritten
+	original := definition.DeepCopy()
+	if err := unstructured.SetNestedStringSlice(definition.Object, []string{storage}, "status", "storedVersions"); err != nil {
+		return fmt.Errorf("unable to set stored versions of [%s] - %w", definition.GetName(), err)
+	}
+
+	if err := migrator.Client.Status().Patch(ctx, definition, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("unable to update stored versions of [%s] - %w", definition.GetName(), err)
+	}
+
+	return nil
+}
+
+// rewrite writes a custom resource back without changing it.  The API server encodes the written
+// custom resource at the storage version, so that it is no longer stored at an older version.  A
+// custom resource which was deleted since it was listed does not need to be migrated.
+func (migrator *Migrator) rewrite(ctx context.Context, object *unstructured.Unstructured) error {
+	if err := migrator.Client.Patch(ctx, object, client.RawPatch(types.MergePatchType, []byte("{}"))); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		//nolint:wrapcheck
+		return err
+	}
+
+	return nil
+}
+
+// storageVersion returns the version at which the custom resources of a custom resource definition
+// are stored.
+func storageVersion(definition *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(definition.Object, "spec", "versions")
+
+	for _, version := range versions {
+		fields, ok := version.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if storage, _, _ := unstructured.NestedBool(fields, "storage"); storage {
+			name, _, _ := unstructured.NestedString(fields, "name")
+
+			return name
+		}
+	}
+
+	return ""
+}
+
+// staleVersions returns the stored versions of a custom resource definition which are not its
+// storage version.
+func staleVersions(definition *unstructured.Unstructured, storage string) []string {
+	stored, _, _ := unstructured.NestedStringSlice(definition.Object, "status", "storedVersions")
+
+	var stale []string
+
+	for _, version := range stored {
+		if version != storage {
+			stale = append(stale, version)
+		}
+	}
+
+	return stale
+}
@@ -0,0 +1,187 @@
+package migration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const testGroup = "ocm.mobb.redhat.com"
+
+func testDefinition(group string, stored ...string) unstructured.Unstructured {
+	storedVersions := make([]interface{}, len(stored))
+	for i := range stored {
+		storedVersions[i] = stored[i]
+	}
+
+	definition := unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "machinepools." + group},
+		"spec": map[string]interface{}{
+			"group": group,
+			"names": map[string]interface{}{"listKind": "MachinePoolList"},
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1alpha1", "storage": false},
+				map[string]interface{}{"name": "v1beta1", "storage": true},
+			},
+		},
+		"status": map[string]interface{}{"storedVersions": storedVersions},
+	}}
+
+	return definition
+}
+
+// migrationClient is a fake client which serves a list of custom resource definitions and a list of
+// custom resources, and records the custom resources and custom resource definitions which are
+// written with it.
+type migrationClient struct {
+	kubernetes.FakeClient
+
+	definitions []unstructured.Unstructured
+	objects     []unstructured.Unstructured
+
+	rewritten []string
+	status    *migrationStatusWriter
+}
+
+func (c *migrationClient) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	unstructuredList, ok := list.(*unstructured.UnstructuredList)
+	if !ok {
+		return nil
+	}
+
+	if unstructuredList.GroupVersionKind() == CustomResourceDefinitionListGroupVersionKind {
+		unstructuredList.Items = c.definitions
+	} else {
+		unstructuredList.Items = c.objects
+	}
+
+	return nil
+}
+
+func (c *migrationClient) Patch(_ context.Context, object client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.rewritten = append(c.rewritten, object.GetName())
+
+	return nil
+}
+
+func (c *migrationClient) Status() client.SubResourceWriter {
+	return c.status
+}
+
+type migrationStatusWriter struct {
+	client.SubResourceWriter
+
+	patched []*unstructured.Unstructured
+}
+
+func (w *migrationStatusWriter) Patch(_ context.Context, object client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	if definition, ok := object.(*unstructured.Unstructured); ok {
+		w.patched = append(w.patched, definition)
+	}
+
+	return nil
+}
+
+func Test_staleVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		stored []string
+		want   []string
+	}{
+		{
+			name:   "ensure an older stored version is stale",
+			stored: []string{"v1alpha1", "v1beta1"},
+			want:   []string{"v1alpha1"},
+		},
+		{
+			name:   "ensure the storage version is not stale",
+			stored: []string{"v1beta1"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			definition := testDefinition(testGroup, tt.stored...)
+
+			if got := staleVersions(&definition, storageVersion(&definition)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("staleVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	t.Parallel()
+
+	object := unstructured.Unstructured{}
+	object.SetName("my-pool")
+
+	tests := []struct {
+		name          string
+		definition    unstructured.Unstructured
+		wantRewritten []string
+		wantStored    []string
+	}{
+		{
+			name:          "ensure custom resources with a stale stored version are migrated",
+			definition:    testDefinition(testGroup, "v1alpha1", "v1beta1"),
+			wantRewritten: []string{"my-pool"},
+			wantStored:    []string{"v1beta1"},
+		},
+		{
+			name:          "ensure custom resources without a stale stored version are not migrated",
+			definition:    testDefinition(testGroup, "v1beta1"),
+			wantRewritten: nil,
+			wantStored:    nil,
+		},
+		{
+			name:          "ensure custom resources of another group are not migrated",
+			definition:    testDefinition("example.com", "v1alpha1", "v1beta1"),
+			wantRewritten: nil,
+			wantStored:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &migrationClient{
+				definitions: []unstructured.Unstructured{tt.definition},
+				objects:     []unstructured.Unstructured{*object.DeepCopy()},
+				status:      &migrationStatusWriter{},
+			}
+
+			migrator := &Migrator{Client: fake, Log: logr.Discard(), Group: testGroup}
+			if err := migrator.Migrate(context.Background()); err != nil {
+				t.Fatalf("Migrate() error = %v, wantErr %v", err, false)
+			}
+
+			if !reflect.DeepEqual(fake.rewritten, tt.wantRewritten) {
+				t.Errorf("Migrate() rewritten = %v, want %v", fake.rewritten, tt.wantRewritten)
+			}
+
+			var gotStored []string
+			if len(fake.status.patched) > 0 {
+				gotStored, _, _ = unstructured.NestedStringSlice(fake.status.patched[0].Object, "status", "storedVersions")
+			}
+
+			if !reflect.DeepEqual(gotStored, tt.wantStored) {
+				t.Errorf("Migrate() stored versions = %v, want %v", gotStored, tt.wantStored)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+const (
+	// DefaultName is the default name of the metrics service and service monitor.
+	DefaultName = "ocm-operator-metrics"
+
+	fieldOwner = "ocm-operator"
+
+	metricsPortName = "metrics"
+	metricsPath     = "/metrics"
+
+	// ProxyPort and proxyPortName are the port and container port name on which the kube-rbac-proxy
+	// sidecar serves the metrics endpoint when the operator does not serve it over https itself.
+	ProxyPort     = 8443
+	proxyPortName = "https"
+
+	// the serving certificate of the metrics service is issued by the openshift service ca, whose
+	// certificate is published to the openshift-service-ca.crt config map of every namespace
+	servingCertAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	serviceCAConfigMap    = "openshift-service-ca.crt"
+	serviceCAKey          = "service-ca.crt"
+
+	bearerTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+var (
+	ErrMissingNamespace = errors.New("namespace of the operator is unknown")
+	ErrInvalidAddress   = errors.New("invalid metrics bind address")
+)
+
+// ServiceMonitorGroupVersionKind is the group, version and kind of the prometheus-operator service
+// monitor.
+var ServiceMonitorGroupVersionKind = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// DefaultSelector is the default set of labels which select the pods of the operator.  It matches
+// the labels of the deployment in config/manager.
+func DefaultSelector() map[string]string {
+	return map[string]string{"control-plane": "controller-manager"}
+}
+
+// The operator must be able to manage the metrics service and service monitor when they are
+// created by the operator.
+
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;create;patch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;patch
+
+// Installer creates and manages a metrics service and a prometheus-operator service monitor for the
+// metrics endpoint of the operator, so that the monitoring of the operator always matches the port
+// with which the metrics are served.  The metrics are always scraped over https with the token of the
+// service account of prometheus, and the serving certificate is verified against the openshift
+// service ca, which issues it into the secret named by ServingCertSecretName.  The objects are
+// applied with server-side apply when the operator starts.
+type Installer struct {
+	Client kubernetes.Client
+	Log    logr.Logger
+
+	// Name and Namespace are the name and namespace of the metrics service and service monitor.
+	Name      string
+	Namespace string
+
+	// Address is the bind address of the metrics endpoint, for example :8080.
+	Address string
+
+	// Secure indicates that the metrics endpoint is served over HTTPS by the operator itself.  The
+	// metrics are otherwise scraped through the kube-rbac-proxy sidecar on the ProxyPort.
+	Secure bool
+
+	// Selector is the set of labels which select the pods of the operator.
+	Selector map[string]string
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.  The objects are only
+// applied by the leader.
+func (installer *Installer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements the manager.Runnable interface.  Failing to apply the objects is logged rather
+// than returned, so that the operator continues to run on clusters without prometheus-operator.
+func (installer *Installer) Start(ctx context.Context) error {
+	if err := installer.Apply(ctx); err != nil {
+		installer.Log.Error(err, "unable to install metrics service monitor")
+
+		return nil
+	}
+
+	installer.Log.Info("installed metrics service monitor", "namespace", installer.Namespace, "name", installer.Name)
+
+	return nil
+}
+
+// Apply applies the metrics service and service monitor.
+func (installer *Installer) Apply(ctx context.Context) error {
+	if installer.Namespace == "" {
+		return ErrMissingNamespace
+	}
+
+	// the metrics are served on the bind address only when they are served over https, and are served by
+	// the kube-rbac-proxy sidecar otherwise
+	port, targetPort := int32(ProxyPort), intstr.FromString(proxyPortName)
+
+	if installer.Secure {
+		metrics, err := metricsPort(installer.Address)
+		if err != nil {
+			return err
+		}
+
+		port, targetPort = metrics, intstr.FromInt(int(metrics))
+	}
+
+	for _, object := range []client.Object{installer.service(port, targetPort), installer.serviceMonitor()} {
+		if err := installer.Client.Patch(
+			ctx,
+			object,
+			client.Apply,
+			client.FieldOwner(fieldOwner),
+			client.ForceOwnership,
+		); err != nil {
+			return fmt.Errorf(
+				"unable to apply %s [%s/%s] - %w",
+				object.GetObjectKind().GroupVersionKind().Kind,
+				installer.Namespace,
+				installer.Name,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (installer *Installer) labels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       installer.Name,
+		"app.kubernetes.io/component":  "metrics",
+		"app.kubernetes.io/managed-by": fieldOwner,
+	}
+}
+
+// ServingCertSecretName returns the name of the secret into which the openshift service ca issues the
+// serving certificate of the metrics service.  The certificate must be served by the metrics endpoint,
+// or by the kube-rbac-proxy sidecar, so that it may be verified by prometheus.
+func (installer *Installer) ServingCertSecretName() string {
+	return installer.Name + "-tls"
+}
+
+func (installer *Installer) service(port int32, targetPort intstr.IntOrString) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        installer.Name,
+			Namespace:   installer.Namespace,
+			Labels:      installer.labels(),
+			Annotations: map[string]string{servingCertAnnotation: installer.ServingCertSecretName()},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: installer.Selector,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       metricsPortName,
+					Port:       port,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: targetPort,
+				},
+			},
+		},
+	}
+}
+
+func (installer *Installer) serviceMonitor() *unstructured.Unstructured {
+	endpoint := map[string]interface{}{
+		"port":            metricsPortName,
+		"path":            metricsPath,
+		"scheme":          "https",
+		"bearerTokenFile": bearerTokenFile,
+		"tlsConfig": map[string]interface{}{
+			"serverName": fmt.Sprintf("%s.%s.svc", installer.Name, installer.Namespace),
+			"ca": map[string]interface{}{
+				"configMap": map[string]interface{}{
+					"name": serviceCAConfigMap,
+					"key":  serviceCAKey,
+				},
+			},
+		},
+	}
+
+	labels := map[string]interface{}{}
+	for key, value := range installer.labels() {
+		labels[key] = value
+	}
+
+	monitor := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":      installer.Name,
+			"namespace": installer.Namespace,
+			"labels":    labels,
+		},
+		"spec": map[string]interface{}{
+			"endpoints": []interface{}{endpoint},
+			"selector": map[string]interface{}{
+				"matchLabels": labels,
+			},
+		},
+	}}
+
+	monitor.SetGroupVersionKind(ServiceMonitorGroupVersionKind)
+
+	return monitor
+}
+
+// metricsPort returns the port of a metrics bind address.
+func metricsPort(address string) (int32, error) {
+	_, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return 0, fmt.Errorf("%s - %w", err, ErrInvalidAddress)
+	}
+
+	port, err := strconv.ParseInt(portString, 10, 32)
+	if err != nil || port < 1 {
+		return 0, fmt.Errorf("invalid port [%s] - %w", portString, ErrInvalidAddress)
+	}
+
+	return int32(port), nil
+}
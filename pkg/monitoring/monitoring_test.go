@@ -0,0 +1,168 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/kubernetes"
+)
+
+// applyClient is a fake client which records the objects which are applied with it.
+type applyClient struct {
+	kubernetes.FakeClient
+
+	applied []client.Object
+}
+
+func (c *applyClient) Patch(_ context.Context, object client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.applied = append(c.applied, object)
+
+	return nil
+}
+
+func Test_metricsPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		address string
+		want    int32
+		wantErr bool
+	}{
+		{
+			name:    "ensure the port of an address without a host is returned",
+			address: ":8080",
+			want:    8080,
+		},
+		{
+			name:    "ensure the port of an address with a host is returned",
+			address: "127.0.0.1:8443",
+			want:    8443,
+		},
+		{
+			name:    "ensure an address without a port is invalid",
+			address: "127.0.0.1",
+			wantErr: true,
+		},
+		{
+			name:    "ensure an address with a named port is invalid",
+			address: ":metrics",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := metricsPort(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("metricsPort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("metricsPort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstaller_Apply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		namespace      string
+		secure         bool
+		wantPort       int32
+		wantTargetPort intstr.IntOrString
+		wantErr        error
+	}{
+		{
+			name:           "ensure an insecure metrics endpoint is scraped through the proxy",
+			namespace:      "ocm-operator",
+			wantPort:       ProxyPort,
+			wantTargetPort: intstr.FromString(proxyPortName),
+		},
+		{
+			name:           "ensure a secure metrics endpoint is scraped directly",
+			namespace:      "ocm-operator",
+			secure:         true,
+			wantPort:       8080,
+			wantTargetPort: intstr.FromInt(8080),
+		},
+		{
+			name:    "ensure the objects are not applied without a namespace",
+			wantErr: ErrMissingNamespace,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &applyClient{}
+			installer := &Installer{
+				Client:    c,
+				Name:      DefaultName,
+				Namespace: tt.namespace,
+				Address:   ":8080",
+				Secure:    tt.secure,
+				Selector:  DefaultSelector(),
+			}
+
+			if err := installer.Apply(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(c.applied) != 2 {
+				t.Fatalf("Apply() applied = %v, want %v", len(c.applied), 2)
+			}
+
+			service, ok := c.applied[0].(*corev1.Service)
+			if !ok {
+				t.Fatalf("Apply() service = %v, want service", c.applied[0])
+			}
+
+			if got := service.Spec.Ports[0]; got.Port != tt.wantPort || got.TargetPort != tt.wantTargetPort {
+				t.Errorf("Apply() service port = %v -> %v, want %v -> %v", got.Port, got.TargetPort.String(), tt.wantPort, tt.wantTargetPort.String())
+			}
+
+			if got := service.Annotations[servingCertAnnotation]; got != installer.ServingCertSecretName() {
+				t.Errorf("Apply() serving cert secret = %v, want %v", got, installer.ServingCertSecretName())
+			}
+
+			monitor, ok := c.applied[1].(*unstructured.Unstructured)
+			if !ok {
+				t.Fatalf("Apply() service monitor = %v, want unstructured", c.applied[1])
+			}
+
+			endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+			endpoint := endpoints[0].(map[string]interface{})
+
+			if got := endpoint["scheme"]; got != "https" {
+				t.Errorf("Apply() scheme = %v, want %v", got, "https")
+			}
+
+			if _, found, _ := unstructured.NestedBool(endpoint, "tlsConfig", "insecureSkipVerify"); found {
+				t.Errorf("Apply() tlsConfig = %v, want the serving certificate to be verified", endpoint["tlsConfig"])
+			}
+
+			if got, _, _ := unstructured.NestedString(endpoint, "tlsConfig", "ca", "configMap", "name"); got != serviceCAConfigMap {
+				t.Errorf("Apply() ca config map = %v, want %v", got, serviceCAConfigMap)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+)
+
+const (
+	// DefaultTimeout is the default amount of time to wait for the webhook to accept a notification.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Formats of the payload which is sent to the webhook.
+const (
+	// FormatGeneric sends a JSON object describing the event to the webhook.
+	FormatGeneric = "generic"
+
+	// FormatSlack sends a message which is compatible with Slack, and other chat systems which
+	// implement Slack compatible incoming webhooks.
+	FormatSlack = "slack"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported notification format")
+	ErrRejected          = errors.New("notification rejected by webhook")
+)
+
+// Notification is the payload which is sent to the webhook when using the generic format.
+type Notification struct {
+	Event     string    `json:"event"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Time      time.Time `json:"time"`
+}
+
+// slackMessage is the payload which is sent to the webhook when using the slack format.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// Webhook pushes significant lifecycle events of the managed resources, such as their creation,
+// deletion, the completion of an upgrade or a terminal failure, to a webhook so that teams are
+// notified of changes to their fleet.  Notifications are sent asynchronously so that they do not
+// delay reconciliation, and failing to send a notification is logged rather than retried.
+type Webhook struct {
+	URL     string
+	Format  string
+	Client  *http.Client
+	Log     logr.Logger
+	Timeout time.Duration
+}
+
+// NewWebhook returns a webhook notifier which sends notifications to the url in the requested
+// format, over a transport such as the proxy transport of the operator.  The generic format is used if
+// the format is empty, and the default transport is used if the transport is nil.
+func NewWebhook(url, format string, transport http.RoundTripper, log logr.Logger) (*Webhook, error) {
+	switch format {
+	case "":
+		format = FormatGeneric
+	case FormatGeneric, FormatSlack:
+	default:
+		return nil, fmt.Errorf("unable to create notification webhook with format [%s] - %w", format, ErrUnsupportedFormat)
+	}
+
+	return &Webhook{
+		URL:     url,
+		Format:  format,
+		Client:  &http.Client{Transport: transport},
+		Log:     log,
+		Timeout: DefaultTimeout,
+	}, nil
+}
+
+// Notifies determines if an event is significant enough to be sent to the webhook.
+func Notifies(event events.Event) bool {
+	switch event {
+	case events.Created, events.Deleted, events.UpgradeCompleted, events.Failed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Notify implements the events.Notifier interface.  It sends a notification for a significant event
+// to the webhook in the background.
+func (webhook *Webhook) Notify(event events.Event, object client.Object, message string) {
+	if !Notifies(event) {
+		return
+	}
+
+	notification := &Notification{
+		Event:     event.String(),
+		Kind:      kindOf(object),
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+		Message:   message,
+		Time:      time.Now().UTC(),
+	}
+
+	go func() {
+		timeout := webhook.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := webhook.Send(ctx, notification); err != nil {
+			webhook.Log.Error(err, "unable to send notification", "event", notification.Event, "kind", notification.Kind,
+				"namespace", notification.Namespace, "name", notification.Name)
+		}
+	}()
+}
+
+// Send sends a notification to the webhook and waits for it to be accepted.
+func (webhook *Webhook) Send(ctx context.Context, notification *Notification) error {
+	payload, err := webhook.payload(notification)
+	if err != nil {
+		return fmt.Errorf("unable to encode notification - %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("unable to create notification request - %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	httpClient := webhook.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to send notification request - %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unable to send notification with status [%d] - %w", response.StatusCode, ErrRejected)
+	}
+
+	return nil
+}
+
+// payload returns the body of the request which is sent to the webhook for a notification.
+func (webhook *Webhook) payload(notification *Notification) ([]byte, error) {
+	if webhook.Format == FormatSlack {
+		//nolint:wrapcheck
+		return json.Marshal(&slackMessage{
+			Text: fmt.Sprintf(
+				"[%s] %s %s/%s: %s",
+				notification.Event,
+				notification.Kind,
+				notification.Namespace,
+				notification.Name,
+				notification.Message,
+			),
+		})
+	}
+
+	//nolint:wrapcheck
+	return json.Marshal(notification)
+}
+
+// kindOf returns the kind of an object.  Objects which are retrieved by a typed client do not always
+// have their kind set, in which case the name of their type is used.
+func kindOf(object client.Object) string {
+	if kind := object.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+
+	return reflect.Indirect(reflect.ValueOf(object)).Type().Name()
+}
+
+// Recorder is an event recorder which also pushes the actions which are registered on an object to a
+// notifier.
+type Recorder struct {
+	record.EventRecorder
+
+	Notifier events.Notifier
+}
+
+// NewRecorder returns an event recorder which pushes actions to the notifier.  The recorder is
+// returned unchanged if the notifier is nil.
+func NewRecorder(recorder record.EventRecorder, notifier events.Notifier) record.EventRecorder {
+	if notifier == nil {
+		return recorder
+	}
+
+	return &Recorder{EventRecorder: recorder, Notifier: notifier}
+}
+
+// Notify implements the events.Notifier interface.
+func (recorder *Recorder) Notify(event events.Event, object client.Object, message string) {
+	recorder.Notifier.Notify(event, object, message)
+}
@@ -0,0 +1,245 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/events"
+)
+
+// fakeNotifier is a notifier which records the events which it is notified of.
+type fakeNotifier struct {
+	notified []events.Event
+}
+
+func (notifier *fakeNotifier) Notify(event events.Event, _ client.Object, _ string) {
+	notifier.notified = append(notifier.notified, event)
+}
+
+func TestNewWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "ensure the generic format is used by default",
+			format: "",
+			want:   FormatGeneric,
+		},
+		{
+			name:   "ensure the slack format is supported",
+			format: FormatSlack,
+			want:   FormatSlack,
+		},
+		{
+			name:    "ensure an unknown format is unsupported",
+			format:  "teams",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewWebhook("http://localhost", tt.format, nil, logr.Discard())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got.Format != tt.want {
+				t.Errorf("NewWebhook() format = %v, want %v", got.Format, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_Send(t *testing.T) {
+	t.Parallel()
+
+	notification := &Notification{
+		Event:     events.CreatedString,
+		Kind:      "MachinePool",
+		Namespace: "default",
+		Name:      "test",
+		Message:   "created",
+		Time:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		status  int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "ensure the generic format sends the notification",
+			format: FormatGeneric,
+			status: http.StatusOK,
+			want: `{"event":"Created","kind":"MachinePool","namespace":"default","name":"test",` +
+				`"message":"created","time":"2023-01-01T00:00:00Z"}`,
+		},
+		{
+			name:   "ensure the slack format sends a text message",
+			format: FormatSlack,
+			status: http.StatusOK,
+			want:   `{"text":"[Created] MachinePool default/test: created"}`,
+		},
+		{
+			name:    "ensure a notification rejected by the webhook is an error",
+			format:  FormatGeneric,
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				got = string(body)
+
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			webhook, err := NewWebhook(server.URL, tt.format, nil, logr.Discard())
+			if err != nil {
+				t.Fatalf("NewWebhook() error = %v", err)
+			}
+
+			err = webhook.Send(context.Background(), notification)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Webhook.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Webhook.Send() body = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan *Notification, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notification := &Notification{}
+		if err := json.NewDecoder(r.Body).Decode(notification); err != nil {
+			t.Errorf("unable to decode notification - %v", err)
+		}
+
+		received <- notification
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhook(server.URL, FormatGeneric, nil, logr.Discard())
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+
+	pool := &ocmv1alpha1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	// an insignificant event is not sent to the webhook
+	webhook.Notify(events.Updated, pool, "updated")
+	webhook.Notify(events.Deleted, pool, "deleted")
+
+	select {
+	case got := <-received:
+		if got.Event != events.DeletedString || got.Kind != "MachinePool" || got.Name != "test" || got.Namespace != "default" {
+			t.Errorf("Webhook.Notify() notification = %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Webhook.Notify() notification was not sent")
+	}
+}
+
+// countingTransport is a transport which counts the requests which are sent through it.
+type countingTransport struct {
+	requests int
+}
+
+func (transport *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	transport.requests++
+
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestWebhook_Send_Transport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+
+	webhook, err := NewWebhook(server.URL, FormatGeneric, transport, logr.Discard())
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+
+	if err := webhook.Send(context.Background(), &Notification{Event: events.CreatedString}); err != nil {
+		t.Fatalf("Webhook.Send() error = %v", err)
+	}
+
+	if transport.requests != 1 {
+		t.Errorf("Webhook.Send() requests through transport = %v, want %v", transport.requests, 1)
+	}
+}
+
+func TestNewRecorder(t *testing.T) {
+	t.Parallel()
+
+	pool := &ocmv1alpha1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	t.Run("ensure the recorder is unchanged without a notifier", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := record.NewFakeRecorder(1)
+		if got := NewRecorder(recorder, nil); got != recorder {
+			t.Errorf("NewRecorder() = %v, want %v", got, recorder)
+		}
+	})
+
+	t.Run("ensure registered actions are pushed to the notifier", func(t *testing.T) {
+		t.Parallel()
+
+		notifier := &fakeNotifier{}
+		recorder := NewRecorder(record.NewFakeRecorder(2), notifier)
+
+		events.RegisterAction(events.Created, pool, recorder, "test", "cluster")
+		events.RegisterWarning(pool, recorder, "Warning", "warning")
+
+		if len(notifier.notified) != 1 || notifier.notified[0] != events.Created {
+			t.Errorf("NewRecorder() notified = %v, want [%v]", notifier.notified, events.Created)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+// Account represents the OpenShift Cluster Manager account, and the organization of the account,
+// which the operator is authenticated as.
+type Account struct {
+	Username               string `json:"username"`
+	Email                  string `json:"email,omitempty"`
+	OrganizationID         string `json:"organizationID"`
+	OrganizationName       string `json:"organizationName,omitempty"`
+	OrganizationExternalID string `json:"organizationExternalID,omitempty"`
+}
+
+// String returns a human readable representation of the account.
+func (account *Account) String() string {
+	return fmt.Sprintf("[%s] in organization [%s] with id [%s]", account.Username, account.OrganizationName, account.OrganizationID)
+}
+
+// GetAccount retrieves the account, and the organization of the account, which a connection is
+// authenticated as.
+func GetAccount(ctx context.Context, connection *sdk.Connection) (*Account, error) {
+	response, err := connection.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve current account from ocm - %w", err)
+	}
+
+	current := response.Body()
+	organization := current.Organization()
+
+	account := &Account{
+		Username:               current.Username(),
+		Email:                  current.Email(),
+		OrganizationID:         organization.ID(),
+		OrganizationName:       organization.Name(),
+		OrganizationExternalID: organization.ExternalID(),
+	}
+
+	// the organization of the current account may only be returned as a link, in which case the details
+	// of the organization are retrieved separately
+	if account.OrganizationID != "" && account.OrganizationName == "" {
+		response, err := connection.AccountsMgmt().V1().Organizations().Organization(account.OrganizationID).Get().SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve organization [%s] from ocm - %w", account.OrganizationID, err)
+		}
+
+		account.OrganizationName = response.Body().Name()
+		account.OrganizationExternalID = response.Body().ExternalID()
+	}
+
+	return account, nil
+}
@@ -0,0 +1,102 @@
+package ocm
+
+import (
+	"math"
+	"net"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// MaximumClassicComputeNodes is the maximum number of compute nodes of a cluster without a hosted
+	// control plane, as documented by the service definition.  The maximum is not reported by
+	// OpenShift Cluster Manager.
+	MaximumClassicComputeNodes = 249
+
+	// MaximumHostedComputeNodes is the maximum number of compute nodes of a cluster with a hosted
+	// control plane, as documented by the service definition.  The maximum is not reported by
+	// OpenShift Cluster Manager.
+	MaximumHostedComputeNodes = 500
+)
+
+// MaximumComputeNodes returns the maximum number of compute nodes, across all machine pools (or node
+// pools for hosted control plane clusters), which a cluster supports.
+func MaximumComputeNodes(cluster *clustersmgmtv1.Cluster) int {
+	if cluster.Hypershift().Enabled() {
+		return MaximumHostedComputeNodes
+	}
+
+	return MaximumClassicComputeNodes
+}
+
+// ControlPlaneNodes returns the number of control plane and infrastructure nodes of a cluster, which
+// are allocated from the network of the cluster along with its compute nodes.  A cluster with a hosted
+// control plane has no such nodes within its network.
+func ControlPlaneNodes(cluster *clustersmgmtv1.Cluster) int {
+	if cluster.Hypershift().Enabled() {
+		return 0
+	}
+
+	return cluster.Nodes().Master() + cluster.Nodes().Infra()
+}
+
+// PodCIDRNodeCapacity returns the maximum number of nodes which the pod network of a cluster is able to
+// allocate, as each node is allocated a subnet of the pod cidr the size of the host prefix.  False is
+// returned when the network of the cluster does not report a valid pod cidr and host prefix.
+func PodCIDRNodeCapacity(network *clustersmgmtv1.Network) (int, bool) {
+	_, podCIDR, err := net.ParseCIDR(network.PodCIDR())
+	if err != nil {
+		return 0, false
+	}
+
+	ones, bits := podCIDR.Mask.Size()
+	if hostPrefix := network.HostPrefix(); hostPrefix >= ones && hostPrefix <= bits {
+		return capacity(hostPrefix - ones), true
+	}
+
+	return 0, false
+}
+
+// MachineCIDRNodeCapacity returns the number of addresses within the machine cidr of a cluster, which
+// bounds the number of nodes of the cluster as each node is allocated an address from it.  False is
+// returned when the network of the cluster does not report a valid machine cidr.
+func MachineCIDRNodeCapacity(network *clustersmgmtv1.Network) (int, bool) {
+	_, machineCIDR, err := net.ParseCIDR(network.MachineCIDR())
+	if err != nil {
+		return 0, false
+	}
+
+	ones, bits := machineCIDR.Mask.Size()
+
+	return capacity(bits - ones), true
+}
+
+// MachinePoolMaximumReplicas returns the maximum number of nodes of a machine pool, which is the maximum
+// of its autoscaling configuration when it is autoscaling.
+func MachinePoolMaximumReplicas(machinePool *clustersmgmtv1.MachinePool) int {
+	if maximum, ok := machinePool.Autoscaling().GetMaxReplicas(); ok {
+		return maximum
+	}
+
+	return machinePool.Replicas()
+}
+
+// NodePoolMaximumReplicas returns the maximum number of nodes of a node pool, which is the maximum of
+// its autoscaling configuration when it is autoscaling.
+func NodePoolMaximumReplicas(nodePool *clustersmgmtv1.NodePool) int {
+	if maximum, ok := nodePool.Autoscaling().GetMaxReplica(); ok {
+		return maximum
+	}
+
+	return nodePool.Replicas()
+}
+
+// capacity returns the number of subnets or addresses for a number of bits, which is limited so that a
+// large ipv6 network does not overflow.
+func capacity(bits int) int {
+	if bits >= 31 {
+		return math.MaxInt32
+	}
+
+	return 1 << bits
+}
@@ -0,0 +1,84 @@
+package ocm
+
+import (
+	"testing"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestNodeCapacity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		machineCIDR   string
+		podCIDR       string
+		hostPrefix    int
+		wantPod       int
+		wantPodOK     bool
+		wantMachine   int
+		wantMachineOK bool
+	}{
+		{
+			name:          "ensure the capacity of the default network is calculated",
+			machineCIDR:   "10.0.0.0/16",
+			podCIDR:       "10.128.0.0/14",
+			hostPrefix:    23,
+			wantPod:       512,
+			wantPodOK:     true,
+			wantMachine:   65536,
+			wantMachineOK: true,
+		},
+		{
+			name:          "ensure a small network is calculated",
+			machineCIDR:   "10.0.0.0/26",
+			podCIDR:       "10.128.0.0/20",
+			hostPrefix:    24,
+			wantPod:       16,
+			wantPodOK:     true,
+			wantMachine:   64,
+			wantMachineOK: true,
+		},
+		{
+			name:          "ensure a host prefix outside of the pod cidr is not calculated",
+			machineCIDR:   "10.0.0.0/16",
+			podCIDR:       "10.128.0.0/24",
+			hostPrefix:    23,
+			wantPod:       0,
+			wantPodOK:     false,
+			wantMachine:   65536,
+			wantMachineOK: true,
+		},
+		{
+			name:          "ensure a missing network is not calculated",
+			wantPod:       0,
+			wantPodOK:     false,
+			wantMachine:   0,
+			wantMachineOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			network, err := clustersmgmtv1.NewNetwork().
+				MachineCIDR(tt.machineCIDR).
+				PodCIDR(tt.podCIDR).
+				HostPrefix(tt.hostPrefix).
+				Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			if got, ok := PodCIDRNodeCapacity(network); got != tt.wantPod || ok != tt.wantPodOK {
+				t.Errorf("PodCIDRNodeCapacity() = %v, %v, want %v, %v", got, ok, tt.wantPod, tt.wantPodOK)
+			}
+
+			if got, ok := MachineCIDRNodeCapacity(network); got != tt.wantMachine || ok != tt.wantMachineOK {
+				t.Errorf("MachineCIDRNodeCapacity() = %v, %v, want %v, %v", got, ok, tt.wantMachine, tt.wantMachineOK)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package ocm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -10,11 +11,16 @@ import (
 
 var (
 	ErrClusterResponse = errors.New("invalid cluster response")
+	ErrClusterNotFound = errors.New("cluster not found")
 )
 
 type clusterClient struct {
+	requestContext
+
 	Name       string
 	Connection *clustersmgmtv1.ClustersClient
+
+	guard *OrganizationGuard
 }
 
 func NewClusterClient(connection *sdk.Connection, name string) *clusterClient {
@@ -24,13 +30,32 @@ func NewClusterClient(connection *sdk.Connection, name string) *clusterClient {
 	}
 }
 
+// WithOrganizationGuard ensures that the retrieved cluster belongs to an allowed organization.
+func (cc *clusterClient) WithOrganizationGuard(guard *OrganizationGuard) *clusterClient {
+	cc.guard = guard
+
+	return cc
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (cc *clusterClient) WithContext(ctx context.Context) *clusterClient {
+	cc.setContext(ctx)
+
+	return cc
+}
+
 func (cc *clusterClient) Get() (cluster *clustersmgmtv1.Cluster, err error) {
 	// retrieve the cluster from openshift cluster manager
-	clusterList, err := cc.Connection.List().Search(fmt.Sprintf("name = '%s'", cc.Name)).Send()
+	clusterList, err := cc.Connection.List().Search(fmt.Sprintf("name = '%s'", cc.Name)).SendContext(cc.sendContext())
 	if err != nil {
 		return cluster, fmt.Errorf("unable to retrieve cluster from openshift cluster manager - %w", err)
 	}
 
+	// return an error if we did not find the cluster
+	if len(clusterList.Items().Slice()) == 0 {
+		return cluster, fmt.Errorf("unable to find cluster with name [%s] - %w", cc.Name, ErrClusterNotFound)
+	}
+
 	// return an error if we did not find exactly 1 cluster
 	if len(clusterList.Items().Slice()) != 1 {
 		return cluster, fmt.Errorf(
@@ -41,5 +66,11 @@ func (cc *clusterClient) Get() (cluster *clustersmgmtv1.Cluster, err error) {
 		)
 	}
 
-	return clusterList.Items().Slice()[0], nil
+	cluster = clusterList.Items().Slice()[0]
+
+	if err := cc.guard.Check(cc.sendContext(), cluster); err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
 }
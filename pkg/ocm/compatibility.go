@@ -0,0 +1,157 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+var (
+	ErrIncompatibleAPI = errors.New("openshift cluster manager api is incompatible with the operator")
+)
+
+// API is a versioned API of OpenShift Cluster Manager which the operator depends upon.
+type API struct {
+	// Name is the name of the API service, for example clusters_mgmt.
+	Name string
+
+	// Path is the path of the versioned API, which returns the metadata of the API.
+	Path string
+}
+
+// The APIs of OpenShift Cluster Manager which the version of the SDK which the operator is built with
+// expects to be served.
+var (
+	APIClustersMgmt = API{Name: "clusters_mgmt", Path: "/api/clusters_mgmt/v1"}
+	APIAccountsMgmt = API{Name: "accounts_mgmt", Path: "/api/accounts_mgmt/v1"}
+)
+
+// SDKVersion is the version of the OpenShift Cluster Manager SDK which the operator is built with, and
+// therefore the version whose APIs the operator expects to be served.
+const SDKVersion = sdk.Version
+
+// APIStatus is the result of probing an API of OpenShift Cluster Manager.
+type APIStatus struct {
+	API API
+
+	// ServerVersion is the version of OpenShift Cluster Manager which serves the API.
+	ServerVersion string
+
+	// Deprecation is the deprecation notice which OpenShift Cluster Manager returned for the API, if
+	// the API is deprecated.  A deprecated API remains compatible until it is removed.
+	Deprecation string
+
+	// Err is the reason that the API is incompatible, or nil if the API is compatible.
+	Err error
+}
+
+// apiMetadata is the metadata which is returned from the root of an API.
+type apiMetadata struct {
+	ServerVersion string `json:"server_version"`
+}
+
+// Compatibility records the compatibility of the APIs of OpenShift Cluster Manager with the operator, as
+// probed when the operator starts, so that controllers which depend upon an incompatible API may be
+// marked degraded rather than failing mid-reconcile.  A nil compatibility considers every API compatible.
+//
+// The compatibility is only recorded when it is probed, and is read-only thereafter.
+type Compatibility struct {
+	statuses map[string]APIStatus
+}
+
+// ProbeCompatibility probes each of the APIs of OpenShift Cluster Manager and records their
+// compatibility.  An API which is not served, because it has been removed, is incompatible.  An API
+// which could not be probed, for example because OpenShift Cluster Manager is unreachable, is not
+// considered incompatible, so that a transient failure does not degrade the operator.
+func ProbeCompatibility(ctx context.Context, connection *sdk.Connection, apis ...API) *Compatibility {
+	compatibility := &Compatibility{statuses: map[string]APIStatus{}}
+
+	for _, api := range apis {
+		response, err := connection.Get().Path(api.Path).SendContext(ctx)
+		if err != nil {
+			continue
+		}
+
+		compatibility.statuses[api.Name] = NewAPIStatus(api, response.Status(), response.Header, response.Bytes())
+	}
+
+	return compatibility
+}
+
+// NewAPIStatus returns the status of an API from the response to a request for its metadata.
+func NewAPIStatus(api API, status int, header func(string) string, body []byte) APIStatus {
+	apiStatus := APIStatus{API: api}
+
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		apiStatus.Err = fmt.Errorf("api [%s] is not served at [%s] - %w", api.Name, api.Path, ErrIncompatibleAPI)
+
+		return apiStatus
+	case status != http.StatusOK:
+		return apiStatus
+	}
+
+	metadata := &apiMetadata{}
+	if err := json.Unmarshal(body, metadata); err == nil {
+		apiStatus.ServerVersion = metadata.ServerVersion
+	}
+
+	if deprecation := header("Deprecation"); deprecation != "" {
+		apiStatus.Deprecation = deprecation
+
+		if sunset := header("Sunset"); sunset != "" {
+			apiStatus.Deprecation = fmt.Sprintf("%s, sunset %s", deprecation, sunset)
+		}
+	}
+
+	return apiStatus
+}
+
+// Statuses returns the status of each API which was probed, sorted by the name of the API.
+func (compatibility *Compatibility) Statuses() []APIStatus {
+	if compatibility == nil {
+		return nil
+	}
+
+	statuses := make([]APIStatus, 0, len(compatibility.statuses))
+	for _, status := range compatibility.statuses {
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].API.Name < statuses[j].API.Name })
+
+	return statuses
+}
+
+// Check checks that each of the APIs is compatible with the operator.
+func (compatibility *Compatibility) Check(apis ...API) error {
+	if compatibility == nil {
+		return nil
+	}
+
+	for _, api := range apis {
+		if status, ok := compatibility.statuses[api.Name]; ok && status.Err != nil {
+			return status.Err
+		}
+	}
+
+	return nil
+}
+
+// Incompatible returns the sorted names of the APIs which are incompatible with the operator.
+func (compatibility *Compatibility) Incompatible() []string {
+	incompatible := []string{}
+
+	for _, status := range compatibility.Statuses() {
+		if status.Err != nil {
+			incompatible = append(incompatible, status.API.Name)
+		}
+	}
+
+	return incompatible
+}
@@ -0,0 +1,117 @@
+package ocm
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNewAPIStatus(t *testing.T) {
+	t.Parallel()
+
+	noHeaders := func(string) string { return "" }
+
+	tests := []struct {
+		name   string
+		status int
+		header func(string) string
+		body   string
+		want   APIStatus
+	}{
+		{
+			name:   "ensure a served api is compatible",
+			status: http.StatusOK,
+			header: noHeaders,
+			body:   `{"kind":"Metadata","server_version":"1.2.3"}`,
+			want:   APIStatus{API: APIClustersMgmt, ServerVersion: "1.2.3"},
+		},
+		{
+			name:   "ensure a deprecated api is compatible",
+			status: http.StatusOK,
+			header: func(name string) string {
+				return map[string]string{"Deprecation": "true", "Sunset": "Wed, 01 Jan 2025 00:00:00 GMT"}[name]
+			},
+			body: `{"kind":"Metadata","server_version":"1.2.3"}`,
+			want: APIStatus{
+				API:           APIClustersMgmt,
+				ServerVersion: "1.2.3",
+				Deprecation:   "true, sunset Wed, 01 Jan 2025 00:00:00 GMT",
+			},
+		},
+		{
+			name:   "ensure an api which could not be probed is compatible",
+			status: http.StatusServiceUnavailable,
+			header: noHeaders,
+			want:   APIStatus{API: APIClustersMgmt},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewAPIStatus(APIClustersMgmt, tt.status, tt.header, []byte(tt.body)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAPIStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("ensure an api which is not served is incompatible", func(t *testing.T) {
+		t.Parallel()
+		if got := NewAPIStatus(APIClustersMgmt, http.StatusNotFound, noHeaders, nil); !errors.Is(got.Err, ErrIncompatibleAPI) {
+			t.Errorf("NewAPIStatus() error = %v, want %v", got.Err, ErrIncompatibleAPI)
+		}
+	})
+}
+
+func TestCompatibility_Check(t *testing.T) {
+	t.Parallel()
+
+	noHeaders := func(string) string { return "" }
+
+	compatibility := &Compatibility{statuses: map[string]APIStatus{
+		APIClustersMgmt.Name: NewAPIStatus(APIClustersMgmt, http.StatusNotFound, noHeaders, nil),
+		APIAccountsMgmt.Name: NewAPIStatus(APIAccountsMgmt, http.StatusOK, noHeaders, []byte(`{}`)),
+	}}
+
+	tests := []struct {
+		name          string
+		compatibility *Compatibility
+		apis          []API
+		want          error
+	}{
+		{
+			name:          "ensure a compatible api passes",
+			compatibility: compatibility,
+			apis:          []API{APIAccountsMgmt},
+			want:          nil,
+		},
+		{
+			name:          "ensure an incompatible api fails",
+			compatibility: compatibility,
+			apis:          []API{APIAccountsMgmt, APIClustersMgmt},
+			want:          ErrIncompatibleAPI,
+		},
+		{
+			name:          "ensure a nil compatibility passes",
+			compatibility: nil,
+			apis:          []API{APIClustersMgmt},
+			want:          nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.compatibility.Check(tt.apis...); !errors.Is(err, tt.want) {
+				t.Errorf("Compatibility.Check() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if got, want := compatibility.Incompatible(), []string{APIClustersMgmt.Name}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Compatibility.Incompatible() = %v, want %v", got, want)
+	}
+}
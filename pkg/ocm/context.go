@@ -0,0 +1,22 @@
+package ocm
+
+import "context"
+
+// requestContext tracks the context with which a client sends requests to OpenShift Cluster Manager, so
+// that the requests are cancelled along with, and bound by the deadline of, the reconciliation which
+// sends them.  Requests are sent with a background context when no context has been set.
+type requestContext struct {
+	ctx context.Context
+}
+
+func (rc *requestContext) setContext(ctx context.Context) {
+	rc.ctx = ctx
+}
+
+func (rc *requestContext) sendContext() context.Context {
+	if rc.ctx == nil {
+		return context.Background()
+	}
+
+	return rc.ctx
+}
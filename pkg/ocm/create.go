@@ -0,0 +1,34 @@
+package ocm
+
+// recoverCreate recovers from a create request which returned an indeterminate error, such as a timeout
+// of a request which was nevertheless applied by OpenShift Cluster Manager, or a server error returned
+// part way through the request.  The object is retrieved again and, when it exists and carries the
+// ownership marker of the create request, it is returned as though the create request had succeeded, so
+// that the next reconcile does not fail with a duplicate object.  The error of the create request is
+// returned otherwise, including for a conflict, as an object which already exists may belong to someone
+// else.
+func recoverCreate[T any](createErr error, get func() (*T, error), created func(*T) bool) (*T, error) {
+	if !IsIndeterminate(createErr) {
+		return nil, createErr
+	}
+
+	existing, err := get()
+	if err != nil || existing == nil || !created(existing) {
+		return nil, createErr
+	}
+
+	return existing, nil
+}
+
+// hasManagedLabels determines if the labels of an existing object carry the managed labels of the
+// desired object, which identify that the existing object was created by the operator for the desired
+// object.  An object is never identified by labels which the desired object does not carry.
+func hasManagedLabels(existing, desired map[string]string) bool {
+	for _, label := range []string{LabelPrefixManaged, LabelPrefixName} {
+		if desired[label] == "" || existing[label] != desired[label] {
+			return false
+		}
+	}
+
+	return true
+}
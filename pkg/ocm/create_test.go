@@ -0,0 +1,159 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestRecoverCreate(t *testing.T) {
+	t.Parallel()
+
+	type object struct {
+		name string
+	}
+
+	errGet := errors.New("get failed")
+
+	tests := []struct {
+		name      string
+		createErr error
+		existing  *object
+		getErr    error
+		created   bool
+		want      *object
+		wantErr   bool
+	}{
+		{
+			name:      "ensure a timed out create which was applied is recovered",
+			createErr: fmt.Errorf("error in create request - %w", context.DeadlineExceeded),
+			existing:  &object{name: "infra"},
+			created:   true,
+			want:      &object{name: "infra"},
+			wantErr:   false,
+		},
+		{
+			name:      "ensure a create which failed with a server error and was applied is recovered",
+			createErr: testOCMError(t, http.StatusServiceUnavailable),
+			existing:  &object{name: "infra"},
+			created:   true,
+			want:      &object{name: "infra"},
+			wantErr:   false,
+		},
+		{
+			name:      "ensure a conflicting create is not recovered",
+			createErr: testOCMError(t, http.StatusConflict),
+			existing:  &object{name: "infra"},
+			created:   true,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure a timed out create which was not applied returns the create error",
+			createErr: context.DeadlineExceeded,
+			existing:  nil,
+			created:   false,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure an existing object which was not created by the request returns the create error",
+			createErr: context.DeadlineExceeded,
+			existing:  &object{name: "infra"},
+			created:   false,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure a failure to retrieve the object returns the create error",
+			createErr: context.DeadlineExceeded,
+			getErr:    errGet,
+			created:   true,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure a determinate create error is not recovered",
+			createErr: testOCMError(t, http.StatusBadRequest),
+			existing:  &object{name: "infra"},
+			created:   true,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := recoverCreate(
+				tt.createErr,
+				func() (*object, error) { return tt.existing, tt.getErr },
+				func(*object) bool { return tt.created },
+			)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recoverCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, tt.createErr) {
+				t.Errorf("recoverCreate() error = %v, want %v", err, tt.createErr)
+			}
+
+			if (got == nil) != (tt.want == nil) || (got != nil && got.name != tt.want.name) {
+				t.Errorf("recoverCreate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasManagedLabels(t *testing.T) {
+	t.Parallel()
+
+	desired := map[string]string{LabelPrefixManaged: "true", LabelPrefixName: "infra", "team": "mobb"}
+
+	tests := []struct {
+		name     string
+		existing map[string]string
+		desired  map[string]string
+		want     bool
+	}{
+		{
+			name:     "ensure an object with the managed labels of the desired object is managed",
+			existing: map[string]string{LabelPrefixManaged: "true", LabelPrefixName: "infra"},
+			desired:  desired,
+			want:     true,
+		},
+		{
+			name:     "ensure an object with the managed labels of another object is not managed",
+			existing: map[string]string{LabelPrefixManaged: "true", LabelPrefixName: "gpu"},
+			desired:  desired,
+			want:     false,
+		},
+		{
+			name:     "ensure an object without labels is not managed",
+			existing: nil,
+			desired:  desired,
+			want:     false,
+		},
+		{
+			name:     "ensure an object is not managed when the desired object has no managed labels",
+			existing: map[string]string{},
+			desired:  map[string]string{},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := hasManagedLabels(tt.existing, tt.desired); got != tt.want {
+				t.Errorf("hasManagedLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
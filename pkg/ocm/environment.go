@@ -0,0 +1,178 @@
+package ocm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+const (
+	// EnvironmentProduction is the production environment of OpenShift Cluster Manager.
+	EnvironmentProduction = "production"
+
+	// EnvironmentStage is the stage environment of OpenShift Cluster Manager.
+	EnvironmentStage = "stage"
+
+	// EnvironmentIntegration is the integration environment of OpenShift Cluster Manager.
+	EnvironmentIntegration = "integration"
+)
+
+var (
+	ErrEnvironmentUnknown       = errors.New("unknown ocm environment")
+	ErrEnvironmentNotConfigured = errors.New("ocm environment is not configured")
+	ErrEnvironmentDuplicate     = errors.New("ocm environment is configured more than once")
+)
+
+// environmentURLs are the urls of the api of each environment of OpenShift Cluster Manager.
+var environmentURLs = map[string]string{
+	EnvironmentProduction:  sdk.DefaultURL,
+	EnvironmentStage:       "https://api.stage.openshift.com",
+	EnvironmentIntegration: "https://api.integration.openshift.com",
+}
+
+// EnvironmentURL returns the url of the api of an environment of OpenShift Cluster Manager.
+func EnvironmentURL(name string) (string, error) {
+	url, ok := environmentURLs[name]
+	if !ok {
+		return "", fmt.Errorf("environment [%s] - %w", name, ErrEnvironmentUnknown)
+	}
+
+	return url, nil
+}
+
+// ParseEnvironmentTokenFiles parses a comma-separated list of environment=file pairs into the token
+// file of each environment of OpenShift Cluster Manager.
+func ParseEnvironmentTokenFiles(pairs string) (map[string]string, error) {
+	files := map[string]string{}
+
+	for _, pair := range strings.Split(pairs, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		name, file, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(file) == "" {
+			return nil, fmt.Errorf("environment token file [%s] must be in environment=file format - %w", pair, ErrEnvironmentUnknown)
+		}
+
+		name = strings.TrimSpace(name)
+		if _, err := EnvironmentURL(name); err != nil {
+			return nil, err
+		}
+
+		files[name] = strings.TrimSpace(file)
+	}
+
+	return files, nil
+}
+
+// Environment is a connection to an environment of OpenShift Cluster Manager, along with the guard
+// which ensures that the clusters managed in the environment belong to an allowed organization.  The
+// compatibility and maintenance of each environment are probed separately, as the environments are
+// upgraded and maintained independently of each other.
+type Environment struct {
+	Connection    *sdk.Connection
+	Organizations *OrganizationGuard
+	Compatibility *Compatibility
+	Maintenance   *Maintenance
+}
+
+// Environments are the environments of OpenShift Cluster Manager which the operator is connected
+// to, so that objects which target different environments may be managed by the same operator.  A
+// nil set of environments only contains the default environment.
+type Environments struct {
+	// Default is the name of the environment which objects target when they do not request an
+	// environment.
+	Default string
+
+	environments map[string]*Environment
+}
+
+// NewEnvironments returns a set of environments whose default environment has a name.
+func NewEnvironments(defaultEnvironment string) *Environments {
+	return &Environments{Default: defaultEnvironment, environments: map[string]*Environment{}}
+}
+
+// Add adds a connection to an environment, replacing any existing connection to the environment.
+func (environments *Environments) Add(name string, environment *Environment) {
+	environments.environments[name] = environment
+}
+
+// DefaultName returns the name of the default environment.
+func (environments *Environments) DefaultName() string {
+	if environments == nil || environments.Default == "" {
+		return EnvironmentProduction
+	}
+
+	return environments.Default
+}
+
+// IsDefault determines if a requested environment is the default environment.  An empty name requests
+// the default environment.
+func (environments *Environments) IsDefault(name string) bool {
+	return name == "" || name == environments.DefaultName()
+}
+
+// Get returns the connection to an environment.
+func (environments *Environments) Get(name string) (*Environment, error) {
+	if environments != nil {
+		if environment, ok := environments.environments[name]; ok {
+			return environment, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"environment [%s] is not one of the configured environments [%s] - %w",
+		name,
+		strings.Join(environments.Names(), ","),
+		ErrEnvironmentNotConfigured,
+	)
+}
+
+// Each calls a function with each of the configured environments, in the alphabetical order of their
+// names.
+func (environments *Environments) Each(function func(name string, environment *Environment)) {
+	for _, name := range environments.Names() {
+		if environment, err := environments.Get(name); err == nil {
+			function(name, environment)
+		}
+	}
+}
+
+// Names returns the names of the environments, in alphabetical order, including the default
+// environment.
+func (environments *Environments) Names() []string {
+	names := []string{environments.DefaultName()}
+
+	if environments != nil {
+		for name := range environments.environments {
+			if name != environments.DefaultName() {
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Close closes the connection to each environment, returning the first error which was encountered.
+func (environments *Environments) Close() error {
+	if environments == nil {
+		return nil
+	}
+
+	var closeErr error
+
+	for name, environment := range environments.environments {
+		if err := environment.Connection.Close(); err != nil && closeErr == nil {
+			closeErr = fmt.Errorf("unable to close connection to environment [%s] - %w", name, err)
+		}
+	}
+
+	return closeErr
+}
@@ -0,0 +1,99 @@
+package ocm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseEnvironmentTokenFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pairs   string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name:  "ensure an empty list has no token files",
+			pairs: "",
+			want:  map[string]string{},
+		},
+		{
+			name:  "ensure the token file of each environment is parsed",
+			pairs: " stage=/tmp/stage.json, ,integration = /tmp/integration.json",
+			want:  map[string]string{EnvironmentStage: "/tmp/stage.json", EnvironmentIntegration: "/tmp/integration.json"},
+		},
+		{
+			name:    "ensure a pair without a token file is rejected",
+			pairs:   "stage",
+			wantErr: ErrEnvironmentUnknown,
+		},
+		{
+			name:    "ensure an unknown environment is rejected",
+			pairs:   "staging=/tmp/stage.json",
+			wantErr: ErrEnvironmentUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseEnvironmentTokenFiles(tt.pairs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseEnvironmentTokenFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEnvironmentTokenFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironments(t *testing.T) {
+	t.Parallel()
+
+	var unset *Environments
+	if !unset.IsDefault(EnvironmentProduction) || unset.IsDefault(EnvironmentStage) {
+		t.Errorf("Environments.IsDefault() want only %s to be the default environment", EnvironmentProduction)
+	}
+
+	if _, err := unset.Get(EnvironmentStage); !errors.Is(err, ErrEnvironmentNotConfigured) {
+		t.Errorf("Environments.Get() error = %v, want %v", err, ErrEnvironmentNotConfigured)
+	}
+
+	stage := &Environment{}
+	environments := NewEnvironments(EnvironmentStage)
+	environments.Add(EnvironmentStage, stage)
+	environments.Add(EnvironmentIntegration, &Environment{})
+
+	if !environments.IsDefault("") || !environments.IsDefault(EnvironmentStage) || environments.IsDefault(EnvironmentProduction) {
+		t.Errorf("Environments.IsDefault() want only %s to be the default environment", EnvironmentStage)
+	}
+
+	if got, err := environments.Get(EnvironmentStage); err != nil || got != stage {
+		t.Errorf("Environments.Get() = %v, %v, want %v, %v", got, err, stage, nil)
+	}
+
+	if _, err := environments.Get(EnvironmentProduction); !errors.Is(err, ErrEnvironmentNotConfigured) {
+		t.Errorf("Environments.Get() error = %v, want %v", err, ErrEnvironmentNotConfigured)
+	}
+
+	if got, want := environments.Names(), []string{EnvironmentIntegration, EnvironmentStage}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Environments.Names() = %v, want %v", got, want)
+	}
+
+	each := []string{}
+	environments.Each(func(name string, _ *Environment) { each = append(each, name) })
+
+	if want := []string{EnvironmentIntegration, EnvironmentStage}; !reflect.DeepEqual(each, want) {
+		t.Errorf("Environments.Each() = %v, want %v", each, want)
+	}
+
+	// the default environment of an unset set of environments has no connection
+	unset.Each(func(name string, _ *Environment) { t.Errorf("Environments.Each() called with %s, want none", name) })
+}
@@ -0,0 +1,79 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// IsUnsupported determines if an error returned from OpenShift Cluster Manager indicates that a
+// request was rejected as invalid or unsupported for the cluster.  Retrying such a request without
+// changing it will not succeed.
+func IsUnsupported(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized determines if an error returned from OpenShift Cluster Manager indicates that the
+// credentials of the operator were rejected, or that the operator is not permitted to perform the
+// request.
+func IsUnauthorized(err error) bool {
+	if errors.Is(err, ErrTokenInvalid) {
+		return true
+	}
+
+	return hasStatus(err, http.StatusUnauthorized) || (hasStatus(err, http.StatusForbidden) && !IsQuotaExceeded(err))
+}
+
+// IsQuotaExceeded determines if an error returned from OpenShift Cluster Manager indicates that the
+// organization of the operator does not have enough quota to fulfil a request.
+func IsQuotaExceeded(err error) bool {
+	var ocmErr *ocmerrors.Error
+	if !errors.As(err, &ocmErr) {
+		return false
+	}
+
+	return ocmErr.Status() == http.StatusPaymentRequired ||
+		strings.Contains(strings.ToLower(ocmErr.Reason()), "quota")
+}
+
+// IsUnavailable determines if an error returned from OpenShift Cluster Manager indicates that it was
+// unable to process a request due to a server side failure.  Retrying such a request may succeed.
+func IsUnavailable(err error) bool {
+	var ocmErr *ocmerrors.Error
+	if !errors.As(err, &ocmErr) {
+		return false
+	}
+
+	return ocmErr.Status() >= http.StatusInternalServerError
+}
+
+// IsIndeterminate determines if an error returned from a request to OpenShift Cluster Manager leaves it
+// unknown whether the request was applied, for example when the request timed out after it was sent, or
+// when OpenShift Cluster Manager failed part way through the request.  A conflict or an object which
+// already exists is a determinate rejection of the request and says nothing about who created the object.
+func IsIndeterminate(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return IsUnavailable(err)
+}
+
+// hasStatus determines if an error was returned from OpenShift Cluster Manager with a given status.
+func hasStatus(err error, status int) bool {
+	var ocmErr *ocmerrors.Error
+	if !errors.As(err, &ocmErr) {
+		return false
+	}
+
+	return ocmErr.Status() == status
+}
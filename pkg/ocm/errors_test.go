@@ -0,0 +1,114 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+func testOCMError(t *testing.T, status int) error {
+	t.Helper()
+
+	err, buildErr := ocmerrors.NewError().Status(status).Reason("test").Build()
+	if buildErr != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", buildErr, false)
+	}
+
+	return err
+}
+
+func TestIsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "ensure a wrapped bad request is unsupported",
+			err:  fmt.Errorf("error in create request - %w", testOCMError(t, http.StatusBadRequest)),
+			want: true,
+		},
+		{
+			name: "ensure a server error is not unsupported",
+			err:  testOCMError(t, http.StatusInternalServerError),
+			want: false,
+		},
+		{
+			name: "ensure a non-ocm error is not unsupported",
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsUnsupported(tt.err); got != tt.want {
+				t.Errorf("IsUnsupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsIndeterminate(t *testing.T) {
+	t.Parallel()
+
+	alreadyExists, err := ocmerrors.NewError().Status(http.StatusBadRequest).Reason("Identity provider 'gitlab' already exists").Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", err, false)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "ensure a timed out request is indeterminate",
+			err:  fmt.Errorf("error in create request - %w", context.DeadlineExceeded),
+			want: true,
+		},
+		{
+			name: "ensure a conflict is not indeterminate",
+			err:  testOCMError(t, http.StatusConflict),
+			want: false,
+		},
+		{
+			name: "ensure a server error is indeterminate",
+			err:  testOCMError(t, http.StatusBadGateway),
+			want: true,
+		},
+		{
+			name: "ensure an object which already exists is not indeterminate",
+			err:  alreadyExists,
+			want: false,
+		},
+		{
+			name: "ensure a bad request is not indeterminate",
+			err:  testOCMError(t, http.StatusBadRequest),
+			want: false,
+		},
+		{
+			name: "ensure a refused connection is not indeterminate",
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsIndeterminate(tt.err); got != tt.want {
+				t.Errorf("IsIndeterminate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package ocm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+const (
+	// faultBody is the body of the error response of a request which fails with an injected fault.
+	faultBody = `{"kind":"Error","id":"%d","code":"OCM-FAULT-%d","reason":"injected fault"}`
+
+	// malformedBody is the body of a response which is replaced by an injected fault.  It is truncated
+	// so that it may not be unmarshalled.
+	malformedBody = `{"kind":"Cluster","id":`
+)
+
+var ErrInvalidFault = errors.New("invalid fault")
+
+// Fault is a type of fault which is injected into a request to OpenShift Cluster Manager.
+type Fault int
+
+const (
+	// FaultNone does not inject a fault into a request.
+	FaultNone Fault = iota
+
+	// FaultError fails a request with an error response, without sending it.
+	FaultError
+
+	// FaultMalformed sends a request and replaces the body of its response with malformed json.
+	FaultMalformed
+)
+
+// Faults are the faults which are injected into requests to OpenShift Cluster Manager, so that the
+// handling of an unreliable OpenShift Cluster Manager, such as retries and backoff, may be validated.
+// They are intended for tests and debugging only.
+type Faults struct {
+	// ErrorRate is the fraction of requests, between 0 and 1, which fail with an error response.
+	ErrorRate float64
+
+	// ErrorCode is the status code of the error response of a failed request.  A service unavailable
+	// response is returned if this is zero.
+	ErrorCode int
+
+	// MalformedRate is the fraction of requests, between 0 and 1, whose response body is replaced
+	// with malformed json.
+	MalformedRate float64
+
+	// Latency is the amount of time which is added before each request is sent.
+	Latency time.Duration
+
+	// Seed seeds the random source which determines the requests that faults are injected into, so
+	// that the same sequence of faults is injected each time.
+	Seed int64
+}
+
+// ParseFaults parses a comma-separated list of key=value pairs into the faults which are injected into
+// requests to OpenShift Cluster Manager, for example "error-rate=0.1,latency=500ms,seed=42".  The keys
+// are error-rate, error-code, malformed-rate, latency and seed.
+func ParseFaults(pairs string) (Faults, error) {
+	faults := Faults{}
+
+	for _, pair := range strings.Split(pairs, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return Faults{}, fmt.Errorf("fault [%s] must be in key=value format - %w", pair, ErrInvalidFault)
+		}
+
+		if err := faults.set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return Faults{}, fmt.Errorf("unable to parse fault [%s] - %w", pair, err)
+		}
+	}
+
+	return faults, nil
+}
+
+// set sets a fault by its key.
+func (faults *Faults) set(key, value string) (err error) {
+	switch key {
+	case "error-rate":
+		faults.ErrorRate, err = parseRate(value)
+	case "malformed-rate":
+		faults.MalformedRate, err = parseRate(value)
+	case "error-code":
+		faults.ErrorCode, err = strconv.Atoi(value)
+		if err == nil && (faults.ErrorCode < http.StatusBadRequest || faults.ErrorCode > 599) {
+			err = fmt.Errorf("error code [%d] must be between 400 and 599 - %w", faults.ErrorCode, ErrInvalidFault)
+		}
+	case "latency":
+		faults.Latency, err = time.ParseDuration(value)
+	case "seed":
+		faults.Seed, err = strconv.ParseInt(value, 10, 64)
+	default:
+		err = fmt.Errorf("unknown fault [%s] - %w", key, ErrInvalidFault)
+	}
+
+	//nolint:wrapcheck
+	return err
+}
+
+// parseRate parses a fraction between 0 and 1.
+func parseRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse rate [%s] - %w", value, err)
+	}
+
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate [%s] must be between 0 and 1 - %w", value, ErrInvalidFault)
+	}
+
+	return rate, nil
+}
+
+// Enabled determines if any fault is injected.
+func (faults Faults) Enabled() bool {
+	return faults.ErrorRate > 0 || faults.MalformedRate > 0 || faults.Latency > 0
+}
+
+// FaultInjector injects faults into the requests which are sent over an OpenShift Cluster Manager
+// connection.  It counts the faults which it has injected, so that tests may assert upon them.
+type FaultInjector struct {
+	faults Faults
+
+	mutex    sync.Mutex
+	random   *rand.Rand
+	injected map[Fault]int
+}
+
+// NewFaultInjector returns a fault injector which injects faults into requests.
+func NewFaultInjector(faults Faults) *FaultInjector {
+	return &FaultInjector{
+		faults: faults,
+		//nolint:gosec
+		random:   rand.New(rand.NewSource(faults.Seed)),
+		injected: map[Fault]int{},
+	}
+}
+
+// Wrapper returns a wrapper for the transport of an OpenShift Cluster Manager connection which injects
+// faults into each request.  It should be the innermost wrapper of the connection other than the proxy
+// transport, so that the hooks and timeouts of the other wrappers observe the injected faults.
+func (injector *FaultInjector) Wrapper() sdk.TransportWrapper {
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		return &faultTransport{wrapped: wrapped, injector: injector}
+	}
+}
+
+// Injected returns the number of times that a type of fault has been injected.
+func (injector *FaultInjector) Injected(fault Fault) int {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	return injector.injected[fault]
+}
+
+// next determines the fault which is injected into the next request.  An error is injected in
+// preference to a malformed response.
+func (injector *FaultInjector) next() Fault {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	fault := FaultNone
+
+	// both values are always drawn so that the sequence of faults only depends upon the seed
+	errorDraw, malformedDraw := injector.random.Float64(), injector.random.Float64()
+
+	switch {
+	case errorDraw < injector.faults.ErrorRate:
+		fault = FaultError
+	case malformedDraw < injector.faults.MalformedRate:
+		fault = FaultMalformed
+	}
+
+	injector.injected[fault]++
+
+	return fault
+}
+
+// errorCode returns the status code of the error response of a failed request.
+func (injector *FaultInjector) errorCode() int {
+	if injector.faults.ErrorCode == 0 {
+		return http.StatusServiceUnavailable
+	}
+
+	return injector.faults.ErrorCode
+}
+
+// faultTransport is a transport which injects faults into the requests of a wrapped transport.
+type faultTransport struct {
+	wrapped  http.RoundTripper
+	injector *FaultInjector
+}
+
+// RoundTrip implements the http.RoundTripper interface.  The latency of a request is abandoned when
+// the context of the request is done.
+func (transport *faultTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if latency := transport.injector.faults.Latency; latency > 0 {
+		timer := time.NewTimer(latency)
+
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+
+			//nolint:wrapcheck
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	switch transport.injector.next() {
+	case FaultError:
+		return faultResponse(request, transport.injector.errorCode()), nil
+	case FaultMalformed:
+		//nolint:wrapcheck
+		response, err := transport.wrapped.RoundTrip(request)
+		if err != nil {
+			return response, err
+		}
+
+		response.Body.Close()
+		response.Body = io.NopCloser(strings.NewReader(malformedBody))
+		response.ContentLength = int64(len(malformedBody))
+		response.Header.Del("Content-Length")
+
+		return response, nil
+	default:
+		//nolint:wrapcheck
+		return transport.wrapped.RoundTrip(request)
+	}
+}
+
+// faultResponse returns the error response of a request which fails with an injected fault.
+func faultResponse(request *http.Request, code int) *http.Response {
+	body := fmt.Sprintf(faultBody, code, code)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
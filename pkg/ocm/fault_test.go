@@ -0,0 +1,208 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFaults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pairs   string
+		want    Faults
+		wantErr bool
+	}{
+		{
+			name:    "ensure empty faults are allowed",
+			pairs:   "",
+			want:    Faults{},
+			wantErr: false,
+		},
+		{
+			name:  "ensure multiple faults are parsed",
+			pairs: "error-rate=0.1, error-code=500, malformed-rate=0.05, latency=500ms, seed=42",
+			want: Faults{
+				ErrorRate:     0.1,
+				ErrorCode:     http.StatusInternalServerError,
+				MalformedRate: 0.05,
+				Latency:       500 * time.Millisecond,
+				Seed:          42,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ensure a fault without a value separator returns an error",
+			pairs:   "error-rate",
+			want:    Faults{},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an unknown fault returns an error",
+			pairs:   "drop-rate=0.1",
+			want:    Faults{},
+			wantErr: true,
+		},
+		{
+			name:    "ensure a rate above 1 returns an error",
+			pairs:   "error-rate=1.5",
+			want:    Faults{},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an error code which is not an error returns an error",
+			pairs:   "error-code=200",
+			want:    Faults{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseFaults(tt.pairs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFaults() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFaults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFaultInjector_Wrapper(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Cluster","id":"abc123"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name          string
+		faults        Faults
+		wantCode      int
+		wantMalformed bool
+		wantFault     Fault
+	}{
+		{
+			name:          "ensure a request without faults is sent",
+			faults:        Faults{},
+			wantCode:      http.StatusOK,
+			wantMalformed: false,
+			wantFault:     FaultNone,
+		},
+		{
+			name:          "ensure an error is injected",
+			faults:        Faults{ErrorRate: 1},
+			wantCode:      http.StatusServiceUnavailable,
+			wantMalformed: false,
+			wantFault:     FaultError,
+		},
+		{
+			name:          "ensure an error is injected with the requested status code",
+			faults:        Faults{ErrorRate: 1, ErrorCode: http.StatusTooManyRequests},
+			wantCode:      http.StatusTooManyRequests,
+			wantMalformed: false,
+			wantFault:     FaultError,
+		},
+		{
+			name:          "ensure a malformed response is injected",
+			faults:        Faults{MalformedRate: 1},
+			wantCode:      http.StatusOK,
+			wantMalformed: true,
+			wantFault:     FaultMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			injector := NewFaultInjector(tt.faults)
+			client := &http.Client{Transport: injector.Wrapper()(http.DefaultTransport)}
+
+			response, err := client.Get(server.URL + "/api/clusters_mgmt/v1/clusters/abc123")
+			if err != nil {
+				t.Fatalf("Get() error = %v, wantErr %v", err, false)
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != tt.wantCode {
+				t.Errorf("Get() code = %v, want %v", response.StatusCode, tt.wantCode)
+			}
+
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v, wantErr %v", err, false)
+			}
+
+			if malformed := !json.Valid(body); malformed != tt.wantMalformed {
+				t.Errorf("Get() malformed = %v, want %v", malformed, tt.wantMalformed)
+			}
+
+			if injected := injector.Injected(tt.wantFault); injected != 1 {
+				t.Errorf("Injected() = %v, want %v", injected, 1)
+			}
+		})
+	}
+}
+
+func TestFaultInjector_next(t *testing.T) {
+	t.Parallel()
+
+	faults := Faults{ErrorRate: 0.3, MalformedRate: 0.3, Seed: 42}
+
+	sequence := func() []Fault {
+		injector := NewFaultInjector(faults)
+		sequence := make([]Fault, 20)
+
+		for i := range sequence {
+			sequence[i] = injector.next()
+		}
+
+		return sequence
+	}
+
+	if first, second := sequence(), sequence(); !reflect.DeepEqual(first, second) {
+		t.Errorf("next() = %v, want the same sequence %v for the same seed", second, first)
+	}
+}
+
+func TestFaultInjector_latency(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	injector := NewFaultInjector(Faults{Latency: time.Hour})
+	client := &http.Client{Transport: injector.Wrapper()(http.DefaultTransport)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v, wantErr %v", err, false)
+	}
+
+	//nolint:bodyclose
+	if _, err := client.Do(request); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -1,6 +1,7 @@
 package ocm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,8 +17,14 @@ var (
 // GitLabIdentityProviderClient represents the client used to interact with a GitLab Identity Provider API object.  Machine
 // pools are associated with clusters that are not using hosted control plane.
 type GitLabIdentityProviderClient struct {
-	name       string
-	connection *clustersmgmtv1.IdentityProvidersClient
+	responseStatus
+	requestContext
+
+	name          string
+	mappingMethod string
+	challenge     *bool
+	login         *bool
+	connection    *clustersmgmtv1.IdentityProvidersClient
 }
 
 func NewGitLabIdentityProviderClient(connection *sdk.Connection, name, clusterID string) *GitLabIdentityProviderClient {
@@ -27,26 +34,61 @@ func NewGitLabIdentityProviderClient(connection *sdk.Connection, name, clusterID
 	}
 }
 
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (glc *GitLabIdentityProviderClient) WithContext(ctx context.Context) *GitLabIdentityProviderClient {
+	glc.setContext(ctx)
+
+	return glc
+}
+
+// WithMappingMethod sets the mapping method of the identity providers which are created or updated
+// by the client.  The mapping method is left unchanged if it is empty.
+func (glc *GitLabIdentityProviderClient) WithMappingMethod(mappingMethod string) *GitLabIdentityProviderClient {
+	glc.mappingMethod = mappingMethod
+
+	return glc
+}
+
+// WithLoginOptions sets whether the identity providers which are created or updated by the client may
+// be used by clients which respond to a challenge for credentials, and whether they are offered as a
+// web login option.  An option is left unchanged if it is nil.
+func (glc *GitLabIdentityProviderClient) WithLoginOptions(challenge, login *bool) *GitLabIdentityProviderClient {
+	glc.challenge, glc.login = challenge, login
+
+	return glc
+}
+
 func (glc *GitLabIdentityProviderClient) For(gitLabName string) *clustersmgmtv1.IdentityProviderClient {
 	return glc.connection.IdentityProvider(gitLabName)
 }
 
 func (glc *GitLabIdentityProviderClient) Get() (gitLab *clustersmgmtv1.GitlabIdentityProvider, err error) {
+	idp, err := glc.GetIdentityProvider()
+	if err != nil || idp == nil {
+		return gitLab, err
+	}
+
+	return idp.Gitlab(), nil
+}
+
+// GetIdentityProvider retrieves the identity provider which wraps the gitlab identity provider, along
+// with the options which are common to each type of identity provider.
+func (glc *GitLabIdentityProviderClient) GetIdentityProvider() (idp *clustersmgmtv1.IdentityProvider, err error) {
 	// retrieve the gitlab identity provider from ocm
-	response, err := glc.For(glc.name).Get().Send()
+	response, err := glc.For(glc.name).Get().SendContext(glc.sendContext())
 	if err != nil {
 		if response.Status() == http.StatusNotFound {
-			return gitLab, nil
+			return idp, nil
 		}
 
-		return gitLab, fmt.Errorf("error in get request - %w", err)
+		return idp, fmt.Errorf("error in get request - %w", err)
 	}
 
-	return response.Body().Gitlab(), nil
+	return response.Body(), nil
 }
 
 func (glc *GitLabIdentityProviderClient) Create(builder *clustersmgmtv1.GitlabIdentityProviderBuilder) (gitLab *clustersmgmtv1.GitlabIdentityProvider, err error) {
-	body := clustersmgmtv1.NewIdentityProvider().Gitlab(builder)
+	body := glc.identityProvider(builder)
 
 	// build the object to create
 	object, err := body.Build()
@@ -55,7 +97,9 @@ func (glc *GitLabIdentityProviderClient) Create(builder *clustersmgmtv1.GitlabId
 	}
 
 	// create the gitlab identity provider in ocm
-	response, err := glc.connection.Add().Body(object).Send()
+	response, err := glc.connection.Add().Body(object).SendContext(glc.sendContext())
+	glc.observe(response.Status())
+
 	if err != nil {
 		return gitLab, fmt.Errorf("error in create request - %w", err)
 	}
@@ -64,7 +108,7 @@ func (glc *GitLabIdentityProviderClient) Create(builder *clustersmgmtv1.GitlabId
 }
 
 func (glc *GitLabIdentityProviderClient) Update(builder *clustersmgmtv1.GitlabIdentityProviderBuilder) (gitLab *clustersmgmtv1.GitlabIdentityProvider, err error) {
-	body := clustersmgmtv1.NewIdentityProvider().Gitlab(builder)
+	body := glc.identityProvider(builder)
 
 	// build the object to update
 	object, err := body.Build()
@@ -73,7 +117,9 @@ func (glc *GitLabIdentityProviderClient) Update(builder *clustersmgmtv1.GitlabId
 	}
 
 	// update the gitlab identity provider in ocm
-	response, err := glc.For(object.ID()).Update().Body(object).Send()
+	response, err := glc.For(object.ID()).Update().Body(object).SendContext(glc.sendContext())
+	glc.observe(response.Status())
+
 	if err != nil {
 		return gitLab, fmt.Errorf("error in update request - %w", err)
 	}
@@ -83,7 +129,9 @@ func (glc *GitLabIdentityProviderClient) Update(builder *clustersmgmtv1.GitlabId
 
 func (glc *GitLabIdentityProviderClient) Delete(id string) error {
 	// delete the gitlab identity provider in ocm
-	response, err := glc.For(id).Delete().Send()
+	response, err := glc.For(id).Delete().SendContext(glc.sendContext())
+	glc.observe(response.Status())
+
 	if err != nil {
 		if response.Status() == http.StatusNotFound {
 			return nil
@@ -94,3 +142,24 @@ func (glc *GitLabIdentityProviderClient) Delete(id string) error {
 
 	return nil
 }
+
+// identityProvider returns the identity provider which wraps a gitlab identity provider.
+func (glc *GitLabIdentityProviderClient) identityProvider(
+	builder *clustersmgmtv1.GitlabIdentityProviderBuilder,
+) *clustersmgmtv1.IdentityProviderBuilder {
+	body := clustersmgmtv1.NewIdentityProvider().Gitlab(builder)
+
+	if glc.mappingMethod != "" {
+		body.MappingMethod(clustersmgmtv1.IdentityProviderMappingMethod(glc.mappingMethod))
+	}
+
+	if glc.challenge != nil {
+		body.Challenge(*glc.challenge)
+	}
+
+	if glc.login != nil {
+		body.Login(*glc.login)
+	}
+
+	return body
+}
@@ -1,6 +1,7 @@
 package ocm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -20,10 +21,28 @@ var (
 // IdentityProviderClient represents the client used to interact with a  Identity Provider API object.  Machine
 // pools are associated with clusters that are not using hosted control plane.
 type IdentityProviderClient struct {
+	responseStatus
+	requestContext
+
 	name       string
 	connection *clustersmgmtv1.IdentityProvidersClient
 }
 
+// LoginOptions returns whether an identity provider may be used by clients which respond to a challenge
+// for credentials, and whether it is offered as a web login option.  An option which is not set in
+// OpenShift Cluster Manager is returned as nil.
+func LoginOptions(idp *clustersmgmtv1.IdentityProvider) (challenge, login *bool) {
+	if value, ok := idp.GetChallenge(); ok {
+		challenge = &value
+	}
+
+	if value, ok := idp.GetLogin(); ok {
+		login = &value
+	}
+
+	return challenge, login
+}
+
 func NewIdentityProviderClient(connection *sdk.Connection, name, clusterID string) *IdentityProviderClient {
 	return &IdentityProviderClient{
 		name:       name,
@@ -31,13 +50,20 @@ func NewIdentityProviderClient(connection *sdk.Connection, name, clusterID strin
 	}
 }
 
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (idpClient *IdentityProviderClient) WithContext(ctx context.Context) *IdentityProviderClient {
+	idpClient.setContext(ctx)
+
+	return idpClient
+}
+
 func (idpClient *IdentityProviderClient) For(id string) *clustersmgmtv1.IdentityProviderClient {
 	return idpClient.connection.IdentityProvider(id)
 }
 
 func (idpClient *IdentityProviderClient) Get() (idp *clustersmgmtv1.IdentityProvider, err error) {
 	// retrieve the identity provider from ocm
-	response, err := idpClient.connection.List().Send()
+	response, err := idpClient.connection.List().SendContext(idpClient.sendContext())
 	if err != nil {
 		return idp, fmt.Errorf("error in get request - %w", err)
 	}
@@ -53,6 +79,37 @@ func (idpClient *IdentityProviderClient) Get() (idp *clustersmgmtv1.IdentityProv
 	return idp, nil
 }
 
+// GetByID retrieves an identity provider of the cluster by its id.  A nil identity provider and nil
+// error are returned if the identity provider does not exist.
+func (idpClient *IdentityProviderClient) GetByID(id string) (idp *clustersmgmtv1.IdentityProvider, err error) {
+	response, err := idpClient.For(id).Get().SendContext(idpClient.sendContext())
+	if err != nil {
+		if response.Status() == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return idp, fmt.Errorf("error in get request - %w", err)
+	}
+
+	return response.Body(), nil
+}
+
+// List lists all of the identity providers for the cluster.
+func (idpClient *IdentityProviderClient) List() (identityProviders []*clustersmgmtv1.IdentityProvider, err error) {
+	for page := 1; ; page++ {
+		response, err := idpClient.connection.List().Page(page).Size(listPageSize).SendContext(idpClient.sendContext())
+		if err != nil {
+			return identityProviders, fmt.Errorf("error in list request - %w", err)
+		}
+
+		identityProviders = append(identityProviders, response.Items().Slice()...)
+
+		if response.Size() < listPageSize {
+			return identityProviders, nil
+		}
+	}
+}
+
 func (idpClient *IdentityProviderClient) Create(builder *clustersmgmtv1.IdentityProviderBuilder) (gitLab *clustersmgmtv1.IdentityProvider, err error) {
 	// build the object to create
 	object, err := builder.Build()
@@ -61,7 +118,11 @@ func (idpClient *IdentityProviderClient) Create(builder *clustersmgmtv1.Identity
 	}
 
 	// create the identity provider in ocm
-	response, err := idpClient.connection.Add().Body(object).Send()
+	response, err := idpClient.connection.Add().Body(object).SendContext(idpClient.sendContext())
+	idpClient.observe(response.Status())
+
+	// identity providers carry no marker of the object which created them, so an identity provider which
+	// exists after an indeterminate error may not be claimed as created by this request
 	if err != nil {
 		return gitLab, fmt.Errorf("error in create request - %w", err)
 	}
@@ -77,7 +138,9 @@ func (idpClient *IdentityProviderClient) Update(builder *clustersmgmtv1.Identity
 	}
 
 	// update the identity provider in ocm
-	response, err := idpClient.For(object.ID()).Update().Body(object).Send()
+	response, err := idpClient.For(object.ID()).Update().Body(object).SendContext(idpClient.sendContext())
+	idpClient.observe(response.Status())
+
 	if err != nil {
 		return gitLab, fmt.Errorf("error in update request - %w", err)
 	}
@@ -87,7 +150,9 @@ func (idpClient *IdentityProviderClient) Update(builder *clustersmgmtv1.Identity
 
 func (idpClient *IdentityProviderClient) Delete(id string) error {
 	// delete the identity provider in ocm
-	response, err := idpClient.For(id).Delete().Send()
+	response, err := idpClient.For(id).Delete().SendContext(idpClient.sendContext())
+	idpClient.observe(response.Status())
+
 	if err != nil {
 		if response.Status() == http.StatusNotFound {
 			return nil
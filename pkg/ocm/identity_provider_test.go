@@ -0,0 +1,30 @@
+package ocm
+
+import (
+	"testing"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestLoginOptions(t *testing.T) {
+	t.Parallel()
+
+	unset, err := clustersmgmtv1.NewIdentityProvider().Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if challenge, login := LoginOptions(unset); challenge != nil || login != nil {
+		t.Errorf("LoginOptions() = %v, %v, want %v, %v", challenge, login, nil, nil)
+	}
+
+	set, err := clustersmgmtv1.NewIdentityProvider().Challenge(false).Login(true).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	challenge, login := LoginOptions(set)
+	if challenge == nil || *challenge || login == nil || !*login {
+		t.Errorf("LoginOptions() = %v, %v, want %v, %v", challenge, login, false, true)
+	}
+}
@@ -1,14 +1,20 @@
 package ocm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
+// DefaultMachinePoolName is the name of the machine pool which is created along with a cluster which is
+// not using hosted control plane.  The default machine pool may not be deleted.
+const DefaultMachinePoolName = "worker"
+
 var (
 	ErrConvertMachinePool = errors.New("error converting to machine pool object")
 )
@@ -16,24 +22,47 @@ var (
 // MachinePoolClient represents the client used to interact with a Machine Pool API object.  Machine
 // pools are associated with clusters that are not using hosted control plane.
 type MachinePoolClient struct {
+	responseStatus
+	requestContext
+
 	name       string
+	path       string
+	overrides  []byte
+	raw        *sdk.Connection
 	connection *clustersmgmtv1.MachinePoolsClient
 }
 
 func NewMachinePoolClient(connection *sdk.Connection, name, clusterID string) *MachinePoolClient {
 	return &MachinePoolClient{
 		name:       name,
+		path:       fmt.Sprintf(machinePoolsPath, clusterID),
+		raw:        connection,
 		connection: connection.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools(),
 	}
 }
 
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (mpc *MachinePoolClient) WithContext(ctx context.Context) *MachinePoolClient {
+	mpc.setContext(ctx)
+
+	return mpc
+}
+
+// WithOverrides sets the raw overrides which are merged into the body of the create and update requests
+// of the machine pool, so that fields which are not modeled by the operator may be set.
+func (mpc *MachinePoolClient) WithOverrides(overrides []byte) *MachinePoolClient {
+	mpc.overrides = overrides
+
+	return mpc
+}
+
 func (mpc *MachinePoolClient) For(machinePoolName string) *clustersmgmtv1.MachinePoolClient {
 	return mpc.connection.MachinePool(machinePoolName)
 }
 
 func (mpc *MachinePoolClient) Get() (machinePool *clustersmgmtv1.MachinePool, err error) {
 	// retrieve the machine pool from ocm
-	response, err := mpc.For(mpc.name).Get().Send()
+	response, err := mpc.For(mpc.name).Get().SendContext(mpc.sendContext())
 	if err != nil {
 		if response.Status() == http.StatusNotFound {
 			return machinePool, nil
@@ -45,6 +74,22 @@ func (mpc *MachinePoolClient) Get() (machinePool *clustersmgmtv1.MachinePool, er
 	return response.Body(), nil
 }
 
+// List lists all of the machine pools for the cluster.
+func (mpc *MachinePoolClient) List() (machinePools []*clustersmgmtv1.MachinePool, err error) {
+	for page := 1; ; page++ {
+		response, err := mpc.connection.List().Page(page).Size(listPageSize).SendContext(mpc.sendContext())
+		if err != nil {
+			return machinePools, fmt.Errorf("error in list request - %w", err)
+		}
+
+		machinePools = append(machinePools, response.Items().Slice()...)
+
+		if response.Size() < listPageSize {
+			return machinePools, nil
+		}
+	}
+}
+
 func (mpc *MachinePoolClient) Create(builder *clustersmgmtv1.MachinePoolBuilder) (machinePool *clustersmgmtv1.MachinePool, err error) {
 	// build the object to create
 	object, err := builder.Build()
@@ -53,12 +98,37 @@ func (mpc *MachinePoolClient) Create(builder *clustersmgmtv1.MachinePoolBuilder)
 	}
 
 	// create the machine pool in ocm
-	response, err := mpc.connection.Add().Body(object).Send()
+	created, status, err := mpc.add(object)
+	mpc.observe(status)
+
+	// the machine pool is named deterministically and labeled as managed, so a machine pool which
+	// exists after an indeterminate error was created by this request
 	if err != nil {
-		return machinePool, fmt.Errorf("error in create request - %w", err)
+		return recoverCreate(fmt.Errorf("error in create request - %w", err), mpc.Get, func(existing *clustersmgmtv1.MachinePool) bool {
+			return hasManagedLabels(existing.Labels(), object.Labels())
+		})
 	}
 
-	return response.Body(), nil
+	return created, nil
+}
+
+// add sends the request to create the machine pool, with the raw overrides of the client merged into it.
+func (mpc *MachinePoolClient) add(object *clustersmgmtv1.MachinePool) (*clustersmgmtv1.MachinePool, int, error) {
+	if len(mpc.overrides) > 0 {
+		return sendOverridden(
+			mpc.sendContext(),
+			mpc.raw.Post().Path(mpc.path),
+			object,
+			mpc.overrides,
+			clustersmgmtv1.MarshalMachinePool,
+			clustersmgmtv1.UnmarshalMachinePool,
+		)
+	}
+
+	response, err := mpc.connection.Add().Body(object).SendContext(mpc.sendContext())
+
+	//nolint:wrapcheck
+	return response.Body(), response.Status(), err
 }
 
 func (mpc *MachinePoolClient) Update(builder *clustersmgmtv1.MachinePoolBuilder) (machinePool *clustersmgmtv1.MachinePool, err error) {
@@ -69,17 +139,40 @@ func (mpc *MachinePoolClient) Update(builder *clustersmgmtv1.MachinePoolBuilder)
 	}
 
 	// update the machine pool in ocm
-	response, err := mpc.For(object.ID()).Update().Body(object).Send()
+	updated, status, err := mpc.update(object)
+	mpc.observe(status)
+
 	if err != nil {
 		return machinePool, fmt.Errorf("error in update request - %w", err)
 	}
 
-	return response.Body(), nil
+	return updated, nil
+}
+
+// update sends the request to update the machine pool, with the raw overrides of the client merged into it.
+func (mpc *MachinePoolClient) update(object *clustersmgmtv1.MachinePool) (*clustersmgmtv1.MachinePool, int, error) {
+	if len(mpc.overrides) > 0 {
+		return sendOverridden(
+			mpc.sendContext(),
+			mpc.raw.Patch().Path(path.Join(mpc.path, object.ID())),
+			object,
+			mpc.overrides,
+			clustersmgmtv1.MarshalMachinePool,
+			clustersmgmtv1.UnmarshalMachinePool,
+		)
+	}
+
+	response, err := mpc.For(object.ID()).Update().Body(object).SendContext(mpc.sendContext())
+
+	//nolint:wrapcheck
+	return response.Body(), response.Status(), err
 }
 
 func (mpc *MachinePoolClient) Delete(id string) error {
 	// delete the machine pool in ocm
-	response, err := mpc.For(id).Delete().Send()
+	response, err := mpc.For(id).Delete().SendContext(mpc.sendContext())
+	mpc.observe(response.Status())
+
 	if err != nil {
 		if response.Status() == http.StatusNotFound {
 			return nil
@@ -0,0 +1,63 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+type MachineTypeClient struct {
+	requestContext
+
+	Connection *clustersmgmtv1.MachineTypesClient
+}
+
+func NewMachineTypeClient(connection *sdk.Connection) *MachineTypeClient {
+	return &MachineTypeClient{
+		Connection: connection.ClustersMgmt().V1().MachineTypes(),
+	}
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (mtc *MachineTypeClient) WithContext(ctx context.Context) *MachineTypeClient {
+	mtc.setContext(ctx)
+
+	return mtc
+}
+
+// Get retrieves a machine type of a cloud provider from OpenShift Cluster Manager by its id.  A nil
+// machine type is returned when OpenShift Cluster Manager does not offer the machine type for the
+// cloud provider.
+func (mtc *MachineTypeClient) Get(cloudProvider, id string) (machineType *clustersmgmtv1.MachineType, err error) {
+	response, err := mtc.Connection.List().
+		Search(fmt.Sprintf("id = '%s' and cloud_provider.id = '%s'", id, cloudProvider)).
+		Size(1).
+		SendContext(mtc.sendContext())
+	if err != nil {
+		return machineType, fmt.Errorf(
+			"unable to retrieve machine type [%s] for cloud provider [%s] from openshift cluster manager - %w",
+			id,
+			cloudProvider,
+			err,
+		)
+	}
+
+	if response.Size() == 0 {
+		return nil, nil
+	}
+
+	return response.Items().Get(0), nil
+}
+
+// MachineTypeAvailable determines if a machine type is available to the nodes of a cluster.  A machine
+// type which is only offered for customer cloud subscription clusters is unavailable to a cluster which
+// was not installed into a customer cloud subscription.
+func MachineTypeAvailable(machineType *clustersmgmtv1.MachineType, cluster *clustersmgmtv1.Cluster) bool {
+	if machineType == nil {
+		return false
+	}
+
+	return !machineType.CCSOnly() || cluster.CCS().Enabled()
+}
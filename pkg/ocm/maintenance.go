@@ -0,0 +1,131 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+const (
+	// serviceStatusMaintenance is the status of a service in the status board of OpenShift Cluster
+	// Manager which is under maintenance.
+	serviceStatusMaintenance = "maintenance"
+)
+
+var (
+	ErrMaintenance = errors.New("openshift cluster manager is under maintenance")
+)
+
+// Maintenance records whether OpenShift Cluster Manager has announced maintenance, so that controllers
+// may back off without sending requests which would fail during the maintenance, rather than reporting
+// the resulting errors as failures of the objects which they reconcile.  A nil maintenance is never
+// active.
+type Maintenance struct {
+	mutex   sync.RWMutex
+	active  bool
+	message string
+}
+
+// Set records whether maintenance is active, along with the announcement of the maintenance.  It
+// returns whether the maintenance has started or finished.
+func (maintenance *Maintenance) Set(active bool, message string) bool {
+	maintenance.mutex.Lock()
+	defer maintenance.mutex.Unlock()
+
+	changed := maintenance.active != active
+	maintenance.active, maintenance.message = active, message
+
+	return changed
+}
+
+// Active returns whether maintenance is active, along with the announcement of the maintenance.
+func (maintenance *Maintenance) Active() (bool, string) {
+	if maintenance == nil {
+		return false, ""
+	}
+
+	maintenance.mutex.RLock()
+	defer maintenance.mutex.RUnlock()
+
+	return maintenance.active, maintenance.message
+}
+
+// Err returns an error describing the maintenance when it is active, or nil otherwise.
+func (maintenance *Maintenance) Err() error {
+	active, message := maintenance.Active()
+	if !active {
+		return nil
+	}
+
+	return fmt.Errorf("%s - %w", message, ErrMaintenance)
+}
+
+// ProbeMaintenance determines whether OpenShift Cluster Manager is under maintenance.  OpenShift Cluster
+// Manager is under maintenance when its API responds as unavailable, or when any of the named services
+// in its status board report a status of maintenance.  A status board which could not be queried, for
+// example because the operator is not permitted to read it, is not considered to announce maintenance.
+func ProbeMaintenance(ctx context.Context, connection *sdk.Connection, services ...string) (bool, string, error) {
+	response, err := connection.Get().Path(APIClustersMgmt.Path).SendContext(ctx)
+	if err != nil {
+		return false, "", fmt.Errorf("unable to retrieve ocm api metadata - %w", err)
+	}
+
+	if active, message := MaintenanceFromResponse(response.Status(), response.Header); active {
+		return active, message, nil
+	}
+
+	for _, service := range services {
+		list, err := connection.StatusBoard().V1().Services().List().Fullname(service).SendContext(ctx)
+		if err != nil {
+			continue
+		}
+
+		for _, item := range list.Items().Slice() {
+			if InMaintenance(item.CurrentStatus()) {
+				return true, fmt.Sprintf("ocm status board reports service [%s] is under maintenance", item.Fullname()), nil
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// StatusServices returns the full names of the services in the status board of OpenShift Cluster
+// Manager from a comma-separated list.
+func StatusServices(services string) []string {
+	names := []string{}
+
+	for _, name := range strings.Split(services, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// MaintenanceFromResponse determines whether a response from the API of OpenShift Cluster Manager
+// indicates that it is under maintenance, along with the announcement of the maintenance.
+func MaintenanceFromResponse(status int, header func(string) string) (bool, string) {
+	if status != http.StatusServiceUnavailable {
+		return false, ""
+	}
+
+	message := "ocm api is unavailable"
+	if retryAfter := header("Retry-After"); retryAfter != "" {
+		message = fmt.Sprintf("%s, retry after %s", message, retryAfter)
+	}
+
+	return true, message
+}
+
+// InMaintenance determines whether a status reported by a service in the status board of OpenShift
+// Cluster Manager is a status of maintenance.
+func InMaintenance(status string) bool {
+	return strings.EqualFold(strings.TrimSpace(status), serviceStatusMaintenance)
+}
@@ -0,0 +1,109 @@
+package ocm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMaintenanceFromResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      int
+		header      func(string) string
+		wantActive  bool
+		wantMessage string
+	}{
+		{
+			name:       "ensure an available api is not under maintenance",
+			status:     http.StatusOK,
+			header:     func(string) string { return "" },
+			wantActive: false,
+		},
+		{
+			name:        "ensure an unavailable api is under maintenance",
+			status:      http.StatusServiceUnavailable,
+			header:      func(string) string { return "" },
+			wantActive:  true,
+			wantMessage: "ocm api is unavailable",
+		},
+		{
+			name:        "ensure the retry after header is included in the announcement",
+			status:      http.StatusServiceUnavailable,
+			header:      func(name string) string { return map[string]string{"Retry-After": "3600"}[name] },
+			wantActive:  true,
+			wantMessage: "ocm api is unavailable, retry after 3600",
+		},
+		{
+			name:       "ensure a server error is not under maintenance",
+			status:     http.StatusInternalServerError,
+			header:     func(string) string { return "" },
+			wantActive: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			active, message := MaintenanceFromResponse(tt.status, tt.header)
+			if active != tt.wantActive || message != tt.wantMessage {
+				t.Errorf("MaintenanceFromResponse() = %v, %q, want %v, %q", active, message, tt.wantActive, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestInMaintenance(t *testing.T) {
+	t.Parallel()
+
+	for status, want := range map[string]bool{
+		"ok":          false,
+		"degraded":    false,
+		"maintenance": true,
+		"Maintenance": true,
+	} {
+		if got := InMaintenance(status); got != want {
+			t.Errorf("InMaintenance(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestStatusServices(t *testing.T) {
+	t.Parallel()
+
+	got := StatusServices(" ocm-api, ,service-log ")
+	if len(got) != 2 || got[0] != "ocm-api" || got[1] != "service-log" {
+		t.Errorf("StatusServices() = %v, want %v", got, []string{"ocm-api", "service-log"})
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	t.Parallel()
+
+	var unset *Maintenance
+	if err := unset.Err(); err != nil {
+		t.Errorf("Maintenance.Err() error = %v, want %v", err, nil)
+	}
+
+	maintenance := &Maintenance{}
+	if changed := maintenance.Set(true, "ocm api is unavailable"); !changed {
+		t.Errorf("Maintenance.Set() changed = %v, want %v", changed, true)
+	}
+
+	if changed := maintenance.Set(true, "ocm api is unavailable"); changed {
+		t.Errorf("Maintenance.Set() changed = %v, want %v", changed, false)
+	}
+
+	if err := maintenance.Err(); !errors.Is(err, ErrMaintenance) {
+		t.Errorf("Maintenance.Err() error = %v, want %v", err, ErrMaintenance)
+	}
+
+	maintenance.Set(false, "")
+
+	if err := maintenance.Err(); err != nil {
+		t.Errorf("Maintenance.Err() error = %v, want %v", err, nil)
+	}
+}
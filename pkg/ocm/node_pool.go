@@ -1,9 +1,11 @@
 package ocm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -16,24 +18,47 @@ var (
 // NodePoolClient represents the client used to interact with a Node Pool API object.  Node
 // pools are associated with clusters that are using hosted control plane.
 type NodePoolClient struct {
+	responseStatus
+	requestContext
+
 	name       string
+	path       string
+	overrides  []byte
+	raw        *sdk.Connection
 	connection *clustersmgmtv1.NodePoolsClient
 }
 
 func NewNodePoolClient(connection *sdk.Connection, name, clusterID string) *NodePoolClient {
 	return &NodePoolClient{
 		name:       name,
+		path:       fmt.Sprintf(nodePoolsPath, clusterID),
+		raw:        connection,
 		connection: connection.ClustersMgmt().V1().Clusters().Cluster(clusterID).NodePools(),
 	}
 }
 
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (npc *NodePoolClient) WithContext(ctx context.Context) *NodePoolClient {
+	npc.setContext(ctx)
+
+	return npc
+}
+
+// WithOverrides sets the raw overrides which are merged into the body of the create and update requests
+// of the node pool, so that fields which are not modeled by the operator may be set.
+func (npc *NodePoolClient) WithOverrides(overrides []byte) *NodePoolClient {
+	npc.overrides = overrides
+
+	return npc
+}
+
 func (npc *NodePoolClient) For(nodePoolName string) *clustersmgmtv1.NodePoolClient {
 	return npc.connection.NodePool(nodePoolName)
 }
 
 func (npc *NodePoolClient) Get() (nodePool *clustersmgmtv1.NodePool, err error) {
 	// retrieve the node pool from ocm
-	response, err := npc.For(npc.name).Get().Send()
+	response, err := npc.For(npc.name).Get().SendContext(npc.sendContext())
 	if err != nil {
 		if response.Status() == http.StatusNotFound {
 			return nodePool, nil
@@ -45,6 +70,22 @@ func (npc *NodePoolClient) Get() (nodePool *clustersmgmtv1.NodePool, err error)
 	return response.Body(), nil
 }
 
+// List lists all of the node pools for the cluster.
+func (npc *NodePoolClient) List() (nodePools []*clustersmgmtv1.NodePool, err error) {
+	for page := 1; ; page++ {
+		response, err := npc.connection.List().Page(page).Size(listPageSize).SendContext(npc.sendContext())
+		if err != nil {
+			return nodePools, fmt.Errorf("error in list request - %w", err)
+		}
+
+		nodePools = append(nodePools, response.Items().Slice()...)
+
+		if response.Size() < listPageSize {
+			return nodePools, nil
+		}
+	}
+}
+
 func (npc *NodePoolClient) Create(builder *clustersmgmtv1.NodePoolBuilder) (nodePool *clustersmgmtv1.NodePool, err error) {
 	// build the object to create
 	object, err := builder.Build()
@@ -53,12 +94,37 @@ func (npc *NodePoolClient) Create(builder *clustersmgmtv1.NodePoolBuilder) (node
 	}
 
 	// create the node pool in ocm
-	response, err := npc.connection.Add().Body(object).Send()
+	created, status, err := npc.add(object)
+	npc.observe(status)
+
+	// the node pool is named deterministically and labeled as managed, so a node pool which exists
+	// after an indeterminate error was created by this request
 	if err != nil {
-		return nodePool, fmt.Errorf("error in create request - %w", err)
+		return recoverCreate(fmt.Errorf("error in create request - %w", err), npc.Get, func(existing *clustersmgmtv1.NodePool) bool {
+			return hasManagedLabels(existing.Labels(), object.Labels())
+		})
 	}
 
-	return response.Body(), nil
+	return created, nil
+}
+
+// add sends the request to create the node pool, with the raw overrides of the client merged into it.
+func (npc *NodePoolClient) add(object *clustersmgmtv1.NodePool) (*clustersmgmtv1.NodePool, int, error) {
+	if len(npc.overrides) > 0 {
+		return sendOverridden(
+			npc.sendContext(),
+			npc.raw.Post().Path(npc.path),
+			object,
+			npc.overrides,
+			clustersmgmtv1.MarshalNodePool,
+			clustersmgmtv1.UnmarshalNodePool,
+		)
+	}
+
+	response, err := npc.connection.Add().Body(object).SendContext(npc.sendContext())
+
+	//nolint:wrapcheck
+	return response.Body(), response.Status(), err
 }
 
 func (npc *NodePoolClient) Update(builder *clustersmgmtv1.NodePoolBuilder) (nodePool *clustersmgmtv1.NodePool, err error) {
@@ -69,17 +135,40 @@ func (npc *NodePoolClient) Update(builder *clustersmgmtv1.NodePoolBuilder) (node
 	}
 
 	// update the node pool in ocm
-	response, err := npc.For(object.ID()).Update().Body(object).Send()
+	updated, status, err := npc.update(object)
+	npc.observe(status)
+
 	if err != nil {
 		return nodePool, fmt.Errorf("error in update request - %w", err)
 	}
 
-	return response.Body(), nil
+	return updated, nil
+}
+
+// update sends the request to update the node pool, with the raw overrides of the client merged into it.
+func (npc *NodePoolClient) update(object *clustersmgmtv1.NodePool) (*clustersmgmtv1.NodePool, int, error) {
+	if len(npc.overrides) > 0 {
+		return sendOverridden(
+			npc.sendContext(),
+			npc.raw.Patch().Path(path.Join(npc.path, object.ID())),
+			object,
+			npc.overrides,
+			clustersmgmtv1.MarshalNodePool,
+			clustersmgmtv1.UnmarshalNodePool,
+		)
+	}
+
+	response, err := npc.For(object.ID()).Update().Body(object).SendContext(npc.sendContext())
+
+	//nolint:wrapcheck
+	return response.Body(), response.Status(), err
 }
 
 func (npc *NodePoolClient) Delete(id string) error {
 	// delete the node pool in ocm
-	response, err := npc.For(id).Delete().Send()
+	response, err := npc.For(id).Delete().SendContext(npc.sendContext())
+	npc.observe(response.Status())
+
 	if err != nil {
 		if response.Status() == http.StatusNotFound {
 			return nil
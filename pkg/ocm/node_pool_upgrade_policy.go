@@ -0,0 +1,204 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+const (
+	nodePoolUpgradePoliciesPath = "/api/clusters_mgmt/v1/clusters/%s/node_pools/%s/upgrade_policies"
+
+	nodePoolUpgradeScheduleType = "manual"
+	nodePoolUpgradeType         = "NodePool"
+)
+
+// Upgrade policy states which are reported by OpenShift Cluster Manager.  An upgrade policy in any
+// other state has not yet finished.
+const (
+	UpgradePolicyStatePending   = "pending"
+	UpgradePolicyStateScheduled = "scheduled"
+	UpgradePolicyStateCompleted = "completed"
+	UpgradePolicyStateFailed    = "failed"
+	UpgradePolicyStateCancelled = "cancelled"
+)
+
+var (
+	ErrNodePoolUpgradePolicyResponse = errors.New("invalid node pool upgrade policy response")
+)
+
+// NodePoolUpgradePolicyClient represents the client used to interact with the upgrade policies of
+// a node pool.  The node pool upgrade policies API is not yet exposed by the SDK so the requests
+// are sent using the raw connection.
+type NodePoolUpgradePolicyClient struct {
+	responseStatus
+	requestContext
+
+	path       string
+	connection *sdk.Connection
+}
+
+// NodePoolUpgradePolicy represents an upgrade of a node pool which has been scheduled in OpenShift
+// Cluster Manager.
+type NodePoolUpgradePolicy struct {
+	ID           string                      `json:"id,omitempty"`
+	Version      string                      `json:"version,omitempty"`
+	ScheduleType string                      `json:"schedule_type,omitempty"`
+	UpgradeType  string                      `json:"upgrade_type,omitempty"`
+	NextRun      time.Time                   `json:"next_run"`
+	State        *NodePoolUpgradePolicyState `json:"state,omitempty"`
+}
+
+// NodePoolUpgradePolicyState represents the progress of a node pool upgrade.
+type NodePoolUpgradePolicyState struct {
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type nodePoolUpgradePolicyList struct {
+	Items []*NodePoolUpgradePolicy `json:"items"`
+}
+
+func NewNodePoolUpgradePolicyClient(connection *sdk.Connection, clusterID, nodePoolID string) *NodePoolUpgradePolicyClient {
+	return &NodePoolUpgradePolicyClient{
+		path:       fmt.Sprintf(nodePoolUpgradePoliciesPath, clusterID, nodePoolID),
+		connection: connection,
+	}
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (npupc *NodePoolUpgradePolicyClient) WithContext(ctx context.Context) *NodePoolUpgradePolicyClient {
+	npupc.setContext(ctx)
+
+	return npupc
+}
+
+// Finished determines if the upgrade policy is no longer progressing.  An upgrade policy which does
+// not report a state has not yet started.
+func (policy *NodePoolUpgradePolicy) Finished() bool {
+	if policy.State == nil {
+		return false
+	}
+
+	switch policy.State.Value {
+	case UpgradePolicyStateCompleted, UpgradePolicyStateFailed, UpgradePolicyStateCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Started determines if the upgrade policy has started to upgrade the node pool.  An upgrade policy
+// which does not report a state, or which is pending or scheduled, has not yet started.
+func (policy *NodePoolUpgradePolicy) Started() bool {
+	if policy.State == nil {
+		return false
+	}
+
+	switch policy.State.Value {
+	case "", UpgradePolicyStatePending, UpgradePolicyStateScheduled:
+		return false
+	default:
+		return !policy.Finished()
+	}
+}
+
+// PendingNodePoolUpgrade returns the first upgrade policy from a list of upgrade policies which has not
+// yet finished.  A nil upgrade policy is returned if all of the upgrade policies have finished.
+func PendingNodePoolUpgrade(policies []*NodePoolUpgradePolicy) *NodePoolUpgradePolicy {
+	for _, policy := range policies {
+		if !policy.Finished() {
+			return policy
+		}
+	}
+
+	return nil
+}
+
+// FailedNodePoolUpgrade returns the most recently scheduled upgrade policy from a list of upgrade policies
+// which failed to upgrade the node pool to a version.  A nil upgrade policy is returned if no upgrade to
+// the version has failed.
+func FailedNodePoolUpgrade(policies []*NodePoolUpgradePolicy, version string) *NodePoolUpgradePolicy {
+	var failed *NodePoolUpgradePolicy
+
+	for _, policy := range policies {
+		if policy.Version != version || policy.State == nil || policy.State.Value != UpgradePolicyStateFailed {
+			continue
+		}
+
+		if failed == nil || policy.NextRun.After(failed.NextRun) {
+			failed = policy
+		}
+	}
+
+	return failed
+}
+
+func (npupc *NodePoolUpgradePolicyClient) List() (policies []*NodePoolUpgradePolicy, err error) {
+	// retrieve the upgrade policies from ocm
+	response, err := npupc.connection.Get().Path(npupc.path).SendContext(npupc.sendContext())
+	if err != nil {
+		return policies, fmt.Errorf("error in list request - %w", err)
+	}
+
+	if response.Status() != http.StatusOK {
+		return policies, fmt.Errorf(
+			"error in list request [status=%d, body=%s] - %w",
+			response.Status(),
+			response.String(),
+			ErrNodePoolUpgradePolicyResponse,
+		)
+	}
+
+	list := &nodePoolUpgradePolicyList{}
+	if err := json.Unmarshal(response.Bytes(), list); err != nil {
+		return policies, fmt.Errorf("unable to unmarshal node pool upgrade policies - %w", err)
+	}
+
+	return list.Items, nil
+}
+
+// Create schedules an upgrade of the node pool to a version at a particular point in time.
+func (npupc *NodePoolUpgradePolicyClient) Create(version string, nextRun time.Time) (policy *NodePoolUpgradePolicy, err error) {
+	body, err := json.Marshal(&NodePoolUpgradePolicy{
+		Version:      version,
+		ScheduleType: nodePoolUpgradeScheduleType,
+		UpgradeType:  nodePoolUpgradeType,
+		NextRun:      nextRun.UTC(),
+	})
+	if err != nil {
+		return policy, fmt.Errorf("unable to build object for node pool upgrade policy creation - %w", err)
+	}
+
+	// create the upgrade policy in ocm
+	response, err := npupc.connection.Post().Path(npupc.path).Header("Content-Type", "application/json").Bytes(body).SendContext(npupc.sendContext())
+	if err != nil {
+		// the raw response is not returned when the request could not be sent
+		npupc.observe(0)
+
+		return policy, fmt.Errorf("error in create request - %w", err)
+	}
+
+	npupc.observe(response.Status())
+
+	if response.Status() != http.StatusCreated && response.Status() != http.StatusOK {
+		return policy, fmt.Errorf(
+			"error in create request [status=%d, body=%s] - %w",
+			response.Status(),
+			response.String(),
+			ErrNodePoolUpgradePolicyResponse,
+		)
+	}
+
+	policy = &NodePoolUpgradePolicy{}
+	if err := json.Unmarshal(response.Bytes(), policy); err != nil {
+		return policy, fmt.Errorf("unable to unmarshal node pool upgrade policy - %w", err)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,104 @@
+package ocm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPendingNodePoolUpgrade(t *testing.T) {
+	t.Parallel()
+
+	completed := &NodePoolUpgradePolicy{ID: "completed", State: &NodePoolUpgradePolicyState{Value: UpgradePolicyStateCompleted}}
+	cancelled := &NodePoolUpgradePolicy{ID: "cancelled", State: &NodePoolUpgradePolicyState{Value: UpgradePolicyStateCancelled}}
+	started := &NodePoolUpgradePolicy{ID: "started", State: &NodePoolUpgradePolicyState{Value: "started"}}
+	unknown := &NodePoolUpgradePolicy{ID: "unknown"}
+
+	tests := []struct {
+		name     string
+		policies []*NodePoolUpgradePolicy
+		want     *NodePoolUpgradePolicy
+	}{
+		{
+			name:     "ensure no upgrade is pending without upgrade policies",
+			policies: nil,
+			want:     nil,
+		},
+		{
+			name:     "ensure no upgrade is pending when all upgrade policies have finished",
+			policies: []*NodePoolUpgradePolicy{completed, cancelled},
+			want:     nil,
+		},
+		{
+			name:     "ensure a started upgrade is pending",
+			policies: []*NodePoolUpgradePolicy{completed, started},
+			want:     started,
+		},
+		{
+			name:     "ensure an upgrade without a state is pending",
+			policies: []*NodePoolUpgradePolicy{unknown},
+			want:     unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := PendingNodePoolUpgrade(tt.policies); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PendingNodePoolUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailedNodePoolUpgrade(t *testing.T) {
+	t.Parallel()
+
+	failed := func(id, version string, nextRun time.Time) *NodePoolUpgradePolicy {
+		return &NodePoolUpgradePolicy{
+			ID:      id,
+			Version: version,
+			NextRun: nextRun,
+			State:   &NodePoolUpgradePolicyState{Value: UpgradePolicyStateFailed},
+		}
+	}
+
+	now := time.Now()
+	earlier := failed("earlier", "4.14.2", now.Add(-time.Hour))
+	latest := failed("latest", "4.14.2", now)
+	other := failed("other", "4.14.1", now)
+	completed := &NodePoolUpgradePolicy{ID: "completed", Version: "4.14.2", State: &NodePoolUpgradePolicyState{Value: UpgradePolicyStateCompleted}}
+
+	tests := []struct {
+		name     string
+		policies []*NodePoolUpgradePolicy
+		want     *NodePoolUpgradePolicy
+	}{
+		{
+			name:     "ensure no upgrade has failed without upgrade policies",
+			policies: nil,
+			want:     nil,
+		},
+		{
+			name:     "ensure upgrades which did not fail or are to another version are ignored",
+			policies: []*NodePoolUpgradePolicy{completed, other},
+			want:     nil,
+		},
+		{
+			name:     "ensure the most recently scheduled failed upgrade is returned",
+			policies: []*NodePoolUpgradePolicy{latest, earlier},
+			want:     latest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FailedNodePoolUpgrade(tt.policies, "4.14.2"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FailedNodePoolUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
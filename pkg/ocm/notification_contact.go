@@ -0,0 +1,145 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+const (
+	notificationContactsPath = "/api/accounts_mgmt/v1/subscriptions/%s/notification_contacts"
+)
+
+var (
+	ErrNotificationContactResponse = errors.New("invalid notification contact response")
+)
+
+// NotificationContactClient represents the client used to interact with the notification contacts
+// of a cluster subscription.  The notification contacts API is not yet exposed by the SDK so the
+// requests are sent using the raw connection.
+type NotificationContactClient struct {
+	responseStatus
+	requestContext
+
+	path       string
+	connection *sdk.Connection
+}
+
+type notificationContactList struct {
+	Items []json.RawMessage `json:"items"`
+}
+
+type notificationContactRequest struct {
+	AccountIdentifier string `json:"account_identifier"`
+}
+
+func NewNotificationContactClient(connection *sdk.Connection, subscriptionID string) *NotificationContactClient {
+	return &NotificationContactClient{
+		path:       fmt.Sprintf(notificationContactsPath, subscriptionID),
+		connection: connection,
+	}
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (ncc *NotificationContactClient) WithContext(ctx context.Context) *NotificationContactClient {
+	ncc.setContext(ctx)
+
+	return ncc
+}
+
+func (ncc *NotificationContactClient) List() (contacts []*accountsmgmtv1.Account, err error) {
+	// retrieve the notification contacts from ocm
+	response, err := ncc.connection.Get().Path(ncc.path).SendContext(ncc.sendContext())
+	if err != nil {
+		return contacts, fmt.Errorf("error in list request - %w", err)
+	}
+
+	if response.Status() != http.StatusOK {
+		return contacts, fmt.Errorf(
+			"error in list request [status=%d, body=%s] - %w",
+			response.Status(),
+			response.String(),
+			ErrNotificationContactResponse,
+		)
+	}
+
+	list := &notificationContactList{}
+	if err := json.Unmarshal(response.Bytes(), list); err != nil {
+		return contacts, fmt.Errorf("unable to unmarshal notification contacts - %w", err)
+	}
+
+	for _, item := range list.Items {
+		contact, err := accountsmgmtv1.UnmarshalAccount([]byte(item))
+		if err != nil {
+			return contacts, fmt.Errorf("unable to unmarshal notification contact - %w", err)
+		}
+
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, nil
+}
+
+func (ncc *NotificationContactClient) Create(accountIdentifier string) (contact *accountsmgmtv1.Account, err error) {
+	body, err := json.Marshal(&notificationContactRequest{AccountIdentifier: accountIdentifier})
+	if err != nil {
+		return contact, fmt.Errorf("unable to build object for notification contact creation - %w", err)
+	}
+
+	// create the notification contact in ocm
+	response, err := ncc.connection.Post().Path(ncc.path).Header("Content-Type", "application/json").Bytes(body).SendContext(ncc.sendContext())
+	if err != nil {
+		// the raw response is not returned when the request could not be sent
+		ncc.observe(0)
+
+		return contact, fmt.Errorf("error in create request - %w", err)
+	}
+
+	ncc.observe(response.Status())
+
+	if response.Status() != http.StatusCreated && response.Status() != http.StatusOK {
+		return contact, fmt.Errorf(
+			"error in create request [status=%d, body=%s] - %w",
+			response.Status(),
+			response.String(),
+			ErrNotificationContactResponse,
+		)
+	}
+
+	contact, err = accountsmgmtv1.UnmarshalAccount(response.Bytes())
+	if err != nil {
+		return contact, fmt.Errorf("unable to unmarshal notification contact - %w", err)
+	}
+
+	return contact, nil
+}
+
+func (ncc *NotificationContactClient) Delete(accountID string) error {
+	// delete the notification contact in ocm
+	response, err := ncc.connection.Delete().Path(fmt.Sprintf("%s/%s", ncc.path, accountID)).SendContext(ncc.sendContext())
+	if err != nil {
+		// the raw response is not returned when the request could not be sent
+		ncc.observe(0)
+
+		return fmt.Errorf("error in delete request - %w", err)
+	}
+
+	ncc.observe(response.Status())
+
+	switch response.Status() {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf(
+			"error in delete request [status=%d, body=%s] - %w",
+			response.Status(),
+			response.String(),
+			ErrNotificationContactResponse,
+		)
+	}
+}
@@ -1,6 +1,47 @@
 package ocm
 
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
 const (
 	LabelPrefixManaged = "ocm.mobb.redhat.com/managed"
 	LabelPrefixName    = "ocm.mobb.redhat.com/name"
 )
+
+const (
+	listPageSize = 100
+)
+
+// ReservedAWSTagPrefixes are the prefixes of AWS tags which are reserved for use by AWS, Red Hat
+// and the cluster itself.
+var ReservedAWSTagPrefixes = []string{
+	"aws:",
+	"red-hat-",
+	"kubernetes.io/cluster/",
+	"sigs.k8s.io/cluster-api-provider-aws/",
+}
+
+// IsReservedAWSTag determines if an AWS tag uses a reserved prefix.
+func IsReservedAWSTag(key string) bool {
+	for _, prefix := range ReservedAWSTagPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckConnection checks that a connection is able to authenticate with OpenShift Cluster Manager.
+func CheckConnection(ctx context.Context, connection *sdk.Connection) error {
+	if _, err := connection.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx); err != nil {
+		return fmt.Errorf("unable to retrieve current account from ocm - %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var (
+	ErrClusterOrganization = errors.New("cluster does not belong to an allowed organization")
+	ErrMissingSubscription = errors.New("missing subscription id for cluster")
+)
+
+// OrganizationGuard ensures that a cluster belongs to an allowed OpenShift Cluster Manager organization
+// before it is managed, which prevents accidental management of clusters with look-alike names when the
+// operator is authenticated in a shared account context.
+type OrganizationGuard struct {
+	Connection *sdk.Connection
+
+	// Allowed are the ids of the organizations which clusters may belong to.  When this is empty, clusters
+	// must belong to the organization of the account which the operator is authenticated as.
+	Allowed []string
+
+	mutex   sync.Mutex
+	allowed []string
+}
+
+// NewOrganizationGuard returns a guard which allows clusters which belong to a comma-separated list of
+// organization ids.  When the list is empty, only clusters which belong to the organization of the
+// account which the operator is authenticated as are allowed.
+func NewOrganizationGuard(connection *sdk.Connection, allowed string) *OrganizationGuard {
+	guard := &OrganizationGuard{Connection: connection}
+
+	for _, id := range strings.Split(allowed, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			guard.Allowed = append(guard.Allowed, id)
+		}
+	}
+
+	return guard
+}
+
+// Check checks that a cluster belongs to an allowed organization.  A nil guard allows all clusters.
+func (guard *OrganizationGuard) Check(ctx context.Context, cluster *clustersmgmtv1.Cluster) error {
+	if guard == nil {
+		return nil
+	}
+
+	allowed, err := guard.allowedOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscriptionID := cluster.Subscription().ID()
+	if subscriptionID == "" {
+		return fmt.Errorf("cluster [%s] - %w", cluster.Name(), ErrMissingSubscription)
+	}
+
+	response, err := guard.Connection.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Get().SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve subscription [%s] from ocm - %w", subscriptionID, err)
+	}
+
+	return checkOrganization(cluster.Name(), response.Body().OrganizationID(), allowed)
+}
+
+// CheckSubscription checks that the subscription of a registered cluster belongs to an allowed
+// organization.  A nil guard allows all subscriptions.
+func (guard *OrganizationGuard) CheckSubscription(ctx context.Context, subscription *accountsmgmtv1.Subscription) error {
+	if guard == nil {
+		return nil
+	}
+
+	allowed, err := guard.allowedOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+
+	return checkOrganization(subscription.DisplayName(), subscription.OrganizationID(), allowed)
+}
+
+// checkOrganization checks that the organization of a cluster is one of the allowed organizations.
+func checkOrganization(clusterName, organizationID string, allowed []string) error {
+	for _, id := range allowed {
+		if id == organizationID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"cluster [%s] belongs to organization [%s] but only organizations [%s] are allowed - %w",
+		clusterName,
+		organizationID,
+		strings.Join(allowed, ","),
+		ErrClusterOrganization,
+	)
+}
+
+// allowedOrganizations returns the ids of the organizations which clusters may belong to.  The
+// organization of the account which the operator is authenticated as is only retrieved once.
+func (guard *OrganizationGuard) allowedOrganizations(ctx context.Context) ([]string, error) {
+	if len(guard.Allowed) > 0 {
+		return guard.Allowed, nil
+	}
+
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	if len(guard.allowed) > 0 {
+		return guard.allowed, nil
+	}
+
+	account, err := GetAccount(ctx, guard.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.OrganizationID == "" {
+		return nil, fmt.Errorf("account [%s] does not belong to an organization - %w", account.Username, ErrClusterOrganization)
+	}
+
+	guard.allowed = []string{account.OrganizationID}
+
+	return guard.allowed, nil
+}
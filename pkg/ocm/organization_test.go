@@ -0,0 +1,138 @@
+package ocm
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// testConnection returns a connection to a fake openshift cluster manager which returns the current
+// account with an organization and subscriptions of a given organization.
+func testConnection(t *testing.T, accountOrganization, subscriptionOrganization string) *sdk.Connection {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/accounts_mgmt/v1/current_account":
+			fmt.Fprintf(w, `{"kind":"Account","username":"test","organization":{"id":%q,"name":"test"}}`, accountOrganization)
+		case "/api/accounts_mgmt/v1/subscriptions/subscription":
+			fmt.Fprintf(w, `{"kind":"Subscription","id":"subscription","organization_id":%q}`, subscriptionOrganization)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	token := encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." +
+		encode([]byte(fmt.Sprintf(`{"typ":"Bearer","exp":%d}`, time.Now().Add(time.Hour).Unix()))) + "."
+
+	connection, err := sdk.NewConnectionBuilder().URL(server.URL).Tokens(token).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", err, false)
+	}
+
+	t.Cleanup(func() { connection.Close() })
+
+	return connection
+}
+
+func TestNewOrganizationGuard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		allowed string
+		want    []string
+	}{
+		{
+			name:    "ensure an empty list allows no explicit organizations",
+			allowed: "",
+			want:    nil,
+		},
+		{
+			name:    "ensure a list of organizations is parsed",
+			allowed: "first, second,,",
+			want:    []string{"first", "second"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewOrganizationGuard(nil, tt.allowed).Allowed; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewOrganizationGuard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrganizationGuard_Check(t *testing.T) {
+	t.Parallel()
+
+	cluster, err := clustersmgmtv1.NewCluster().
+		Name("test").
+		Subscription(clustersmgmtv1.NewSubscription().ID("subscription")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", err, false)
+	}
+
+	tests := []struct {
+		name                     string
+		allowed                  string
+		accountOrganization      string
+		subscriptionOrganization string
+		wantErr                  error
+	}{
+		{
+			name:                     "ensure a cluster in the organization of the account is allowed",
+			accountOrganization:      "mine",
+			subscriptionOrganization: "mine",
+		},
+		{
+			name:                     "ensure a cluster outside of the organization of the account is rejected",
+			accountOrganization:      "mine",
+			subscriptionOrganization: "theirs",
+			wantErr:                  ErrClusterOrganization,
+		},
+		{
+			name:                     "ensure a cluster in an allowed organization is allowed",
+			allowed:                  "mine,theirs",
+			accountOrganization:      "mine",
+			subscriptionOrganization: "theirs",
+		},
+		{
+			name:                     "ensure a cluster outside of the allowed organizations is rejected",
+			allowed:                  "other",
+			accountOrganization:      "mine",
+			subscriptionOrganization: "mine",
+			wantErr:                  ErrClusterOrganization,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := NewOrganizationGuard(testConnection(t, tt.accountOrganization, tt.subscriptionOrganization), tt.allowed)
+			if err := guard.Check(context.Background(), cluster); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package ocm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+const (
+	machinePoolsPath = "/api/clusters_mgmt/v1/clusters/%s/machine_pools"
+	nodePoolsPath    = "/api/clusters_mgmt/v1/clusters/%s/node_pools"
+)
+
+var (
+	ErrInvalidOverrides = errors.New("invalid raw overrides")
+)
+
+// protectedOverrides are the fields of an object which may not be set by raw overrides, given by their
+// path of field names.  These are the fields which identify the object in OpenShift Cluster Manager,
+// along with the fields of machine pools and node pools which are modeled by the spec of a machine pool,
+// so that raw overrides may only set the fields which are not yet modeled.
+var protectedOverrides = [][]string{
+	{"kind"},
+	{"id"},
+	{"href"},
+	{"replicas"},
+	{"autoscaling"},
+	{"labels"},
+	{"taints"},
+	{"instance_type"},
+	{"subnets"},
+	{"subnet"},
+	{"auto_repair"},
+	{"version"},
+	{"aws", "spot_market_options"},
+	{"aws_node_pool", "instance_type"},
+	{"aws_node_pool", "tags"},
+}
+
+// ValidateOverrides validates raw overrides which are merged into the body of a request to OpenShift
+// Cluster Manager.  The overrides must be a json object, and may not set the fields which identify the
+// object or which are modeled by the spec.
+func ValidateOverrides(overrides []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(overrides, &fields); err != nil {
+		return fmt.Errorf("raw overrides must be a json object - %w", ErrInvalidOverrides)
+	}
+
+	for _, path := range protectedOverrides {
+		if overridesField(fields, path) {
+			return fmt.Errorf("raw overrides may not set field [%s] - %w", strings.Join(path, "."), ErrInvalidOverrides)
+		}
+	}
+
+	return nil
+}
+
+// overridesField determines if raw overrides set a field, given by its path of field names.  A field is
+// also set when an object which contains it is overridden with a value other than an object, such as
+// null, as the object is then replaced or removed along with the field.
+func overridesField(fields map[string]json.RawMessage, path []string) bool {
+	value, found := fields[path[0]]
+	if !found {
+		return false
+	}
+
+	if len(path) == 1 {
+		return true
+	}
+
+	nested := map[string]json.RawMessage{}
+	if err := json.Unmarshal(value, &nested); err != nil || nested == nil {
+		return true
+	}
+
+	return overridesField(nested, path[1:])
+}
+
+// mergeOverrides merges raw overrides into the json body of a request to OpenShift Cluster Manager as a
+// json merge patch (RFC 7386), so that fields which are not modeled by the operator may be set.  A
+// field of the overrides takes precedence over the same field of the body, and a field of the overrides
+// which is null removes the field from the body.
+func mergeOverrides(body, overrides []byte) ([]byte, error) {
+	if err := ValidateOverrides(overrides); err != nil {
+		return nil, err
+	}
+
+	merged, err := jsonpatch.MergePatch(body, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("unable to merge raw overrides - %w", err)
+	}
+
+	return merged, nil
+}
+
+// sendOverridden sends a create or update request, whose body is an object merged with raw overrides,
+// using the raw connection, as the requests of the SDK may only send the fields which the SDK models.
+// The response is unmarshalled into an object of the same type, and an error response is returned as an
+// error of OpenShift Cluster Manager, so that it is handled like the error of any other request.
+func sendOverridden[T any](
+	ctx context.Context,
+	request *sdk.Request,
+	object T,
+	overrides []byte,
+	marshal func(T, io.Writer) error,
+	unmarshal func(interface{}) (T, error),
+) (result T, status int, err error) {
+	body := &bytes.Buffer{}
+	if err := marshal(object, body); err != nil {
+		return result, 0, fmt.Errorf("unable to marshal object for raw overrides - %w", err)
+	}
+
+	merged, err := mergeOverrides(body.Bytes(), overrides)
+	if err != nil {
+		return result, 0, err
+	}
+
+	response, err := request.Header("Content-Type", "application/json").Bytes(merged).SendContext(ctx)
+	if err != nil {
+		// the raw response is not returned when the request could not be sent
+		//nolint:wrapcheck
+		return result, 0, err
+	}
+
+	if response.Status() >= http.StatusBadRequest {
+		ocmErr, err := ocmerrors.UnmarshalErrorStatus(response.Bytes(), response.Status())
+		if err != nil {
+			// the error response is not always an error object, for example from a proxy
+			ocmErr, err = ocmerrors.NewError().Status(response.Status()).Reason(response.String()).Build()
+			if err != nil {
+				return result, response.Status(), fmt.Errorf("unable to build error response - %w", err)
+			}
+		}
+
+		return result, response.Status(), ocmErr
+	}
+
+	result, err = unmarshal(response.Bytes())
+	if err != nil {
+		return result, response.Status(), fmt.Errorf("unable to unmarshal response - %w", err)
+	}
+
+	return result, response.Status(), nil
+}
@@ -0,0 +1,276 @@
+package ocm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestValidateOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		overrides string
+		wantErr   bool
+	}{
+		{
+			name:      "ensure an object is valid",
+			overrides: `{"aws":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure an array is invalid",
+			overrides: `["sg-0123456789abcdef0"]`,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure malformed json is invalid",
+			overrides: `{"aws":`,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure the id may not be overridden",
+			overrides: `{"id":"other"}`,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure the kind may not be overridden",
+			overrides: `{"kind":"NodePool"}`,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure a modeled field may not be overridden",
+			overrides: `{"instance_type":"m5.2xlarge"}`,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure a modeled nested field may not be overridden",
+			overrides: `{"aws":{"spot_market_options":{"max_price":2}}}`,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure an object containing a modeled field may not be removed",
+			overrides: `{"aws_node_pool":null}`,
+			wantErr:   true,
+		},
+		{
+			name:      "ensure a field which is not modeled is valid alongside a modeled object",
+			overrides: `{"aws_node_pool":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`,
+			wantErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateOverrides([]byte(tt.overrides))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidOverrides) {
+				t.Errorf("ValidateOverrides() error = %v, want %v", err, ErrInvalidOverrides)
+			}
+		})
+	}
+}
+
+func TestMergeOverrides(t *testing.T) {
+	t.Parallel()
+
+	body := `{"kind":"MachinePool","id":"infra","replicas":2,"root_volume":{"aws":{"size":300}}}`
+
+	tests := []struct {
+		name      string
+		overrides string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "ensure a field which is not modeled is added",
+			overrides: `{"aws":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`,
+			want: `{"kind":"MachinePool","id":"infra","replicas":2,"root_volume":{"aws":{"size":300}},` +
+				`"aws":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`,
+			wantErr: false,
+		},
+		{
+			name:      "ensure a field of the overrides takes precedence",
+			overrides: `{"root_volume":{"aws":{"size":500}}}`,
+			want:      `{"kind":"MachinePool","id":"infra","replicas":2,"root_volume":{"aws":{"size":500}}}`,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure a null field is removed",
+			overrides: `{"root_volume":{"aws":null}}`,
+			want:      `{"kind":"MachinePool","id":"infra","replicas":2,"root_volume":{}}`,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure invalid overrides return an error",
+			overrides: `{"id":"other"}`,
+			want:      "",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := mergeOverrides([]byte(body), []byte(tt.overrides))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mergeOverrides() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var gotObject, wantObject interface{}
+			if err := json.Unmarshal(got, &gotObject); err != nil {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, false)
+			}
+
+			if err := json.Unmarshal([]byte(tt.want), &wantObject); err != nil {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, false)
+			}
+
+			if !reflect.DeepEqual(gotObject, wantObject) {
+				t.Errorf("mergeOverrides() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMachinePoolClient_WithOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		overrides   string
+		status      int
+		wantField   bool
+		wantErr     bool
+		unsupported bool
+	}{
+		{
+			name:      "ensure a machine pool is created with the raw overrides",
+			overrides: `{"aws":{"additional_security_group_ids":["sg-0123456789abcdef0"]}}`,
+			status:    http.StatusCreated,
+			wantField: true,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure a machine pool is created without raw overrides",
+			overrides: "",
+			status:    http.StatusCreated,
+			wantField: false,
+			wantErr:   false,
+		},
+		{
+			name:        "ensure a rejected machine pool returns an error of openshift cluster manager",
+			overrides:   `{"aws":{"additional_security_group_ids":["invalid"]}}`,
+			status:      http.StatusBadRequest,
+			wantField:   true,
+			wantErr:     true,
+			unsupported: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var received map[string]interface{}
+
+			connection := testOverridesConnection(t, func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &received)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+
+				if tt.status >= http.StatusBadRequest {
+					fmt.Fprint(w, `{"kind":"Error","id":"400","code":"CLUSTERS-MGMT-400","reason":"invalid security group"}`)
+
+					return
+				}
+
+				_, _ = w.Write(body)
+			})
+
+			client := NewMachinePoolClient(connection, "infra", "cluster").WithOverrides([]byte(tt.overrides))
+
+			got, err := client.Create(clustersmgmtv1.NewMachinePool().ID("infra").Replicas(2))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if client.LastStatus() != tt.status {
+				t.Errorf("LastStatus() = %v, want %v", client.LastStatus(), tt.status)
+			}
+
+			aws, _ := received["aws"].(map[string]interface{})
+			if _, found := aws["additional_security_group_ids"]; found != tt.wantField {
+				t.Errorf("Create() sent overridden field = %v, want %v", found, tt.wantField)
+			}
+
+			if tt.wantErr {
+				if IsUnsupported(err) != tt.unsupported {
+					t.Errorf("IsUnsupported() = %v, want %v", IsUnsupported(err), tt.unsupported)
+				}
+
+				return
+			}
+
+			if got.ID() != "infra" || got.Replicas() != 2 {
+				t.Errorf("Create() = %v, want machine pool [infra] with 2 replicas", got)
+			}
+		})
+	}
+}
+
+// testOverridesConnection returns a connection to a fake openshift cluster manager which serves the
+// machine pools of a cluster with a handler.
+func testOverridesConnection(t *testing.T, handler http.HandlerFunc) *sdk.Connection {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf(machinePoolsPath, "cluster") || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	token := encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." +
+		encode([]byte(fmt.Sprintf(`{"typ":"Bearer","exp":%d}`, time.Now().Add(time.Hour).Unix()))) + "."
+
+	connection, err := sdk.NewConnectionBuilder().URL(server.URL).Tokens(token).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", err, false)
+	}
+
+	t.Cleanup(func() { connection.Close() })
+
+	return connection
+}
@@ -0,0 +1,134 @@
+package ocm
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"golang.org/x/net/http/httpproxy"
+)
+
+var ErrInvalidTrustBundle = errors.New("no certificates found in trust bundle")
+
+// ProxyConfig is the proxy configuration of the connection to OpenShift Cluster Manager.  Settings
+// which are empty are read from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+// proxyFunc returns the function which selects the proxy of each request.
+func (config ProxyConfig) proxyFunc() func(*http.Request) (*url.URL, error) {
+	proxy := httpproxy.FromEnvironment()
+
+	if config.HTTPProxy != "" {
+		proxy.HTTPProxy = config.HTTPProxy
+	}
+
+	if config.HTTPSProxy != "" {
+		proxy.HTTPSProxy = config.HTTPSProxy
+	}
+
+	if config.NoProxy != "" {
+		proxy.NoProxy = config.NoProxy
+	}
+
+	proxyURL := proxy.ProxyFunc()
+
+	return func(request *http.Request) (*url.URL, error) {
+		//nolint:wrapcheck
+		return proxyURL(request.URL)
+	}
+}
+
+// ProxyTransport is the transport of the connection to OpenShift Cluster Manager.  It sends requests
+// through the configured proxy and trusts a custom certificate authority bundle in addition to the
+// system certificate authorities.  The trust bundle may be replaced while the operator is running, for
+// example when a trusted CA bundle which is injected by the cluster is rotated.
+type ProxyTransport struct {
+	config ProxyConfig
+
+	mutex     sync.RWMutex
+	bundle    []byte
+	transport *http.Transport
+}
+
+// NewProxyTransport returns a transport which sends requests through the proxy of a configuration and
+// trusts the system certificate authorities.
+func NewProxyTransport(config ProxyConfig) (*ProxyTransport, error) {
+	proxy := &ProxyTransport{config: config}
+
+	if _, err := proxy.SetTrustBundle(nil); err != nil {
+		return nil, err
+	}
+
+	return proxy, nil
+}
+
+// Wrapper returns the wrapper which replaces the transport of an OpenShift Cluster Manager connection
+// with the proxy transport.  It must be the last wrapper of the connection, so that the hooks of the
+// other wrappers are called for each request.
+func (proxy *ProxyTransport) Wrapper() sdk.TransportWrapper {
+	return func(_ http.RoundTripper) http.RoundTripper {
+		return proxy
+	}
+}
+
+// SetTrustBundle replaces the PEM encoded certificate authorities which are trusted in addition to the
+// system certificate authorities.  It returns whether the trust bundle has changed.  Idle connections of
+// the previous transport are closed so that new connections verify servers against the new bundle.
+func (proxy *ProxyTransport) SetTrustBundle(bundle []byte) (bool, error) {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+
+	if proxy.transport != nil && bytes.Equal(proxy.bundle, bundle) {
+		return false, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if len(bundle) > 0 && !pool.AppendCertsFromPEM(bundle) {
+		return false, ErrInvalidTrustBundle
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return false, fmt.Errorf("unexpected default transport type [%T]", http.DefaultTransport)
+	}
+
+	transport = transport.Clone()
+	transport.Proxy = proxy.config.proxyFunc()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+	}
+
+	if proxy.transport != nil {
+		proxy.transport.CloseIdleConnections()
+	}
+
+	proxy.bundle = bundle
+	proxy.transport = transport
+
+	return true, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (proxy *ProxyTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	proxy.mutex.RLock()
+	transport := proxy.transport
+	proxy.mutex.RUnlock()
+
+	//nolint:wrapcheck
+	return transport.RoundTrip(request)
+}
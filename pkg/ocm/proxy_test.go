@@ -0,0 +1,112 @@
+package ocm
+
+import (
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProxyConfig_proxyFunc(t *testing.T) {
+	t.Parallel()
+
+	config := ProxyConfig{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://secure-proxy.example.com:3128",
+		NoProxy:    ".internal.example.com",
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "ensure http requests use the http proxy",
+			url:  "http://api.openshift.com/api",
+			want: "http://proxy.example.com:3128",
+		},
+		{
+			name: "ensure https requests use the https proxy",
+			url:  "https://api.openshift.com/api",
+			want: "http://secure-proxy.example.com:3128",
+		},
+		{
+			name: "ensure requests to excluded hosts do not use a proxy",
+			url:  "https://ocm.internal.example.com/api",
+			want: "",
+		},
+	}
+
+	proxy := config.proxyFunc()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request, err := http.NewRequest(http.MethodGet, tt.url, http.NoBody)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v, wantErr %v", err, false)
+			}
+
+			got, err := proxy(request)
+			if err != nil {
+				t.Fatalf("proxyFunc() error = %v, wantErr %v", err, false)
+			}
+
+			var gotString string
+			if got != nil {
+				gotString = got.String()
+			}
+
+			if gotString != tt.want {
+				t.Errorf("proxyFunc() = %v, want %v", gotString, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyTransport_SetTrustBundle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	transport, err := NewProxyTransport(ProxyConfig{})
+	if err != nil {
+		t.Fatalf("NewProxyTransport() error = %v, wantErr %v", err, false)
+	}
+
+	client := &http.Client{Transport: transport}
+
+	if response, err := client.Get(server.URL); err == nil {
+		response.Body.Close()
+		t.Fatalf("Get() error = %v, want untrusted certificate error", err)
+	}
+
+	if _, err := transport.SetTrustBundle([]byte("invalid")); !errors.Is(err, ErrInvalidTrustBundle) {
+		t.Errorf("SetTrustBundle() error = %v, wantErr %v", err, ErrInvalidTrustBundle)
+	}
+
+	changed, err := transport.SetTrustBundle(bundle)
+	if err != nil || !changed {
+		t.Fatalf("SetTrustBundle() = %v, %v, want %v, %v", changed, err, true, nil)
+	}
+
+	if changed, _ := transport.SetTrustBundle(bundle); changed {
+		t.Errorf("SetTrustBundle() = %v, want %v", changed, false)
+	}
+
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v, wantErr %v", err, false)
+	}
+
+	response.Body.Close()
+}
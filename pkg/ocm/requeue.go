@@ -0,0 +1,57 @@
+package ocm
+
+import (
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Requeue hints for long-running operations in OpenShift Cluster Manager.  Each hint is the interval at
+// which the state of an operation which is still progressing is polled, based upon how long the
+// operation typically takes, rather than polling every operation at the same fixed interval.
+const (
+	// RequeueClusterInstalling is the interval at which a cluster which is still being installed is
+	// polled.  An installation typically takes 30-40 minutes.
+	RequeueClusterInstalling = 2 * time.Minute
+
+	// RequeueMachinePoolProvisioning is the interval at which the nodes of a machine pool which are
+	// still being provisioned are polled.  A node typically takes 5-10 minutes to become ready.
+	RequeueMachinePoolProvisioning = time.Minute
+
+	// RequeueNodePoolUpgrading is the interval at which a node pool upgrade which has started is
+	// polled.  Each node of the node pool is replaced in turn.
+	RequeueNodePoolUpgrading = 5 * time.Minute
+
+	// requeueMinimum is the shortest requeue hint, so that an operation which is due to start is not
+	// polled in a tight loop.
+	requeueMinimum = 10 * time.Second
+)
+
+// ClusterInstalling determines if a cluster is still being installed, and so is not yet able to have
+// objects such as machine pools created within it.
+func ClusterInstalling(state clustersmgmtv1.ClusterState) bool {
+	switch state {
+	case clustersmgmtv1.ClusterStatePending,
+		clustersmgmtv1.ClusterStateValidating,
+		clustersmgmtv1.ClusterStateWaiting,
+		clustersmgmtv1.ClusterStateInstalling:
+		return true
+	default:
+		return false
+	}
+}
+
+// RequeueForUpgrade returns the interval at which a node pool upgrade which has not yet finished is
+// polled.  An upgrade which is scheduled to start in the future is polled when it is due to start,
+// and an upgrade which has started is polled at the upgrading interval.
+func RequeueForUpgrade(policy *NodePoolUpgradePolicy, now time.Time) time.Duration {
+	if policy == nil || policy.Started() || policy.NextRun.IsZero() {
+		return RequeueNodePoolUpgrading
+	}
+
+	if untilRun := policy.NextRun.Sub(now); untilRun > requeueMinimum {
+		return untilRun
+	}
+
+	return requeueMinimum
+}
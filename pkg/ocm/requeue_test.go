@@ -0,0 +1,73 @@
+package ocm
+
+import (
+	"testing"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestClusterInstalling(t *testing.T) {
+	t.Parallel()
+
+	for state, want := range map[clustersmgmtv1.ClusterState]bool{
+		clustersmgmtv1.ClusterStatePending:      true,
+		clustersmgmtv1.ClusterStateInstalling:   true,
+		clustersmgmtv1.ClusterStateReady:        false,
+		clustersmgmtv1.ClusterStateError:        false,
+		clustersmgmtv1.ClusterStateUninstalling: false,
+		"":                                      false,
+	} {
+		if got := ClusterInstalling(state); got != want {
+			t.Errorf("ClusterInstalling(%q) = %v, want %v", state, got, want)
+		}
+	}
+}
+
+func TestRequeueForUpgrade(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		policy *NodePoolUpgradePolicy
+		want   time.Duration
+	}{
+		{
+			name:   "ensure a scheduled upgrade is polled when it is due to start",
+			policy: &NodePoolUpgradePolicy{NextRun: now.Add(time.Hour), State: &NodePoolUpgradePolicyState{Value: UpgradePolicyStateScheduled}},
+			want:   time.Hour,
+		},
+		{
+			name:   "ensure an upgrade without a state is polled when it is due to start",
+			policy: &NodePoolUpgradePolicy{NextRun: now.Add(30 * time.Minute)},
+			want:   30 * time.Minute,
+		},
+		{
+			name:   "ensure an upgrade which is overdue is not polled in a tight loop",
+			policy: &NodePoolUpgradePolicy{NextRun: now.Add(-time.Minute)},
+			want:   requeueMinimum,
+		},
+		{
+			name:   "ensure a started upgrade is polled at the upgrading interval",
+			policy: &NodePoolUpgradePolicy{NextRun: now.Add(-time.Minute), State: &NodePoolUpgradePolicyState{Value: "started"}},
+			want:   RequeueNodePoolUpgrading,
+		},
+		{
+			name:   "ensure an upgrade without a next run is polled at the upgrading interval",
+			policy: &NodePoolUpgradePolicy{},
+			want:   RequeueNodePoolUpgrading,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := RequeueForUpgrade(tt.policy, now); got != tt.want {
+				t.Errorf("RequeueForUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
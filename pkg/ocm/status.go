@@ -0,0 +1,17 @@
+package ocm
+
+// responseStatus tracks the HTTP status of the last mutating request which was sent to OpenShift
+// Cluster Manager by a client, so that the outcome of the request may be recorded by a controller.
+type responseStatus struct {
+	status int
+}
+
+// LastStatus returns the HTTP status of the last create, update or delete request which was sent by
+// the client, or zero if no response was received.
+func (rs *responseStatus) LastStatus() int {
+	return rs.status
+}
+
+func (rs *responseStatus) observe(status int) {
+	rs.status = status
+}
@@ -0,0 +1,137 @@
+package ocm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// Subscription statuses which are reported by OpenShift Cluster Manager for registered clusters.
+const (
+	SubscriptionStatusDisconnected = "Disconnected"
+	SubscriptionStatusArchived     = "Archived"
+)
+
+// SubscriptionClient represents the client used to register existing clusters, which were not
+// provisioned by OpenShift Cluster Manager, by creating a subscription for them.
+type SubscriptionClient struct {
+	responseStatus
+	requestContext
+
+	connection *sdk.Connection
+}
+
+func NewSubscriptionClient(connection *sdk.Connection) *SubscriptionClient {
+	return &SubscriptionClient{
+		connection: connection,
+	}
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (sc *SubscriptionClient) WithContext(ctx context.Context) *SubscriptionClient {
+	sc.setContext(ctx)
+
+	return sc
+}
+
+// Get retrieves the subscription of a registered cluster given its subscription id.  A nil subscription is
+// returned if the subscription does not exist.
+func (sc *SubscriptionClient) Get(id string) (subscription *accountsmgmtv1.Subscription, err error) {
+	response, err := sc.connection.AccountsMgmt().V1().Subscriptions().Subscription(id).Get().SendContext(sc.sendContext())
+	if err != nil {
+		if response.Status() == http.StatusNotFound {
+			return subscription, nil
+		}
+
+		return subscription, fmt.Errorf("error in get request - %w", err)
+	}
+
+	return response.Body(), nil
+}
+
+// Find retrieves the subscription which has not been archived for a cluster given its cluster uuid, also
+// known as the external cluster id.  A nil subscription is returned if the cluster is not registered.
+func (sc *SubscriptionClient) Find(clusterUUID string) (subscription *accountsmgmtv1.Subscription, err error) {
+	response, err := sc.connection.AccountsMgmt().V1().Subscriptions().List().
+		Search(fmt.Sprintf("external_cluster_id = '%s' and status != '%s'", clusterUUID, SubscriptionStatusArchived)).
+		Size(1).
+		Send()
+	if err != nil {
+		return subscription, fmt.Errorf("error in list request - %w", err)
+	}
+
+	if response.Items().Len() == 0 {
+		return subscription, nil
+	}
+
+	return response.Items().Get(0), nil
+}
+
+// Register registers a disconnected cluster with OpenShift Cluster Manager.
+func (sc *SubscriptionClient) Register(builder *accountsmgmtv1.SubscriptionRegistrationBuilder) (subscription *accountsmgmtv1.Subscription, err error) {
+	// build the object to create
+	object, err := builder.Build()
+	if err != nil {
+		return subscription, fmt.Errorf("unable to build object for cluster registration - %w", err)
+	}
+
+	// register the cluster in ocm
+	response, err := sc.connection.AccountsMgmt().V1().Subscriptions().Post().Request(object).SendContext(sc.sendContext())
+	sc.observe(response.Status())
+
+	if err != nil {
+		return subscription, fmt.Errorf("error in create request - %w", err)
+	}
+
+	return response.Response(), nil
+}
+
+// Update updates the subscription of a registered cluster.
+func (sc *SubscriptionClient) Update(id string, builder *accountsmgmtv1.SubscriptionBuilder) (subscription *accountsmgmtv1.Subscription, err error) {
+	// build the object to update
+	object, err := builder.Build()
+	if err != nil {
+		return subscription, fmt.Errorf("unable to build object for subscription update - %w", err)
+	}
+
+	// update the subscription in ocm
+	response, err := sc.connection.AccountsMgmt().V1().Subscriptions().Subscription(id).Update().Body(object).SendContext(sc.sendContext())
+	sc.observe(response.Status())
+
+	if err != nil {
+		return subscription, fmt.Errorf("error in update request - %w", err)
+	}
+
+	return response.Body(), nil
+}
+
+// Archive archives the subscription of a registered cluster so that it is no longer managed by
+// OpenShift Cluster Manager.  A subscription which no longer exists is considered archived.
+func (sc *SubscriptionClient) Archive(id string) error {
+	_, err := sc.Update(id, accountsmgmtv1.NewSubscription().Status(SubscriptionStatusArchived))
+	if err != nil && sc.LastStatus() != http.StatusNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// PullSecret retrieves the pull secret of the account which the operator is authenticated as, in
+// the format of a docker config json file.
+func (sc *SubscriptionClient) PullSecret() ([]byte, error) {
+	response, err := sc.connection.AccountsMgmt().V1().AccessToken().Post().SendContext(sc.sendContext())
+	if err != nil {
+		return nil, fmt.Errorf("error in access token request - %w", err)
+	}
+
+	var pullSecret bytes.Buffer
+	if err := accountsmgmtv1.MarshalAccessToken(response.Body(), &pullSecret); err != nil {
+		return nil, fmt.Errorf("unable to marshal pull secret - %w", err)
+	}
+
+	return pullSecret.Bytes(), nil
+}
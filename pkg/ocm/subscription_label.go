@@ -0,0 +1,103 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// SubscriptionLabelClient represents the client used to interact with the labels of a cluster
+// subscription.
+type SubscriptionLabelClient struct {
+	responseStatus
+	requestContext
+
+	connection *accountsmgmtv1.GenericLabelsClient
+}
+
+func NewSubscriptionLabelClient(connection *sdk.Connection, subscriptionID string) *SubscriptionLabelClient {
+	return &SubscriptionLabelClient{
+		connection: connection.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Labels(),
+	}
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (slc *SubscriptionLabelClient) WithContext(ctx context.Context) *SubscriptionLabelClient {
+	slc.setContext(ctx)
+
+	return slc
+}
+
+// List lists all of the labels of the subscription.
+func (slc *SubscriptionLabelClient) List() (labels []*accountsmgmtv1.Label, err error) {
+	for page := 1; ; page++ {
+		response, err := slc.connection.List().Page(page).Size(listPageSize).SendContext(slc.sendContext())
+		if err != nil {
+			return labels, fmt.Errorf("error in list request - %w", err)
+		}
+
+		labels = append(labels, response.Items().Slice()...)
+
+		if response.Size() < listPageSize {
+			return labels, nil
+		}
+	}
+}
+
+// Create creates a label on the subscription.
+func (slc *SubscriptionLabelClient) Create(key, value string) (label *accountsmgmtv1.Label, err error) {
+	// build the object to create
+	object, err := accountsmgmtv1.NewLabel().Key(key).Value(value).Build()
+	if err != nil {
+		return label, fmt.Errorf("unable to build object for label creation - %w", err)
+	}
+
+	// create the label in ocm
+	response, err := slc.connection.Add().Body(object).SendContext(slc.sendContext())
+	slc.observe(response.Status())
+
+	if err != nil {
+		return label, fmt.Errorf("error in create request - %w", err)
+	}
+
+	return response.Body(), nil
+}
+
+// Update updates the value of an existing label on the subscription.
+func (slc *SubscriptionLabelClient) Update(key, value string) (label *accountsmgmtv1.Label, err error) {
+	// build the object to update
+	object, err := accountsmgmtv1.NewLabel().Key(key).Value(value).Build()
+	if err != nil {
+		return label, fmt.Errorf("unable to build object for label update - %w", err)
+	}
+
+	// update the label in ocm
+	response, err := slc.connection.Label(key).Update().Body(object).SendContext(slc.sendContext())
+	slc.observe(response.Status())
+
+	if err != nil {
+		return label, fmt.Errorf("error in update request - %w", err)
+	}
+
+	return response.Body(), nil
+}
+
+// Delete deletes a label from the subscription.  A label which no longer exists is considered deleted.
+func (slc *SubscriptionLabelClient) Delete(key string) error {
+	// delete the label in ocm
+	response, err := slc.connection.Label(key).Delete().SendContext(slc.sendContext())
+	slc.observe(response.Status())
+
+	if err != nil {
+		if response.Status() == http.StatusNotFound {
+			return nil
+		}
+
+		return fmt.Errorf("error in delete request - %w", err)
+	}
+
+	return nil
+}
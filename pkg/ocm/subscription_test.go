@@ -0,0 +1,165 @@
+package ocm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+// testSubscriptionConnection returns a connection to a fake openshift cluster manager which serves
+// a single subscription with a given status, and records the status of the subscription when it is
+// updated.
+func testSubscriptionConnection(t *testing.T, status string, updated *string) *sdk.Connection {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/api/accounts_mgmt/v1/subscriptions" && r.Method == http.MethodGet:
+			if status == "" {
+				fmt.Fprint(w, `{"kind":"SubscriptionList","items":[],"page":1,"size":0,"total":0}`)
+
+				return
+			}
+
+			fmt.Fprintf(w, `{"kind":"SubscriptionList","items":[{"kind":"Subscription","id":"subscription","status":%q}],"page":1,"size":1,"total":1}`, status)
+		case r.URL.Path == "/api/accounts_mgmt/v1/subscriptions/subscription" && r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+
+			patch := map[string]interface{}{}
+			_ = json.Unmarshal(body, &patch)
+			*updated, _ = patch["status"].(string)
+
+			fmt.Fprintf(w, `{"kind":"Subscription","id":"subscription","status":%q}`, *updated)
+		case r.URL.Path == "/api/accounts_mgmt/v1/access_token" && r.Method == http.MethodPost:
+			fmt.Fprint(w, `{"auths":{"cloud.openshift.com":{"auth":"dGVzdA==","email":"test@example.com"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Error","id":"404","code":"CLUSTERS-MGMT-404","reason":"not found"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	token := encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." +
+		encode([]byte(fmt.Sprintf(`{"typ":"Bearer","exp":%d}`, time.Now().Add(time.Hour).Unix()))) + "."
+
+	connection, err := sdk.NewConnectionBuilder().URL(server.URL).Tokens(token).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v, wantErr %v", err, false)
+	}
+
+	t.Cleanup(func() { connection.Close() })
+
+	return connection
+}
+
+func TestSubscriptionClient_Find(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status string
+		want   string
+	}{
+		{
+			name:   "ensure an existing subscription is found",
+			status: SubscriptionStatusDisconnected,
+			want:   "subscription",
+		},
+		{
+			name:   "ensure a missing subscription is not found",
+			status: "",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var updated string
+
+			got, err := NewSubscriptionClient(testSubscriptionConnection(t, tt.status, &updated)).Find("uuid")
+			if err != nil {
+				t.Fatalf("Find() error = %v, wantErr %v", err, false)
+			}
+
+			if got.ID() != tt.want {
+				t.Errorf("Find() = %v, want %v", got.ID(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionClient_Archive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		id   string
+		want string
+	}{
+		{
+			name: "ensure an existing subscription is archived",
+			id:   "subscription",
+			want: SubscriptionStatusArchived,
+		},
+		{
+			name: "ensure a missing subscription is considered archived",
+			id:   "missing",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var updated string
+
+			if err := NewSubscriptionClient(testSubscriptionConnection(t, SubscriptionStatusDisconnected, &updated)).Archive(tt.id); err != nil {
+				t.Fatalf("Archive() error = %v, wantErr %v", err, false)
+			}
+
+			if updated != tt.want {
+				t.Errorf("Archive() status = %v, want %v", updated, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionClient_PullSecret(t *testing.T) {
+	t.Parallel()
+
+	var updated string
+
+	got, err := NewSubscriptionClient(testSubscriptionConnection(t, SubscriptionStatusDisconnected, &updated)).PullSecret()
+	if err != nil {
+		t.Fatalf("PullSecret() error = %v, wantErr %v", err, false)
+	}
+
+	pullSecret := map[string]map[string]map[string]string{}
+	if err := json.Unmarshal(got, &pullSecret); err != nil {
+		t.Fatalf("PullSecret() = %s is not a docker config json - %v", got, err)
+	}
+
+	want := map[string]map[string]map[string]string{
+		"auths": {"cloud.openshift.com": {"auth": "dGVzdA==", "email": "test@example.com"}},
+	}
+
+	if !reflect.DeepEqual(pullSecret, want) {
+		t.Errorf("PullSecret() = %v, want %v", pullSecret, want)
+	}
+}
@@ -0,0 +1,45 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// SupportCaseClient represents the client used to open support cases in OpenShift Cluster Manager.
+type SupportCaseClient struct {
+	requestContext
+
+	connection *accountsmgmtv1.SupportCasesClient
+}
+
+func NewSupportCaseClient(connection *sdk.Connection) *SupportCaseClient {
+	return &SupportCaseClient{
+		connection: connection.AccountsMgmt().V1().SupportCases(),
+	}
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (scc *SupportCaseClient) WithContext(ctx context.Context) *SupportCaseClient {
+	scc.setContext(ctx)
+
+	return scc
+}
+
+func (scc *SupportCaseClient) Create(builder *accountsmgmtv1.SupportCaseRequestBuilder) (supportCase *accountsmgmtv1.SupportCaseResponse, err error) {
+	// build the object to create
+	object, err := builder.Build()
+	if err != nil {
+		return supportCase, fmt.Errorf("unable to build object for support case creation - %w", err)
+	}
+
+	// create the support case in ocm
+	response, err := scc.connection.Post().Request(object).SendContext(scc.sendContext())
+	if err != nil {
+		return supportCase, fmt.Errorf("error in create request - %w", err)
+	}
+
+	return response.Response(), nil
+}
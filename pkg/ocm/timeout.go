@@ -0,0 +1,96 @@
+package ocm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+const (
+	// DefaultReadTimeout is the default amount of time after which a request which reads from OpenShift
+	// Cluster Manager is abandoned.
+	DefaultReadTimeout = 30 * time.Second
+
+	// DefaultWriteTimeout is the default amount of time after which a request which writes to OpenShift
+	// Cluster Manager is abandoned.
+	DefaultWriteTimeout = time.Minute
+)
+
+// Timeouts are the amounts of time after which requests to OpenShift Cluster Manager are abandoned, so
+// that an endpoint which does not respond may not stall a reconciliation for the default timeout of the
+// http client.  A zero timeout does not limit requests.
+type Timeouts struct {
+	// Read is the timeout of requests which do not modify objects, such as GET requests.
+	Read time.Duration
+
+	// Write is the timeout of requests which create, update or delete objects.
+	Write time.Duration
+}
+
+// For returns the timeout of a request with an http method.
+func (timeouts Timeouts) For(method string) time.Duration {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return timeouts.Read
+	default:
+		return timeouts.Write
+	}
+}
+
+// NewTimeoutWrapper returns a wrapper for the transport of an OpenShift Cluster Manager connection which
+// bounds each request by its timeout.  The timeout is applied on top of the context of the request, so a
+// request is abandoned at the earlier of its timeout and the deadline of the reconciliation which sent it.
+// Retries of the connection are made with a new timeout.
+func NewTimeoutWrapper(timeouts Timeouts) sdk.TransportWrapper {
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		return &timeoutTransport{wrapped: wrapped, timeouts: timeouts}
+	}
+}
+
+// timeoutTransport is a transport which bounds the requests of a wrapped transport by a timeout.
+type timeoutTransport struct {
+	wrapped  http.RoundTripper
+	timeouts Timeouts
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (transport *timeoutTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	timeout := transport.timeouts.For(request.Method)
+	if timeout <= 0 {
+		//nolint:wrapcheck
+		return transport.wrapped.RoundTrip(request)
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), timeout)
+
+	//nolint:wrapcheck
+	response, err := transport.wrapped.RoundTrip(request.Clone(ctx))
+	if err != nil {
+		cancel()
+
+		return response, err
+	}
+
+	// the context must remain until the body has been read, so it is cancelled once the body is closed
+	response.Body = &cancelBody{ReadCloser: response.Body, cancel: cancel}
+
+	return response, nil
+}
+
+// cancelBody is the body of a response which cancels the context of its request once it is closed.
+type cancelBody struct {
+	io.ReadCloser
+
+	cancel context.CancelFunc
+}
+
+// Close implements the io.Closer interface.
+func (body *cancelBody) Close() error {
+	defer body.cancel()
+
+	//nolint:wrapcheck
+	return body.ReadCloser.Close()
+}
@@ -0,0 +1,203 @@
+package ocm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// endpointVersionSegments is the number of path segments which prefix each OpenShift Cluster
+	// Manager endpoint, for example /api/clusters_mgmt/v1.
+	endpointVersionSegments = 4
+
+	endpointIDPlaceholder = "{id}"
+
+	// RequestDurationMetric is the name of the metric which exposes the latency of each request
+	// to OpenShift Cluster Manager.
+	RequestDurationMetric = "ocm_request_duration_seconds"
+)
+
+var ErrInvalidHeader = errors.New("invalid header")
+
+// TransportHook is a hook into each request which is sent to OpenShift Cluster Manager.  It allows
+// integrators to inspect and modify requests before they are sent, and to inspect the response,
+// or error, once the request completes.
+type TransportHook interface {
+	// BeforeRequest is called before a request is sent.  The request may be modified, for example
+	// to inject headers.
+	BeforeRequest(request *http.Request)
+
+	// AfterResponse is called after a request completes.  The response is nil when the request
+	// returns an error.
+	AfterResponse(request *http.Request, response *http.Response, duration time.Duration, err error)
+}
+
+// NewTransportWrapper returns a wrapper for the transport of an OpenShift Cluster Manager
+// connection which calls each hook, in order, for every request.
+func NewTransportWrapper(hooks ...TransportHook) sdk.TransportWrapper {
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		return &hookTransport{wrapped: wrapped, hooks: hooks}
+	}
+}
+
+// hookTransport is a transport which calls hooks around the requests of a wrapped transport.
+type hookTransport struct {
+	wrapped http.RoundTripper
+	hooks   []TransportHook
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (transport *hookTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	// clone the request as a round tripper must not modify the original request
+	request = request.Clone(request.Context())
+
+	for _, hook := range transport.hooks {
+		hook.BeforeRequest(request)
+	}
+
+	start := time.Now()
+
+	//nolint:wrapcheck
+	response, err := transport.wrapped.RoundTrip(request)
+
+	duration := time.Since(start)
+
+	for _, hook := range transport.hooks {
+		hook.AfterResponse(request, response, duration, err)
+	}
+
+	return response, err
+}
+
+// Endpoint returns the path of a request with the object identifiers replaced by a placeholder,
+// so that requests to the same endpoint for different objects are grouped together.  Paths
+// beneath the version prefix alternate between collections and object identifiers, for example
+// /api/clusters_mgmt/v1/clusters/{id}/machine_pools/{id}.
+func Endpoint(path string) string {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+
+	for i := endpointVersionSegments + 1; i < len(segments); i += 2 {
+		segments[i] = endpointIDPlaceholder
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// LoggingHook is a transport hook which logs the metadata of each request and response.  Requests are
+// only logged when the verbosity of the logger is enabled, including those which fail, as a failed
+// request is also surfaced by the reconciliation which sent it.
+type LoggingHook struct {
+	Log logr.Logger
+}
+
+// BeforeRequest implements the TransportHook interface.
+func (hook *LoggingHook) BeforeRequest(request *http.Request) {}
+
+// AfterResponse implements the TransportHook interface.
+func (hook *LoggingHook) AfterResponse(request *http.Request, response *http.Response, duration time.Duration, err error) {
+	if !hook.Log.Enabled() {
+		return
+	}
+
+	values := []interface{}{
+		"method", request.Method,
+		"path", request.URL.Path,
+		"duration", duration.String(),
+	}
+
+	if err != nil {
+		hook.Log.Info("ocm request failed", append(values, "error", err.Error())...)
+
+		return
+	}
+
+	hook.Log.Info("ocm request completed", append(values, "status", response.StatusCode)...)
+}
+
+// HeaderHook is a transport hook which injects headers into each request.  It may be used to
+// inject headers which allow OpenShift Cluster Manager support to trace requests.
+type HeaderHook struct {
+	Headers map[string]string
+}
+
+// NewHeaderHook returns a header hook from a comma-separated list of key=value pairs.
+func NewHeaderHook(headers string) (*HeaderHook, error) {
+	hook := &HeaderHook{Headers: map[string]string{}}
+
+	if headers == "" {
+		return hook, nil
+	}
+
+	for _, header := range strings.Split(headers, ",") {
+		key, value, found := strings.Cut(header, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("header [%s] must be in key=value format - %w", header, ErrInvalidHeader)
+		}
+
+		hook.Headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return hook, nil
+}
+
+// BeforeRequest implements the TransportHook interface.
+func (hook *HeaderHook) BeforeRequest(request *http.Request) {
+	for key, value := range hook.Headers {
+		request.Header.Set(key, value)
+	}
+}
+
+// AfterResponse implements the TransportHook interface.
+func (hook *HeaderHook) AfterResponse(request *http.Request, response *http.Response, duration time.Duration, err error) {
+}
+
+// MetricsHook is a transport hook which records the latency of each request, per endpoint, as a
+// prometheus histogram.
+type MetricsHook struct {
+	latency *prometheus.HistogramVec
+}
+
+// NewMetricsHook returns a metrics hook whose metrics are registered with a registerer.
+func NewMetricsHook(registerer prometheus.Registerer) (*MetricsHook, error) {
+	hook := &MetricsHook{
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name: RequestDurationMetric,
+				Help: "Latency of requests to OpenShift Cluster Manager, partitioned by method, endpoint and status code.",
+			},
+			[]string{"method", "endpoint", "code"},
+		),
+	}
+
+	if err := registerer.Register(hook.latency); err != nil {
+		return nil, fmt.Errorf("unable to register ocm request metrics - %w", err)
+	}
+
+	return hook, nil
+}
+
+// BeforeRequest implements the TransportHook interface.
+func (hook *MetricsHook) BeforeRequest(request *http.Request) {}
+
+// AfterResponse implements the TransportHook interface.  Requests which return an error are
+// recorded with a status code of 0.
+func (hook *MetricsHook) AfterResponse(request *http.Request, response *http.Response, duration time.Duration, err error) {
+	code := 0
+	if err == nil {
+		code = response.StatusCode
+	}
+
+	hook.latency.WithLabelValues(
+		request.Method,
+		Endpoint(request.URL.Path),
+		strconv.Itoa(code),
+	).Observe(duration.Seconds())
+}
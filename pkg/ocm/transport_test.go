@@ -0,0 +1,299 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// testHook records the requests and responses which it observes.
+type testHook struct {
+	before []string
+	after  []int
+}
+
+func (hook *testHook) BeforeRequest(request *http.Request) {
+	hook.before = append(hook.before, request.Header.Get("X-Test"))
+}
+
+func (hook *testHook) AfterResponse(request *http.Request, response *http.Response, duration time.Duration, err error) {
+	if err == nil {
+		hook.after = append(hook.after, response.StatusCode)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "ensure a collection path is unchanged",
+			path: "/api/clusters_mgmt/v1/clusters",
+			want: "/api/clusters_mgmt/v1/clusters",
+		},
+		{
+			name: "ensure object identifiers are replaced",
+			path: "/api/clusters_mgmt/v1/clusters/abc123/machine_pools/workers",
+			want: "/api/clusters_mgmt/v1/clusters/{id}/machine_pools/{id}",
+		},
+		{
+			name: "ensure a trailing slash is ignored",
+			path: "/api/clusters_mgmt/v1/clusters/abc123/",
+			want: "/api/clusters_mgmt/v1/clusters/{id}",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Endpoint(tt.path); got != tt.want {
+				t.Errorf("Endpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHeaderHook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		headers string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "ensure empty headers are allowed",
+			headers: "",
+			want:    map[string]string{},
+			wantErr: false,
+		},
+		{
+			name:    "ensure multiple headers are parsed",
+			headers: "X-Operator-Trace=abc, X-Team = mobb",
+			want:    map[string]string{"X-Operator-Trace": "abc", "X-Team": "mobb"},
+			wantErr: false,
+		},
+		{
+			name:    "ensure a header without a value separator returns an error",
+			headers: "X-Operator-Trace",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewHeaderHook(tt.headers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHeaderHook() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(got.Headers, tt.want) {
+				t.Errorf("NewHeaderHook() = %v, want %v", got.Headers, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTransportWrapper(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	headers := &HeaderHook{Headers: map[string]string{"X-Test": "traced"}}
+	recorder := &testHook{}
+
+	metrics, err := NewMetricsHook(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewMetricsHook() error = %v, wantErr %v", err, false)
+	}
+
+	client := &http.Client{Transport: NewTransportWrapper(headers, recorder, metrics)(http.DefaultTransport)}
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/api/clusters_mgmt/v1/clusters/abc123", http.NoBody)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v, wantErr %v", err, false)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("Do() error = %v, wantErr %v", err, false)
+	}
+	defer response.Body.Close()
+
+	if !reflect.DeepEqual(recorder.before, []string{"traced"}) {
+		t.Errorf("BeforeRequest() headers = %v, want %v", recorder.before, []string{"traced"})
+	}
+
+	if !reflect.DeepEqual(recorder.after, []int{http.StatusAccepted}) {
+		t.Errorf("AfterResponse() status codes = %v, want %v", recorder.after, []int{http.StatusAccepted})
+	}
+
+	if request.Header.Get("X-Test") != "" {
+		t.Errorf("RoundTrip() modified the original request headers = %v", request.Header)
+	}
+
+	if got := testutil.CollectAndCount(metrics.latency); got != 1 {
+		t.Errorf("MetricsHook series = %v, want %v", got, 1)
+	}
+}
+
+func TestLoggingHook_AfterResponse(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("connection refused")
+
+	for _, tt := range []struct {
+		name      string
+		verbosity int
+		err       error
+		want      int
+	}{
+		{
+			name:      "ensure a completed request is not logged below the verbosity of the logger",
+			verbosity: 0,
+			want:      0,
+		},
+		{
+			name:      "ensure a failed request is not logged below the verbosity of the logger",
+			verbosity: 0,
+			err:       errTest,
+			want:      0,
+		},
+		{
+			name:      "ensure a completed request is logged at the verbosity of the logger",
+			verbosity: 1,
+			want:      1,
+		},
+		{
+			name:      "ensure a failed request is logged at the verbosity of the logger",
+			verbosity: 1,
+			err:       errTest,
+			want:      1,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var logged int
+
+			log := funcr.New(func(_, _ string) { logged++ }, funcr.Options{Verbosity: tt.verbosity})
+			hook := &LoggingHook{Log: log.V(1)}
+
+			request := httptest.NewRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters", http.NoBody)
+			response := &http.Response{StatusCode: http.StatusOK}
+
+			hook.AfterResponse(request, response, time.Second, tt.err)
+
+			if logged != tt.want {
+				t.Errorf("AfterResponse() logged = %v, want %v", logged, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeouts_For(t *testing.T) {
+	t.Parallel()
+
+	timeouts := Timeouts{Read: time.Second, Write: time.Minute}
+
+	for method, want := range map[string]time.Duration{
+		http.MethodGet:    time.Second,
+		http.MethodHead:   time.Second,
+		http.MethodPost:   time.Minute,
+		http.MethodPatch:  time.Minute,
+		http.MethodDelete: time.Minute,
+	} {
+		if got := timeouts.For(method); got != want {
+			t.Errorf("Timeouts.For(%s) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestNewTimeoutWrapper(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// the subtests run in parallel, so the server is closed once they have finished, after releasing any
+	// request which is still waiting
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	client := &http.Client{Transport: NewTimeoutWrapper(Timeouts{
+		Read:  50 * time.Millisecond,
+		Write: 0,
+	})(http.DefaultTransport)}
+
+	tests := []struct {
+		name    string
+		method  string
+		wantErr error
+	}{
+		{
+			name:    "ensure a request which does not complete within its timeout is abandoned",
+			method:  http.MethodGet,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "ensure a request without a timeout is not limited",
+			method:  http.MethodPost,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request, err := http.NewRequest(tt.method, server.URL, http.NoBody)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v, wantErr %v", err, false)
+			}
+
+			response, err := client.Do(request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if response != nil {
+				response.Body.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	versionSegments = 3
+	versionIDPrefix = "openshift-v"
+
+	// NodePoolMinorVersionSkew is the number of minor versions which the version of a node pool may be
+	// behind the version of the control plane of a hosted control plane cluster.  A node pool may never
+	// be newer than the control plane.
+	NodePoolMinorVersionSkew = 2
+)
+
+var (
+	ErrNodePoolVersionSkew = errors.New("node pool version is outside of the allowed skew of the control plane version")
+)
+
+type VersionClient struct {
+	requestContext
+
+	Connection *clustersmgmtv1.VersionsClient
+}
+
+func NewVersionClient(connection *sdk.Connection) *VersionClient {
+	return &VersionClient{
+		Connection: connection.ClustersMgmt().V1().Versions(),
+	}
+}
+
+// WithContext sets the context with which requests are sent to OpenShift Cluster Manager.
+func (vc *VersionClient) WithContext(ctx context.Context) *VersionClient {
+	vc.setContext(ctx)
+
+	return vc
+}
+
+// Get retrieves a version, including the versions which it may be upgraded to, from
+// OpenShift Cluster Manager by its id.
+func (vc *VersionClient) Get(id string) (version *clustersmgmtv1.Version, err error) {
+	response, err := vc.Connection.Version(id).Get().SendContext(vc.sendContext())
+	if err != nil {
+		return version, fmt.Errorf("unable to retrieve version [%s] from openshift cluster manager - %w", id, err)
+	}
+
+	return response.Body(), nil
+}
+
+// VersionID returns the id of a version in OpenShift Cluster Manager (e.g. openshift-v4.12.3) from
+// its raw version (e.g. 4.12.3).
+func VersionID(version string) string {
+	return versionIDPrefix + version
+}
+
+// RawVersion returns the raw version (e.g. 4.12.3) of a version from OpenShift Cluster Manager.  The
+// raw version is derived from the id of the version when it is not included in the response, as is
+// the case for versions which are linked from another object such as a node pool.
+func RawVersion(version *clustersmgmtv1.Version) string {
+	if version.RawID() != "" {
+		return version.RawID()
+	}
+
+	return strings.TrimPrefix(version.ID(), versionIDPrefix)
+}
+
+// IsZStreamUpgrade determines if an upgrade version is newer than the current version while remaining
+// within the minor version of the current version.  Versions which cannot be parsed are never z-stream
+// upgrades.
+func IsZStreamUpgrade(current, upgrade string) bool {
+	currentVersion, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+
+	upgradeVersion, ok := parseVersion(upgrade)
+	if !ok {
+		return false
+	}
+
+	return upgradeVersion[0] == currentVersion[0] &&
+		upgradeVersion[1] == currentVersion[1] &&
+		compareVersions(upgradeVersion, currentVersion) > 0
+}
+
+// IsNewerVersion determines if a version is newer than another version.  Versions which cannot be
+// parsed are never newer.
+func IsNewerVersion(version, than string) bool {
+	parsedVersion, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+
+	parsedThan, ok := parseVersion(than)
+	if !ok {
+		return false
+	}
+
+	return compareVersions(parsedVersion, parsedThan) > 0
+}
+
+// NodePoolVersionRange returns the oldest and newest versions which a node pool of a hosted control plane
+// cluster may run given the version of the control plane.  The oldest version is the first patch version
+// of the oldest minor version within the allowed skew, and the newest version is the version of the
+// control plane.  False is returned if the version of the control plane cannot be parsed.
+func NodePoolVersionRange(controlPlane string) (oldest, newest string, ok bool) {
+	controlPlaneVersion, ok := parseVersion(controlPlane)
+	if !ok {
+		return "", "", false
+	}
+
+	minor := controlPlaneVersion[1] - NodePoolMinorVersionSkew
+	if minor < 0 {
+		minor = 0
+	}
+
+	oldest = fmt.Sprintf("%d.%d.0", controlPlaneVersion[0], minor)
+	newest = fmt.Sprintf("%d.%d.%d", controlPlaneVersion[0], controlPlaneVersion[1], controlPlaneVersion[2])
+
+	return oldest, newest, true
+}
+
+// ValidateNodePoolVersion validates that a version of a node pool is within the allowed skew of the
+// version of the control plane of a hosted control plane cluster.  The error reports the range of
+// versions which are allowed.  Versions which cannot be parsed are not validated, as they are rejected
+// by OpenShift Cluster Manager.
+func ValidateNodePoolVersion(version, controlPlane string) error {
+	oldest, newest, ok := NodePoolVersionRange(controlPlane)
+	if !ok {
+		return nil
+	}
+
+	parsed, ok := parseVersion(version)
+	if !ok {
+		return nil
+	}
+
+	// the range was parsed from valid versions, so it may always be parsed again
+	parsedOldest, _ := parseVersion(oldest)
+	parsedNewest, _ := parseVersion(newest)
+
+	if compareVersions(parsed, parsedOldest) < 0 || compareVersions(parsed, parsedNewest) > 0 {
+		return fmt.Errorf(
+			"version [%s] must be between [%s] and [%s] for control plane version [%s] - %w",
+			version,
+			oldest,
+			newest,
+			controlPlane,
+			ErrNodePoolVersionSkew,
+		)
+	}
+
+	return nil
+}
+
+// LatestUpgrades returns the latest of the available upgrades which remains within the minor version
+// of the current version (z-stream), and the latest of the available upgrades to a newer minor version.
+// An empty string is returned when no such upgrade is available.  Versions which cannot be parsed are
+// ignored.
+func LatestUpgrades(current string, upgrades []string) (zStream, minor string) {
+	currentVersion, ok := parseVersion(current)
+	if !ok {
+		return "", ""
+	}
+
+	var latestZStream, latestMinor []int
+
+	for _, upgrade := range upgrades {
+		upgradeVersion, ok := parseVersion(upgrade)
+		if !ok || compareVersions(upgradeVersion, currentVersion) <= 0 {
+			continue
+		}
+
+		if upgradeVersion[0] == currentVersion[0] && upgradeVersion[1] == currentVersion[1] {
+			if latestZStream == nil || compareVersions(upgradeVersion, latestZStream) > 0 {
+				latestZStream, zStream = upgradeVersion, upgrade
+			}
+
+			continue
+		}
+
+		if latestMinor == nil || compareVersions(upgradeVersion, latestMinor) > 0 {
+			latestMinor, minor = upgradeVersion, upgrade
+		}
+	}
+
+	return zStream, minor
+}
+
+// parseVersion parses the major, minor and patch segments of a version (e.g. 4.12.3).  Any
+// pre-release or build metadata is ignored.
+func parseVersion(version string) ([]int, bool) {
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	segments := strings.Split(version, ".")
+	if len(segments) != versionSegments {
+		return nil, false
+	}
+
+	parsed := make([]int, versionSegments)
+
+	for i := range segments {
+		value, err := strconv.Atoi(segments[i])
+		if err != nil {
+			return nil, false
+		}
+
+		parsed[i] = value
+	}
+
+	return parsed, true
+}
+
+// compareVersions compares two parsed versions, returning a negative number if a is less than b,
+// zero if they are equal and a positive number if a is greater than b.
+func compareVersions(a, b []int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+
+	return 0
+}
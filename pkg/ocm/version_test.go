@@ -0,0 +1,272 @@
+package ocm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLatestUpgrades(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		current     string
+		upgrades    []string
+		wantZStream string
+		wantMinor   string
+	}{
+		{
+			name:        "ensure no upgrades are returned when none are available",
+			current:     "4.12.3",
+			upgrades:    nil,
+			wantZStream: "",
+			wantMinor:   "",
+		},
+		{
+			name:        "ensure the latest z-stream and minor upgrades are returned",
+			current:     "4.12.3",
+			upgrades:    []string{"4.12.10", "4.13.1", "4.12.4", "4.13.0"},
+			wantZStream: "4.12.10",
+			wantMinor:   "4.13.1",
+		},
+		{
+			name:        "ensure only minor upgrades are returned when no z-stream upgrades are available",
+			current:     "4.12.3",
+			upgrades:    []string{"4.13.1"},
+			wantZStream: "",
+			wantMinor:   "4.13.1",
+		},
+		{
+			name:        "ensure older and unparseable versions are ignored",
+			current:     "4.12.3",
+			upgrades:    []string{"4.12.2", "4.12.3", "latest", "4.12.5-rc.1"},
+			wantZStream: "4.12.5-rc.1",
+			wantMinor:   "",
+		},
+		{
+			name:        "ensure no upgrades are returned for an unparseable current version",
+			current:     "unknown",
+			upgrades:    []string{"4.12.4"},
+			wantZStream: "",
+			wantMinor:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			zStream, minor := LatestUpgrades(tt.current, tt.upgrades)
+			if zStream != tt.wantZStream {
+				t.Errorf("LatestUpgrades() zStream = %v, want %v", zStream, tt.wantZStream)
+			}
+
+			if minor != tt.wantMinor {
+				t.Errorf("LatestUpgrades() minor = %v, want %v", minor, tt.wantMinor)
+			}
+		})
+	}
+}
+
+func TestIsZStreamUpgrade(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current string
+		upgrade string
+		want    bool
+	}{
+		{
+			name:    "ensure a newer patch version is a z-stream upgrade",
+			current: "4.12.3",
+			upgrade: "4.12.10",
+			want:    true,
+		},
+		{
+			name:    "ensure a newer minor version is not a z-stream upgrade",
+			current: "4.12.3",
+			upgrade: "4.13.0",
+			want:    false,
+		},
+		{
+			name:    "ensure the same version is not a z-stream upgrade",
+			current: "4.12.3",
+			upgrade: "4.12.3",
+			want:    false,
+		},
+		{
+			name:    "ensure an older version is not a z-stream upgrade",
+			current: "4.12.3",
+			upgrade: "4.12.2",
+			want:    false,
+		},
+		{
+			name:    "ensure an unparseable version is not a z-stream upgrade",
+			current: "4.12.3",
+			upgrade: "latest",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsZStreamUpgrade(tt.current, tt.upgrade); got != tt.want {
+				t.Errorf("IsZStreamUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNewerVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+		than    string
+		want    bool
+	}{
+		{
+			name:    "ensure a newer minor version is newer",
+			version: "4.13.0",
+			than:    "4.12.10",
+			want:    true,
+		},
+		{
+			name:    "ensure the same version is not newer",
+			version: "4.12.3",
+			than:    "4.12.3",
+			want:    false,
+		},
+		{
+			name:    "ensure an older version is not newer",
+			version: "4.12.2",
+			than:    "4.12.3",
+			want:    false,
+		},
+		{
+			name:    "ensure an unparseable version is not newer",
+			version: "latest",
+			than:    "4.12.3",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsNewerVersion(tt.version, tt.than); got != tt.want {
+				t.Errorf("IsNewerVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodePoolVersionRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		controlPlane string
+		wantOldest   string
+		wantNewest   string
+		wantOK       bool
+	}{
+		{
+			name:         "ensure the range spans the allowed minor version skew",
+			controlPlane: "4.14.6",
+			wantOldest:   "4.12.0",
+			wantNewest:   "4.14.6",
+			wantOK:       true,
+		},
+		{
+			name:         "ensure the oldest minor version is not negative",
+			controlPlane: "5.1.2",
+			wantOldest:   "5.0.0",
+			wantNewest:   "5.1.2",
+			wantOK:       true,
+		},
+		{
+			name:         "ensure an unparseable control plane version has no range",
+			controlPlane: "latest",
+			wantOldest:   "",
+			wantNewest:   "",
+			wantOK:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			oldest, newest, ok := NodePoolVersionRange(tt.controlPlane)
+			if oldest != tt.wantOldest || newest != tt.wantNewest || ok != tt.wantOK {
+				t.Errorf(
+					"NodePoolVersionRange() = (%v, %v, %v), want (%v, %v, %v)",
+					oldest, newest, ok, tt.wantOldest, tt.wantNewest, tt.wantOK,
+				)
+			}
+		})
+	}
+}
+
+func TestValidateNodePoolVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		version      string
+		controlPlane string
+		wantErr      bool
+	}{
+		{
+			name:         "ensure the control plane version is allowed",
+			version:      "4.14.6",
+			controlPlane: "4.14.6",
+			wantErr:      false,
+		},
+		{
+			name:         "ensure the oldest minor version within the skew is allowed",
+			version:      "4.12.0",
+			controlPlane: "4.14.6",
+			wantErr:      false,
+		},
+		{
+			name:         "ensure a version newer than the control plane is rejected",
+			version:      "4.14.7",
+			controlPlane: "4.14.6",
+			wantErr:      true,
+		},
+		{
+			name:         "ensure a version beyond the minor version skew is rejected",
+			version:      "4.11.40",
+			controlPlane: "4.14.6",
+			wantErr:      true,
+		},
+		{
+			name:         "ensure an unparseable version is not validated",
+			version:      "latest",
+			controlPlane: "4.14.6",
+			wantErr:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateNodePoolVersion(tt.version, tt.controlPlane)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNodePoolVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrNodePoolVersionSkew) {
+				t.Errorf("ValidateNodePoolVersion() error = %v, want %v", err, ErrNodePoolVersionSkew)
+			}
+		})
+	}
+}
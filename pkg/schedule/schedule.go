@@ -0,0 +1,90 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// maximumWindowIterations limits the number of overlapping schedule windows that are
+	// walked when calculating the end of an active schedule.  this protects against
+	// schedules which fire more often than their duration and would otherwise never end.
+	maximumWindowIterations = 1000
+)
+
+var (
+	ErrScheduleInvalid = errors.New("invalid schedule")
+)
+
+// Schedule represents a recurring window of time.  The window begins each time the cron
+// expression fires and remains open for the specified duration.
+type Schedule struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// Parse parses a standard cron expression, optionally evaluated within a specific time zone, into
+// a schedule which remains active for the duration after each time the expression fires.
+func Parse(expression, timeZone string, duration time.Duration) (*Schedule, error) {
+	if duration <= 0 {
+		return &Schedule{}, fmt.Errorf("duration [%s] must be greater than zero - %w", duration, ErrScheduleInvalid)
+	}
+
+	if timeZone != "" {
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			return &Schedule{}, fmt.Errorf("unable to load time zone [%s] - %w", timeZone, ErrScheduleInvalid)
+		}
+
+		expression = fmt.Sprintf("CRON_TZ=%s %s", timeZone, expression)
+	}
+
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return &Schedule{}, fmt.Errorf("unable to parse cron expression [%s] - %w", expression, ErrScheduleInvalid)
+	}
+
+	return &Schedule{
+		schedule: schedule,
+		duration: duration,
+	}, nil
+}
+
+// Active determines if the schedule is active at a given point in time.
+func (s *Schedule) Active(at time.Time) bool {
+	return !s.start(at).After(at)
+}
+
+// NextTransition returns the next point in time after the given point in time in which
+// the schedule changes from active to inactive, or vice versa.
+func (s *Schedule) NextTransition(at time.Time) time.Time {
+	// if we are not active, the next transition is the next time the schedule fires
+	start := s.start(at)
+	if start.After(at) {
+		return start
+	}
+
+	// if we are active, walk each overlapping window until we find the end of the
+	// last window
+	end := start.Add(s.duration)
+
+	for i := 0; i < maximumWindowIterations; i++ {
+		next := s.schedule.Next(start)
+		if next.After(end) {
+			break
+		}
+
+		start, end = next, next.Add(s.duration)
+	}
+
+	return end
+}
+
+// start returns the first time that the schedule fires for a window which includes the
+// given point in time.  if no window includes the given point in time, the next time
+// the schedule fires is returned.
+func (s *Schedule) start(at time.Time) time.Time {
+	return s.schedule.Next(at.Add(-s.duration))
+}
@@ -0,0 +1,184 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func testTime(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("unable to parse time [%s] - %s", value, err)
+	}
+
+	return parsed
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		expression string
+		timeZone   string
+		duration   time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "ensure valid expression parses",
+			args: args{
+				expression: "0 20 * * 1-5",
+				duration:   12 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure valid expression with time zone parses",
+			args: args{
+				expression: "0 20 * * 1-5",
+				timeZone:   "America/New_York",
+				duration:   12 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure invalid expression returns an error",
+			args: args{
+				expression: "0 20 * *",
+				duration:   12 * time.Hour,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure invalid time zone returns an error",
+			args: args{
+				expression: "0 20 * * 1-5",
+				timeZone:   "Invalid/Zone",
+				duration:   12 * time.Hour,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure missing duration returns an error",
+			args: args{
+				expression: "0 20 * * 1-5",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := Parse(tt.args.expression, tt.args.timeZone, tt.args.duration); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule_Active(t *testing.T) {
+	t.Parallel()
+
+	// nightly schedule which begins at 20:00 and lasts for 12 hours
+	nightly, err := Parse("0 20 * * *", "", 12*time.Hour)
+	if err != nil {
+		t.Fatalf("unable to parse schedule - %s", err)
+	}
+
+	tests := []struct {
+		name string
+		at   string
+		want bool
+	}{
+		{
+			name: "ensure schedule is inactive before it fires",
+			at:   "2023-05-01T19:59:00Z",
+			want: false,
+		},
+		{
+			name: "ensure schedule is active when it fires",
+			at:   "2023-05-01T20:00:00Z",
+			want: true,
+		},
+		{
+			name: "ensure schedule is active within its duration",
+			at:   "2023-05-02T07:59:00Z",
+			want: true,
+		},
+		{
+			name: "ensure schedule is inactive after its duration",
+			at:   "2023-05-02T08:00:00Z",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := nightly.Active(testTime(t, tt.at)); got != tt.want {
+				t.Errorf("Schedule.Active() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextTransition(t *testing.T) {
+	t.Parallel()
+
+	// nightly schedule which begins at 20:00 and lasts for 12 hours
+	nightly, err := Parse("0 20 * * *", "", 12*time.Hour)
+	if err != nil {
+		t.Fatalf("unable to parse schedule - %s", err)
+	}
+
+	// hourly schedule which lasts for 2 hours and therefore never ends
+	overlapping, err := Parse("0 * * * *", "", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("unable to parse schedule - %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule *Schedule
+		at       string
+		want     string
+	}{
+		{
+			name:     "ensure inactive schedule transitions when it next fires",
+			schedule: nightly,
+			at:       "2023-05-01T12:00:00Z",
+			want:     "2023-05-01T20:00:00Z",
+		},
+		{
+			name:     "ensure active schedule transitions at the end of its duration",
+			schedule: nightly,
+			at:       "2023-05-01T23:00:00Z",
+			want:     "2023-05-02T08:00:00Z",
+		},
+		{
+			name:     "ensure overlapping schedule transitions after the maximum window iterations",
+			schedule: overlapping,
+			at:       "2023-05-01T00:30:00Z",
+			want:     testTime(t, "2023-05-01T01:00:00Z").Add(maximumWindowIterations * time.Hour).Format(time.RFC3339),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.schedule.NextTransition(testTime(t, tt.at)); !got.Equal(testTime(t, tt.want)) {
+				t.Errorf("Schedule.NextTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const (
+	// EnvTokenFile is the environment variable which holds the path to the token file used to connect
+	// to OpenShift Cluster Manager.
+	EnvTokenFile = "OCM_E2E_TOKEN_FILE"
+
+	// EnvClusterID is the environment variable which holds the id of the cluster, in OpenShift Cluster
+	// Manager, against which objects are created.
+	EnvClusterID = "OCM_E2E_CLUSTER_ID"
+
+	// EnvEnvironment is the environment variable which holds the environment of OpenShift Cluster
+	// Manager to connect to.  The stage environment is used when it is unset.
+	EnvEnvironment = "OCM_E2E_ENVIRONMENT"
+
+	// DefaultPollInterval is the default interval at which a condition is polled.
+	DefaultPollInterval = 15 * time.Second
+
+	// DefaultPollTimeout is the default amount of time a condition is polled for before giving up.
+	DefaultPollTimeout = 20 * time.Minute
+
+	prefixLength = 5
+)
+
+var (
+	ErrHarnessNotConfigured     = errors.New("e2e harness is not configured")
+	ErrHarnessProduction        = errors.New("e2e harness may not be run against the production environment")
+	ErrHarnessConditionTimeout  = errors.New("timed out waiting for condition")
+	ErrHarnessClusterNotFound   = errors.New("e2e cluster not found")
+	ErrHarnessClusterNotReady   = errors.New("e2e cluster is not ready")
+	ErrHarnessCleanupIncomplete = errors.New("e2e cleanup did not complete")
+)
+
+// Harness is a connection to a non-production environment of OpenShift Cluster Manager, along with
+// the test cluster which objects are created against, used to exercise the lifecycle of objects
+// against a real OpenShift Cluster Manager.  Each object created through the harness should register
+// a cleanup so that the environment is left as it was found.
+type Harness struct {
+	Connection  *sdk.Connection
+	Cluster     *clustersmgmtv1.Cluster
+	Environment string
+
+	// Prefix is the unique prefix of the names of the objects created by the harness, so that
+	// concurrent runs do not collide and leaked objects are easy to identify.
+	Prefix string
+
+	mutex    sync.Mutex
+	cleanups []cleanup
+}
+
+type cleanup struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Configured determines if the environment variables required by the harness are set.
+func Configured() bool {
+	return os.Getenv(EnvTokenFile) != "" && os.Getenv(EnvClusterID) != ""
+}
+
+// NewHarness returns a harness from the environment variables, connected to the environment of
+// OpenShift Cluster Manager which they request, with the test cluster retrieved.
+func NewHarness(ctx context.Context) (*Harness, error) {
+	if !Configured() {
+		return nil, fmt.Errorf("environment variables [%s] and [%s] must be set - %w", EnvTokenFile, EnvClusterID, ErrHarnessNotConfigured)
+	}
+
+	environment := os.Getenv(EnvEnvironment)
+	if environment == "" {
+		environment = ocm.EnvironmentStage
+	}
+
+	if environment == ocm.EnvironmentProduction {
+		return nil, ErrHarnessProduction
+	}
+
+	url, err := ocm.EnvironmentURL(environment)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine url of environment - %w", err)
+	}
+
+	token, err := ocm.NewToken(os.Getenv(EnvTokenFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load token - %w", err)
+	}
+
+	connection, err := sdk.NewConnectionBuilder().Tokens(token.RefreshToken).URL(url).BuildContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build ocm connection - %w", err)
+	}
+
+	harness := &Harness{
+		Connection:  connection,
+		Environment: environment,
+		Prefix:      "e2e-" + utilrand.String(prefixLength),
+	}
+
+	if err := harness.RefreshCluster(ctx); err != nil {
+		connection.Close()
+
+		return nil, err
+	}
+
+	if harness.Cluster.State() != clustersmgmtv1.ClusterStateReady {
+		connection.Close()
+
+		return nil, fmt.Errorf("cluster [%s] in state [%s] - %w", harness.Cluster.ID(), harness.Cluster.State(), ErrHarnessClusterNotReady)
+	}
+
+	return harness, nil
+}
+
+// RefreshCluster retrieves the current state of the test cluster.
+func (harness *Harness) RefreshCluster(ctx context.Context) error {
+	id := os.Getenv(EnvClusterID)
+
+	response, err := harness.Connection.ClustersMgmt().V1().Clusters().Cluster(id).Get().SendContext(ctx)
+	if err != nil {
+		if response.Status() == http.StatusNotFound {
+			return fmt.Errorf("cluster [%s] in environment [%s] - %w", id, harness.Environment, ErrHarnessClusterNotFound)
+		}
+
+		return fmt.Errorf("unable to retrieve cluster [%s] - %w", id, err)
+	}
+
+	harness.Cluster = response.Body()
+
+	return nil
+}
+
+// Hosted determines if the test cluster uses a hosted control plane.
+func (harness *Harness) Hosted() bool {
+	return harness.Cluster.Hypershift().Enabled()
+}
+
+// Name returns a unique name for an object created by the harness.  The suffix should be short, as
+// some objects in OpenShift Cluster Manager have a maximum name length of 15 characters.
+func (harness *Harness) Name(suffix string) string {
+	return harness.Prefix + "-" + suffix
+}
+
+// Cleanup registers a function which deletes an object created by the harness.  Cleanups are run
+// in the reverse order in which they were registered when the harness is closed.
+func (harness *Harness) Cleanup(name string, run func(ctx context.Context) error) {
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	harness.cleanups = append(harness.cleanups, cleanup{name: name, run: run})
+}
+
+// Close runs each of the registered cleanups, even when a cleanup fails, and closes the connection
+// to OpenShift Cluster Manager.  An error is returned if any of the cleanups failed, naming them
+// so that the leaked objects may be deleted by hand.
+func (harness *Harness) Close(ctx context.Context) error {
+	harness.mutex.Lock()
+	cleanups := harness.cleanups
+	harness.cleanups = nil
+	harness.mutex.Unlock()
+
+	failed := []string{}
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := cleanups[i].run(ctx); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", cleanups[i].name, err))
+		}
+	}
+
+	if harness.Connection != nil {
+		if err := harness.Connection.Close(); err != nil {
+			failed = append(failed, fmt.Sprintf("connection: %s", err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed cleanups %v - %w", failed, ErrHarnessCleanupIncomplete)
+	}
+
+	return nil
+}
+
+// Eventually polls a condition at an interval until it is met, returns an error, or the timeout
+// expires.
+func Eventually(ctx context.Context, interval, timeout time.Duration, condition func(ctx context.Context) (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := condition(ctx)
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("condition not met after %s - %w", timeout, ErrHarnessConditionTimeout)
+		case <-ticker.C:
+		}
+	}
+}
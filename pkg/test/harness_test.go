@@ -0,0 +1,89 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var errCleanup = errors.New("cleanup failed")
+
+func TestHarness_Close(t *testing.T) {
+	t.Parallel()
+
+	harness := &Harness{Prefix: "e2e-abcde"}
+	got := []string{}
+
+	for _, name := range []string{"first", "failed", "last"} {
+		name := name
+		harness.Cleanup(name, func(ctx context.Context) error {
+			got = append(got, name)
+			if name == "failed" {
+				return errCleanup
+			}
+
+			return nil
+		})
+	}
+
+	if err := harness.Close(context.Background()); !errors.Is(err, ErrHarnessCleanupIncomplete) {
+		t.Errorf("Harness.Close() error = %v, wantErr %v", err, ErrHarnessCleanupIncomplete)
+	}
+
+	if want := []string{"last", "failed", "first"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Harness.Close() ran cleanups %v, want %v", got, want)
+	}
+
+	if err := harness.Close(context.Background()); err != nil {
+		t.Errorf("Harness.Close() error = %v, want cleanups to run once", err)
+	}
+
+	if got, want := harness.Name("mp"), "e2e-abcde-mp"; got != want {
+		t.Errorf("Harness.Name() = %v, want %v", got, want)
+	}
+}
+
+func TestEventually(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition func(calls int) (bool, error)
+		wantErr   error
+	}{
+		{
+			name:      "ensure a condition which is met stops polling",
+			condition: func(calls int) (bool, error) { return calls == 3, nil },
+		},
+		{
+			name:      "ensure a condition which fails stops polling",
+			condition: func(calls int) (bool, error) { return false, errCleanup },
+			wantErr:   errCleanup,
+		},
+		{
+			name:      "ensure a condition which is never met times out",
+			condition: func(calls int) (bool, error) { return false, nil },
+			wantErr:   ErrHarnessConditionTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+
+			err := Eventually(context.Background(), time.Millisecond, 100*time.Millisecond, func(ctx context.Context) (bool, error) {
+				calls++
+
+				return tt.condition(calls)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Eventually() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
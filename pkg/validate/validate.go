@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+const (
+	decoderBufferSize = 4096
+)
+
+var (
+	ErrUnknownKind = errors.New("unknown kind")
+)
+
+// Result represents the result of validating an individual object from a manifest.
+type Result struct {
+	Source string
+	Kind   string
+	Name   string
+	Err    error
+
+	// Warnings are the warnings of a valid object whose configuration is risky, as returned by the
+	// warning webhooks.
+	Warnings []string
+}
+
+// String returns the string representation of a result.
+func (result Result) String() string {
+	if result.Err != nil {
+		return fmt.Sprintf("%s: %s/%s: %s", result.Source, result.Kind, result.Name, result.Err)
+	}
+
+	if len(result.Warnings) > 0 {
+		return fmt.Sprintf("%s: %s/%s: valid with warnings: %s", result.Source, result.Kind, result.Name, strings.Join(result.Warnings, "; "))
+	}
+
+	return fmt.Sprintf("%s: %s/%s: valid", result.Source, result.Kind, result.Name)
+}
+
+// Path validates all of the YAML manifests at a path.  If the path is a directory, all of the
+// files with a .yaml or .yml extension beneath the directory are validated.
+func Path(scheme *runtime.Scheme, path string) ([]Result, error) {
+	results := []Result{}
+
+	err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		// only validate yaml files when walking a directory
+		if file != path && filepath.Ext(file) != ".yaml" && filepath.Ext(file) != ".yml" {
+			return nil
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read manifest [%s] - %w", file, err)
+		}
+
+		manifestResults, err := Manifest(scheme, file, content)
+		if err != nil {
+			return err
+		}
+
+		results = append(results, manifestResults...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate manifests at path [%s] - %w", path, err)
+	}
+
+	return results, nil
+}
+
+// Manifest validates each of the objects within a YAML manifest, which may contain multiple documents.
+// Objects which do not belong to the API group of this operator are not validated.  Each object is
+// decoded strictly, so that unknown fields are reported, and then validated with the same logic as
+// the admission webhooks, if an admission webhook exists for its kind.
+func Manifest(scheme *runtime.Scheme, source string, content []byte) ([]Result, error) {
+	results := []Result{}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), decoderBufferSize)
+
+	for {
+		document := &unstructured.Unstructured{}
+		if err := decoder.Decode(&document.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+
+			return nil, fmt.Errorf("unable to decode manifest [%s] - %w", source, err)
+		}
+
+		// skip empty documents and objects which are not managed by this operator
+		if len(document.Object) == 0 || document.GroupVersionKind().Group != ocmv1alpha1.GroupVersion.Group {
+			continue
+		}
+
+		results = append(results, Object(scheme, source, document))
+	}
+}
+
+// Object validates an individual object.
+func Object(scheme *runtime.Scheme, source string, document *unstructured.Unstructured) Result {
+	result := Result{
+		Source: source,
+		Kind:   document.GetKind(),
+		Name:   document.GetName(),
+	}
+
+	object, err := scheme.New(document.GroupVersionKind())
+	if err != nil {
+		result.Err = fmt.Errorf("%s - %w", document.GroupVersionKind(), ErrUnknownKind)
+
+		return result
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(document.Object, object, true); err != nil {
+		result.Err = fmt.Errorf("unable to decode object - %w", err)
+
+		return result
+	}
+
+	if validator, ok := object.(webhook.Validator); ok {
+		result.Err = validator.ValidateCreate()
+	}
+
+	if warner, ok := object.(ocmv1alpha1.Warner); ok && result.Err == nil {
+		result.Warnings = warner.Warnings()
+	}
+
+	return result
+}
@@ -0,0 +1,149 @@
+package validate
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := ocmv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v, wantErr %v", err, false)
+	}
+
+	return scheme
+}
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		wantResults int
+		wantInvalid int
+		wantWarned  int
+	}{
+		{
+			name: "ensure a valid object is valid",
+			content: `
+apiVersion: ocm.mobb.redhat.com/v1alpha1
+kind: LDAPIdentityProvider
+metadata:
+  name: ldap
+spec:
+  clusterName: test
+  url: ldap://ldap.example.com/ou=users,dc=example,dc=com?uid
+`,
+			wantResults: 1,
+			wantInvalid: 0,
+		},
+		{
+			name: "ensure an object which fails webhook validation is invalid",
+			content: `
+apiVersion: ocm.mobb.redhat.com/v1alpha1
+kind: LDAPIdentityProvider
+metadata:
+  name: ldap
+spec:
+  clusterName: test
+  url: https://ldap.example.com
+`,
+			wantResults: 1,
+			wantInvalid: 1,
+		},
+		{
+			name: "ensure an object with unknown fields is invalid",
+			content: `
+apiVersion: ocm.mobb.redhat.com/v1alpha1
+kind: MachinePool
+metadata:
+  name: pool
+spec:
+  clusterName: test
+  minimumNodes: 1
+`,
+			wantResults: 1,
+			wantInvalid: 1,
+		},
+		{
+			name: "ensure an unknown kind is invalid",
+			content: `
+apiVersion: ocm.mobb.redhat.com/v1alpha1
+kind: Unknown
+metadata:
+  name: unknown
+`,
+			wantResults: 1,
+			wantInvalid: 1,
+		},
+		{
+			name: "ensure objects from other api groups are not validated",
+			content: `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+---
+apiVersion: ocm.mobb.redhat.com/v1alpha1
+kind: MachinePool
+metadata:
+  name: pool
+spec:
+  clusterName: test
+  minimumNodesPerZone: 1
+`,
+			wantResults: 1,
+			wantInvalid: 0,
+		},
+		{
+			name: "ensure a valid but risky object is warned of",
+			content: `
+apiVersion: ocm.mobb.redhat.com/v1alpha1
+kind: MachinePool
+metadata:
+  name: pool
+spec:
+  clusterName: test
+  minimumNodesPerZone: 0
+`,
+			wantResults: 1,
+			wantInvalid: 0,
+			wantWarned:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			results, err := Manifest(testScheme(t), "test.yaml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Manifest() error = %v, wantErr %v", err, false)
+			}
+			if len(results) != tt.wantResults {
+				t.Fatalf("Manifest() results = %v, want %v", len(results), tt.wantResults)
+			}
+			var invalid, warned int
+			for _, result := range results {
+				if result.Err != nil {
+					invalid++
+				}
+				if len(result.Warnings) > 0 {
+					warned++
+				}
+			}
+			if invalid != tt.wantInvalid {
+				t.Errorf("Manifest() invalid = %v, want %v", invalid, tt.wantInvalid)
+			}
+			if warned != tt.wantWarned {
+				t.Errorf("Manifest() warned = %v, want %v", warned, tt.wantWarned)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rh-mobb/ocm-operator/pkg/test"
+)
+
+// harness is the connection to OpenShift Cluster Manager shared by each of the tests in the suite.
+var harness *test.Harness
+
+func TestMain(m *testing.M) {
+	if !test.Configured() {
+		fmt.Printf("skipping e2e tests: environment variables [%s] and [%s] are not set\n", test.EnvTokenFile, test.EnvClusterID)
+		os.Exit(0)
+	}
+
+	var err error
+
+	harness, err = test.NewHarness(context.Background())
+	if err != nil {
+		fmt.Printf("unable to create e2e harness: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf(
+		"running e2e tests against cluster [%s] in environment [%s] with prefix [%s]\n",
+		harness.Cluster.ID(),
+		harness.Environment,
+		harness.Prefix,
+	)
+
+	code := m.Run()
+
+	// cleanups run regardless of the result of the tests, so that a failed test does not leak objects
+	if err := harness.Close(context.Background()); err != nil {
+		fmt.Printf("unable to clean up e2e objects: %s\n", err)
+
+		code = 1
+	}
+
+	os.Exit(code)
+}
+
+// eventually fails the test if a condition is not met before the default timeout of the harness.
+func eventually(t *testing.T, condition func(ctx context.Context) (bool, error)) {
+	t.Helper()
+
+	if err := test.Eventually(context.Background(), test.DefaultPollInterval, test.DefaultPollTimeout, condition); err != nil {
+		t.Fatalf("eventually() error = %v", err)
+	}
+}
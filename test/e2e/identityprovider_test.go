@@ -0,0 +1,94 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+// testIdentityProvider exercises the lifecycle of an identity provider of the test cluster, which is
+// created and updated from the builders returned by build.
+func testIdentityProvider(t *testing.T, name string, build func(update bool) *clustersmgmtv1.IdentityProviderBuilder) {
+	t.Helper()
+
+	ctx := context.Background()
+	client := ocm.NewIdentityProviderClient(harness.Connection, name, harness.Cluster.ID()).WithContext(ctx)
+
+	created, err := client.Create(build(false))
+	if err != nil {
+		t.Fatalf("IdentityProviderClient.Create() error = %v", err)
+	}
+
+	harness.Cleanup("identity provider "+name, func(ctx context.Context) error {
+		return ocm.NewIdentityProviderClient(harness.Connection, name, harness.Cluster.ID()).WithContext(ctx).Delete(created.ID())
+	})
+
+	if _, err := client.Update(build(true).ID(created.ID())); err != nil {
+		t.Fatalf("IdentityProviderClient.Update() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).GetByID(created.ID())
+		if err != nil || current == nil {
+			return false, err
+		}
+
+		return current.MappingMethod() == clustersmgmtv1.IdentityProviderMappingMethodAdd, nil
+	})
+
+	if err := client.Delete(created.ID()); err != nil {
+		t.Fatalf("IdentityProviderClient.Delete() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).GetByID(created.ID())
+
+		return current == nil, err
+	})
+}
+
+func TestLDAPIdentityProvider(t *testing.T) {
+	t.Parallel()
+
+	ldap := &ocmv1alpha1.LDAPIdentityProvider{}
+	ldap.Spec.DisplayName = harness.Name("ldap")
+	ldap.Spec.URL = "ldap://ldap.e2e.example.com/ou=users,dc=example,dc=com?uid"
+	ldap.Spec.BindDN = "cn=e2e,dc=example,dc=com"
+	ldap.Spec.Insecure = true
+
+	testIdentityProvider(t, ldap.Spec.DisplayName, func(update bool) *clustersmgmtv1.IdentityProviderBuilder {
+		ldap.Spec.MappingMethod = string(clustersmgmtv1.IdentityProviderMappingMethodClaim)
+		if update {
+			ldap.Spec.MappingMethod = string(clustersmgmtv1.IdentityProviderMappingMethodAdd)
+		}
+
+		return ldap.Builder("", "e2e-bind-password")
+	})
+}
+
+func TestGitLabIdentityProvider(t *testing.T) {
+	t.Parallel()
+
+	gitlab := &ocmv1alpha1.GitLabIdentityProvider{}
+	gitlab.Spec.DisplayName = harness.Name("glab")
+	gitlab.Spec.URL = "https://gitlab.e2e.example.com"
+
+	testIdentityProvider(t, gitlab.Spec.DisplayName, func(update bool) *clustersmgmtv1.IdentityProviderBuilder {
+		gitlab.Spec.MappingMethod = string(clustersmgmtv1.IdentityProviderMappingMethodClaim)
+		if update {
+			gitlab.Spec.MappingMethod = string(clustersmgmtv1.IdentityProviderMappingMethodAdd)
+		}
+
+		return clustersmgmtv1.NewIdentityProvider().
+			Name(gitlab.Spec.DisplayName).
+			Type(clustersmgmtv1.IdentityProviderTypeGitlab).
+			MappingMethod(clustersmgmtv1.IdentityProviderMappingMethod(gitlab.GetMappingMethod())).
+			Gitlab(gitlab.Builder("", "e2e-client-secret").ClientID("e2e-client-id"))
+	})
+}
@@ -0,0 +1,118 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+const e2eInstanceType = "m5.xlarge"
+
+func newE2EMachinePool(labels map[string]string) *ocmv1alpha1.MachinePool {
+	pool := &ocmv1alpha1.MachinePool{}
+	pool.Spec.DisplayName = harness.Name("mp")
+	pool.Spec.InstanceType = e2eInstanceType
+	pool.Spec.Labels = labels
+
+	// the replicas of a machine pool of a multi-zone cluster must be a multiple of the zones
+	pool.Spec.MinimumNodesPerZone = 1
+	if zones := len(harness.Cluster.Nodes().AvailabilityZones()); zones > 0 && !harness.Hosted() {
+		pool.Spec.MinimumNodesPerZone = zones
+	}
+
+	return pool
+}
+
+func TestMachinePool(t *testing.T) {
+	t.Parallel()
+
+	if harness.Hosted() {
+		testNodePool(t)
+
+		return
+	}
+
+	ctx := context.Background()
+	pool := newE2EMachinePool(map[string]string{"e2e": "create"})
+	client := ocm.NewMachinePoolClient(harness.Connection, pool.Spec.DisplayName, harness.Cluster.ID()).WithContext(ctx)
+
+	created, err := client.Create(pool.MachinePoolBuilder())
+	if err != nil {
+		t.Fatalf("MachinePoolClient.Create() error = %v", err)
+	}
+
+	harness.Cleanup("machine pool "+created.ID(), func(ctx context.Context) error {
+		return ocm.NewMachinePoolClient(harness.Connection, created.ID(), harness.Cluster.ID()).WithContext(ctx).Delete(created.ID())
+	})
+
+	pool.Spec.Labels["e2e"] = "update"
+
+	if _, err := client.Update(pool.MachinePoolBuilder()); err != nil {
+		t.Fatalf("MachinePoolClient.Update() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).Get()
+		if err != nil || current == nil {
+			return false, err
+		}
+
+		return current.Labels()["e2e"] == "update", nil
+	})
+
+	if err := client.Delete(created.ID()); err != nil {
+		t.Fatalf("MachinePoolClient.Delete() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).Get()
+
+		return current == nil, err
+	})
+}
+
+func testNodePool(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+	pool := newE2EMachinePool(map[string]string{"e2e": "create"})
+	client := ocm.NewNodePoolClient(harness.Connection, pool.Spec.DisplayName, harness.Cluster.ID()).WithContext(ctx)
+
+	created, err := client.Create(pool.NodePoolBuilder())
+	if err != nil {
+		t.Fatalf("NodePoolClient.Create() error = %v", err)
+	}
+
+	harness.Cleanup("node pool "+created.ID(), func(ctx context.Context) error {
+		return ocm.NewNodePoolClient(harness.Connection, created.ID(), harness.Cluster.ID()).WithContext(ctx).Delete(created.ID())
+	})
+
+	pool.Spec.Labels["e2e"] = "update"
+
+	if _, err := client.Update(pool.NodePoolBuilder()); err != nil {
+		t.Fatalf("NodePoolClient.Update() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).Get()
+		if err != nil || current == nil {
+			return false, err
+		}
+
+		return current.Labels()["e2e"] == "update", nil
+	})
+
+	if err := client.Delete(created.ID()); err != nil {
+		t.Fatalf("NodePoolClient.Delete() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).Get()
+
+		return current == nil, err
+	})
+}
@@ -0,0 +1,188 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	ocmv1alpha1 "github.com/rh-mobb/ocm-operator/api/v1alpha1"
+	"github.com/rh-mobb/ocm-operator/pkg/ocm"
+)
+
+func TestClusterLabels(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := harness.Name("label")
+	client := ocm.NewSubscriptionLabelClient(harness.Connection, harness.Cluster.Subscription().ID()).WithContext(ctx)
+
+	if _, err := client.Create(key, "create"); err != nil {
+		t.Fatalf("SubscriptionLabelClient.Create() error = %v", err)
+	}
+
+	harness.Cleanup("subscription label "+key, func(ctx context.Context) error {
+		return ocm.NewSubscriptionLabelClient(harness.Connection, harness.Cluster.Subscription().ID()).WithContext(ctx).Delete(key)
+	})
+
+	if _, err := client.Update(key, "update"); err != nil {
+		t.Fatalf("SubscriptionLabelClient.Update() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		value, found, err := findLabel(ctx, key)
+
+		return found && value == "update", err
+	})
+
+	if err := client.Delete(key); err != nil {
+		t.Fatalf("SubscriptionLabelClient.Delete() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		_, found, err := findLabel(ctx, key)
+
+		return !found, err
+	})
+}
+
+func findLabel(ctx context.Context, key string) (value string, found bool, err error) {
+	labels, err := ocm.NewSubscriptionLabelClient(harness.Connection, harness.Cluster.Subscription().ID()).WithContext(ctx).List()
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, label := range labels {
+		if label.Key() == key {
+			return label.Value(), true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func TestClusterNotification(t *testing.T) {
+	t.Parallel()
+
+	// support cases may not be deleted once they are opened, so only the notification contacts of the
+	// cluster are exercised
+	ctx := context.Background()
+
+	account, err := ocm.GetAccount(ctx, harness.Connection)
+	if err != nil {
+		t.Fatalf("GetAccount() error = %v", err)
+	}
+
+	client := ocm.NewNotificationContactClient(harness.Connection, harness.Cluster.Subscription().ID()).WithContext(ctx)
+
+	contacts, err := client.List()
+	if err != nil {
+		t.Fatalf("NotificationContactClient.List() error = %v", err)
+	}
+
+	for _, contact := range contacts {
+		if contact.Username() == account.Username {
+			t.Skipf("account [%s] is already a notification contact of the cluster", account.Username)
+		}
+	}
+
+	created, err := client.Create(account.Username)
+	if err != nil {
+		t.Fatalf("NotificationContactClient.Create() error = %v", err)
+	}
+
+	harness.Cleanup("notification contact "+account.Username, func(ctx context.Context) error {
+		return ocm.NewNotificationContactClient(harness.Connection, harness.Cluster.Subscription().ID()).WithContext(ctx).Delete(created.ID())
+	})
+
+	if err := client.Delete(created.ID()); err != nil {
+		t.Fatalf("NotificationContactClient.Delete() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		contacts, err := client.WithContext(ctx).List()
+		if err != nil {
+			return false, err
+		}
+
+		for _, contact := range contacts {
+			if contact.ID() == created.ID() {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
+
+func TestClusterRegistration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	registration := &ocmv1alpha1.ClusterRegistration{}
+	registration.Spec.ClusterUUID = string(uuid.NewUUID())
+	registration.Spec.DisplayName = harness.Name("registration")
+	registration.Spec.ConsoleURL = "https://console.e2e.example.com"
+
+	client := ocm.NewSubscriptionClient(harness.Connection).WithContext(ctx)
+
+	subscription, err := client.Register(registration.RegistrationBuilder())
+	if err != nil {
+		t.Fatalf("SubscriptionClient.Register() error = %v", err)
+	}
+
+	harness.Cleanup("subscription "+subscription.ID(), func(ctx context.Context) error {
+		cleanup := ocm.NewSubscriptionClient(harness.Connection).WithContext(ctx)
+
+		current, err := cleanup.Get(subscription.ID())
+		if err != nil || current == nil || current.Status() == ocm.SubscriptionStatusArchived {
+			return err
+		}
+
+		return cleanup.Archive(subscription.ID())
+	})
+
+	registration.Spec.DisplayName = harness.Name("registration-updated")
+
+	if _, err := client.Update(subscription.ID(), registration.SubscriptionBuilder()); err != nil {
+		t.Fatalf("SubscriptionClient.Update() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).Get(subscription.ID())
+		if err != nil || current == nil {
+			return false, err
+		}
+
+		return registration.SubscriptionDesired(current), nil
+	})
+
+	if err := client.Archive(subscription.ID()); err != nil {
+		t.Fatalf("SubscriptionClient.Archive() error = %v", err)
+	}
+
+	eventually(t, func(ctx context.Context) (bool, error) {
+		current, err := client.WithContext(ctx).Find(registration.Spec.ClusterUUID)
+
+		return current == nil, err
+	})
+}
+
+func TestClusterVersionCheck(t *testing.T) {
+	t.Parallel()
+
+	// a version check does not create any objects in ocm, so only the version of the cluster is read
+	version, err := ocm.NewVersionClient(harness.Connection).
+		WithContext(context.Background()).
+		Get(harness.Cluster.Version().ID())
+	if err != nil {
+		t.Fatalf("VersionClient.Get() error = %v", err)
+	}
+
+	if version == nil || ocm.RawVersion(version) == "" {
+		t.Fatalf("VersionClient.Get() = %v, want version of cluster [%s]", version, harness.Cluster.ID())
+	}
+}
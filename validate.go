@@ -0,0 +1,73 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rh-mobb/ocm-operator/pkg/validate"
+)
+
+const (
+	validateCommand = "validate"
+)
+
+// runValidate runs the validate subcommand, which validates custom resource manifests with the
+// same logic as the admission webhooks without requiring a cluster.  It returns the exit code of
+// the subcommand, which is non-zero if any object is invalid.
+func runValidate(args []string) int {
+	flags := flag.NewFlagSet(validateCommand, flag.ExitOnError)
+
+	path := flags.String("f", "", "The manifest file, or directory of manifest files, to validate.")
+
+	//nolint:errcheck
+	flags.Parse(args)
+
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "missing required flag: -f")
+		flags.Usage()
+
+		return 1
+	}
+
+	results, err := validate.Path(scheme, *path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	var invalid int
+
+	for _, result := range results {
+		if result.Err != nil {
+			invalid++
+		}
+
+		fmt.Fprintln(os.Stdout, result)
+	}
+
+	if invalid > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d objects are invalid\n", invalid, len(results))
+
+		return 1
+	}
+
+	return 0
+}